@@ -23,13 +23,30 @@ import (
 	adAnalysis "github.com/specterops/bloodhound/analysis/ad"
 	"github.com/specterops/bloodhound/dawgs/graph"
 	"github.com/specterops/bloodhound/graphschema/ad"
+	"github.com/specterops/bloodhound/src/model/appcfg"
 )
 
+// PostProcessorName is the name the Active Directory post-processor is registered under. Post-processors that depend
+// on Active Directory post-processed relationships should list it in their dependencies.
+const PostProcessorName = "ad"
+
+func init() {
+	analysis.RegisterPostProcessor(analysis.PostProcessor{
+		Name:               PostProcessorName,
+		EntityKind:         ad.Entity,
+		PostProcessedKinds: adAnalysis.PostProcessedRelationships(),
+		Delegate: func(ctx context.Context, db graph.Database, flags analysis.FeatureFlags) (*analysis.AtomicPostProcessingStats, error) {
+			// TODO: Cleanup #ADCSFeatureFlag after full launch.
+			return Post(ctx, db, flags.Enabled(appcfg.FeatureAdcs))
+		},
+	})
+}
+
+// Post creates all Active Directory post-processed relationships. Previously post-processed relationships are expected
+// to have been deleted by the post-processor registry before this is called.
 func Post(ctx context.Context, db graph.Database, adcsEnabled bool) (*analysis.AtomicPostProcessingStats, error) {
 	aggregateStats := analysis.NewAtomicPostProcessingStats()
-	if stats, err := analysis.DeleteTransitEdges(ctx, db, ad.Entity, ad.Entity, adAnalysis.PostProcessedRelationships()...); err != nil {
-		return &aggregateStats, err
-	} else if dcSyncStats, err := adAnalysis.PostDCSync(ctx, db); err != nil {
+	if dcSyncStats, err := adAnalysis.PostDCSync(ctx, db); err != nil {
 		return &aggregateStats, err
 	} else if syncLAPSStats, err := adAnalysis.PostSyncLAPSPassword(ctx, db); err != nil {
 		return &aggregateStats, err
@@ -40,7 +57,6 @@ func Post(ctx context.Context, db graph.Database, adcsEnabled bool) (*analysis.A
 	} else if adcsStats, err := adAnalysis.PostADCS(ctx, db, groupExpansions, adcsEnabled); err != nil {
 		return &aggregateStats, err
 	} else {
-		aggregateStats.Merge(stats)
 		aggregateStats.Merge(syncLAPSStats)
 		aggregateStats.Merge(dcSyncStats)
 		aggregateStats.Merge(localGroupStats)
@@ -25,11 +25,26 @@ import (
 	"github.com/specterops/bloodhound/graphschema/azure"
 )
 
+// PostProcessorName is the name the Azure post-processor is registered under. Post-processors that depend on Azure
+// post-processed relationships should list it in their dependencies.
+const PostProcessorName = "azure"
+
+func init() {
+	analysis.RegisterPostProcessor(analysis.PostProcessor{
+		Name:               PostProcessorName,
+		EntityKind:         azure.Entity,
+		PostProcessedKinds: azureAnalysis.AzurePostProcessedRelationships(),
+		Delegate: func(ctx context.Context, db graph.Database, _ analysis.FeatureFlags) (*analysis.AtomicPostProcessingStats, error) {
+			return Post(ctx, db)
+		},
+	})
+}
+
+// Post creates all Azure post-processed relationships. Previously post-processed relationships are expected to have
+// been deleted by the post-processor registry before this is called.
 func Post(ctx context.Context, db graph.Database) (*analysis.AtomicPostProcessingStats, error) {
 	aggregateStats := analysis.NewAtomicPostProcessingStats()
-	if stats, err := analysis.DeleteTransitEdges(ctx, db, azure.Entity, azure.Entity, azureAnalysis.AzurePostProcessedRelationships()...); err != nil {
-		return &aggregateStats, err
-	} else if userRoleStats, err := azureAnalysis.UserRoleAssignments(ctx, db); err != nil {
+	if userRoleStats, err := azureAnalysis.UserRoleAssignments(ctx, db); err != nil {
 		return &aggregateStats, err
 	} else if addSecretStats, err := azureAnalysis.AddSecret(ctx, db); err != nil {
 		return &aggregateStats, err
@@ -38,7 +53,6 @@ func Post(ctx context.Context, db graph.Database) (*analysis.AtomicPostProcessin
 	} else if appRoleAssignmentStats, err := azureAnalysis.AppRoleAssignments(ctx, db); err != nil {
 		return &aggregateStats, err
 	} else {
-		aggregateStats.Merge(stats)
 		aggregateStats.Merge(userRoleStats)
 		aggregateStats.Merge(addSecretStats)
 		aggregateStats.Merge(executeCommandStats)
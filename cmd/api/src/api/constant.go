@@ -46,6 +46,7 @@ const (
 	URIPathVariableDomainID                          = "domain_id"
 	URIPathVariableEventID                           = "event_id"
 	URIPathVariableFeatureID                         = "feature_id"
+	URIPathVariableGraphPropertyIndexID              = "graph_property_index_id"
 	URIPathVariableJobID                             = "job_id"
 	URIPathVariableObjectID                          = "object_id"
 	URIPathVariablePermissionID                      = "permission_id"
@@ -58,4 +59,5 @@ const (
 	URIPathVariableTokenID                           = "token_id"
 	URIPathVariableUserID                            = "user_id"
 	URIPathVariableSavedQueryID                      = "saved_query_id"
+	URIPathVariableWorkspaceID                       = "workspace_id"
 )
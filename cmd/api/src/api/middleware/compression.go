@@ -48,6 +48,21 @@ func (s *GzipResponseWriter) Write(p []byte) (int, error) {
 	return s.gw.Write(p)
 }
 
+// Flush writes the data buffered by the gzip writer to the underlying response writer and then flushes the underlying
+// response writer to the client
+func (s *GzipResponseWriter) Flush() {
+	if err := s.gw.Flush(); err != nil {
+		log.Debugf("Failed flushing gzip response writer: %v", err)
+	} else if err := http.NewResponseController(s.ResponseWriter).Flush(); err != nil {
+		log.Debugf("Failed flushing response writer: %v", err)
+	}
+}
+
+// Unwrap returns the underlying response writer for use by http.ResponseController
+func (s *GzipResponseWriter) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
+
 func (s *GzipResponseWriter) Close() error {
 	return s.gw.Close()
 }
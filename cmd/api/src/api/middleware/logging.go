@@ -92,6 +92,11 @@ func (s *responseRecorder) WriteHeader(statusCode int) {
 	s.delegate.WriteHeader(statusCode)
 }
 
+// Unwrap returns the delegate response writer so that http.ResponseController can flush streamed responses
+func (s *responseRecorder) Unwrap() http.ResponseWriter {
+	return s.delegate
+}
+
 func getSignedRequestDate(request *http.Request) (string, bool) {
 	requestDateHeader := request.Header.Get(headers.RequestDate.String())
 	return requestDateHeader, requestDateHeader != ""
@@ -218,7 +218,7 @@ func CORSMiddleware() mux.MiddlewareFunc {
 	return handlers.CORS(
 		handlers.AllowCredentials(),
 		handlers.AllowedMethods([]string{"HEAD", "GET", "POST", "DELETE", "PUT"}),
-		handlers.AllowedHeaders([]string{headers.ContentType.String(), headers.Authorization.String()}),
+		handlers.AllowedHeaders([]string{headers.ContentType.String(), headers.Authorization.String(), headers.Workspace.String()}),
 		handlers.AllowedOrigins([]string{""}),
 	)
 }
@@ -0,0 +1,75 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/specterops/bloodhound/headers"
+	"github.com/specterops/bloodhound/src/api"
+	"github.com/specterops/bloodhound/src/auth"
+	"github.com/specterops/bloodhound/src/ctx"
+	"github.com/specterops/bloodhound/src/model"
+	"github.com/specterops/bloodhound/src/services/workspace"
+)
+
+var workspacePathRegex = regexp.MustCompile(`^/api/v2/workspaces/(\d+)(/.+)$`)
+
+// WorkspacePathMiddleware rewrites requests of the form /api/v2/workspaces/{id}/<resource> to /api/v2/<resource> and
+// selects the workspace through the workspace header. This must run before routing so that the rewritten path is
+// matched against the registered routes.
+func WorkspacePathMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+		if matches := workspacePathRegex.FindStringSubmatch(request.URL.Path); matches != nil {
+			request = request.Clone(request.Context())
+			request.Header.Set(headers.Workspace.String(), matches[1])
+			request.URL.Path = "/api/v2" + matches[2]
+			request.URL.RawPath = ""
+		}
+
+		next.ServeHTTP(response, request)
+	})
+}
+
+// WorkspaceMiddleware resolves the workspace selected by the workspace header and scopes the request context to it.
+// Requests that do not select a workspace are scoped to the default workspace. This must run after authentication.
+func WorkspaceMiddleware(db workspace.WorkspaceData, authorizer auth.Authorizer) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+			rawWorkspaceID := request.Header.Get(headers.Workspace.String())
+
+			if rawWorkspaceID == "" {
+				next.ServeHTTP(response, request.WithContext(workspace.Scope(request.Context(), model.DefaultWorkspace())))
+			} else if workspaceID, err := strconv.ParseInt(rawWorkspaceID, 10, 32); err != nil || workspaceID < 0 {
+				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "Workspace header has an invalid value", request), response)
+			} else if bhCtx := ctx.FromRequest(request); !bhCtx.AuthCtx.Authenticated() {
+				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusUnauthorized, "not authenticated", request), response)
+			} else if selectedWorkspace, err := workspace.Get(request.Context(), db, int32(workspaceID)); err != nil {
+				api.HandleDatabaseError(request, response, err)
+			} else if !workspace.Accessible(authorizer, bhCtx.AuthCtx, selectedWorkspace) {
+				authorizer.AuditLogUnauthorizedAccess(request)
+				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusForbidden, "not authorized", request), response)
+			} else {
+				response.Header().Set(headers.Workspace.String(), rawWorkspaceID)
+				next.ServeHTTP(response, request.WithContext(workspace.Scope(request.Context(), selectedWorkspace)))
+			}
+		})
+	}
+}
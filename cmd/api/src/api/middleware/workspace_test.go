@@ -0,0 +1,148 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/headers"
+	"github.com/specterops/bloodhound/src/api/middleware"
+	"github.com/specterops/bloodhound/src/auth"
+	"github.com/specterops/bloodhound/src/ctx"
+	"github.com/specterops/bloodhound/src/database"
+	dbMocks "github.com/specterops/bloodhound/src/database/mocks"
+	"github.com/specterops/bloodhound/src/model"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWorkspacePathMiddleware(t *testing.T) {
+	var (
+		servedPath      string
+		servedWorkspace string
+		handler         = middleware.WorkspacePathMiddleware(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+			servedPath = request.URL.Path
+			servedWorkspace = request.Header.Get(headers.Workspace.String())
+		}))
+	)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v2/workspaces/3/saved-queries?limit=5", nil))
+	require.Equal(t, "/api/v2/saved-queries", servedPath)
+	require.Equal(t, "3", servedWorkspace)
+
+	// Requests for the workspace resource itself must not be rewritten
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodDelete, "/api/v2/workspaces/3", nil))
+	require.Equal(t, "/api/v2/workspaces/3", servedPath)
+	require.Equal(t, "", servedWorkspace)
+}
+
+func newWorkspaceRequest(user model.User, workspaceID string) *http.Request {
+	request := httptest.NewRequest(http.MethodGet, "/api/v2/saved-queries", nil)
+
+	if workspaceID != "" {
+		request.Header.Set(headers.Workspace.String(), workspaceID)
+	}
+
+	return request.WithContext(ctx.Set(context.Background(), &ctx.Context{
+		AuthCtx: auth.Context{
+			Owner: user,
+		},
+	}))
+}
+
+func TestWorkspaceMiddleware(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockDB    = dbMocks.NewMockDatabase(mockCtrl)
+		user      = model.User{Unique: model.Unique{ID: uuid.Must(uuid.NewV4())}}
+		servedCtx context.Context
+		handler   = middleware.WorkspaceMiddleware(mockDB, auth.NewAuthorizer(mockDB))(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+			servedCtx = request.Context()
+		}))
+	)
+	defer mockCtrl.Finish()
+
+	t.Run("Default", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		handler.ServeHTTP(response, newWorkspaceRequest(user, ""))
+
+		require.Equal(t, http.StatusOK, response.Code)
+
+		workspaceID, isScoped := ctx.WorkspaceID(servedCtx)
+		require.True(t, isScoped)
+		require.Equal(t, model.DefaultWorkspaceID, workspaceID)
+
+		_, hasGraph := graph.GraphFromContext(servedCtx)
+		require.False(t, hasGraph)
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		handler.ServeHTTP(response, newWorkspaceRequest(user, "-1"))
+
+		require.Equal(t, http.StatusBadRequest, response.Code)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockDB.EXPECT().GetWorkspace(gomock.Any(), int32(4)).Return(model.Workspace{}, database.ErrNotFound)
+
+		response := httptest.NewRecorder()
+		handler.ServeHTTP(response, newWorkspaceRequest(user, "4"))
+
+		require.Equal(t, http.StatusNotFound, response.Code)
+	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		mockDB.EXPECT().GetWorkspace(gomock.Any(), int32(2)).Return(model.Workspace{
+			GraphName: "workspace_2",
+			UserIDs:   []uuid.UUID{uuid.Must(uuid.NewV4())},
+			Serial:    model.Serial{ID: 2},
+		}, nil)
+
+		response := httptest.NewRecorder()
+		handler.ServeHTTP(response, newWorkspaceRequest(user, "2"))
+
+		require.Equal(t, http.StatusForbidden, response.Code)
+	})
+
+	t.Run("Member", func(t *testing.T) {
+		mockDB.EXPECT().GetWorkspace(gomock.Any(), int32(2)).Return(model.Workspace{
+			GraphName: "workspace_2",
+			UserIDs:   []uuid.UUID{user.ID},
+			Serial:    model.Serial{ID: 2},
+		}, nil)
+
+		response := httptest.NewRecorder()
+		handler.ServeHTTP(response, newWorkspaceRequest(user, "2"))
+
+		require.Equal(t, http.StatusOK, response.Code)
+		require.Equal(t, "2", response.Header().Get(headers.Workspace.String()))
+
+		workspaceID, isScoped := ctx.WorkspaceID(servedCtx)
+		require.True(t, isScoped)
+		require.Equal(t, int32(2), workspaceID)
+
+		graphTarget, hasGraph := graph.GraphFromContext(servedCtx)
+		require.True(t, hasGraph)
+		require.Equal(t, "workspace_2", graphTarget.Name)
+	})
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/specterops/bloodhound/src/daemons/datapipe"
 	"github.com/specterops/bloodhound/src/database"
 	"github.com/specterops/bloodhound/src/queries"
+	"github.com/specterops/bloodhound/src/services/graphindex"
 )
 
 func RegisterFossGlobalMiddleware(routerInst *router.Router, cfg config.Configuration, db *database.BloodhoundDB, identityResolver auth.IdentityResolver, authenticator api.Authenticator) {
@@ -43,9 +44,13 @@ func RegisterFossGlobalMiddleware(routerInst *router.Router, cfg config.Configur
 		routerInst.UsePrerouting(middleware.LoggingMiddleware(cfg, identityResolver, db))
 	}
 
+	// Workspace path selection rewrites the request path and must therefore run before routing
+	routerInst.UsePrerouting(middleware.WorkspacePathMiddleware)
+
 	routerInst.UsePostrouting(
 		middleware.PanicHandler,
 		middleware.AuthMiddleware(authenticator),
+		middleware.WorkspaceMiddleware(db, auth.NewAuthorizer(db)),
 		middleware.CompressionMiddleware,
 	)
 }
@@ -60,6 +65,7 @@ func RegisterFossRoutes(
 	collectorManifests config.CollectorManifests,
 	authenticator api.Authenticator,
 	taskNotifier datapipe.Tasker,
+	indexBuilder graphindex.Builder,
 ) {
 	router.With(middleware.DefaultRateLimitMiddleware,
 		// Health Endpoint
@@ -76,6 +82,6 @@ func RegisterFossRoutes(
 		routerInst.PathPrefix("/ui", static.Handler()),
 	)
 
-	var resources = v2.NewResources(rdms, graphDB, cfg, apiCache, graphQuery, collectorManifests, taskNotifier)
+	var resources = v2.NewResources(rdms, graphDB, cfg, apiCache, graphQuery, collectorManifests, taskNotifier, indexBuilder)
 	NewV2API(cfg, resources, routerInst, authenticator)
 }
@@ -155,12 +155,30 @@ func NewV2API(cfg config.Configuration, resources v2.Resources, routerInst *rout
 		routerInst.GET("/api/v2/pathfinding", resources.GetPathfindingResult).Queries("start_node", "{start_node}", "end_node", "{end_node}").RequirePermissions(permissions.GraphDBRead),
 		routerInst.GET("/api/v2/graphs/shortest-path", resources.GetShortestPath).Queries(params.StartNode.String(), params.StartNode.RouteMatcher(), params.EndNode.String(), params.EndNode.RouteMatcher()).RequirePermissions(permissions.GraphDBRead),
 		routerInst.GET("/api/v2/graphs/edge-composition", resources.GetEdgeComposition).RequirePermissions(permissions.GraphDBRead),
+		routerInst.GET("/api/v2/graphs/export", resources.ExportGraph).RequirePermissions(permissions.GraphDBRead),
+		routerInst.POST("/api/v2/graphs/import", resources.ImportGraph).RequirePermissions(permissions.GraphDBWrite),
+		routerInst.GET("/api/v2/graphs/diff", resources.GetGraphDiff).RequirePermissions(permissions.GraphDBRead),
+		routerInst.GET("/api/v2/graphs/changes", resources.ListGraphChanges).RequirePermissions(permissions.GraphDBRead),
+		routerInst.GET("/api/v2/graphs/indexes", resources.ListGraphPropertyIndexes).RequirePermissions(permissions.AppReadApplicationConfiguration),
+		routerInst.POST("/api/v2/graphs/indexes", resources.CreateGraphPropertyIndex).RequirePermissions(permissions.AppWriteApplicationConfiguration),
+		routerInst.DELETE(fmt.Sprintf("/api/v2/graphs/indexes/{%s}", api.URIPathVariableGraphPropertyIndexID), resources.DeleteGraphPropertyIndex).RequirePermissions(permissions.AppWriteApplicationConfiguration),
+		routerInst.GET("/api/v2/graphs/indexes/utilization", resources.GetGraphIndexUtilization).RequirePermissions(permissions.AppReadApplicationConfiguration),
+
+		// Workspaces
+		routerInst.GET("/api/v2/workspaces", resources.ListWorkspaces).RequireAuth(),
+		routerInst.POST("/api/v2/workspaces", resources.CreateWorkspace).RequirePermissions(permissions.AppWriteApplicationConfiguration),
+		routerInst.PUT(fmt.Sprintf("/api/v2/workspaces/{%s}", api.URIPathVariableWorkspaceID), resources.UpdateWorkspace).RequirePermissions(permissions.AppWriteApplicationConfiguration),
+		routerInst.DELETE(fmt.Sprintf("/api/v2/workspaces/{%s}", api.URIPathVariableWorkspaceID), resources.DeleteWorkspace).RequirePermissions(permissions.AppWriteApplicationConfiguration),
 
 		// TODO discuss if this should be a post endpoint
 		routerInst.GET("/api/v2/graph-search", resources.GetSearchResult).RequirePermissions(permissions.GraphDBRead),
 
 		// Cypher Queries API
 		routerInst.POST("/api/v2/graphs/cypher", resources.CypherSearch).RequirePermissions(permissions.GraphDBRead),
+		routerInst.POST("/api/v2/graphs/cypher/explain", resources.CypherExplain).RequirePermissions(permissions.GraphDBRead),
+		routerInst.POST("/api/v2/graphs/cypher/mutate", resources.CypherMutation).RequirePermissions(permissions.GraphDBMutate),
+		routerInst.POST("/api/v2/graphs/cypher/complete", resources.CypherComplete).RequirePermissions(permissions.GraphDBRead),
+		routerInst.POST("/api/v2/graphs/cypher/validate", resources.CypherValidate).RequirePermissions(permissions.GraphDBRead),
 		routerInst.GET("/api/v2/saved-queries", resources.ListSavedQueries).RequirePermissions(permissions.SavedQueriesRead),
 		routerInst.POST("/api/v2/saved-queries", resources.CreateSavedQuery).RequirePermissions(permissions.SavedQueriesWrite),
 		routerInst.DELETE(fmt.Sprintf("/api/v2/saved-queries/{%s}", api.URIPathVariableSavedQueryID), resources.DeleteSavedQuery).RequirePermissions(permissions.SavedQueriesWrite),
@@ -212,6 +230,12 @@ func NewV2API(cfg config.Configuration, resources v2.Resources, routerInst *rout
 		routerInst.GET(fmt.Sprintf("/api/v2/domains/{%s}/controllers", api.URIPathVariableObjectID), resources.ListADEntityControllers).RequirePermissions(permissions.GraphDBRead),
 		routerInst.GET(fmt.Sprintf("/api/v2/domains/{%s}/dc-syncers", api.URIPathVariableObjectID), resources.ListADDomainDCSyncers).RequirePermissions(permissions.GraphDBRead),
 		routerInst.GET(fmt.Sprintf("/api/v2/domains/{%s}/linked-gpos", api.URIPathVariableObjectID), resources.ListADEntityLinkedGPOs).RequirePermissions(permissions.GraphDBRead),
+		routerInst.GET(fmt.Sprintf("/api/v2/domains/{%s}/session-exposures", api.URIPathVariableObjectID), resources.ListADDomainSessionExposures).RequirePermissions(permissions.GraphDBRead),
+		routerInst.GET(fmt.Sprintf("/api/v2/domains/{%s}/session-exposures/export", api.URIPathVariableObjectID), resources.ExportADDomainSessionExposures).RequirePermissions(permissions.GraphDBRead),
+		routerInst.GET(fmt.Sprintf("/api/v2/domains/{%s}/attack-path-findings", api.URIPathVariableObjectID), resources.ListDomainAttackPathFindings).RequirePermissions(permissions.GraphDBRead),
+		routerInst.GET(fmt.Sprintf("/api/v2/domains/{%s}/attack-path-findings/details", api.URIPathVariableObjectID), resources.ListDomainAttackPathFindingDetails).RequirePermissions(permissions.GraphDBRead),
+		routerInst.PUT(fmt.Sprintf("/api/v2/domains/{%s}/attack-path-findings/risk-acceptance", api.URIPathVariableObjectID), resources.UpdateDomainAttackPathRiskAcceptance).RequirePermissions(permissions.GraphDBWrite),
+		routerInst.GET("/api/v2/attack-path-types", resources.ListAttackPathFindingTypes).RequirePermissions(permissions.GraphDBRead),
 
 		// GPO Entity API
 		routerInst.GET(fmt.Sprintf("/api/v2/gpos/{%s}", api.URIPathVariableObjectID), resources.GetGPOEntityInfo).RequirePermissions(permissions.GraphDBRead),
@@ -278,9 +302,13 @@ func NewV2API(cfg config.Configuration, resources v2.Resources, routerInst *rout
 		routerInst.GET(fmt.Sprintf("/api/v2/azure-tenants/{%s}/data-quality-stats", api.URIPathVariableTenantID), resources.GetAzureDataQualityStats).RequirePermissions(permissions.GraphDBRead),
 		routerInst.GET(fmt.Sprintf("/api/v2/platform/{%s}/data-quality-stats", api.URIPathVariablePlatformID), resources.GetPlatformAggregateStats).RequirePermissions(permissions.GraphDBRead),
 
+		// Tier Zero violations API
+		routerInst.GET("/api/v2/tier-zero/violations", resources.ListTierZeroViolations).RequirePermissions(permissions.GraphDBRead),
+
 		// Datapipe API
 		routerInst.GET("/api/v2/datapipe/status", resources.GetDatapipeStatus).RequireAuth(),
 		//TODO: Update the permission on this once we get something more concrete
 		routerInst.PUT("/api/v2/analysis", resources.RequestAnalysis).RequirePermissions(permissions.GraphDBWrite),
+		routerInst.GET("/api/v2/analysis/runs", resources.ListAnalysisRuns).RequirePermissions(permissions.GraphDBRead),
 	)
 }
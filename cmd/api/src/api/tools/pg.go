@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/dbtype"
 	"github.com/specterops/bloodhound/dawgs"
+	"github.com/specterops/bloodhound/dawgs/changefeed"
 	"github.com/specterops/bloodhound/dawgs/drivers/neo4j"
 	"github.com/specterops/bloodhound/dawgs/drivers/pg"
 	"github.com/specterops/bloodhound/dawgs/graph"
@@ -28,6 +29,7 @@ import (
 	"github.com/specterops/bloodhound/log"
 	"github.com/specterops/bloodhound/src/api"
 	"github.com/specterops/bloodhound/src/config"
+	"github.com/specterops/bloodhound/src/services/workspace"
 	"net/http"
 	"sync"
 )
@@ -193,9 +195,10 @@ type PGMigrator struct {
 	state               MigratorState
 	lock                *sync.Mutex
 	cfg                 config.Configuration
+	workspaceData       workspace.WorkspaceData
 }
 
-func NewPGMigrator(serverCtx context.Context, cfg config.Configuration, graphSchema graph.Schema, graphDBSwitch *graph.DatabaseSwitch) *PGMigrator {
+func NewPGMigrator(serverCtx context.Context, cfg config.Configuration, graphSchema graph.Schema, graphDBSwitch *graph.DatabaseSwitch, workspaceData workspace.WorkspaceData) *PGMigrator {
 	return &PGMigrator{
 		graphSchema:   graphSchema,
 		graphDBSwitch: graphDBSwitch,
@@ -203,6 +206,7 @@ func NewPGMigrator(serverCtx context.Context, cfg config.Configuration, graphSch
 		state:         stateIdle,
 		lock:          &sync.Mutex{},
 		cfg:           cfg,
+		workspaceData: workspaceData,
 	}
 }
 
@@ -227,6 +231,15 @@ func (s *PGMigrator) advanceState(next MigratorState, validTransitions ...Migrat
 	return nil
 }
 
+// wrap re-applies the change feed of the current graph database, if any, to a database that is about to replace it
+func (s *PGMigrator) wrap(db graph.Database) graph.Database {
+	if changeFeed, found := changefeed.Find(s.graphDBSwitch); found {
+		return changeFeed.Rewrap(db)
+	}
+
+	return db
+}
+
 func (s *PGMigrator) SwitchPostgreSQL(response http.ResponseWriter, request *http.Request) {
 	if pgDB, err := dawgs.Open(s.serverCtx, pg.DriverName, dawgs.Config{
 		TraversalMemoryLimit: size.Gibibyte,
@@ -240,15 +253,25 @@ func (s *PGMigrator) SwitchPostgreSQL(response http.ResponseWriter, request *htt
 			"error": fmt.Errorf("failed updating graph database driver preferences: %w", err),
 		}, http.StatusInternalServerError, response)
 	} else {
-		s.graphDBSwitch.Switch(pgDB)
+		s.graphDBSwitch.Switch(s.wrap(pgDB))
 		response.WriteHeader(http.StatusOK)
 
 		log.Infof("Updated default graph driver to PostgreSQL")
 	}
 }
 
+// SwitchNeo4j switches the graph database to Neo4j. The switch is refused while named workspaces exist as Neo4j does
+// not support the graphs that back them.
 func (s *PGMigrator) SwitchNeo4j(response http.ResponseWriter, request *http.Request) {
-	if neo4jDB, err := dawgs.Open(s.serverCtx, neo4j.DriverName, dawgs.Config{
+	if workspaces, err := s.workspaceData.GetAllWorkspaces(request.Context()); err != nil {
+		api.WriteJSONResponse(request.Context(), map[string]any{
+			"error": fmt.Sprintf("failed listing workspaces: %v", err),
+		}, http.StatusInternalServerError, response)
+	} else if len(workspaces) > 0 {
+		api.WriteJSONResponse(request.Context(), map[string]any{
+			"error": fmt.Sprintf("%d workspaces must be deleted before switching to Neo4j as Neo4j does not support workspaces", len(workspaces)),
+		}, http.StatusConflict, response)
+	} else if neo4jDB, err := dawgs.Open(s.serverCtx, neo4j.DriverName, dawgs.Config{
 		TraversalMemoryLimit: size.Gibibyte,
 		DriverCfg:            s.cfg.Neo4J.Neo4jConnectionString(),
 	}); err != nil {
@@ -260,7 +283,7 @@ func (s *PGMigrator) SwitchNeo4j(response http.ResponseWriter, request *http.Req
 			"error": fmt.Errorf("failed updating graph database driver preferences: %w", err),
 		}, http.StatusInternalServerError, response)
 	} else {
-		s.graphDBSwitch.Switch(neo4jDB)
+		s.graphDBSwitch.Switch(s.wrap(neo4jDB))
 		response.WriteHeader(http.StatusOK)
 
 		log.Infof("Updated default graph driver to Neo4j")
@@ -0,0 +1,58 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tools_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/src/api/tools"
+	"github.com/specterops/bloodhound/src/config"
+	"github.com/specterops/bloodhound/src/model"
+	workspaceMocks "github.com/specterops/bloodhound/src/services/workspace/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPGMigrator_SwitchNeo4j_Workspaces(t *testing.T) {
+	var (
+		mockCtrl          = gomock.NewController(t)
+		mockWorkspaceData = workspaceMocks.NewMockWorkspaceData(mockCtrl)
+		migrator          = tools.NewPGMigrator(context.Background(), config.Configuration{}, graph.Schema{}, nil, mockWorkspaceData)
+	)
+
+	// Switching is refused while named workspaces exist
+	mockWorkspaceData.EXPECT().GetAllWorkspaces(gomock.Any()).Return(model.Workspaces{{Name: "Engagement"}}, nil)
+
+	response := httptest.NewRecorder()
+	migrator.SwitchNeo4j(response, httptest.NewRequest(http.MethodPut, "/graph-db/switch/neo4j", nil))
+
+	require.Equal(t, http.StatusConflict, response.Code)
+	require.Contains(t, response.Body.String(), "1 workspaces must be deleted before switching to Neo4j")
+
+	mockWorkspaceData.EXPECT().GetAllWorkspaces(gomock.Any()).Return(nil, errors.New("database error"))
+
+	response = httptest.NewRecorder()
+	migrator.SwitchNeo4j(response, httptest.NewRequest(http.MethodPut, "/graph-db/switch/neo4j", nil))
+
+	require.Equal(t, http.StatusInternalServerError, response.Code)
+	require.Contains(t, response.Body.String(), "failed listing workspaces: database error")
+}
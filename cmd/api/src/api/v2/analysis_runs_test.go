@@ -0,0 +1,83 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v2_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/specterops/bloodhound/src/api"
+	v2 "github.com/specterops/bloodhound/src/api/v2"
+	"github.com/specterops/bloodhound/src/database/mocks"
+	"github.com/specterops/bloodhound/src/model"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const analysisRunsEndpoint = "/api/v2/analysis/runs"
+
+func serveAnalysisRuns(t *testing.T, resources v2.Resources, params url.Values) *httptest.ResponseRecorder {
+	req, err := http.NewRequest(http.MethodGet, analysisRunsEndpoint, nil)
+	require.Nil(t, err)
+
+	req.URL.RawQuery = params.Encode()
+
+	router := mux.NewRouter()
+	router.HandleFunc(analysisRunsEndpoint, resources.ListAnalysisRuns).Methods(http.MethodGet)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, req)
+	return response
+}
+
+func TestResources_ListAnalysisRuns_SortingError(t *testing.T) {
+	response := serveAnalysisRuns(t, v2.Resources{}, url.Values{"sort_by": []string{"errors"}})
+
+	require.Equal(t, http.StatusBadRequest, response.Code)
+	require.Contains(t, response.Body.String(), api.ErrorResponseDetailsNotSortable)
+}
+
+func TestResources_ListAnalysisRuns_InvalidFilterColumn(t *testing.T) {
+	response := serveAnalysisRuns(t, v2.Resources{}, url.Values{"steps": []string{"eq:foo"}})
+
+	require.Equal(t, http.StatusBadRequest, response.Code)
+	require.Contains(t, response.Body.String(), api.ErrorResponseDetailsColumnNotFilterable)
+}
+
+func TestResources_ListAnalysisRuns(t *testing.T) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		mockDB   = mocks.NewMockDatabase(mockCtrl)
+		run      = model.NewAnalysisRun(model.AnalysisRunTriggerFileUpload, []int64{4, 5})
+	)
+	defer mockCtrl.Finish()
+
+	run.RelationshipsCreated["AdminTo"] = 12
+	run.Complete(model.AnalysisRunStatusComplete)
+
+	mockDB.EXPECT().ListAnalysisRuns(gomock.Any(), "started_at desc", gomock.Any(), 0, 10).Return(model.AnalysisRuns{*run}, 1, nil)
+
+	response := serveAnalysisRuns(t, v2.Resources{DB: mockDB}, url.Values{"sort_by": []string{"-started_at"}, "status": []string{"eq:complete"}, "limit": []string{"10"}})
+
+	require.Equal(t, http.StatusOK, response.Code)
+	require.Contains(t, response.Body.String(), `"file_upload_job_ids":[4,5]`)
+	require.Contains(t, response.Body.String(), `"relationships_created":{"AdminTo":12}`)
+	require.Contains(t, response.Body.String(), `"status":"complete"`)
+}
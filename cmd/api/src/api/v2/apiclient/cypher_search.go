@@ -17,7 +17,12 @@
 package apiclient
 
 import (
+	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 
 	"github.com/specterops/bloodhound/src/api"
 	v2 "github.com/specterops/bloodhound/src/api/v2"
@@ -39,3 +44,130 @@ func (s Client) CypherSearch(request v2.CypherSearch) (model.UnifiedGraph, error
 		return graphResponse, api.ReadAPIV2ResponsePayload(&graphResponse, response)
 	}
 }
+
+func (s Client) CypherTableSearch(request v2.CypherSearch, skip, limit int) (model.CypherTable, int, error) {
+	var (
+		tableResponse struct {
+			api.ResponseWrapper
+			Data model.CypherTable `json:"data"`
+		}
+		params = url.Values{
+			"skip":  []string{strconv.Itoa(skip)},
+			"limit": []string{strconv.Itoa(limit)},
+		}
+	)
+
+	request.ResultFormat = v2.CypherResultFormatTable
+
+	if response, err := s.Request(http.MethodPost, "api/v2/graphs/cypher", params, request); err != nil {
+		return tableResponse.Data, 0, err
+	} else {
+		defer response.Body.Close()
+
+		if api.IsErrorResponse(response) {
+			return tableResponse.Data, 0, ReadAPIError(response)
+		}
+
+		if err := api.ReadAPIV2ResponseWrapperPayload(&tableResponse, response); err != nil {
+			return tableResponse.Data, 0, err
+		}
+
+		return tableResponse.Data, tableResponse.Count, nil
+	}
+}
+
+// CypherStreamSearch runs a cypher query with results streamed as newline delimited JSON and passes each record to the
+// given delegate as it is read. A stream that ends with an error record returns the error of the record.
+func (s Client) CypherStreamSearch(request v2.CypherSearch, delegate func(record model.CypherStreamRecord) error) error {
+	request.ResultFormat = v2.CypherResultFormatNDJSON
+
+	if response, err := s.Request(http.MethodPost, "api/v2/graphs/cypher", nil, request); err != nil {
+		return err
+	} else {
+		defer response.Body.Close()
+
+		if api.IsErrorResponse(response) {
+			return ReadAPIError(response)
+		}
+
+		decoder := json.NewDecoder(response.Body)
+
+		for {
+			var record model.CypherStreamRecord
+
+			if err := decoder.Decode(&record); errors.Is(err, io.EOF) {
+				return nil
+			} else if err != nil {
+				return err
+			} else if record.Type == model.CypherStreamRecordError {
+				return errors.New(record.Error)
+			} else if err := delegate(record); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s Client) CypherExplain(request v2.CypherSearch) (model.CypherExplanation, error) {
+	var explanation model.CypherExplanation
+
+	if response, err := s.Request(http.MethodPost, "api/v2/graphs/cypher/explain", nil, request); err != nil {
+		return explanation, err
+	} else {
+		defer response.Body.Close()
+
+		if api.IsErrorResponse(response) {
+			return explanation, ReadAPIError(response)
+		}
+
+		return explanation, api.ReadAPIV2ResponsePayload(&explanation, response)
+	}
+}
+
+func (s Client) CypherMutation(request v2.CypherMutationRequest) (model.CypherMutation, error) {
+	var mutation model.CypherMutation
+
+	if response, err := s.Request(http.MethodPost, "api/v2/graphs/cypher/mutate", nil, request); err != nil {
+		return mutation, err
+	} else {
+		defer response.Body.Close()
+
+		if api.IsErrorResponse(response) {
+			return mutation, ReadAPIError(response)
+		}
+
+		return mutation, api.ReadAPIV2ResponsePayload(&mutation, response)
+	}
+}
+
+func (s Client) CypherComplete(request v2.CypherCompletionRequest) (model.CypherCompletion, error) {
+	var completion model.CypherCompletion
+
+	if response, err := s.Request(http.MethodPost, "api/v2/graphs/cypher/complete", nil, request); err != nil {
+		return completion, err
+	} else {
+		defer response.Body.Close()
+
+		if api.IsErrorResponse(response) {
+			return completion, ReadAPIError(response)
+		}
+
+		return completion, api.ReadAPIV2ResponsePayload(&completion, response)
+	}
+}
+
+func (s Client) CypherValidate(request v2.CypherSearch) (model.CypherValidation, error) {
+	var validation model.CypherValidation
+
+	if response, err := s.Request(http.MethodPost, "api/v2/graphs/cypher/validate", nil, request); err != nil {
+		return validation, err
+	} else {
+		defer response.Body.Close()
+
+		if api.IsErrorResponse(response) {
+			return validation, ReadAPIError(response)
+		}
+
+		return validation, api.ReadAPIV2ResponsePayload(&validation, response)
+	}
+}
@@ -17,7 +17,17 @@
 package v2
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
 	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/specterops/bloodhound/analysis/findings"
+	"github.com/specterops/bloodhound/src/api"
+	"github.com/specterops/bloodhound/src/model"
+	gormutils "gorm.io/gorm/utils"
 )
 
 const (
@@ -27,10 +37,185 @@ const (
 	ErrorNoFindingType      = "no finding type specified"
 	ErrorInvalidFindingType = "invalid finding type specified: %v"
 	ErrorInvalidRFC3339     = "invalid RFC-3339 datetime format: %v"
+	ErrorAcceptUntilInPast  = "accept_until must be in the future"
+
+	QueryParameterFinding = "finding"
 )
 
+// acceptRiskIndefinitely is the expiry recorded for acceptances made through the deprecated accepted field without an
+// accept_until value
+var acceptRiskIndefinitely = time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+
 type RiskAcceptRequest struct {
 	RiskType    string    `json:"risk_type"`
 	AcceptUntil time.Time `json:"accept_until"`
 	Accepted    bool      `json:"accepted"` // DEPRECATED remove this field for V3
 }
+
+type AttackPathFindingType struct {
+	Name            string `json:"name"`
+	Title           string `json:"title"`
+	Severity        string `json:"severity"`
+	EnvironmentKind string `json:"environment_kind"`
+}
+
+// ListAttackPathFindingTypes returns the catalog of finding types evaluated after each analysis run
+func (s Resources) ListAttackPathFindingTypes(response http.ResponseWriter, request *http.Request) {
+	var (
+		catalog = findings.Catalog()
+		types   = make([]AttackPathFindingType, 0, len(catalog))
+	)
+
+	for _, findingType := range catalog {
+		types = append(types, AttackPathFindingType{
+			Name:            findingType.Name,
+			Title:           findingType.Title,
+			Severity:        string(findingType.Severity),
+			EnvironmentKind: findingType.EnvironmentKind.String(),
+		})
+	}
+
+	api.WriteBasicResponse(request.Context(), types, http.StatusOK, response)
+}
+
+// ListDomainAttackPathFindings returns a summary of every finding type with impacted principals in the domain or tenant
+func (s Resources) ListDomainAttackPathFindings(response http.ResponseWriter, request *http.Request) {
+	if environmentID, hasEnvironmentID := mux.Vars(request)[api.URIPathVariableObjectID]; !hasEnvironmentID || environmentID == "" {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, ErrorNoDomainId, request), response)
+	} else if summaries, err := s.DB.GetAttackPathFindingSummaries(request.Context(), environmentID); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else {
+		for idx := range summaries {
+			if findingType, found := findings.Lookup(summaries[idx].FindingType); found {
+				summaries[idx].Title = findingType.Title
+			}
+		}
+
+		api.WriteBasicResponse(request.Context(), summaries, http.StatusOK, response)
+	}
+}
+
+// ListDomainAttackPathFindingDetails returns the principals impacted by a single finding type in the domain or tenant
+func (s Resources) ListDomainAttackPathFindingDetails(response http.ResponseWriter, request *http.Request) {
+	var (
+		order         []string
+		queryParams   = request.URL.Query()
+		sortByColumns = queryParams[api.QueryParameterSortBy]
+		findingType   = queryParams.Get(QueryParameterFinding)
+		attackPaths   model.AttackPathFindings
+	)
+
+	environmentID, hasEnvironmentID := mux.Vars(request)[api.URIPathVariableObjectID]
+	if !hasEnvironmentID || environmentID == "" {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, ErrorNoDomainId, request), response)
+		return
+	} else if findingType == "" {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, ErrorNoFindingType, request), response)
+		return
+	} else if _, found := findings.Lookup(findingType); !found {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, fmt.Sprintf(ErrorInvalidFindingType, findingType), request), response)
+		return
+	}
+
+	for _, column := range sortByColumns {
+		var descending bool
+		if string(column[0]) == "-" {
+			descending = true
+			column = column[1:]
+		}
+
+		if !attackPaths.IsSortable(column) {
+			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsNotSortable, request), response)
+			return
+		}
+
+		if descending {
+			order = append(order, column+" desc")
+		} else {
+			order = append(order, column)
+		}
+	}
+
+	queryParameterFilterParser := model.NewQueryParameterFilterParser()
+	if queryFilters, err := queryParameterFilterParser.ParseQueryParameterFilters(request); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsBadQueryParameterFilters, request), response)
+		return
+	} else {
+		// The finding type is a required parameter rather than a filter
+		delete(queryFilters, QueryParameterFinding)
+
+		for name, filters := range queryFilters {
+			if validPredicates, err := attackPaths.GetValidFilterPredicatesAsStrings(name); err != nil {
+				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, fmt.Sprintf("%s: %s", api.ErrorResponseDetailsColumnNotFilterable, name), request), response)
+				return
+			} else {
+				for i, filter := range filters {
+					if !gormutils.Contains(validPredicates, string(filter.Operator)) {
+						api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, fmt.Sprintf("%s: %s %s", api.ErrorResponseDetailsFilterPredicateNotSupported, filter.Name, filter.Operator), request), response)
+						return
+					}
+
+					queryFilters[name][i].IsStringData = attackPaths.IsString(filter.Name)
+				}
+			}
+		}
+
+		if sqlFilter, err := queryFilters.BuildSQLFilter(); err != nil {
+			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "error building SQL for filter", request), response)
+		} else if skip, err := ParseSkipQueryParameter(queryParams, 0); err != nil {
+			api.WriteErrorResponse(request.Context(), ErrBadQueryParameter(request, model.PaginationQueryParameterSkip, err), response)
+		} else if limit, err := ParseLimitQueryParameter(queryParams, 100); err != nil {
+			api.WriteErrorResponse(request.Context(), ErrBadQueryParameter(request, model.PaginationQueryParameterLimit, err), response)
+		} else if attackPaths, count, err := s.DB.GetAttackPathFindings(request.Context(), environmentID, findingType, strings.Join(order, ", "), sqlFilter, skip, limit); err != nil {
+			api.HandleDatabaseError(request, response, err)
+		} else {
+			api.WriteResponseWrapperWithPagination(request.Context(), attackPaths, limit, skip, count, http.StatusOK, response)
+		}
+	}
+}
+
+// UpdateDomainAttackPathRiskAcceptance accepts or unaccepts the risk of a finding type in the domain or tenant. An
+// acceptance expires at accept_until; omitting accept_until removes the acceptance unless the deprecated accepted field
+// is set, in which case the risk is accepted indefinitely.
+func (s Resources) UpdateDomainAttackPathRiskAcceptance(response http.ResponseWriter, request *http.Request) {
+	var (
+		acceptRequest RiskAcceptRequest
+		parseErr      *time.ParseError
+	)
+
+	if environmentID, hasEnvironmentID := mux.Vars(request)[api.URIPathVariableObjectID]; !hasEnvironmentID || environmentID == "" {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, ErrorNoDomainId, request), response)
+	} else if err := api.ReadJSONRequestPayloadLimited(&acceptRequest, request); errors.As(err, &parseErr) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, fmt.Sprintf(ErrorInvalidRFC3339, parseErr.Value), request), response)
+	} else if err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, ErrorDecodeParams, request), response)
+	} else if acceptRequest.RiskType == "" {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, ErrorNoFindingType, request), response)
+	} else if _, found := findings.Lookup(acceptRequest.RiskType); !found {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, fmt.Sprintf(ErrorInvalidFindingType, acceptRequest.RiskType), request), response)
+	} else if acceptRequest.AcceptUntil.IsZero() && !acceptRequest.Accepted {
+		if err := s.DB.UnacceptAttackPathRisk(request.Context(), environmentID, acceptRequest.RiskType); err != nil {
+			api.HandleDatabaseError(request, response, err)
+		} else {
+			response.WriteHeader(http.StatusNoContent)
+		}
+	} else {
+		acceptance := model.AttackPathRiskAcceptance{
+			FindingType:   acceptRequest.RiskType,
+			EnvironmentID: environmentID,
+			AcceptedUntil: acceptRequest.AcceptUntil,
+		}
+
+		if acceptance.AcceptedUntil.IsZero() {
+			acceptance.AcceptedUntil = acceptRiskIndefinitely
+		}
+
+		if !acceptance.IsAccepted(time.Now()) {
+			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, ErrorAcceptUntilInPast, request), response)
+		} else if acceptance, err := s.DB.AcceptAttackPathRisk(request.Context(), acceptance); err != nil {
+			api.HandleDatabaseError(request, response, err)
+		} else {
+			api.WriteBasicResponse(request.Context(), acceptance, http.StatusOK, response)
+		}
+	}
+}
@@ -15,3 +15,191 @@
 // SPDX-License-Identifier: Apache-2.0
 
 package v2_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/specterops/bloodhound/analysis/findings"
+	"github.com/specterops/bloodhound/headers"
+	"github.com/specterops/bloodhound/mediatypes"
+	"github.com/specterops/bloodhound/src/api"
+	v2 "github.com/specterops/bloodhound/src/api/v2"
+	"github.com/specterops/bloodhound/src/database/mocks"
+	"github.com/specterops/bloodhound/src/model"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const attackPathDomainSID = "S-1-5-21-2"
+
+func serveAttackPathFindings(t *testing.T, method, endpoint string, handler http.HandlerFunc, params url.Values, body string) *httptest.ResponseRecorder {
+	req, err := http.NewRequest(method, fmt.Sprintf(endpoint, attackPathDomainSID), bytes.NewBufferString(body))
+	require.Nil(t, err)
+
+	req.URL.RawQuery = params.Encode()
+	req.Header.Set(headers.ContentType.String(), mediatypes.ApplicationJson.String())
+
+	router := mux.NewRouter()
+	router.HandleFunc(fmt.Sprintf(endpoint, fmt.Sprintf("{%s}", api.URIPathVariableObjectID)), handler).Methods(method)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, req)
+	return response
+}
+
+func TestResources_ListAttackPathFindingTypes(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "/api/v2/attack-path-types", nil)
+	require.Nil(t, err)
+
+	response := httptest.NewRecorder()
+	http.HandlerFunc(v2.Resources{}.ListAttackPathFindingTypes).ServeHTTP(response, req)
+
+	require.Equal(t, http.StatusOK, response.Code)
+	require.Contains(t, response.Body.String(), fmt.Sprintf(`"name":"%s"`, findings.NonTierZeroDCSync))
+}
+
+func TestResources_ListDomainAttackPathFindings(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockDB    = mocks.NewMockDatabase(mockCtrl)
+		resources = v2.Resources{DB: mockDB}
+	)
+	defer mockCtrl.Finish()
+
+	mockDB.EXPECT().GetAttackPathFindingSummaries(gomock.Any(), attackPathDomainSID).Return(model.AttackPathFindingSummaries{{
+		FindingType:            findings.KerberoastableTierZeroUsers,
+		Severity:               string(findings.SeverityHigh),
+		ImpactedPrincipalCount: 3,
+	}}, nil)
+
+	response := serveAttackPathFindings(t, http.MethodGet, "/api/v2/domains/%s/attack-path-findings", resources.ListDomainAttackPathFindings, url.Values{}, "")
+
+	require.Equal(t, http.StatusOK, response.Code)
+	require.Contains(t, response.Body.String(), `"title":"Kerberoastable Tier Zero Users"`)
+	require.Contains(t, response.Body.String(), `"impacted_principal_count":3`)
+}
+
+func TestResources_ListDomainAttackPathFindingDetails_NoFindingType(t *testing.T) {
+	resources := v2.Resources{}
+	response := serveAttackPathFindings(t, http.MethodGet, "/api/v2/domains/%s/attack-path-findings/details", resources.ListDomainAttackPathFindingDetails, url.Values{}, "")
+
+	require.Equal(t, http.StatusBadRequest, response.Code)
+	require.Contains(t, response.Body.String(), v2.ErrorNoFindingType)
+}
+
+func TestResources_ListDomainAttackPathFindingDetails_InvalidFindingType(t *testing.T) {
+	resources := v2.Resources{}
+	response := serveAttackPathFindings(t, http.MethodGet, "/api/v2/domains/%s/attack-path-findings/details", resources.ListDomainAttackPathFindingDetails, url.Values{"finding": []string{"NotAFinding"}}, "")
+
+	require.Equal(t, http.StatusBadRequest, response.Code)
+	require.Contains(t, response.Body.String(), fmt.Sprintf(v2.ErrorInvalidFindingType, "NotAFinding"))
+}
+
+func TestResources_ListDomainAttackPathFindingDetails_SortingError(t *testing.T) {
+	resources := v2.Resources{}
+	response := serveAttackPathFindings(t, http.MethodGet, "/api/v2/domains/%s/attack-path-findings/details", resources.ListDomainAttackPathFindingDetails, url.Values{
+		"finding": []string{findings.NonTierZeroDCSync},
+		"sort_by": []string{"severity"},
+	}, "")
+
+	require.Equal(t, http.StatusBadRequest, response.Code)
+	require.Contains(t, response.Body.String(), api.ErrorResponseDetailsNotSortable)
+}
+
+func TestResources_ListDomainAttackPathFindingDetails(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockDB    = mocks.NewMockDatabase(mockCtrl)
+		resources = v2.Resources{DB: mockDB}
+	)
+	defer mockCtrl.Finish()
+
+	mockDB.EXPECT().GetAttackPathFindings(gomock.Any(), attackPathDomainSID, findings.NonTierZeroDCSync, "principal_name desc", gomock.Any(), 0, 100).Return(model.AttackPathFindings{{
+		FindingType:       findings.NonTierZeroDCSync,
+		EnvironmentID:     attackPathDomainSID,
+		PrincipalObjectID: "S-1-5-21-2-1105",
+		PrincipalName:     "SVC_SYNC@TESTLAB.LOCAL",
+		PrincipalKind:     "User",
+		Severity:          string(findings.SeverityCritical),
+	}}, 1, nil)
+
+	response := serveAttackPathFindings(t, http.MethodGet, "/api/v2/domains/%s/attack-path-findings/details", resources.ListDomainAttackPathFindingDetails, url.Values{
+		"finding": []string{findings.NonTierZeroDCSync},
+		"sort_by": []string{"-principal_name"},
+	}, "")
+
+	require.Equal(t, http.StatusOK, response.Code)
+	require.Contains(t, response.Body.String(), `"principal_object_id":"S-1-5-21-2-1105"`)
+}
+
+func TestResources_UpdateDomainAttackPathRiskAcceptance_InvalidRFC3339(t *testing.T) {
+	resources := v2.Resources{}
+	response := serveAttackPathFindings(t, http.MethodPut, "/api/v2/domains/%s/attack-path-findings/risk-acceptance", resources.UpdateDomainAttackPathRiskAcceptance, url.Values{},
+		fmt.Sprintf(`{"risk_type":"%s","accept_until":"tomorrow"}`, findings.NonTierZeroDCSync))
+
+	require.Equal(t, http.StatusBadRequest, response.Code)
+	require.Contains(t, response.Body.String(), "invalid RFC-3339 datetime format")
+}
+
+func TestResources_UpdateDomainAttackPathRiskAcceptance_NoFindingType(t *testing.T) {
+	resources := v2.Resources{}
+	response := serveAttackPathFindings(t, http.MethodPut, "/api/v2/domains/%s/attack-path-findings/risk-acceptance", resources.UpdateDomainAttackPathRiskAcceptance, url.Values{}, `{"accepted":true}`)
+
+	require.Equal(t, http.StatusBadRequest, response.Code)
+	require.Contains(t, response.Body.String(), v2.ErrorNoFindingType)
+}
+
+func TestResources_UpdateDomainAttackPathRiskAcceptance_InPast(t *testing.T) {
+	resources := v2.Resources{}
+	response := serveAttackPathFindings(t, http.MethodPut, "/api/v2/domains/%s/attack-path-findings/risk-acceptance", resources.UpdateDomainAttackPathRiskAcceptance, url.Values{},
+		fmt.Sprintf(`{"risk_type":"%s","accept_until":"2020-01-01T00:00:00Z"}`, findings.NonTierZeroDCSync))
+
+	require.Equal(t, http.StatusBadRequest, response.Code)
+	require.Contains(t, response.Body.String(), v2.ErrorAcceptUntilInPast)
+}
+
+func TestResources_UpdateDomainAttackPathRiskAcceptance_Accept(t *testing.T) {
+	var (
+		mockCtrl    = gomock.NewController(t)
+		mockDB      = mocks.NewMockDatabase(mockCtrl)
+		resources   = v2.Resources{DB: mockDB}
+		acceptUntil = time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+		acceptance  = model.AttackPathRiskAcceptance{
+			FindingType:   findings.NonTierZeroDCSync,
+			EnvironmentID: attackPathDomainSID,
+			AcceptedUntil: acceptUntil,
+		}
+	)
+	defer mockCtrl.Finish()
+
+	mockDB.EXPECT().AcceptAttackPathRisk(gomock.Any(), acceptance).Return(acceptance, nil)
+
+	response := serveAttackPathFindings(t, http.MethodPut, "/api/v2/domains/%s/attack-path-findings/risk-acceptance", resources.UpdateDomainAttackPathRiskAcceptance, url.Values{},
+		fmt.Sprintf(`{"risk_type":"%s","accept_until":"%s"}`, findings.NonTierZeroDCSync, acceptUntil.Format(time.RFC3339)))
+
+	require.Equal(t, http.StatusOK, response.Code)
+	require.Contains(t, response.Body.String(), `"finding_type":"NonTierZeroDCSync"`)
+}
+
+func TestResources_UpdateDomainAttackPathRiskAcceptance_Unaccept(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockDB    = mocks.NewMockDatabase(mockCtrl)
+		resources = v2.Resources{DB: mockDB}
+	)
+	defer mockCtrl.Finish()
+
+	mockDB.EXPECT().UnacceptAttackPathRisk(gomock.Any(), attackPathDomainSID, findings.NonTierZeroDCSync).Return(nil)
+
+	response := serveAttackPathFindings(t, http.MethodPut, "/api/v2/domains/%s/attack-path-findings/risk-acceptance", resources.UpdateDomainAttackPathRiskAcceptance, url.Values{},
+		fmt.Sprintf(`{"risk_type":"%s","accepted":false}`, findings.NonTierZeroDCSync))
+
+	require.Equal(t, http.StatusNoContent, response.Code)
+}
@@ -17,16 +17,68 @@
 package v2
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"time"
+	"unicode/utf8"
 
+	"github.com/gofrs/uuid"
 	"github.com/specterops/bloodhound/dawgs/util"
+	"github.com/specterops/bloodhound/headers"
+	"github.com/specterops/bloodhound/log"
 	"github.com/specterops/bloodhound/src/api"
+	"github.com/specterops/bloodhound/src/auth"
+	"github.com/specterops/bloodhound/src/ctx"
+	"github.com/specterops/bloodhound/src/model"
 	"github.com/specterops/bloodhound/src/queries"
+	"github.com/specterops/bloodhound/src/utils"
+)
+
+const (
+	CypherResultFormatGraph  = "graph"
+	CypherResultFormatTable  = "table"
+	CypherResultFormatNDJSON = "ndjson"
+
+	// MediaTypeNDJSON is the content type of cypher query results streamed as newline delimited JSON
+	MediaTypeNDJSON = "application/x-ndjson"
+
+	// cypherStreamFlushInterval is the number of records written to a streamed response between flushes
+	cypherStreamFlushInterval = 256
 )
 
 type CypherSearch struct {
-	Query             string `json:"query"`
-	IncludeProperties bool   `json:"include_properties,omitempty"`
+	Query             string         `json:"query"`
+	SavedQueryID      int64          `json:"saved_query_id,omitempty"`
+	Parameters        map[string]any `json:"parameters,omitempty"`
+	IncludeProperties bool           `json:"include_properties,omitempty"`
+	ResultFormat      string         `json:"result_format,omitempty"`
+}
+
+// resolveSavedQuery replaces the query of a search that runs a saved query with the query that was saved and binds
+// the supplied parameters to the parameters the saved query declares. If the saved query can not be run an error
+// response is written and false is returned.
+func (s Resources) resolveSavedQuery(response http.ResponseWriter, request *http.Request, payload *CypherSearch) bool {
+	if payload.SavedQueryID == 0 {
+		return true
+	}
+
+	if payload.Query != "" {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "query and saved_query_id may not both be set", request), response)
+	} else if user, isUser := auth.GetUserFromAuthCtx(ctx.FromRequest(request).AuthCtx); !isUser {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "No associated user found", request), response)
+	} else if savedQuery, err := s.DB.GetSavedQuery(request.Context(), user.ID, payload.SavedQueryID); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if parameters, err := savedQuery.Parameters.Bind(payload.Parameters); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+	} else {
+		payload.Query = savedQuery.Query
+		payload.Parameters = parameters
+		return true
+	}
+
+	return false
 }
 
 func (s Resources) CypherSearch(response http.ResponseWriter, request *http.Request) {
@@ -37,22 +89,289 @@ func (s Resources) CypherSearch(response http.ResponseWriter, request *http.Requ
 			request.Context(),
 			api.BuildErrorResponse(http.StatusBadRequest, "JSON malformed.", request), response,
 		)
-	} else if graphResponse, err := s.GraphQuery.RawCypherSearch(request.Context(), payload.Query, payload.IncludeProperties); err != nil {
-		if queries.IsQueryError(err) {
+	} else if s.resolveSavedQuery(response, request, &payload) {
+		switch payload.ResultFormat {
+		case "", CypherResultFormatGraph:
+			if graphResponse, err := s.GraphQuery.RawCypherSearch(request.Context(), payload.Query, payload.Parameters, payload.IncludeProperties); err != nil {
+				writeCypherSearchError(response, request, err)
+			} else {
+				api.WriteBasicResponse(request.Context(), graphResponse, http.StatusOK, response)
+			}
+
+		case CypherResultFormatTable:
+			if skip, limit, _, err := utils.GetPageParamsForGraphQuery(request.Context(), request.URL.Query()); err != nil {
+				api.WriteErrorResponse(
+					request.Context(),
+					api.BuildErrorResponse(http.StatusBadRequest, fmt.Sprintf(api.FmtErrorResponseDetailsBadQueryParameters, err), request), response,
+				)
+			} else if table, count, err := s.GraphQuery.RawCypherTableSearch(request.Context(), payload.Query, payload.Parameters, payload.IncludeProperties, skip, limit); err != nil {
+				writeCypherSearchError(response, request, err)
+			} else {
+				api.WriteResponseWrapperWithPagination(request.Context(), table, queries.CypherTablePageLimit(limit), skip, count, http.StatusOK, response)
+			}
+
+		case CypherResultFormatNDJSON:
+			s.streamCypherSearch(response, request, payload)
+
+		default:
 			api.WriteErrorResponse(
 				request.Context(),
-				api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response,
+				api.BuildErrorResponse(http.StatusBadRequest, fmt.Sprintf("result_format must be one of: %s, %s, %s", CypherResultFormatGraph, CypherResultFormatTable, CypherResultFormatNDJSON), request), response,
 			)
-		} else if util.IsNeoTimeoutError(err) {
-			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, "transaction timed out, reduce query complexity or try again later", request), response)
+		}
+	}
+}
+
+// cypherStreamWriter writes the records of a streamed cypher query as newline delimited JSON. The response status and
+// headers are written with the first record so that errors raised before the query produces any records are written
+// as regular error responses.
+type cypherStreamWriter struct {
+	response   http.ResponseWriter
+	controller *http.ResponseController
+	encoder    *json.Encoder
+	started    bool
+	unflushed  int
+}
+
+func newCypherStreamWriter(response http.ResponseWriter) *cypherStreamWriter {
+	return &cypherStreamWriter{
+		response:   response,
+		controller: http.NewResponseController(response),
+		encoder:    json.NewEncoder(response),
+	}
+}
+
+func (s *cypherStreamWriter) Write(record model.CypherStreamRecord) error {
+	if !s.started {
+		s.started = true
+
+		// Streams may outlive the write timeout of the server. The query that feeds the stream remains bound by its
+		// transaction timeout.
+		if err := s.controller.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return err
+		}
+
+		s.response.Header().Set(headers.ContentType.String(), MediaTypeNDJSON)
+		s.response.WriteHeader(http.StatusOK)
+	}
+
+	if err := s.encoder.Encode(record); err != nil {
+		return err
+	}
+
+	if s.unflushed++; s.unflushed >= cypherStreamFlushInterval {
+		return s.Flush()
+	}
+
+	return nil
+}
+
+func (s *cypherStreamWriter) Flush() error {
+	s.unflushed = 0
+
+	if err := s.controller.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+
+	return nil
+}
+
+// cypherStreamRowLimit returns the highest streamed row limit of the roles of the requesting user
+func (s Resources) cypherStreamRowLimit(request *http.Request) int {
+	if user, isUser := auth.GetUserFromAuthCtx(ctx.FromRequest(request).AuthCtx); isUser {
+		roleNames := make([]string, len(user.Roles))
+
+		for idx, role := range user.Roles {
+			roleNames[idx] = role.Name
+		}
+
+		return s.Config.CypherStreamRowLimit(roleNames...)
+	}
+
+	return s.Config.CypherStreamRowLimit()
+}
+
+// streamCypherSearch writes the results of a cypher query as newline delimited JSON while they are read from the
+// graph database. The query is bound to the request context and stops when the client disconnects. Errors raised after
+// the first record was written end the stream with an error record.
+func (s Resources) streamCypherSearch(response http.ResponseWriter, request *http.Request, payload CypherSearch) {
+	streamWriter := newCypherStreamWriter(response)
+
+	if numRows, err := s.GraphQuery.StreamRawCypherSearch(request.Context(), payload.Query, payload.Parameters, payload.IncludeProperties, s.cypherStreamRowLimit(request), streamWriter.Write); err != nil {
+		if !streamWriter.started {
+			writeCypherSearchError(response, request, err)
+		} else if request.Context().Err() != nil {
+			log.Infof("Cypher stream stopped after %d rows: %v", numRows, request.Context().Err())
+		} else {
+			_, message := cypherSearchError(err)
+
+			if err := streamWriter.Write(model.CypherStreamRecord{Type: model.CypherStreamRecordError, Error: message}); err != nil {
+				log.Warnf("Failed writing cypher stream error record: %v", err)
+			} else if err := streamWriter.Flush(); err != nil {
+				log.Warnf("Failed flushing cypher stream: %v", err)
+			}
+		}
+	} else if err := streamWriter.Write(model.CypherStreamRecord{Type: model.CypherStreamRecordEnd, Summary: &model.CypherStreamSummary{Rows: numRows}}); err != nil {
+		log.Warnf("Failed writing cypher stream end record: %v", err)
+	} else if err := streamWriter.Flush(); err != nil {
+		log.Warnf("Failed flushing cypher stream: %v", err)
+	}
+}
+
+// CypherExplain describes how a cypher query would be run without running it
+func (s Resources) CypherExplain(response http.ResponseWriter, request *http.Request) {
+	var payload CypherSearch
+
+	if err := api.ReadJSONRequestPayloadLimited(&payload, request); err != nil {
+		api.WriteErrorResponse(
+			request.Context(),
+			api.BuildErrorResponse(http.StatusBadRequest, "JSON malformed.", request), response,
+		)
+	} else if s.resolveSavedQuery(response, request, &payload) {
+		if explanation, err := s.GraphQuery.ExplainCypherQuery(request.Context(), payload.Query, payload.Parameters); err != nil {
+			writeCypherSearchError(response, request, err)
+		} else {
+			api.WriteBasicResponse(request.Context(), explanation, http.StatusOK, response)
+		}
+	}
+}
+
+type CypherCompletionRequest struct {
+	Query    string `json:"query"`
+	Position *int   `json:"position,omitempty"`
+}
+
+// CypherComplete lists the symbols that may be written at a position of a cypher query. The position is a character
+// offset into the query and defaults to the end of the query.
+func (s Resources) CypherComplete(response http.ResponseWriter, request *http.Request) {
+	var payload CypherCompletionRequest
+
+	if err := api.ReadJSONRequestPayloadLimited(&payload, request); err != nil {
+		api.WriteErrorResponse(
+			request.Context(),
+			api.BuildErrorResponse(http.StatusBadRequest, "JSON malformed.", request), response,
+		)
+	} else {
+		position := utf8.RuneCountInString(payload.Query)
+
+		if payload.Position != nil {
+			position = *payload.Position
+		}
+
+		if completion, err := s.GraphQuery.CompleteCypherQuery(request.Context(), payload.Query, position); err != nil {
+			writeCypherSearchError(response, request, err)
 		} else {
+			api.WriteBasicResponse(request.Context(), completion, http.StatusOK, response)
+		}
+	}
+}
+
+// CypherValidate returns the syntax errors in a cypher query along with warnings for constructs that are likely
+// mistakes. The query is not run.
+func (s Resources) CypherValidate(response http.ResponseWriter, request *http.Request) {
+	var payload CypherSearch
+
+	if err := api.ReadJSONRequestPayloadLimited(&payload, request); err != nil {
+		api.WriteErrorResponse(
+			request.Context(),
+			api.BuildErrorResponse(http.StatusBadRequest, "JSON malformed.", request), response,
+		)
+	} else if validation, err := s.GraphQuery.ValidateCypherQuery(request.Context(), payload.Query); err != nil {
+		writeCypherSearchError(response, request, err)
+	} else {
+		api.WriteBasicResponse(request.Context(), validation, http.StatusOK, response)
+	}
+}
+
+type CypherMutationRequest struct {
+	Query      string         `json:"query"`
+	Parameters map[string]any `json:"parameters,omitempty"`
+	DryRun     bool           `json:"dry_run,omitempty"`
+}
+
+// CypherMutation runs a cypher query with updating clauses and returns a summary of the changes it made. Dry runs
+// report the changes without committing them. Every mutation that is not a dry run is recorded in the audit log along
+// with the changes it made.
+func (s Resources) CypherMutation(response http.ResponseWriter, request *http.Request) {
+	var payload CypherMutationRequest
+
+	if err := api.ReadJSONRequestPayloadLimited(&payload, request); err != nil {
+		api.WriteErrorResponse(
+			request.Context(),
+			api.BuildErrorResponse(http.StatusBadRequest, "JSON malformed.", request), response,
+		)
+	} else if payload.DryRun {
+		if summary, err := s.GraphQuery.RawCypherMutation(request.Context(), payload.Query, payload.Parameters, true); err != nil {
+			writeCypherSearchError(response, request, err)
+		} else {
+			api.WriteBasicResponse(request.Context(), model.CypherMutation{
+				Query:   payload.Query,
+				DryRun:  true,
+				Summary: summary,
+			}, http.StatusOK, response)
+		}
+	} else if commitID, err := uuid.NewV4(); err != nil {
+		api.WriteErrorResponse(
+			request.Context(),
+			api.BuildErrorResponse(http.StatusInternalServerError, fmt.Sprintf("failure generating uuid: %v", err.Error()), request),
+			response,
+		)
+	} else {
+		var (
+			mutation = model.CypherMutation{
+				Query: payload.Query,
+			}
+			auditEntry = model.AuditEntry{
+				Action:   model.AuditLogActionMutateGraph,
+				Model:    &mutation, // Pointer is required to ensure the success log contains the summary of the mutation
+				Status:   model.AuditLogStatusIntent,
+				CommitID: commitID,
+			}
+		)
+
+		if err := s.DB.AppendAuditLog(request.Context(), auditEntry); err != nil {
 			api.WriteErrorResponse(
 				request.Context(),
-				api.BuildErrorResponse(http.StatusInternalServerError, err.Error(), request), response,
+				api.BuildErrorResponse(http.StatusInternalServerError, "failure creating an intent audit log", request),
+				response,
 			)
+		} else if mutation.Summary, err = s.GraphQuery.RawCypherMutation(request.Context(), payload.Query, payload.Parameters, false); err != nil {
+			auditEntry.Status = model.AuditLogStatusFailure
+			auditEntry.ErrorMsg = err.Error()
+
+			if err := s.DB.AppendAuditLog(request.Context(), auditEntry); err != nil {
+				log.Errorf("%s: %s", "error writing to audit log", err.Error())
+			}
+
+			writeCypherSearchError(response, request, err)
+		} else {
+			auditEntry.Status = model.AuditLogStatusSuccess
+
+			if err := s.DB.AppendAuditLog(request.Context(), auditEntry); err != nil {
+				log.Errorf("%s: %s", "error writing to audit log", err.Error())
+			}
+
+			api.WriteBasicResponse(request.Context(), mutation, http.StatusOK, response)
 		}
+	}
+}
+
+// cypherSearchError returns the response status and message for an error raised by a cypher query
+func cypherSearchError(err error) (int, string) {
+	if queries.IsQueryError(err) {
+		return http.StatusBadRequest, err.Error()
+	} else if util.IsNeoTimeoutError(err) {
+		return http.StatusInternalServerError, "transaction timed out, reduce query complexity or try again later"
 	} else {
-		api.WriteBasicResponse(request.Context(), graphResponse, http.StatusOK, response)
+		return http.StatusInternalServerError, err.Error()
 	}
+}
+
+func writeCypherSearchError(response http.ResponseWriter, request *http.Request, err error) {
+	statusCode, message := cypherSearchError(err)
 
+	api.WriteErrorResponse(
+		request.Context(),
+		api.BuildErrorResponse(statusCode, message, request), response,
+	)
 }
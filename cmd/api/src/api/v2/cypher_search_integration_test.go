@@ -26,6 +26,7 @@ import (
 	"github.com/specterops/bloodhound/graphschema/common"
 	"github.com/specterops/bloodhound/lab"
 	v2 "github.com/specterops/bloodhound/src/api/v2"
+	"github.com/specterops/bloodhound/src/model"
 	"github.com/specterops/bloodhound/src/test/lab/fixtures"
 	"github.com/specterops/bloodhound/src/test/lab/harnesses"
 	"github.com/stretchr/testify/require"
@@ -83,5 +84,133 @@ func Test_CypherSearch(t *testing.T) {
 			actualComputerId := graphResponse.Nodes[expectedComputer.ID.String()].ObjectId
 			assert.Equal(expectedComputerId, actualComputerId)
 		}),
+		lab.TestCase("successfully runs cypher query with an optional match", func(assert *require.Assertions, harness *lab.Harness) {
+			apiClient, ok := lab.Unpack(harness, fixtures.BHAdminApiClientFixture)
+			assert.True(ok)
+
+			computerQuery := "match (n:Computer) where n.objectid = '" + fixtures.BasicComputerSID.String() + "' optional match (n)-[:HasSession]->(u:User)"
+
+			// Optional matches that find nothing must not filter the rows of the match that precedes them
+			graphResponse, err := apiClient.CypherSearch(v2.CypherSearch{
+				Query: computerQuery + " return n",
+			})
+			assert.NoError(err)
+			assert.Equal(1, len(graphResponse.Nodes))
+
+			// Optional matches that find nothing bind null values which are left out of the graph response
+			graphResponse, err = apiClient.CypherSearch(v2.CypherSearch{
+				Query: computerQuery + " return u",
+			})
+			assert.NoError(err)
+			assert.Equal(0, len(graphResponse.Nodes))
+			assert.Equal(0, len(graphResponse.Edges))
+		}),
+		lab.TestCase("successfully runs cypher query with a table result format", func(assert *require.Assertions, harness *lab.Harness) {
+			apiClient, ok := lab.Unpack(harness, fixtures.BHAdminApiClientFixture)
+			assert.True(ok)
+
+			table, count, err := apiClient.CypherTableSearch(v2.CypherSearch{
+				Query: "match (n:Computer) where n.objectid = '" + fixtures.BasicComputerSID.String() + "' return n, n.objectid as objectid, count(n) as total",
+			}, 0, 10)
+			assert.NoError(err)
+			assert.Equal(1, count)
+			assert.Equal([]model.CypherColumn{{
+				Name: "n",
+				Type: model.CypherColumnTypeNode,
+			}, {
+				Name: "objectid",
+				Type: model.CypherColumnTypeString,
+			}, {
+				Name: "total",
+				Type: model.CypherColumnTypeNumber,
+			}}, table.Columns)
+			assert.Equal(1, len(table.Rows))
+			assert.Equal(fixtures.BasicComputerSID.String(), table.Rows[0][1])
+		}),
+		lab.TestCase("successfully streams cypher query results", func(assert *require.Assertions, harness *lab.Harness) {
+			apiClient, ok := lab.Unpack(harness, fixtures.BHAdminApiClientFixture)
+			assert.True(ok)
+
+			var records []model.CypherStreamRecord
+
+			err := apiClient.CypherStreamSearch(v2.CypherSearch{
+				Query: "match (n:Computer) where n.objectid = '" + fixtures.BasicComputerSID.String() + "' return n, n.objectid as objectid",
+			}, func(record model.CypherStreamRecord) error {
+				records = append(records, record)
+				return nil
+			})
+			assert.NoError(err)
+			assert.Equal(3, len(records))
+			assert.Equal([]string{"n", "objectid"}, records[0].Columns)
+			assert.Equal(model.CypherStreamRecordRow, records[1].Type)
+			assert.Equal(fixtures.BasicComputerSID.String(), records[1].Row[1])
+			assert.Equal(model.CypherStreamRecordEnd, records[2].Type)
+			assert.Equal(1, records[2].Summary.Rows)
+		}),
+		lab.TestCase("successfully runs cypher query with parameters", func(assert *require.Assertions, harness *lab.Harness) {
+			apiClient, ok := lab.Unpack(harness, fixtures.BHAdminApiClientFixture)
+			assert.True(ok)
+
+			graphResponse, err := apiClient.CypherSearch(v2.CypherSearch{
+				Query: "match (n:Computer) where n.objectid in $objectids return n",
+				Parameters: map[string]any{
+					"objectids": []string{fixtures.BasicComputerSID.String()},
+				},
+			})
+			assert.NoError(err)
+			assert.Equal(1, len(graphResponse.Nodes))
+
+			_, err = apiClient.CypherSearch(v2.CypherSearch{
+				Query: "match (n:Computer) where n.objectid = $objectid return n",
+			})
+			assert.ErrorContains(err, "no value supplied for query parameter $objectid")
+		}),
+		lab.TestCase("successfully explains cypher query", func(assert *require.Assertions, harness *lab.Harness) {
+			apiClient, ok := lab.Unpack(harness, fixtures.BHAdminApiClientFixture)
+			assert.True(ok)
+
+			explanation, err := apiClient.CypherExplain(v2.CypherSearch{
+				Query: "match (n:Computer) where n.objectid = $objectid return n",
+				Parameters: map[string]any{
+					"objectid": fixtures.BasicComputerSID.String(),
+				},
+			})
+			assert.NoError(err)
+			assert.NotEmpty(explanation.Statement)
+			assert.NotNil(explanation.Plan)
+			assert.Equal([]string{"match", "return"}, explanation.Summary.Clauses)
+			assert.Equal([]string{"Computer"}, explanation.Summary.Kinds)
+			assert.False(explanation.Complexity.TooComplex)
+			assert.Greater(explanation.TimeoutSeconds, float64(0))
+		}),
+		lab.TestCase("successfully completes cypher query", func(assert *require.Assertions, harness *lab.Harness) {
+			apiClient, ok := lab.Unpack(harness, fixtures.BHAdminApiClientFixture)
+			assert.True(ok)
+
+			completion, err := apiClient.CypherComplete(v2.CypherCompletionRequest{
+				Query: "match (n:Comput",
+			})
+			assert.NoError(err)
+			assert.Equal("node_kind", completion.Target)
+			assert.Contains(completion.Candidates, model.CypherCompletionCandidate{Value: "Computer", Type: model.CypherCompletionTypeNodeKind})
+		}),
+		lab.TestCase("successfully validates cypher query", func(assert *require.Assertions, harness *lab.Harness) {
+			apiClient, ok := lab.Unpack(harness, fixtures.BHAdminApiClientFixture)
+			assert.True(ok)
+
+			validation, err := apiClient.CypherValidate(v2.CypherSearch{
+				Query: "match (n:Computer) where n.objectid = $objectid return n",
+			})
+			assert.NoError(err)
+			assert.True(validation.Valid)
+			assert.Empty(validation.Warnings)
+
+			validation, err = apiClient.CypherValidate(v2.CypherSearch{
+				Query: "my syntax stinks",
+			})
+			assert.NoError(err)
+			assert.False(validation.Valid)
+			assert.Equal(1, validation.Errors[0].Line)
+		}),
 	)
 }
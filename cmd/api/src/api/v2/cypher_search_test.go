@@ -0,0 +1,452 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v2_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/specterops/bloodhound/headers"
+	"github.com/specterops/bloodhound/mediatypes"
+	v2 "github.com/specterops/bloodhound/src/api/v2"
+	"github.com/specterops/bloodhound/src/api/v2/apitest"
+	"github.com/specterops/bloodhound/src/auth"
+	"github.com/specterops/bloodhound/src/config"
+	"github.com/specterops/bloodhound/src/database"
+	"github.com/specterops/bloodhound/src/database/mocks"
+	"github.com/specterops/bloodhound/src/model"
+	"github.com/specterops/bloodhound/src/queries"
+	queriesMocks "github.com/specterops/bloodhound/src/queries/mocks"
+	"github.com/specterops/bloodhound/src/test/must"
+	"go.uber.org/mock/gomock"
+)
+
+func TestResources_CypherSearch_NDJSON(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockGraph = queriesMocks.NewMockGraph(mockCtrl)
+		resources = v2.Resources{
+			GraphQuery: mockGraph,
+			Config: config.Configuration{
+				CypherStreamRowLimits: map[string]int{
+					auth.RoleReadOnly: 10,
+					auth.RoleUser:     100,
+				},
+			},
+		}
+		query   = "match (n) return n"
+		payload = v2.CypherSearch{Query: query, ResultFormat: v2.CypherResultFormatNDJSON}
+		user    = model.User{
+			Roles: model.Roles{{Name: auth.RoleReadOnly}, {Name: auth.RoleUser}},
+		}
+		node = model.CypherTableNode{ID: "1", UnifiedNode: model.UnifiedNode{Label: "USER@TESTLAB.LOCAL", Kind: "User"}}
+	)
+	defer mockCtrl.Finish()
+
+	apitest.NewHarness(t, resources.CypherSearch).
+		WithCommonRequest(func(input *apitest.Input) {
+			apitest.SetHeader(input, headers.ContentType.String(), mediatypes.ApplicationJson.String())
+			apitest.SetContext(input, setupUserCtx(user))
+			apitest.BodyStruct(input, payload)
+		}).
+		Run([]apitest.Case{
+			{
+				Name: "UnknownResultFormat",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.CypherSearch{Query: query, ResultFormat: "csv"})
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "result_format must be one of: graph, table, ndjson")
+				},
+			},
+			{
+				Name: "ErrorBeforeFirstRecord",
+				Setup: func() {
+					mockGraph.EXPECT().StreamRawCypherSearch(gomock.Any(), query, gomock.Nil(), false, 100, gomock.Any()).Return(0, queries.ErrCypherQueryToComplex)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusInternalServerError)
+					apitest.BodyContains(output, queries.ErrCypherQueryToComplex.Error())
+				},
+			},
+			{
+				Name: "ErrorAfterFirstRecord",
+				Setup: func() {
+					mockGraph.EXPECT().StreamRawCypherSearch(gomock.Any(), query, gomock.Nil(), false, 100, gomock.Any()).DoAndReturn(
+						func(_ context.Context, _ string, _ map[string]any, _ bool, _ int, emit queries.CypherStreamFunc) (int, error) {
+							if err := emit(model.CypherStreamRecord{Type: model.CypherStreamRecordColumns, Columns: []string{"n"}}); err != nil {
+								return 0, err
+							}
+
+							return 0, errors.New("graph error")
+						})
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.BodyContains(output, `{"type":"columns","columns":["n"]}`+"\n")
+					apitest.BodyContains(output, `{"type":"error","error":"graph error"}`+"\n")
+				},
+			},
+			{
+				Name: "Success",
+				Setup: func() {
+					mockGraph.EXPECT().StreamRawCypherSearch(gomock.Any(), query, gomock.Nil(), false, 100, gomock.Any()).DoAndReturn(
+						func(_ context.Context, _ string, _ map[string]any, _ bool, _ int, emit queries.CypherStreamFunc) (int, error) {
+							if err := emit(model.CypherStreamRecord{Type: model.CypherStreamRecordColumns, Columns: []string{"n"}}); err != nil {
+								return 0, err
+							} else if err := emit(model.CypherStreamRecord{Type: model.CypherStreamRecordNode, Node: &node}); err != nil {
+								return 0, err
+							}
+
+							return 1, nil
+						})
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.BodyContains(output, `{"type":"node","node":{"id":"1","label":"USER@TESTLAB.LOCAL","kind":"User"`)
+					apitest.BodyContains(output, `{"type":"end","summary":{"rows":1}}`+"\n")
+					apitest.BodyNotContains(output, `"type":"error"`)
+				},
+			},
+		})
+}
+
+func TestResources_CypherSearch_SavedQuery(t *testing.T) {
+	var (
+		mockCtrl   = gomock.NewController(t)
+		mockDB     = mocks.NewMockDatabase(mockCtrl)
+		mockGraph  = queriesMocks.NewMockGraph(mockCtrl)
+		resources  = v2.Resources{DB: mockDB, GraphQuery: mockGraph}
+		userID     = must.NewUUIDv4()
+		query      = "match (n) where n.name = $name and n.weight > $weight return n"
+		savedQuery = model.SavedQuery{
+			UserID: userID.String(),
+			Name:   "weighted",
+			Query:  query,
+			Parameters: model.SavedQueryParameters{
+				{Name: "name", Type: model.CypherParameterTypeString},
+				{Name: "weight", Type: model.CypherParameterTypeFloat, Default: float64(1)},
+			},
+			BigSerial: model.BigSerial{ID: 1},
+		}
+	)
+	defer mockCtrl.Finish()
+
+	apitest.NewHarness(t, resources.CypherSearch).
+		WithCommonRequest(func(input *apitest.Input) {
+			apitest.SetHeader(input, headers.ContentType.String(), mediatypes.ApplicationJson.String())
+			apitest.SetContext(input, createContextWithOwnerId(userID))
+		}).
+		Run([]apitest.Case{
+			{
+				Name: "QueryAndSavedQuery",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.CypherSearch{Query: query, SavedQueryID: 1})
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "query and saved_query_id may not both be set")
+				},
+			},
+			{
+				Name: "SavedQueryNotFound",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.CypherSearch{SavedQueryID: 2})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetSavedQuery(gomock.Any(), userID, int64(2)).Return(model.SavedQuery{}, database.ErrNotFound)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusNotFound)
+				},
+			},
+			{
+				Name: "MissingParameter",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.CypherSearch{SavedQueryID: 1})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetSavedQuery(gomock.Any(), userID, int64(1)).Return(savedQuery, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "no value supplied for parameter name")
+				},
+			},
+			{
+				Name: "UndeclaredParameter",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.CypherSearch{SavedQueryID: 1, Parameters: map[string]any{"name": "lol", "limit": 10}})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetSavedQuery(gomock.Any(), userID, int64(1)).Return(savedQuery, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "parameter limit is not declared by the saved query")
+				},
+			},
+			{
+				Name: "WrongParameterType",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.CypherSearch{SavedQueryID: 1, Parameters: map[string]any{"name": "lol", "weight": "heavy"}})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetSavedQuery(gomock.Any(), userID, int64(1)).Return(savedQuery, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "invalid value for parameter weight")
+				},
+			},
+			{
+				Name: "Success",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.CypherSearch{SavedQueryID: 1, Parameters: map[string]any{"name": "lol"}})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetSavedQuery(gomock.Any(), userID, int64(1)).Return(savedQuery, nil)
+					mockGraph.EXPECT().RawCypherSearch(gomock.Any(), query, map[string]any{
+						"name":   model.TypedCypherParameterValue{Type: model.CypherParameterTypeString, Value: "lol"},
+						"weight": model.TypedCypherParameterValue{Type: model.CypherParameterTypeFloat, Value: float64(1)},
+					}, false).Return(model.NewUnifiedGraph(), nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+				},
+			},
+		})
+}
+
+func TestResources_CypherMutation(t *testing.T) {
+	var (
+		mockCtrl   = gomock.NewController(t)
+		mockDB     = mocks.NewMockDatabase(mockCtrl)
+		mockGraph  = queriesMocks.NewMockGraph(mockCtrl)
+		resources  = v2.Resources{DB: mockDB, GraphQuery: mockGraph}
+		query      = "match (n:User) set n.owned = true"
+		summary    = model.CypherMutationSummary{PropertiesSet: 3}
+		intentLog  = model.AuditEntry{Action: model.AuditLogActionMutateGraph, Model: &model.CypherMutation{Query: query}, Status: model.AuditLogStatusIntent}
+		successLog = model.AuditEntry{Action: model.AuditLogActionMutateGraph, Model: &model.CypherMutation{Query: query, Summary: summary}, Status: model.AuditLogStatusSuccess}
+		failureLog = model.AuditEntry{Action: model.AuditLogActionMutateGraph, Model: &model.CypherMutation{Query: query}, Status: model.AuditLogStatusFailure, ErrorMsg: "graph error"}
+	)
+	defer mockCtrl.Finish()
+
+	apitest.NewHarness(t, resources.CypherMutation).
+		WithCommonRequest(func(input *apitest.Input) {
+			apitest.SetHeader(input, headers.ContentType.String(), mediatypes.ApplicationJson.String())
+		}).
+		Run([]apitest.Case{
+			{
+				Name: "InvalidPayload",
+				Input: func(input *apitest.Input) {
+					apitest.BodyString(input, "not json")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "JSON malformed.")
+				},
+			},
+			{
+				Name: "DryRun",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.CypherMutationRequest{Query: query, DryRun: true})
+				},
+				Setup: func() {
+					mockGraph.EXPECT().RawCypherMutation(gomock.Any(), query, gomock.Nil(), true).Return(summary, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.BodyContains(output, `"dry_run":true`)
+					apitest.BodyContains(output, `"properties_set":3`)
+				},
+			},
+			{
+				Name: "IntentAuditLogError",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.CypherMutationRequest{Query: query})
+				},
+				Setup: func() {
+					mockDB.EXPECT().AppendAuditLog(gomock.Any(), intentLog).Return(errors.New("database error"))
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusInternalServerError)
+				},
+			},
+			{
+				Name: "MutationError",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.CypherMutationRequest{Query: query})
+				},
+				Setup: func() {
+					gomock.InOrder(
+						mockDB.EXPECT().AppendAuditLog(gomock.Any(), intentLog).Return(nil),
+						mockGraph.EXPECT().RawCypherMutation(gomock.Any(), query, gomock.Nil(), false).Return(model.CypherMutationSummary{}, errors.New("graph error")),
+						mockDB.EXPECT().AppendAuditLog(gomock.Any(), failureLog).Return(nil),
+					)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusInternalServerError)
+				},
+			},
+			{
+				Name: "Success",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.CypherMutationRequest{Query: query})
+				},
+				Setup: func() {
+					gomock.InOrder(
+						mockDB.EXPECT().AppendAuditLog(gomock.Any(), intentLog).Return(nil),
+						mockGraph.EXPECT().RawCypherMutation(gomock.Any(), query, gomock.Nil(), false).Return(summary, nil),
+						mockDB.EXPECT().AppendAuditLog(gomock.Any(), successLog).Return(nil),
+					)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.BodyContains(output, `"dry_run":false`)
+					apitest.BodyContains(output, `"properties_set":3`)
+				},
+			},
+		})
+}
+
+func TestResources_CypherComplete(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockGraph = queriesMocks.NewMockGraph(mockCtrl)
+		resources = v2.Resources{GraphQuery: mockGraph}
+		position  = 3
+	)
+	defer mockCtrl.Finish()
+
+	apitest.NewHarness(t, resources.CypherComplete).
+		WithCommonRequest(func(input *apitest.Input) {
+			apitest.SetHeader(input, headers.ContentType.String(), mediatypes.ApplicationJson.String())
+		}).
+		Run([]apitest.Case{
+			{
+				Name: "InvalidPayload",
+				Input: func(input *apitest.Input) {
+					apitest.BodyString(input, "not json")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+				},
+			},
+			{
+				Name: "DefaultPosition",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.CypherCompletionRequest{Query: "match (n:Üs"})
+				},
+				Setup: func() {
+					mockGraph.EXPECT().CompleteCypherQuery(gomock.Any(), "match (n:Üs", 11).Return(model.CypherCompletion{
+						Target:      "node_kind",
+						Prefix:      "Üs",
+						ReplaceFrom: 9,
+						Candidates:  []model.CypherCompletionCandidate{},
+					}, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.BodyContains(output, `"target":"node_kind"`)
+					apitest.BodyContains(output, `"replace_from":9`)
+				},
+			},
+			{
+				Name: "Position",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.CypherCompletionRequest{Query: "match (n) return n", Position: &position})
+				},
+				Setup: func() {
+					mockGraph.EXPECT().CompleteCypherQuery(gomock.Any(), "match (n) return n", position).Return(model.CypherCompletion{
+						Target: "expression",
+						Prefix: "mat",
+						Candidates: []model.CypherCompletionCandidate{{
+							Value: "MATCH",
+							Type:  model.CypherCompletionTypeKeyword,
+						}},
+					}, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.BodyContains(output, `{"value":"MATCH","type":"keyword"}`)
+				},
+			},
+		})
+}
+
+func TestResources_CypherValidate(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockGraph = queriesMocks.NewMockGraph(mockCtrl)
+		resources = v2.Resources{GraphQuery: mockGraph}
+	)
+	defer mockCtrl.Finish()
+
+	apitest.NewHarness(t, resources.CypherValidate).
+		WithCommonRequest(func(input *apitest.Input) {
+			apitest.SetHeader(input, headers.ContentType.String(), mediatypes.ApplicationJson.String())
+		}).
+		Run([]apitest.Case{
+			{
+				Name: "InvalidPayload",
+				Input: func(input *apitest.Input) {
+					apitest.BodyString(input, "not json")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+				},
+			},
+			{
+				Name: "GraphError",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.CypherSearch{Query: "match (n) where n.name = 'user' return n"})
+				},
+				Setup: func() {
+					mockGraph.EXPECT().ValidateCypherQuery(gomock.Any(), "match (n) where n.name = 'user' return n").Return(model.CypherValidation{}, errors.New("graph error"))
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusInternalServerError)
+				},
+			},
+			{
+				Name: "Success",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.CypherSearch{Query: "match (n:User return n"})
+				},
+				Setup: func() {
+					mockGraph.EXPECT().ValidateCypherQuery(gomock.Any(), "match (n:User return n").Return(model.CypherValidation{
+						Errors: []model.CypherValidationIssue{{
+							Line:    1,
+							Column:  14,
+							Symbol:  "return",
+							Message: "mismatched input",
+						}},
+						Warnings: []model.CypherValidationIssue{},
+					}, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.BodyContains(output, `"valid":false`)
+					apitest.BodyContains(output, `{"line":1,"column":14,"symbol":"return","message":"mismatched input"}`)
+				},
+			},
+		})
+}
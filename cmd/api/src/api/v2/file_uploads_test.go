@@ -25,11 +25,14 @@ import (
 	"testing"
 
 	"github.com/specterops/bloodhound/errors"
+	"github.com/specterops/bloodhound/headers"
+	"github.com/specterops/bloodhound/mediatypes"
 	v2 "github.com/specterops/bloodhound/src/api/v2"
 	"github.com/specterops/bloodhound/src/api/v2/apitest"
 	"github.com/specterops/bloodhound/src/auth"
 	"github.com/specterops/bloodhound/src/ctx"
 	taskerMocks "github.com/specterops/bloodhound/src/daemons/datapipe/mocks"
+	"github.com/specterops/bloodhound/src/database"
 	dbMocks "github.com/specterops/bloodhound/src/database/mocks"
 	"github.com/specterops/bloodhound/src/database/types/null"
 	"github.com/specterops/bloodhound/src/model"
@@ -178,6 +181,19 @@ func TestResources_EndFileUploadJob(t *testing.T) {
 					apitest.StatusCode(output, http.StatusInternalServerError)
 				},
 			},
+			{
+				Name: "JobInOtherWorkspace",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, ctx.WithWorkspace(context.Background(), 2))
+					apitest.SetURLVar(input, v2.FileUploadJobIdPathParameterName, "123")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetFileUploadJob(gomock.Any(), int64(123)).DoAndReturn(getFileUploadJobInWorkspace(1))
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusNotFound)
+				},
+			},
 			{
 				Name: "InvalidJobStatus",
 				Input: func(input *apitest.Input) {
@@ -226,6 +242,61 @@ func TestResources_EndFileUploadJob(t *testing.T) {
 		})
 }
 
+// getFileUploadJobInWorkspace mocks the lookup of a file upload job that belongs to the given workspace. Jobs are not
+// found by requests scoped to any other workspace.
+func getFileUploadJobInWorkspace(workspaceID int32) func(context.Context, int64) (model.FileUploadJob, error) {
+	return func(requestCtx context.Context, id int64) (model.FileUploadJob, error) {
+		if scopedWorkspaceID, isScoped := ctx.WorkspaceID(requestCtx); isScoped && scopedWorkspaceID != workspaceID {
+			return model.FileUploadJob{}, database.ErrNotFound
+		}
+
+		return model.FileUploadJob{
+			BigSerial:   model.BigSerial{ID: id},
+			WorkspaceID: workspaceID,
+			Status:      model.JobStatusRunning,
+		}, nil
+	}
+}
+
+func TestResources_ProcessFileUpload(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockDB    = dbMocks.NewMockDatabase(mockCtrl)
+		resources = v2.Resources{DB: mockDB}
+	)
+	defer mockCtrl.Finish()
+
+	apitest.
+		NewHarness(t, resources.ProcessFileUpload).
+		Run([]apitest.Case{
+			{
+				Name: "InvalidContentType",
+				Input: func(input *apitest.Input) {
+					apitest.SetHeader(input, headers.ContentType.String(), "text/plain")
+					apitest.SetURLVar(input, v2.FileUploadJobIdPathParameterName, "123")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+				},
+			},
+			{
+				Name: "JobInOtherWorkspace",
+				Input: func(input *apitest.Input) {
+					apitest.SetContext(input, ctx.WithWorkspace(context.Background(), 2))
+					apitest.SetHeader(input, headers.ContentType.String(), mediatypes.ApplicationJson.String())
+					apitest.SetURLVar(input, v2.FileUploadJobIdPathParameterName, "123")
+					apitest.BodyString(input, "{}")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetFileUploadJob(gomock.Any(), int64(123)).DoAndReturn(getFileUploadJobInWorkspace(1))
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusNotFound)
+				},
+			},
+		})
+}
+
 func TestResources_ListAcceptedFileUploadTypes(t *testing.T) {
 	bytes, err := json.Marshal(ingest.AllowedFileUploadTypes)
 	if err != nil {
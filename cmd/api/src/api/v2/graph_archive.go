@@ -0,0 +1,76 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v2
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/specterops/bloodhound/dawgs/archive"
+	"github.com/specterops/bloodhound/headers"
+	"github.com/specterops/bloodhound/log"
+	"github.com/specterops/bloodhound/mediatypes"
+	"github.com/specterops/bloodhound/src/api"
+	"github.com/specterops/bloodhound/src/utils"
+)
+
+const (
+	ErrorResponseGraphArchiveContentType = "Content type must be application/gzip or application/octet-stream"
+	ErrorResponseGraphNotEmpty           = "graph archives may only be restored into an empty graph"
+)
+
+// ExportGraph streams a compressed archive of every node and edge in the graph. Since the archive is streamed, errors
+// encountered after the response has started are logged and surface to the client as a truncated archive.
+func (s Resources) ExportGraph(response http.ResponseWriter, request *http.Request) {
+	filename := fmt.Sprintf("bloodhound-graph-%s%s", time.Now().UTC().Format("20060102T150405Z"), archive.FileExtension)
+
+	response.Header().Set(headers.ContentType.String(), mediatypes.ApplicationGzip.String())
+	response.Header().Set(headers.ContentDisposition.String(), fmt.Sprintf(utils.ContentDispositionAttachmentTemplate, filename))
+	response.WriteHeader(http.StatusOK)
+
+	if summary, err := archive.Export(request.Context(), s.Graph, response); err != nil {
+		log.Errorf("Failed exporting graph archive %s: %v", filename, err)
+	} else {
+		log.Infof("Exported graph archive %s with %d nodes and %d edges", filename, summary.Nodes, summary.Edges)
+	}
+}
+
+// ImportGraph restores a graph archive produced by ExportGraph into the graph. The graph must be empty.
+func (s Resources) ImportGraph(response http.ResponseWriter, request *http.Request) {
+	if request.Body != nil {
+		defer request.Body.Close()
+	}
+
+	if !utils.HeaderMatches(request.Header, headers.ContentType.String(), mediatypes.ApplicationGzip.String(), mediatypes.ApplicationOctetStream.String()) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, ErrorResponseGraphArchiveContentType, request), response)
+	} else if summary, err := archive.Import(request.Context(), s.Graph, request.Body); errors.Is(err, archive.ErrGraphNotEmpty) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusConflict, ErrorResponseGraphNotEmpty, request), response)
+	} else if errors.Is(err, archive.ErrInvalidArchive) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+	} else if err != nil {
+		log.Errorf("Failed importing graph archive: %v", err)
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, api.ErrorResponseDetailsInternalServerError, request), response)
+	} else {
+		if err := s.Cache.Reset(); err != nil {
+			log.Errorf("Error while resetting the cache after a graph import: %v", err)
+		}
+
+		api.WriteBasicResponse(request.Context(), summary, http.StatusOK, response)
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v2_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/specterops/bloodhound/cache"
+	"github.com/specterops/bloodhound/dawgs"
+	"github.com/specterops/bloodhound/dawgs/drivers/memory"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/headers"
+	"github.com/specterops/bloodhound/mediatypes"
+	v2 "github.com/specterops/bloodhound/src/api/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func newArchiveResources(t *testing.T) v2.Resources {
+	graphDB, err := dawgs.Open(context.Background(), memory.DriverName, dawgs.Config{})
+	require.Nil(t, err)
+
+	apiCache, err := cache.NewCache(cache.Config{MaxSize: 10})
+	require.Nil(t, err)
+
+	return v2.Resources{
+		Graph: graphDB,
+		Cache: apiCache,
+	}
+}
+
+func TestResources_ExportImportGraph(t *testing.T) {
+	var (
+		source = newArchiveResources(t)
+		target = newArchiveResources(t)
+	)
+
+	require.Nil(t, source.Graph.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		if user, err := tx.CreateNode(graph.AsProperties(map[string]any{"name": "alice"}), graph.StringKind("User")); err != nil {
+			return err
+		} else if group, err := tx.CreateNode(graph.AsProperties(map[string]any{"name": "admins"}), graph.StringKind("Group")); err != nil {
+			return err
+		} else {
+			_, err := tx.CreateRelationshipByIDs(user.ID, group.ID, graph.StringKind("MemberOf"), graph.NewProperties())
+			return err
+		}
+	}))
+
+	exportRequest, err := http.NewRequest(http.MethodGet, "/api/v2/graphs/export", nil)
+	require.Nil(t, err)
+
+	exportResponse := httptest.NewRecorder()
+	http.HandlerFunc(source.ExportGraph).ServeHTTP(exportResponse, exportRequest)
+
+	require.Equal(t, http.StatusOK, exportResponse.Code)
+	require.Equal(t, mediatypes.ApplicationGzip.String(), exportResponse.Header().Get(headers.ContentType.String()))
+	require.Contains(t, exportResponse.Header().Get(headers.ContentDisposition.String()), ".ndjson.gz")
+
+	archiveContent := exportResponse.Body.Bytes()
+
+	t.Run("rejects unexpected content types", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodPost, "/api/v2/graphs/import", bytes.NewReader(archiveContent))
+		require.Nil(t, err)
+		request.Header.Set(headers.ContentType.String(), mediatypes.ApplicationJson.String())
+
+		response := httptest.NewRecorder()
+		http.HandlerFunc(target.ImportGraph).ServeHTTP(response, request)
+
+		require.Equal(t, http.StatusBadRequest, response.Code)
+	})
+
+	t.Run("rejects invalid archives", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodPost, "/api/v2/graphs/import", bytes.NewReader([]byte("not an archive")))
+		require.Nil(t, err)
+		request.Header.Set(headers.ContentType.String(), mediatypes.ApplicationGzip.String())
+
+		response := httptest.NewRecorder()
+		http.HandlerFunc(target.ImportGraph).ServeHTTP(response, request)
+
+		require.Equal(t, http.StatusBadRequest, response.Code)
+	})
+
+	t.Run("restores into an empty graph", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodPost, "/api/v2/graphs/import", bytes.NewReader(archiveContent))
+		require.Nil(t, err)
+		request.Header.Set(headers.ContentType.String(), mediatypes.ApplicationGzip.String())
+
+		response := httptest.NewRecorder()
+		http.HandlerFunc(target.ImportGraph).ServeHTTP(response, request)
+
+		require.Equal(t, http.StatusOK, response.Code)
+		require.JSONEq(t, `{"data":{"nodes":2,"edges":1}}`, response.Body.String())
+	})
+
+	t.Run("refuses to restore into a populated graph", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodPost, "/api/v2/graphs/import", bytes.NewReader(archiveContent))
+		require.Nil(t, err)
+		request.Header.Set(headers.ContentType.String(), mediatypes.ApplicationOctetStream.String())
+
+		response := httptest.NewRecorder()
+		http.HandlerFunc(target.ImportGraph).ServeHTTP(response, request)
+
+		require.Equal(t, http.StatusConflict, response.Code)
+	})
+}
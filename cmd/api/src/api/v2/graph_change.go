@@ -0,0 +1,78 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v2
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/specterops/bloodhound/errors"
+	"github.com/specterops/bloodhound/src/api"
+	"github.com/specterops/bloodhound/src/model"
+)
+
+const (
+	graphChangesAfterIDQueryParameterName = "after_id"
+	graphChangesDefaultLimit              = 100
+	graphChangesMaxLimit                  = 1000
+
+	errGraphChangesLimitTooLarge = errors.Error("limit may not exceed 1000")
+)
+
+type GraphChangesResponse struct {
+	Events model.GraphChangeEvents `json:"events"`
+	LastID int64                   `json:"last_id"`
+}
+
+// ListGraphChanges reads events from the graph change outbox in commit order. Consumers resume reading by passing the
+// last_id of the previous response as after_id. The outbox is only written to when the graph change feed is enabled.
+func (s Resources) ListGraphChanges(response http.ResponseWriter, request *http.Request) {
+	var (
+		queryParams = request.URL.Query()
+		afterID     int64
+	)
+
+	if rawAfterID := queryParams.Get(graphChangesAfterIDQueryParameterName); rawAfterID != "" {
+		if parsedAfterID, err := strconv.ParseInt(rawAfterID, 10, 64); err != nil {
+			api.WriteErrorResponse(request.Context(), ErrBadQueryParameter(request, graphChangesAfterIDQueryParameterName, err), response)
+			return
+		} else {
+			afterID = parsedAfterID
+		}
+	}
+
+	if limit, err := ParseLimitQueryParameter(queryParams, graphChangesDefaultLimit); err != nil {
+		api.WriteErrorResponse(request.Context(), ErrBadQueryParameter(request, model.PaginationQueryParameterLimit, err), response)
+	} else if limit > graphChangesMaxLimit {
+		api.WriteErrorResponse(request.Context(), ErrBadQueryParameter(request, model.PaginationQueryParameterLimit, errGraphChangesLimitTooLarge), response)
+	} else if events, err := s.DB.ListGraphChangeEvents(request.Context(), afterID, limit); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else {
+		result := GraphChangesResponse{
+			Events: events,
+			LastID: afterID,
+		}
+
+		if result.Events == nil {
+			result.Events = model.GraphChangeEvents{}
+		} else if len(events) > 0 {
+			result.LastID = events[len(events)-1].ID
+		}
+
+		api.WriteBasicResponse(request.Context(), result, http.StatusOK, response)
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v2_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/specterops/bloodhound/dawgs/changefeed"
+	v2 "github.com/specterops/bloodhound/src/api/v2"
+	"github.com/specterops/bloodhound/src/api/v2/apitest"
+	"github.com/specterops/bloodhound/src/database/mocks"
+	"github.com/specterops/bloodhound/src/model"
+	"go.uber.org/mock/gomock"
+)
+
+func TestResources_ListGraphChanges(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockDB    = mocks.NewMockDatabase(mockCtrl)
+		resources = v2.Resources{DB: mockDB}
+	)
+	defer mockCtrl.Finish()
+
+	apitest.NewHarness(t, resources.ListGraphChanges).
+		Run([]apitest.Case{
+			{
+				Name: "InvalidAfterID",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "after_id", "abc")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+				},
+			},
+			{
+				Name: "LimitTooLarge",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "limit", "1001")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+				},
+			},
+			{
+				Name: "DatabaseError",
+				Setup: func() {
+					mockDB.EXPECT().ListGraphChangeEvents(gomock.Any(), int64(0), 100).Return(nil, errors.New("database error"))
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusInternalServerError)
+				},
+			},
+			{
+				Name: "NoEvents",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "after_id", "7")
+				},
+				Setup: func() {
+					mockDB.EXPECT().ListGraphChangeEvents(gomock.Any(), int64(7), 100).Return(nil, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.BodyContains(output, `"events":[],"last_id":7`)
+				},
+			},
+			{
+				Name: "Success",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "after_id", "7")
+					apitest.AddQueryParam(input, "limit", "2")
+				},
+				Setup: func() {
+					mockDB.EXPECT().ListGraphChangeEvents(gomock.Any(), int64(7), 2).Return(model.GraphChangeEvents{{
+						Changes: model.GraphChanges{{
+							Entity:       changefeed.EntityNode,
+							Operation:    changefeed.OperationUpdate,
+							ID:           12,
+							Kinds:        []string{"User"},
+							PropertyKeys: []string{"enabled"},
+						}},
+						CommittedAt: time.Now(),
+						BigSerial:   model.BigSerial{ID: 8},
+					}}, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.BodyContains(output, `{"entity":"node","operation":"update","id":12,"kinds":["User"],"property_keys":["enabled"]}`)
+					apitest.BodyContains(output, `"last_id":8`)
+				},
+			},
+		})
+}
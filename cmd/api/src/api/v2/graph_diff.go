@@ -0,0 +1,197 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v2
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/specterops/bloodhound/analysis/snapshot"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/errors"
+	"github.com/specterops/bloodhound/graphschema/common"
+	"github.com/specterops/bloodhound/src/api"
+	"github.com/specterops/bloodhound/src/model"
+)
+
+const (
+	graphDiffFromQueryParameterName       = "from"
+	graphDiffToQueryParameterName         = "to"
+	graphDiffReturnTypeQueryParameterName = "type"
+	graphDiffReturnTypeDelta              = "delta"
+	graphDiffReturnTypeGraph              = "graph"
+
+	// GraphDiffChangeProperty is set on every node and edge of a rendered graph diff to one of the change values below
+	GraphDiffChangeProperty = "diff_change"
+	// GraphDiffAttackPathProperty is set on rendered edges that are new attack paths into Tier Zero
+	GraphDiffAttackPathProperty = "diff_tier_zero_attack_path"
+
+	GraphDiffChangeAdded     = "added"
+	GraphDiffChangeRemoved   = "removed"
+	GraphDiffChangeUnchanged = "unchanged"
+
+	errBadGraphDiffReturnType = errors.Error("invalid return type requested for graph diff")
+)
+
+// GetGraphDiff compares the graph snapshots recorded by two analysis runs. The delta is returned as-is by default or
+// rendered as a unified graph of the changed nodes and edges when type=graph is requested.
+func (s Resources) GetGraphDiff(response http.ResponseWriter, request *http.Request) {
+	var (
+		queryParams = request.URL.Query()
+		returnType  = queryParams.Get(graphDiffReturnTypeQueryParameterName)
+	)
+
+	if returnType == "" {
+		returnType = graphDiffReturnTypeDelta
+	}
+
+	if returnType != graphDiffReturnTypeDelta && returnType != graphDiffReturnTypeGraph {
+		api.WriteErrorResponse(request.Context(), ErrBadQueryParameter(request, graphDiffReturnTypeQueryParameterName, errBadGraphDiffReturnType), response)
+	} else if fromRunID, err := strconv.ParseInt(queryParams.Get(graphDiffFromQueryParameterName), 10, 64); err != nil {
+		api.WriteErrorResponse(request.Context(), ErrBadQueryParameter(request, graphDiffFromQueryParameterName, err), response)
+	} else if toRunID, err := strconv.ParseInt(queryParams.Get(graphDiffToQueryParameterName), 10, 64); err != nil {
+		api.WriteErrorResponse(request.Context(), ErrBadQueryParameter(request, graphDiffToQueryParameterName, err), response)
+	} else if fromSnapshot, err := s.DB.GetGraphSnapshotByAnalysisRunID(request.Context(), fromRunID); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if toSnapshot, err := s.DB.GetGraphSnapshotByAnalysisRunID(request.Context(), toRunID); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if from, err := snapshot.Decode(fromSnapshot.Content); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, api.ErrorResponseDetailsInternalServerError, request), response)
+	} else if to, err := snapshot.Decode(toSnapshot.Content); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, api.ErrorResponseDetailsInternalServerError, request), response)
+	} else if delta := snapshot.Diff(from, to); returnType == graphDiffReturnTypeGraph {
+		api.WriteBasicResponse(request.Context(), graphDiffToUnifiedGraph(from, to, delta), http.StatusOK, response)
+	} else {
+		api.WriteBasicResponse(request.Context(), delta, http.StatusOK, response)
+	}
+}
+
+func sortedKeys(edgesByKind map[string][]snapshot.Edge) []string {
+	keys := make([]string, 0, len(edgesByKind))
+
+	for key := range edgesByKind {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+func graphDiffNode(node snapshot.Node, change string) model.UnifiedNode {
+	properties := graph.AsProperties(map[string]any{
+		common.ObjectID.String():   node.ObjectID,
+		common.Name.String():       node.Name,
+		common.SystemTags.String(): node.SystemTags,
+	})
+
+	if node.Name == "" {
+		properties.Set(common.Name.String(), node.ObjectID)
+	}
+
+	rendered := model.FromDAWGSNode(graph.NewNode(0, properties, node.GraphKinds()...), false)
+	rendered.Properties = map[string]any{
+		GraphDiffChangeProperty: change,
+	}
+
+	return rendered
+}
+
+// graphDiffToUnifiedGraph renders the changed nodes and edges of a delta keyed by object ID. Nodes that are unchanged
+// but sit at either end of a changed edge are included so that every edge can be drawn.
+func graphDiffToUnifiedGraph(from, to snapshot.Snapshot, delta snapshot.Delta) model.UnifiedGraph {
+	var (
+		unifiedGraph = model.NewUnifiedGraph()
+		fromNodes    = map[string]snapshot.Node{}
+		toNodes      = map[string]snapshot.Node{}
+		attackPaths  = map[snapshot.Edge]struct{}{}
+		now          = time.Now()
+	)
+
+	for _, node := range from.Nodes {
+		fromNodes[node.ObjectID] = node
+	}
+
+	for _, node := range to.Nodes {
+		toNodes[node.ObjectID] = node
+	}
+
+	for _, edge := range delta.NewTierZeroAttackPaths {
+		attackPaths[edge] = struct{}{}
+	}
+
+	for _, node := range delta.NodesAdded {
+		unifiedGraph.Nodes[node.ObjectID] = graphDiffNode(node, GraphDiffChangeAdded)
+	}
+
+	for _, node := range delta.NodesRemoved {
+		unifiedGraph.Nodes[node.ObjectID] = graphDiffNode(node, GraphDiffChangeRemoved)
+	}
+
+	addEndpoint := func(objectID string) {
+		if _, exists := unifiedGraph.Nodes[objectID]; exists {
+			return
+		} else if node, found := toNodes[objectID]; found {
+			unifiedGraph.Nodes[objectID] = graphDiffNode(node, GraphDiffChangeUnchanged)
+		} else if node, found := fromNodes[objectID]; found {
+			unifiedGraph.Nodes[objectID] = graphDiffNode(node, GraphDiffChangeUnchanged)
+		}
+	}
+
+	addEdges := func(edges []snapshot.Edge, change string) {
+		for _, edge := range edges {
+			properties := map[string]any{
+				GraphDiffChangeProperty: change,
+			}
+
+			if _, isAttackPath := attackPaths[edge]; isAttackPath {
+				properties[GraphDiffAttackPathProperty] = true
+				delete(attackPaths, edge)
+			}
+
+			addEndpoint(edge.Start)
+			addEndpoint(edge.End)
+
+			unifiedGraph.Edges = append(unifiedGraph.Edges, model.UnifiedEdge{
+				Source:     edge.Start,
+				Target:     edge.End,
+				Label:      edge.Kind,
+				Kind:       edge.Kind,
+				LastSeen:   now,
+				Properties: properties,
+			})
+		}
+	}
+
+	for _, kind := range sortedKeys(delta.EdgesAdded) {
+		addEdges(delta.EdgesAdded[kind], GraphDiffChangeAdded)
+	}
+
+	for _, kind := range sortedKeys(delta.EdgesRemoved) {
+		addEdges(delta.EdgesRemoved[kind], GraphDiffChangeRemoved)
+	}
+
+	// Attack paths created by a change of tier rather than a new edge have not been rendered yet
+	for _, edge := range delta.NewTierZeroAttackPaths {
+		if _, pending := attackPaths[edge]; pending {
+			addEdges([]snapshot.Edge{edge}, GraphDiffChangeUnchanged)
+		}
+	}
+
+	return unifiedGraph
+}
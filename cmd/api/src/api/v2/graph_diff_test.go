@@ -0,0 +1,155 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v2_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/specterops/bloodhound/analysis/snapshot"
+	"github.com/specterops/bloodhound/graphschema/ad"
+	"github.com/specterops/bloodhound/src/api"
+	v2 "github.com/specterops/bloodhound/src/api/v2"
+	"github.com/specterops/bloodhound/src/database"
+	"github.com/specterops/bloodhound/src/database/mocks"
+	"github.com/specterops/bloodhound/src/model"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func serveGraphDiff(t *testing.T, resources v2.Resources, params url.Values) *httptest.ResponseRecorder {
+	request, err := http.NewRequest(http.MethodGet, "/api/v2/graphs/diff", nil)
+	require.Nil(t, err)
+
+	request.URL.RawQuery = params.Encode()
+
+	response := httptest.NewRecorder()
+	http.HandlerFunc(resources.GetGraphDiff).ServeHTTP(response, request)
+
+	return response
+}
+
+func encodedGraphSnapshot(t *testing.T, runID int64, captured snapshot.Snapshot) model.GraphSnapshot {
+	content, err := snapshot.Encode(captured)
+	require.Nil(t, err)
+
+	return model.GraphSnapshot{
+		AnalysisRunID: runID,
+		NodeCount:     len(captured.Nodes),
+		EdgeCount:     len(captured.Edges),
+		Content:       content,
+	}
+}
+
+func expectGraphDiffSnapshots(t *testing.T, mockDB *mocks.MockDatabase) {
+	var (
+		admins = snapshot.Node{ObjectID: "S-1-5-21-512", Name: "DOMAIN ADMINS@TESTLAB.LOCAL", Kinds: []string{ad.Entity.String(), ad.Group.String()}, SystemTags: ad.AdminTierZero, TierZero: true}
+		alice  = snapshot.Node{ObjectID: "S-1-5-21-1000", Name: "ALICE@TESTLAB.LOCAL", Kinds: []string{ad.Entity.String(), ad.User.String()}}
+		bob    = snapshot.Node{ObjectID: "S-1-5-21-1001", Name: "BOB@TESTLAB.LOCAL", Kinds: []string{ad.Entity.String(), ad.User.String()}}
+		from   = snapshot.Snapshot{
+			Nodes: []snapshot.Node{admins, alice},
+			Edges: []snapshot.Edge{{Start: alice.ObjectID, End: admins.ObjectID, Kind: ad.MemberOf.String()}},
+		}
+		to = snapshot.Snapshot{
+			Nodes: []snapshot.Node{admins, alice, bob},
+			Edges: []snapshot.Edge{{Start: bob.ObjectID, End: admins.ObjectID, Kind: ad.GenericAll.String()}},
+		}
+	)
+
+	mockDB.EXPECT().GetGraphSnapshotByAnalysisRunID(gomock.Any(), int64(1)).Return(encodedGraphSnapshot(t, 1, from), nil)
+	mockDB.EXPECT().GetGraphSnapshotByAnalysisRunID(gomock.Any(), int64(2)).Return(encodedGraphSnapshot(t, 2, to), nil)
+}
+
+func TestResources_GetGraphDiff_BadParameters(t *testing.T) {
+	for _, params := range []url.Values{
+		{"to": []string{"2"}},
+		{"from": []string{"1"}, "to": []string{"two"}},
+		{"from": []string{"1"}, "to": []string{"2"}, "type": []string{"list"}},
+	} {
+		response := serveGraphDiff(t, v2.Resources{}, params)
+		require.Equal(t, http.StatusBadRequest, response.Code, params.Encode())
+	}
+}
+
+func TestResources_GetGraphDiff_MissingSnapshot(t *testing.T) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		mockDB   = mocks.NewMockDatabase(mockCtrl)
+	)
+	defer mockCtrl.Finish()
+
+	mockDB.EXPECT().GetGraphSnapshotByAnalysisRunID(gomock.Any(), int64(1)).Return(model.GraphSnapshot{}, database.ErrNotFound)
+
+	response := serveGraphDiff(t, v2.Resources{DB: mockDB}, url.Values{"from": []string{"1"}, "to": []string{"2"}})
+	require.Equal(t, http.StatusNotFound, response.Code)
+	require.Contains(t, response.Body.String(), api.ErrorResponseDetailsResourceNotFound)
+}
+
+func TestResources_GetGraphDiff(t *testing.T) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		mockDB   = mocks.NewMockDatabase(mockCtrl)
+		result   struct {
+			Data snapshot.Delta `json:"data"`
+		}
+	)
+	defer mockCtrl.Finish()
+
+	expectGraphDiffSnapshots(t, mockDB)
+
+	response := serveGraphDiff(t, v2.Resources{DB: mockDB}, url.Values{"from": []string{"1"}, "to": []string{"2"}})
+	require.Equal(t, http.StatusOK, response.Code)
+	require.Nil(t, json.Unmarshal(response.Body.Bytes(), &result))
+
+	require.Equal(t, 1, result.Data.Summary.NodesAdded)
+	require.Equal(t, map[string]int{ad.GenericAll.String(): 1}, result.Data.Summary.EdgesAdded)
+	require.Equal(t, map[string]int{ad.MemberOf.String(): 1}, result.Data.Summary.EdgesRemoved)
+	require.Equal(t, 1, result.Data.Summary.NewTierZeroAttackPaths)
+}
+
+func TestResources_GetGraphDiff_Graph(t *testing.T) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		mockDB   = mocks.NewMockDatabase(mockCtrl)
+		result   struct {
+			Data model.UnifiedGraph `json:"data"`
+		}
+	)
+	defer mockCtrl.Finish()
+
+	expectGraphDiffSnapshots(t, mockDB)
+
+	response := serveGraphDiff(t, v2.Resources{DB: mockDB}, url.Values{"from": []string{"1"}, "to": []string{"2"}, "type": []string{"graph"}})
+	require.Equal(t, http.StatusOK, response.Code)
+	require.Nil(t, json.Unmarshal(response.Body.Bytes(), &result))
+
+	require.Len(t, result.Data.Nodes, 3)
+	require.Equal(t, v2.GraphDiffChangeAdded, result.Data.Nodes["S-1-5-21-1001"].Properties[v2.GraphDiffChangeProperty])
+	require.Equal(t, v2.GraphDiffChangeUnchanged, result.Data.Nodes["S-1-5-21-512"].Properties[v2.GraphDiffChangeProperty])
+	require.True(t, result.Data.Nodes["S-1-5-21-512"].IsTierZero)
+	require.Equal(t, ad.User.String(), result.Data.Nodes["S-1-5-21-1000"].Kind)
+
+	require.Len(t, result.Data.Edges, 2)
+	require.Equal(t, ad.GenericAll.String(), result.Data.Edges[0].Kind)
+	require.Equal(t, v2.GraphDiffChangeAdded, result.Data.Edges[0].Properties[v2.GraphDiffChangeProperty])
+	require.Equal(t, true, result.Data.Edges[0].Properties[v2.GraphDiffAttackPathProperty])
+	require.Equal(t, ad.MemberOf.String(), result.Data.Edges[1].Kind)
+	require.Equal(t, v2.GraphDiffChangeRemoved, result.Data.Edges[1].Properties[v2.GraphDiffChangeProperty])
+}
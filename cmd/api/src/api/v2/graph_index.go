@@ -0,0 +1,162 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v2
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/specterops/bloodhound/graphschema"
+	"github.com/specterops/bloodhound/src/api"
+	"github.com/specterops/bloodhound/src/model"
+	"github.com/specterops/bloodhound/src/services/graphindex"
+)
+
+type CreateGraphPropertyIndexRequest struct {
+	Kind      string `json:"kind"`
+	Property  string `json:"property"`
+	IndexType string `json:"index_type"`
+}
+
+// GraphPropertyIndexView is a runtime property index along with the population percentage of the index while it is
+// being built.
+type GraphPropertyIndexView struct {
+	model.GraphPropertyIndex
+
+	Progress *float64 `json:"progress,omitempty"`
+}
+
+type DefaultGraphPropertyIndex struct {
+	Property  string `json:"property"`
+	IndexType string `json:"index_type"`
+}
+
+type GraphPropertyIndexesResponse struct {
+	DefaultIndexes []DefaultGraphPropertyIndex `json:"default_indexes"`
+	Indexes        []GraphPropertyIndexView    `json:"indexes"`
+}
+
+// ListGraphPropertyIndexes returns the node property indexes of the default graph schema, which apply to every node
+// kind, along with all runtime-managed property indexes.
+func (s Resources) ListGraphPropertyIndexes(response http.ResponseWriter, request *http.Request) {
+	if indexes, err := s.DB.GetGraphPropertyIndexes(request.Context()); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if progress, err := graphindex.BuildProgress(request.Context(), s.Graph, s.Config.GraphDriver, indexes); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, err.Error(), request), response)
+	} else {
+		result := GraphPropertyIndexesResponse{
+			DefaultIndexes: []DefaultGraphPropertyIndex{},
+			Indexes:        make([]GraphPropertyIndexView, 0, len(indexes)),
+		}
+
+		for _, index := range graphschema.DefaultGraph().NodeIndexes {
+			result.DefaultIndexes = append(result.DefaultIndexes, DefaultGraphPropertyIndex{
+				Property:  index.Field,
+				IndexType: index.Type.String(),
+			})
+		}
+
+		for _, index := range indexes {
+			view := GraphPropertyIndexView{
+				GraphPropertyIndex: index,
+			}
+
+			if indexProgress, hasProgress := progress[index.ID]; hasProgress {
+				view.Progress = &indexProgress
+			}
+
+			result.Indexes = append(result.Indexes, view)
+		}
+
+		api.WriteBasicResponse(request.Context(), result, http.StatusOK, response)
+	}
+}
+
+// CreateGraphPropertyIndex persists a new property index for a node kind and schedules it to be built. The index is
+// returned in the building state as the build itself happens asynchronously. Runtime indexes are only built on the
+// default graph and do not apply to the graphs of named workspaces.
+func (s Resources) CreateGraphPropertyIndex(response http.ResponseWriter, request *http.Request) {
+	var createRequest CreateGraphPropertyIndexRequest
+
+	if err := api.ReadJSONRequestPayloadLimited(&createRequest, request); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponsePayloadUnmarshalError, request), response)
+	} else {
+		index := model.GraphPropertyIndex{
+			Kind:      createRequest.Kind,
+			Property:  createRequest.Property,
+			IndexType: createRequest.IndexType,
+			Status:    model.GraphPropertyIndexStatusBuilding,
+		}
+
+		if err := graphindex.Validate(index); err != nil {
+			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+		} else if existingIndexes, err := s.DB.GetGraphPropertyIndexes(request.Context()); err != nil {
+			api.HandleDatabaseError(request, response, err)
+		} else if hasIndex(existingIndexes, index) {
+			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusConflict, "an index already exists for this kind and property", request), response)
+		} else if createdIndex, err := s.DB.CreateGraphPropertyIndex(request.Context(), index); err != nil {
+			api.HandleDatabaseError(request, response, err)
+		} else {
+			s.IndexBuilder.RequestBuild()
+			api.WriteBasicResponse(request.Context(), createdIndex, http.StatusAccepted, response)
+		}
+	}
+}
+
+func hasIndex(indexes model.GraphPropertyIndexes, target model.GraphPropertyIndex) bool {
+	for _, index := range indexes {
+		if index.Kind == target.Kind && index.Property == target.Property {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DeleteGraphPropertyIndex removes a runtime property index and schedules the index to be dropped from the graph.
+func (s Resources) DeleteGraphPropertyIndex(response http.ResponseWriter, request *http.Request) {
+	rawIndexID := mux.Vars(request)[api.URIPathVariableGraphPropertyIndexID]
+
+	if indexID, err := strconv.ParseInt(rawIndexID, 10, 64); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsIDMalformed, request), response)
+	} else if index, err := s.DB.GetGraphPropertyIndex(request.Context(), indexID); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if err := s.DB.DeleteGraphPropertyIndex(request.Context(), index); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else {
+		s.IndexBuilder.RequestBuild()
+		response.WriteHeader(http.StatusAccepted)
+	}
+}
+
+// GetGraphIndexUtilization reports the index utilization of the graph tables with the most scans. This is only
+// available for the PostgreSQL graph driver.
+func (s Resources) GetGraphIndexUtilization(response http.ResponseWriter, request *http.Request) {
+	if utilization, err := graphindex.Utilization(request.Context(), s.Graph, s.Config.GraphDriver); errors.Is(err, graphindex.ErrUtilizationNotSupported) {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusNotImplemented, err.Error(), request), response)
+	} else if err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, err.Error(), request), response)
+	} else {
+		if utilization == nil {
+			utilization = []graphindex.TableIndexUtilization{}
+		}
+
+		api.WriteBasicResponse(request.Context(), utilization, http.StatusOK, response)
+	}
+}
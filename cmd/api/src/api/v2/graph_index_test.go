@@ -0,0 +1,236 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v2_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/specterops/bloodhound/dawgs/drivers/neo4j"
+	"github.com/specterops/bloodhound/headers"
+	"github.com/specterops/bloodhound/mediatypes"
+	"github.com/specterops/bloodhound/src/api"
+	v2 "github.com/specterops/bloodhound/src/api/v2"
+	"github.com/specterops/bloodhound/src/api/v2/apitest"
+	"github.com/specterops/bloodhound/src/config"
+	"github.com/specterops/bloodhound/src/database"
+	"github.com/specterops/bloodhound/src/database/mocks"
+	"github.com/specterops/bloodhound/src/model"
+	graphindexMocks "github.com/specterops/bloodhound/src/services/graphindex/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestResources_ListGraphPropertyIndexes(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockDB    = mocks.NewMockDatabase(mockCtrl)
+		resources = v2.Resources{DB: mockDB, Config: config.Configuration{GraphDriver: neo4j.DriverName}}
+	)
+	defer mockCtrl.Finish()
+
+	apitest.NewHarness(t, resources.ListGraphPropertyIndexes).
+		Run([]apitest.Case{
+			{
+				Name: "DatabaseError",
+				Setup: func() {
+					mockDB.EXPECT().GetGraphPropertyIndexes(gomock.Any()).Return(nil, errors.New("database error"))
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusInternalServerError)
+				},
+			},
+			{
+				Name: "Success",
+				Setup: func() {
+					mockDB.EXPECT().GetGraphPropertyIndexes(gomock.Any()).Return(model.GraphPropertyIndexes{{
+						Kind:      "User",
+						Property:  "department",
+						IndexType: "btree",
+						Status:    model.GraphPropertyIndexStatusOnline,
+					}}, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.BodyContains(output, `"kind":"User","property":"department","index_type":"btree","status":"online"`)
+					apitest.BodyContains(output, `{"property":"domainsid","index_type":"btree"}`)
+					apitest.BodyNotContains(output, `"progress"`)
+				},
+			},
+		})
+}
+
+func TestResources_CreateGraphPropertyIndex(t *testing.T) {
+	var (
+		mockCtrl    = gomock.NewController(t)
+		mockDB      = mocks.NewMockDatabase(mockCtrl)
+		mockBuilder = graphindexMocks.NewMockBuilder(mockCtrl)
+		resources   = v2.Resources{DB: mockDB, IndexBuilder: mockBuilder}
+	)
+	defer mockCtrl.Finish()
+
+	apitest.NewHarness(t, resources.CreateGraphPropertyIndex).
+		WithCommonRequest(func(input *apitest.Input) {
+			apitest.SetHeader(input, headers.ContentType.String(), mediatypes.ApplicationJson.String())
+		}).
+		Run([]apitest.Case{
+			{
+				Name: "InvalidPayload",
+				Input: func(input *apitest.Input) {
+					apitest.BodyString(input, "not json")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, api.ErrorResponsePayloadUnmarshalError)
+				},
+			},
+			{
+				Name: "InvalidProperty",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.CreateGraphPropertyIndexRequest{Kind: "User", Property: "owned'); drop table node; --", IndexType: "btree"})
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "invalid property")
+				},
+			},
+			{
+				Name: "InvalidIndexType",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.CreateGraphPropertyIndexRequest{Kind: "User", Property: "department", IndexType: "hash"})
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "invalid index type")
+				},
+			},
+			{
+				Name: "DefaultSchemaProperty",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.CreateGraphPropertyIndexRequest{Kind: "User", Property: "name", IndexType: "btree"})
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "already indexed by the default graph schema")
+				},
+			},
+			{
+				Name: "Duplicate",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.CreateGraphPropertyIndexRequest{Kind: "User", Property: "department", IndexType: "fts"})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetGraphPropertyIndexes(gomock.Any()).Return(model.GraphPropertyIndexes{{Kind: "User", Property: "department", IndexType: "btree"}}, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusConflict)
+				},
+			},
+			{
+				Name: "Success",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.CreateGraphPropertyIndexRequest{Kind: "User", Property: "department", IndexType: "fts"})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetGraphPropertyIndexes(gomock.Any()).Return(model.GraphPropertyIndexes{{Kind: "Computer", Property: "department", IndexType: "btree"}}, nil)
+					mockDB.EXPECT().CreateGraphPropertyIndex(gomock.Any(), model.GraphPropertyIndex{
+						Kind:      "User",
+						Property:  "department",
+						IndexType: "fts",
+						Status:    model.GraphPropertyIndexStatusBuilding,
+					}).DoAndReturn(func(_ any, index model.GraphPropertyIndex) (model.GraphPropertyIndex, error) {
+						index.ID = 7
+						return index, nil
+					})
+					mockBuilder.EXPECT().RequestBuild()
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusAccepted)
+					apitest.BodyContains(output, `"status":"building"`)
+					apitest.BodyContains(output, `"id":7`)
+				},
+			},
+		})
+}
+
+func TestResources_DeleteGraphPropertyIndex(t *testing.T) {
+	var (
+		mockCtrl    = gomock.NewController(t)
+		mockDB      = mocks.NewMockDatabase(mockCtrl)
+		mockBuilder = graphindexMocks.NewMockBuilder(mockCtrl)
+		resources   = v2.Resources{DB: mockDB, IndexBuilder: mockBuilder}
+		index       = model.GraphPropertyIndex{Kind: "User", Property: "department", IndexType: "btree"}
+	)
+	defer mockCtrl.Finish()
+
+	index.ID = 7
+
+	apitest.NewHarness(t, resources.DeleteGraphPropertyIndex).
+		Run([]apitest.Case{
+			{
+				Name: "MalformedID",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, api.URIPathVariableGraphPropertyIndexID, "seven")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, api.ErrorResponseDetailsIDMalformed)
+				},
+			},
+			{
+				Name: "NotFound",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, api.URIPathVariableGraphPropertyIndexID, "8")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetGraphPropertyIndex(gomock.Any(), int64(8)).Return(model.GraphPropertyIndex{}, database.ErrNotFound)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusNotFound)
+				},
+			},
+			{
+				Name: "Success",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, api.URIPathVariableGraphPropertyIndexID, "7")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetGraphPropertyIndex(gomock.Any(), int64(7)).Return(index, nil)
+					mockDB.EXPECT().DeleteGraphPropertyIndex(gomock.Any(), index).Return(nil)
+					mockBuilder.EXPECT().RequestBuild()
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusAccepted)
+				},
+			},
+		})
+}
+
+func TestResources_GetGraphIndexUtilization_UnsupportedDriver(t *testing.T) {
+	resources := v2.Resources{Config: config.Configuration{GraphDriver: neo4j.DriverName}}
+
+	apitest.NewHarness(t, resources.GetGraphIndexUtilization).
+		Run([]apitest.Case{
+			{
+				Name: "Neo4j",
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusNotImplemented)
+					apitest.BodyContains(output, "only available for the PostgreSQL graph driver")
+				},
+			},
+		})
+}
@@ -21,12 +21,14 @@ import (
 	"github.com/specterops/bloodhound/cache"
 	_ "github.com/specterops/bloodhound/dawgs/drivers/neo4j"
 	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/src/auth"
 	"github.com/specterops/bloodhound/src/config"
 	"github.com/specterops/bloodhound/src/daemons/datapipe"
 	"github.com/specterops/bloodhound/src/database"
 	"github.com/specterops/bloodhound/src/model"
 	"github.com/specterops/bloodhound/src/queries"
 	"github.com/specterops/bloodhound/src/serde"
+	"github.com/specterops/bloodhound/src/services/graphindex"
 )
 
 type ListPermissionsResponse struct {
@@ -141,6 +143,8 @@ type Resources struct {
 	Cache                      cache.Cache
 	CollectorManifests         config.CollectorManifests
 	TaskNotifier               datapipe.Tasker
+	IndexBuilder               graphindex.Builder
+	Authorizer                 auth.Authorizer
 }
 
 func NewResources(
@@ -151,6 +155,7 @@ func NewResources(
 	graphQuery queries.Graph,
 	collectorManifests config.CollectorManifests,
 	taskNotifier datapipe.Tasker,
+	indexBuilder graphindex.Builder,
 ) Resources {
 	return Resources{
 		Decoder:                    schema.NewDecoder(),
@@ -162,5 +167,7 @@ func NewResources(
 		Cache:                      apiCache,
 		CollectorManifests:         collectorManifests,
 		TaskNotifier:               taskNotifier,
+		IndexBuilder:               indexBuilder,
+		Authorizer:                 auth.NewAuthorizer(rdms),
 	}
 }
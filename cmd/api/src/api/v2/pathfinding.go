@@ -17,12 +17,17 @@
 package v2
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/ops"
 	"github.com/specterops/bloodhound/dawgs/query"
+	"github.com/specterops/bloodhound/dawgs/traversal"
 	"github.com/specterops/bloodhound/graphschema/ad"
 	"github.com/specterops/bloodhound/graphschema/azure"
 	"github.com/specterops/bloodhound/params"
@@ -121,6 +126,76 @@ func parseRelationshipKindsParamFilter(relationshipKindsParam string) (graph.Cri
 	}
 }
 
+// MaxRankedPaths is the largest number of ranked paths that may be requested with the k query parameter
+const MaxRankedPaths = 10
+
+// RankedPath is a single path of a ranked pathfinding result. Node IDs reference the nodes of the enclosing graph.
+type RankedPath struct {
+	Rank  int                 `json:"rank"`
+	Cost  float64             `json:"cost"`
+	Nodes []string            `json:"nodes"`
+	Edges []model.UnifiedEdge `json:"edges"`
+}
+
+// RankedPathsResponse renders ranked paths along with the graph formed by all of them.
+type RankedPathsResponse struct {
+	model.UnifiedGraph
+	Paths []RankedPath `json:"paths"`
+}
+
+func writeRankedPathsResult(paths []traversal.WeightedPath, response http.ResponseWriter, request *http.Request) {
+	if len(paths) == 0 {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusNotFound, "Path not found", request), response)
+	} else {
+		rankedPaths := RankedPathsResponse{
+			UnifiedGraph: model.NewUnifiedGraph(),
+			Paths:        make([]RankedPath, 0, len(paths)),
+		}
+
+		for idx, path := range paths {
+			rankedPath := RankedPath{
+				Rank:  idx + 1,
+				Cost:  path.Cost,
+				Nodes: make([]string, 0, len(path.Path.Nodes)),
+				Edges: slicesext.Map(path.Path.Edges, model.FromDAWGSRelationship(false)),
+			}
+
+			for _, node := range path.Path.Nodes {
+				rankedPath.Nodes = append(rankedPath.Nodes, node.ID.String())
+				rankedPaths.Nodes[node.ID.String()] = model.FromDAWGSNode(node, false)
+			}
+
+			rankedPaths.Edges = append(rankedPaths.Edges, rankedPath.Edges...)
+			rankedPaths.Paths = append(rankedPaths.Paths, rankedPath)
+		}
+
+		rankedPaths.Edges = slicesext.UniqueBy(rankedPaths.Edges, func(edge model.UnifiedEdge) string {
+			return edge.Source + edge.Kind + edge.Target
+		})
+
+		api.WriteBasicResponse(request.Context(), rankedPaths, http.StatusOK, response)
+	}
+}
+
+// parseRankedPathParams returns whether the weighted cost model was requested, the number of ranked paths requested
+// and whether ranked pathfinding was requested at all.
+func parseRankedPathParams(queryParams url.Values) (bool, int, bool, error) {
+	var (
+		weightedParam = queryParams.Get(params.Weighted.String())
+		kParam        = queryParams.Get(params.K.String())
+	)
+
+	if weighted, err := api.ParseOptionalBool(weightedParam, false); err != nil {
+		return false, 0, false, fmt.Errorf("invalid query parameter '%s': %w", params.Weighted, err)
+	} else if kParam == "" {
+		return weighted, 1, weighted, nil
+	} else if k, err := strconv.Atoi(kParam); err != nil || k < 1 || k > MaxRankedPaths {
+		return false, 0, false, fmt.Errorf("invalid query parameter '%s': expected an integer between 1 and %d", params.K, MaxRankedPaths)
+	} else {
+		return weighted, k, true, nil
+	}
+}
+
 func (s Resources) GetShortestPath(response http.ResponseWriter, request *http.Request) {
 	var (
 		queryParams            = request.URL.Query()
@@ -135,6 +210,18 @@ func (s Resources) GetShortestPath(response http.ResponseWriter, request *http.R
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "Missing query parameter: end_node", request), response)
 	} else if kindFilter, err := parseRelationshipKindsParamFilter(relationshipKindsParam); err != nil {
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+	} else if weighted, k, ranked, err := parseRankedPathParams(queryParams); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+	} else if ranked {
+		if paths, err := s.GraphQuery.GetKCheapestPaths(request.Context(), startNode, endNode, kindFilter, weighted, k); err != nil {
+			if errors.Is(err, ops.ErrTraversalMemoryLimit) {
+				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, "calculating the request results exceeded memory limitations due to the volume of objects involved", request), response)
+			} else {
+				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, err.Error(), request), response)
+			}
+		} else {
+			writeRankedPathsResult(paths, response, request)
+		}
 	} else if paths, err := s.GraphQuery.GetAllShortestPaths(request.Context(), startNode, endNode, kindFilter); err != nil {
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, err.Error(), request), response)
 	} else {
@@ -26,6 +26,8 @@ import (
 	mocks_graph "github.com/specterops/bloodhound/src/queries/mocks"
 	"go.uber.org/mock/gomock"
 	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/ops"
+	"github.com/specterops/bloodhound/dawgs/traversal"
 	"github.com/specterops/bloodhound/errors"
 	"github.com/specterops/bloodhound/graphschema/ad"
 )
@@ -204,6 +206,119 @@ func TestResources_GetShortestPath(t *testing.T) {
 					apitest.BodyContains(output, "Path not found")
 				},
 			},
+			{
+				Name: "InvalidKParam",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "start_node", "someID")
+					apitest.AddQueryParam(input, "end_node", "someOtherID")
+					apitest.AddQueryParam(input, "k", "11")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.UnmarshalBody(output, &api.ErrorWrapper{})
+					apitest.BodyContains(output, "invalid query parameter 'k': expected an integer between 1 and 10")
+				},
+			},
+			{
+				Name: "InvalidWeightedParam",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "start_node", "someID")
+					apitest.AddQueryParam(input, "end_node", "someOtherID")
+					apitest.AddQueryParam(input, "weighted", "maybe")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.UnmarshalBody(output, &api.ErrorWrapper{})
+					apitest.BodyContains(output, "invalid query parameter 'weighted'")
+				},
+			},
+			{
+				Name: "GraphDBGetKCheapestPathsError",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "start_node", "someID")
+					apitest.AddQueryParam(input, "end_node", "someOtherID")
+					apitest.AddQueryParam(input, "weighted", "true")
+				},
+				Setup: func() {
+					mockGraph.EXPECT().
+						GetKCheapestPaths(gomock.Any(), "someID", "someOtherID", gomock.Any(), true, 1).
+						Return(nil, errors.New("graph error"))
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusInternalServerError)
+					apitest.BodyContains(output, "graph error")
+				},
+			},
+			{
+				Name: "GraphDBGetKCheapestPathsMemoryLimit",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "start_node", "someID")
+					apitest.AddQueryParam(input, "end_node", "someOtherID")
+					apitest.AddQueryParam(input, "k", "3")
+				},
+				Setup: func() {
+					mockGraph.EXPECT().
+						GetKCheapestPaths(gomock.Any(), "someID", "someOtherID", gomock.Any(), false, 3).
+						Return(nil, ops.ErrTraversalMemoryLimit)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusInternalServerError)
+					apitest.BodyContains(output, "calculating the request results exceeded memory limitations")
+				},
+			},
+			{
+				Name: "RankedPathsNotFound",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "start_node", "someID")
+					apitest.AddQueryParam(input, "end_node", "someOtherID")
+					apitest.AddQueryParam(input, "k", "2")
+				},
+				Setup: func() {
+					mockGraph.EXPECT().
+						GetKCheapestPaths(gomock.Any(), "someID", "someOtherID", gomock.Any(), false, 2).
+						Return(nil, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusNotFound)
+					apitest.BodyContains(output, "Path not found")
+				},
+			},
+			{
+				Name: "RankedPathsSuccess",
+				Input: func(input *apitest.Input) {
+					apitest.AddQueryParam(input, "start_node", "someID")
+					apitest.AddQueryParam(input, "end_node", "someOtherID")
+					apitest.AddQueryParam(input, "weighted", "true")
+					apitest.AddQueryParam(input, "k", "2")
+				},
+				Setup: func() {
+					var (
+						start  = graph.NewNode(1, graph.NewProperties(), ad.User)
+						middle = graph.NewNode(2, graph.NewProperties(), ad.Group)
+						end    = graph.NewNode(3, graph.NewProperties(), ad.Computer)
+						direct = graph.Path{
+							Nodes: []*graph.Node{start, end},
+							Edges: []*graph.Relationship{graph.NewRelationship(10, 1, 3, graph.NewProperties(), ad.HasSession)},
+						}
+						viaGroup = graph.Path{
+							Nodes: []*graph.Node{start, middle, end},
+							Edges: []*graph.Relationship{
+								graph.NewRelationship(11, 1, 2, graph.NewProperties(), ad.MemberOf),
+								graph.NewRelationship(12, 2, 3, graph.NewProperties(), ad.AdminTo),
+							},
+						}
+					)
+
+					mockGraph.EXPECT().
+						GetKCheapestPaths(gomock.Any(), "someID", "someOtherID", gomock.Any(), true, 2).
+						Return([]traversal.WeightedPath{{Path: viaGroup, Cost: 1}, {Path: direct, Cost: 2}}, nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.BodyContains(output, `"rank":1,"cost":1,"nodes":["1","2","3"]`)
+					apitest.BodyContains(output, `"rank":2,"cost":2,"nodes":["1","3"]`)
+				},
+			},
 			{
 				Name: "NotFoundNin",
 				Input: func(input *apitest.Input) {
@@ -29,6 +29,7 @@ import (
 	ctx2 "github.com/specterops/bloodhound/src/ctx"
 	"github.com/specterops/bloodhound/src/database"
 	"github.com/specterops/bloodhound/src/model"
+	"github.com/specterops/bloodhound/src/queries"
 	"gorm.io/gorm/utils"
 )
 
@@ -99,8 +100,9 @@ func (s Resources) ListSavedQueries(response http.ResponseWriter, request *http.
 }
 
 type CreateSavedQueryRequest struct {
-	Query string `json:"query"`
-	Name  string `json:"name"`
+	Query      string                     `json:"query"`
+	Name       string                     `json:"name"`
+	Parameters model.SavedQueryParameters `json:"parameters,omitempty"`
 }
 
 func (s Resources) CreateSavedQuery(response http.ResponseWriter, request *http.Request) {
@@ -114,7 +116,13 @@ func (s Resources) CreateSavedQuery(response http.ResponseWriter, request *http.
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
 	} else if createRequest.Name == "" || createRequest.Query == "" {
 		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "the name and/or query field is empty", request), response)
-	} else if savedQuery, err := s.DB.CreateSavedQuery(request.Context(), user.ID, createRequest.Name, createRequest.Query); err != nil {
+	} else if err := createRequest.Parameters.Validate(); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+	} else if referencedNames, err := queries.CypherParameterNames(createRequest.Query); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, fmt.Sprintf("invalid query: %v", err), request), response)
+	} else if err := createRequest.Parameters.CheckReferences(referencedNames); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+	} else if savedQuery, err := s.DB.CreateSavedQuery(request.Context(), user.ID, createRequest.Name, createRequest.Query, savedQueryParameters(createRequest.Parameters)); err != nil {
 		if strings.Contains(err.Error(), "duplicate key value violates unique constraint") {
 			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "duplicate name for saved query: please choose a different name", request), response)
 		} else {
@@ -125,6 +133,15 @@ func (s Resources) CreateSavedQuery(response http.ResponseWriter, request *http.
 	}
 }
 
+// savedQueryParameters returns the given parameters or an empty set of parameters if none were declared
+func savedQueryParameters(parameters model.SavedQueryParameters) model.SavedQueryParameters {
+	if parameters == nil {
+		return model.SavedQueryParameters{}
+	}
+
+	return parameters
+}
+
 func (s Resources) DeleteSavedQuery(response http.ResponseWriter, request *http.Request) {
 	var (
 		rawSavedQueryID = mux.Vars(request)[api.URIPathVariableSavedQueryID]
@@ -329,7 +329,7 @@ func TestResources_CreateSavedQuery_DuplicateName(t *testing.T) {
 	userId, err := uuid2.NewV4()
 	require.Nil(t, err)
 
-	mockDB.EXPECT().CreateSavedQuery(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(model.SavedQuery{}, fmt.Errorf("duplicate key value violates unique constraint \"idx_saved_queries_composite_index\""))
+	mockDB.EXPECT().CreateSavedQuery(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(model.SavedQuery{}, fmt.Errorf("duplicate key value violates unique constraint \"idx_saved_queries_composite_index\""))
 
 	payload := v2.CreateSavedQueryRequest{
 		Query: "Match(n) return n",
@@ -367,7 +367,7 @@ func TestResources_CreateSavedQuery_CreateFailure(t *testing.T) {
 		Name:  "myCustomQuery1",
 	}
 
-	mockDB.EXPECT().CreateSavedQuery(gomock.Any(), userId, payload.Name, payload.Query).Return(model.SavedQuery{}, fmt.Errorf("foo"))
+	mockDB.EXPECT().CreateSavedQuery(gomock.Any(), userId, payload.Name, payload.Query, model.SavedQueryParameters{}).Return(model.SavedQuery{}, fmt.Errorf("foo"))
 
 	req, err := http.NewRequestWithContext(createContextWithOwnerId(userId), "POST", endpoint, must.MarshalJSONReader(payload))
 	require.Nil(t, err)
@@ -399,7 +399,7 @@ func TestResources_CreateSavedQuery(t *testing.T) {
 		Name:  "myCustomQuery1",
 	}
 
-	mockDB.EXPECT().CreateSavedQuery(gomock.Any(), userId, payload.Name, payload.Query).Return(model.SavedQuery{
+	mockDB.EXPECT().CreateSavedQuery(gomock.Any(), userId, payload.Name, payload.Query, model.SavedQueryParameters{}).Return(model.SavedQuery{
 		UserID: userId.String(),
 		Name:   payload.Name,
 		Query:  payload.Query,
@@ -418,6 +418,140 @@ func TestResources_CreateSavedQuery(t *testing.T) {
 	require.Equal(t, http.StatusCreated, response.Code)
 }
 
+func TestResources_CreateSavedQuery_WithParameters(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockDB    = mocks.NewMockDatabase(mockCtrl)
+		resources = v2.Resources{DB: mockDB}
+	)
+	defer mockCtrl.Finish()
+
+	endpoint := "/api/v2/saved-queries"
+	userId, err := uuid2.NewV4()
+	require.Nil(t, err)
+
+	payload := v2.CreateSavedQueryRequest{
+		Query: "match (n:Domain) where n.objectid = $domain return n",
+		Name:  "myCustomQuery1",
+		Parameters: model.SavedQueryParameters{{
+			Name: "domain",
+			Type: model.CypherParameterTypeString,
+		}},
+	}
+
+	mockDB.EXPECT().CreateSavedQuery(gomock.Any(), userId, payload.Name, payload.Query, payload.Parameters).Return(model.SavedQuery{
+		UserID:     userId.String(),
+		Name:       payload.Name,
+		Query:      payload.Query,
+		Parameters: payload.Parameters,
+	}, nil)
+
+	req, err := http.NewRequestWithContext(createContextWithOwnerId(userId), "POST", endpoint, must.MarshalJSONReader(payload))
+	require.Nil(t, err)
+
+	req.Header.Set(headers.ContentType.String(), mediatypes.ApplicationJson.String())
+
+	router := mux.NewRouter()
+	router.HandleFunc(endpoint, resources.CreateSavedQuery).Methods("POST")
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, req)
+	require.Equal(t, http.StatusCreated, response.Code)
+}
+
+func TestResources_CreateSavedQuery_InvalidParameters(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockDB    = mocks.NewMockDatabase(mockCtrl)
+		resources = v2.Resources{DB: mockDB}
+	)
+	defer mockCtrl.Finish()
+
+	endpoint := "/api/v2/saved-queries"
+	userId, err := uuid2.NewV4()
+	require.Nil(t, err)
+
+	payload := v2.CreateSavedQueryRequest{
+		Query: "match (n:Domain) where n.objectid = $domain return n",
+		Name:  "myCustomQuery1",
+		Parameters: model.SavedQueryParameters{{
+			Name:    "domain",
+			Type:    model.CypherParameterTypeString,
+			Default: 1234,
+		}},
+	}
+
+	req, err := http.NewRequestWithContext(createContextWithOwnerId(userId), "POST", endpoint, must.MarshalJSONReader(payload))
+	require.Nil(t, err)
+
+	req.Header.Set(headers.ContentType.String(), mediatypes.ApplicationJson.String())
+
+	router := mux.NewRouter()
+	router.HandleFunc(endpoint, resources.CreateSavedQuery).Methods("POST")
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, req)
+	require.Equal(t, http.StatusBadRequest, response.Code)
+	require.Contains(t, response.Body.String(), "invalid default value for parameter domain")
+}
+
+func TestResources_CreateSavedQuery_ParameterReferences(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockDB    = mocks.NewMockDatabase(mockCtrl)
+		resources = v2.Resources{DB: mockDB}
+	)
+	defer mockCtrl.Finish()
+
+	endpoint := "/api/v2/saved-queries"
+	userId, err := uuid2.NewV4()
+	require.Nil(t, err)
+
+	for _, testCase := range []struct {
+		Payload         v2.CreateSavedQueryRequest
+		ExpectedMessage string
+	}{{
+		Payload: v2.CreateSavedQueryRequest{
+			Query: "match (n:Domain) where n.objectid = $domain return n",
+			Name:  "myCustomQuery1",
+		},
+		ExpectedMessage: "query references parameter $domain which is not declared",
+	}, {
+		Payload: v2.CreateSavedQueryRequest{
+			Query: "match (n:Domain) return n",
+			Name:  "myCustomQuery1",
+			Parameters: model.SavedQueryParameters{{
+				Name: "domain",
+				Type: model.CypherParameterTypeString,
+			}},
+		},
+		ExpectedMessage: "parameter domain is declared but not referenced by the query",
+	}, {
+		Payload: v2.CreateSavedQueryRequest{
+			Query: "match (n:Domain where n.objectid = $domain return n",
+			Name:  "myCustomQuery1",
+			Parameters: model.SavedQueryParameters{{
+				Name: "domain",
+				Type: model.CypherParameterTypeString,
+			}},
+		},
+		ExpectedMessage: "invalid query",
+	}} {
+		req, err := http.NewRequestWithContext(createContextWithOwnerId(userId), "POST", endpoint, must.MarshalJSONReader(testCase.Payload))
+		require.Nil(t, err)
+
+		req.Header.Set(headers.ContentType.String(), mediatypes.ApplicationJson.String())
+
+		router := mux.NewRouter()
+		router.HandleFunc(endpoint, resources.CreateSavedQuery).Methods("POST")
+
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, req)
+		require.Equal(t, http.StatusBadRequest, response.Code)
+		require.Contains(t, response.Body.String(), testCase.ExpectedMessage)
+	}
+}
+
 func TestResources_DeleteSavedQuery_IDMalformed(t *testing.T) {
 	var (
 		mockCtrl  = gomock.NewController(t)
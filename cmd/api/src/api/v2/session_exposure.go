@@ -0,0 +1,106 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v2
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/specterops/bloodhound/headers"
+	"github.com/specterops/bloodhound/src/api"
+	"github.com/specterops/bloodhound/src/model"
+	"github.com/specterops/bloodhound/src/utils"
+	gormutils "gorm.io/gorm/utils"
+)
+
+// ListADDomainSessionExposures returns the privileged session exposures recorded for the domain by the last analysis run
+func (s Resources) ListADDomainSessionExposures(response http.ResponseWriter, request *http.Request) {
+	var (
+		order         []string
+		queryParams   = request.URL.Query()
+		sortByColumns = queryParams[api.QueryParameterSortBy]
+		domainSID     = mux.Vars(request)[api.URIPathVariableObjectID]
+		exposures     model.PrivilegedSessionExposures
+	)
+
+	for _, column := range sortByColumns {
+		var descending bool
+		if string(column[0]) == "-" {
+			descending = true
+			column = column[1:]
+		}
+
+		if !exposures.IsSortable(column) {
+			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsNotSortable, request), response)
+			return
+		}
+
+		if descending {
+			order = append(order, column+" desc")
+		} else {
+			order = append(order, column)
+		}
+	}
+
+	queryParameterFilterParser := model.NewQueryParameterFilterParser()
+	if queryFilters, err := queryParameterFilterParser.ParseQueryParameterFilters(request); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsBadQueryParameterFilters, request), response)
+		return
+	} else {
+		for name, filters := range queryFilters {
+			if validPredicates, err := exposures.GetValidFilterPredicatesAsStrings(name); err != nil {
+				api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, fmt.Sprintf("%s: %s", api.ErrorResponseDetailsColumnNotFilterable, name), request), response)
+				return
+			} else {
+				for i, filter := range filters {
+					if !gormutils.Contains(validPredicates, string(filter.Operator)) {
+						api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, fmt.Sprintf("%s: %s %s", api.ErrorResponseDetailsFilterPredicateNotSupported, filter.Name, filter.Operator), request), response)
+						return
+					}
+
+					queryFilters[name][i].IsStringData = exposures.IsString(filter.Name)
+				}
+			}
+		}
+
+		if sqlFilter, err := queryFilters.BuildSQLFilter(); err != nil {
+			api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, "error building SQL for filter", request), response)
+		} else if skip, err := ParseSkipQueryParameter(queryParams, 0); err != nil {
+			api.WriteErrorResponse(request.Context(), ErrBadQueryParameter(request, model.PaginationQueryParameterSkip, err), response)
+		} else if limit, err := ParseLimitQueryParameter(queryParams, 100); err != nil {
+			api.WriteErrorResponse(request.Context(), ErrBadQueryParameter(request, model.PaginationQueryParameterLimit, err), response)
+		} else if exposures, count, err := s.DB.GetPrivilegedSessionExposures(request.Context(), domainSID, strings.Join(order, ", "), sqlFilter, skip, limit); err != nil {
+			api.HandleDatabaseError(request, response, err)
+		} else {
+			api.WriteResponseWrapperWithPagination(request.Context(), exposures, limit, skip, count, http.StatusOK, response)
+		}
+	}
+}
+
+// ExportADDomainSessionExposures writes every privileged session exposure recorded for the domain as a CSV attachment
+func (s Resources) ExportADDomainSessionExposures(response http.ResponseWriter, request *http.Request) {
+	domainSID := mux.Vars(request)[api.URIPathVariableObjectID]
+
+	if exposures, _, err := s.DB.GetPrivilegedSessionExposures(request.Context(), domainSID, "", model.SQLFilter{}, 0, 0); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else {
+		response.Header().Set(headers.ContentDisposition.String(), fmt.Sprintf(utils.ContentDispositionAttachmentTemplate, fmt.Sprintf("session-exposures-%s.csv", domainSID)))
+		api.WriteCSVResponse(request.Context(), exposures, http.StatusOK, response)
+	}
+}
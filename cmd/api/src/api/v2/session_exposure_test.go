@@ -0,0 +1,112 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v2_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/specterops/bloodhound/headers"
+	"github.com/specterops/bloodhound/mediatypes"
+	"github.com/specterops/bloodhound/src/api"
+	v2 "github.com/specterops/bloodhound/src/api/v2"
+	"github.com/specterops/bloodhound/src/database/mocks"
+	"github.com/specterops/bloodhound/src/model"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const sessionExposureDomainSID = "S-1-5-21-1"
+
+var testSessionExposures = model.PrivilegedSessionExposures{{
+	DomainSID:               sessionExposureDomainSID,
+	ComputerObjectID:        "S-1-5-21-1-1001",
+	ComputerName:            "WS01.TESTLAB.LOCAL",
+	AssetGroupTag:           "admin_tier_0",
+	ExposedUserCount:        2,
+	ExposedUsers:            []string{"S-1-5-21-1-500", "S-1-5-21-1-1105"},
+	ReachablePrincipalCount: 42,
+}}
+
+func serveSessionExposures(t *testing.T, resources v2.Resources, endpoint string, handler http.HandlerFunc, params url.Values) *httptest.ResponseRecorder {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf(endpoint, sessionExposureDomainSID), nil)
+	require.Nil(t, err)
+
+	req.URL.RawQuery = params.Encode()
+
+	router := mux.NewRouter()
+	router.HandleFunc(fmt.Sprintf(endpoint, fmt.Sprintf("{%s}", api.URIPathVariableObjectID)), handler).Methods(http.MethodGet)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, req)
+	return response
+}
+
+func TestResources_ListADDomainSessionExposures_SortingError(t *testing.T) {
+	resources := v2.Resources{}
+	response := serveSessionExposures(t, resources, "/api/v2/domains/%s/session-exposures", resources.ListADDomainSessionExposures, url.Values{"sort_by": []string{"exposed_users"}})
+
+	require.Equal(t, http.StatusBadRequest, response.Code)
+	require.Contains(t, response.Body.String(), api.ErrorResponseDetailsNotSortable)
+}
+
+func TestResources_ListADDomainSessionExposures_InvalidFilterPredicate(t *testing.T) {
+	resources := v2.Resources{}
+	response := serveSessionExposures(t, resources, "/api/v2/domains/%s/session-exposures", resources.ListADDomainSessionExposures, url.Values{"computer_name": []string{"gt:WS01"}})
+
+	require.Equal(t, http.StatusBadRequest, response.Code)
+	require.Contains(t, response.Body.String(), api.ErrorResponseDetailsFilterPredicateNotSupported)
+}
+
+func TestResources_ListADDomainSessionExposures(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockDB    = mocks.NewMockDatabase(mockCtrl)
+		resources = v2.Resources{DB: mockDB}
+	)
+	defer mockCtrl.Finish()
+
+	mockDB.EXPECT().GetPrivilegedSessionExposures(gomock.Any(), sessionExposureDomainSID, "reachable_principal_count desc", gomock.Any(), 0, 100).Return(testSessionExposures, 1, nil)
+
+	response := serveSessionExposures(t, resources, "/api/v2/domains/%s/session-exposures", resources.ListADDomainSessionExposures, url.Values{"sort_by": []string{"-reachable_principal_count"}})
+
+	require.Equal(t, http.StatusOK, response.Code)
+	require.Contains(t, response.Body.String(), `"computer_object_id":"S-1-5-21-1-1001"`)
+	require.Contains(t, response.Body.String(), `"reachable_principal_count":42`)
+}
+
+func TestResources_ExportADDomainSessionExposures(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockDB    = mocks.NewMockDatabase(mockCtrl)
+		resources = v2.Resources{DB: mockDB}
+	)
+	defer mockCtrl.Finish()
+
+	mockDB.EXPECT().GetPrivilegedSessionExposures(gomock.Any(), sessionExposureDomainSID, "", model.SQLFilter{}, 0, 0).Return(testSessionExposures, 1, nil)
+
+	response := serveSessionExposures(t, resources, "/api/v2/domains/%s/session-exposures/export", resources.ExportADDomainSessionExposures, url.Values{})
+
+	require.Equal(t, http.StatusOK, response.Code)
+	require.Equal(t, mediatypes.TextCsv.String(), response.Header().Get(headers.ContentType.String()))
+	require.Equal(t, "domain_sid,computer_object_id,computer_name,asset_group_tag,exposed_user_count,exposed_users,reachable_principal_count\n"+
+		"S-1-5-21-1,S-1-5-21-1-1001,WS01.TESTLAB.LOCAL,admin_tier_0,2,S-1-5-21-1-500 S-1-5-21-1-1105,42\n", response.Body.String())
+}
@@ -0,0 +1,114 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v2_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/specterops/bloodhound/src/api"
+	v2 "github.com/specterops/bloodhound/src/api/v2"
+	"github.com/specterops/bloodhound/src/database"
+	"github.com/specterops/bloodhound/src/database/mocks"
+	"github.com/specterops/bloodhound/src/model"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const tierZeroViolationsEndpoint = "/api/v2/tier-zero/violations"
+
+func serveTierZeroViolations(t *testing.T, resources v2.Resources, params url.Values) *httptest.ResponseRecorder {
+	req, err := http.NewRequest(http.MethodGet, tierZeroViolationsEndpoint, nil)
+	require.Nil(t, err)
+
+	req.URL.RawQuery = params.Encode()
+
+	router := mux.NewRouter()
+	router.HandleFunc(tierZeroViolationsEndpoint, resources.ListTierZeroViolations).Methods(http.MethodGet)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, req)
+	return response
+}
+
+func TestResources_ListTierZeroViolations_SortingError(t *testing.T) {
+	response := serveTierZeroViolations(t, v2.Resources{}, url.Values{"sort_by": []string{"invalidColumn"}})
+
+	require.Equal(t, http.StatusBadRequest, response.Code)
+	require.Contains(t, response.Body.String(), api.ErrorResponseDetailsNotSortable)
+}
+
+func TestResources_ListTierZeroViolations_InvalidFilterColumn(t *testing.T) {
+	response := serveTierZeroViolations(t, v2.Resources{}, url.Values{"foo": []string{"eq:bar"}})
+
+	require.Equal(t, http.StatusBadRequest, response.Code)
+	require.Contains(t, response.Body.String(), api.ErrorResponseDetailsColumnNotFilterable)
+}
+
+func TestResources_ListTierZeroViolations_InvalidFilterPredicate(t *testing.T) {
+	response := serveTierZeroViolations(t, v2.Resources{}, url.Values{"name": []string{"gt:bar"}})
+
+	require.Equal(t, http.StatusBadRequest, response.Code)
+	require.Contains(t, response.Body.String(), api.ErrorResponseDetailsFilterPredicateNotSupported)
+}
+
+func TestResources_ListTierZeroViolations_NoRun(t *testing.T) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		mockDB   = mocks.NewMockDatabase(mockCtrl)
+	)
+	defer mockCtrl.Finish()
+
+	mockDB.EXPECT().GetLatestTierZeroViolationRun(gomock.Any()).Return(model.TierZeroViolationRun{}, database.ErrNotFound)
+
+	response := serveTierZeroViolations(t, v2.Resources{DB: mockDB}, url.Values{})
+
+	require.Equal(t, http.StatusOK, response.Code)
+	require.JSONEq(t, `{"count":0,"limit":10000,"skip":0,"data":[]}`, response.Body.String())
+}
+
+func TestResources_ListTierZeroViolations(t *testing.T) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		mockDB   = mocks.NewMockDatabase(mockCtrl)
+		run      = model.TierZeroViolationRun{ViolationCount: 1}
+	)
+	defer mockCtrl.Finish()
+
+	run.ID = 7
+
+	mockDB.EXPECT().GetLatestTierZeroViolationRun(gomock.Any()).Return(run, nil)
+	mockDB.EXPECT().ListTierZeroViolations(gomock.Any(), int64(7), "depth desc", gomock.Any(), 0, 10).Return(model.TierZeroViolations{{
+		RunID:            7,
+		ObjectID:         "S-1-5-21-1-1105",
+		Name:             "BOB@TESTLAB.LOCAL",
+		NodeLabel:        "User",
+		TierZeroObjectID: "S-1-5-21-1-512",
+		TierZeroName:     "DOMAIN ADMINS@TESTLAB.LOCAL",
+		RelationshipKind: "GenericAll",
+		Depth:            1,
+	}}, 1, nil)
+
+	response := serveTierZeroViolations(t, v2.Resources{DB: mockDB}, url.Values{"sort_by": []string{"-depth"}, "limit": []string{"10"}})
+
+	require.Equal(t, http.StatusOK, response.Code)
+	require.Contains(t, response.Body.String(), `"object_id":"S-1-5-21-1-1105"`)
+	require.Contains(t, response.Body.String(), `"count":1`)
+}
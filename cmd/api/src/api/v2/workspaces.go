@@ -0,0 +1,170 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v2
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/mux"
+	"github.com/specterops/bloodhound/dawgs/drivers/pg"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/log"
+	"github.com/specterops/bloodhound/src/api"
+	"github.com/specterops/bloodhound/src/auth"
+	"github.com/specterops/bloodhound/src/ctx"
+	"github.com/specterops/bloodhound/src/model"
+	"github.com/specterops/bloodhound/src/services/workspace"
+)
+
+const ErrorResponseWorkspacesNotSupported = "workspaces are only available for the PostgreSQL graph driver"
+
+type WorkspaceRequest struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	UserIDs     []uuid.UUID `json:"user_ids"`
+}
+
+// readWorkspaceRequest applies the workspace request in the body of the given request to the target workspace and
+// validates the result. Returned errors are suitable for reporting back to the client.
+func readWorkspaceRequest(request *http.Request, target model.Workspace) (model.Workspace, error) {
+	var workspaceRequest WorkspaceRequest
+
+	if err := api.ReadJSONRequestPayloadLimited(&workspaceRequest, request); err != nil {
+		return target, errors.New(api.ErrorResponsePayloadUnmarshalError)
+	}
+
+	target.Name = strings.TrimSpace(workspaceRequest.Name)
+	target.Description = workspaceRequest.Description
+	target.UserIDs = workspaceRequest.UserIDs
+
+	if target.UserIDs == nil {
+		target.UserIDs = []uuid.UUID{}
+	}
+
+	return target, workspace.Validate(target)
+}
+
+// newWorkspaceFor returns an empty workspace created by the user of the given auth context, if any
+func newWorkspaceFor(authCtx auth.Context) model.Workspace {
+	var newWorkspace model.Workspace
+
+	if user, isUser := auth.GetUserFromAuthCtx(authCtx); isUser {
+		newWorkspace.CreatedBy = uuid.NullUUID{UUID: user.ID, Valid: true}
+	}
+
+	return newWorkspace
+}
+
+type ListWorkspacesResponse struct {
+	Workspaces model.Workspaces `json:"workspaces"`
+}
+
+// ListWorkspaces returns the default workspace followed by every named workspace that the requesting user may select.
+func (s Resources) ListWorkspaces(response http.ResponseWriter, request *http.Request) {
+	if workspaces, err := workspace.All(request.Context(), s.DB); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else {
+		var (
+			authCtx    = ctx.FromRequest(request).AuthCtx
+			accessible = make(model.Workspaces, 0, len(workspaces))
+		)
+
+		for _, candidate := range workspaces {
+			if workspace.Accessible(s.Authorizer, authCtx, candidate) {
+				accessible = append(accessible, candidate)
+			}
+		}
+
+		api.WriteBasicResponse(request.Context(), ListWorkspacesResponse{Workspaces: accessible}, http.StatusOK, response)
+	}
+}
+
+// CreateWorkspace persists a new workspace and creates the graph that backs it. Workspaces are only supported by the
+// PostgreSQL graph driver as Neo4j does not support multiple graph namespaces within the same database. The driver is
+// checked at runtime as the graph database may be switched while the server is running.
+func (s Resources) CreateWorkspace(response http.ResponseWriter, request *http.Request) {
+	if _, isPG := graph.UnwrapDatabase(s.Graph).(*pg.Driver); !isPG {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusNotImplemented, ErrorResponseWorkspacesNotSupported, request), response)
+	} else if newWorkspace, err := readWorkspaceRequest(request, newWorkspaceFor(ctx.FromRequest(request).AuthCtx)); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+	} else if graphManager, isManager := s.Graph.(graph.GraphManager); !isManager {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusNotImplemented, ErrorResponseWorkspacesNotSupported, request), response)
+	} else if createdWorkspace, err := s.DB.CreateWorkspace(request.Context(), newWorkspace); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if err := graphManager.AssertGraph(request.Context(), workspace.GraphSchema(createdWorkspace)); err != nil {
+		log.Errorf("Failed creating graph %s for workspace %d: %v", createdWorkspace.GraphName, createdWorkspace.ID, err)
+
+		// Remove the workspace again so that it does not refer to a missing graph
+		if err := s.DB.DeleteWorkspace(request.Context(), createdWorkspace); err != nil {
+			log.Errorf("Failed removing workspace %d after graph creation failure: %v", createdWorkspace.ID, err)
+		}
+
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, api.ErrorResponseDetailsInternalServerError, request), response)
+	} else {
+		api.WriteBasicResponse(request.Context(), createdWorkspace, http.StatusCreated, response)
+	}
+}
+
+// UpdateWorkspace changes the name, description and members of a workspace. The default workspace may not be changed.
+func (s Resources) UpdateWorkspace(response http.ResponseWriter, request *http.Request) {
+	if existingWorkspace, ok := s.lookupWorkspace(response, request); !ok {
+		return
+	} else if updatedWorkspace, err := readWorkspaceRequest(request, existingWorkspace); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, err.Error(), request), response)
+	} else if err := s.DB.UpdateWorkspace(request.Context(), updatedWorkspace); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else {
+		api.WriteBasicResponse(request.Context(), updatedWorkspace, http.StatusOK, response)
+	}
+}
+
+// DeleteWorkspace removes a workspace, every row that it owns and the graph that backs it. The default workspace may
+// not be deleted.
+func (s Resources) DeleteWorkspace(response http.ResponseWriter, request *http.Request) {
+	if existingWorkspace, ok := s.lookupWorkspace(response, request); !ok {
+		return
+	} else if graphManager, isManager := s.Graph.(graph.GraphManager); !isManager {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusNotImplemented, ErrorResponseWorkspacesNotSupported, request), response)
+	} else if err := s.DB.DeleteWorkspace(request.Context(), existingWorkspace); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else if err := graphManager.DropGraph(request.Context(), existingWorkspace.GraphName); err != nil && !errors.Is(err, graph.ErrGraphManagementUnsupported) {
+		log.Errorf("Failed dropping graph %s of deleted workspace %d: %v", existingWorkspace.GraphName, existingWorkspace.ID, err)
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusInternalServerError, api.ErrorResponseDetailsInternalServerError, request), response)
+	} else {
+		response.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// lookupWorkspace fetches the named workspace identified by the request path, writing an error response if it can not
+// be found. The implicit default workspace is never found.
+func (s Resources) lookupWorkspace(response http.ResponseWriter, request *http.Request) (model.Workspace, bool) {
+	rawWorkspaceID := mux.Vars(request)[api.URIPathVariableWorkspaceID]
+
+	if workspaceID, err := strconv.ParseInt(rawWorkspaceID, 10, 32); err != nil {
+		api.WriteErrorResponse(request.Context(), api.BuildErrorResponse(http.StatusBadRequest, api.ErrorResponseDetailsIDMalformed, request), response)
+	} else if existingWorkspace, err := s.DB.GetWorkspace(request.Context(), int32(workspaceID)); err != nil {
+		api.HandleDatabaseError(request, response, err)
+	} else {
+		return existingWorkspace, true
+	}
+
+	return model.Workspace{}, false
+}
@@ -0,0 +1,248 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v2_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/specterops/bloodhound/dawgs/drivers/pg"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	graphMocks "github.com/specterops/bloodhound/dawgs/graph/mocks"
+	"github.com/specterops/bloodhound/headers"
+	"github.com/specterops/bloodhound/mediatypes"
+	"github.com/specterops/bloodhound/src/api"
+	v2 "github.com/specterops/bloodhound/src/api/v2"
+	"github.com/specterops/bloodhound/src/api/v2/apitest"
+	"github.com/specterops/bloodhound/src/auth"
+	"github.com/specterops/bloodhound/src/config"
+	"github.com/specterops/bloodhound/src/database"
+	dbMocks "github.com/specterops/bloodhound/src/database/mocks"
+	"github.com/specterops/bloodhound/src/model"
+	"go.uber.org/mock/gomock"
+)
+
+func TestResources_ListWorkspaces(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockDB    = dbMocks.NewMockDatabase(mockCtrl)
+		resources = v2.Resources{DB: mockDB, Authorizer: auth.NewAuthorizer(mockDB)}
+		user      = setupUser()
+		otherUser = uuid.Must(uuid.NewV4())
+	)
+	defer mockCtrl.Finish()
+
+	user.ID = uuid.Must(uuid.NewV4())
+
+	apitest.NewHarness(t, resources.ListWorkspaces).
+		WithCommonRequest(func(input *apitest.Input) {
+			apitest.SetContext(input, setupUserCtx(user))
+		}).
+		Run([]apitest.Case{
+			{
+				Name: "DatabaseError",
+				Setup: func() {
+					mockDB.EXPECT().GetAllWorkspaces(gomock.Any()).Return(nil, errors.New("database error"))
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusInternalServerError)
+				},
+			},
+			{
+				Name: "Success",
+				Setup: func() {
+					mockDB.EXPECT().GetAllWorkspaces(gomock.Any()).Return(model.Workspaces{
+						{Name: "Created", GraphName: "workspace_1", CreatedBy: uuid.NullUUID{UUID: user.ID, Valid: true}, UserIDs: []uuid.UUID{}, Serial: model.Serial{ID: 1}},
+						{Name: "Member", GraphName: "workspace_2", UserIDs: []uuid.UUID{user.ID}, Serial: model.Serial{ID: 2}},
+						{Name: "Restricted", GraphName: "workspace_3", UserIDs: []uuid.UUID{otherUser}, Serial: model.Serial{ID: 3}},
+						{Name: "Unshared", GraphName: "workspace_4", CreatedBy: uuid.NullUUID{UUID: otherUser, Valid: true}, UserIDs: []uuid.UUID{}, Serial: model.Serial{ID: 4}},
+					}, nil)
+				},
+				Test: func(output apitest.Output) {
+					var result v2.ListWorkspacesResponse
+
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.UnmarshalData(output, &result)
+					apitest.Equal(output, 3, len(result.Workspaces))
+					apitest.Equal(output, model.DefaultWorkspaceGraphName, result.Workspaces[0].GraphName)
+					apitest.Equal(output, "Created", result.Workspaces[1].Name)
+					apitest.Equal(output, "Member", result.Workspaces[2].Name)
+				},
+			},
+		})
+}
+
+func TestResources_CreateWorkspace(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockDB    = dbMocks.NewMockDatabase(mockCtrl)
+		resources = v2.Resources{DB: mockDB, Graph: graph.NewDatabaseSwitch(context.Background(), &pg.Driver{})}
+	)
+	defer mockCtrl.Finish()
+
+	apitest.NewHarness(t, resources.CreateWorkspace).
+		WithCommonRequest(func(input *apitest.Input) {
+			apitest.SetHeader(input, headers.ContentType.String(), mediatypes.ApplicationJson.String())
+		}).
+		Run([]apitest.Case{
+			{
+				Name: "InvalidPayload",
+				Input: func(input *apitest.Input) {
+					apitest.BodyString(input, "not json")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, api.ErrorResponsePayloadUnmarshalError)
+				},
+			},
+			{
+				Name: "EmptyName",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.WorkspaceRequest{Name: "  "})
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "workspace name must not be empty")
+				},
+			},
+			{
+				Name: "ReservedName",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.WorkspaceRequest{Name: "default"})
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, "is reserved")
+				},
+			},
+		})
+}
+
+func TestResources_CreateWorkspace_Neo4j(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		graphDB   = graph.NewDatabaseSwitch(context.Background(), &pg.Driver{})
+		resources = v2.Resources{
+			DB:     dbMocks.NewMockDatabase(mockCtrl),
+			Graph:  graphDB,
+			Config: config.Configuration{GraphDriver: pg.DriverName},
+		}
+	)
+	defer mockCtrl.Finish()
+
+	apitest.NewHarness(t, resources.CreateWorkspace).
+		Run([]apitest.Case{
+			{
+				Name: "NotImplemented",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.WorkspaceRequest{Name: "Engagement"})
+				},
+				Setup: func() {
+					// The graph database was switched to Neo4j after the server started with PostgreSQL
+					graphDB.Switch(graphMocks.NewMockDatabase(mockCtrl))
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusNotImplemented)
+					apitest.BodyContains(output, v2.ErrorResponseWorkspacesNotSupported)
+				},
+			},
+		})
+}
+
+func TestResources_UpdateWorkspace(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockDB    = dbMocks.NewMockDatabase(mockCtrl)
+		resources = v2.Resources{DB: mockDB}
+		memberID  = uuid.Must(uuid.NewV4())
+	)
+	defer mockCtrl.Finish()
+
+	apitest.NewHarness(t, resources.UpdateWorkspace).
+		WithCommonRequest(func(input *apitest.Input) {
+			apitest.SetHeader(input, headers.ContentType.String(), mediatypes.ApplicationJson.String())
+			apitest.SetURLVar(input, api.URIPathVariableWorkspaceID, "1")
+		}).
+		Run([]apitest.Case{
+			{
+				Name: "MalformedID",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, api.URIPathVariableWorkspaceID, "one")
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusBadRequest)
+					apitest.BodyContains(output, api.ErrorResponseDetailsIDMalformed)
+				},
+			},
+			{
+				Name: "NotFound",
+				Setup: func() {
+					mockDB.EXPECT().GetWorkspace(gomock.Any(), int32(1)).Return(model.Workspace{}, database.ErrNotFound)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusNotFound)
+				},
+			},
+			{
+				Name: "Success",
+				Input: func(input *apitest.Input) {
+					apitest.BodyStruct(input, v2.WorkspaceRequest{Name: "Renamed", UserIDs: []uuid.UUID{memberID}})
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetWorkspace(gomock.Any(), int32(1)).Return(model.Workspace{Name: "Engagement", GraphName: "workspace_1", Serial: model.Serial{ID: 1}}, nil)
+					mockDB.EXPECT().UpdateWorkspace(gomock.Any(), model.Workspace{
+						Name:      "Renamed",
+						GraphName: "workspace_1",
+						UserIDs:   []uuid.UUID{memberID},
+						Serial:    model.Serial{ID: 1},
+					}).Return(nil)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusOK)
+					apitest.BodyContains(output, `"graph_name":"workspace_1"`)
+				},
+			},
+		})
+}
+
+func TestResources_DeleteWorkspace(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockDB    = dbMocks.NewMockDatabase(mockCtrl)
+		resources = v2.Resources{DB: mockDB}
+	)
+	defer mockCtrl.Finish()
+
+	apitest.NewHarness(t, resources.DeleteWorkspace).
+		Run([]apitest.Case{
+			{
+				Name: "DefaultWorkspace",
+				Input: func(input *apitest.Input) {
+					apitest.SetURLVar(input, api.URIPathVariableWorkspaceID, "0")
+				},
+				Setup: func() {
+					mockDB.EXPECT().GetWorkspace(gomock.Any(), int32(0)).Return(model.Workspace{}, database.ErrNotFound)
+				},
+				Test: func(output apitest.Output) {
+					apitest.StatusCode(output, http.StatusNotFound)
+				},
+			},
+		})
+}
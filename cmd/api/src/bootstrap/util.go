@@ -22,6 +22,8 @@ import (
 	"os"
 
 	"github.com/specterops/bloodhound/dawgs"
+	"github.com/specterops/bloodhound/dawgs/changefeed"
+	"github.com/specterops/bloodhound/dawgs/drivers/memory"
 	"github.com/specterops/bloodhound/dawgs/drivers/neo4j"
 	"github.com/specterops/bloodhound/dawgs/drivers/pg"
 	"github.com/specterops/bloodhound/dawgs/graph"
@@ -72,7 +74,9 @@ func DefaultConfigFilePath() string {
 	return "/etc/bhapi/bhapi.json"
 }
 
-func ConnectGraph(ctx context.Context, cfg config.Configuration) (*graph.DatabaseSwitch, error) {
+// ConnectGraph opens the configured graph database. If any change feed sinks are given the database is wrapped so
+// that committed graph mutations are published to them.
+func ConnectGraph(ctx context.Context, cfg config.Configuration, sinks ...changefeed.Sink) (*graph.DatabaseSwitch, error) {
 	var connectionString string
 
 	if driverName, err := tools.LookupGraphDriver(ctx, cfg); err != nil {
@@ -87,11 +91,14 @@ func ConnectGraph(ctx context.Context, cfg config.Configuration) (*graph.Databas
 			log.Infof("Connecting to graph using PostgreSQL")
 			connectionString = cfg.Database.PostgreSQLConnectionString()
 
+		case memory.DriverName:
+			log.Warnf("Connecting to graph using the in-memory driver. Graph data will not persist across restarts")
+
 		default:
 			return nil, fmt.Errorf("unknown graphdb driver name: %s", driverName)
 		}
 
-		if connectionString == "" {
+		if connectionString == "" && driverName != memory.DriverName {
 			return nil, fmt.Errorf("graph connection requires a connection url to be set")
 		} else if graphDatabase, err := dawgs.Open(ctx, driverName, dawgs.Config{
 			TraversalMemoryLimit: size.Size(cfg.TraversalMemoryLimit) * size.Gibibyte,
@@ -99,6 +106,16 @@ func ConnectGraph(ctx context.Context, cfg config.Configuration) (*graph.Databas
 		}); err != nil {
 			return nil, err
 		} else {
+			if pgDriver, isPG := graphDatabase.(*pg.Driver); isPG && cfg.EnableGraphBulkLoad {
+				log.Infof("PostgreSQL graph batch operations will bulk load using COPY")
+				pgDriver.SetBulkLoad(true)
+			}
+
+			if len(sinks) > 0 {
+				log.Infof("Publishing graph mutations to %d change feed sink(s)", len(sinks))
+				graphDatabase = changefeed.Wrap(graphDatabase, sinks...)
+			}
+
 			return graph.NewDatabaseSwitch(ctx, graphDatabase), nil
 		}
 	}
@@ -177,6 +177,9 @@ func (s *TestCase) execute(db graph.Database) error {
 	case func(batch graph.Batch) error:
 		return db.BatchOperation(context.Background(), typedDelegate)
 
+	case func(db graph.Database) error:
+		return typedDelegate(db)
+
 	default:
 		panic(fmt.Sprintf("Bad test case delegate type: %T", s.Delegate))
 	}
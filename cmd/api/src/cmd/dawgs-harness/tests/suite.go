@@ -41,6 +41,9 @@ func RunSuite(db graph.Database, driverName string) (TestSuite, error) {
 
 	suite.NewTestCase("Node and Relationship Creation", NodeAndRelationshipCreationTest)
 	suite.NewTestCase("Batch Node and Relationship Creation", BatchNodeAndRelationshipCreationTest)
+	suite.NewTestCase("Bulk Batch Node and Relationship Creation", BulkBatchNodeAndRelationshipCreationTest)
+	suite.NewTestCase("Batch Node Creation", BatchNodeCreationTest)
+	suite.NewTestCase("Bulk Batch Node Creation", BulkBatchNodeCreationTest)
 
 	suite.NewTestCase("Fetch Nodes by ID", FetchNodesByID)
 	suite.NewTestCase("Fetch Nodes by Filter Item", FetchNodesByProperty(common.ObjectID.String(), SimpleRelationshipsToCreate/4))
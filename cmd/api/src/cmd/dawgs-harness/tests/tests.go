@@ -17,6 +17,7 @@
 package tests
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 
@@ -371,14 +372,51 @@ func NodeUpdateTests(testCase *TestCase) any {
 	}
 }
 
-func BatchNodeAndRelationshipCreationTest(testCase *TestCase) any {
+// BulkLoader is implemented by drivers that support an opt-in bulk load path for batch operations
+type BulkLoader interface {
+	SetBulkLoad(enabled bool)
+}
+
+// withBulkLoad runs the given batch delegate with the driver's bulk load path enabled. Drivers that do not support
+// bulk loading run the delegate as a regular batch operation.
+func withBulkLoad(delegate func(batch graph.Batch) error) func(db graph.Database) error {
+	return func(db graph.Database) error {
+		if bulkLoader, supported := db.(BulkLoader); supported {
+			bulkLoader.SetBulkLoad(true)
+			defer bulkLoader.SetBulkLoad(false)
+		}
+
+		return db.BatchOperation(context.Background(), delegate)
+	}
+}
+
+func batchNodeCreation(testCase *TestCase, namePrefix string) func(batch graph.Batch) error {
+	return func(batch graph.Batch) error {
+		for iteration := 0; iteration < SimpleRelationshipsToCreate; iteration++ {
+			nodePropertyValue := namePrefix + " node " + strconv.Itoa(iteration)
+
+			if err := testCase.Sample(func() error {
+				return batch.CreateNode(graph.PrepareNode(graph.AsProperties(graph.PropertyMap{
+					common.Name:     nodePropertyValue,
+					common.ObjectID: nodePropertyValue,
+				}), ad.Entity, ad.Computer))
+			}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func batchNodeAndRelationshipCreation(testCase *TestCase, namePrefix string) func(batch graph.Batch) error {
 	return func(batch graph.Batch) error {
 		for iteration := 0; iteration < SimpleRelationshipsToCreate; iteration++ {
 			var (
 				iterationStr              = strconv.Itoa(iteration)
-				startNodePropertyValue    = "batch start node " + iterationStr
-				endNodePropertyValue      = "batch end node " + iterationStr
-				relationshipPropertyValue = "batch relationship " + iterationStr
+				startNodePropertyValue    = namePrefix + " start node " + iterationStr
+				endNodePropertyValue      = namePrefix + " end node " + iterationStr
+				relationshipPropertyValue = namePrefix + " relationship " + iterationStr
 			)
 
 			var (
@@ -415,6 +453,22 @@ func BatchNodeAndRelationshipCreationTest(testCase *TestCase) any {
 	}
 }
 
+func BatchNodeAndRelationshipCreationTest(testCase *TestCase) any {
+	return batchNodeAndRelationshipCreation(testCase, "batch")
+}
+
+func BulkBatchNodeAndRelationshipCreationTest(testCase *TestCase) any {
+	return withBulkLoad(batchNodeAndRelationshipCreation(testCase, "bulk batch"))
+}
+
+func BatchNodeCreationTest(testCase *TestCase) any {
+	return batchNodeCreation(testCase, "batch created")
+}
+
+func BulkBatchNodeCreationTest(testCase *TestCase) any {
+	return withBulkLoad(batchNodeCreation(testCase, "bulk batch created"))
+}
+
 func NodeAndRelationshipCreationTest(testCase *TestCase) any {
 	return func(tx graph.Transaction) error {
 		for iteration := 0; iteration < SimpleRelationshipsToCreate; iteration++ {
@@ -36,6 +36,10 @@ const (
 	CurrentConfigurationVersion = 2
 	DefaultLogFilePath          = "/var/log/bhapi.log"
 
+	// DefaultCypherStreamRowLimit is the number of rows a streamed cypher query may return for users whose roles do
+	// not have a configured limit
+	DefaultCypherStreamRowLimit = 100_000
+
 	bhAPIEnvironmentVariablePrefix       = "bhe"
 	environmentVariablePathSeparator     = "_"
 	environmentVariableKeyValueSeparator = "="
@@ -168,13 +172,42 @@ type Configuration struct {
 	DisableIngest           bool                      `json:"disable_ingest"`
 	DisableMigrations       bool                      `json:"disable_migrations"`
 	TraversalMemoryLimit    uint16                    `json:"traversal_memory_limit"`
+	EnableGraphBulkLoad     bool                      `json:"enable_graph_bulk_load"`
+	EnableGraphChangeFeed   bool                      `json:"enable_graph_change_feed"`
+	PathfindingEdgeCosts    map[string]float64        `json:"pathfinding_edge_costs"`
 	AuthSessionTTLHours     int                       `json:"auth_session_ttl_hours"`
+	CypherStreamRowLimits   map[string]int            `json:"cypher_stream_row_limits"`
 }
 
 func (s Configuration) AuthSessionTTL() time.Duration {
 	return time.Hour * time.Duration(s.AuthSessionTTLHours)
 }
 
+// CypherStreamRowLimit returns the number of rows a streamed cypher query may return for a user with the given roles.
+// Users with more than one role receive the highest limit of their roles. Roles without a positive configured limit
+// receive DefaultCypherStreamRowLimit.
+func (s Configuration) CypherStreamRowLimit(roleNames ...string) int {
+	rowLimit := 0
+
+	for _, roleName := range roleNames {
+		roleLimit, hasLimit := s.CypherStreamRowLimits[roleName]
+
+		if !hasLimit || roleLimit <= 0 {
+			roleLimit = DefaultCypherStreamRowLimit
+		}
+
+		if roleLimit > rowLimit {
+			rowLimit = roleLimit
+		}
+	}
+
+	if rowLimit == 0 {
+		return DefaultCypherStreamRowLimit
+	}
+
+	return rowLimit
+}
+
 func (s Configuration) TempDirectory() string {
 	return filepath.Join(s.WorkDir, "tmp")
 }
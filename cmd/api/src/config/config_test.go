@@ -177,3 +177,21 @@ func TestSetValuesFromEnv(t *testing.T) {
 		})
 	})
 }
+
+func TestConfiguration_CypherStreamRowLimit(t *testing.T) {
+	cfg := config.Configuration{
+		CypherStreamRowLimits: map[string]int{
+			"Read-Only":     10,
+			"User":          100,
+			"Administrator": 0,
+		},
+	}
+
+	assert.Equal(t, 10, cfg.CypherStreamRowLimit("Read-Only"))
+	assert.Equal(t, 100, cfg.CypherStreamRowLimit("Read-Only", "User"))
+
+	// Roles without a positive limit and users without roles receive the default limit
+	assert.Equal(t, config.DefaultCypherStreamRowLimit, cfg.CypherStreamRowLimit("Administrator"))
+	assert.Equal(t, config.DefaultCypherStreamRowLimit, cfg.CypherStreamRowLimit("Power User", "Read-Only"))
+	assert.Equal(t, config.DefaultCypherStreamRowLimit, cfg.CypherStreamRowLimit())
+}
@@ -64,6 +64,12 @@ func NewDefaultConfiguration() (Configuration, error) {
 			Neo4J: DatabaseConfiguration{
 				MaxConcurrentSessions: 10,
 			},
+			CypherStreamRowLimits: map[string]int{
+				"Read-Only":     100_000,
+				"User":          1_000_000,
+				"Power User":    5_000_000,
+				"Administrator": 10_000_000,
+			},
 			Crypto: CryptoConfiguration{
 				JWT: JWTConfiguration{
 					SigningKey: jwtSigningKey,
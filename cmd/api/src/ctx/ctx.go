@@ -99,3 +99,18 @@ func SetRequestContext(request *http.Request, bhCtx *Context) *http.Request {
 	newRequestContext := context.WithValue(request.Context(), ValueKey, bhCtx)
 	return request.WithContext(newRequestContext)
 }
+
+type workspaceKey struct{}
+
+// WithWorkspace scopes the given golang context to the workspace with the given ID. Database operations on workspace
+// owned rows will only see rows of this workspace.
+func WithWorkspace(ctx context.Context, workspaceID int32) context.Context {
+	return context.WithValue(ctx, workspaceKey{}, workspaceID)
+}
+
+// WorkspaceID returns the ID of the workspace that the given golang context is scoped to, if any. Contexts that are
+// not scoped to a workspace, such as those of background daemons, operate across every workspace.
+func WorkspaceID(ctx context.Context) (int32, bool) {
+	workspaceID, isScoped := ctx.Value(workspaceKey{}).(int32)
+	return workspaceID, isScoped
+}
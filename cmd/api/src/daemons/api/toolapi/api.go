@@ -42,7 +42,7 @@ type Daemon struct {
 func NewDaemon[DBType database.Database](ctx context.Context, connections bootstrap.DatabaseConnections[DBType, *graph.DatabaseSwitch], cfg config.Configuration, graphSchema graph.Schema, extensions ...func(router *chi.Mux)) Daemon {
 	var (
 		networkTimeout = time.Duration(cfg.NetTimeoutSeconds) * time.Second
-		pgMigrator     = tools.NewPGMigrator(ctx, cfg, graphSchema, connections.Graph)
+		pgMigrator     = tools.NewPGMigrator(ctx, cfg, graphSchema, connections.Graph, connections.RDMS)
 		router         = chi.NewRouter()
 		toolContainer  = tools.NewToolContainer(connections.RDMS)
 	)
@@ -25,13 +25,19 @@ import (
 	"github.com/specterops/bloodhound/analysis"
 	adAnalysis "github.com/specterops/bloodhound/analysis/ad"
 	"github.com/specterops/bloodhound/dawgs/graph"
-	"github.com/specterops/bloodhound/src/analysis/ad"
-	"github.com/specterops/bloodhound/src/analysis/azure"
 	"github.com/specterops/bloodhound/src/config"
 	"github.com/specterops/bloodhound/src/database"
+	"github.com/specterops/bloodhound/src/model"
 	"github.com/specterops/bloodhound/src/model/appcfg"
 	"github.com/specterops/bloodhound/src/services/agi"
+	"github.com/specterops/bloodhound/src/services/attackpaths"
 	"github.com/specterops/bloodhound/src/services/dataquality"
+	"github.com/specterops/bloodhound/src/services/sessionexposure"
+	"github.com/specterops/bloodhound/src/services/tierzero"
+
+	// Imported for the built-in post-processors they register
+	_ "github.com/specterops/bloodhound/src/analysis/ad"
+	_ "github.com/specterops/bloodhound/src/analysis/azure"
 )
 
 var (
@@ -39,66 +45,116 @@ var (
 	ErrAnalysisPartiallyCompleted = errors.New("analysis partially completed")
 )
 
-func RunAnalysisOperations(ctx context.Context, db database.Database, graphDB graph.Database, _ config.Configuration) error {
+// RunAnalysisOperations runs every step of the analysis pipeline, recording the timing, relationship counts and errors
+// of each step into the given run.
+func RunAnalysisOperations(ctx context.Context, db database.Database, graphDB graph.Database, _ config.Configuration, run *model.AnalysisRun) error {
 	var (
 		collectedErrors []error
+		recordErr       = func(err error) {
+			collectedErrors = append(collectedErrors, err)
+			run.AddError(err)
+		}
 	)
 
-	if err := adAnalysis.FixWellKnownNodeTypes(ctx, graphDB); err != nil {
-		collectedErrors = append(collectedErrors, fmt.Errorf("fix well known node types failed: %w", err))
+	if err := measureStep(run, "fix_well_known_node_types", func() error {
+		return adAnalysis.FixWellKnownNodeTypes(ctx, graphDB)
+	}); err != nil {
+		recordErr(fmt.Errorf("fix well known node types failed: %w", err))
 	}
 
-	if err := adAnalysis.RunDomainAssociations(ctx, graphDB); err != nil {
-		collectedErrors = append(collectedErrors, fmt.Errorf("domain association and pruning failed: %w", err))
+	if err := measureStep(run, "domain_associations", func() error {
+		return adAnalysis.RunDomainAssociations(ctx, graphDB)
+	}); err != nil {
+		recordErr(fmt.Errorf("domain association and pruning failed: %w", err))
 	}
 
-	if err := adAnalysis.LinkWellKnownGroups(ctx, graphDB); err != nil {
-		collectedErrors = append(collectedErrors, fmt.Errorf("well known group linking failed: %w", err))
+	if err := measureStep(run, "well_known_group_linking", func() error {
+		return adAnalysis.LinkWellKnownGroups(ctx, graphDB)
+	}); err != nil {
+		recordErr(fmt.Errorf("well known group linking failed: %w", err))
 	}
 
-	if err := updateAssetGroupIsolationTags(ctx, db, graphDB); err != nil {
-		collectedErrors = append(collectedErrors, fmt.Errorf("asset group isolation tagging failed: %w", err))
+	if err := measureStep(run, "asset_group_isolation_tagging", func() error {
+		return updateAssetGroupIsolationTags(ctx, db, graphDB)
+	}); err != nil {
+		recordErr(fmt.Errorf("asset group isolation tagging failed: %w", err))
 	}
 
-	if err := TagActiveDirectoryTierZero(ctx, graphDB); err != nil {
-		collectedErrors = append(collectedErrors, fmt.Errorf("active directory tier zero tagging failed: %w", err))
+	if err := measureStep(run, "ad_tier_zero_tagging", func() error {
+		return TagActiveDirectoryTierZero(ctx, graphDB)
+	}); err != nil {
+		recordErr(fmt.Errorf("active directory tier zero tagging failed: %w", err))
 	}
 
-	if err := ParallelTagAzureTierZero(ctx, graphDB); err != nil {
-		collectedErrors = append(collectedErrors, fmt.Errorf("azure tier zero tagging failed: %w", err))
+	if err := measureStep(run, "azure_tier_zero_tagging", func() error {
+		return ParallelTagAzureTierZero(ctx, graphDB)
+	}); err != nil {
+		recordErr(fmt.Errorf("azure tier zero tagging failed: %w", err))
 	}
 
 	var (
-		adFailed          = false
-		azureFailed       = false
+		postFailed        = false
+		tierZeroFailed    = false
+		sessionsFailed    = false
+		findingsFailed    = false
 		agiFailed         = false
 		dataQualityFailed = false
 	)
 
-	// TODO: Cleanup #ADCSFeatureFlag after full launch.
-	if adcsFlag, err := db.GetFlagByKey(ctx, appcfg.FeatureAdcs); err != nil {
-		collectedErrors = append(collectedErrors, fmt.Errorf("error retrieving ADCS feature flag: %w", err))
-	} else if stats, err := ad.Post(ctx, graphDB, adcsFlag.Enabled); err != nil {
-		collectedErrors = append(collectedErrors, fmt.Errorf("error during ad post: %w", err))
-		adFailed = true
+	if flags, err := db.GetAllFlags(ctx); err != nil {
+		recordErr(fmt.Errorf("error retrieving feature flags: %w", err))
+		postFailed = true
+	} else if stats, err := analysis.DefaultPostProcessorRegistry().Run(ctx, graphDB, featureFlagSnapshot(flags), func(result analysis.PostProcessorResult) {
+		run.AddStep(model.AnalysisRunStep{
+			Name:       "post_processing:" + result.Name,
+			StartedAt:  result.Started.UTC(),
+			DurationMS: result.Duration.Milliseconds(),
+			Failed:     result.Err != nil,
+			Skipped:    result.Skipped,
+		})
+	}); err != nil {
+		recordPostProcessingStats(run, stats)
+		recordErr(fmt.Errorf("error during post-processing: %w", err))
+		postFailed = true
 	} else {
+		recordPostProcessingStats(run, stats)
 		stats.LogStats()
 	}
 
-	if stats, err := azure.Post(ctx, graphDB); err != nil {
-		collectedErrors = append(collectedErrors, fmt.Errorf("error during azure post: %w", err))
-		azureFailed = true
-	} else {
-		stats.LogStats()
+	// Tier Zero violations depend on post-processed edges and may add new selectors, so this must run after post
+	// processing but before asset group collections are recorded
+	if err := measureStep(run, "tier_zero_violations", func() error {
+		return tierzero.SaveTierZeroViolations(ctx, db, graphDB)
+	}); err != nil {
+		recordErr(fmt.Errorf("tier zero violation discovery failed: %w", err))
+		tierZeroFailed = true
+	}
+
+	if err := measureStep(run, "privileged_session_exposures", func() error {
+		return sessionexposure.SavePrivilegedSessionExposures(ctx, db, graphDB)
+	}); err != nil {
+		recordErr(fmt.Errorf("privileged session exposure analysis failed: %w", err))
+		sessionsFailed = true
 	}
 
-	if err := agi.RunAssetGroupIsolationCollections(ctx, db, graphDB, analysis.GetNodeKindDisplayLabel); err != nil {
-		collectedErrors = append(collectedErrors, fmt.Errorf("asset group isolation collection failed: %w", err))
+	if err := measureStep(run, "attack_path_findings", func() error {
+		return attackpaths.SaveAttackPathFindings(ctx, db, graphDB)
+	}); err != nil {
+		recordErr(fmt.Errorf("attack path finding evaluation failed: %w", err))
+		findingsFailed = true
+	}
+
+	if err := measureStep(run, "asset_group_isolation_collections", func() error {
+		return agi.RunAssetGroupIsolationCollections(ctx, db, graphDB, analysis.GetNodeKindDisplayLabel)
+	}); err != nil {
+		recordErr(fmt.Errorf("asset group isolation collection failed: %w", err))
 		agiFailed = true
 	}
 
-	if err := dataquality.SaveDataQuality(ctx, db, graphDB); err != nil {
-		collectedErrors = append(collectedErrors, fmt.Errorf("error saving data quality stat: %v", err))
+	if err := measureStep(run, "data_quality", func() error {
+		return dataquality.SaveDataQuality(ctx, db, graphDB)
+	}); err != nil {
+		recordErr(fmt.Errorf("error saving data quality stat: %v", err))
 		dataQualityFailed = true
 	}
 
@@ -108,11 +164,40 @@ func RunAnalysisOperations(ctx context.Context, db database.Database, graphDB gr
 		}
 	}
 
-	if adFailed && azureFailed && agiFailed && dataQualityFailed {
+	if postFailed && tierZeroFailed && sessionsFailed && findingsFailed && agiFailed && dataQualityFailed {
 		return ErrAnalysisFailed
-	} else if adFailed || azureFailed || agiFailed || dataQualityFailed {
+	} else if postFailed || tierZeroFailed || sessionsFailed || findingsFailed || agiFailed || dataQualityFailed {
 		return ErrAnalysisPartiallyCompleted
 	}
 
 	return nil
 }
+
+func measureStep(run *model.AnalysisRun, name string, step func() error) error {
+	done := run.MeasureStep(name)
+	err := step()
+	done(err)
+
+	return err
+}
+
+func recordPostProcessingStats(run *model.AnalysisRun, stats *analysis.AtomicPostProcessingStats) {
+	for kind, count := range stats.RelationshipsCreated {
+		run.RelationshipsCreated[kind.String()] += int(*count)
+	}
+
+	for kind, count := range stats.RelationshipsDeleted {
+		run.RelationshipsDeleted[kind.String()] += int(*count)
+	}
+}
+
+// featureFlagSnapshot captures the state of the given feature flags for the duration of a post-processing run
+func featureFlagSnapshot(flags []appcfg.FeatureFlag) analysis.FeatureFlags {
+	snapshot := make(analysis.FeatureFlags, len(flags))
+
+	for _, flag := range flags {
+		snapshot[flag.Key] = flag.Enabled
+	}
+
+	return snapshot
+}
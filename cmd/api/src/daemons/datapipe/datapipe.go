@@ -32,6 +32,8 @@ import (
 	"github.com/specterops/bloodhound/src/model"
 	"github.com/specterops/bloodhound/src/model/appcfg"
 	"github.com/specterops/bloodhound/src/services/fileupload"
+	"github.com/specterops/bloodhound/src/services/graphsnapshot"
+	"github.com/specterops/bloodhound/src/services/workspace"
 )
 
 const (
@@ -113,6 +115,9 @@ func (s *Daemon) getDeletionRequested() bool {
 }
 
 func (s *Daemon) analyze() {
+	// Capture whether this run was user-requested before the switch is reset for recording purposes
+	userRequested := s.getAnalysisRequested()
+
 	// Ensure that the user-requested analysis switch is flipped back to false. This is done at the beginning of the
 	// function so that any re-analysis requests are caught while analysis is in-progress.
 	s.setAnalysisRequested(false)
@@ -121,21 +126,71 @@ func (s *Daemon) analyze() {
 		return
 	}
 
+	if targets, err := analysisTargets(s.ctx, s.db, userRequested); err != nil {
+		log.Errorf("Failed resolving workspaces to analyze: %v", err)
+	} else {
+		for _, target := range targets {
+			s.analyzeWorkspace(workspace.Scope(s.ctx, target), target, userRequested)
+		}
+	}
+}
+
+// analysisTargets returns the workspaces to analyze. User-requested analysis covers every workspace while analysis
+// triggered by file uploads only covers the workspaces of the file upload jobs awaiting analysis.
+func analysisTargets(ctx context.Context, db database.Database, userRequested bool) (model.Workspaces, error) {
+	if userRequested {
+		return workspace.All(ctx, db)
+	}
+
+	var (
+		targets          model.Workspaces
+		seenWorkspaceIDs = map[int32]struct{}{}
+	)
+
+	if fileUploadJobsUnderAnalysis, err := db.GetFileUploadJobsWithStatus(ctx, model.JobStatusAnalyzing); err != nil {
+		return nil, err
+	} else {
+		for _, job := range fileUploadJobsUnderAnalysis {
+			if _, seen := seenWorkspaceIDs[job.WorkspaceID]; seen {
+				continue
+			}
+
+			seenWorkspaceIDs[job.WorkspaceID] = struct{}{}
+
+			if target, err := workspace.Get(ctx, db, job.WorkspaceID); err != nil {
+				log.Errorf("Failed to fetch workspace %d for file upload job %d: %v", job.WorkspaceID, job.ID, err)
+			} else {
+				targets = append(targets, target)
+			}
+		}
+	}
+
+	return targets, nil
+}
+
+// analyzeWorkspace runs analysis against the graph of a single workspace. The given context must be scoped to the
+// workspace.
+func (s *Daemon) analyzeWorkspace(ctx context.Context, target model.Workspace, userRequested bool) {
 	s.status.Update(model.DatapipeStatusAnalyzing, false)
-	defer log.LogAndMeasure(log.LevelInfo, "Graph Analysis")()
+	defer log.LogAndMeasure(log.LevelInfo, "Graph Analysis of workspace %s", target.Name)()
 
-	if err := RunAnalysisOperations(s.ctx, s.db, s.graphdb, s.cfg); err != nil {
+	run := newAnalysisRun(ctx, s.db, userRequested)
+
+	if err := RunAnalysisOperations(ctx, s.db, s.graphdb, s.cfg, run); err != nil {
 		if errors.Is(err, ErrAnalysisFailed) {
-			FailAnalyzedFileUploadJobs(s.ctx, s.db)
+			run.Complete(model.AnalysisRunStatusFailed)
+			FailAnalyzedFileUploadJobs(ctx, s.db)
 			s.status.Update(model.DatapipeStatusIdle, false)
 		} else if errors.Is(err, ErrAnalysisPartiallyCompleted) {
-			PartialCompleteFileUploadJobs(s.ctx, s.db)
+			run.Complete(model.AnalysisRunStatusPartiallyComplete)
+			PartialCompleteFileUploadJobs(ctx, s.db)
 			s.status.Update(model.DatapipeStatusIdle, true)
 		}
 	} else {
-		CompleteAnalyzedFileUploadJobs(s.ctx, s.db)
+		run.Complete(model.AnalysisRunStatusComplete)
+		CompleteAnalyzedFileUploadJobs(ctx, s.db)
 
-		if entityPanelCachingFlag, err := s.db.GetFlagByKey(s.ctx, appcfg.FeatureEntityPanelCaching); err != nil {
+		if entityPanelCachingFlag, err := s.db.GetFlagByKey(ctx, appcfg.FeatureEntityPanelCaching); err != nil {
 			log.Errorf("Error retrieving entity panel caching flag: %v", err)
 		} else {
 			resetCache(s.cache, entityPanelCachingFlag.Enabled)
@@ -143,6 +198,34 @@ func (s *Daemon) analyze() {
 
 		s.status.Update(model.DatapipeStatusIdle, true)
 	}
+
+	if savedRun, err := s.db.CreateAnalysisRun(ctx, *run); err != nil {
+		log.Errorf("Failed to record analysis run: %v", err)
+	} else if err := graphsnapshot.SaveGraphSnapshot(ctx, s.db, s.graphdb, savedRun.ID); err != nil {
+		log.Errorf("Failed to record graph snapshot for analysis run %d: %v", savedRun.ID, err)
+	}
+}
+
+// newAnalysisRun creates the record for an analysis run, attributing it to the file upload jobs awaiting analysis
+func newAnalysisRun(ctx context.Context, db database.Database, userRequested bool) *model.AnalysisRun {
+	var (
+		trigger = model.AnalysisRunTriggerFileUpload
+		jobIDs  []int64
+	)
+
+	if userRequested {
+		trigger = model.AnalysisRunTriggerManual
+	}
+
+	if fileUploadJobsUnderAnalysis, err := db.GetFileUploadJobsWithStatus(ctx, model.JobStatusAnalyzing); err != nil {
+		log.Errorf("Failed to load file upload jobs under analysis: %v", err)
+	} else {
+		for _, job := range fileUploadJobsUnderAnalysis {
+			jobIDs = append(jobIDs, job.ID)
+		}
+	}
+
+	return model.NewAnalysisRun(trigger, jobIDs)
 }
 
 func resetCache(cacher cache.Cache, cacheEnabled bool) {
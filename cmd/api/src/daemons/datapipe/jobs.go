@@ -31,6 +31,7 @@ import (
 	"github.com/specterops/bloodhound/log"
 	"github.com/specterops/bloodhound/src/model"
 	"github.com/specterops/bloodhound/src/services/fileupload"
+	"github.com/specterops/bloodhound/src/services/workspace"
 )
 
 func HasFileUploadJobsWaitingForAnalysis(ctx context.Context, db database.Database) (bool, error) {
@@ -234,7 +235,9 @@ func (s *Daemon) processIngestTasks(ctx context.Context, ingestTasks model.Inges
 
 		if job, err := s.db.GetFileUploadJob(ctx, ingestTask.TaskID.ValueOrZero()); err != nil {
 			log.Errorf("Failed to fetch job for ingest task %d: %v", ingestTask.ID, err)
-		} else if total, failed, err := s.processIngestFile(ctx, ingestTask.FileName, ingestTask.FileType); err != nil {
+		} else if jobWorkspace, err := workspace.Get(ctx, s.db, job.WorkspaceID); err != nil {
+			log.Errorf("Failed to fetch workspace %d for ingest task %d: %v", job.WorkspaceID, ingestTask.ID, err)
+		} else if total, failed, err := s.processIngestFile(workspace.Scope(ctx, jobWorkspace), ingestTask.FileName, ingestTask.FileType); err != nil {
 			log.Errorf("Failed processing ingest task %d with file %s: %v", ingestTask.ID, ingestTask.FileName, err)
 		} else {
 			job.TotalFiles = total
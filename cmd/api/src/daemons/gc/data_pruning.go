@@ -52,6 +52,8 @@ func (s *Daemon) Start(ctx context.Context) {
 	// prune sessions and collections once when the daemon starts up
 	s.db.SweepSessions(ctx)
 	s.db.SweepAssetGroupCollections(ctx)
+	s.db.SweepTierZeroViolationRuns(ctx)
+	s.db.SweepGraphChangeEvents(ctx)
 
 	// thereafter, prune conditionally once a day
 	for {
@@ -59,6 +61,8 @@ func (s *Daemon) Start(ctx context.Context) {
 		case <-ticker.C:
 			s.db.SweepSessions(ctx)
 			s.db.SweepAssetGroupCollections(ctx)
+			s.db.SweepTierZeroViolationRuns(ctx)
+			s.db.SweepGraphChangeEvents(ctx)
 
 		case <-s.exitC:
 			return
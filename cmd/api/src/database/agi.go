@@ -32,6 +32,7 @@ func (s *BloodhoundDB) CreateAssetGroup(ctx context.Context, name, tag string, s
 			Name:        name,
 			Tag:         tag,
 			SystemGroup: systemGroup,
+			WorkspaceID: contextWorkspaceID(ctx),
 		}
 
 		auditEntry = model.AuditEntry{
@@ -78,7 +79,7 @@ func (s *BloodhoundDB) DeleteAssetGroup(ctx context.Context, assetGroup model.As
 func (s *BloodhoundDB) GetAssetGroup(ctx context.Context, id int32) (model.AssetGroup, error) {
 	var (
 		assetGroup model.AssetGroup
-		result     = s.preload(model.AssetGroupAssociations()).WithContext(ctx).First(&assetGroup, id)
+		result     = s.preload(model.AssetGroupAssociations()).Scopes(WorkspaceScope(ctx)).WithContext(ctx).First(&assetGroup, id)
 	)
 	return assetGroup, CheckError(result)
 }
@@ -90,13 +91,13 @@ func (s *BloodhoundDB) GetAllAssetGroups(ctx context.Context, order string, filt
 	)
 
 	if order != "" && filter.SQLString == "" {
-		result = s.preload(model.AssetGroupAssociations()).WithContext(ctx).Order(order).Find(&assetGroups)
+		result = s.preload(model.AssetGroupAssociations()).Scopes(WorkspaceScope(ctx)).WithContext(ctx).Order(order).Find(&assetGroups)
 	} else if order != "" && filter.SQLString != "" {
-		result = s.preload(model.AssetGroupAssociations()).WithContext(ctx).Where(filter.SQLString, filter.Params).Order(order).Find(&assetGroups)
+		result = s.preload(model.AssetGroupAssociations()).Scopes(WorkspaceScope(ctx)).WithContext(ctx).Where(filter.SQLString, filter.Params).Order(order).Find(&assetGroups)
 	} else if order == "" && filter.SQLString != "" {
-		result = s.preload(model.AssetGroupAssociations()).WithContext(ctx).Where(filter.SQLString, filter.Params).Find(&assetGroups)
+		result = s.preload(model.AssetGroupAssociations()).Scopes(WorkspaceScope(ctx)).WithContext(ctx).Where(filter.SQLString, filter.Params).Find(&assetGroups)
 	} else {
-		result = s.preload(model.AssetGroupAssociations()).WithContext(ctx).Find(&assetGroups)
+		result = s.preload(model.AssetGroupAssociations()).Scopes(WorkspaceScope(ctx)).WithContext(ctx).Find(&assetGroups)
 	}
 
 	if result.Error != nil {
@@ -0,0 +1,57 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+
+	"github.com/specterops/bloodhound/src/model"
+	"gorm.io/gorm"
+)
+
+func (s *BloodhoundDB) CreateAnalysisRun(ctx context.Context, run model.AnalysisRun) (model.AnalysisRun, error) {
+	run.WorkspaceID = contextWorkspaceID(ctx)
+
+	result := s.db.WithContext(ctx).Create(&run)
+	return run, CheckError(result)
+}
+
+func (s *BloodhoundDB) ListAnalysisRuns(ctx context.Context, order string, filter model.SQLFilter, skip, limit int) (model.AnalysisRuns, int, error) {
+	var (
+		runs    model.AnalysisRuns
+		count   int64
+		result  *gorm.DB
+		cursor  = s.Scope(Paginate(skip, limit), WorkspaceScope(ctx)).WithContext(ctx)
+		counter = s.Scope(WorkspaceScope(ctx)).Model(&runs).WithContext(ctx)
+	)
+
+	if filter.SQLString != "" {
+		cursor = cursor.Where(filter.SQLString, filter.Params...)
+		counter = counter.Where(filter.SQLString, filter.Params...)
+	}
+
+	if result = counter.Count(&count); result.Error != nil {
+		return runs, 0, CheckError(result)
+	}
+
+	if order == "" {
+		order = "started_at desc"
+	}
+
+	result = cursor.Order(order).Find(&runs)
+	return runs, int(count), CheckError(result)
+}
@@ -0,0 +1,147 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/specterops/bloodhound/src/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const attackPathFindingBatchSize = 1000
+
+// ReplaceAttackPathFindings removes all previously stored findings of the workspace the given context is scoped to and
+// stores the given ones in their place. Risk acceptances are left untouched.
+func (s *BloodhoundDB) ReplaceAttackPathFindings(ctx context.Context, findings model.AttackPathFindings) error {
+	for idx := range findings {
+		findings[idx].WorkspaceID = contextWorkspaceID(ctx)
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if result := tx.Scopes(WorkspaceScope(ctx)).Where("1 = 1").Delete(&model.AttackPathFinding{}); result.Error != nil {
+			return CheckError(result)
+		}
+
+		// GORM will fail on an attempt to insert an empty slice
+		if len(findings) > 0 {
+			return CheckError(tx.CreateInBatches(&findings, attackPathFindingBatchSize))
+		}
+
+		return nil
+	})
+}
+
+// GetAttackPathFindingSummaries returns the number of impacted principals for each finding type present in the given
+// domain or tenant along with the risk acceptance state of the finding type. Titles are left for the caller to fill.
+func (s *BloodhoundDB) GetAttackPathFindingSummaries(ctx context.Context, environmentID string) (model.AttackPathFindingSummaries, error) {
+	var (
+		summaries   model.AttackPathFindingSummaries
+		acceptances model.AttackPathRiskAcceptances
+		now         = time.Now()
+	)
+
+	if result := s.Scope(WorkspaceScope(ctx)).WithContext(ctx).
+		Model(&model.AttackPathFinding{}).
+		Select("finding_type, severity, count(*) as impacted_principal_count").
+		Where("environment_id = ?", environmentID).
+		Group("finding_type, severity").
+		Order("finding_type").
+		Scan(&summaries); result.Error != nil {
+		return nil, CheckError(result)
+	}
+
+	if result := s.Scope(WorkspaceScope(ctx)).WithContext(ctx).Where("environment_id = ?", environmentID).Find(&acceptances); result.Error != nil {
+		return nil, CheckError(result)
+	}
+
+	for idx := range summaries {
+		for _, acceptance := range acceptances {
+			if acceptance.FindingType == summaries[idx].FindingType {
+				summaries[idx].Accepted = acceptance.IsAccepted(now)
+				summaries[idx].AcceptedUntil = acceptance.AcceptedUntil
+			}
+		}
+	}
+
+	return summaries, nil
+}
+
+func (s *BloodhoundDB) GetAttackPathFindings(ctx context.Context, environmentID, findingType string, order string, filter model.SQLFilter, skip, limit int) (model.AttackPathFindings, int, error) {
+	var (
+		findings model.AttackPathFindings
+		count    int64
+		result   *gorm.DB
+		cursor   = s.Scope(Paginate(skip, limit), WorkspaceScope(ctx)).WithContext(ctx).Where("environment_id = ? and finding_type = ?", environmentID, findingType)
+		counter  = s.Scope(WorkspaceScope(ctx)).Model(&findings).WithContext(ctx).Where("environment_id = ? and finding_type = ?", environmentID, findingType)
+	)
+
+	if filter.SQLString != "" {
+		cursor = cursor.Where(filter.SQLString, filter.Params...)
+		counter = counter.Where(filter.SQLString, filter.Params...)
+	}
+
+	if result = counter.Count(&count); result.Error != nil {
+		return findings, 0, CheckError(result)
+	}
+
+	if order == "" {
+		order = "principal_name"
+	}
+
+	result = cursor.Order(order).Find(&findings)
+	return findings, int(count), CheckError(result)
+}
+
+// AcceptAttackPathRisk records the finding type as an accepted risk for the domain or tenant until the acceptance's
+// expiry, replacing any earlier acceptance.
+func (s *BloodhoundDB) AcceptAttackPathRisk(ctx context.Context, acceptance model.AttackPathRiskAcceptance) (model.AttackPathRiskAcceptance, error) {
+	acceptance.WorkspaceID = contextWorkspaceID(ctx)
+
+	auditEntry := model.AuditEntry{
+		Action: model.AuditLogActionAcceptRisk,
+		Model:  &acceptance, // Pointer is required to ensure success log contains updated fields after transaction
+	}
+
+	return acceptance, s.AuditableTransaction(ctx, auditEntry, func(tx *gorm.DB) error {
+		return CheckError(tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "workspace_id"}, {Name: "environment_id"}, {Name: "finding_type"}},
+			DoUpdates: clause.AssignmentColumns([]string{"accepted_until", "updated_at"}),
+		}).Create(&acceptance))
+	})
+}
+
+// UnacceptAttackPathRisk removes any risk acceptance of the finding type for the domain or tenant.
+func (s *BloodhoundDB) UnacceptAttackPathRisk(ctx context.Context, environmentID, findingType string) error {
+	var (
+		acceptance = model.AttackPathRiskAcceptance{
+			FindingType:   findingType,
+			EnvironmentID: environmentID,
+			WorkspaceID:   contextWorkspaceID(ctx),
+		}
+		auditEntry = model.AuditEntry{
+			Action: model.AuditLogActionUnacceptRisk,
+			Model:  &acceptance,
+		}
+	)
+
+	return s.AuditableTransaction(ctx, auditEntry, func(tx *gorm.DB) error {
+		return CheckError(tx.Scopes(WorkspaceScope(ctx)).Where("environment_id = ? and finding_type = ?", environmentID, findingType).Delete(&model.AttackPathRiskAcceptance{}))
+	})
+}
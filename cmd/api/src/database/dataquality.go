@@ -25,6 +25,10 @@ import (
 )
 
 func (s *BloodhoundDB) CreateADDataQualityStats(ctx context.Context, stats model.ADDataQualityStats) (model.ADDataQualityStats, error) {
+	for idx := range stats {
+		stats[idx].WorkspaceID = contextWorkspaceID(ctx)
+	}
+
 	result := s.db.WithContext(ctx).Create(&stats)
 	return stats, CheckError(result)
 }
@@ -40,7 +44,7 @@ func (s *BloodhoundDB) GetADDataQualityStats(ctx context.Context, domainSid stri
 		result             *gorm.DB
 	)
 
-	result = s.db.Model(model.ADDataQualityStats{}).WithContext(ctx).Where(defaultWhere, domainSid, start, end).Count(&count)
+	result = s.Scope(WorkspaceScope(ctx)).Model(model.ADDataQualityStats{}).WithContext(ctx).Where(defaultWhere, domainSid, start, end).Count(&count)
 	if CheckError(result) != nil {
 		return adDataQualityStats, 0, result.Error
 	}
@@ -49,7 +53,7 @@ func (s *BloodhoundDB) GetADDataQualityStats(ctx context.Context, domainSid stri
 		order = "created_at desc"
 	}
 
-	result = s.Scope(Paginate(skip, limit)).WithContext(ctx).Where(defaultWhere, domainSid, start, end).Order(order).Find(&adDataQualityStats)
+	result = s.Scope(Paginate(skip, limit), WorkspaceScope(ctx)).WithContext(ctx).Where(defaultWhere, domainSid, start, end).Order(order).Find(&adDataQualityStats)
 	if CheckError(result) != nil {
 		return adDataQualityStats, 0, result.Error
 	}
@@ -58,6 +62,8 @@ func (s *BloodhoundDB) GetADDataQualityStats(ctx context.Context, domainSid stri
 }
 
 func (s *BloodhoundDB) CreateADDataQualityAggregation(ctx context.Context, aggregation model.ADDataQualityAggregation) (model.ADDataQualityAggregation, error) {
+	aggregation.WorkspaceID = contextWorkspaceID(ctx)
+
 	result := s.db.WithContext(ctx).Create(&aggregation)
 	return aggregation, CheckError(result)
 }
@@ -73,7 +79,7 @@ func (s *BloodhoundDB) GetADDataQualityAggregations(ctx context.Context, start t
 		result                    *gorm.DB
 	)
 
-	result = s.db.Model(model.ADDataQualityAggregations{}).WithContext(ctx).Where(defaultWhere, start, end).Count(&count)
+	result = s.Scope(WorkspaceScope(ctx)).Model(model.ADDataQualityAggregations{}).WithContext(ctx).Where(defaultWhere, start, end).Count(&count)
 	if CheckError(result) != nil {
 		return adDataQualityAggregations, 0, result.Error
 	}
@@ -82,7 +88,7 @@ func (s *BloodhoundDB) GetADDataQualityAggregations(ctx context.Context, start t
 		order = "created_at desc"
 	}
 
-	result = s.Scope(Paginate(skip, limit)).WithContext(ctx).Where(defaultWhere, start, end).Order(order).Find(&adDataQualityAggregations)
+	result = s.Scope(Paginate(skip, limit), WorkspaceScope(ctx)).WithContext(ctx).Where(defaultWhere, start, end).Order(order).Find(&adDataQualityAggregations)
 	if CheckError(result) != nil {
 		return adDataQualityAggregations, 0, result.Error
 	}
@@ -91,6 +97,10 @@ func (s *BloodhoundDB) GetADDataQualityAggregations(ctx context.Context, start t
 }
 
 func (s *BloodhoundDB) CreateAzureDataQualityStats(ctx context.Context, stats model.AzureDataQualityStats) (model.AzureDataQualityStats, error) {
+	for idx := range stats {
+		stats[idx].WorkspaceID = contextWorkspaceID(ctx)
+	}
+
 	result := s.db.WithContext(ctx).Create(&stats)
 	return stats, CheckError(result)
 }
@@ -106,7 +116,7 @@ func (s *BloodhoundDB) GetAzureDataQualityStats(ctx context.Context, tenantId st
 		result                *gorm.DB
 	)
 
-	result = s.db.Model(model.AzureDataQualityStats{}).WithContext(ctx).Where(defaultWhere, tenantId, start, end).Count(&count)
+	result = s.Scope(WorkspaceScope(ctx)).Model(model.AzureDataQualityStats{}).WithContext(ctx).Where(defaultWhere, tenantId, start, end).Count(&count)
 	if CheckError(result) != nil {
 		return azureDataQualityStats, 0, result.Error
 	}
@@ -115,7 +125,7 @@ func (s *BloodhoundDB) GetAzureDataQualityStats(ctx context.Context, tenantId st
 		order = "created_at desc"
 	}
 
-	result = s.Scope(Paginate(skip, limit)).WithContext(ctx).Where(defaultWhere, tenantId, start, end).Order(order).Find(&azureDataQualityStats)
+	result = s.Scope(Paginate(skip, limit), WorkspaceScope(ctx)).WithContext(ctx).Where(defaultWhere, tenantId, start, end).Order(order).Find(&azureDataQualityStats)
 	if CheckError(result) != nil {
 		return azureDataQualityStats, 0, result.Error
 	}
@@ -124,6 +134,8 @@ func (s *BloodhoundDB) GetAzureDataQualityStats(ctx context.Context, tenantId st
 }
 
 func (s *BloodhoundDB) CreateAzureDataQualityAggregation(ctx context.Context, aggregation model.AzureDataQualityAggregation) (model.AzureDataQualityAggregation, error) {
+	aggregation.WorkspaceID = contextWorkspaceID(ctx)
+
 	result := s.db.WithContext(ctx).Create(&aggregation)
 	return aggregation, CheckError(result)
 }
@@ -139,7 +151,7 @@ func (s *BloodhoundDB) GetAzureDataQualityAggregations(ctx context.Context, star
 		result                       *gorm.DB
 	)
 
-	result = s.db.Model(model.AzureDataQualityAggregations{}).WithContext(ctx).Where(defaultWhere, start, end).Count(&count)
+	result = s.Scope(WorkspaceScope(ctx)).Model(model.AzureDataQualityAggregations{}).WithContext(ctx).Where(defaultWhere, start, end).Count(&count)
 	if CheckError(result) != nil {
 		return azureDataQualityAggregations, 0, result.Error
 	}
@@ -148,7 +160,7 @@ func (s *BloodhoundDB) GetAzureDataQualityAggregations(ctx context.Context, star
 		order = "created_at desc"
 	}
 
-	result = s.Scope(Paginate(skip, limit)).WithContext(ctx).Where(defaultWhere, start, end).Order(order).Find(&azureDataQualityAggregations)
+	result = s.Scope(Paginate(skip, limit), WorkspaceScope(ctx)).WithContext(ctx).Where(defaultWhere, start, end).Order(order).Find(&azureDataQualityAggregations)
 	if CheckError(result) != nil {
 		return azureDataQualityAggregations, 0, result.Error
 	}
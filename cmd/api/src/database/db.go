@@ -22,9 +22,16 @@ import (
 	"context"
 	"fmt"
 	"github.com/specterops/bloodhound/src/services/agi"
+	"github.com/specterops/bloodhound/src/services/attackpaths"
 	"github.com/specterops/bloodhound/src/services/dataquality"
 	"github.com/specterops/bloodhound/src/services/fileupload"
+	"github.com/specterops/bloodhound/src/services/graphchange"
+	"github.com/specterops/bloodhound/src/services/graphindex"
+	"github.com/specterops/bloodhound/src/services/graphsnapshot"
 	"github.com/specterops/bloodhound/src/services/ingest"
+	"github.com/specterops/bloodhound/src/services/sessionexposure"
+	"github.com/specterops/bloodhound/src/services/tierzero"
+	"github.com/specterops/bloodhound/src/services/workspace"
 	"time"
 
 	"github.com/gofrs/uuid"
@@ -145,9 +152,52 @@ type Database interface {
 	// File Upload
 	fileupload.FileUploadData
 
+	// Tier Zero Violations
+	tierzero.TierZeroData
+	GetLatestTierZeroViolationRun(ctx context.Context) (model.TierZeroViolationRun, error)
+	ListTierZeroViolations(ctx context.Context, runID int64, order string, filter model.SQLFilter, skip, limit int) (model.TierZeroViolations, int, error)
+	SweepTierZeroViolationRuns(ctx context.Context)
+
+	// Analysis Runs
+	CreateAnalysisRun(ctx context.Context, run model.AnalysisRun) (model.AnalysisRun, error)
+	ListAnalysisRuns(ctx context.Context, order string, filter model.SQLFilter, skip, limit int) (model.AnalysisRuns, int, error)
+
+	// Graph Snapshots
+	graphsnapshot.GraphSnapshotData
+	GetGraphSnapshotByAnalysisRunID(ctx context.Context, analysisRunID int64) (model.GraphSnapshot, error)
+
+	// Privileged Session Exposures
+	sessionexposure.SessionExposureData
+	GetPrivilegedSessionExposures(ctx context.Context, domainSID string, order string, filter model.SQLFilter, skip, limit int) (model.PrivilegedSessionExposures, int, error)
+
+	// Attack Path Findings
+	attackpaths.AttackPathData
+	GetAttackPathFindingSummaries(ctx context.Context, environmentID string) (model.AttackPathFindingSummaries, error)
+	GetAttackPathFindings(ctx context.Context, environmentID, findingType string, order string, filter model.SQLFilter, skip, limit int) (model.AttackPathFindings, int, error)
+	AcceptAttackPathRisk(ctx context.Context, acceptance model.AttackPathRiskAcceptance) (model.AttackPathRiskAcceptance, error)
+	UnacceptAttackPathRisk(ctx context.Context, environmentID, findingType string) error
+
+	// Graph Property Indexes
+	graphindex.GraphIndexData
+	GetGraphPropertyIndex(ctx context.Context, id int64) (model.GraphPropertyIndex, error)
+	CreateGraphPropertyIndex(ctx context.Context, index model.GraphPropertyIndex) (model.GraphPropertyIndex, error)
+	DeleteGraphPropertyIndex(ctx context.Context, index model.GraphPropertyIndex) error
+
+	// Graph Change Feed
+	graphchange.GraphChangeData
+	ListGraphChangeEvents(ctx context.Context, afterID int64, limit int) (model.GraphChangeEvents, error)
+	SweepGraphChangeEvents(ctx context.Context)
+
+	// Workspaces
+	workspace.WorkspaceData
+	CreateWorkspace(ctx context.Context, workspace model.Workspace) (model.Workspace, error)
+	UpdateWorkspace(ctx context.Context, workspace model.Workspace) error
+	DeleteWorkspace(ctx context.Context, workspace model.Workspace) error
+
 	// Saved Queries
 	ListSavedQueries(ctx context.Context, userID uuid.UUID, order string, filter model.SQLFilter, skip, limit int) (model.SavedQueries, int, error)
-	CreateSavedQuery(ctx context.Context, userID uuid.UUID, name string, query string) (model.SavedQuery, error)
+	CreateSavedQuery(ctx context.Context, userID uuid.UUID, name string, query string, parameters model.SavedQueryParameters) (model.SavedQuery, error)
+	GetSavedQuery(ctx context.Context, userID uuid.UUID, savedQueryID int64) (model.SavedQuery, error)
 	DeleteSavedQuery(ctx context.Context, id int) error
 	SavedQueryBelongsToUser(ctx context.Context, userID uuid.UUID, savedQueryID int) (bool, error)
 	DeleteAssetGroupSelectorsForAssetGroups(ctx context.Context, assetGroupIds []int) error
@@ -29,13 +29,15 @@ func (s *BloodhoundDB) UpdateFileUploadJob(ctx context.Context, job model.FileUp
 }
 
 func (s *BloodhoundDB) CreateFileUploadJob(ctx context.Context, job model.FileUploadJob) (model.FileUploadJob, error) {
+	job.WorkspaceID = contextWorkspaceID(ctx)
+
 	result := s.db.WithContext(ctx).Create(&job)
 	return job, CheckError(result)
 }
 
 func (s *BloodhoundDB) GetFileUploadJob(ctx context.Context, id int64) (model.FileUploadJob, error) {
 	var job model.FileUploadJob
-	if result := s.db.Preload("User").WithContext(ctx).First(&job, id); result.Error != nil {
+	if result := s.Scope(WorkspaceScope(ctx)).Preload("User").WithContext(ctx).First(&job, id); result.Error != nil {
 		return job, CheckError(result)
 	} else {
 		return job, nil
@@ -44,7 +46,7 @@ func (s *BloodhoundDB) GetFileUploadJob(ctx context.Context, id int64) (model.Fi
 
 func (s *BloodhoundDB) GetFileUploadJobsWithStatus(ctx context.Context, status model.JobStatus) ([]model.FileUploadJob, error) {
 	var jobs model.FileUploadJobs
-	result := s.db.WithContext(ctx).Where("status = ?", status).Find(&jobs)
+	result := s.Scope(WorkspaceScope(ctx)).WithContext(ctx).Where("status = ?", status).Find(&jobs)
 
 	return jobs, CheckError(result)
 }
@@ -66,9 +68,9 @@ func (s *BloodhoundDB) GetAllFileUploadJobs(ctx context.Context, skip int, limit
 	}
 
 	if filter.SQLString != "" {
-		result = s.db.Model(model.FileUploadJob{}).WithContext(ctx).Where(filter.SQLString, filter.Params).Count(&count)
+		result = s.Scope(WorkspaceScope(ctx)).Model(model.FileUploadJob{}).WithContext(ctx).Where(filter.SQLString, filter.Params).Count(&count)
 	} else {
-		result = s.db.Model(model.FileUploadJob{}).WithContext(ctx).Count(&count)
+		result = s.Scope(WorkspaceScope(ctx)).Model(model.FileUploadJob{}).WithContext(ctx).Count(&count)
 	}
 
 	if result.Error != nil {
@@ -76,9 +78,9 @@ func (s *BloodhoundDB) GetAllFileUploadJobs(ctx context.Context, skip int, limit
 	}
 
 	if filter.SQLString != "" {
-		result = s.Scope(Paginate(skip, limit)).WithContext(ctx).Preload("User").Where(filter.SQLString, filter.Params).Order(order).Find(&jobs)
+		result = s.Scope(Paginate(skip, limit), WorkspaceScope(ctx)).WithContext(ctx).Preload("User").Where(filter.SQLString, filter.Params).Order(order).Find(&jobs)
 	} else {
-		result = s.Scope(Paginate(skip, limit)).WithContext(ctx).Preload("User").Order(order).Find(&jobs)
+		result = s.Scope(Paginate(skip, limit), WorkspaceScope(ctx)).WithContext(ctx).Preload("User").Order(order).Find(&jobs)
 	}
 
 	if result.Error != nil {
@@ -0,0 +1,58 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+// +build integration
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/specterops/bloodhound/src/ctx"
+	"github.com/specterops/bloodhound/src/database"
+	"github.com/specterops/bloodhound/src/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileUploadJobs_GetFileUploadJob_WorkspaceScope(t *testing.T) {
+	var (
+		dbInst, user  = initAndCreateUser(t)
+		workspaceACtx = ctx.WithWorkspace(context.Background(), 1)
+		workspaceBCtx = ctx.WithWorkspace(context.Background(), 2)
+		unscopedCtx   = context.Background()
+	)
+
+	job, err := dbInst.CreateFileUploadJob(workspaceACtx, model.FileUploadJob{
+		UserID: user.ID,
+		Status: model.JobStatusRunning,
+	})
+	require.Nil(t, err)
+	require.Equal(t, int32(1), job.WorkspaceID)
+
+	// The job is visible to its own workspace and to contexts that are not scoped to a workspace
+	fetchedJob, err := dbInst.GetFileUploadJob(workspaceACtx, job.ID)
+	require.Nil(t, err)
+	require.Equal(t, job.ID, fetchedJob.ID)
+
+	_, err = dbInst.GetFileUploadJob(unscopedCtx, job.ID)
+	require.Nil(t, err)
+
+	// Other workspaces can not look the job up
+	_, err = dbInst.GetFileUploadJob(workspaceBCtx, job.ID)
+	require.ErrorIs(t, err, database.ErrNotFound)
+}
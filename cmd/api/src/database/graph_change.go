@@ -0,0 +1,64 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+
+	"github.com/specterops/bloodhound/src/model"
+	"gorm.io/gorm"
+)
+
+const (
+	graphChangeEventBatchSize = 100
+
+	// graphChangeEventLockID identifies the transaction scoped advisory lock that serializes writers of the outbox
+	graphChangeEventLockID int64 = 0x6267636576656e74
+)
+
+// AppendGraphChangeEvents writes the given events to the outbox. Writers hold an advisory lock from before the IDs of
+// their events are allocated until their transaction commits so that events become visible in ID order. Without the
+// lock, a consumer could read past the ID of an event whose transaction commits after that of an event with a higher
+// ID and skip it permanently.
+func (s *BloodhoundDB) AppendGraphChangeEvents(ctx context.Context, events model.GraphChangeEvents) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	for idx := range events {
+		events[idx].WorkspaceID = contextWorkspaceID(ctx)
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if result := tx.Exec("select pg_advisory_xact_lock(?)", graphChangeEventLockID); result.Error != nil {
+			return CheckError(result)
+		}
+
+		return CheckError(tx.CreateInBatches(&events, graphChangeEventBatchSize))
+	})
+}
+
+// ListGraphChangeEvents returns up to limit events from the outbox with an ID greater than afterID in ID order. ID order
+// is commit order since AppendGraphChangeEvents serializes writers.
+func (s *BloodhoundDB) ListGraphChangeEvents(ctx context.Context, afterID int64, limit int) (model.GraphChangeEvents, error) {
+	var events model.GraphChangeEvents
+	return events, CheckError(s.Scope(WorkspaceScope(ctx)).WithContext(ctx).Where("id > ?", afterID).Order("id").Limit(limit).Find(&events))
+}
+
+func (s *BloodhoundDB) SweepGraphChangeEvents(ctx context.Context) {
+	s.db.WithContext(ctx).Where("committed_at < now() - INTERVAL '7 DAYS'").Delete(&model.GraphChangeEvent{})
+}
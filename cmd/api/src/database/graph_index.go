@@ -0,0 +1,69 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+
+	"github.com/specterops/bloodhound/src/model"
+	"gorm.io/gorm"
+)
+
+func (s *BloodhoundDB) GetGraphPropertyIndexes(ctx context.Context) (model.GraphPropertyIndexes, error) {
+	var indexes model.GraphPropertyIndexes
+	return indexes, CheckError(s.db.WithContext(ctx).Order("kind, property").Find(&indexes))
+}
+
+func (s *BloodhoundDB) GetGraphPropertyIndex(ctx context.Context, id int64) (model.GraphPropertyIndex, error) {
+	var index model.GraphPropertyIndex
+	return index, CheckError(s.db.WithContext(ctx).First(&index, id))
+}
+
+func (s *BloodhoundDB) CreateGraphPropertyIndex(ctx context.Context, index model.GraphPropertyIndex) (model.GraphPropertyIndex, error) {
+	auditEntry := model.AuditEntry{
+		Action: model.AuditLogActionCreateGraphPropertyIndex,
+		Model:  &index, // Pointer is required to ensure success log contains updated fields after transaction
+	}
+
+	return index, s.AuditableTransaction(ctx, auditEntry, func(tx *gorm.DB) error {
+		return CheckError(tx.Create(&index))
+	})
+}
+
+func (s *BloodhoundDB) DeleteGraphPropertyIndex(ctx context.Context, index model.GraphPropertyIndex) error {
+	auditEntry := model.AuditEntry{
+		Action: model.AuditLogActionDeleteGraphPropertyIndex,
+		Model:  &index,
+	}
+
+	return s.AuditableTransaction(ctx, auditEntry, func(tx *gorm.DB) error {
+		return CheckError(tx.Delete(&model.GraphPropertyIndex{}, index.ID))
+	})
+}
+
+// UpdateGraphPropertyIndexStatus sets the build status of the given indexes. Indexes deleted in the meantime are
+// silently skipped.
+func (s *BloodhoundDB) UpdateGraphPropertyIndexStatus(ctx context.Context, ids []int64, status model.GraphPropertyIndexStatus, buildErr string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return CheckError(s.db.WithContext(ctx).Model(&model.GraphPropertyIndex{}).Where("id in ?", ids).Updates(map[string]any{
+		"status": status,
+		"error":  buildErr,
+	}))
+}
@@ -0,0 +1,43 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+
+	"github.com/specterops/bloodhound/src/model"
+	"gorm.io/gorm"
+)
+
+// SaveGraphSnapshot stores the given snapshot and removes all but the most recent retained snapshots of the workspace
+// the given context is scoped to
+func (s *BloodhoundDB) SaveGraphSnapshot(ctx context.Context, snapshot model.GraphSnapshot, retained int) error {
+	snapshot.WorkspaceID = contextWorkspaceID(ctx)
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if result := tx.Create(&snapshot); result.Error != nil {
+			return CheckError(result)
+		}
+
+		return CheckError(tx.Exec(`delete from graph_snapshots where workspace_id = ? and id not in (select id from graph_snapshots where workspace_id = ? order by id desc limit ?)`, snapshot.WorkspaceID, snapshot.WorkspaceID, retained))
+	})
+}
+
+func (s *BloodhoundDB) GetGraphSnapshotByAnalysisRunID(ctx context.Context, analysisRunID int64) (model.GraphSnapshot, error) {
+	var snapshot model.GraphSnapshot
+	return snapshot, CheckError(s.Scope(WorkspaceScope(ctx)).WithContext(ctx).Where("analysis_run_id = ?", analysisRunID).First(&snapshot))
+}
@@ -46,7 +46,7 @@ func (s *Migrator) updateAssetGroups() error {
 		var systemAssetGroups model.AssetGroups
 
 		// Lookup system asset groups
-		if result := tx.Where("system_group = true").Find(&systemAssetGroups); result.Error != nil {
+		if result := tx.Where("system_group = true and workspace_id = ?", model.DefaultWorkspaceID).Find(&systemAssetGroups); result.Error != nil {
 			return result.Error
 		}
 
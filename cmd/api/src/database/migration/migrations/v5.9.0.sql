@@ -0,0 +1,180 @@
+-- Copyright 2024 Specter Ops, Inc.
+--
+-- Licensed under the Apache License, Version 2.0
+-- you may not use this file except in compliance with the License.
+-- You may obtain a copy of the License at
+--
+--     http://www.apache.org/licenses/LICENSE-2.0
+--
+-- Unless required by applicable law or agreed to in writing, software
+-- distributed under the License is distributed on an "AS IS" BASIS,
+-- WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+-- See the License for the specific language governing permissions and
+-- limitations under the License.
+--
+-- SPDX-License-Identifier: Apache-2.0
+
+
+-- Tier Zero control closure violations
+CREATE TABLE IF NOT EXISTS tier_zero_violation_runs (
+  id BIGSERIAL PRIMARY KEY,
+  workspace_id INTEGER NOT NULL DEFAULT 0,
+  violation_count INTEGER NOT NULL DEFAULT 0,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT current_timestamp,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT current_timestamp
+);
+
+CREATE TABLE IF NOT EXISTS tier_zero_violations (
+  id BIGSERIAL PRIMARY KEY,
+  run_id BIGINT NOT NULL REFERENCES tier_zero_violation_runs (id) ON DELETE CASCADE,
+  object_id TEXT NOT NULL,
+  name TEXT NOT NULL DEFAULT '',
+  node_label TEXT NOT NULL DEFAULT '',
+  tier_zero_object_id TEXT NOT NULL,
+  tier_zero_name TEXT NOT NULL DEFAULT '',
+  relationship_kind TEXT NOT NULL,
+  depth INTEGER NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT current_timestamp,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT current_timestamp
+);
+
+CREATE INDEX IF NOT EXISTS idx_tier_zero_violations_run_id ON tier_zero_violations USING btree (run_id);
+CREATE INDEX IF NOT EXISTS idx_tier_zero_violations_object_id ON tier_zero_violations USING btree (object_id);
+CREATE INDEX IF NOT EXISTS idx_tier_zero_violation_runs_workspace_id ON tier_zero_violation_runs USING btree (workspace_id);
+
+-- Privileged session exposures
+CREATE TABLE IF NOT EXISTS privileged_session_exposures (
+  id BIGSERIAL PRIMARY KEY,
+  workspace_id INTEGER NOT NULL DEFAULT 0,
+  domain_sid TEXT NOT NULL,
+  computer_object_id TEXT NOT NULL,
+  computer_name TEXT NOT NULL DEFAULT '',
+  asset_group_tag TEXT NOT NULL,
+  exposed_user_count INTEGER NOT NULL DEFAULT 0,
+  exposed_users TEXT[] NOT NULL DEFAULT '{}',
+  reachable_principal_count BIGINT NOT NULL DEFAULT 0,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT current_timestamp,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT current_timestamp
+);
+
+CREATE INDEX IF NOT EXISTS idx_privileged_session_exposures_workspace_id_domain_sid ON privileged_session_exposures USING btree (workspace_id, domain_sid);
+
+-- Analysis run history
+CREATE TABLE IF NOT EXISTS analysis_runs (
+  id BIGSERIAL PRIMARY KEY,
+  workspace_id INTEGER NOT NULL DEFAULT 0,
+  trigger TEXT NOT NULL,
+  file_upload_job_ids BIGINT[] NOT NULL DEFAULT '{}',
+  status TEXT NOT NULL,
+  started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  completed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  steps JSONB NOT NULL DEFAULT '[]',
+  relationships_created JSONB NOT NULL DEFAULT '{}',
+  relationships_deleted JSONB NOT NULL DEFAULT '{}',
+  errors TEXT[] NOT NULL DEFAULT '{}',
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT current_timestamp,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT current_timestamp
+);
+
+CREATE INDEX IF NOT EXISTS idx_analysis_runs_started_at ON analysis_runs USING btree (started_at);
+CREATE INDEX IF NOT EXISTS idx_analysis_runs_workspace_id ON analysis_runs USING btree (workspace_id);
+
+-- Attack path findings
+CREATE TABLE IF NOT EXISTS attack_path_findings (
+  id BIGSERIAL PRIMARY KEY,
+  workspace_id INTEGER NOT NULL DEFAULT 0,
+  finding_type TEXT NOT NULL,
+  environment_id TEXT NOT NULL,
+  principal_object_id TEXT NOT NULL,
+  principal_name TEXT NOT NULL DEFAULT '',
+  principal_kind TEXT NOT NULL DEFAULT '',
+  severity TEXT NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT current_timestamp,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT current_timestamp
+);
+
+CREATE INDEX IF NOT EXISTS idx_attack_path_findings_workspace_id_environment_id_finding_type ON attack_path_findings USING btree (workspace_id, environment_id, finding_type);
+
+CREATE TABLE IF NOT EXISTS attack_path_risk_acceptances (
+  id BIGSERIAL PRIMARY KEY,
+  workspace_id INTEGER NOT NULL DEFAULT 0,
+  finding_type TEXT NOT NULL,
+  environment_id TEXT NOT NULL,
+  accepted_until TIMESTAMP WITH TIME ZONE NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT current_timestamp,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT current_timestamp,
+  UNIQUE (workspace_id, environment_id, finding_type)
+);
+
+-- Graph snapshots
+CREATE TABLE IF NOT EXISTS graph_snapshots (
+  id BIGSERIAL PRIMARY KEY,
+  workspace_id INTEGER NOT NULL DEFAULT 0,
+  analysis_run_id BIGINT NOT NULL UNIQUE REFERENCES analysis_runs (id) ON DELETE CASCADE,
+  node_count INTEGER NOT NULL DEFAULT 0,
+  edge_count INTEGER NOT NULL DEFAULT 0,
+  content BYTEA NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT current_timestamp,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT current_timestamp
+);
+
+-- Runtime-managed graph property indexes
+CREATE TABLE IF NOT EXISTS graph_property_indexes (
+  id BIGSERIAL PRIMARY KEY,
+  kind TEXT NOT NULL,
+  property TEXT NOT NULL,
+  index_type TEXT NOT NULL,
+  status TEXT NOT NULL,
+  error TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT current_timestamp,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT current_timestamp,
+  UNIQUE (kind, property)
+);
+
+-- Graph change feed outbox
+CREATE TABLE IF NOT EXISTS graph_change_events (
+  id BIGSERIAL PRIMARY KEY,
+  workspace_id INTEGER NOT NULL DEFAULT 0,
+  changes JSONB NOT NULL,
+  committed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT current_timestamp,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT current_timestamp
+);
+
+CREATE INDEX IF NOT EXISTS idx_graph_change_events_committed_at ON graph_change_events USING btree (committed_at);
+
+-- Named graph workspaces
+CREATE TABLE IF NOT EXISTS workspaces (
+  id SERIAL PRIMARY KEY,
+  name TEXT NOT NULL UNIQUE,
+  description TEXT NOT NULL DEFAULT '',
+  graph_name TEXT NOT NULL DEFAULT '',
+  created_by TEXT REFERENCES users (id) ON DELETE SET NULL,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT current_timestamp,
+  updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT current_timestamp
+);
+
+CREATE TABLE IF NOT EXISTS workspace_users (
+  workspace_id INTEGER NOT NULL REFERENCES workspaces (id) ON DELETE CASCADE,
+  user_id TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+  PRIMARY KEY (workspace_id, user_id)
+);
+
+-- Workspace owned rows. Rows of the default workspace carry a workspace ID of 0.
+ALTER TABLE IF EXISTS saved_queries ADD COLUMN IF NOT EXISTS workspace_id INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE IF EXISTS asset_groups ADD COLUMN IF NOT EXISTS workspace_id INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE IF EXISTS file_upload_jobs ADD COLUMN IF NOT EXISTS workspace_id INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE IF EXISTS ad_data_quality_stats ADD COLUMN IF NOT EXISTS workspace_id INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE IF EXISTS ad_data_quality_aggregations ADD COLUMN IF NOT EXISTS workspace_id INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE IF EXISTS azure_data_quality_stats ADD COLUMN IF NOT EXISTS workspace_id INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE IF EXISTS azure_data_quality_aggregations ADD COLUMN IF NOT EXISTS workspace_id INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_asset_groups_workspace_id ON asset_groups USING btree (workspace_id);
+CREATE INDEX IF NOT EXISTS idx_file_upload_jobs_workspace_id ON file_upload_jobs USING btree (workspace_id);
+
+-- Saved query names are unique per user within a workspace
+DROP INDEX IF EXISTS idx_saved_queries_composite_index;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_saved_queries_composite_index ON saved_queries USING btree (user_id, workspace_id, name);
+
+-- Declared saved query parameters
+ALTER TABLE IF EXISTS saved_queries ADD COLUMN IF NOT EXISTS parameters JSONB NOT NULL DEFAULT '[]';
@@ -54,6 +54,21 @@ func (m *MockDatabase) EXPECT() *MockDatabaseMockRecorder {
 	return m.recorder
 }
 
+// AcceptAttackPathRisk mocks base method.
+func (m *MockDatabase) AcceptAttackPathRisk(arg0 context.Context, arg1 model.AttackPathRiskAcceptance) (model.AttackPathRiskAcceptance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptAttackPathRisk", arg0, arg1)
+	ret0, _ := ret[0].(model.AttackPathRiskAcceptance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptAttackPathRisk indicates an expected call of AcceptAttackPathRisk.
+func (mr *MockDatabaseMockRecorder) AcceptAttackPathRisk(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptAttackPathRisk", reflect.TypeOf((*MockDatabase)(nil).AcceptAttackPathRisk), arg0, arg1)
+}
+
 // AppendAuditLog mocks base method.
 func (m *MockDatabase) AppendAuditLog(arg0 context.Context, arg1 model.AuditEntry) error {
 	m.ctrl.T.Helper()
@@ -68,6 +83,20 @@ func (mr *MockDatabaseMockRecorder) AppendAuditLog(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendAuditLog", reflect.TypeOf((*MockDatabase)(nil).AppendAuditLog), arg0, arg1)
 }
 
+// AppendGraphChangeEvents mocks base method.
+func (m *MockDatabase) AppendGraphChangeEvents(arg0 context.Context, arg1 model.GraphChangeEvents) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendGraphChangeEvents", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AppendGraphChangeEvents indicates an expected call of AppendGraphChangeEvents.
+func (mr *MockDatabaseMockRecorder) AppendGraphChangeEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendGraphChangeEvents", reflect.TypeOf((*MockDatabase)(nil).AppendGraphChangeEvents), arg0, arg1)
+}
+
 // CancelAllFileUploads mocks base method.
 func (m *MockDatabase) CancelAllFileUploads(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -124,6 +153,21 @@ func (mr *MockDatabaseMockRecorder) CreateADDataQualityStats(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateADDataQualityStats", reflect.TypeOf((*MockDatabase)(nil).CreateADDataQualityStats), arg0, arg1)
 }
 
+// CreateAnalysisRun mocks base method.
+func (m *MockDatabase) CreateAnalysisRun(arg0 context.Context, arg1 model.AnalysisRun) (model.AnalysisRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAnalysisRun", arg0, arg1)
+	ret0, _ := ret[0].(model.AnalysisRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAnalysisRun indicates an expected call of CreateAnalysisRun.
+func (mr *MockDatabaseMockRecorder) CreateAnalysisRun(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAnalysisRun", reflect.TypeOf((*MockDatabase)(nil).CreateAnalysisRun), arg0, arg1)
+}
+
 // CreateAssetGroup mocks base method.
 func (m *MockDatabase) CreateAssetGroup(arg0 context.Context, arg1, arg2 string, arg3 bool) (model.AssetGroup, error) {
 	m.ctrl.T.Helper()
@@ -242,6 +286,21 @@ func (mr *MockDatabaseMockRecorder) CreateFileUploadJob(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFileUploadJob", reflect.TypeOf((*MockDatabase)(nil).CreateFileUploadJob), arg0, arg1)
 }
 
+// CreateGraphPropertyIndex mocks base method.
+func (m *MockDatabase) CreateGraphPropertyIndex(arg0 context.Context, arg1 model.GraphPropertyIndex) (model.GraphPropertyIndex, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGraphPropertyIndex", arg0, arg1)
+	ret0, _ := ret[0].(model.GraphPropertyIndex)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGraphPropertyIndex indicates an expected call of CreateGraphPropertyIndex.
+func (mr *MockDatabaseMockRecorder) CreateGraphPropertyIndex(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGraphPropertyIndex", reflect.TypeOf((*MockDatabase)(nil).CreateGraphPropertyIndex), arg0, arg1)
+}
+
 // CreateIngestTask mocks base method.
 func (m *MockDatabase) CreateIngestTask(arg0 context.Context, arg1 model.IngestTask) (model.IngestTask, error) {
 	m.ctrl.T.Helper()
@@ -288,18 +347,33 @@ func (mr *MockDatabaseMockRecorder) CreateSAMLIdentityProvider(arg0, arg1 interf
 }
 
 // CreateSavedQuery mocks base method.
-func (m *MockDatabase) CreateSavedQuery(arg0 context.Context, arg1 uuid.UUID, arg2, arg3 string) (model.SavedQuery, error) {
+func (m *MockDatabase) CreateSavedQuery(arg0 context.Context, arg1 uuid.UUID, arg2, arg3 string, arg4 model.SavedQueryParameters) (model.SavedQuery, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateSavedQuery", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "CreateSavedQuery", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].(model.SavedQuery)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateSavedQuery indicates an expected call of CreateSavedQuery.
-func (mr *MockDatabaseMockRecorder) CreateSavedQuery(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockDatabaseMockRecorder) CreateSavedQuery(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSavedQuery", reflect.TypeOf((*MockDatabase)(nil).CreateSavedQuery), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSavedQuery", reflect.TypeOf((*MockDatabase)(nil).CreateSavedQuery), arg0, arg1, arg2, arg3, arg4)
+}
+
+// CreateTierZeroViolationRun mocks base method.
+func (m *MockDatabase) CreateTierZeroViolationRun(arg0 context.Context, arg1 model.TierZeroViolations) (model.TierZeroViolationRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTierZeroViolationRun", arg0, arg1)
+	ret0, _ := ret[0].(model.TierZeroViolationRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTierZeroViolationRun indicates an expected call of CreateTierZeroViolationRun.
+func (mr *MockDatabaseMockRecorder) CreateTierZeroViolationRun(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTierZeroViolationRun", reflect.TypeOf((*MockDatabase)(nil).CreateTierZeroViolationRun), arg0, arg1)
 }
 
 // CreateUser mocks base method.
@@ -332,6 +406,21 @@ func (mr *MockDatabaseMockRecorder) CreateUserSession(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserSession", reflect.TypeOf((*MockDatabase)(nil).CreateUserSession), arg0, arg1)
 }
 
+// CreateWorkspace mocks base method.
+func (m *MockDatabase) CreateWorkspace(arg0 context.Context, arg1 model.Workspace) (model.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWorkspace", arg0, arg1)
+	ret0, _ := ret[0].(model.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWorkspace indicates an expected call of CreateWorkspace.
+func (mr *MockDatabaseMockRecorder) CreateWorkspace(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorkspace", reflect.TypeOf((*MockDatabase)(nil).CreateWorkspace), arg0, arg1)
+}
+
 // DeleteAllDataQuality mocks base method.
 func (m *MockDatabase) DeleteAllDataQuality(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -444,6 +533,20 @@ func (mr *MockDatabaseMockRecorder) DeleteAuthToken(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAuthToken", reflect.TypeOf((*MockDatabase)(nil).DeleteAuthToken), arg0, arg1)
 }
 
+// DeleteGraphPropertyIndex mocks base method.
+func (m *MockDatabase) DeleteGraphPropertyIndex(arg0 context.Context, arg1 model.GraphPropertyIndex) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGraphPropertyIndex", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGraphPropertyIndex indicates an expected call of DeleteGraphPropertyIndex.
+func (mr *MockDatabaseMockRecorder) DeleteGraphPropertyIndex(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGraphPropertyIndex", reflect.TypeOf((*MockDatabase)(nil).DeleteGraphPropertyIndex), arg0, arg1)
+}
+
 // DeleteIngestTask mocks base method.
 func (m *MockDatabase) DeleteIngestTask(arg0 context.Context, arg1 model.IngestTask) error {
 	m.ctrl.T.Helper()
@@ -500,6 +603,20 @@ func (mr *MockDatabaseMockRecorder) DeleteUser(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockDatabase)(nil).DeleteUser), arg0, arg1)
 }
 
+// DeleteWorkspace mocks base method.
+func (m *MockDatabase) DeleteWorkspace(arg0 context.Context, arg1 model.Workspace) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWorkspace", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWorkspace indicates an expected call of DeleteWorkspace.
+func (mr *MockDatabaseMockRecorder) DeleteWorkspace(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkspace", reflect.TypeOf((*MockDatabase)(nil).DeleteWorkspace), arg0, arg1)
+}
+
 // EndUserSession mocks base method.
 func (m *MockDatabase) EndUserSession(arg0 context.Context, arg1 model.UserSession) {
 	m.ctrl.T.Helper()
@@ -695,6 +812,21 @@ func (mr *MockDatabaseMockRecorder) GetAllUsers(arg0, arg1, arg2 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUsers", reflect.TypeOf((*MockDatabase)(nil).GetAllUsers), arg0, arg1, arg2)
 }
 
+// GetAllWorkspaces mocks base method.
+func (m *MockDatabase) GetAllWorkspaces(arg0 context.Context) (model.Workspaces, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllWorkspaces", arg0)
+	ret0, _ := ret[0].(model.Workspaces)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllWorkspaces indicates an expected call of GetAllWorkspaces.
+func (mr *MockDatabaseMockRecorder) GetAllWorkspaces(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllWorkspaces", reflect.TypeOf((*MockDatabase)(nil).GetAllWorkspaces), arg0)
+}
+
 // GetAssetGroup mocks base method.
 func (m *MockDatabase) GetAssetGroup(arg0 context.Context, arg1 int32) (model.AssetGroup, error) {
 	m.ctrl.T.Helper()
@@ -740,6 +872,37 @@ func (mr *MockDatabaseMockRecorder) GetAssetGroupSelector(arg0, arg1 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetGroupSelector", reflect.TypeOf((*MockDatabase)(nil).GetAssetGroupSelector), arg0, arg1)
 }
 
+// GetAttackPathFindingSummaries mocks base method.
+func (m *MockDatabase) GetAttackPathFindingSummaries(arg0 context.Context, arg1 string) (model.AttackPathFindingSummaries, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttackPathFindingSummaries", arg0, arg1)
+	ret0, _ := ret[0].(model.AttackPathFindingSummaries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttackPathFindingSummaries indicates an expected call of GetAttackPathFindingSummaries.
+func (mr *MockDatabaseMockRecorder) GetAttackPathFindingSummaries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttackPathFindingSummaries", reflect.TypeOf((*MockDatabase)(nil).GetAttackPathFindingSummaries), arg0, arg1)
+}
+
+// GetAttackPathFindings mocks base method.
+func (m *MockDatabase) GetAttackPathFindings(arg0 context.Context, arg1, arg2, arg3 string, arg4 model.SQLFilter, arg5, arg6 int) (model.AttackPathFindings, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttackPathFindings", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret0, _ := ret[0].(model.AttackPathFindings)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAttackPathFindings indicates an expected call of GetAttackPathFindings.
+func (mr *MockDatabaseMockRecorder) GetAttackPathFindings(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttackPathFindings", reflect.TypeOf((*MockDatabase)(nil).GetAttackPathFindings), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+}
+
 // GetAuthSecret mocks base method.
 func (m *MockDatabase) GetAuthSecret(arg0 context.Context, arg1 int32) (model.AuthSecret, error) {
 	m.ctrl.T.Helper()
@@ -877,6 +1040,51 @@ func (mr *MockDatabaseMockRecorder) GetFlagByKey(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFlagByKey", reflect.TypeOf((*MockDatabase)(nil).GetFlagByKey), arg0, arg1)
 }
 
+// GetGraphPropertyIndex mocks base method.
+func (m *MockDatabase) GetGraphPropertyIndex(arg0 context.Context, arg1 int64) (model.GraphPropertyIndex, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGraphPropertyIndex", arg0, arg1)
+	ret0, _ := ret[0].(model.GraphPropertyIndex)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGraphPropertyIndex indicates an expected call of GetGraphPropertyIndex.
+func (mr *MockDatabaseMockRecorder) GetGraphPropertyIndex(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGraphPropertyIndex", reflect.TypeOf((*MockDatabase)(nil).GetGraphPropertyIndex), arg0, arg1)
+}
+
+// GetGraphPropertyIndexes mocks base method.
+func (m *MockDatabase) GetGraphPropertyIndexes(arg0 context.Context) (model.GraphPropertyIndexes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGraphPropertyIndexes", arg0)
+	ret0, _ := ret[0].(model.GraphPropertyIndexes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGraphPropertyIndexes indicates an expected call of GetGraphPropertyIndexes.
+func (mr *MockDatabaseMockRecorder) GetGraphPropertyIndexes(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGraphPropertyIndexes", reflect.TypeOf((*MockDatabase)(nil).GetGraphPropertyIndexes), arg0)
+}
+
+// GetGraphSnapshotByAnalysisRunID mocks base method.
+func (m *MockDatabase) GetGraphSnapshotByAnalysisRunID(arg0 context.Context, arg1 int64) (model.GraphSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGraphSnapshotByAnalysisRunID", arg0, arg1)
+	ret0, _ := ret[0].(model.GraphSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGraphSnapshotByAnalysisRunID indicates an expected call of GetGraphSnapshotByAnalysisRunID.
+func (mr *MockDatabaseMockRecorder) GetGraphSnapshotByAnalysisRunID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGraphSnapshotByAnalysisRunID", reflect.TypeOf((*MockDatabase)(nil).GetGraphSnapshotByAnalysisRunID), arg0, arg1)
+}
+
 // GetIngestTasksForJob mocks base method.
 func (m *MockDatabase) GetIngestTasksForJob(arg0 context.Context, arg1 int64) (model.IngestTasks, error) {
 	m.ctrl.T.Helper()
@@ -922,6 +1130,21 @@ func (mr *MockDatabaseMockRecorder) GetLatestAssetGroupCollection(arg0, arg1 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestAssetGroupCollection", reflect.TypeOf((*MockDatabase)(nil).GetLatestAssetGroupCollection), arg0, arg1)
 }
 
+// GetLatestTierZeroViolationRun mocks base method.
+func (m *MockDatabase) GetLatestTierZeroViolationRun(arg0 context.Context) (model.TierZeroViolationRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestTierZeroViolationRun", arg0)
+	ret0, _ := ret[0].(model.TierZeroViolationRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestTierZeroViolationRun indicates an expected call of GetLatestTierZeroViolationRun.
+func (mr *MockDatabaseMockRecorder) GetLatestTierZeroViolationRun(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestTierZeroViolationRun", reflect.TypeOf((*MockDatabase)(nil).GetLatestTierZeroViolationRun), arg0)
+}
+
 // GetPermission mocks base method.
 func (m *MockDatabase) GetPermission(arg0 context.Context, arg1 int) (model.Permission, error) {
 	m.ctrl.T.Helper()
@@ -937,6 +1160,22 @@ func (mr *MockDatabaseMockRecorder) GetPermission(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPermission", reflect.TypeOf((*MockDatabase)(nil).GetPermission), arg0, arg1)
 }
 
+// GetPrivilegedSessionExposures mocks base method.
+func (m *MockDatabase) GetPrivilegedSessionExposures(arg0 context.Context, arg1, arg2 string, arg3 model.SQLFilter, arg4, arg5 int) (model.PrivilegedSessionExposures, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrivilegedSessionExposures", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(model.PrivilegedSessionExposures)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPrivilegedSessionExposures indicates an expected call of GetPrivilegedSessionExposures.
+func (mr *MockDatabaseMockRecorder) GetPrivilegedSessionExposures(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrivilegedSessionExposures", reflect.TypeOf((*MockDatabase)(nil).GetPrivilegedSessionExposures), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // GetRole mocks base method.
 func (m *MockDatabase) GetRole(arg0 context.Context, arg1 int32) (model.Role, error) {
 	m.ctrl.T.Helper()
@@ -997,6 +1236,21 @@ func (mr *MockDatabaseMockRecorder) GetSAMLProviderUsers(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSAMLProviderUsers", reflect.TypeOf((*MockDatabase)(nil).GetSAMLProviderUsers), arg0, arg1)
 }
 
+// GetSavedQuery mocks base method.
+func (m *MockDatabase) GetSavedQuery(arg0 context.Context, arg1 uuid.UUID, arg2 int64) (model.SavedQuery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSavedQuery", arg0, arg1, arg2)
+	ret0, _ := ret[0].(model.SavedQuery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSavedQuery indicates an expected call of GetSavedQuery.
+func (mr *MockDatabaseMockRecorder) GetSavedQuery(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSavedQuery", reflect.TypeOf((*MockDatabase)(nil).GetSavedQuery), arg0, arg1, arg2)
+}
+
 // GetTimeRangedAssetGroupCollections mocks base method.
 func (m *MockDatabase) GetTimeRangedAssetGroupCollections(arg0 context.Context, arg1 int32, arg2, arg3 int64, arg4 string) (model.AssetGroupCollections, error) {
 	m.ctrl.T.Helper()
@@ -1057,6 +1311,21 @@ func (mr *MockDatabaseMockRecorder) GetUserToken(arg0, arg1, arg2 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserToken", reflect.TypeOf((*MockDatabase)(nil).GetUserToken), arg0, arg1, arg2)
 }
 
+// GetWorkspace mocks base method.
+func (m *MockDatabase) GetWorkspace(arg0 context.Context, arg1 int32) (model.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspace", arg0, arg1)
+	ret0, _ := ret[0].(model.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspace indicates an expected call of GetWorkspace.
+func (mr *MockDatabaseMockRecorder) GetWorkspace(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspace", reflect.TypeOf((*MockDatabase)(nil).GetWorkspace), arg0, arg1)
+}
+
 // HasInstallation mocks base method.
 func (m *MockDatabase) HasInstallation(arg0 context.Context) (bool, error) {
 	m.ctrl.T.Helper()
@@ -1087,6 +1356,22 @@ func (mr *MockDatabaseMockRecorder) InitializeSecretAuth(arg0, arg1, arg2 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InitializeSecretAuth", reflect.TypeOf((*MockDatabase)(nil).InitializeSecretAuth), arg0, arg1, arg2)
 }
 
+// ListAnalysisRuns mocks base method.
+func (m *MockDatabase) ListAnalysisRuns(arg0 context.Context, arg1 string, arg2 model.SQLFilter, arg3, arg4 int) (model.AnalysisRuns, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAnalysisRuns", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(model.AnalysisRuns)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListAnalysisRuns indicates an expected call of ListAnalysisRuns.
+func (mr *MockDatabaseMockRecorder) ListAnalysisRuns(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAnalysisRuns", reflect.TypeOf((*MockDatabase)(nil).ListAnalysisRuns), arg0, arg1, arg2, arg3, arg4)
+}
+
 // ListAuditLogs mocks base method.
 func (m *MockDatabase) ListAuditLogs(arg0 context.Context, arg1, arg2 time.Time, arg3, arg4 int, arg5 string, arg6 model.SQLFilter) (model.AuditLogs, int, error) {
 	m.ctrl.T.Helper()
@@ -1103,6 +1388,21 @@ func (mr *MockDatabaseMockRecorder) ListAuditLogs(arg0, arg1, arg2, arg3, arg4,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditLogs", reflect.TypeOf((*MockDatabase)(nil).ListAuditLogs), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 }
 
+// ListGraphChangeEvents mocks base method.
+func (m *MockDatabase) ListGraphChangeEvents(arg0 context.Context, arg1 int64, arg2 int) (model.GraphChangeEvents, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGraphChangeEvents", arg0, arg1, arg2)
+	ret0, _ := ret[0].(model.GraphChangeEvents)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGraphChangeEvents indicates an expected call of ListGraphChangeEvents.
+func (mr *MockDatabaseMockRecorder) ListGraphChangeEvents(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGraphChangeEvents", reflect.TypeOf((*MockDatabase)(nil).ListGraphChangeEvents), arg0, arg1, arg2)
+}
+
 // ListSavedQueries mocks base method.
 func (m *MockDatabase) ListSavedQueries(arg0 context.Context, arg1 uuid.UUID, arg2 string, arg3 model.SQLFilter, arg4, arg5 int) (model.SavedQueries, int, error) {
 	m.ctrl.T.Helper()
@@ -1119,6 +1419,22 @@ func (mr *MockDatabaseMockRecorder) ListSavedQueries(arg0, arg1, arg2, arg3, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSavedQueries", reflect.TypeOf((*MockDatabase)(nil).ListSavedQueries), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
+// ListTierZeroViolations mocks base method.
+func (m *MockDatabase) ListTierZeroViolations(arg0 context.Context, arg1 int64, arg2 string, arg3 model.SQLFilter, arg4, arg5 int) (model.TierZeroViolations, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTierZeroViolations", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(model.TierZeroViolations)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListTierZeroViolations indicates an expected call of ListTierZeroViolations.
+func (mr *MockDatabaseMockRecorder) ListTierZeroViolations(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTierZeroViolations", reflect.TypeOf((*MockDatabase)(nil).ListTierZeroViolations), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // LookupActiveSessionsByUser mocks base method.
 func (m *MockDatabase) LookupActiveSessionsByUser(arg0 context.Context, arg1 model.User) ([]model.UserSession, error) {
 	m.ctrl.T.Helper()
@@ -1178,6 +1494,34 @@ func (mr *MockDatabaseMockRecorder) Migrate(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Migrate", reflect.TypeOf((*MockDatabase)(nil).Migrate), arg0)
 }
 
+// ReplaceAttackPathFindings mocks base method.
+func (m *MockDatabase) ReplaceAttackPathFindings(arg0 context.Context, arg1 model.AttackPathFindings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceAttackPathFindings", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceAttackPathFindings indicates an expected call of ReplaceAttackPathFindings.
+func (mr *MockDatabaseMockRecorder) ReplaceAttackPathFindings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceAttackPathFindings", reflect.TypeOf((*MockDatabase)(nil).ReplaceAttackPathFindings), arg0, arg1)
+}
+
+// ReplacePrivilegedSessionExposures mocks base method.
+func (m *MockDatabase) ReplacePrivilegedSessionExposures(arg0 context.Context, arg1 model.PrivilegedSessionExposures) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplacePrivilegedSessionExposures", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplacePrivilegedSessionExposures indicates an expected call of ReplacePrivilegedSessionExposures.
+func (mr *MockDatabaseMockRecorder) ReplacePrivilegedSessionExposures(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplacePrivilegedSessionExposures", reflect.TypeOf((*MockDatabase)(nil).ReplacePrivilegedSessionExposures), arg0, arg1)
+}
+
 // RequiresMigration mocks base method.
 func (m *MockDatabase) RequiresMigration(arg0 context.Context) (bool, error) {
 	m.ctrl.T.Helper()
@@ -1193,6 +1537,20 @@ func (mr *MockDatabaseMockRecorder) RequiresMigration(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequiresMigration", reflect.TypeOf((*MockDatabase)(nil).RequiresMigration), arg0)
 }
 
+// SaveGraphSnapshot mocks base method.
+func (m *MockDatabase) SaveGraphSnapshot(arg0 context.Context, arg1 model.GraphSnapshot, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveGraphSnapshot", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveGraphSnapshot indicates an expected call of SaveGraphSnapshot.
+func (mr *MockDatabaseMockRecorder) SaveGraphSnapshot(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveGraphSnapshot", reflect.TypeOf((*MockDatabase)(nil).SaveGraphSnapshot), arg0, arg1, arg2)
+}
+
 // SavedQueryBelongsToUser mocks base method.
 func (m *MockDatabase) SavedQueryBelongsToUser(arg0 context.Context, arg1 uuid.UUID, arg2 int) (bool, error) {
 	m.ctrl.T.Helper()
@@ -1248,6 +1606,18 @@ func (mr *MockDatabaseMockRecorder) SweepAssetGroupCollections(arg0 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SweepAssetGroupCollections", reflect.TypeOf((*MockDatabase)(nil).SweepAssetGroupCollections), arg0)
 }
 
+// SweepGraphChangeEvents mocks base method.
+func (m *MockDatabase) SweepGraphChangeEvents(arg0 context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SweepGraphChangeEvents", arg0)
+}
+
+// SweepGraphChangeEvents indicates an expected call of SweepGraphChangeEvents.
+func (mr *MockDatabaseMockRecorder) SweepGraphChangeEvents(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SweepGraphChangeEvents", reflect.TypeOf((*MockDatabase)(nil).SweepGraphChangeEvents), arg0)
+}
+
 // SweepSessions mocks base method.
 func (m *MockDatabase) SweepSessions(arg0 context.Context) {
 	m.ctrl.T.Helper()
@@ -1260,6 +1630,32 @@ func (mr *MockDatabaseMockRecorder) SweepSessions(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SweepSessions", reflect.TypeOf((*MockDatabase)(nil).SweepSessions), arg0)
 }
 
+// SweepTierZeroViolationRuns mocks base method.
+func (m *MockDatabase) SweepTierZeroViolationRuns(arg0 context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SweepTierZeroViolationRuns", arg0)
+}
+
+// SweepTierZeroViolationRuns indicates an expected call of SweepTierZeroViolationRuns.
+func (mr *MockDatabaseMockRecorder) SweepTierZeroViolationRuns(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SweepTierZeroViolationRuns", reflect.TypeOf((*MockDatabase)(nil).SweepTierZeroViolationRuns), arg0)
+}
+
+// UnacceptAttackPathRisk mocks base method.
+func (m *MockDatabase) UnacceptAttackPathRisk(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnacceptAttackPathRisk", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnacceptAttackPathRisk indicates an expected call of UnacceptAttackPathRisk.
+func (mr *MockDatabaseMockRecorder) UnacceptAttackPathRisk(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnacceptAttackPathRisk", reflect.TypeOf((*MockDatabase)(nil).UnacceptAttackPathRisk), arg0, arg1, arg2)
+}
+
 // UpdateAssetGroup mocks base method.
 func (m *MockDatabase) UpdateAssetGroup(arg0 context.Context, arg1 model.AssetGroup) error {
 	m.ctrl.T.Helper()
@@ -1331,6 +1727,20 @@ func (mr *MockDatabaseMockRecorder) UpdateFileUploadJob(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFileUploadJob", reflect.TypeOf((*MockDatabase)(nil).UpdateFileUploadJob), arg0, arg1)
 }
 
+// UpdateGraphPropertyIndexStatus mocks base method.
+func (m *MockDatabase) UpdateGraphPropertyIndexStatus(arg0 context.Context, arg1 []int64, arg2 model.GraphPropertyIndexStatus, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGraphPropertyIndexStatus", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGraphPropertyIndexStatus indicates an expected call of UpdateGraphPropertyIndexStatus.
+func (mr *MockDatabaseMockRecorder) UpdateGraphPropertyIndexStatus(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGraphPropertyIndexStatus", reflect.TypeOf((*MockDatabase)(nil).UpdateGraphPropertyIndexStatus), arg0, arg1, arg2, arg3)
+}
+
 // UpdateSAMLIdentityProvider mocks base method.
 func (m *MockDatabase) UpdateSAMLIdentityProvider(arg0 context.Context, arg1 model.SAMLProvider) error {
 	m.ctrl.T.Helper()
@@ -1359,6 +1769,20 @@ func (mr *MockDatabaseMockRecorder) UpdateUser(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockDatabase)(nil).UpdateUser), arg0, arg1)
 }
 
+// UpdateWorkspace mocks base method.
+func (m *MockDatabase) UpdateWorkspace(arg0 context.Context, arg1 model.Workspace) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWorkspace", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWorkspace indicates an expected call of UpdateWorkspace.
+func (mr *MockDatabaseMockRecorder) UpdateWorkspace(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspace", reflect.TypeOf((*MockDatabase)(nil).UpdateWorkspace), arg0, arg1)
+}
+
 // Wipe mocks base method.
 func (m *MockDatabase) Wipe(arg0 context.Context) error {
 	m.ctrl.T.Helper()
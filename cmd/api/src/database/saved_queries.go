@@ -28,14 +28,14 @@ func (s *BloodhoundDB) ListSavedQueries(ctx context.Context, userID uuid.UUID, o
 		queries model.SavedQueries
 		result  *gorm.DB
 		count   int64
-		cursor  = s.Scope(Paginate(skip, limit)).WithContext(ctx).Where("user_id = ?", userID)
+		cursor  = s.Scope(Paginate(skip, limit), WorkspaceScope(ctx)).WithContext(ctx).Where("user_id = ?", userID)
 	)
 
 	if filter.SQLString != "" {
 		cursor = cursor.Where(filter.SQLString, filter.Params)
-		result = s.db.Model(&queries).WithContext(ctx).Where("user_id = ?", userID).Where(filter.SQLString, filter.Params).Count(&count)
+		result = s.Scope(WorkspaceScope(ctx)).Model(&queries).WithContext(ctx).Where("user_id = ?", userID).Where(filter.SQLString, filter.Params).Count(&count)
 	} else {
-		result = s.db.Model(&queries).WithContext(ctx).Where("user_id = ?", userID).Count(&count)
+		result = s.Scope(WorkspaceScope(ctx)).Model(&queries).WithContext(ctx).Where("user_id = ?", userID).Count(&count)
 	}
 
 	if result.Error != nil {
@@ -50,23 +50,31 @@ func (s *BloodhoundDB) ListSavedQueries(ctx context.Context, userID uuid.UUID, o
 	return queries, int(count), CheckError(result)
 }
 
-func (s *BloodhoundDB) CreateSavedQuery(ctx context.Context, userID uuid.UUID, name string, query string) (model.SavedQuery, error) {
+func (s *BloodhoundDB) CreateSavedQuery(ctx context.Context, userID uuid.UUID, name string, query string, parameters model.SavedQueryParameters) (model.SavedQuery, error) {
 	savedQuery := model.SavedQuery{
-		UserID: userID.String(),
-		Name:   name,
-		Query:  query,
+		UserID:      userID.String(),
+		WorkspaceID: contextWorkspaceID(ctx),
+		Name:        name,
+		Query:       query,
+		Parameters:  parameters,
 	}
 
 	return savedQuery, CheckError(s.db.WithContext(ctx).Create(&savedQuery))
 }
 
+// GetSavedQuery returns the saved query with the given ID if it belongs to the given user
+func (s *BloodhoundDB) GetSavedQuery(ctx context.Context, userID uuid.UUID, savedQueryID int64) (model.SavedQuery, error) {
+	var savedQuery model.SavedQuery
+	return savedQuery, CheckError(s.Scope(WorkspaceScope(ctx)).WithContext(ctx).Where("user_id = ?", userID).First(&savedQuery, savedQueryID))
+}
+
 func (s *BloodhoundDB) DeleteSavedQuery(ctx context.Context, id int) error {
 	return CheckError(s.db.WithContext(ctx).Delete(&model.SavedQuery{}, id))
 }
 
 func (s *BloodhoundDB) SavedQueryBelongsToUser(ctx context.Context, userID uuid.UUID, savedQueryID int) (bool, error) {
 	var savedQuery model.SavedQuery
-	if result := s.db.WithContext(ctx).First(&savedQuery, savedQueryID); result.Error != nil {
+	if result := s.Scope(WorkspaceScope(ctx)).WithContext(ctx).First(&savedQuery, savedQueryID); result.Error != nil {
 		return false, CheckError(result)
 	} else if savedQuery.UserID == userID.String() {
 		return true, nil
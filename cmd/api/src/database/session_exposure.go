@@ -0,0 +1,73 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+
+	"github.com/specterops/bloodhound/src/model"
+	"gorm.io/gorm"
+)
+
+const privilegedSessionExposureBatchSize = 1000
+
+// ReplacePrivilegedSessionExposures removes all previously stored exposures of the workspace the given context is
+// scoped to and stores the given ones in their place
+func (s *BloodhoundDB) ReplacePrivilegedSessionExposures(ctx context.Context, exposures model.PrivilegedSessionExposures) error {
+	for idx := range exposures {
+		exposures[idx].WorkspaceID = contextWorkspaceID(ctx)
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if result := tx.Scopes(WorkspaceScope(ctx)).Where("1 = 1").Delete(&model.PrivilegedSessionExposure{}); result.Error != nil {
+			return CheckError(result)
+		}
+
+		// GORM will fail on an attempt to insert an empty slice
+		if len(exposures) > 0 {
+			return CheckError(tx.CreateInBatches(&exposures, privilegedSessionExposureBatchSize))
+		}
+
+		return nil
+	})
+}
+
+func (s *BloodhoundDB) GetPrivilegedSessionExposures(ctx context.Context, domainSID string, order string, filter model.SQLFilter, skip, limit int) (model.PrivilegedSessionExposures, int, error) {
+	var (
+		exposures model.PrivilegedSessionExposures
+		count     int64
+		result    *gorm.DB
+		cursor    = s.Scope(Paginate(skip, limit), WorkspaceScope(ctx)).WithContext(ctx).Where("domain_sid = ?", domainSID)
+		counter   = s.Scope(WorkspaceScope(ctx)).Model(&exposures).WithContext(ctx).Where("domain_sid = ?", domainSID)
+	)
+
+	if filter.SQLString != "" {
+		cursor = cursor.Where(filter.SQLString, filter.Params...)
+		counter = counter.Where(filter.SQLString, filter.Params...)
+	}
+
+	if result = counter.Count(&count); result.Error != nil {
+		return exposures, 0, CheckError(result)
+	}
+
+	if order == "" {
+		order = "reachable_principal_count desc, computer_name"
+	}
+
+	result = cursor.Order(order).Find(&exposures)
+	return exposures, int(count), CheckError(result)
+}
@@ -0,0 +1,88 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+
+	"github.com/specterops/bloodhound/src/model"
+	"gorm.io/gorm"
+)
+
+const tierZeroViolationBatchSize = 1000
+
+func (s *BloodhoundDB) CreateTierZeroViolationRun(ctx context.Context, violations model.TierZeroViolations) (model.TierZeroViolationRun, error) {
+	var run = model.TierZeroViolationRun{
+		ViolationCount: len(violations),
+		WorkspaceID:    contextWorkspaceID(ctx),
+	}
+
+	return run, s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if result := tx.Omit("Violations").Create(&run); result.Error != nil {
+			return CheckError(result)
+		}
+
+		// GORM will fail on an attempt to insert an empty slice, so we have to guard against empty runs here
+		if len(violations) > 0 {
+			for idx := range violations {
+				violations[idx].RunID = run.ID
+			}
+
+			return CheckError(tx.CreateInBatches(&violations, tierZeroViolationBatchSize))
+		}
+
+		return nil
+	})
+}
+
+func (s *BloodhoundDB) GetLatestTierZeroViolationRun(ctx context.Context) (model.TierZeroViolationRun, error) {
+	var run model.TierZeroViolationRun
+	return run, CheckError(s.Scope(WorkspaceScope(ctx)).WithContext(ctx).Last(&run))
+}
+
+// ListTierZeroViolations returns the violations of the given run. Runs of workspaces other than the one the given context
+// is scoped to have no visible violations.
+func (s *BloodhoundDB) ListTierZeroViolations(ctx context.Context, runID int64, order string, filter model.SQLFilter, skip, limit int) (model.TierZeroViolations, int, error) {
+	var (
+		violations model.TierZeroViolations
+		count      int64
+		result     *gorm.DB
+		runs       = s.Scope(WorkspaceScope(ctx)).Model(&model.TierZeroViolationRun{}).Select("id").Where("id = ?", runID)
+		cursor     = s.Scope(Paginate(skip, limit)).WithContext(ctx).Where("run_id in (?)", runs)
+		counter    = s.db.Model(&violations).WithContext(ctx).Where("run_id in (?)", runs)
+	)
+
+	if filter.SQLString != "" {
+		cursor = cursor.Where(filter.SQLString, filter.Params...)
+		counter = counter.Where(filter.SQLString, filter.Params...)
+	}
+
+	if result = counter.Count(&count); result.Error != nil {
+		return violations, 0, CheckError(result)
+	}
+
+	if order == "" {
+		order = "depth, name"
+	}
+
+	result = cursor.Order(order).Find(&violations)
+	return violations, int(count), CheckError(result)
+}
+
+func (s *BloodhoundDB) SweepTierZeroViolationRuns(ctx context.Context) {
+	s.db.WithContext(ctx).Where("created_at < now() - INTERVAL '30 DAYS'").Delete(&model.TierZeroViolationRun{})
+}
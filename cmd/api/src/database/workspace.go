@@ -0,0 +1,212 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gofrs/uuid"
+	"github.com/specterops/bloodhound/src/ctx"
+	"github.com/specterops/bloodhound/src/model"
+	"gorm.io/gorm"
+)
+
+// workspaceOwnedTables lists the tables whose rows belong to a single workspace
+var workspaceOwnedTables = []string{
+	"saved_queries",
+	"asset_groups",
+	"file_upload_jobs",
+	"ad_data_quality_stats",
+	"ad_data_quality_aggregations",
+	"azure_data_quality_stats",
+	"azure_data_quality_aggregations",
+	"tier_zero_violation_runs",
+	"privileged_session_exposures",
+	"analysis_runs",
+	"attack_path_findings",
+	"attack_path_risk_acceptances",
+	"graph_snapshots",
+	"graph_change_events",
+}
+
+// WorkspaceScope restricts a query of a workspace owned table to the workspace that the given context is scoped to.
+// Queries made with contexts that are not scoped to a workspace see the rows of every workspace.
+func WorkspaceScope(requestCtx context.Context) ScopeFunc {
+	return func(db *gorm.DB) *gorm.DB {
+		if workspaceID, isScoped := ctx.WorkspaceID(requestCtx); isScoped {
+			return db.Where("workspace_id = ?", workspaceID)
+		}
+
+		return db
+	}
+}
+
+// contextWorkspaceID returns the ID of the workspace that new workspace owned rows should belong to
+func contextWorkspaceID(requestCtx context.Context) int32 {
+	if workspaceID, isScoped := ctx.WorkspaceID(requestCtx); isScoped {
+		return workspaceID
+	}
+
+	return model.DefaultWorkspaceID
+}
+
+func loadWorkspaceUsers(tx *gorm.DB, workspaces model.Workspaces) error {
+	var members []model.WorkspaceUser
+
+	if len(workspaces) == 0 {
+		return nil
+	}
+
+	workspaceIDs := make([]int32, len(workspaces))
+	for idx, workspace := range workspaces {
+		workspaceIDs[idx] = workspace.ID
+		workspaces[idx].UserIDs = []uuid.UUID{}
+	}
+
+	if err := CheckError(tx.Where("workspace_id in ?", workspaceIDs).Find(&members)); err != nil {
+		return err
+	}
+
+	for _, member := range members {
+		for idx := range workspaces {
+			if workspaces[idx].ID == member.WorkspaceID {
+				workspaces[idx].UserIDs = append(workspaces[idx].UserIDs, member.UserID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func replaceWorkspaceUsers(tx *gorm.DB, workspace model.Workspace) error {
+	if err := CheckError(tx.Where("workspace_id = ?", workspace.ID).Delete(&model.WorkspaceUser{})); err != nil {
+		return err
+	}
+
+	if len(workspace.UserIDs) == 0 {
+		return nil
+	}
+
+	members := make([]model.WorkspaceUser, len(workspace.UserIDs))
+	for idx, userID := range workspace.UserIDs {
+		members[idx] = model.WorkspaceUser{
+			WorkspaceID: workspace.ID,
+			UserID:      userID,
+		}
+	}
+
+	return CheckError(tx.Create(&members))
+}
+
+func (s *BloodhoundDB) GetAllWorkspaces(ctx context.Context) (model.Workspaces, error) {
+	var workspaces model.Workspaces
+
+	if err := CheckError(s.db.WithContext(ctx).Order("name").Find(&workspaces)); err != nil {
+		return workspaces, err
+	}
+
+	return workspaces, loadWorkspaceUsers(s.db.WithContext(ctx), workspaces)
+}
+
+func (s *BloodhoundDB) GetWorkspace(ctx context.Context, id int32) (model.Workspace, error) {
+	var workspace model.Workspace
+
+	if err := CheckError(s.db.WithContext(ctx).First(&workspace, id)); err != nil {
+		return workspace, err
+	}
+
+	workspaces := model.Workspaces{workspace}
+	return workspaces[0], loadWorkspaceUsers(s.db.WithContext(ctx), workspaces)
+}
+
+// CreateWorkspace persists a new workspace along with its members and the system asset groups that analysis expects
+// to exist. The graph name of the workspace is derived from its ID.
+func (s *BloodhoundDB) CreateWorkspace(ctx context.Context, workspace model.Workspace) (model.Workspace, error) {
+	auditEntry := model.AuditEntry{
+		Action: model.AuditLogActionCreateWorkspace,
+		Model:  &workspace, // Pointer is required to ensure success log contains updated fields after transaction
+	}
+
+	return workspace, s.AuditableTransaction(ctx, auditEntry, func(tx *gorm.DB) error {
+		systemAssetGroups := []model.AssetGroup{{
+			Name:        model.TierZeroAssetGroupName,
+			Tag:         model.TierZeroAssetGroupTag,
+			SystemGroup: true,
+		}, {
+			Name:        model.OwnedAssetGroupName,
+			Tag:         model.OwnedAssetGroupTag,
+			SystemGroup: true,
+		}}
+
+		if err := CheckError(tx.Create(&workspace)); err != nil {
+			return err
+		}
+
+		workspace.GraphName = model.WorkspaceGraphName(workspace.ID)
+
+		if err := CheckError(tx.Model(&workspace).Update("graph_name", workspace.GraphName)); err != nil {
+			return err
+		} else if err := replaceWorkspaceUsers(tx, workspace); err != nil {
+			return err
+		}
+
+		for idx := range systemAssetGroups {
+			systemAssetGroups[idx].WorkspaceID = workspace.ID
+		}
+
+		return CheckError(tx.Create(&systemAssetGroups))
+	})
+}
+
+// UpdateWorkspace saves the name and description of the given workspace and replaces its members
+func (s *BloodhoundDB) UpdateWorkspace(ctx context.Context, workspace model.Workspace) error {
+	auditEntry := model.AuditEntry{
+		Action: model.AuditLogActionUpdateWorkspace,
+		Model:  &workspace,
+	}
+
+	return s.AuditableTransaction(ctx, auditEntry, func(tx *gorm.DB) error {
+		if err := CheckError(tx.Model(&workspace).Updates(map[string]any{
+			"name":        workspace.Name,
+			"description": workspace.Description,
+		})); err != nil {
+			return err
+		}
+
+		return replaceWorkspaceUsers(tx, workspace)
+	})
+}
+
+// DeleteWorkspace removes the given workspace and every workspace owned row. The graph backing the workspace must be
+// dropped separately.
+func (s *BloodhoundDB) DeleteWorkspace(ctx context.Context, workspace model.Workspace) error {
+	auditEntry := model.AuditEntry{
+		Action: model.AuditLogActionDeleteWorkspace,
+		Model:  &workspace,
+	}
+
+	return s.AuditableTransaction(ctx, auditEntry, func(tx *gorm.DB) error {
+		for _, tableName := range workspaceOwnedTables {
+			if err := CheckError(tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE workspace_id = ?", tableName), workspace.ID)); err != nil {
+				return err
+			}
+		}
+
+		return CheckError(tx.Delete(&model.Workspace{}, workspace.ID))
+	})
+}
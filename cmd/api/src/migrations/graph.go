@@ -21,7 +21,6 @@ import (
 	"fmt"
 	"github.com/specterops/bloodhound/dawgs/graph"
 	"github.com/specterops/bloodhound/dawgs/query"
-	"github.com/specterops/bloodhound/graphschema"
 	"github.com/specterops/bloodhound/graphschema/common"
 	"github.com/specterops/bloodhound/log"
 	"github.com/specterops/bloodhound/src/version"
@@ -47,7 +46,7 @@ func (s *GraphMigrator) Migrate(ctx context.Context, schema graph.Schema) error
 	}
 
 	// Perform stepwise migrations
-	if err := s.executeStepwiseMigrations(); err != nil {
+	if err := s.executeStepwiseMigrations(schema); err != nil {
 		return err
 	}
 
@@ -146,8 +145,8 @@ func (s *GraphMigrator) executeMigrations(target version.Version) error {
 	return nil
 }
 
-func (s *GraphMigrator) executeStepwiseMigrations() error {
-	if err := s.db.AssertSchema(context.Background(), graphschema.DefaultGraphSchema()); err != nil {
+func (s *GraphMigrator) executeStepwiseMigrations(schema graph.Schema) error {
+	if err := s.db.AssertSchema(context.Background(), schema); err != nil {
 		return fmt.Errorf("error asserting current schema: %w", err)
 	}
 
@@ -40,6 +40,7 @@ type ADDataQualityStat struct {
 	SessionCompleteness    nan.Float64 `json:"session_completeness"`
 	LocalGroupCompleteness nan.Float64 `json:"local_group_completeness"`
 	RunID                  string      `json:"run_id" gorm:"index"`
+	WorkspaceID            int32       `json:"workspace_id"`
 
 	Serial
 }
@@ -64,6 +65,7 @@ type ADDataQualityAggregation struct {
 	SessionCompleteness    float32 `json:"session_completeness"`
 	LocalGroupCompleteness float32 `json:"local_group_completeness"`
 	RunID                  string  `json:"run_id" gorm:"index"`
+	WorkspaceID            int32   `json:"workspace_id"`
 
 	Serial
 }
@@ -66,6 +66,7 @@ type AssetGroup struct {
 	Selectors   AssetGroupSelectors   `gorm:"constraint:OnDelete:CASCADE;"`
 	Collections AssetGroupCollections `json:"-" gorm:"constraint:OnDelete:CASCADE;"`
 	MemberCount int                   `json:"member_count" gorm:"-"`
+	WorkspaceID int32                 `json:"workspace_id"`
 
 	Serial
 }
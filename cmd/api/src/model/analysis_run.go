@@ -0,0 +1,189 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type AnalysisRunTrigger string
+
+const (
+	AnalysisRunTriggerFileUpload AnalysisRunTrigger = "file_upload"
+	AnalysisRunTriggerManual     AnalysisRunTrigger = "manual"
+)
+
+type AnalysisRunStatus string
+
+const (
+	AnalysisRunStatusComplete          AnalysisRunStatus = "complete"
+	AnalysisRunStatusPartiallyComplete AnalysisRunStatus = "partially_complete"
+	AnalysisRunStatusFailed            AnalysisRunStatus = "failed"
+)
+
+// AnalysisRunStep records the timing of a single step of an analysis run
+type AnalysisRunStep struct {
+	Name       string    `json:"name"`
+	StartedAt  time.Time `json:"started_at"`
+	DurationMS int64     `json:"duration_ms"`
+	Failed     bool      `json:"failed"`
+	Skipped    bool      `json:"skipped,omitempty"`
+}
+
+type AnalysisRunSteps []AnalysisRunStep
+
+func (s *AnalysisRunSteps) Scan(value any) error {
+	return scanJSONB(value, s)
+}
+
+func (s AnalysisRunSteps) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
+
+// RelationshipKindCounts maps relationship kind names to the number of relationships affected
+type RelationshipKindCounts map[string]int
+
+func (s *RelationshipKindCounts) Scan(value any) error {
+	return scanJSONB(value, s)
+}
+
+func (s RelationshipKindCounts) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
+
+func scanJSONB(value any, target any) error {
+	if bytes, typeOK := value.([]byte); !typeOK {
+		return fmt.Errorf("expected JSONB type of []byte but received %T", value)
+	} else {
+		return json.Unmarshal(bytes, target)
+	}
+}
+
+// AnalysisRun is the persisted record of a single run of the analysis pipeline
+type AnalysisRun struct {
+	Trigger              AnalysisRunTrigger     `json:"trigger"`
+	FileUploadJobIDs     pq.Int64Array          `json:"file_upload_job_ids" gorm:"type:bigint[]"`
+	Status               AnalysisRunStatus      `json:"status"`
+	StartedAt            time.Time              `json:"started_at"`
+	CompletedAt          time.Time              `json:"completed_at"`
+	Steps                AnalysisRunSteps       `json:"steps" gorm:"type:jsonb"`
+	RelationshipsCreated RelationshipKindCounts `json:"relationships_created" gorm:"type:jsonb"`
+	RelationshipsDeleted RelationshipKindCounts `json:"relationships_deleted" gorm:"type:jsonb"`
+	Errors               pq.StringArray         `json:"errors" gorm:"type:text[]"`
+	WorkspaceID          int32                  `json:"workspace_id"`
+
+	BigSerial
+}
+
+func NewAnalysisRun(trigger AnalysisRunTrigger, fileUploadJobIDs []int64) *AnalysisRun {
+	return &AnalysisRun{
+		Trigger:              trigger,
+		FileUploadJobIDs:     append(pq.Int64Array{}, fileUploadJobIDs...),
+		StartedAt:            time.Now().UTC(),
+		Steps:                AnalysisRunSteps{},
+		RelationshipsCreated: RelationshipKindCounts{},
+		RelationshipsDeleted: RelationshipKindCounts{},
+		Errors:               pq.StringArray{},
+	}
+}
+
+// MeasureStep starts timing a step of the run. The returned function must be called with the result of the step once
+// it has finished.
+func (s *AnalysisRun) MeasureStep(name string) func(err error) {
+	started := time.Now().UTC()
+
+	return func(err error) {
+		s.AddStep(AnalysisRunStep{
+			Name:       name,
+			StartedAt:  started,
+			DurationMS: time.Since(started).Milliseconds(),
+			Failed:     err != nil,
+		})
+	}
+}
+
+func (s *AnalysisRun) AddStep(step AnalysisRunStep) {
+	s.Steps = append(s.Steps, step)
+}
+
+func (s *AnalysisRun) AddError(err error) {
+	s.Errors = append(s.Errors, err.Error())
+}
+
+// Complete marks the run as finished with the given status
+func (s *AnalysisRun) Complete(status AnalysisRunStatus) {
+	s.Status = status
+	s.CompletedAt = time.Now().UTC()
+}
+
+type AnalysisRuns []AnalysisRun
+
+func (s AnalysisRuns) IsSortable(column string) bool {
+	switch column {
+	case "trigger",
+		"status",
+		"started_at",
+		"completed_at",
+		"id":
+		return true
+	default:
+		return false
+	}
+}
+
+func (s AnalysisRuns) ValidFilters() map[string][]FilterOperator {
+	return map[string][]FilterOperator{
+		"trigger":      {Equals, NotEquals},
+		"status":       {Equals, NotEquals},
+		"started_at":   {Equals, GreaterThan, GreaterThanOrEquals, LessThan, LessThanOrEquals, NotEquals},
+		"completed_at": {Equals, GreaterThan, GreaterThanOrEquals, LessThan, LessThanOrEquals, NotEquals},
+	}
+}
+
+func (s AnalysisRuns) IsString(column string) bool {
+	switch column {
+	case "trigger", "status":
+		return true
+	default:
+		return false
+	}
+}
+
+func (s AnalysisRuns) GetFilterableColumns() []string {
+	var columns = make([]string, 0)
+	for column := range s.ValidFilters() {
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+func (s AnalysisRuns) GetValidFilterPredicatesAsStrings(column string) ([]string, error) {
+	if predicates, validColumn := s.ValidFilters()[column]; !validColumn {
+		return []string{}, fmt.Errorf(ErrorResponseDetailsColumnNotFilterable)
+	} else {
+		var stringPredicates = make([]string, 0)
+		for _, predicate := range predicates {
+			stringPredicates = append(stringPredicates, string(predicate))
+		}
+		return stringPredicates, nil
+	}
+}
@@ -18,6 +18,7 @@ package appcfg
 
 import (
 	"context"
+	"sync"
 
 	"github.com/specterops/bloodhound/src/model"
 )
@@ -32,11 +33,43 @@ const (
 	FeatureAdcs                       = "adcs"
 	FeatureClearGraphData             = "clear_graph_data"
 	FeatureRiskExposureNewCalculation = "risk_exposure_new_calculation"
+	FeatureTierZeroViolationSelectors = "tier_zero_violation_selectors"
 )
 
+var (
+	registeredFlags     = FeatureFlagSet{}
+	registeredFlagsLock = &sync.Mutex{}
+)
+
+// RegisterFeatureFlag makes an additional feature flag available alongside the flags returned by AvailableFlags. This
+// allows code outside of this package, such as externally registered analysis post-processors, to declare a toggle
+// without modifying the list of built-in flags. It is intended to be called from a package init function.
+func RegisterFeatureFlag(flag FeatureFlag) {
+	registeredFlagsLock.Lock()
+	defer registeredFlagsLock.Unlock()
+
+	registeredFlags[flag.Key] = flag
+}
+
 // AvailableFlags returns a FeatureFlagSet of expected feature flags. Feature flag defaults introduced here will become the initial
 // default value of the feature flag once it is inserted into the database.
 func AvailableFlags() FeatureFlagSet {
+	flags := builtinFlags()
+
+	registeredFlagsLock.Lock()
+	defer registeredFlagsLock.Unlock()
+
+	for key, flag := range registeredFlags {
+		// Built-in flags may not be redefined
+		if _, isBuiltin := flags[key]; !isBuiltin {
+			flags[key] = flag
+		}
+	}
+
+	return flags
+}
+
+func builtinFlags() FeatureFlagSet {
 	return FeatureFlagSet{
 		FeatureButterflyAnalysis: {
 			Key:           FeatureButterflyAnalysis,
@@ -101,6 +134,13 @@ func AvailableFlags() FeatureFlagSet {
 			Enabled:       false,
 			UserUpdatable: false,
 		},
+		FeatureTierZeroViolationSelectors: {
+			Key:           FeatureTierZeroViolationSelectors,
+			Name:          "Tier Zero Violation Selectors",
+			Description:   "Automatically adds principals with control over Tier Zero to the Admin Tier Zero asset group during analysis.",
+			Enabled:       false,
+			UserUpdatable: true,
+		},
 	}
 }
 
@@ -36,6 +36,10 @@ const (
 	Neo4jConfigsName                    = "Neo4j Configuration Parameters"
 	PasswordExpirationWindowDescription = "This configuration parameter sets the local auth password expiry window for users that have valid auth secrets. Values for this configuration must follow the duration specification of ISO-8601."
 	Neo4jConfigsDescription             = "This configuration parameter sets the BatchWriteSize and the BatchFlushSize for Neo4J."
+	SessionExposure                     = "analysis.session_exposure"
+	SessionExposureName                 = "Privileged Session Exposure Analysis"
+	SessionExposureDescription          = "This configuration parameter sets the tag of the asset group whose user sessions are tracked by privileged session exposure analysis."
+	DefaultSessionExposureAssetGroupTag = "admin_tier_0"
 )
 
 // Parameter is a runtime configuration parameter that can be fetched from the appcfg.ParameterService interface. The
@@ -95,6 +99,10 @@ func AvailableParameters() (ParameterSet, error) {
 		WriteFlushSize: neo4j.DefaultWriteFlushSize,
 	}); err != nil {
 		return ParameterSet{}, fmt.Errorf("error creating neo4jExpirationValue parameter: %w", err)
+	} else if sessionExposureValue, err := types.NewJSONBObject(SessionExposureParameters{
+		AssetGroupTag: DefaultSessionExposureAssetGroupTag,
+	}); err != nil {
+		return ParameterSet{}, fmt.Errorf("error creating SessionExposure parameter: %w", err)
 	} else {
 		return ParameterSet{
 			PasswordExpirationWindow: {
@@ -110,6 +118,12 @@ func AvailableParameters() (ParameterSet, error) {
 				Description: Neo4jConfigsDescription,
 				Value:       neo4jExpirationValue,
 			},
+			SessionExposure: {
+				Key:         SessionExposure,
+				Name:        SessionExposureName,
+				Description: SessionExposureDescription,
+				Value:       sessionExposureValue,
+			},
 		}, nil
 	}
 }
@@ -162,3 +176,25 @@ func GetNeo4jParameters(ctx context.Context, service ParameterService) Neo4jPara
 
 	return result
 }
+
+type SessionExposureParameters struct {
+	AssetGroupTag string `json:"asset_group_tag"`
+}
+
+func GetSessionExposureParameters(ctx context.Context, service ParameterService) SessionExposureParameters {
+	var result SessionExposureParameters
+
+	if sessionExposureCfg, err := service.GetConfigurationParameter(ctx, SessionExposure); err != nil {
+		log.Errorf("Failed to fetch session exposure configuration; returning default values")
+		result = SessionExposureParameters{
+			AssetGroupTag: DefaultSessionExposureAssetGroupTag,
+		}
+	} else if err = sessionExposureCfg.Map(&result); err != nil || result.AssetGroupTag == "" {
+		log.Errorf("Invalid session exposure configuration supplied; returning default values")
+		result = SessionExposureParameters{
+			AssetGroupTag: DefaultSessionExposureAssetGroupTag,
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,129 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// AttackPathFinding is a single principal impacted by a finding type within a domain or tenant. Findings are replaced
+// in full after every analysis run.
+type AttackPathFinding struct {
+	FindingType       string `json:"finding_type"`
+	EnvironmentID     string `json:"environment_id"`
+	PrincipalObjectID string `json:"principal_object_id"`
+	PrincipalName     string `json:"principal_name"`
+	PrincipalKind     string `json:"principal_kind"`
+	Severity          string `json:"severity"`
+	WorkspaceID       int32  `json:"workspace_id"`
+
+	BigSerial
+}
+
+type AttackPathFindings []AttackPathFinding
+
+func (s AttackPathFindings) IsSortable(column string) bool {
+	switch column {
+	case "principal_object_id",
+		"principal_name",
+		"principal_kind",
+		"id",
+		"created_at":
+		return true
+	default:
+		return false
+	}
+}
+
+func (s AttackPathFindings) ValidFilters() map[string][]FilterOperator {
+	return map[string][]FilterOperator{
+		"principal_object_id": {Equals, NotEquals},
+		"principal_name":      {Equals, NotEquals},
+		"principal_kind":      {Equals, NotEquals},
+	}
+}
+
+func (s AttackPathFindings) IsString(column string) bool {
+	switch column {
+	case "principal_object_id",
+		"principal_name",
+		"principal_kind":
+		return true
+	default:
+		return false
+	}
+}
+
+func (s AttackPathFindings) GetFilterableColumns() []string {
+	var columns = make([]string, 0)
+	for column := range s.ValidFilters() {
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+func (s AttackPathFindings) GetValidFilterPredicatesAsStrings(column string) ([]string, error) {
+	if predicates, validColumn := s.ValidFilters()[column]; !validColumn {
+		return []string{}, fmt.Errorf(ErrorResponseDetailsColumnNotFilterable)
+	} else {
+		var stringPredicates = make([]string, 0)
+		for _, predicate := range predicates {
+			stringPredicates = append(stringPredicates, string(predicate))
+		}
+		return stringPredicates, nil
+	}
+}
+
+// AttackPathRiskAcceptance marks a finding type as an accepted risk for a domain or tenant until the given time.
+// Acceptances are kept independently of findings so that they survive analysis runs.
+type AttackPathRiskAcceptance struct {
+	FindingType   string    `json:"finding_type"`
+	EnvironmentID string    `json:"environment_id"`
+	AcceptedUntil time.Time `json:"accepted_until"`
+	WorkspaceID   int32     `json:"workspace_id"`
+
+	BigSerial
+}
+
+func (s AttackPathRiskAcceptance) AuditData() AuditData {
+	return AuditData{
+		"finding_type":   s.FindingType,
+		"environment_id": s.EnvironmentID,
+		"accepted_until": s.AcceptedUntil,
+		"workspace_id":   s.WorkspaceID,
+	}
+}
+
+// IsAccepted returns true if the acceptance has not yet expired at the given time.
+func (s AttackPathRiskAcceptance) IsAccepted(now time.Time) bool {
+	return s.AcceptedUntil.After(now)
+}
+
+type AttackPathRiskAcceptances []AttackPathRiskAcceptance
+
+// AttackPathFindingSummary aggregates the findings of a single finding type within a domain or tenant.
+type AttackPathFindingSummary struct {
+	FindingType            string    `json:"finding_type"`
+	Title                  string    `json:"title"`
+	Severity               string    `json:"severity"`
+	ImpactedPrincipalCount int       `json:"impacted_principal_count"`
+	Accepted               bool      `json:"accepted"`
+	AcceptedUntil          time.Time `json:"accepted_until"`
+}
+
+type AttackPathFindingSummaries []AttackPathFindingSummary
@@ -69,6 +69,15 @@ const (
 	AuditLogActionExportListRisks         AuditLogAction = "ExportListRisks"
 
 	AuditLogActionDeleteBloodhoundData AuditLogAction = "DeleteBloodhoundData"
+
+	AuditLogActionCreateGraphPropertyIndex AuditLogAction = "CreateGraphPropertyIndex"
+	AuditLogActionDeleteGraphPropertyIndex AuditLogAction = "DeleteGraphPropertyIndex"
+
+	AuditLogActionMutateGraph AuditLogAction = "MutateGraph"
+
+	AuditLogActionCreateWorkspace AuditLogAction = "CreateWorkspace"
+	AuditLogActionUpdateWorkspace AuditLogAction = "UpdateWorkspace"
+	AuditLogActionDeleteWorkspace AuditLogAction = "DeleteWorkspace"
 )
 
 // TODO embed Basic into this struct instead of declaring the ID and CreatedAt fields. This will require a migration
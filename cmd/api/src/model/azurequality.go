@@ -40,15 +40,17 @@ type AzureStatKinds struct {
 type AzureDataQualityStat struct {
 	Serial
 	AzureStatKinds
-	RunID    string `json:"run_id" gorm:"index"`
-	TenantID string `json:"tenantid" gorm:"column:tenant_id"`
+	RunID       string `json:"run_id" gorm:"index"`
+	TenantID    string `json:"tenantid" gorm:"column:tenant_id"`
+	WorkspaceID int32  `json:"workspace_id"`
 }
 
 type AzureDataQualityAggregation struct {
 	Serial
 	AzureStatKinds
-	RunID   string `json:"run_id" gorm:"index"`
-	Tenants int    `json:"tenants"`
+	RunID       string `json:"run_id" gorm:"index"`
+	Tenants     int    `json:"tenants"`
+	WorkspaceID int32  `json:"workspace_id"`
 }
 
 type AzureDataQualityStats []AzureDataQualityStat
@@ -0,0 +1,57 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+const (
+	CypherCompletionTypeNodeKind         = "node_kind"
+	CypherCompletionTypeRelationshipKind = "relationship_kind"
+	CypherCompletionTypeProperty         = "property"
+	CypherCompletionTypeFunction         = "function"
+	CypherCompletionTypeKeyword          = "keyword"
+)
+
+// CypherCompletionCandidate is a symbol that may be written at the position of a completion request
+type CypherCompletionCandidate struct {
+	Value string `json:"value"`
+	Type  string `json:"type"`
+}
+
+// CypherCompletion lists the candidates for the symbol at a position of a cypher query. Candidates replace the text of
+// the query from ReplaceFrom up to the requested position.
+type CypherCompletion struct {
+	Target      string                      `json:"target"`
+	Prefix      string                      `json:"prefix"`
+	ReplaceFrom int                         `json:"replace_from"`
+	Candidates  []CypherCompletionCandidate `json:"candidates"`
+}
+
+// CypherValidationIssue is a problem found in a cypher query. Line and column are only set for syntax errors, lines
+// start at 1 and columns start at 0.
+type CypherValidationIssue struct {
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+	Symbol  string `json:"symbol,omitempty"`
+	Message string `json:"message"`
+}
+
+// CypherValidation lists the problems found in a cypher query. Queries with errors can not be run while warnings
+// describe constructs that are likely mistakes.
+type CypherValidation struct {
+	Valid    bool                    `json:"valid"`
+	Errors   []CypherValidationIssue `json:"errors"`
+	Warnings []CypherValidationIssue `json:"warnings"`
+}
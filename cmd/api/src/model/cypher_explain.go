@@ -0,0 +1,55 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+// CypherComplexityContribution is the weight that a single construct of a cypher query adds to its complexity
+type CypherComplexityContribution struct {
+	Construct string  `json:"construct"`
+	Reason    string  `json:"reason"`
+	Weight    float64 `json:"weight"`
+}
+
+// CypherComplexity describes the complexity of a cypher query and the limit it is held to
+type CypherComplexity struct {
+	Weight        float64                        `json:"weight"`
+	MaxWeight     float64                        `json:"max_weight"`
+	Enforced      bool                           `json:"enforced"`
+	TooComplex    bool                           `json:"too_complex"`
+	Contributions []CypherComplexityContribution `json:"contributions"`
+}
+
+// CypherQuerySummary describes the structure of a parsed cypher query
+type CypherQuerySummary struct {
+	Clauses                []string `json:"clauses"`
+	PatternParts           int      `json:"pattern_parts"`
+	NodePatterns           int      `json:"node_patterns"`
+	RelationshipPatterns   int      `json:"relationship_patterns"`
+	VariableLengthPatterns int      `json:"variable_length_patterns"`
+	Functions              []string `json:"functions"`
+	Kinds                  []string `json:"kinds"`
+}
+
+// CypherExplanation describes how a cypher query would be run without running it. The statement is the translated
+// SQL for PostgreSQL graph databases and the normalized cypher query for Neo4j.
+type CypherExplanation struct {
+	Query          string             `json:"query"`
+	Statement      string             `json:"statement"`
+	Summary        CypherQuerySummary `json:"summary"`
+	Complexity     CypherComplexity   `json:"complexity"`
+	Plan           any                `json:"plan"`
+	TimeoutSeconds float64            `json:"timeout_seconds"`
+}
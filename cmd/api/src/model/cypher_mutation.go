@@ -0,0 +1,49 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+// CypherMutationSummary counts the changes a cypher mutation made to the graph, or would have made for dry runs
+type CypherMutationSummary struct {
+	NodesCreated         int `json:"nodes_created"`
+	NodesDeleted         int `json:"nodes_deleted"`
+	RelationshipsCreated int `json:"relationships_created"`
+	RelationshipsDeleted int `json:"relationships_deleted"`
+	PropertiesSet        int `json:"properties_set"`
+	LabelsAdded          int `json:"labels_added"`
+	LabelsRemoved        int `json:"labels_removed"`
+}
+
+// CypherMutation is the result of running a cypher query with updating clauses. Dry runs are rolled back after the
+// changes are counted.
+type CypherMutation struct {
+	Query   string                `json:"query"`
+	DryRun  bool                  `json:"dry_run"`
+	Summary CypherMutationSummary `json:"summary"`
+}
+
+func (s CypherMutation) AuditData() AuditData {
+	return AuditData{
+		"query":                 s.Query,
+		"nodes_created":         s.Summary.NodesCreated,
+		"nodes_deleted":         s.Summary.NodesDeleted,
+		"relationships_created": s.Summary.RelationshipsCreated,
+		"relationships_deleted": s.Summary.RelationshipsDeleted,
+		"properties_set":        s.Summary.PropertiesSet,
+		"labels_added":          s.Summary.LabelsAdded,
+		"labels_removed":        s.Summary.LabelsRemoved,
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+)
+
+type CypherParameterType string
+
+const (
+	CypherParameterTypeString      CypherParameterType = "string"
+	CypherParameterTypeInteger     CypherParameterType = "integer"
+	CypherParameterTypeFloat       CypherParameterType = "float"
+	CypherParameterTypeBoolean     CypherParameterType = "boolean"
+	CypherParameterTypeStringList  CypherParameterType = "string_list"
+	CypherParameterTypeIntegerList CypherParameterType = "integer_list"
+	CypherParameterTypeFloatList   CypherParameterType = "float_list"
+)
+
+var cypherParameterNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// IsValidCypherParameterName returns true if the given name can be referenced as a parameter in a cypher query
+func IsValidCypherParameterName(name string) bool {
+	return cypherParameterNameRegex.MatchString(name)
+}
+
+func (s CypherParameterType) IsValid() bool {
+	switch s {
+	case CypherParameterTypeString,
+		CypherParameterTypeInteger,
+		CypherParameterTypeFloat,
+		CypherParameterTypeBoolean,
+		CypherParameterTypeStringList,
+		CypherParameterTypeIntegerList,
+		CypherParameterTypeFloatList:
+		return true
+	default:
+		return false
+	}
+}
+
+// Convert normalizes the given value and checks that it may be bound as a parameter of this type. Integers are
+// accepted in place of floats.
+func (s CypherParameterType) Convert(value any) (any, error) {
+	normalized, valueType, err := NormalizeCypherParameterValue(value)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case valueType == s:
+		return normalized, nil
+
+	case s == CypherParameterTypeFloat && valueType == CypherParameterTypeInteger:
+		return float64(normalized.(int64)), nil
+
+	case s == CypherParameterTypeFloatList && valueType == CypherParameterTypeIntegerList:
+		var (
+			integers = normalized.([]int64)
+			floats   = make([]float64, len(integers))
+		)
+
+		for idx, integer := range integers {
+			floats[idx] = float64(integer)
+		}
+
+		return floats, nil
+
+	case valueType == CypherParameterTypeStringList && len(normalized.([]string)) == 0:
+		// Empty lists carry no element type and are accepted for any list type
+		switch s {
+		case CypherParameterTypeIntegerList:
+			return []int64{}, nil
+
+		case CypherParameterTypeFloatList:
+			return []float64{}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("expected a value of type %s but got %s", s, valueType)
+}
+
+// TypedCypherParameterValue is a parameter value with a declared type. Typed values are converted to their declared
+// type when normalized instead of taking the type of their value, which keeps whole numbers declared as floats from
+// being bound as integers.
+type TypedCypherParameterValue struct {
+	Type  CypherParameterType
+	Value any
+}
+
+// NormalizeCypherParameterValue converts a decoded JSON value into a value that can be bound as a cypher query
+// parameter and returns its type. Whole numbers are converted to integers and lists must contain elements of a single
+// type. Null values, maps and nested lists are not supported.
+func NormalizeCypherParameterValue(value any) (any, CypherParameterType, error) {
+	switch typedValue := value.(type) {
+	case TypedCypherParameterValue:
+		converted, err := typedValue.Type.Convert(typedValue.Value)
+		return converted, typedValue.Type, err
+
+	case string:
+		return typedValue, CypherParameterTypeString, nil
+
+	case bool:
+		return typedValue, CypherParameterTypeBoolean, nil
+
+	case int:
+		return int64(typedValue), CypherParameterTypeInteger, nil
+
+	case int64:
+		return typedValue, CypherParameterTypeInteger, nil
+
+	case float64:
+		if isWholeNumber(typedValue) {
+			return int64(typedValue), CypherParameterTypeInteger, nil
+		}
+
+		return typedValue, CypherParameterTypeFloat, nil
+
+	case []string:
+		return typedValue, CypherParameterTypeStringList, nil
+
+	case []int64:
+		return typedValue, CypherParameterTypeIntegerList, nil
+
+	case []float64:
+		return typedValue, CypherParameterTypeFloatList, nil
+
+	case []any:
+		return normalizeCypherParameterList(typedValue)
+
+	case nil:
+		return nil, "", fmt.Errorf("null values are not supported")
+
+	default:
+		return nil, "", fmt.Errorf("values of type %T are not supported", value)
+	}
+}
+
+// NormalizeCypherParameters validates the names of the given parameters and normalizes their values
+func NormalizeCypherParameters(parameters map[string]any) (map[string]any, error) {
+	normalized := make(map[string]any, len(parameters))
+
+	for name, value := range parameters {
+		if !IsValidCypherParameterName(name) {
+			return nil, fmt.Errorf("invalid parameter name %q", name)
+		} else if normalizedValue, _, err := NormalizeCypherParameterValue(value); err != nil {
+			return nil, fmt.Errorf("invalid value for parameter %s: %w", name, err)
+		} else {
+			normalized[name] = normalizedValue
+		}
+	}
+
+	return normalized, nil
+}
+
+func isWholeNumber(value float64) bool {
+	return value == math.Trunc(value) && value >= math.MinInt64 && value < math.MaxInt64
+}
+
+func normalizeCypherParameterList(values []any) (any, CypherParameterType, error) {
+	var (
+		stringValues = make([]string, 0, len(values))
+		floatValues  = make([]float64, 0, len(values))
+		wholeNumbers = true
+	)
+
+	for _, value := range values {
+		switch typedValue := value.(type) {
+		case string:
+			stringValues = append(stringValues, typedValue)
+
+		case float64:
+			floatValues = append(floatValues, typedValue)
+			wholeNumbers = wholeNumbers && isWholeNumber(typedValue)
+
+		default:
+			return nil, "", fmt.Errorf("lists may only contain strings or numbers")
+		}
+	}
+
+	if len(stringValues) > 0 && len(floatValues) > 0 {
+		return nil, "", fmt.Errorf("lists must contain elements of a single type")
+	} else if len(floatValues) == 0 {
+		return stringValues, CypherParameterTypeStringList, nil
+	} else if !wholeNumbers {
+		return floatValues, CypherParameterTypeFloatList, nil
+	}
+
+	integers := make([]int64, len(floatValues))
+
+	for idx, value := range floatValues {
+		integers[idx] = int64(value)
+	}
+
+	return integers, CypherParameterTypeIntegerList, nil
+}
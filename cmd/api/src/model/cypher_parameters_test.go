@@ -0,0 +1,108 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model_test
+
+import (
+	"testing"
+
+	"github.com/specterops/bloodhound/src/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeCypherParameterValue(t *testing.T) {
+	for _, testCase := range []struct {
+		Value        any
+		Expected     any
+		ExpectedType model.CypherParameterType
+	}{
+		{Value: "lol", Expected: "lol", ExpectedType: model.CypherParameterTypeString},
+		{Value: true, Expected: true, ExpectedType: model.CypherParameterTypeBoolean},
+		{Value: float64(42), Expected: int64(42), ExpectedType: model.CypherParameterTypeInteger},
+		{Value: 4.2, Expected: 4.2, ExpectedType: model.CypherParameterTypeFloat},
+		{Value: []any{"a", "b"}, Expected: []string{"a", "b"}, ExpectedType: model.CypherParameterTypeStringList},
+		{Value: []any{float64(1), float64(2)}, Expected: []int64{1, 2}, ExpectedType: model.CypherParameterTypeIntegerList},
+		{Value: []any{float64(1), 2.5}, Expected: []float64{1, 2.5}, ExpectedType: model.CypherParameterTypeFloatList},
+		{Value: []any{}, Expected: []string{}, ExpectedType: model.CypherParameterTypeStringList},
+	} {
+		normalized, valueType, err := model.NormalizeCypherParameterValue(testCase.Value)
+
+		require.Nil(t, err)
+		require.Equal(t, testCase.Expected, normalized)
+		require.Equal(t, testCase.ExpectedType, valueType)
+	}
+
+	for _, value := range []any{nil, map[string]any{"a": "b"}, []any{"a", float64(1)}, []any{true}, []any{[]any{"a"}}} {
+		_, _, err := model.NormalizeCypherParameterValue(value)
+		require.NotNil(t, err)
+	}
+}
+
+func TestNormalizeCypherParameterValue_Typed(t *testing.T) {
+	// Whole numbers declared as floats are not bound as integers
+	normalized, valueType, err := model.NormalizeCypherParameterValue(model.TypedCypherParameterValue{
+		Type:  model.CypherParameterTypeFloat,
+		Value: float64(2),
+	})
+	require.Nil(t, err)
+	require.Equal(t, float64(2), normalized)
+	require.Equal(t, model.CypherParameterTypeFloat, valueType)
+
+	_, _, err = model.NormalizeCypherParameterValue(model.TypedCypherParameterValue{
+		Type:  model.CypherParameterTypeInteger,
+		Value: "2",
+	})
+	require.ErrorContains(t, err, "expected a value of type integer but got string")
+}
+
+func TestNormalizeCypherParameters(t *testing.T) {
+	normalized, err := model.NormalizeCypherParameters(map[string]any{
+		"name": "lol",
+		"ids":  []any{"1", "2"},
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, map[string]any{
+		"name": "lol",
+		"ids":  []string{"1", "2"},
+	}, normalized)
+
+	_, err = model.NormalizeCypherParameters(map[string]any{"not a name": "lol"})
+	require.ErrorContains(t, err, "invalid parameter name")
+
+	_, err = model.NormalizeCypherParameters(map[string]any{"name": nil})
+	require.ErrorContains(t, err, "invalid value for parameter name")
+}
+
+func TestCypherParameterType_Convert(t *testing.T) {
+	converted, err := model.CypherParameterTypeFloat.Convert(float64(2))
+	require.Nil(t, err)
+	require.Equal(t, float64(2), converted)
+
+	converted, err = model.CypherParameterTypeFloatList.Convert([]any{float64(1), float64(2)})
+	require.Nil(t, err)
+	require.Equal(t, []float64{1, 2}, converted)
+
+	converted, err = model.CypherParameterTypeIntegerList.Convert([]any{})
+	require.Nil(t, err)
+	require.Equal(t, []int64{}, converted)
+
+	_, err = model.CypherParameterTypeInteger.Convert(4.2)
+	require.ErrorContains(t, err, "expected a value of type integer but got float")
+
+	_, err = model.CypherParameterTypeString.Convert([]any{})
+	require.NotNil(t, err)
+}
@@ -0,0 +1,50 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+type CypherStreamRecordType string
+
+const (
+	// CypherStreamRecordColumns is the first record of a stream and names the projections of the query
+	CypherStreamRecordColumns CypherStreamRecordType = "columns"
+	CypherStreamRecordNode    CypherStreamRecordType = "node"
+	CypherStreamRecordEdge    CypherStreamRecordType = "edge"
+	CypherStreamRecordRow     CypherStreamRecordType = "row"
+
+	// CypherStreamRecordEnd is the last record of a stream that completed successfully
+	CypherStreamRecordEnd CypherStreamRecordType = "end"
+
+	// CypherStreamRecordError is the last record of a stream that failed after its first record was written
+	CypherStreamRecordError CypherStreamRecordType = "error"
+)
+
+// CypherStreamSummary describes a stream that completed successfully
+type CypherStreamSummary struct {
+	Rows int `json:"rows"`
+}
+
+// CypherStreamRecord is a single line of a cypher query result streamed as newline delimited JSON. Only the field
+// that matches the type of the record is set.
+type CypherStreamRecord struct {
+	Type    CypherStreamRecordType   `json:"type"`
+	Columns []string                 `json:"columns,omitempty"`
+	Node    *CypherTableNode         `json:"node,omitempty"`
+	Edge    *CypherTableRelationship `json:"edge,omitempty"`
+	Row     []any                    `json:"row,omitempty"`
+	Summary *CypherStreamSummary     `json:"summary,omitempty"`
+	Error   string                   `json:"error,omitempty"`
+}
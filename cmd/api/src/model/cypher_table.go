@@ -0,0 +1,103 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"github.com/specterops/bloodhound/dawgs/graph"
+)
+
+type CypherColumnType string
+
+const (
+	CypherColumnTypeNode         CypherColumnType = "node"
+	CypherColumnTypeRelationship CypherColumnType = "relationship"
+	CypherColumnTypePath         CypherColumnType = "path"
+	CypherColumnTypeString       CypherColumnType = "string"
+	CypherColumnTypeNumber       CypherColumnType = "number"
+	CypherColumnTypeBoolean      CypherColumnType = "boolean"
+	CypherColumnTypeDateTime     CypherColumnType = "datetime"
+	CypherColumnTypeList         CypherColumnType = "list"
+	CypherColumnTypeMap          CypherColumnType = "map"
+
+	// CypherColumnTypeAny is the type of columns that contain values of more than one type or only null values
+	CypherColumnTypeAny CypherColumnType = "any"
+)
+
+// CypherColumn describes a single projection of a cypher query
+type CypherColumn struct {
+	Name string           `json:"name"`
+	Type CypherColumnType `json:"type"`
+}
+
+// CypherTable represents the projections of a cypher query as rows of values. Each row contains one value per column.
+type CypherTable struct {
+	Columns []CypherColumn `json:"columns"`
+	Rows    [][]any        `json:"rows"`
+
+	// CountCapped is set when the query produced more rows than were counted. The reported count is then a lower
+	// bound of the number of rows.
+	CountCapped bool `json:"count_capped"`
+}
+
+// CypherTableNode is the tabular representation of a node
+type CypherTableNode struct {
+	ID string `json:"id"`
+	UnifiedNode
+}
+
+// CypherTableRelationship is the tabular representation of a relationship
+type CypherTableRelationship struct {
+	ID string `json:"id"`
+	UnifiedEdge
+}
+
+// CypherTablePath is the tabular representation of a path
+type CypherTablePath struct {
+	Nodes         []CypherTableNode         `json:"nodes"`
+	Relationships []CypherTableRelationship `json:"relationships"`
+}
+
+func NewCypherTableNode(node *graph.Node, includeProperties bool) CypherTableNode {
+	return CypherTableNode{
+		ID:          node.ID.String(),
+		UnifiedNode: FromDAWGSNode(node, includeProperties),
+	}
+}
+
+func NewCypherTableRelationship(relationship *graph.Relationship, includeProperties bool) CypherTableRelationship {
+	return CypherTableRelationship{
+		ID:          relationship.ID.String(),
+		UnifiedEdge: FromDAWGSRelationship(includeProperties)(relationship),
+	}
+}
+
+func NewCypherTablePath(path graph.Path, includeProperties bool) CypherTablePath {
+	tablePath := CypherTablePath{
+		Nodes:         make([]CypherTableNode, len(path.Nodes)),
+		Relationships: make([]CypherTableRelationship, len(path.Edges)),
+	}
+
+	for idx, node := range path.Nodes {
+		tablePath.Nodes[idx] = NewCypherTableNode(node, includeProperties)
+	}
+
+	for idx, relationship := range path.Edges {
+		tablePath.Relationships[idx] = NewCypherTableRelationship(relationship, includeProperties)
+	}
+
+	return tablePath
+}
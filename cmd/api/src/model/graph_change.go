@@ -0,0 +1,48 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"github.com/specterops/bloodhound/dawgs/changefeed"
+)
+
+// GraphChanges is the set of graph mutations recorded by a single change feed event
+type GraphChanges []changefeed.Change
+
+func (s *GraphChanges) Scan(value any) error {
+	return scanJSONB(value, s)
+}
+
+func (s GraphChanges) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
+
+// GraphChangeEvent is a change feed event persisted to the graph change outbox. Events become visible in ID order so
+// that consumers can resume reading the outbox from the last event they processed without skipping events.
+type GraphChangeEvent struct {
+	Changes     GraphChanges `json:"changes" gorm:"type:jsonb"`
+	CommittedAt time.Time    `json:"committed_at"`
+	WorkspaceID int32        `json:"workspace_id"`
+
+	BigSerial
+}
+
+type GraphChangeEvents []GraphChangeEvent
@@ -0,0 +1,70 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"github.com/specterops/bloodhound/dawgs/graph"
+)
+
+type GraphPropertyIndexStatus string
+
+const (
+	GraphPropertyIndexStatusBuilding GraphPropertyIndexStatus = "building"
+	GraphPropertyIndexStatusOnline   GraphPropertyIndexStatus = "online"
+	GraphPropertyIndexStatusFailed   GraphPropertyIndexStatus = "failed"
+)
+
+// GraphPropertyIndex is a property index on nodes of a single kind that was created at runtime rather than being part
+// of the default graph schema. Index types use the names rendered by graph.IndexType.
+type GraphPropertyIndex struct {
+	Kind      string                   `json:"kind"`
+	Property  string                   `json:"property"`
+	IndexType string                   `json:"index_type"`
+	Status    GraphPropertyIndexStatus `json:"status"`
+	Error     string                   `json:"error"`
+
+	BigSerial
+}
+
+func (s GraphPropertyIndex) AuditData() AuditData {
+	return AuditData{
+		"id":         s.ID,
+		"kind":       s.Kind,
+		"property":   s.Property,
+		"index_type": s.IndexType,
+	}
+}
+
+// Index returns the graph schema definition of this index.
+func (s GraphPropertyIndex) Index() graph.Index {
+	var indexType = graph.UnsupportedIndex
+
+	switch s.IndexType {
+	case graph.BTreeIndex.String():
+		indexType = graph.BTreeIndex
+	case graph.TextSearchIndex.String():
+		indexType = graph.TextSearchIndex
+	}
+
+	return graph.Index{
+		Field: s.Property,
+		Type:  indexType,
+		Kind:  graph.StringKind(s.Kind),
+	}
+}
+
+type GraphPropertyIndexes []GraphPropertyIndex
@@ -0,0 +1,29 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+// GraphSnapshot is the compressed summary of the graph as it stood at the end of an analysis run. The content is
+// produced by the analysis snapshot package and is only ever read back in full, so it is excluded from JSON output.
+type GraphSnapshot struct {
+	AnalysisRunID int64  `json:"analysis_run_id"`
+	NodeCount     int    `json:"node_count"`
+	EdgeCount     int    `json:"edge_count"`
+	Content       []byte `json:"-"`
+	WorkspaceID   int32  `json:"workspace_id"`
+
+	BigSerial
+}
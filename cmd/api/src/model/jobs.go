@@ -36,6 +36,7 @@ type FileUploadJob struct {
 	LastIngest       time.Time   `json:"last_ingest"`
 	TotalFiles       int         `json:"total_files"`
 	FailedFiles      int         `json:"failed_files"`
+	WorkspaceID      int32       `json:"workspace_id"`
 	//DomainResults []DomainCollectionResult `json:"domain_results" gorm:"-"`
 
 	BigSerial
@@ -16,12 +16,121 @@
 
 package model
 
-import "fmt"
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// SavedQueryParameter declares a parameter that a saved query references. The default value, if any, is used when
+// the query is run without a value for the parameter.
+type SavedQueryParameter struct {
+	Name    string              `json:"name"`
+	Type    CypherParameterType `json:"type"`
+	Default any                 `json:"default,omitempty"`
+}
+
+type SavedQueryParameters []SavedQueryParameter
+
+func (s *SavedQueryParameters) Scan(value any) error {
+	return scanJSONB(value, s)
+}
+
+func (s SavedQueryParameters) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
+
+// Validate checks that each declared parameter has a unique and valid name, a known type and a default value that
+// matches its type
+func (s SavedQueryParameters) Validate() error {
+	seen := make(map[string]struct{}, len(s))
+
+	for _, parameter := range s {
+		if !IsValidCypherParameterName(parameter.Name) {
+			return fmt.Errorf("invalid parameter name %q", parameter.Name)
+		} else if _, isDuplicate := seen[parameter.Name]; isDuplicate {
+			return fmt.Errorf("parameter %s is declared more than once", parameter.Name)
+		} else if !parameter.Type.IsValid() {
+			return fmt.Errorf("parameter %s has an invalid type %q", parameter.Name, parameter.Type)
+		} else if parameter.Default != nil {
+			if _, err := parameter.Type.Convert(parameter.Default); err != nil {
+				return fmt.Errorf("invalid default value for parameter %s: %w", parameter.Name, err)
+			}
+		}
+
+		seen[parameter.Name] = struct{}{}
+	}
+
+	return nil
+}
+
+// CheckReferences checks that the declared parameters are exactly the parameters referenced by the saved query
+func (s SavedQueryParameters) CheckReferences(referencedNames []string) error {
+	declared := make(map[string]struct{}, len(s))
+
+	for _, parameter := range s {
+		declared[parameter.Name] = struct{}{}
+	}
+
+	for _, name := range referencedNames {
+		if _, isDeclared := declared[name]; !isDeclared {
+			return fmt.Errorf("query references parameter $%s which is not declared", name)
+		}
+
+		delete(declared, name)
+	}
+
+	for _, parameter := range s {
+		if _, isUnreferenced := declared[parameter.Name]; isUnreferenced {
+			return fmt.Errorf("parameter %s is declared but not referenced by the query", parameter.Name)
+		}
+	}
+
+	return nil
+}
+
+// Bind returns the parameter values to run the saved query with. Parameters without a supplied value take their
+// default value and each value is converted to the type declared for it. Values for undeclared parameters and
+// declared parameters that have neither a value nor a default are rejected.
+func (s SavedQueryParameters) Bind(values map[string]any) (map[string]any, error) {
+	bound := make(map[string]any, len(s))
+
+	for _, parameter := range s {
+		value, hasValue := values[parameter.Name]
+
+		if !hasValue {
+			if parameter.Default == nil {
+				return nil, fmt.Errorf("no value supplied for parameter %s", parameter.Name)
+			}
+
+			value = parameter.Default
+		}
+
+		if converted, err := parameter.Type.Convert(value); err != nil {
+			return nil, fmt.Errorf("invalid value for parameter %s: %w", parameter.Name, err)
+		} else {
+			bound[parameter.Name] = TypedCypherParameterValue{
+				Type:  parameter.Type,
+				Value: converted,
+			}
+		}
+	}
+
+	for name := range values {
+		if _, isDeclared := bound[name]; !isDeclared {
+			return nil, fmt.Errorf("parameter %s is not declared by the saved query", name)
+		}
+	}
+
+	return bound, nil
+}
 
 type SavedQuery struct {
-	UserID string `json:"user_id" gorm:"index:,unique,composite:compositeIndex"`
-	Name   string `json:"name" gorm:"index:,unique,composite:compositeIndex"`
-	Query  string `json:"query"`
+	UserID      string               `json:"user_id" gorm:"index:,unique,composite:compositeIndex"`
+	WorkspaceID int32                `json:"workspace_id" gorm:"index:,unique,composite:compositeIndex"`
+	Name        string               `json:"name" gorm:"index:,unique,composite:compositeIndex"`
+	Query       string               `json:"query"`
+	Parameters  SavedQueryParameters `json:"parameters" gorm:"type:jsonb"`
 
 	BigSerial
 }
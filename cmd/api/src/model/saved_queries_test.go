@@ -61,3 +61,78 @@ func TestSavedQueries_IsString(t *testing.T) {
 		require.True(t, savedQueries.IsString(column))
 	}
 }
+
+func TestSavedQueryParameters_Validate(t *testing.T) {
+	require.Nil(t, model.SavedQueryParameters{
+		{Name: "name", Type: model.CypherParameterTypeString, Default: "lol"},
+		{Name: "ids", Type: model.CypherParameterTypeStringList},
+		{Name: "weight", Type: model.CypherParameterTypeFloat, Default: float64(1)},
+	}.Validate())
+
+	require.ErrorContains(t, model.SavedQueryParameters{
+		{Name: "$name", Type: model.CypherParameterTypeString},
+	}.Validate(), "invalid parameter name")
+
+	require.ErrorContains(t, model.SavedQueryParameters{
+		{Name: "name", Type: model.CypherParameterTypeString},
+		{Name: "name", Type: model.CypherParameterTypeInteger},
+	}.Validate(), "declared more than once")
+
+	require.ErrorContains(t, model.SavedQueryParameters{
+		{Name: "name", Type: "map"},
+	}.Validate(), "invalid type")
+
+	require.ErrorContains(t, model.SavedQueryParameters{
+		{Name: "name", Type: model.CypherParameterTypeBoolean, Default: "true"},
+	}.Validate(), "invalid default value for parameter name")
+}
+
+func TestSavedQueryParameters_CheckReferences(t *testing.T) {
+	parameters := model.SavedQueryParameters{
+		{Name: "name", Type: model.CypherParameterTypeString},
+		{Name: "ids", Type: model.CypherParameterTypeStringList},
+	}
+
+	require.Nil(t, parameters.CheckReferences([]string{"ids", "name"}))
+	require.Nil(t, model.SavedQueryParameters{}.CheckReferences(nil))
+
+	require.ErrorContains(t, parameters.CheckReferences([]string{"ids", "name", "weight"}), "query references parameter $weight which is not declared")
+	require.ErrorContains(t, parameters.CheckReferences([]string{"name"}), "parameter ids is declared but not referenced by the query")
+}
+
+func TestSavedQueryParameters_Bind(t *testing.T) {
+	parameters := model.SavedQueryParameters{
+		{Name: "name", Type: model.CypherParameterTypeString},
+		{Name: "weight", Type: model.CypherParameterTypeFloat, Default: float64(1)},
+		{Name: "ids", Type: model.CypherParameterTypeIntegerList, Default: []any{}},
+	}
+
+	// Supplied values are converted to their declared type and missing values take their default
+	bound, err := parameters.Bind(map[string]any{
+		"name": "lol",
+		"ids":  []any{float64(1), float64(2)},
+	})
+	require.Nil(t, err)
+	require.Equal(t, map[string]any{
+		"name":   model.TypedCypherParameterValue{Type: model.CypherParameterTypeString, Value: "lol"},
+		"weight": model.TypedCypherParameterValue{Type: model.CypherParameterTypeFloat, Value: float64(1)},
+		"ids":    model.TypedCypherParameterValue{Type: model.CypherParameterTypeIntegerList, Value: []int64{1, 2}},
+	}, bound)
+
+	normalized, err := model.NormalizeCypherParameters(bound)
+	require.Nil(t, err)
+	require.Equal(t, map[string]any{
+		"name":   "lol",
+		"weight": float64(1),
+		"ids":    []int64{1, 2},
+	}, normalized)
+
+	_, err = parameters.Bind(map[string]any{})
+	require.ErrorContains(t, err, "no value supplied for parameter name")
+
+	_, err = parameters.Bind(map[string]any{"name": true})
+	require.ErrorContains(t, err, "invalid value for parameter name: expected a value of type string but got boolean")
+
+	_, err = parameters.Bind(map[string]any{"name": "lol", "other": "value"})
+	require.ErrorContains(t, err, "parameter other is not declared by the saved query")
+}
@@ -0,0 +1,126 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// PrivilegedSessionExposure records a computer outside of Tier Zero that holds a session of a user in the asset group
+// selected for session exposure analysis.
+type PrivilegedSessionExposure struct {
+	DomainSID               string         `json:"domain_sid"`
+	ComputerObjectID        string         `json:"computer_object_id"`
+	ComputerName            string         `json:"computer_name"`
+	AssetGroupTag           string         `json:"asset_group_tag"`
+	ExposedUserCount        int            `json:"exposed_user_count"`
+	ExposedUsers            pq.StringArray `json:"exposed_users" gorm:"type:text[]"`
+	ReachablePrincipalCount int64          `json:"reachable_principal_count"`
+	WorkspaceID             int32          `json:"workspace_id"`
+
+	BigSerial
+}
+
+type PrivilegedSessionExposures []PrivilegedSessionExposure
+
+func (s PrivilegedSessionExposures) IsSortable(column string) bool {
+	switch column {
+	case "computer_object_id",
+		"computer_name",
+		"asset_group_tag",
+		"exposed_user_count",
+		"reachable_principal_count",
+		"id",
+		"created_at":
+		return true
+	default:
+		return false
+	}
+}
+
+func (s PrivilegedSessionExposures) ValidFilters() map[string][]FilterOperator {
+	return map[string][]FilterOperator{
+		"computer_object_id":        {Equals, NotEquals},
+		"computer_name":             {Equals, NotEquals},
+		"asset_group_tag":           {Equals, NotEquals},
+		"exposed_user_count":        {Equals, GreaterThan, GreaterThanOrEquals, LessThan, LessThanOrEquals, NotEquals},
+		"reachable_principal_count": {Equals, GreaterThan, GreaterThanOrEquals, LessThan, LessThanOrEquals, NotEquals},
+	}
+}
+
+func (s PrivilegedSessionExposures) IsString(column string) bool {
+	switch column {
+	case "computer_object_id",
+		"computer_name",
+		"asset_group_tag":
+		return true
+	default:
+		return false
+	}
+}
+
+func (s PrivilegedSessionExposures) GetFilterableColumns() []string {
+	var columns = make([]string, 0)
+	for column := range s.ValidFilters() {
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+func (s PrivilegedSessionExposures) GetValidFilterPredicatesAsStrings(column string) ([]string, error) {
+	if predicates, validColumn := s.ValidFilters()[column]; !validColumn {
+		return []string{}, fmt.Errorf(ErrorResponseDetailsColumnNotFilterable)
+	} else {
+		var stringPredicates = make([]string, 0)
+		for _, predicate := range predicates {
+			stringPredicates = append(stringPredicates, string(predicate))
+		}
+		return stringPredicates, nil
+	}
+}
+
+// WriteCSV writes the exposures as CSV with a header row. Exposed users are joined into a single space separated field.
+func (s PrivilegedSessionExposures) WriteCSV(writer io.Writer) error {
+	csvWriter := csv.NewWriter(writer)
+
+	if err := csvWriter.Write([]string{"domain_sid", "computer_object_id", "computer_name", "asset_group_tag", "exposed_user_count", "exposed_users", "reachable_principal_count"}); err != nil {
+		return err
+	}
+
+	for _, exposure := range s {
+		if err := csvWriter.Write([]string{
+			exposure.DomainSID,
+			exposure.ComputerObjectID,
+			exposure.ComputerName,
+			exposure.AssetGroupTag,
+			strconv.Itoa(exposure.ExposedUserCount),
+			strings.Join(exposure.ExposedUsers, " "),
+			strconv.FormatInt(exposure.ReachablePrincipalCount, 10),
+		}); err != nil {
+			return err
+		}
+	}
+
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
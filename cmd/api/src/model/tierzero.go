@@ -0,0 +1,107 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import "fmt"
+
+// TierZeroViolationRun records a single evaluation of the Tier Zero control closure. A run is recorded even when no
+// violations were found so that the latest run always reflects the current state of the graph.
+type TierZeroViolationRun struct {
+	ViolationCount int                `json:"violation_count"`
+	Violations     TierZeroViolations `json:"-" gorm:"foreignKey:RunID;constraint:OnDelete:CASCADE;"`
+	WorkspaceID    int32              `json:"workspace_id"`
+
+	BigSerial
+}
+
+type TierZeroViolation struct {
+	RunID            int64  `json:"run_id"`
+	ObjectID         string `json:"object_id"`
+	Name             string `json:"name"`
+	NodeLabel        string `json:"node_label"`
+	TierZeroObjectID string `json:"tier_zero_object_id"`
+	TierZeroName     string `json:"tier_zero_name"`
+	RelationshipKind string `json:"relationship_kind"`
+	Depth            int    `json:"depth"`
+
+	BigSerial
+}
+
+type TierZeroViolations []TierZeroViolation
+
+func (s TierZeroViolations) IsSortable(column string) bool {
+	switch column {
+	case "object_id",
+		"name",
+		"node_label",
+		"tier_zero_object_id",
+		"tier_zero_name",
+		"relationship_kind",
+		"depth",
+		"id",
+		"created_at":
+		return true
+	default:
+		return false
+	}
+}
+
+func (s TierZeroViolations) ValidFilters() map[string][]FilterOperator {
+	return map[string][]FilterOperator{
+		"object_id":           {Equals, NotEquals},
+		"name":                {Equals, NotEquals},
+		"node_label":          {Equals, NotEquals},
+		"tier_zero_object_id": {Equals, NotEquals},
+		"tier_zero_name":      {Equals, NotEquals},
+		"relationship_kind":   {Equals, NotEquals},
+		"depth":               {Equals, GreaterThan, GreaterThanOrEquals, LessThan, LessThanOrEquals, NotEquals},
+	}
+}
+
+func (s TierZeroViolations) IsString(column string) bool {
+	switch column {
+	case "object_id",
+		"name",
+		"node_label",
+		"tier_zero_object_id",
+		"tier_zero_name",
+		"relationship_kind":
+		return true
+	default:
+		return false
+	}
+}
+
+func (s TierZeroViolations) GetFilterableColumns() []string {
+	var columns = make([]string, 0)
+	for column := range s.ValidFilters() {
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+func (s TierZeroViolations) GetValidFilterPredicatesAsStrings(column string) ([]string, error) {
+	if predicates, validColumn := s.ValidFilters()[column]; !validColumn {
+		return []string{}, fmt.Errorf(ErrorResponseDetailsColumnNotFilterable)
+	} else {
+		var stringPredicates = make([]string, 0)
+		for _, predicate := range predicates {
+			stringPredicates = append(stringPredicates, string(predicate))
+		}
+		return stringPredicates, nil
+	}
+}
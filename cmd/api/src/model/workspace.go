@@ -0,0 +1,97 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"fmt"
+
+	"github.com/gofrs/uuid"
+)
+
+const (
+	// DefaultWorkspaceID is the ID of the implicit workspace that owns the default graph. It has no row in the
+	// workspaces table.
+	DefaultWorkspaceID int32 = 0
+
+	DefaultWorkspaceName      = "Default"
+	DefaultWorkspaceGraphName = "default"
+)
+
+// Workspace is a named graph hosted alongside the default graph. Only the listed users may select the workspace;
+// workspaces without users may only be selected by the user that created them.
+type Workspace struct {
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	GraphName   string        `json:"graph_name"`
+	CreatedBy   uuid.NullUUID `json:"created_by"`
+	UserIDs     []uuid.UUID   `json:"user_ids" gorm:"-"`
+
+	Serial
+}
+
+func DefaultWorkspace() Workspace {
+	return Workspace{
+		Name:        DefaultWorkspaceName,
+		Description: "The default graph of this instance",
+		GraphName:   DefaultWorkspaceGraphName,
+		UserIDs:     []uuid.UUID{},
+	}
+}
+
+// WorkspaceGraphName returns the name of the graph that backs the workspace with the given ID
+func WorkspaceGraphName(workspaceID int32) string {
+	return fmt.Sprintf("workspace_%d", workspaceID)
+}
+
+func (s Workspace) IsDefault() bool {
+	return s.ID == DefaultWorkspaceID
+}
+
+// AllowsUser returns true if the given user is a member of the workspace or, for workspaces without members, if the
+// given user created the workspace
+func (s Workspace) AllowsUser(userID uuid.UUID) bool {
+	if len(s.UserIDs) == 0 {
+		return s.CreatedBy.Valid && s.CreatedBy.UUID == userID
+	}
+
+	for _, memberID := range s.UserIDs {
+		if memberID == userID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s Workspace) AuditData() AuditData {
+	return AuditData{
+		"id":          s.ID,
+		"name":        s.Name,
+		"description": s.Description,
+		"graph_name":  s.GraphName,
+		"created_by":  s.CreatedBy,
+		"user_ids":    s.UserIDs,
+	}
+}
+
+type Workspaces []Workspace
+
+// WorkspaceUser is a membership of a user in a workspace
+type WorkspaceUser struct {
+	WorkspaceID int32     `json:"workspace_id" gorm:"primaryKey"`
+	UserID      uuid.UUID `json:"user_id" gorm:"primaryKey"`
+}
@@ -0,0 +1,266 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package queries
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/specterops/bloodhound/cypher/analyzer"
+	"github.com/specterops/bloodhound/cypher/backend/pgsql"
+	"github.com/specterops/bloodhound/cypher/frontend"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/errors"
+	"github.com/specterops/bloodhound/graphschema"
+	"github.com/specterops/bloodhound/graphschema/ad"
+	"github.com/specterops/bloodhound/graphschema/azure"
+	"github.com/specterops/bloodhound/graphschema/common"
+	"github.com/specterops/bloodhound/src/model"
+)
+
+// schemaPropertyKeys returns the property keys defined by the graph schema
+func schemaPropertyKeys() []string {
+	var propertyKeys []string
+
+	for _, property := range ad.AllProperties() {
+		propertyKeys = append(propertyKeys, property.String())
+	}
+
+	for _, property := range azure.AllProperties() {
+		propertyKeys = append(propertyKeys, property.String())
+	}
+
+	for _, property := range common.AllProperties() {
+		propertyKeys = append(propertyKeys, property.String())
+	}
+
+	return propertyKeys
+}
+
+// propertyKeys returns the property keys defined by the graph schema along with the property keys observed in the
+// graph if the graph database is able to list them
+func (s *GraphQuery) propertyKeys(ctx context.Context) ([]string, error) {
+	propertyKeys := schemaPropertyKeys()
+
+	if err := s.Graph.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		if observedKeys, err := graph.PropertyKeys(tx); err != nil {
+			// Drivers that can not list property keys still complete the property keys of the schema
+			if errors.Is(err, graph.ErrPropertyKeysUnsupported) {
+				return nil
+			}
+
+			return err
+		} else {
+			propertyKeys = append(propertyKeys, observedKeys...)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return propertyKeys, nil
+}
+
+// completionCandidates returns the sorted, distinct values that start with the given prefix, ignoring case, as
+// candidates of the given type
+func completionCandidates(candidateType, prefix string, values []string) []model.CypherCompletionCandidate {
+	var (
+		lowerPrefix = strings.ToLower(prefix)
+		seen        = map[string]struct{}{}
+		candidates  []model.CypherCompletionCandidate
+	)
+
+	for _, value := range values {
+		if _, isSeen := seen[value]; isSeen || !strings.HasPrefix(strings.ToLower(value), lowerPrefix) {
+			continue
+		}
+
+		seen[value] = struct{}{}
+		candidates = append(candidates, model.CypherCompletionCandidate{
+			Value: value,
+			Type:  candidateType,
+		})
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Value < candidates[j].Value
+	})
+
+	return candidates
+}
+
+// CompleteCypherQuery lists the node kinds, relationship kinds, property keys, functions or keywords that may be written
+// at the given character offset of the given cypher query.
+func (s *GraphQuery) CompleteCypherQuery(ctx context.Context, rawCypher string, offset int) (model.CypherCompletion, error) {
+	completionAt, err := frontend.CompletionAt(rawCypher, offset)
+
+	if err != nil {
+		return model.CypherCompletion{}, newQueryError(err)
+	}
+
+	var (
+		schema     = graphschema.DefaultGraph()
+		completion = model.CypherCompletion{
+			Target:      string(completionAt.Target),
+			Prefix:      completionAt.Prefix,
+			ReplaceFrom: completionAt.PrefixStart,
+			Candidates:  []model.CypherCompletionCandidate{},
+		}
+	)
+
+	switch completionAt.Target {
+	case frontend.CompletionTargetNodeKind:
+		completion.Candidates = append(completion.Candidates, completionCandidates(model.CypherCompletionTypeNodeKind, completionAt.Prefix, schema.Nodes.Strings())...)
+
+	case frontend.CompletionTargetRelationshipKind:
+		completion.Candidates = append(completion.Candidates, completionCandidates(model.CypherCompletionTypeRelationshipKind, completionAt.Prefix, schema.Edges.Strings())...)
+
+	case frontend.CompletionTargetProperty:
+		if propertyKeys, err := s.propertyKeys(ctx); err != nil {
+			return completion, err
+		} else {
+			completion.Candidates = append(completion.Candidates, completionCandidates(model.CypherCompletionTypeProperty, completionAt.Prefix, propertyKeys)...)
+		}
+
+	case frontend.CompletionTargetExpression:
+		completion.Candidates = append(completion.Candidates, completionCandidates(model.CypherCompletionTypeFunction, completionAt.Prefix, pgsql.SupportedFunctions())...)
+		completion.Candidates = append(completion.Candidates, completionCandidates(model.CypherCompletionTypeKeyword, completionAt.Prefix, frontend.Keywords)...)
+	}
+
+	return completion, nil
+}
+
+// cypherValidationIssue converts the given parser error to a validation issue. Syntax errors carry the position of the
+// symbol that caused them.
+func cypherValidationIssue(err error) model.CypherValidationIssue {
+	var syntaxError *frontend.SyntaxError
+
+	switch typedErr := err.(type) {
+	case *frontend.SyntaxError:
+		syntaxError = typedErr
+
+	case frontend.SyntaxError:
+		syntaxError = &typedErr
+
+	default:
+		return model.CypherValidationIssue{
+			Message: err.Error(),
+		}
+	}
+
+	issue := model.CypherValidationIssue{
+		Line:    syntaxError.Line,
+		Column:  syntaxError.Column,
+		Message: syntaxError.Message,
+	}
+
+	// Symbols reported by the parser are tokens while symbols of unsupported rules are the text of the rule
+	switch typedSymbol := syntaxError.OffendingSymbol.(type) {
+	case interface{ GetText() string }:
+		issue.Symbol = typedSymbol.GetText()
+
+	case string:
+		issue.Symbol = typedSymbol
+	}
+
+	return issue
+}
+
+// ValidateCypherQuery parses the given cypher query and returns the syntax errors found in it. Queries that parse are
+// checked for kinds and property keys that are not defined by the graph schema or observed in the graph, and for
+// complexity that exceeds the limit queries are held to.
+func (s *GraphQuery) ValidateCypherQuery(ctx context.Context, rawCypher string) (model.CypherValidation, error) {
+	var (
+		parseCtx   = frontend.ParameterizedCypherContext()
+		validation = model.CypherValidation{
+			Errors:   []model.CypherValidationIssue{},
+			Warnings: []model.CypherValidationIssue{},
+		}
+	)
+
+	queryModel, err := frontend.ParseCypher(parseCtx, rawCypher)
+
+	if err != nil {
+		if len(parseCtx.Errors) == 0 {
+			validation.Errors = append(validation.Errors, cypherValidationIssue(err))
+		}
+
+		for _, parseErr := range parseCtx.Errors {
+			validation.Errors = append(validation.Errors, cypherValidationIssue(parseErr))
+		}
+
+		return validation, nil
+	}
+
+	summary, err := analyzer.Summarize(queryModel)
+
+	if err != nil {
+		return validation, err
+	}
+
+	schema := graphschema.DefaultGraph()
+
+	for _, kind := range summary.Kinds {
+		if !schema.Nodes.ContainsOneOf(graph.StringKind(kind)) && !schema.Edges.ContainsOneOf(graph.StringKind(kind)) {
+			validation.Warnings = append(validation.Warnings, model.CypherValidationIssue{
+				Symbol:  kind,
+				Message: fmt.Sprintf("kind %s is not defined by the graph schema", kind),
+			})
+		}
+	}
+
+	if len(summary.Properties) > 0 {
+		if propertyKeys, err := s.propertyKeys(ctx); err != nil {
+			return validation, err
+		} else {
+			knownPropertyKeys := make(map[string]struct{}, len(propertyKeys))
+
+			for _, propertyKey := range propertyKeys {
+				knownPropertyKeys[propertyKey] = struct{}{}
+			}
+
+			for _, propertyKey := range summary.Properties {
+				if _, isKnown := knownPropertyKeys[propertyKey]; !isKnown {
+					validation.Warnings = append(validation.Warnings, model.CypherValidationIssue{
+						Symbol:  propertyKey,
+						Message: fmt.Sprintf("property %s is not defined by the graph schema or present in the graph", propertyKey),
+					})
+				}
+			}
+		}
+	}
+
+	if complexityMeasure, err := analyzer.QueryComplexity(queryModel); err != nil {
+		return validation, err
+	} else if complexityMeasure.Weight > MaxQueryComplexityWeightAllowed {
+		if s.DisableCypherQC {
+			validation.Warnings = append(validation.Warnings, model.CypherValidationIssue{
+				Message: ErrCypherQueryToComplex.Error(),
+			})
+		} else {
+			validation.Errors = append(validation.Errors, model.CypherValidationIssue{
+				Message: ErrCypherQueryToComplex.Error(),
+			})
+		}
+	}
+
+	validation.Valid = len(validation.Errors) == 0
+	return validation, nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package queries
+
+import (
+	"context"
+
+	"github.com/specterops/bloodhound/cypher/frontend"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/errors"
+	"github.com/specterops/bloodhound/log"
+	bhCtx "github.com/specterops/bloodhound/src/ctx"
+	"github.com/specterops/bloodhound/src/model"
+)
+
+// ExplainCypherQuery describes how the given cypher query would be run without running it. Queries that exceed the
+// complexity limit are explained rather than rejected so that the constructs contributing to their weight can be seen.
+func (s *GraphQuery) ExplainCypherQuery(ctx context.Context, rawCypher string, parameters map[string]any) (model.CypherExplanation, error) {
+	preparedQuery, err := s.prepareGraphQuery(frontend.ParameterizedCypherContext(), rawCypher, parameters, true)
+
+	if err != nil {
+		return model.CypherExplanation{}, err
+	}
+
+	var (
+		transactionConfig = s.cypherTransactionConfig(bhCtx.Get(ctx), preparedQuery)
+		effectiveConfig   = graph.TransactionConfig{}
+		explanation       = model.CypherExplanation{
+			Query:     preparedQuery.query,
+			Statement: preparedQuery.query,
+			Summary:   cypherQuerySummary(preparedQuery),
+			Complexity: model.CypherComplexity{
+				Weight:        preparedQuery.complexity.Weight,
+				MaxWeight:     MaxQueryComplexityWeightAllowed,
+				Enforced:      !s.DisableCypherQC,
+				TooComplex:    !s.DisableCypherQC && preparedQuery.complexity.Weight > MaxQueryComplexityWeightAllowed,
+				Contributions: make([]model.CypherComplexityContribution, 0, len(preparedQuery.complexity.Contributions)),
+			},
+		}
+	)
+
+	for _, contribution := range preparedQuery.complexity.Contributions {
+		explanation.Complexity.Contributions = append(explanation.Complexity.Contributions, model.CypherComplexityContribution{
+			Construct: contribution.Construct,
+			Reason:    contribution.Reason,
+			Weight:    contribution.Weight,
+		})
+	}
+
+	transactionConfig(&effectiveConfig)
+	explanation.TimeoutSeconds = effectiveConfig.Timeout.Seconds()
+
+	logEvent := log.WithLevel(log.LevelInfo)
+	logEvent.Str("query", preparedQuery.strippedQuery)
+	logEvent.Msg("Explaining user cypher query")
+
+	if err := s.Graph.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		if plan, err := graph.Explain(tx, preparedQuery.query, preparedQuery.parameters); err != nil {
+			// Drivers that can not explain queries still return the normalized query and its complexity
+			if errors.Is(err, graph.ErrQueryExplainUnsupported) {
+				return nil
+			}
+
+			return err
+		} else {
+			explanation.Statement = plan.Statement
+			explanation.Plan = plan.Plan
+		}
+
+		return nil
+	}, transactionConfig); err != nil {
+		return explanation, err
+	}
+
+	return explanation, nil
+}
+
+func cypherQuerySummary(preparedQuery preparedQuery) model.CypherQuerySummary {
+	return model.CypherQuerySummary{
+		Clauses:                preparedQuery.summary.Clauses,
+		PatternParts:           preparedQuery.summary.PatternParts,
+		NodePatterns:           preparedQuery.summary.NodePatterns,
+		RelationshipPatterns:   preparedQuery.summary.RelationshipPatterns,
+		VariableLengthPatterns: preparedQuery.summary.VariableLengthPatterns,
+		Functions:              preparedQuery.summary.Functions,
+		Kinds:                  preparedQuery.summary.Kinds,
+	}
+}
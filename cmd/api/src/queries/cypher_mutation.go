@@ -0,0 +1,76 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package queries
+
+import (
+	"context"
+
+	"github.com/specterops/bloodhound/cypher/frontend"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/errors"
+	"github.com/specterops/bloodhound/log"
+	bhCtx "github.com/specterops/bloodhound/src/ctx"
+	"github.com/specterops/bloodhound/src/model"
+)
+
+// errCypherMutationDryRun is returned from the transaction delegate of a dry run to roll back the changes it made
+var errCypherMutationDryRun = errors.New("cypher mutation dry run")
+
+// RawCypherMutation runs the given cypher query with updating clauses and summarizes the changes it made to the graph.
+// Dry runs count the changes the query would make and then roll them back.
+func (s *GraphQuery) RawCypherMutation(ctx context.Context, rawCypher string, parameters map[string]any, dryRun bool) (model.CypherMutationSummary, error) {
+	var mutationSummary model.CypherMutationSummary
+
+	if preparedQuery, err := s.prepareGraphQuery(frontend.MutationCypherContext(), rawCypher, parameters, s.DisableCypherQC); err != nil {
+		return mutationSummary, err
+	} else if preparedQuery.summary.UpdatingClauses == 0 {
+		return mutationSummary, newQueryError(ErrCypherQueryNoUpdates)
+	} else {
+		logEvent := log.WithLevel(log.LevelInfo)
+		logEvent.Str("query", preparedQuery.strippedQuery)
+		logEvent.Bool("dry_run", dryRun)
+		logEvent.Msg("Executing user cypher mutation")
+
+		err := s.Graph.WriteTransaction(ctx, func(tx graph.Transaction) error {
+			if summary, err := graph.Mutate(tx, preparedQuery.query, preparedQuery.parameters); err != nil {
+				return err
+			} else {
+				mutationSummary = model.CypherMutationSummary{
+					NodesCreated:         summary.NodesCreated,
+					NodesDeleted:         summary.NodesDeleted,
+					RelationshipsCreated: summary.RelationshipsCreated,
+					RelationshipsDeleted: summary.RelationshipsDeleted,
+					PropertiesSet:        summary.PropertiesSet,
+					LabelsAdded:          summary.LabelsAdded,
+					LabelsRemoved:        summary.LabelsRemoved,
+				}
+			}
+
+			if dryRun {
+				return errCypherMutationDryRun
+			}
+
+			return nil
+		}, s.cypherTransactionConfig(bhCtx.Get(ctx), preparedQuery))
+
+		if errors.Is(err, errCypherMutationDryRun) {
+			return mutationSummary, nil
+		}
+
+		return mutationSummary, err
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+// +build integration
+
+package queries_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/specterops/bloodhound/cache"
+	"github.com/specterops/bloodhound/dawgs/drivers/pg"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/query"
+	"github.com/specterops/bloodhound/graphschema/common"
+	"github.com/specterops/bloodhound/src/config"
+	bhCtx "github.com/specterops/bloodhound/src/ctx"
+	"github.com/specterops/bloodhound/src/queries"
+	"github.com/specterops/bloodhound/src/test/integration"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphQuery_RawCypherMutation_NodeDeletion(t *testing.T) {
+	var (
+		pgDB = integration.OpenGraphDBWithDriver(t, pg.DriverName)
+		gq   = queries.NewGraphQuery(graph.NewDatabaseSwitch(context.Background(), pgDB), cache.Cache{}, config.Configuration{})
+		ctx  = (&bhCtx.Context{Timeout: time.Minute}).ConstructGoContext()
+
+		countNodes = func(objectIDs ...string) int64 {
+			var numNodes int64
+
+			require.Nil(t, pgDB.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+				var err error
+				numNodes, err = tx.Nodes().Filter(query.In(query.NodeProperty(common.ObjectID.String()), objectIDs)).Count()
+				return err
+			}))
+
+			return numNodes
+		}
+	)
+
+	defer pgDB.Close(context.Background())
+
+	setupShortestPathFixture(t, pgDB)
+
+	t.Run("Delete Rejects Nodes With Relationships", func(t *testing.T) {
+		_, err := gq.RawCypherMutation(ctx, "match (n) where n.objectid = 'COMP1' delete n", nil, false)
+		require.ErrorContains(t, err, pg.ErrDeleteNodeWithRelationships.Error())
+		require.Equal(t, int64(1), countNodes("COMP1"))
+	})
+
+	t.Run("Detach Delete Counts Removed Relationships", func(t *testing.T) {
+		summary, err := gq.RawCypherMutation(ctx, "match (n) where n.objectid = 'COMP1' detach delete n", nil, true)
+		require.Nil(t, err)
+		require.Equal(t, 1, summary.NodesDeleted)
+		require.Equal(t, 4, summary.RelationshipsDeleted)
+		require.Equal(t, int64(1), countNodes("COMP1"))
+	})
+
+	t.Run("Detach Delete Counts Shared Relationships Once", func(t *testing.T) {
+		// USER1 has three relationships and GROUP1 has two, one of which is shared with USER1
+		summary, err := gq.RawCypherMutation(ctx, "match (n) where n.objectid = 'USER1' or n.objectid = 'GROUP1' detach delete n", nil, false)
+		require.Nil(t, err)
+		require.Equal(t, 2, summary.NodesDeleted)
+		require.Equal(t, 4, summary.RelationshipsDeleted)
+		require.Equal(t, int64(0), countNodes("USER1", "GROUP1"))
+	})
+
+	t.Run("Delete Nodes Without Relationships", func(t *testing.T) {
+		// GROUP2 lost its relationship from USER1 when USER1 was deleted which leaves its relationship to COMP1
+		summary, err := gq.RawCypherMutation(ctx, "match (n)-[r]->() where n.objectid = 'GROUP2' delete r", nil, false)
+		require.Nil(t, err)
+		require.Equal(t, 1, summary.RelationshipsDeleted)
+
+		summary, err = gq.RawCypherMutation(ctx, "match (n) where n.objectid = 'GROUP2' delete n", nil, false)
+		require.Nil(t, err)
+		require.Equal(t, 1, summary.NodesDeleted)
+		require.Equal(t, 0, summary.RelationshipsDeleted)
+		require.Equal(t, int64(0), countNodes("GROUP2"))
+	})
+
+	t.Run("Delete Can Not Be Combined With Set", func(t *testing.T) {
+		_, err := gq.RawCypherMutation(ctx, "match (n) where n.objectid = 'DA' set n.name = 'a' delete n", nil, false)
+		require.True(t, queries.IsQueryError(err))
+	})
+}
@@ -0,0 +1,231 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/specterops/bloodhound/cypher/frontend"
+	"github.com/specterops/bloodhound/dawgs/cardinality"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/errors"
+	"github.com/specterops/bloodhound/log"
+	bhCtx "github.com/specterops/bloodhound/src/ctx"
+	"github.com/specterops/bloodhound/src/model"
+)
+
+// ErrCypherStreamRowLimit is returned by the transaction of a streamed cypher query that read more rows than allowed
+var ErrCypherStreamRowLimit = errors.New("cypher query returned more rows than allowed")
+
+// CypherStreamFunc receives the records of a streamed cypher query in the order they are read. Returning an error
+// stops the query.
+type CypherStreamFunc func(record model.CypherStreamRecord) error
+
+// cypherStreamState tracks the nodes and edges that have already been written to a stream
+type cypherStreamState struct {
+	emit              CypherStreamFunc
+	includeProperties bool
+	nodes             cardinality.Duplex[uint64]
+	edges             cardinality.Duplex[uint64]
+}
+
+func (s *cypherStreamState) emitNode(node *graph.Node) error {
+	if !s.nodes.CheckedAdd(node.ID.Uint64()) {
+		return nil
+	}
+
+	streamNode := model.NewCypherTableNode(node, s.includeProperties)
+
+	return s.emit(model.CypherStreamRecord{
+		Type: model.CypherStreamRecordNode,
+		Node: &streamNode,
+	})
+}
+
+func (s *cypherStreamState) emitEdge(relationship *graph.Relationship) error {
+	if !s.edges.CheckedAdd(relationship.ID.Uint64()) {
+		return nil
+	}
+
+	streamEdge := model.NewCypherTableRelationship(relationship, s.includeProperties)
+
+	return s.emit(model.CypherStreamRecord{
+		Type: model.CypherStreamRecordEdge,
+		Edge: &streamEdge,
+	})
+}
+
+// emitEntities writes the nodes and edges of a row that contains only graph entities
+func (s *cypherStreamState) emitEntities(entities []any) error {
+	for _, entity := range entities {
+		switch typedEntity := entity.(type) {
+		case *graph.Node:
+			if err := s.emitNode(typedEntity); err != nil {
+				return err
+			}
+
+		case *graph.Relationship:
+			if err := s.emitEdge(typedEntity); err != nil {
+				return err
+			}
+
+		case *graph.Path:
+			for _, node := range typedEntity.Nodes {
+				if err := s.emitNode(node); err != nil {
+					return err
+				}
+			}
+
+			for _, relationship := range typedEntity.Edges {
+				if err := s.emitEdge(relationship); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// StreamRawCypherSearch runs the given cypher query and passes its results to emit as they are read from the
+// database instead of collecting them in memory. Rows that contain only nodes, relationships and paths are written as
+// the nodes and edges they contain with each node and edge written once. All other rows are written as row records. A
+// row limit greater than 0 fails the query once more rows than the limit are read. The number of rows read is returned.
+//
+// The query runs in a transaction bound to the given context so that cancelling the context, for example when the
+// client of a request disconnects, stops the query.
+func (s *GraphQuery) StreamRawCypherSearch(ctx context.Context, rawCypher string, parameters map[string]any, includeProperties bool, rowLimit int, emit CypherStreamFunc) (int, error) {
+	var (
+		numRows   = 0
+		bhCtxInst = bhCtx.Get(ctx)
+		state     = &cypherStreamState{
+			emit:              emit,
+			includeProperties: includeProperties,
+			nodes:             cardinality.NewBitmap64(),
+			edges:             cardinality.NewBitmap64(),
+		}
+	)
+
+	preparedQuery, err := s.prepareGraphQuery(frontend.ParameterizedCypherContext(), rawCypher, parameters, s.DisableCypherQC)
+
+	if err != nil {
+		return 0, err
+	} else if len(preparedQuery.columns) == 0 {
+		return 0, newQueryError(ErrCypherTableNoProjection)
+	}
+
+	logEvent := log.WithLevel(log.LevelInfo)
+	logEvent.Str("query", preparedQuery.strippedQuery)
+	logEvent.Msg("Executing user cypher query as a stream")
+
+	if err := s.Graph.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		result := tx.Query(preparedQuery.query, preparedQuery.parameters)
+
+		if result.Error() != nil {
+			return result.Error()
+		}
+
+		defer result.Close()
+
+		columnRecord := model.CypherStreamRecord{
+			Type:    model.CypherStreamRecordColumns,
+			Columns: make([]string, len(preparedQuery.columns)),
+		}
+
+		for idx, column := range preparedQuery.columns {
+			columnRecord.Columns[idx] = column.name
+		}
+
+		if err := emit(columnRecord); err != nil {
+			return err
+		}
+
+		for result.Next() {
+			if numRows++; rowLimit > 0 && numRows > rowLimit {
+				return ErrCypherStreamRowLimit
+			}
+
+			if values, err := result.Values(); err != nil {
+				return err
+			} else if err := s.streamCypherRow(state, values, preparedQuery.columns); err != nil {
+				return err
+			}
+		}
+
+		return result.Error()
+	}, s.cypherTransactionConfig(bhCtxInst, preparedQuery)); err != nil {
+		if errors.Is(err, ErrCypherStreamRowLimit) {
+			return numRows - 1, newQueryError(fmt.Errorf("cypher query returned more than %d rows, limit the number of rows returned by the query", rowLimit))
+		}
+
+		return numRows, err
+	}
+
+	return numRows, nil
+}
+
+// streamCypherRow writes a single row of a streamed cypher query. Null values, such as those bound by optional matches
+// that found nothing, are left out of rows that are written as nodes and edges.
+func (s *GraphQuery) streamCypherRow(state *cypherStreamState, values graph.ValueMapper, columns []preparedColumn) error {
+	var (
+		rawValues    = make([]any, len(columns))
+		entities     = make([]any, 0, len(columns))
+		onlyEntities = true
+	)
+
+	for idx := range columns {
+		rawValue, err := values.Next()
+
+		if err != nil {
+			return err
+		}
+
+		rawValues[idx] = rawValue
+
+		if rawValue == nil || !onlyEntities {
+			continue
+		}
+
+		var (
+			node         graph.Node
+			relationship graph.Relationship
+			path         graph.Path
+		)
+
+		if mapped, err := values.MapValueOptions(rawValue, &relationship, &node, &path); err != nil {
+			onlyEntities = false
+		} else {
+			entities = append(entities, mapped)
+		}
+	}
+
+	if onlyEntities {
+		return state.emitEntities(entities)
+	}
+
+	row := make([]any, len(rawValues))
+
+	for idx, rawValue := range rawValues {
+		row[idx] = cypherTableValue(values, rawValue, state.includeProperties)
+	}
+
+	return state.emit(model.CypherStreamRecord{
+		Type: model.CypherStreamRecordRow,
+		Row:  row,
+	})
+}
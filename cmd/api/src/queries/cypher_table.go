@@ -0,0 +1,307 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package queries
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/specterops/bloodhound/cypher/frontend"
+	cypherModel "github.com/specterops/bloodhound/cypher/model"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/errors"
+	"github.com/specterops/bloodhound/log"
+	bhCtx "github.com/specterops/bloodhound/src/ctx"
+	"github.com/specterops/bloodhound/src/model"
+)
+
+const (
+	// MaxCypherTableRows is the maximum number of rows returned in a single page of a cypher table
+	MaxCypherTableRows = 10_000
+
+	// MaxCypherTableCount is the maximum number of rows counted for a cypher table. Queries that produce more rows
+	// report this count instead.
+	MaxCypherTableCount = 100_000
+)
+
+var (
+	ErrCypherTableNoProjection = errors.New("cypher query must return explicitly named projections to be formatted as a table")
+)
+
+// preparedColumn describes a projection of the final return clause of a cypher query
+type preparedColumn struct {
+	name string
+}
+
+// returnProjection returns the projection of the return clause that produces the rows of the given query
+func returnProjection(queryModel *cypherModel.RegularQuery) *cypherModel.Projection {
+	if queryModel.SingleQuery == nil {
+		return nil
+	}
+
+	singlePartQuery := queryModel.SingleQuery.SinglePartQuery
+
+	if multiPartQuery := queryModel.SingleQuery.MultiPartQuery; multiPartQuery != nil {
+		singlePartQuery = multiPartQuery.SinglePartQuery
+	}
+
+	if singlePartQuery == nil || singlePartQuery.Return == nil {
+		return nil
+	}
+
+	return singlePartQuery.Return.Projection
+}
+
+// prepareColumns names the columns of the given query. Columns are named after the binding of the projection or,
+// if the projection has no binding, the projection expression itself. This matches the column names of Neo4j.
+func (s *GraphQuery) prepareColumns(queryModel *cypherModel.RegularQuery) ([]preparedColumn, error) {
+	projection := returnProjection(queryModel)
+
+	if projection == nil || projection.All {
+		return nil, nil
+	}
+
+	var (
+		buffer  = &bytes.Buffer{}
+		columns = make([]preparedColumn, len(projection.Items))
+	)
+
+	for idx, item := range projection.Items {
+		projectionItem, isProjectionItem := item.(*cypherModel.ProjectionItem)
+
+		if !isProjectionItem {
+			return nil, fmt.Errorf("unexpected projection item type %T", item)
+		}
+
+		if binding, hasBinding := projectionItem.Binding.(*cypherModel.Variable); hasBinding && binding != nil {
+			columns[idx].name = binding.Symbol
+		} else if err := s.cypherEmitter.WriteExpression(buffer, projectionItem.Expression); err != nil {
+			return nil, err
+		} else {
+			columns[idx].name = buffer.String()
+			buffer.Reset()
+		}
+	}
+
+	return columns, nil
+}
+
+// CypherTablePageLimit returns the number of rows in a page of a cypher table for the requested limit. A limit of 0
+// requests as many rows as a page may hold.
+func CypherTablePageLimit(limit int) int {
+	if limit <= 0 || limit > MaxCypherTableRows {
+		return MaxCypherTableRows
+	}
+
+	return limit
+}
+
+// RawCypherTableSearch runs the given cypher query and returns its projections as a table. Only the rows in the page
+// described by skip and limit are returned along with the total number of rows the query produced. Pages are limited
+// to MaxCypherTableRows rows, which is also the page size used when limit is 0. Rows are counted up to
+// MaxCypherTableCount or the end of the requested page, whichever is greater. When the query produces more rows than
+// were counted the CountCapped flag of the table is set.
+func (s *GraphQuery) RawCypherTableSearch(ctx context.Context, rawCypher string, parameters map[string]any, includeProperties bool, skip, limit int) (model.CypherTable, int, error) {
+	var (
+		table = model.CypherTable{
+			Columns: []model.CypherColumn{},
+			Rows:    [][]any{},
+		}
+		numRows   = 0
+		bhCtxInst = bhCtx.Get(ctx)
+	)
+
+	limit = CypherTablePageLimit(limit)
+	maxCount := max(skip+limit, MaxCypherTableCount)
+
+	preparedQuery, err := s.prepareGraphQuery(frontend.ParameterizedCypherContext(), rawCypher, parameters, s.DisableCypherQC)
+
+	if err != nil {
+		return table, 0, err
+	} else if len(preparedQuery.columns) == 0 {
+		return table, 0, newQueryError(ErrCypherTableNoProjection)
+	}
+
+	for _, column := range preparedQuery.columns {
+		table.Columns = append(table.Columns, model.CypherColumn{
+			Name: column.name,
+		})
+	}
+
+	logEvent := log.WithLevel(log.LevelInfo)
+	logEvent.Str("query", preparedQuery.strippedQuery)
+	logEvent.Msg("Executing user cypher query as a table")
+
+	if err := s.Graph.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		result := tx.Query(preparedQuery.query, preparedQuery.parameters)
+
+		if result.Error() != nil {
+			return result.Error()
+		}
+
+		defer result.Close()
+
+		for numRows < maxCount && result.Next() {
+			// Rows outside the requested page are counted but not read
+			if numRows++; numRows <= skip || numRows > skip+limit {
+				continue
+			}
+
+			if values, err := result.Values(); err != nil {
+				return err
+			} else if row, err := s.cypherTableRow(values, preparedQuery.columns, includeProperties); err != nil {
+				return err
+			} else {
+				table.Rows = append(table.Rows, row)
+			}
+		}
+
+		// Check for a row past the count limit so that callers know the count is only a lower bound
+		if numRows == maxCount && result.Next() {
+			table.CountCapped = true
+		}
+
+		return result.Error()
+	}, s.cypherTransactionConfig(bhCtxInst, preparedQuery)); err != nil {
+		return table, 0, err
+	}
+
+	for idx := range table.Columns {
+		table.Columns[idx].Type = cypherTableColumnType(table.Rows, idx)
+	}
+
+	return table, numRows, nil
+}
+
+// cypherTableRow reads the values of a single row
+func (s *GraphQuery) cypherTableRow(values graph.ValueMapper, columns []preparedColumn, includeProperties bool) ([]any, error) {
+	row := make([]any, len(columns))
+
+	for idx := range columns {
+		if rawValue, err := values.Next(); err != nil {
+			return nil, err
+		} else {
+			row[idx] = cypherTableValue(values, rawValue, includeProperties)
+		}
+	}
+
+	return row, nil
+}
+
+// cypherTableValue converts a raw value read from the database into a value that can be serialized as JSON. Graph
+// entities are converted to their tabular representation, including those nested in lists and maps.
+func cypherTableValue(values graph.ValueMapper, rawValue any, includeProperties bool) any {
+	var (
+		node         graph.Node
+		relationship graph.Relationship
+		path         graph.Path
+	)
+
+	if mapped, err := values.MapValueOptions(rawValue, &relationship, &node, &path); err == nil {
+		switch typedMapped := mapped.(type) {
+		case *graph.Relationship:
+			return model.NewCypherTableRelationship(typedMapped, includeProperties)
+
+		case *graph.Node:
+			return model.NewCypherTableNode(typedMapped, includeProperties)
+
+		case *graph.Path:
+			return model.NewCypherTablePath(*typedMapped, includeProperties)
+		}
+	}
+
+	switch typedValue := rawValue.(type) {
+	case []any:
+		elements := make([]any, len(typedValue))
+
+		for idx, element := range typedValue {
+			elements[idx] = cypherTableValue(values, element, includeProperties)
+		}
+
+		return elements
+
+	case map[string]any:
+		entries := make(map[string]any, len(typedValue))
+
+		for key, value := range typedValue {
+			entries[key] = cypherTableValue(values, value, includeProperties)
+		}
+
+		return entries
+	}
+
+	return rawValue
+}
+
+// cypherTableColumnType returns the type shared by the values of a column. Null values are ignored.
+func cypherTableColumnType(rows [][]any, columnIdx int) model.CypherColumnType {
+	var columnType model.CypherColumnType
+
+	for _, row := range rows {
+		if row[columnIdx] == nil {
+			continue
+		}
+
+		if valueType := cypherTableValueType(row[columnIdx]); columnType == "" {
+			columnType = valueType
+		} else if columnType != valueType {
+			return model.CypherColumnTypeAny
+		}
+	}
+
+	if columnType == "" {
+		return model.CypherColumnTypeAny
+	}
+
+	return columnType
+}
+
+func cypherTableValueType(value any) model.CypherColumnType {
+	switch value.(type) {
+	case model.CypherTableNode:
+		return model.CypherColumnTypeNode
+
+	case model.CypherTableRelationship:
+		return model.CypherColumnTypeRelationship
+
+	case model.CypherTablePath:
+		return model.CypherColumnTypePath
+
+	case string:
+		return model.CypherColumnTypeString
+
+	case bool:
+		return model.CypherColumnTypeBoolean
+
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return model.CypherColumnTypeNumber
+
+	case time.Time:
+		return model.CypherColumnTypeDateTime
+
+	case []any:
+		return model.CypherColumnTypeList
+
+	case map[string]any:
+		return model.CypherColumnTypeMap
+
+	default:
+		return model.CypherColumnTypeAny
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+// +build integration
+
+package queries_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/specterops/bloodhound/cache"
+	"github.com/specterops/bloodhound/dawgs/changefeed"
+	"github.com/specterops/bloodhound/dawgs/drivers/pg"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/graphschema/ad"
+	"github.com/specterops/bloodhound/graphschema/common"
+	"github.com/specterops/bloodhound/src/config"
+	bhCtx "github.com/specterops/bloodhound/src/ctx"
+	"github.com/specterops/bloodhound/src/model"
+	"github.com/specterops/bloodhound/src/queries"
+	"github.com/specterops/bloodhound/src/test/integration"
+	"github.com/stretchr/testify/require"
+)
+
+const kindNamesQuery = "match (n:User)-[r:MemberOf]->(m:Group) where n.objectid = 'USER2' return type(r) as kind, labels(m) as labels, [type(r)] as kinds, {kind: type(r)} as entry"
+
+// TestGraphQuery_CypherKindNames runs queries through a database switch and a change feed, as the API does, to check
+// that kind names rather than kind IDs are returned by PostgreSQL for the type and labels functions
+func TestGraphQuery_CypherKindNames(t *testing.T) {
+	var (
+		pgDB     = integration.OpenGraphDBWithDriver(t, pg.DriverName)
+		dbSwitch = graph.NewDatabaseSwitch(context.Background(), changefeed.Wrap(pgDB))
+		gq       = queries.NewGraphQuery(dbSwitch, cache.Cache{}, config.Configuration{})
+		ctx      = (&bhCtx.Context{Timeout: time.Minute}).ConstructGoContext()
+	)
+
+	defer pgDB.Close(context.Background())
+
+	setupShortestPathFixture(t, pgDB)
+
+	t.Run("Table", func(t *testing.T) {
+		table, numRows, err := gq.RawCypherTableSearch(ctx, kindNamesQuery, nil, false, 0, 0)
+		require.Nil(t, err)
+		require.Equal(t, 1, numRows)
+		require.Len(t, table.Rows, 1)
+
+		row := table.Rows[0]
+		require.Equal(t, "MemberOf", row[0])
+		require.Contains(t, row[1], "Group")
+		require.Contains(t, row[2], "MemberOf")
+		require.Equal(t, map[string]any{"kind": "MemberOf"}, row[3])
+	})
+
+	t.Run("Stream", func(t *testing.T) {
+		var rows [][]any
+
+		numRows, err := gq.StreamRawCypherSearch(ctx, kindNamesQuery, nil, false, 0, func(record model.CypherStreamRecord) error {
+			if record.Type == model.CypherStreamRecordRow {
+				rows = append(rows, record.Row)
+			}
+
+			return nil
+		})
+
+		require.Nil(t, err)
+		require.Equal(t, 1, numRows)
+		require.Len(t, rows, 1)
+		require.Equal(t, "MemberOf", rows[0][0])
+		require.Contains(t, rows[0][1], "Group")
+		require.Contains(t, rows[0][2], "MemberOf")
+		require.Equal(t, map[string]any{"kind": "MemberOf"}, rows[0][3])
+	})
+
+	t.Run("Untranslatable Queries Are Rejected", func(t *testing.T) {
+		// Queries that can not be translated for PostgreSQL must be rejected before they are run
+		_, _, err := gq.RawCypherTableSearch(ctx, "match (n) return reverse(n.name) as name", nil, false, 0, 0)
+		require.True(t, queries.IsQueryError(err))
+		require.ErrorContains(t, err, "unsupported function invocation reverse")
+	})
+}
+
+// TestGraphQuery_CypherNumericConversions checks that PostgreSQL converts values that do not represent a number to
+// null in toInteger and toFloat instead of failing the query
+func TestGraphQuery_CypherNumericConversions(t *testing.T) {
+	var (
+		pgDB     = integration.OpenGraphDBWithDriver(t, pg.DriverName)
+		dbSwitch = graph.NewDatabaseSwitch(context.Background(), changefeed.Wrap(pgDB))
+		gq       = queries.NewGraphQuery(dbSwitch, cache.Cache{}, config.Configuration{})
+		ctx      = (&bhCtx.Context{Timeout: time.Minute}).ConstructGoContext()
+	)
+
+	defer pgDB.Close(context.Background())
+
+	setupShortestPathFixture(t, pgDB)
+
+	require.Nil(t, pgDB.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		_, err := tx.CreateNode(graph.AsProperties(map[string]any{
+			common.ObjectID.String(): "NUMERIC",
+			"count":                  "12",
+			"score":                  "1.5",
+			"label":                  "abc",
+		}), ad.Entity, ad.User)
+
+		return err
+	}))
+
+	table, numRows, err := gq.RawCypherTableSearch(ctx, "match (n) where n.objectid = 'NUMERIC' return toInteger(n.count) as count, toFloat(n.score) as score, toInteger(n.label) as labelInt, toFloat(n.label) as labelFloat", nil, false, 0, 0)
+	require.Nil(t, err)
+	require.Equal(t, 1, numRows)
+	require.Len(t, table.Rows, 1)
+
+	row := table.Rows[0]
+	require.EqualValues(t, 12, row[0])
+	require.EqualValues(t, 1.5, row[1])
+	require.Nil(t, row[2])
+	require.Nil(t, row[3])
+}
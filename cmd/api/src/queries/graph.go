@@ -40,12 +40,15 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/specterops/bloodhound/analysis"
+	"github.com/specterops/bloodhound/analysis/pathcost"
 	"github.com/specterops/bloodhound/cache"
 	"github.com/specterops/bloodhound/cypher/analyzer"
 	"github.com/specterops/bloodhound/cypher/frontend"
+	cypherModel "github.com/specterops/bloodhound/cypher/model"
 	"github.com/specterops/bloodhound/dawgs/graph"
 	"github.com/specterops/bloodhound/dawgs/ops"
 	"github.com/specterops/bloodhound/dawgs/query"
+	"github.com/specterops/bloodhound/dawgs/traversal"
 	"github.com/specterops/bloodhound/errors"
 	"github.com/specterops/bloodhound/graphschema/ad"
 	"github.com/specterops/bloodhound/graphschema/azure"
@@ -69,6 +72,7 @@ var (
 	ErrUnsupportedDataType  = errors.New("unsupported result type for this query")
 	ErrGraphUnsupported     = errors.New("type 'graph' is not supported for this endpoint")
 	ErrCypherQueryToComplex = errors.New("cypher query is too complex and is likely to result in poor or unstable database performance")
+	ErrCypherQueryNoUpdates = errors.New("cypher query does not contain any updating clauses")
 )
 
 type EntityQueryParameters struct {
@@ -133,6 +137,7 @@ type Graph interface {
 	GetAssetGroupComboNode(ctx context.Context, owningObjectID string, assetGroupTag string) (map[string]any, error)
 	GetAssetGroupNodes(ctx context.Context, assetGroupTag string) (graph.NodeSet, error)
 	GetAllShortestPaths(ctx context.Context, startNodeID string, endNodeID string, filter graph.Criteria) (graph.PathSet, error)
+	GetKCheapestPaths(ctx context.Context, startNodeID string, endNodeID string, filter graph.Criteria, weighted bool, k int) ([]traversal.WeightedPath, error)
 	SearchNodesByName(ctx context.Context, nodeKinds graph.Kinds, nameQuery string, skip int, limit int) ([]model.SearchResult, error)
 	SearchByNameOrObjectID(ctx context.Context, searchValue string, searchType string) (graph.NodeSet, error)
 	GetADEntityQueryResult(ctx context.Context, params EntityQueryParameters, cacheEnabled bool) (any, int, error)
@@ -143,7 +148,13 @@ type Graph interface {
 	FetchNodesByObjectIDs(ctx context.Context, objectIDs ...string) (graph.NodeSet, error)
 	ValidateOUs(ctx context.Context, ous []string) ([]string, error)
 	BatchNodeUpdate(ctx context.Context, nodeUpdate graph.NodeUpdate) error
-	RawCypherSearch(ctx context.Context, rawCypher string, includeProperties bool) (model.UnifiedGraph, error)
+	RawCypherSearch(ctx context.Context, rawCypher string, parameters map[string]any, includeProperties bool) (model.UnifiedGraph, error)
+	RawCypherTableSearch(ctx context.Context, rawCypher string, parameters map[string]any, includeProperties bool, skip, limit int) (model.CypherTable, int, error)
+	StreamRawCypherSearch(ctx context.Context, rawCypher string, parameters map[string]any, includeProperties bool, rowLimit int, emit CypherStreamFunc) (int, error)
+	ExplainCypherQuery(ctx context.Context, rawCypher string, parameters map[string]any) (model.CypherExplanation, error)
+	RawCypherMutation(ctx context.Context, rawCypher string, parameters map[string]any, dryRun bool) (model.CypherMutationSummary, error)
+	CompleteCypherQuery(ctx context.Context, rawCypher string, offset int) (model.CypherCompletion, error)
+	ValidateCypherQuery(ctx context.Context, rawCypher string) (model.CypherValidation, error)
 	UpdateSelectorTags(ctx context.Context, db agi.AgiData, selectors model.UpdatedAssetGroupSelectors) error
 }
 
@@ -152,6 +163,7 @@ type GraphQuery struct {
 	Cache                 cache.Cache
 	SlowQueryThreshold    int64 // Threshold in milliseconds
 	DisableCypherQC       bool
+	EdgeCostModel         pathcost.Model
 	cypherEmitter         cypher.Emitter
 	strippedCypherEmitter cypher.Emitter
 }
@@ -162,6 +174,7 @@ func NewGraphQuery(graphDB graph.Database, cache cache.Cache, cfg config.Configu
 		Cache:                 cache,
 		SlowQueryThreshold:    cfg.SlowQueryThreshold,
 		DisableCypherQC:       cfg.DisableCypherQC,
+		EdgeCostModel:         pathcost.DefaultModel().WithKindCosts(cfg.PathfindingEdgeCosts),
 		cypherEmitter:         cypher.NewCypherEmitter(false),
 		strippedCypherEmitter: cypher.NewCypherEmitter(true),
 	}
@@ -276,6 +289,51 @@ func (s *GraphQuery) GetAllShortestPaths(ctx context.Context, startNodeID string
 	})
 }
 
+// GetKCheapestPaths returns up to k loopless paths between the two nodes ranked by ascending cost. Weighted searches
+// price relationships with the configured edge cost model while unweighted searches rank paths by their length.
+func (s *GraphQuery) GetKCheapestPaths(ctx context.Context, startNodeID string, endNodeID string, filter graph.Criteria, weighted bool, k int) ([]traversal.WeightedPath, error) {
+	defer log.Measure(log.LevelInfo, "GetKCheapestPaths")()
+
+	var (
+		paths []traversal.WeightedPath
+		plan  = traversal.WeightedPlan{
+			Direction: graph.DirectionOutbound,
+			Criteria:  filter,
+			Cost:      traversal.UnitCost,
+		}
+	)
+
+	if weighted {
+		costModel := s.EdgeCostModel
+
+		// Guard against graph queries that were not built with NewGraphQuery(...)
+		if costModel.KindCosts == nil {
+			costModel = pathcost.DefaultModel()
+		}
+
+		plan.Cost = costModel.Cost
+	}
+
+	return paths, s.Graph.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		if startNode, err := analysis.FetchNodeByObjectID(tx, startNodeID); err != nil {
+			return err
+		} else if endNode, err := analysis.FetchNodeByObjectID(tx, endNodeID); err != nil {
+			return err
+		} else {
+			plan.StartID = startNode.ID
+			plan.EndID = endNode.ID
+
+			if cheapestPaths, err := traversal.KCheapestPaths(ctx, tx, plan, k); err != nil {
+				return err
+			} else {
+				paths = cheapestPaths
+			}
+
+			return nil
+		}
+	})
+}
+
 // the following negation clause matches nodes that have both ADLocalGroup and Group labels, but excludes nodes that only have the ADLocalGroup label.
 // equivalent cypher: MATCH (n) WHERE NOT (n:ADLocalGroup AND NOT n:Group)
 var groupFilter = query.Not(
@@ -368,34 +426,41 @@ func (s *GraphQuery) SearchNodesByName(ctx context.Context, nodeKinds graph.Kind
 type preparedQuery struct {
 	query         string
 	strippedQuery string
+	parameters    map[string]any
 	complexity    *analyzer.ComplexityMeasure
+	summary       *analyzer.QuerySummary
+	columns       []preparedColumn
 }
 
-func (s *GraphQuery) prepareGraphQuery(rawCypher string, disableCypherQC bool) (preparedQuery, error) {
+// CypherParameterNames returns the names of the parameters referenced by the given cypher query
+func CypherParameterNames(rawCypher string) ([]string, error) {
+	if queryModel, err := frontend.ParseCypher(frontend.ParameterizedCypherContext(), rawCypher); err != nil {
+		return nil, newQueryError(err)
+	} else {
+		return cypherModel.ParameterSymbols(queryModel)
+	}
+}
+
+func (s *GraphQuery) prepareGraphQuery(parseCtx *frontend.Context, rawCypher string, parameters map[string]any, disableCypherQC bool) (preparedQuery, error) {
 	var (
-		parseCtx   = frontend.DefaultCypherContext()
 		buffer     = &bytes.Buffer{}
 		graphQuery preparedQuery
 	)
 
 	if queryModel, err := frontend.ParseCypher(parseCtx, rawCypher); err != nil {
 		return graphQuery, newQueryError(err)
+	} else if graphQuery.parameters, err = model.NormalizeCypherParameters(parameters); err != nil {
+		return graphQuery, newQueryError(err)
+	} else if err := cypherModel.BindParameters(queryModel, graphQuery.parameters); err != nil {
+		return graphQuery, newQueryError(err)
 	} else if complexityMeasure, err := analyzer.QueryComplexity(queryModel); err != nil {
 		return graphQuery, newQueryError(err)
 	} else if !disableCypherQC && complexityMeasure.Weight > MaxQueryComplexityWeightAllowed {
 		return graphQuery, newQueryError(ErrCypherQueryToComplex)
-	} else if pgDB, isPG := s.Graph.(*pg.Driver); isPG {
-		if _, err := pgsql.Translate(queryModel, pgDB.KindMapper()); err != nil {
-			return graphQuery, newQueryError(err)
-		}
-
-		if err := pgsql.NewEmitter(false, pgDB.KindMapper()).Write(queryModel, buffer); err != nil {
-			return graphQuery, err
-		} else {
-			graphQuery.query = buffer.String()
-		}
-
-		return graphQuery, nil
+	} else if graphQuery.columns, err = s.prepareColumns(queryModel); err != nil {
+		return graphQuery, newQueryError(err)
+	} else if graphQuery.summary, err = analyzer.Summarize(queryModel); err != nil {
+		return graphQuery, newQueryError(err)
 	} else {
 		graphQuery.complexity = complexityMeasure
 
@@ -412,18 +477,37 @@ func (s *GraphQuery) prepareGraphQuery(rawCypher string, disableCypherQC bool) (
 		} else {
 			graphQuery.strippedQuery = buffer.String()
 		}
+
+		// The pg driver translates cypher queries when they are run. Translating the query here rejects queries that
+		// can not be translated before a transaction is opened for them. Translation rewrites the query model so this
+		// must happen after the query has been formatted.
+		if pgDB, isPG := graph.UnwrapDatabase(s.Graph).(*pg.Driver); isPG {
+			buffer.Reset()
+
+			emitter := pgsql.NewEmitter(false, pgDB.KindMapper())
+
+			if defaultGraph, hasDefaultGraph := pgDB.DefaultGraph(); hasDefaultGraph {
+				emitter.WithGraph(defaultGraph)
+			}
+
+			if _, err := pgsql.Translate(queryModel, pgDB.KindMapper()); err != nil {
+				return graphQuery, newQueryError(err)
+			} else if err := emitter.Write(queryModel, buffer); err != nil {
+				return graphQuery, newQueryError(err)
+			}
+		}
 	}
 
 	return graphQuery, nil
 }
 
-func (s *GraphQuery) RawCypherSearch(ctx context.Context, rawCypher string, includeProperties bool) (model.UnifiedGraph, error) {
+func (s *GraphQuery) RawCypherSearch(ctx context.Context, rawCypher string, parameters map[string]any, includeProperties bool) (model.UnifiedGraph, error) {
 	var (
 		graphResponse = model.NewUnifiedGraph()
 		bhCtxInst     = bhCtx.Get(ctx)
 	)
 
-	if preparedQuery, err := s.prepareGraphQuery(rawCypher, s.DisableCypherQC); err != nil {
+	if preparedQuery, err := s.prepareGraphQuery(frontend.ParameterizedCypherContext(), rawCypher, parameters, s.DisableCypherQC); err != nil {
 		return graphResponse, err
 	} else {
 		logEvent := log.WithLevel(log.LevelInfo)
@@ -431,37 +515,42 @@ func (s *GraphQuery) RawCypherSearch(ctx context.Context, rawCypher string, incl
 		logEvent.Msg("Executing user cypher query")
 
 		return graphResponse, s.Graph.ReadTransaction(ctx, func(tx graph.Transaction) error {
-			if pathSet, err := ops.FetchPathSetByQuery(tx, preparedQuery.query); err != nil {
+			if pathSet, err := ops.FetchPathSetByQuery(tx, preparedQuery.query, preparedQuery.parameters); err != nil {
 				return err
 			} else {
 				graphResponse.AddPathSet(pathSet, includeProperties)
 			}
 
 			return nil
-		}, func(config *graph.TransactionConfig) {
-			// The upperbound for this query must be either the custom request timeout, or if it isn't
-			// supplied then 30 minutes- since that's a reasonable duration at which to deem the transaction
-			// as having been stuck in a deadlock or other error
-			availableRuntime := bhCtxInst.Timeout
-			if availableRuntime > 0 {
-				log.Debugf("Available timeout for query is set to: %.2f seconds", availableRuntime.Seconds())
-			} else {
-				availableRuntime = time.Minute * 30
+		}, s.cypherTransactionConfig(bhCtxInst, preparedQuery))
+	}
+}
+
+// cypherTransactionConfig returns the transaction option used to run a user supplied cypher query
+func (s *GraphQuery) cypherTransactionConfig(bhCtxInst *bhCtx.Context, preparedQuery preparedQuery) graph.TransactionOption {
+	return func(config *graph.TransactionConfig) {
+		// The upperbound for this query must be either the custom request timeout, or if it isn't
+		// supplied then 30 minutes- since that's a reasonable duration at which to deem the transaction
+		// as having been stuck in a deadlock or other error
+		availableRuntime := bhCtxInst.Timeout
+		if availableRuntime > 0 {
+			log.Debugf("Available timeout for query is set to: %.2f seconds", availableRuntime.Seconds())
+		} else {
+			availableRuntime = time.Minute * 30
 
-				if !s.DisableCypherQC {
-					// The weight of the query is divided by 5 to get a runtime reduction factor. This means that query weights
-					// of 5 or less will get the full runtime duration.
-					if reductionFactor := time.Duration(preparedQuery.complexity.Weight) / 5; reductionFactor > 0 {
-						availableRuntime /= reductionFactor
+			if !s.DisableCypherQC {
+				// The weight of the query is divided by 5 to get a runtime reduction factor. This means that query weights
+				// of 5 or less will get the full runtime duration.
+				if reductionFactor := time.Duration(preparedQuery.complexity.Weight) / 5; reductionFactor > 0 {
+					availableRuntime /= reductionFactor
 
-						log.Infof("Cypher query cost is: %.2f. Reduction factor for query is: %d. Available timeout for query is now set to: %.2f minutes", preparedQuery.complexity.Weight, reductionFactor, availableRuntime.Minutes())
-					}
+					log.Infof("Cypher query cost is: %.2f. Reduction factor for query is: %d. Available timeout for query is now set to: %.2f minutes", preparedQuery.complexity.Weight, reductionFactor, availableRuntime.Minutes())
 				}
 			}
+		}
 
-			// Set the timeout for this DB interaction
-			config.Timeout = availableRuntime
-		})
+		// Set the timeout for this DB interaction
+		config.Timeout = availableRuntime
 	}
 }
 
@@ -772,10 +861,22 @@ func runEntityQuery(ctx context.Context, db graph.Database, delegate any, node *
 	return result, nil
 }
 
+// entityQueryCacheKey returns the cache key of an entity query. Keys of queries scoped to a graph other than the
+// default graph are prefixed with the name of the graph so that workspaces do not share cached results.
+func entityQueryCacheKey(ctx context.Context, params EntityQueryParameters) string {
+	cacheKey := fmt.Sprintf("ad-entity-query_%s_%s_%d", params.QueryName, params.ObjectID, params.RequestedType)
+
+	if graphTarget, hasGraph := graph.GraphFromContext(ctx); hasGraph {
+		return graphTarget.Name + "_" + cacheKey
+	}
+
+	return cacheKey
+}
+
 func (s *GraphQuery) runMaybeCachedEntityQuery(ctx context.Context, node *graph.Node, params EntityQueryParameters, cacheEnabled bool) (graph.NodeSet, error) {
 	var (
 		queryStart = time.Now()
-		cacheKey   = fmt.Sprintf("ad-entity-query_%s_%s_%d", params.QueryName, params.ObjectID, params.RequestedType)
+		cacheKey   = entityQueryCacheKey(ctx, params)
 
 		foundResultInCache = false
 
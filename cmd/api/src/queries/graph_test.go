@@ -22,6 +22,7 @@ import (
 	"github.com/specterops/bloodhound/src/config"
 	"net/http"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -78,7 +79,7 @@ func TestGraphQuery_RawCypherSearch(t *testing.T) {
 		return nil
 	})
 
-	_, err := gq.RawCypherSearch(outerBHCtxInst.ConstructGoContext(), "match (n) return n;", false)
+	_, err := gq.RawCypherSearch(outerBHCtxInst.ConstructGoContext(), "match (n) return n;", nil, false)
 	require.Nil(t, err)
 
 	// Validate that query complexity controls are working
@@ -101,17 +102,468 @@ func TestGraphQuery_RawCypherSearch(t *testing.T) {
 	// This will be set to a default of 30 min, with a reduction factor of 3, so we should have a 10 min config timeout
 	outerBHCtxInst.Timeout = 0
 
-	_, err = gq.RawCypherSearch(outerBHCtxInst.ConstructGoContext(), "match ()-[:HasSession*..]->()-[:MemberOf*..]->() return n;", false)
+	_, err = gq.RawCypherSearch(outerBHCtxInst.ConstructGoContext(), "match ()-[:HasSession*..]->()-[:MemberOf*..]->() return n;", nil, false)
 	require.Nil(t, err)
 
 	// Prove that overriding QC with a user-preference works
 	// This will be directly used as the config timeout, without any reduction factor
 	outerBHCtxInst.Timeout = time.Minute * 10
 
-	_, err = gq.RawCypherSearch(outerBHCtxInst.ConstructGoContext(), "match ()-[:HasSession*..]->()-[:MemberOf*..]->() return n;", false)
+	_, err = gq.RawCypherSearch(outerBHCtxInst.ConstructGoContext(), "match ()-[:HasSession*..]->()-[:MemberOf*..]->() return n;", nil, false)
 	require.Nil(t, err)
 }
 
+func TestGraphQuery_RawCypherTableSearch(t *testing.T) {
+	var (
+		mockCtrl    = gomock.NewController(t)
+		mockGraphDB = graphMocks.NewMockDatabase(mockCtrl)
+		mockTx      = graphMocks.NewMockTransaction(mockCtrl)
+		mockResult  = graphMocks.NewMockResult(mockCtrl)
+		gq          = queries.NewGraphQuery(mockGraphDB, cache.Cache{}, config.Configuration{})
+		ctx         = (&bhCtx.Context{Timeout: time.Second * 5}).ConstructGoContext()
+		rows        = [][]any{
+			{"a", int64(1)},
+			{"b", int64(2)},
+			{"c", int64(3)},
+		}
+		rowIdx = -1
+	)
+
+	mockGraphDB.EXPECT().ReadTransaction(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, txDelegate graph.TransactionDelegate, options ...graph.TransactionOption) error {
+		return txDelegate(mockTx)
+	}).Times(2)
+
+	mockTx.EXPECT().Query(gomock.Any(), gomock.Any()).Return(mockResult).Times(2)
+	mockResult.EXPECT().Error().Return(nil).AnyTimes()
+	mockResult.EXPECT().Close().Times(2)
+
+	// Only the rows of the requested page are read but every row is counted
+	mockResult.EXPECT().Next().DoAndReturn(func() bool {
+		rowIdx++
+		return rowIdx < len(rows)
+	}).Times(len(rows) + 1)
+
+	mockResult.EXPECT().Values().DoAndReturn(func() (graph.ValueMapper, error) {
+		return graph.NewValueMapper(rows[rowIdx]), nil
+	}).Times(1)
+
+	table, count, err := gq.RawCypherTableSearch(ctx, "match (n) return n.name, count(n) as total", nil, false, 1, 1)
+	require.Nil(t, err)
+	require.Equal(t, 3, count)
+	require.Equal(t, []model.CypherColumn{{
+		Name: "n.name",
+		Type: model.CypherColumnTypeString,
+	}, {
+		Name: "total",
+		Type: model.CypherColumnTypeNumber,
+	}}, table.Columns)
+	require.Equal(t, [][]any{{"b", int64(2)}}, table.Rows)
+	require.False(t, table.CountCapped)
+
+	// Pages are limited to the maximum number of rows and counting stops at the maximum count. The result has more
+	// rows than the maximum count so the count is flagged as capped.
+	mockResult.EXPECT().Next().Return(true).Times(queries.MaxCypherTableCount + 1)
+	mockResult.EXPECT().Values().DoAndReturn(func() (graph.ValueMapper, error) {
+		return graph.NewValueMapper(rows[0]), nil
+	}).Times(queries.MaxCypherTableRows)
+
+	table, count, err = gq.RawCypherTableSearch(ctx, "match (n) return n.name, count(n) as total", nil, false, 0, 0)
+	require.Nil(t, err)
+	require.Equal(t, queries.MaxCypherTableCount, count)
+	require.Len(t, table.Rows, queries.MaxCypherTableRows)
+	require.True(t, table.CountCapped)
+}
+
+func TestGraphQuery_RawCypherSearch_Parameters(t *testing.T) {
+	var (
+		mockCtrl    = gomock.NewController(t)
+		mockGraphDB = graphMocks.NewMockDatabase(mockCtrl)
+		mockTx      = graphMocks.NewMockTransaction(mockCtrl)
+		mockResult  = graphMocks.NewMockResult(mockCtrl)
+		gq          = queries.NewGraphQuery(mockGraphDB, cache.Cache{}, config.Configuration{})
+		ctx         = (&bhCtx.Context{Timeout: time.Second * 5}).ConstructGoContext()
+	)
+
+	mockGraphDB.EXPECT().ReadTransaction(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, txDelegate graph.TransactionDelegate, options ...graph.TransactionOption) error {
+		return txDelegate(mockTx)
+	})
+
+	// Parameter values are normalized before they are bound to the query
+	mockTx.EXPECT().Query("match (n) where n.objectid in $ids and n.hasspn = $spn return n", map[string]any{
+		"ids": []string{"1234", "5678"},
+		"spn": true,
+	}).Return(mockResult)
+
+	mockResult.EXPECT().Error().Return(nil).AnyTimes()
+	mockResult.EXPECT().Close()
+	mockResult.EXPECT().Next().Return(false)
+
+	_, err := gq.RawCypherSearch(ctx, "match (n) where n.objectid in $ids and n.hasspn = $spn return n", map[string]any{
+		"ids": []any{"1234", "5678"},
+		"spn": true,
+	}, false)
+	require.Nil(t, err)
+
+	// Every parameter referenced by the query must have a value
+	_, err = gq.RawCypherSearch(ctx, "match (n) where n.objectid in $ids return n", map[string]any{}, false)
+	require.True(t, queries.IsQueryError(err))
+	require.ErrorContains(t, err, "no value supplied for query parameter $ids")
+
+	// Parameter values must be of a supported type
+	_, err = gq.RawCypherSearch(ctx, "match (n) where n.objectid = $id return n", map[string]any{
+		"id": map[string]any{"objectid": "1234"},
+	}, false)
+	require.True(t, queries.IsQueryError(err))
+	require.ErrorContains(t, err, "invalid value for parameter id")
+}
+
+type explainingTransaction struct {
+	graph.Transaction
+}
+
+func (s explainingTransaction) Explain(query string, parameters map[string]any) (graph.QueryPlan, error) {
+	return graph.QueryPlan{
+		Statement: "select 1",
+		Plan:      []any{map[string]any{"Plan": "Seq Scan"}},
+	}, nil
+}
+
+func TestGraphQuery_ExplainCypherQuery(t *testing.T) {
+	var (
+		mockCtrl    = gomock.NewController(t)
+		mockGraphDB = graphMocks.NewMockDatabase(mockCtrl)
+		mockTx      = graphMocks.NewMockTransaction(mockCtrl)
+		gq          = queries.NewGraphQuery(mockGraphDB, cache.Cache{}, config.Configuration{})
+		ctx         = (&bhCtx.Context{}).ConstructGoContext()
+		rawCypher   = "match ()-[:HasSession*..]->()-[:MemberOf*..]->() return n"
+	)
+
+	// Drivers that can not explain queries still describe the query
+	mockGraphDB.EXPECT().ReadTransaction(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, txDelegate graph.TransactionDelegate, options ...graph.TransactionOption) error {
+		return txDelegate(mockTx)
+	})
+
+	explanation, err := gq.ExplainCypherQuery(ctx, rawCypher, nil)
+	require.Nil(t, err)
+	require.Equal(t, "match ()-[:HasSession*]->()-[:MemberOf*]->() return n", explanation.Statement)
+	require.Nil(t, explanation.Plan)
+	require.Equal(t, []string{"match", "return"}, explanation.Summary.Clauses)
+	require.Equal(t, 2, explanation.Summary.VariableLengthPatterns)
+	require.False(t, explanation.Complexity.TooComplex)
+	require.True(t, explanation.Complexity.Enforced)
+	require.NotEmpty(t, explanation.Complexity.Contributions)
+
+	// The complexity of the query reduces the 30 minute default timeout by a factor of 3
+	require.Equal(t, (time.Minute * 10).Seconds(), explanation.TimeoutSeconds)
+
+	contributedWeight := float64(0)
+
+	for _, contribution := range explanation.Complexity.Contributions {
+		contributedWeight += contribution.Weight
+	}
+
+	require.Equal(t, explanation.Complexity.Weight, contributedWeight)
+
+	// Drivers that can explain queries return their statement and plan
+	mockGraphDB.EXPECT().ReadTransaction(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, txDelegate graph.TransactionDelegate, options ...graph.TransactionOption) error {
+		return txDelegate(explainingTransaction{
+			Transaction: mockTx,
+		})
+	})
+
+	explanation, err = gq.ExplainCypherQuery(ctx, rawCypher, nil)
+	require.Nil(t, err)
+	require.Equal(t, "select 1", explanation.Statement)
+	require.Equal(t, []any{map[string]any{"Plan": "Seq Scan"}}, explanation.Plan)
+
+	// Queries that are too complex to run are still explained
+	mockGraphDB.EXPECT().ReadTransaction(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	explanation, err = gq.ExplainCypherQuery(ctx, "match (a)-[*..]->(b), (c)-[*..]->(d), (e)-[*..]->(f), (g)-[*..]->(h), (i)-[*..]->(j) return a", nil)
+	require.Nil(t, err)
+	require.True(t, explanation.Complexity.TooComplex)
+	require.Greater(t, explanation.Complexity.Weight, float64(queries.MaxQueryComplexityWeightAllowed))
+}
+
+type mutatingTransaction struct {
+	graph.Transaction
+}
+
+func (s mutatingTransaction) Mutate(query string, parameters map[string]any) (graph.MutationSummary, error) {
+	return graph.MutationSummary{
+		PropertiesSet: len(parameters),
+	}, nil
+}
+
+func TestGraphQuery_RawCypherMutation(t *testing.T) {
+	var (
+		mockCtrl    = gomock.NewController(t)
+		mockGraphDB = graphMocks.NewMockDatabase(mockCtrl)
+		mockTx      = graphMocks.NewMockTransaction(mockCtrl)
+		gq          = queries.NewGraphQuery(mockGraphDB, cache.Cache{}, config.Configuration{})
+		ctx         = (&bhCtx.Context{}).ConstructGoContext()
+		rawCypher   = "match (n) where n.objectid = $id set n.name = $name"
+		parameters  = map[string]any{
+			"id":   "1234",
+			"name": "name",
+		}
+	)
+
+	// Queries without updating clauses are rejected before a transaction is opened
+	_, err := gq.RawCypherMutation(ctx, "match (n) return n", nil, false)
+	require.True(t, queries.IsQueryError(err))
+	require.ErrorContains(t, err, queries.ErrCypherQueryNoUpdates.Error())
+
+	// Drivers that can not summarize mutations fail the transaction
+	mockGraphDB.EXPECT().WriteTransaction(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, txDelegate graph.TransactionDelegate, options ...graph.TransactionOption) error {
+		return txDelegate(mockTx)
+	})
+
+	_, err = gq.RawCypherMutation(ctx, rawCypher, parameters, false)
+	require.ErrorIs(t, err, graph.ErrQueryMutationUnsupported)
+
+	// Mutations return the summary of their changes and commit them
+	mockGraphDB.EXPECT().WriteTransaction(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, txDelegate graph.TransactionDelegate, options ...graph.TransactionOption) error {
+		return txDelegate(mutatingTransaction{
+			Transaction: mockTx,
+		})
+	})
+
+	summary, err := gq.RawCypherMutation(ctx, rawCypher, parameters, false)
+	require.Nil(t, err)
+	require.Equal(t, model.CypherMutationSummary{PropertiesSet: 2}, summary)
+
+	// Dry runs return the summary of their changes without committing them
+	mockGraphDB.EXPECT().WriteTransaction(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, txDelegate graph.TransactionDelegate, options ...graph.TransactionOption) error {
+		if err := txDelegate(mutatingTransaction{
+			Transaction: mockTx,
+		}); err != nil {
+			return err
+		}
+
+		require.Fail(t, "dry run transaction delegate did not return an error")
+		return nil
+	})
+
+	summary, err = gq.RawCypherMutation(ctx, rawCypher, parameters, true)
+	require.Nil(t, err)
+	require.Equal(t, model.CypherMutationSummary{PropertiesSet: 2}, summary)
+}
+
+type propertyKeyListingTransaction struct {
+	graph.Transaction
+}
+
+func (s propertyKeyListingTransaction) PropertyKeys() ([]string, error) {
+	return []string{"observedproperty"}, nil
+}
+
+func TestGraphQuery_CompleteCypherQuery(t *testing.T) {
+	var (
+		mockCtrl    = gomock.NewController(t)
+		mockGraphDB = graphMocks.NewMockDatabase(mockCtrl)
+		mockTx      = graphMocks.NewMockTransaction(mockCtrl)
+		gq          = queries.NewGraphQuery(mockGraphDB, cache.Cache{}, config.Configuration{})
+		ctx         = (&bhCtx.Context{}).ConstructGoContext()
+	)
+
+	completion, err := gq.CompleteCypherQuery(ctx, "match (n:Grou", 13)
+	require.Nil(t, err)
+	require.Equal(t, "node_kind", completion.Target)
+	require.Equal(t, "Grou", completion.Prefix)
+	require.Equal(t, 9, completion.ReplaceFrom)
+	require.Contains(t, completion.Candidates, model.CypherCompletionCandidate{Value: ad.Group.String(), Type: model.CypherCompletionTypeNodeKind})
+
+	for _, candidate := range completion.Candidates {
+		require.True(t, strings.HasPrefix(strings.ToLower(candidate.Value), "grou"))
+	}
+
+	completion, err = gq.CompleteCypherQuery(ctx, "match (n)-[:memberof", 20)
+	require.Nil(t, err)
+	require.Equal(t, []model.CypherCompletionCandidate{
+		{Value: ad.MemberOf.String(), Type: model.CypherCompletionTypeRelationshipKind},
+		{Value: ad.MemberOfLocalGroup.String(), Type: model.CypherCompletionTypeRelationshipKind},
+	}, completion.Candidates)
+
+	completion, err = gq.CompleteCypherQuery(ctx, "match (n) return toL", 20)
+	require.Nil(t, err)
+	require.Equal(t, []model.CypherCompletionCandidate{{Value: "toLower", Type: model.CypherCompletionTypeFunction}}, completion.Candidates)
+
+	completion, err = gq.CompleteCypherQuery(ctx, "match (n) ret", 13)
+	require.Nil(t, err)
+	require.Equal(t, []model.CypherCompletionCandidate{{Value: "RETURN", Type: model.CypherCompletionTypeKeyword}}, completion.Candidates)
+
+	// Property keys are completed from the schema and the graph
+	mockGraphDB.EXPECT().ReadTransaction(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, txDelegate graph.TransactionDelegate, options ...graph.TransactionOption) error {
+		return txDelegate(propertyKeyListingTransaction{
+			Transaction: mockTx,
+		})
+	})
+
+	completion, err = gq.CompleteCypherQuery(ctx, "match (n) where n.o", 19)
+	require.Nil(t, err)
+	require.Equal(t, "property", completion.Target)
+	require.Contains(t, completion.Candidates, model.CypherCompletionCandidate{Value: common.ObjectID.String(), Type: model.CypherCompletionTypeProperty})
+	require.Contains(t, completion.Candidates, model.CypherCompletionCandidate{Value: "observedproperty", Type: model.CypherCompletionTypeProperty})
+
+	_, err = gq.CompleteCypherQuery(ctx, "match (n) return n", 100)
+	require.True(t, queries.IsQueryError(err))
+}
+
+func TestGraphQuery_ValidateCypherQuery(t *testing.T) {
+	var (
+		mockCtrl    = gomock.NewController(t)
+		mockGraphDB = graphMocks.NewMockDatabase(mockCtrl)
+		mockTx      = graphMocks.NewMockTransaction(mockCtrl)
+		gq          = queries.NewGraphQuery(mockGraphDB, cache.Cache{}, config.Configuration{})
+		ctx         = (&bhCtx.Context{}).ConstructGoContext()
+	)
+
+	// Syntax errors are returned with their position
+	validation, err := gq.ValidateCypherQuery(ctx, "match (n:User return n")
+	require.Nil(t, err)
+	require.False(t, validation.Valid)
+	require.NotEmpty(t, validation.Errors)
+	require.Equal(t, 1, validation.Errors[0].Line)
+	require.Equal(t, 14, validation.Errors[0].Column)
+	require.Equal(t, "return", validation.Errors[0].Symbol)
+
+	// Unsupported clauses are errors
+	validation, err = gq.ValidateCypherQuery(ctx, "match (n) delete n")
+	require.Nil(t, err)
+	require.False(t, validation.Valid)
+	require.Equal(t, []model.CypherValidationIssue{{Message: "updating clauses are not supported"}}, validation.Errors)
+
+	// Unknown kinds and property keys are warnings
+	mockGraphDB.EXPECT().ReadTransaction(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, txDelegate graph.TransactionDelegate, options ...graph.TransactionOption) error {
+		return txDelegate(propertyKeyListingTransaction{
+			Transaction: mockTx,
+		})
+	})
+
+	validation, err = gq.ValidateCypherQuery(ctx, "match (n:Usr)-[:MemberOf]->(g:Group) where n.observedproperty = 1 and g.nme = 'group' return n")
+	require.Nil(t, err)
+	require.True(t, validation.Valid)
+	require.Empty(t, validation.Errors)
+	require.Equal(t, []model.CypherValidationIssue{{
+		Symbol:  "Usr",
+		Message: "kind Usr is not defined by the graph schema",
+	}, {
+		Symbol:  "nme",
+		Message: "property nme is not defined by the graph schema or present in the graph",
+	}}, validation.Warnings)
+
+	// Queries that are too complex to run are errors
+	validation, err = gq.ValidateCypherQuery(ctx, "match (a)-[*..]->(b), (c)-[*..]->(d), (e)-[*..]->(f), (g)-[*..]->(h), (i)-[*..]->(j) return a")
+	require.Nil(t, err)
+	require.False(t, validation.Valid)
+	require.Equal(t, []model.CypherValidationIssue{{Message: queries.ErrCypherQueryToComplex.Error()}}, validation.Errors)
+}
+
+// mapStreamEntity maps the nodes and relationships of test rows the way graph drivers map their own entity types
+func mapStreamEntity(rawValue, target any) (bool, error) {
+	switch typedTarget := target.(type) {
+	case *graph.Node:
+		if node, isNode := rawValue.(*graph.Node); isNode {
+			*typedTarget = *node
+			return true, nil
+		}
+
+	case *graph.Relationship:
+		if relationship, isRelationship := rawValue.(*graph.Relationship); isRelationship {
+			*typedTarget = *relationship
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func TestGraphQuery_StreamRawCypherSearch(t *testing.T) {
+	var (
+		mockCtrl    = gomock.NewController(t)
+		mockGraphDB = graphMocks.NewMockDatabase(mockCtrl)
+		mockTx      = graphMocks.NewMockTransaction(mockCtrl)
+		mockResult  = graphMocks.NewMockResult(mockCtrl)
+		gq          = queries.NewGraphQuery(mockGraphDB, cache.Cache{}, config.Configuration{})
+		ctx         = (&bhCtx.Context{Timeout: time.Second * 5}).ConstructGoContext()
+		user        = graph.NewNode(1, graph.NewProperties(), ad.User)
+		group       = graph.NewNode(2, graph.NewProperties(), ad.Group)
+		computer    = graph.NewNode(3, graph.NewProperties(), ad.Computer)
+		memberOf    = graph.NewRelationship(10, 1, 2, graph.NewProperties(), ad.MemberOf)
+		adminTo     = graph.NewRelationship(11, 2, 3, graph.NewProperties(), ad.AdminTo)
+		rows        = [][]any{
+			{user, memberOf, group},
+			{group, adminTo, computer},
+			{user, nil, nil},
+			{computer, "COMPUTER", int64(1)},
+		}
+		rowIdx  = -1
+		records []model.CypherStreamRecord
+		emit    = func(record model.CypherStreamRecord) error {
+			records = append(records, record)
+			return nil
+		}
+	)
+
+	mockGraphDB.EXPECT().ReadTransaction(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, txDelegate graph.TransactionDelegate, options ...graph.TransactionOption) error {
+		return txDelegate(mockTx)
+	}).Times(2)
+
+	mockTx.EXPECT().Query(gomock.Any(), gomock.Any()).Return(mockResult).Times(2)
+	mockResult.EXPECT().Error().Return(nil).AnyTimes()
+	mockResult.EXPECT().Close().Times(2)
+	mockResult.EXPECT().Next().DoAndReturn(func() bool {
+		rowIdx++
+		return rowIdx < len(rows)
+	}).Times(len(rows) + 1)
+
+	mockResult.EXPECT().Values().DoAndReturn(func() (graph.ValueMapper, error) {
+		return graph.NewValueMapper(rows[rowIdx], mapStreamEntity), nil
+	}).Times(len(rows))
+
+	numRows, err := gq.StreamRawCypherSearch(ctx, "match (a)-[r]->(b) return a, r, b", nil, false, 0, emit)
+	require.Nil(t, err)
+	require.Equal(t, len(rows), numRows)
+
+	var recordTypes []model.CypherStreamRecordType
+
+	for _, record := range records {
+		recordTypes = append(recordTypes, record.Type)
+	}
+
+	// Each node and edge is written once and rows that contain other values are written as rows
+	require.Equal(t, []model.CypherStreamRecordType{
+		model.CypherStreamRecordColumns,
+		model.CypherStreamRecordNode,
+		model.CypherStreamRecordEdge,
+		model.CypherStreamRecordNode,
+		model.CypherStreamRecordEdge,
+		model.CypherStreamRecordNode,
+		model.CypherStreamRecordRow,
+	}, recordTypes)
+	require.Equal(t, []string{"a", "r", "b"}, records[0].Columns)
+	require.Equal(t, "1", records[1].Node.ID)
+	require.Equal(t, "1", records[2].Edge.Source)
+	require.Equal(t, "2", records[2].Edge.Target)
+	require.Equal(t, "3", records[5].Node.ID)
+	require.Equal(t, "COMPUTER", records[6].Row[1])
+	require.Equal(t, int64(1), records[6].Row[2])
+
+	// Queries that return more rows than the row limit are stopped
+	records = nil
+	mockResult.EXPECT().Next().Return(true).Times(3)
+	mockResult.EXPECT().Values().DoAndReturn(func() (graph.ValueMapper, error) {
+		return graph.NewValueMapper([]any{user}, mapStreamEntity), nil
+	}).Times(2)
+
+	numRows, err = gq.StreamRawCypherSearch(ctx, "match (n) return n", nil, false, 2, emit)
+	require.True(t, queries.IsQueryError(err))
+	require.ErrorContains(t, err, "cypher query returned more than 2 rows")
+	require.Equal(t, 2, numRows)
+	require.Len(t, records, 2)
+}
+
 func TestQueries_GetEntityObjectIDFromRequestPath(t *testing.T) {
 	req, err := http.NewRequest("GET", "/api/v2/users/S-1-5-21-570004220-2248230615-4072641716-4001/admin-rights", nil)
 	require.Nil(t, err)
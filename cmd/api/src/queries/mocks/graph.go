@@ -25,6 +25,7 @@ import (
 	reflect "reflect"
 
 	graph "github.com/specterops/bloodhound/dawgs/graph"
+	traversal "github.com/specterops/bloodhound/dawgs/traversal"
 	model "github.com/specterops/bloodhound/src/model"
 	queries "github.com/specterops/bloodhound/src/queries"
 	agi "github.com/specterops/bloodhound/src/services/agi"
@@ -68,6 +69,36 @@ func (mr *MockGraphMockRecorder) BatchNodeUpdate(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchNodeUpdate", reflect.TypeOf((*MockGraph)(nil).BatchNodeUpdate), arg0, arg1)
 }
 
+// CompleteCypherQuery mocks base method.
+func (m *MockGraph) CompleteCypherQuery(arg0 context.Context, arg1 string, arg2 int) (model.CypherCompletion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteCypherQuery", arg0, arg1, arg2)
+	ret0, _ := ret[0].(model.CypherCompletion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteCypherQuery indicates an expected call of CompleteCypherQuery.
+func (mr *MockGraphMockRecorder) CompleteCypherQuery(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteCypherQuery", reflect.TypeOf((*MockGraph)(nil).CompleteCypherQuery), arg0, arg1, arg2)
+}
+
+// ExplainCypherQuery mocks base method.
+func (m *MockGraph) ExplainCypherQuery(arg0 context.Context, arg1 string, arg2 map[string]interface{}) (model.CypherExplanation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExplainCypherQuery", arg0, arg1, arg2)
+	ret0, _ := ret[0].(model.CypherExplanation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExplainCypherQuery indicates an expected call of ExplainCypherQuery.
+func (mr *MockGraphMockRecorder) ExplainCypherQuery(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExplainCypherQuery", reflect.TypeOf((*MockGraph)(nil).ExplainCypherQuery), arg0, arg1, arg2)
+}
+
 // FetchNodesByObjectIDs mocks base method.
 func (m *MockGraph) FetchNodesByObjectIDs(arg0 context.Context, arg1 ...string) (graph.NodeSet, error) {
 	m.ctrl.T.Helper()
@@ -198,6 +229,21 @@ func (mr *MockGraphMockRecorder) GetFilteredAndSortedNodes(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilteredAndSortedNodes", reflect.TypeOf((*MockGraph)(nil).GetFilteredAndSortedNodes), arg0, arg1)
 }
 
+// GetKCheapestPaths mocks base method.
+func (m *MockGraph) GetKCheapestPaths(arg0 context.Context, arg1, arg2 string, arg3 graph.Criteria, arg4 bool, arg5 int) ([]traversal.WeightedPath, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKCheapestPaths", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].([]traversal.WeightedPath)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKCheapestPaths indicates an expected call of GetKCheapestPaths.
+func (mr *MockGraphMockRecorder) GetKCheapestPaths(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKCheapestPaths", reflect.TypeOf((*MockGraph)(nil).GetKCheapestPaths), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // GetNodesByKind mocks base method.
 func (m *MockGraph) GetNodesByKind(arg0 context.Context, arg1 ...graph.Kind) (graph.NodeSet, error) {
 	m.ctrl.T.Helper()
@@ -218,19 +264,50 @@ func (mr *MockGraphMockRecorder) GetNodesByKind(arg0 interface{}, arg1 ...interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodesByKind", reflect.TypeOf((*MockGraph)(nil).GetNodesByKind), varargs...)
 }
 
+// RawCypherMutation mocks base method.
+func (m *MockGraph) RawCypherMutation(arg0 context.Context, arg1 string, arg2 map[string]interface{}, arg3 bool) (model.CypherMutationSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RawCypherMutation", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(model.CypherMutationSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RawCypherMutation indicates an expected call of RawCypherMutation.
+func (mr *MockGraphMockRecorder) RawCypherMutation(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RawCypherMutation", reflect.TypeOf((*MockGraph)(nil).RawCypherMutation), arg0, arg1, arg2, arg3)
+}
+
 // RawCypherSearch mocks base method.
-func (m *MockGraph) RawCypherSearch(arg0 context.Context, arg1 string, arg2 bool) (model.UnifiedGraph, error) {
+func (m *MockGraph) RawCypherSearch(arg0 context.Context, arg1 string, arg2 map[string]interface{}, arg3 bool) (model.UnifiedGraph, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RawCypherSearch", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "RawCypherSearch", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(model.UnifiedGraph)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // RawCypherSearch indicates an expected call of RawCypherSearch.
-func (mr *MockGraphMockRecorder) RawCypherSearch(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockGraphMockRecorder) RawCypherSearch(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RawCypherSearch", reflect.TypeOf((*MockGraph)(nil).RawCypherSearch), arg0, arg1, arg2, arg3)
+}
+
+// RawCypherTableSearch mocks base method.
+func (m *MockGraph) RawCypherTableSearch(arg0 context.Context, arg1 string, arg2 map[string]interface{}, arg3 bool, arg4, arg5 int) (model.CypherTable, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RawCypherTableSearch", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(model.CypherTable)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RawCypherTableSearch indicates an expected call of RawCypherTableSearch.
+func (mr *MockGraphMockRecorder) RawCypherTableSearch(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RawCypherSearch", reflect.TypeOf((*MockGraph)(nil).RawCypherSearch), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RawCypherTableSearch", reflect.TypeOf((*MockGraph)(nil).RawCypherTableSearch), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
 // SearchByNameOrObjectID mocks base method.
@@ -263,6 +340,21 @@ func (mr *MockGraphMockRecorder) SearchNodesByName(arg0, arg1, arg2, arg3, arg4
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchNodesByName", reflect.TypeOf((*MockGraph)(nil).SearchNodesByName), arg0, arg1, arg2, arg3, arg4)
 }
 
+// StreamRawCypherSearch mocks base method.
+func (m *MockGraph) StreamRawCypherSearch(arg0 context.Context, arg1 string, arg2 map[string]interface{}, arg3 bool, arg4 int, arg5 queries.CypherStreamFunc) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamRawCypherSearch", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StreamRawCypherSearch indicates an expected call of StreamRawCypherSearch.
+func (mr *MockGraphMockRecorder) StreamRawCypherSearch(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamRawCypherSearch", reflect.TypeOf((*MockGraph)(nil).StreamRawCypherSearch), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // UpdateSelectorTags mocks base method.
 func (m *MockGraph) UpdateSelectorTags(arg0 context.Context, arg1 agi.AgiData, arg2 model.UpdatedAssetGroupSelectors) error {
 	m.ctrl.T.Helper()
@@ -277,6 +369,21 @@ func (mr *MockGraphMockRecorder) UpdateSelectorTags(arg0, arg1, arg2 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSelectorTags", reflect.TypeOf((*MockGraph)(nil).UpdateSelectorTags), arg0, arg1, arg2)
 }
 
+// ValidateCypherQuery mocks base method.
+func (m *MockGraph) ValidateCypherQuery(arg0 context.Context, arg1 string) (model.CypherValidation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateCypherQuery", arg0, arg1)
+	ret0, _ := ret[0].(model.CypherValidation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateCypherQuery indicates an expected call of ValidateCypherQuery.
+func (mr *MockGraphMockRecorder) ValidateCypherQuery(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateCypherQuery", reflect.TypeOf((*MockGraph)(nil).ValidateCypherQuery), arg0, arg1)
+}
+
 // ValidateOUs mocks base method.
 func (m *MockGraph) ValidateOUs(arg0 context.Context, arg1 []string) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,190 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+// +build integration
+
+package queries_test
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/specterops/bloodhound/cache"
+	"github.com/specterops/bloodhound/dawgs/drivers/neo4j"
+	"github.com/specterops/bloodhound/dawgs/drivers/pg"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/query"
+	"github.com/specterops/bloodhound/graphschema/ad"
+	"github.com/specterops/bloodhound/graphschema/common"
+	"github.com/specterops/bloodhound/src/config"
+	"github.com/specterops/bloodhound/src/queries"
+	"github.com/specterops/bloodhound/src/test/integration"
+	"github.com/stretchr/testify/require"
+)
+
+type shortestPathFixtureEdge struct {
+	start string
+	end   string
+	kind  graph.Kind
+}
+
+var (
+	shortestPathFixtureNodes = map[string]graph.Kind{
+		"USER1":  ad.User,
+		"USER2":  ad.User,
+		"USER3":  ad.User,
+		"GROUP1": ad.Group,
+		"GROUP2": ad.Group,
+		"COMP1":  ad.Computer,
+		"DA":     ad.Group,
+	}
+
+	shortestPathFixtureEdges = []shortestPathFixtureEdge{
+		{start: "USER1", end: "GROUP1", kind: ad.MemberOf},
+		{start: "USER1", end: "GROUP2", kind: ad.MemberOf},
+		{start: "GROUP1", end: "COMP1", kind: ad.AdminTo},
+		{start: "GROUP2", end: "COMP1", kind: ad.AdminTo},
+		{start: "COMP1", end: "USER2", kind: ad.HasSession},
+		{start: "COMP1", end: "USER3", kind: ad.HasSession},
+		{start: "USER2", end: "DA", kind: ad.MemberOf},
+		{start: "USER3", end: "DA", kind: ad.MemberOf},
+		{start: "USER1", end: "DA", kind: ad.GenericAll},
+	}
+)
+
+func setupShortestPathFixture(t *testing.T, db graph.Database) {
+	require.Nil(t, db.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		return tx.Nodes().Delete()
+	}))
+
+	require.Nil(t, db.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		nodeIDs := map[string]graph.ID{}
+
+		for objectID, kind := range shortestPathFixtureNodes {
+			if node, err := tx.CreateNode(graph.AsProperties(graph.PropertyMap{
+				common.ObjectID: objectID,
+				common.Name:     objectID,
+			}), ad.Entity, kind); err != nil {
+				return err
+			} else {
+				nodeIDs[objectID] = node.ID
+			}
+		}
+
+		for _, edge := range shortestPathFixtureEdges {
+			if _, err := tx.CreateRelationshipByIDs(nodeIDs[edge.start], nodeIDs[edge.end], edge.kind, graph.NewProperties()); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}))
+}
+
+// pathSignatures renders each path as a string of object IDs and relationship kinds so that paths can be compared
+// between drivers that assign different database IDs
+func pathSignatures(t *testing.T, paths graph.PathSet) []string {
+	signatures := make([]string, 0, paths.Len())
+
+	for _, path := range paths {
+		builder := strings.Builder{}
+
+		for idx, node := range path.Nodes {
+			objectID, err := node.Properties.Get(common.ObjectID.String()).String()
+			require.Nil(t, err)
+
+			if idx > 0 {
+				builder.WriteString("-[" + path.Edges[idx-1].Kind.String() + "]->")
+			}
+
+			builder.WriteString(objectID)
+		}
+
+		signatures = append(signatures, builder.String())
+	}
+
+	sort.Strings(signatures)
+	return signatures
+}
+
+func TestGetAllShortestPaths_DriverParity(t *testing.T) {
+	var (
+		testCases = []struct {
+			name              string
+			start             string
+			end               string
+			filter            graph.Criteria
+			expectedPathCount int
+		}{{
+			name:              "Direct Relationship",
+			start:             "USER1",
+			end:               "DA",
+			expectedPathCount: 1,
+		}, {
+			name:              "Relationship Kind Filter",
+			start:             "USER1",
+			end:               "DA",
+			filter:            query.KindIn(query.Relationship(), ad.MemberOf, ad.AdminTo, ad.HasSession),
+			expectedPathCount: 4,
+		}, {
+			name:              "Partial Path",
+			start:             "GROUP2",
+			end:               "USER3",
+			expectedPathCount: 1,
+		}, {
+			name:              "Respects Direction",
+			start:             "DA",
+			end:               "USER1",
+			expectedPathCount: 0,
+		}, {
+			name:              "Filter Excludes All Paths",
+			start:             "USER1",
+			end:               "COMP1",
+			filter:            query.KindIn(query.Relationship(), ad.MemberOf),
+			expectedPathCount: 0,
+		}}
+
+		pgDB    = integration.OpenGraphDBWithDriver(t, pg.DriverName)
+		neo4jDB = integration.OpenGraphDBWithDriver(t, neo4j.DriverName)
+	)
+
+	defer pgDB.Close(context.Background())
+	defer neo4jDB.Close(context.Background())
+
+	setupShortestPathFixture(t, pgDB)
+	setupShortestPathFixture(t, neo4jDB)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			pgPaths, err := queries.NewGraphQuery(pgDB, cache.Cache{}, config.Configuration{}).GetAllShortestPaths(context.Background(), testCase.start, testCase.end, testCase.filter)
+			require.Nil(t, err)
+
+			neo4jPaths, err := queries.NewGraphQuery(neo4jDB, cache.Cache{}, config.Configuration{}).GetAllShortestPaths(context.Background(), testCase.start, testCase.end, testCase.filter)
+			require.Nil(t, err)
+
+			var (
+				pgSignatures    = pathSignatures(t, pgPaths)
+				neo4jSignatures = pathSignatures(t, neo4jPaths)
+			)
+
+			require.Len(t, neo4jSignatures, testCase.expectedPathCount)
+			require.Equal(t, neo4jSignatures, pgSignatures)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package attackpaths
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/specterops/bloodhound/analysis"
+	"github.com/specterops/bloodhound/analysis/findings"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/graphschema/common"
+	"github.com/specterops/bloodhound/log"
+	"github.com/specterops/bloodhound/src/model"
+)
+
+type AttackPathData interface {
+	ReplaceAttackPathFindings(ctx context.Context, findings model.AttackPathFindings) error
+}
+
+// SaveAttackPathFindings evaluates the attack path finding catalog against every domain and tenant and stores each
+// impacted principal.
+func SaveAttackPathFindings(ctx context.Context, db AttackPathData, graphDB graph.Database) error {
+	defer log.Measure(log.LevelInfo, "Saved attack path findings")()
+
+	if results, err := findings.Evaluate(ctx, graphDB); err != nil {
+		return fmt.Errorf("could not evaluate attack path findings: %w", err)
+	} else if err := db.ReplaceAttackPathFindings(ctx, toModel(results)); err != nil {
+		return fmt.Errorf("could not save attack path findings: %w", err)
+	}
+
+	return nil
+}
+
+func toModel(results []findings.Finding) model.AttackPathFindings {
+	entries := make(model.AttackPathFindings, 0, len(results))
+
+	for _, result := range results {
+		if environmentID, err := findings.EnvironmentID(result.Environment); err != nil {
+			log.Warnf("Environment node %d does not have a valid identifier; skipping attack path finding", result.Environment.ID)
+		} else if objectID, err := result.Principal.Properties.Get(common.ObjectID.String()).String(); err != nil {
+			log.Errorf("Node %d that does not have valid %s property", result.Principal.ID, common.ObjectID)
+		} else if name, err := result.Principal.Properties.GetOrDefault(common.Name.String(), objectID).String(); err != nil {
+			log.Errorf("Node %d that does not have valid %s property", result.Principal.ID, common.Name)
+		} else {
+			entries = append(entries, model.AttackPathFinding{
+				FindingType:       result.Type.Name,
+				EnvironmentID:     environmentID,
+				PrincipalObjectID: objectID,
+				PrincipalName:     name,
+				PrincipalKind:     analysis.GetNodeKindDisplayLabel(result.Principal),
+				Severity:          string(result.Type.Severity),
+			})
+		}
+	}
+
+	return entries
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/specterops/bloodhound/src/queries"
 
 	"github.com/specterops/bloodhound/cache"
+	"github.com/specterops/bloodhound/dawgs/changefeed"
 	"github.com/specterops/bloodhound/dawgs/graph"
 	"github.com/specterops/bloodhound/src/api"
 	"github.com/specterops/bloodhound/src/api/registration"
@@ -40,6 +41,8 @@ import (
 	"github.com/specterops/bloodhound/src/daemons/gc"
 	"github.com/specterops/bloodhound/src/database"
 	"github.com/specterops/bloodhound/src/model/appcfg"
+	"github.com/specterops/bloodhound/src/services/graphchange"
+	"github.com/specterops/bloodhound/src/services/graphindex"
 )
 
 // ConnectPostgres initializes a connection to PG, and returns errors if any
@@ -57,7 +60,7 @@ func ConnectDatabases(ctx context.Context, cfg config.Configuration) (bootstrap.
 
 	if db, err := ConnectPostgres(cfg); err != nil {
 		return connections, err
-	} else if graphDB, err := bootstrap.ConnectGraph(ctx, cfg); err != nil {
+	} else if graphDB, err := bootstrap.ConnectGraph(ctx, cfg, graphChangeSinks(cfg, db)...); err != nil {
 		return connections, err
 	} else {
 		connections.RDMS = db
@@ -67,11 +70,42 @@ func ConnectDatabases(ctx context.Context, cfg config.Configuration) (bootstrap.
 	}
 }
 
+// graphChangeSinks returns the change feed sinks that committed graph mutations are published to
+func graphChangeSinks(cfg config.Configuration, db *database.BloodhoundDB) []changefeed.Sink {
+	if !cfg.EnableGraphChangeFeed {
+		return nil
+	}
+
+	return []changefeed.Sink{graphchange.NewOutbox(db, graphchange.DefaultQueueSize), changefeed.NewFeed()}
+}
+
+// graphChangeDaemons returns the daemons that write the outbox and consume the in-process change feed of the given
+// graph, if there are any
+func graphChangeDaemons(graphDB graph.Database, graphQueryCache cache.Cache) []daemons.Daemon {
+	var graphChangeDaemons []daemons.Daemon
+
+	if changeFeed, found := changefeed.Find(graphDB); found {
+		for _, sink := range changeFeed.Sinks() {
+			if outbox, isOutbox := sink.(*graphchange.Outbox); isOutbox {
+				graphChangeDaemons = append(graphChangeDaemons, outbox)
+			}
+		}
+
+		if feed, found := changeFeed.Feed(); found {
+			graphChangeDaemons = append(graphChangeDaemons, graphchange.NewCacheInvalidator(feed, graphQueryCache))
+		}
+	}
+
+	return graphChangeDaemons
+}
+
 func Entrypoint(ctx context.Context, cfg config.Configuration, connections bootstrap.DatabaseConnections[*database.BloodhoundDB, *graph.DatabaseSwitch]) ([]daemons.Daemon, error) {
 	if !cfg.DisableMigrations {
 		if err := bootstrap.MigrateDB(ctx, cfg, connections.RDMS); err != nil {
 			return nil, fmt.Errorf("rdms migration error: %w", err)
-		} else if err := bootstrap.MigrateGraph(ctx, connections.Graph, schema.DefaultGraphSchema()); err != nil {
+		} else if graphSchema, err := graphindex.Schema(ctx, connections.RDMS); err != nil {
+			return nil, fmt.Errorf("graph schema error: %w", err)
+		} else if err := bootstrap.MigrateGraph(ctx, connections.Graph, graphSchema); err != nil {
 			return nil, fmt.Errorf("graph migration error: %w", err)
 		}
 	} else if err := connections.Graph.SetDefaultGraph(ctx, schema.DefaultGraph()); err != nil {
@@ -86,6 +120,8 @@ func Entrypoint(ctx context.Context, cfg config.Configuration, connections boots
 		return nil, fmt.Errorf("failed to create in-memory cache for graph queries: %w", err)
 	} else if collectorManifests, err := cfg.SaveCollectorManifests(); err != nil {
 		return nil, fmt.Errorf("failed to save collector manifests: %w", err)
+	} else if graphSchema, err := graphindex.Schema(ctx, connections.RDMS); err != nil {
+		return nil, fmt.Errorf("graph schema error: %w", err)
 	} else {
 		var (
 			graphQuery     = queries.NewGraphQuery(connections.Graph, graphQueryCache, cfg)
@@ -93,10 +129,11 @@ func Entrypoint(ctx context.Context, cfg config.Configuration, connections boots
 			routerInst     = router.NewRouter(cfg, auth.NewAuthorizer(connections.RDMS), bootstrap.ContentSecurityPolicy)
 			ctxInitializer = database.NewContextInitializer(connections.RDMS)
 			authenticator  = api.NewAuthenticator(cfg, connections.RDMS, ctxInitializer)
+			indexDaemon    = graphindex.NewDaemon(connections.RDMS, connections.Graph)
 		)
 
 		registration.RegisterFossGlobalMiddleware(&routerInst, cfg, connections.RDMS, auth.NewIdentityResolver(), authenticator)
-		registration.RegisterFossRoutes(&routerInst, cfg, connections.RDMS, connections.Graph, graphQuery, apiCache, collectorManifests, authenticator, datapipeDaemon)
+		registration.RegisterFossRoutes(&routerInst, cfg, connections.RDMS, connections.Graph, graphQuery, apiCache, collectorManifests, authenticator, datapipeDaemon, indexDaemon)
 
 		// Set neo4j batch and flush sizes
 		neo4jParameters := appcfg.GetNeo4jParameters(ctx, connections.RDMS)
@@ -106,11 +143,12 @@ func Entrypoint(ctx context.Context, cfg config.Configuration, connections boots
 		// Trigger analysis on first start
 		datapipeDaemon.RequestAnalysis()
 
-		return []daemons.Daemon{
+		return append([]daemons.Daemon{
 			bhapi.NewDaemon(cfg, routerInst.Handler()),
-			toolapi.NewDaemon(ctx, connections, cfg, schema.DefaultGraphSchema()),
+			toolapi.NewDaemon(ctx, connections, cfg, graphSchema),
 			gc.NewDataPruningDaemon(connections.RDMS),
 			datapipeDaemon,
-		}, nil
+			indexDaemon,
+		}, graphChangeDaemons(connections.Graph, graphQueryCache)...), nil
 	}
 }
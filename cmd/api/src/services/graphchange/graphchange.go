@@ -0,0 +1,248 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate go run go.uber.org/mock/mockgen -copyright_file=../../../../../LICENSE.header -destination=./mocks/mock.go -package=mocks . GraphChangeData
+package graphchange
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/specterops/bloodhound/cache"
+	"github.com/specterops/bloodhound/dawgs/changefeed"
+	"github.com/specterops/bloodhound/log"
+	"github.com/specterops/bloodhound/src/model"
+)
+
+const (
+	// MaxChangesPerEvent bounds the size of a single outbox row. Large commits, such as those made by ingest and
+	// analysis, are split across several consecutive outbox events.
+	MaxChangesPerEvent = 1000
+
+	// MaxWriteAttempts is the number of times the outbox attempts to persist the events of a single commit before the
+	// commit's changes are dropped from the outbox
+	MaxWriteAttempts = 5
+
+	// DefaultWriteRetryInterval is the time waited before the first retry of a failed outbox write. The interval
+	// doubles with each subsequent attempt.
+	DefaultWriteRetryInterval = 500 * time.Millisecond
+
+	// DefaultQueueSize is the number of commits whose events may wait to be written to the outbox. The changes of
+	// commits made while the queue is full are dropped from the outbox.
+	DefaultQueueSize = 1024
+
+	// cacheInvalidatorBuffer is the number of events the cache invalidator may fall behind the feed before events are
+	// dropped. Dropped events are safe to lose since the invalidator still resets the cache for the events it does
+	// receive.
+	cacheInvalidatorBuffer = 64
+)
+
+var ErrOutboxQueueFull = errors.New("graph change outbox queue is full")
+
+type GraphChangeData interface {
+	AppendGraphChangeEvents(ctx context.Context, events model.GraphChangeEvents) error
+}
+
+// outboxWrite is the set of outbox events made from a single commit along with the context of the commit
+type outboxWrite struct {
+	ctx    context.Context
+	events model.GraphChangeEvents
+}
+
+// Outbox is a change feed sink and daemon that persists graph change events to the database so that they may be
+// consumed by readers outside of this process. The committing goroutine only queues the events of a commit; the daemon
+// writes them to the database so that commits never wait on the outbox. The outbox is not written to within the graph
+// transaction, so events still queued when the process exits are lost.
+type Outbox struct {
+	db            GraphChangeData
+	retryInterval time.Duration
+	queue         chan outboxWrite
+	stopOnce      sync.Once
+	stopC         chan struct{}
+	doneC         chan struct{}
+}
+
+func NewOutbox(db GraphChangeData, queueSize int) *Outbox {
+	return &Outbox{
+		db:            db,
+		retryInterval: DefaultWriteRetryInterval,
+		queue:         make(chan outboxWrite, queueSize),
+		stopC:         make(chan struct{}),
+		doneC:         make(chan struct{}),
+	}
+}
+
+// SetRetryInterval sets the time waited before the first retry of a failed outbox write
+func (s *Outbox) SetRetryInterval(interval time.Duration) {
+	s.retryInterval = interval
+}
+
+// Write queues the events of the given commit for the daemon to persist. Write never blocks; if the queue is full the
+// events are dropped and ErrOutboxQueueFull is returned.
+func (s *Outbox) Write(ctx context.Context, event changefeed.Event) error {
+	events := make(model.GraphChangeEvents, 0, len(event.Changes)/MaxChangesPerEvent+1)
+
+	for start := 0; start < len(event.Changes); start += MaxChangesPerEvent {
+		end := min(start+MaxChangesPerEvent, len(event.Changes))
+
+		events = append(events, model.GraphChangeEvent{
+			Changes:     event.Changes[start:end],
+			CommittedAt: event.CommittedAt,
+		})
+	}
+
+	select {
+	case s.queue <- outboxWrite{ctx: ctx, events: events}:
+		return nil
+
+	default:
+		return fmt.Errorf("%w: dropping %d graph change event(s)", ErrOutboxQueueFull, len(events))
+	}
+}
+
+func (s *Outbox) Name() string {
+	return "Graph Change Outbox"
+}
+
+func (s *Outbox) Start(ctx context.Context) {
+	defer close(s.doneC)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-s.stopC:
+			// Persist what was queued before the daemon was asked to stop
+			for {
+				select {
+				case next := <-s.queue:
+					s.write(ctx, next)
+
+				default:
+					return
+				}
+			}
+
+		case next := <-s.queue:
+			s.write(ctx, next)
+		}
+	}
+}
+
+// Stop asks the daemon to persist the events that are already queued and waits for the daemon to exit
+func (s *Outbox) Stop(ctx context.Context) error {
+	s.stopOnce.Do(func() {
+		close(s.stopC)
+	})
+
+	select {
+	case <-s.doneC:
+		return nil
+
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Outbox) write(ctx context.Context, next outboxWrite) {
+	// The graph changes are already committed so transient failures are retried rather than losing the events. Retries
+	// are abandoned once the daemon is asked to stop.
+	retryInterval := s.retryInterval
+
+	for attempt := 1; attempt <= MaxWriteAttempts; attempt++ {
+		if err := s.db.AppendGraphChangeEvents(next.ctx, next.events); err == nil {
+			return
+		} else if attempt == MaxWriteAttempts {
+			log.Errorf("Failed writing %d graph change event(s) to the outbox after %d attempts: %v", len(next.events), MaxWriteAttempts, err)
+		} else {
+			log.Warnf("Failed writing %d graph change event(s) to the outbox on attempt %d of %d: %v", len(next.events), attempt, MaxWriteAttempts, err)
+
+			select {
+			case <-time.After(retryInterval):
+				retryInterval *= 2
+
+			case <-s.stopC:
+				log.Errorf("Dropping %d graph change event(s) from the outbox on shutdown: %v", len(next.events), err)
+				return
+
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// CacheInvalidator is a daemon that resets the graph query cache each time committed graph changes are published to
+// an in-process change feed so that cached query results never outlive the graph they were computed from.
+type CacheInvalidator struct {
+	subscription *changefeed.Subscription
+	cache        cache.Cache
+	doneC        chan struct{}
+}
+
+func NewCacheInvalidator(feed *changefeed.Feed, cache cache.Cache) *CacheInvalidator {
+	return &CacheInvalidator{
+		subscription: feed.Subscribe(cacheInvalidatorBuffer),
+		cache:        cache,
+		doneC:        make(chan struct{}),
+	}
+}
+
+func (s *CacheInvalidator) Name() string {
+	return "Graph Change Cache Invalidator"
+}
+
+func (s *CacheInvalidator) Start(ctx context.Context) {
+	defer close(s.doneC)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case _, open := <-s.subscription.Events():
+			if !open {
+				return
+			}
+
+			// Events that arrived while the cache was being reset are covered by a single reset
+			for pending := len(s.subscription.Events()); pending > 0; pending-- {
+				<-s.subscription.Events()
+			}
+
+			if err := s.cache.Reset(); err != nil {
+				log.Errorf("Error while resetting the graph query cache after graph changes: %v", err)
+			}
+		}
+	}
+}
+
+// Stop cancels the daemon's subscription to the change feed and waits for the daemon to exit
+func (s *CacheInvalidator) Stop(ctx context.Context) error {
+	s.subscription.Cancel()
+
+	select {
+	case <-s.doneC:
+		return nil
+
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package graphchange_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/specterops/bloodhound/cache"
+	"github.com/specterops/bloodhound/dawgs/changefeed"
+	"github.com/specterops/bloodhound/src/model"
+	"github.com/specterops/bloodhound/src/services/graphchange"
+	"github.com/specterops/bloodhound/src/services/graphchange/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func startOutbox(t *testing.T, outbox *graphchange.Outbox) {
+	ctx, cancel := context.WithCancel(context.Background())
+	go outbox.Start(ctx)
+
+	t.Cleanup(func() {
+		stopCtx, stopCancel := context.WithTimeout(context.Background(), time.Second)
+		defer stopCancel()
+
+		require.Nil(t, outbox.Stop(stopCtx))
+		cancel()
+	})
+}
+
+func TestOutbox_Write(t *testing.T) {
+	var (
+		mockCtrl    = gomock.NewController(t)
+		mockDB      = mocks.NewMockGraphChangeData(mockCtrl)
+		outbox      = graphchange.NewOutbox(mockDB, graphchange.DefaultQueueSize)
+		committedAt = time.Now()
+		changes     = make([]changefeed.Change, graphchange.MaxChangesPerEvent*2+1)
+		writtenC    = make(chan struct{})
+	)
+	defer mockCtrl.Finish()
+
+	mockDB.EXPECT().AppendGraphChangeEvents(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, events model.GraphChangeEvents) error {
+		defer close(writtenC)
+
+		require.Len(t, events, 3)
+		require.Len(t, events[0].Changes, graphchange.MaxChangesPerEvent)
+		require.Len(t, events[1].Changes, graphchange.MaxChangesPerEvent)
+		require.Len(t, events[2].Changes, 1)
+
+		for _, event := range events {
+			require.Equal(t, committedAt, event.CommittedAt)
+		}
+
+		return nil
+	})
+
+	startOutbox(t, outbox)
+
+	require.Nil(t, outbox.Write(context.Background(), changefeed.Event{
+		Changes:     changes,
+		CommittedAt: committedAt,
+	}))
+
+	select {
+	case <-writtenC:
+	case <-time.After(time.Second):
+		t.Fatal("outbox events were not written")
+	}
+}
+
+func TestOutbox_WriteRetries(t *testing.T) {
+	var (
+		mockCtrl  = gomock.NewController(t)
+		mockDB    = mocks.NewMockGraphChangeData(mockCtrl)
+		outbox    = graphchange.NewOutbox(mockDB, graphchange.DefaultQueueSize)
+		errFailed = errors.New("failed")
+		writtenC  = make(chan struct{}, 2)
+		event     = changefeed.Event{
+			Changes: make([]changefeed.Change, 1),
+		}
+	)
+	defer mockCtrl.Finish()
+
+	outbox.SetRetryInterval(time.Millisecond)
+
+	// Transient failures are retried and persistent failures are dropped once all attempts are exhausted
+	gomock.InOrder(
+		mockDB.EXPECT().AppendGraphChangeEvents(gomock.Any(), gomock.Len(1)).Return(errFailed).Times(2),
+		mockDB.EXPECT().AppendGraphChangeEvents(gomock.Any(), gomock.Len(1)).DoAndReturn(func(_ context.Context, _ model.GraphChangeEvents) error {
+			writtenC <- struct{}{}
+			return nil
+		}),
+		mockDB.EXPECT().AppendGraphChangeEvents(gomock.Any(), gomock.Len(1)).Return(errFailed).Times(graphchange.MaxWriteAttempts-1),
+		mockDB.EXPECT().AppendGraphChangeEvents(gomock.Any(), gomock.Len(1)).DoAndReturn(func(_ context.Context, _ model.GraphChangeEvents) error {
+			writtenC <- struct{}{}
+			return errFailed
+		}),
+	)
+
+	startOutbox(t, outbox)
+
+	require.Nil(t, outbox.Write(context.Background(), event))
+	require.Nil(t, outbox.Write(context.Background(), event))
+
+	for written := 0; written < 2; written++ {
+		select {
+		case <-writtenC:
+		case <-time.After(time.Second):
+			t.Fatal("outbox events were not written")
+		}
+	}
+}
+
+func TestOutbox_WriteDoesNotBlock(t *testing.T) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		mockDB   = mocks.NewMockGraphChangeData(mockCtrl)
+		outbox   = graphchange.NewOutbox(mockDB, 1)
+		event    = changefeed.Event{
+			Changes: make([]changefeed.Change, 1),
+		}
+	)
+	defer mockCtrl.Finish()
+
+	// Without a running daemon the queue fills up and further events are dropped rather than blocking the committer
+	require.Nil(t, outbox.Write(context.Background(), event))
+	require.ErrorIs(t, outbox.Write(context.Background(), event), graphchange.ErrOutboxQueueFull)
+
+	// Stopping the daemon persists what was already queued
+	mockDB.EXPECT().AppendGraphChangeEvents(gomock.Any(), gomock.Len(1)).Return(nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	go outbox.Start(ctx)
+	require.Nil(t, outbox.Stop(ctx))
+}
+
+func TestCacheInvalidator(t *testing.T) {
+	var (
+		feed        = changefeed.NewFeed()
+		ctx, cancel = context.WithCancel(context.Background())
+	)
+	defer cancel()
+
+	graphQueryCache, err := cache.NewCache(cache.Config{MaxSize: 10})
+	require.Nil(t, err)
+
+	invalidator := graphchange.NewCacheInvalidator(feed, graphQueryCache)
+	go invalidator.Start(ctx)
+
+	_, _, err = graphQueryCache.Set("query", "result")
+	require.Nil(t, err)
+	require.Equal(t, 1, graphQueryCache.Len())
+
+	require.Nil(t, feed.Write(ctx, changefeed.Event{
+		Changes: make([]changefeed.Change, 1),
+	}))
+
+	require.Eventually(t, func() bool {
+		return graphQueryCache.Len() == 0
+	}, time.Second, time.Millisecond)
+
+	stopCtx, stopCancel := context.WithTimeout(context.Background(), time.Second)
+	defer stopCancel()
+
+	require.Nil(t, invalidator.Stop(stopCtx))
+}
@@ -0,0 +1,383 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate go run go.uber.org/mock/mockgen -copyright_file=../../../../../LICENSE.header -destination=./mocks/mock.go -package=mocks . GraphIndexData,Builder
+package graphindex
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/specterops/bloodhound/dawgs/drivers/neo4j"
+	"github.com/specterops/bloodhound/dawgs/drivers/pg"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/errors"
+	"github.com/specterops/bloodhound/graphschema"
+	"github.com/specterops/bloodhound/log"
+	"github.com/specterops/bloodhound/src/model"
+)
+
+const (
+	// Runtime index names become part of index DDL in both drivers and are therefore restricted. Property names must
+	// be lower case as PostgreSQL folds unquoted index names to lower case.
+	propertyNamePattern = `^[a-z_][a-z0-9_]*$`
+	kindNamePattern     = `^[A-Za-z][A-Za-z0-9_]*$`
+
+	pgBuildProgressStatement = `select coalesce(sum(blocks_done), 0), coalesce(sum(blocks_total), 0) from pg_stat_progress_create_index;`
+	pgUtilizationStatement   = `select table_name, idx_scans, seq_scans, index_usage, rows_in_table from public.index_utilization();`
+	neo4jProgressStatement   = `call db.indexes() yield name, populationPercent;`
+
+	ErrUtilizationNotSupported = errors.Error("index utilization is only available for the PostgreSQL graph driver")
+)
+
+var (
+	propertyNameRegex = regexp.MustCompile(propertyNamePattern)
+	kindNameRegex     = regexp.MustCompile(kindNamePattern)
+)
+
+type GraphIndexData interface {
+	GetGraphPropertyIndexes(ctx context.Context) (model.GraphPropertyIndexes, error)
+	UpdateGraphPropertyIndexStatus(ctx context.Context, ids []int64, status model.GraphPropertyIndexStatus, buildErr string) error
+}
+
+// Builder schedules an asynchronous assertion of the graph schema including all runtime property indexes.
+type Builder interface {
+	RequestBuild()
+}
+
+// Validate checks that a requested runtime index is well-formed and is not already covered by the default graph
+// schema, which indexes its fields for every node kind.
+func Validate(index model.GraphPropertyIndex) error {
+	if !kindNameRegex.MatchString(index.Kind) {
+		return fmt.Errorf("invalid kind %q: kinds must match %s", index.Kind, kindNamePattern)
+	} else if !propertyNameRegex.MatchString(index.Property) {
+		return fmt.Errorf("invalid property %q: properties must match %s", index.Property, propertyNamePattern)
+	} else if index.Index().Type == graph.UnsupportedIndex {
+		return fmt.Errorf("invalid index type %q: expected %s or %s", index.IndexType, graph.BTreeIndex, graph.TextSearchIndex)
+	}
+
+	for _, defaultIndex := range graphschema.DefaultGraph().NodeIndexes {
+		if defaultIndex.Field == index.Property {
+			return fmt.Errorf("property %s is already indexed by the default graph schema", index.Property)
+		}
+	}
+
+	for _, defaultConstraint := range graphschema.DefaultGraph().NodeConstraints {
+		if defaultConstraint.Field == index.Property {
+			return fmt.Errorf("property %s is already constrained by the default graph schema", index.Property)
+		}
+	}
+
+	return nil
+}
+
+// MergeSchema returns a copy of the given schema with the runtime indexes added to its default graph. Runtime indexes
+// only apply to the default graph; the graphs of named workspaces are created with the static graph schema alone.
+func MergeSchema(schema graph.Schema, indexes model.GraphPropertyIndexes) graph.Schema {
+	var (
+		defaultGraph = schema.DefaultGraph
+		nodeIndexes  = make([]graph.Index, 0, len(defaultGraph.NodeIndexes)+len(indexes))
+		graphs       = make([]graph.Graph, len(schema.Graphs))
+	)
+
+	nodeIndexes = append(nodeIndexes, defaultGraph.NodeIndexes...)
+
+	for _, index := range indexes {
+		nodeIndexes = append(nodeIndexes, index.Index())
+	}
+
+	defaultGraph.NodeIndexes = nodeIndexes
+
+	for idx, graphSchema := range schema.Graphs {
+		if graphSchema.Name == defaultGraph.Name {
+			graphs[idx] = defaultGraph
+		} else {
+			graphs[idx] = graphSchema
+		}
+	}
+
+	return graph.Schema{
+		Graphs:       graphs,
+		DefaultGraph: defaultGraph,
+	}
+}
+
+// buildableIndexes returns all persisted runtime indexes except those whose build has failed. Failed indexes are left
+// out of the schema so that they do not fail every subsequent build.
+func buildableIndexes(ctx context.Context, db GraphIndexData) (model.GraphPropertyIndexes, error) {
+	if indexes, err := db.GetGraphPropertyIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("could not fetch graph property indexes: %w", err)
+	} else {
+		buildable := make(model.GraphPropertyIndexes, 0, len(indexes))
+
+		for _, index := range indexes {
+			if index.Status != model.GraphPropertyIndexStatusFailed {
+				buildable = append(buildable, index)
+			}
+		}
+
+		return buildable, nil
+	}
+}
+
+// Schema returns the default graph schema along with all persisted runtime indexes that have not failed to build.
+func Schema(ctx context.Context, db GraphIndexData) (graph.Schema, error) {
+	if indexes, err := buildableIndexes(ctx, db); err != nil {
+		return graph.Schema{}, err
+	} else {
+		return MergeSchema(graphschema.DefaultGraphSchema(), indexes), nil
+	}
+}
+
+// Build asserts the graph schema including all persisted runtime indexes that have not failed to build. Indexes that
+// are still building are added to the schema one at a time so that an index that fails to build is recorded as failed
+// without affecting the others. Indexes that are no longer persisted or that have failed are dropped by the assertion.
+func Build(ctx context.Context, db GraphIndexData, graphDB graph.Database) error {
+	defer log.Measure(log.LevelInfo, "Built graph property indexes")()
+
+	if indexes, err := buildableIndexes(ctx, db); err != nil {
+		return err
+	} else {
+		var (
+			built   = make(model.GraphPropertyIndexes, 0, len(indexes))
+			pending model.GraphPropertyIndexes
+			errs    errors.ErrorCollector
+		)
+
+		for _, index := range indexes {
+			if index.Status == model.GraphPropertyIndexStatusOnline {
+				built = append(built, index)
+			} else {
+				pending = append(pending, index)
+			}
+		}
+
+		if len(pending) == 0 {
+			// Assert the schema regardless so that indexes which are no longer persisted are dropped
+			if err := graphDB.AssertSchema(ctx, MergeSchema(graphschema.DefaultGraphSchema(), built)); err != nil {
+				return fmt.Errorf("could not assert graph schema: %w", err)
+			}
+
+			return nil
+		}
+
+		for _, index := range pending {
+			candidate := append(slices.Clip(built), index)
+
+			if err := graphDB.AssertSchema(ctx, MergeSchema(graphschema.DefaultGraphSchema(), candidate)); err != nil {
+				if updateErr := db.UpdateGraphPropertyIndexStatus(ctx, []int64{index.ID}, model.GraphPropertyIndexStatusFailed, err.Error()); updateErr != nil {
+					log.Errorf("Failed updating graph property index status: %v", updateErr)
+				}
+
+				errs.Collect(fmt.Errorf("could not build index on %s.%s: %w", index.Kind, index.Property, err))
+				continue
+			}
+
+			// The index exists from here on and must remain in the schema of later assertions so that it is not dropped
+			built = candidate
+
+			if err := db.UpdateGraphPropertyIndexStatus(ctx, []int64{index.ID}, model.GraphPropertyIndexStatusOnline, ""); err != nil {
+				errs.Collect(err)
+			}
+		}
+
+		return errs.Return()
+	}
+}
+
+// BuildProgress returns the population percentage of each building index, keyed by index ID. PostgreSQL only reports
+// progress for the index build currently in flight which is attributed to every building index. Graph drivers that do
+// not report index build progress report none.
+func BuildProgress(ctx context.Context, graphDB graph.Database, driverName string, indexes model.GraphPropertyIndexes) (map[int64]float64, error) {
+	var (
+		progress = map[int64]float64{}
+		building = make(model.GraphPropertyIndexes, 0, len(indexes))
+	)
+
+	for _, index := range indexes {
+		if index.Status == model.GraphPropertyIndexStatusBuilding {
+			building = append(building, index)
+		}
+	}
+
+	if len(building) == 0 {
+		return progress, nil
+	}
+
+	return progress, graphDB.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		switch driverName {
+		case pg.DriverName:
+			var (
+				blocksDone  int64
+				blocksTotal int64
+				result      = tx.Raw(pgBuildProgressStatement, nil)
+			)
+
+			defer result.Close()
+
+			if result.Next() {
+				if err := result.Scan(&blocksDone, &blocksTotal); err != nil {
+					return err
+				}
+			}
+
+			for _, index := range building {
+				if blocksTotal > 0 {
+					progress[index.ID] = 100 * float64(blocksDone) / float64(blocksTotal)
+				} else {
+					progress[index.ID] = 0
+				}
+			}
+
+			return result.Error()
+
+		case neo4j.DriverName:
+			var (
+				name               string
+				populationPercent  float64
+				populationsByIndex = map[string]float64{}
+				result             = tx.Raw(neo4jProgressStatement, nil)
+			)
+
+			defer result.Close()
+
+			for result.Next() {
+				if err := result.Scan(&name, &populationPercent); err != nil {
+					return err
+				}
+
+				populationsByIndex[name] = populationPercent
+			}
+
+			for _, index := range building {
+				progress[index.ID] = populationsByIndex[neo4jIndexName(index)]
+			}
+
+			return result.Error()
+
+		default:
+			return nil
+		}
+	})
+}
+
+func neo4jIndexName(index model.GraphPropertyIndex) string {
+	return strings.ToLower(index.Kind) + "_" + strings.ToLower(index.Property) + "_index"
+}
+
+// TableIndexUtilization is a row of the PostgreSQL index_utilization() report.
+type TableIndexUtilization struct {
+	TableName   string `json:"table_name"`
+	IndexScans  int64  `json:"idx_scans"`
+	TableScans  int64  `json:"seq_scans"`
+	IndexUsage  int64  `json:"index_usage"`
+	RowsInTable int64  `json:"rows_in_table"`
+}
+
+// Utilization reports how often the tables with the most scans were read using an index rather than a sequential
+// scan.
+func Utilization(ctx context.Context, graphDB graph.Database, driverName string) ([]TableIndexUtilization, error) {
+	if driverName != pg.DriverName {
+		return nil, ErrUtilizationNotSupported
+	}
+
+	var utilization []TableIndexUtilization
+
+	return utilization, graphDB.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		result := tx.Raw(pgUtilizationStatement, nil)
+		defer result.Close()
+
+		for result.Next() {
+			var next TableIndexUtilization
+
+			if err := result.Scan(&next.TableName, &next.IndexScans, &next.TableScans, &next.IndexUsage, &next.RowsInTable); err != nil {
+				return err
+			}
+
+			utilization = append(utilization, next)
+		}
+
+		return result.Error()
+	})
+}
+
+// Daemon serializes runtime index builds. Build requests that arrive while a build is in flight are coalesced into a
+// single follow-up build.
+type Daemon struct {
+	db       GraphIndexData
+	graphDB  graph.Database
+	requestC chan struct{}
+	stopOnce sync.Once
+	stopC    chan struct{}
+	doneC    chan struct{}
+}
+
+func NewDaemon(db GraphIndexData, graphDB graph.Database) *Daemon {
+	return &Daemon{
+		db:       db,
+		graphDB:  graphDB,
+		requestC: make(chan struct{}, 1),
+		stopC:    make(chan struct{}),
+		doneC:    make(chan struct{}),
+	}
+}
+
+func (s *Daemon) Name() string {
+	return "Graph Index Daemon"
+}
+
+func (s *Daemon) RequestBuild() {
+	select {
+	case s.requestC <- struct{}{}:
+	default:
+		// A build is already pending and will pick up this request's changes
+	}
+}
+
+func (s *Daemon) Start(ctx context.Context) {
+	defer close(s.doneC)
+
+	for {
+		select {
+		case <-s.requestC:
+			if err := Build(ctx, s.db, s.graphDB); err != nil {
+				log.Errorf("Graph property index build failed: %v", err)
+			}
+
+		case <-s.stopC:
+			return
+		}
+	}
+}
+
+// Stop signals the daemon to exit and waits for any in-flight build to finish or for the given context to expire.
+func (s *Daemon) Stop(ctx context.Context) error {
+	s.stopOnce.Do(func() {
+		close(s.stopC)
+	})
+
+	select {
+	case <-s.doneC:
+		return nil
+
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
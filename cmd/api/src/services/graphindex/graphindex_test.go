@@ -0,0 +1,208 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package graphindex_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/specterops/bloodhound/dawgs"
+	"github.com/specterops/bloodhound/dawgs/drivers/memory"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	graph_mocks "github.com/specterops/bloodhound/dawgs/graph/mocks"
+	"github.com/specterops/bloodhound/graphschema"
+	"github.com/specterops/bloodhound/src/model"
+	"github.com/specterops/bloodhound/src/services/graphindex"
+	"github.com/specterops/bloodhound/src/services/graphindex/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newIndex(id int64, kind, property, indexType string, status model.GraphPropertyIndexStatus) model.GraphPropertyIndex {
+	index := model.GraphPropertyIndex{
+		Kind:      kind,
+		Property:  property,
+		IndexType: indexType,
+		Status:    status,
+	}
+
+	index.ID = id
+	return index
+}
+
+func TestMergeSchema(t *testing.T) {
+	var (
+		defaultSchema = graphschema.DefaultGraphSchema()
+		numIndexes    = len(defaultSchema.DefaultGraph.NodeIndexes)
+		merged        = graphindex.MergeSchema(defaultSchema, model.GraphPropertyIndexes{
+			newIndex(1, "User", "department", "fts", model.GraphPropertyIndexStatusBuilding),
+		})
+	)
+
+	require.Len(t, defaultSchema.DefaultGraph.NodeIndexes, numIndexes)
+	require.Len(t, merged.DefaultGraph.NodeIndexes, numIndexes+1)
+	require.Equal(t, graph.Index{
+		Field: "department",
+		Type:  graph.TextSearchIndex,
+		Kind:  graph.StringKind("User"),
+	}, merged.DefaultGraph.NodeIndexes[numIndexes])
+
+	require.Len(t, merged.Graphs, 1)
+	require.Equal(t, merged.DefaultGraph, merged.Graphs[0])
+}
+
+func TestValidate(t *testing.T) {
+	require.Nil(t, graphindex.Validate(newIndex(0, "User", "department", "btree", "")))
+	require.ErrorContains(t, graphindex.Validate(newIndex(0, "User", "Department", "btree", "")), "invalid property")
+	require.ErrorContains(t, graphindex.Validate(newIndex(0, "User Kind", "department", "btree", "")), "invalid kind")
+	require.ErrorContains(t, graphindex.Validate(newIndex(0, "User", "department", "gist", "")), "invalid index type")
+	require.ErrorContains(t, graphindex.Validate(newIndex(0, "User", "objectid", "btree", "")), "already constrained")
+}
+
+func TestBuild(t *testing.T) {
+	var (
+		mockCtrl    = gomock.NewController(t)
+		mockData    = mocks.NewMockGraphIndexData(mockCtrl)
+		mockGraphDB = graph_mocks.NewMockDatabase(mockCtrl)
+		indexes     = model.GraphPropertyIndexes{
+			newIndex(1, "User", "department", "btree", model.GraphPropertyIndexStatusOnline),
+			newIndex(2, "User", "owned", "btree", model.GraphPropertyIndexStatusBuilding),
+			newIndex(3, "Computer", "owned", "btree", model.GraphPropertyIndexStatusFailed),
+		}
+	)
+	defer mockCtrl.Finish()
+
+	t.Run("Success", func(t *testing.T) {
+		mockData.EXPECT().GetGraphPropertyIndexes(gomock.Any()).Return(indexes, nil)
+		mockGraphDB.EXPECT().AssertSchema(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, schema graph.Schema) error {
+			// The failed index must be left out of the schema
+			require.Len(t, schema.DefaultGraph.NodeIndexes, len(graphschema.DefaultGraph().NodeIndexes)+2)
+
+			for _, index := range schema.DefaultGraph.NodeIndexes {
+				require.NotEqual(t, graph.StringKind("Computer"), index.Kind)
+			}
+
+			return nil
+		})
+		mockData.EXPECT().UpdateGraphPropertyIndexStatus(gomock.Any(), []int64{2}, model.GraphPropertyIndexStatusOnline, "").Return(nil)
+
+		require.Nil(t, graphindex.Build(context.Background(), mockData, mockGraphDB))
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		var (
+			assertions      = 0
+			pendingIndexes  = append(indexes, newIndex(4, "Group", "owned", "btree", model.GraphPropertyIndexStatusBuilding))
+			numIndexes      = len(graphschema.DefaultGraph().NodeIndexes)
+			hasIndexForKind = func(schema graph.Schema, kind string) bool {
+				for _, index := range schema.DefaultGraph.NodeIndexes {
+					if index.Kind == graph.StringKind(kind) {
+						return true
+					}
+				}
+
+				return false
+			}
+		)
+
+		// Each pending index is built on its own and only the index that failed to build is marked as failed
+		mockData.EXPECT().GetGraphPropertyIndexes(gomock.Any()).Return(pendingIndexes, nil)
+		mockGraphDB.EXPECT().AssertSchema(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, schema graph.Schema) error {
+			if assertions++; assertions == 1 {
+				require.Len(t, schema.DefaultGraph.NodeIndexes, numIndexes+2)
+				require.False(t, hasIndexForKind(schema, "Group"))
+
+				return errors.New("index build failed")
+			}
+
+			// The index that failed to build must be left out of the following assertions
+			require.Len(t, schema.DefaultGraph.NodeIndexes, numIndexes+2)
+			require.True(t, hasIndexForKind(schema, "Group"))
+
+			return nil
+		}).Times(2)
+		mockData.EXPECT().UpdateGraphPropertyIndexStatus(gomock.Any(), []int64{2}, model.GraphPropertyIndexStatusFailed, "index build failed").Return(nil)
+		mockData.EXPECT().UpdateGraphPropertyIndexStatus(gomock.Any(), []int64{4}, model.GraphPropertyIndexStatusOnline, "").Return(nil)
+
+		require.ErrorContains(t, graphindex.Build(context.Background(), mockData, mockGraphDB), "index build failed")
+	})
+
+	t.Run("No Pending Indexes", func(t *testing.T) {
+		// The schema is still asserted so that indexes that are no longer persisted are dropped
+		mockData.EXPECT().GetGraphPropertyIndexes(gomock.Any()).Return(indexes[:1], nil)
+		mockGraphDB.EXPECT().AssertSchema(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, schema graph.Schema) error {
+			require.Len(t, schema.DefaultGraph.NodeIndexes, len(graphschema.DefaultGraph().NodeIndexes)+1)
+			return nil
+		})
+
+		require.Nil(t, graphindex.Build(context.Background(), mockData, mockGraphDB))
+	})
+}
+
+func TestSchema_ExcludesFailedIndexes(t *testing.T) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		mockData = mocks.NewMockGraphIndexData(mockCtrl)
+	)
+	defer mockCtrl.Finish()
+
+	mockData.EXPECT().GetGraphPropertyIndexes(gomock.Any()).Return(model.GraphPropertyIndexes{
+		newIndex(1, "User", "department", "btree", model.GraphPropertyIndexStatusOnline),
+		newIndex(2, "Computer", "owned", "btree", model.GraphPropertyIndexStatusFailed),
+	}, nil)
+
+	schema, err := graphindex.Schema(context.Background(), mockData)
+	require.Nil(t, err)
+	require.Len(t, schema.DefaultGraph.NodeIndexes, len(graphschema.DefaultGraph().NodeIndexes)+1)
+	require.Equal(t, "department", schema.DefaultGraph.NodeIndexes[len(schema.DefaultGraph.NodeIndexes)-1].Field)
+}
+
+func TestBuildProgress_UnsupportedDriver(t *testing.T) {
+	graphDB, err := dawgs.Open(context.Background(), memory.DriverName, dawgs.Config{})
+	require.Nil(t, err)
+
+	progress, err := graphindex.BuildProgress(context.Background(), graphDB, memory.DriverName, model.GraphPropertyIndexes{
+		newIndex(1, "User", "department", "btree", model.GraphPropertyIndexStatusBuilding),
+	})
+
+	require.Nil(t, err)
+	require.Empty(t, progress)
+}
+
+func TestDaemon_Stop(t *testing.T) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		mockData = mocks.NewMockGraphIndexData(mockCtrl)
+		daemon   = graphindex.NewDaemon(mockData, graph_mocks.NewMockDatabase(mockCtrl))
+	)
+	defer mockCtrl.Finish()
+
+	t.Run("Not Started", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		require.ErrorIs(t, daemon.Stop(ctx), context.DeadlineExceeded)
+	})
+
+	t.Run("Started", func(t *testing.T) {
+		go daemon.Start(context.Background())
+
+		require.Nil(t, daemon.Stop(context.Background()))
+	})
+}
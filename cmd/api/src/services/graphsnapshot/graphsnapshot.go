@@ -0,0 +1,55 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate go run go.uber.org/mock/mockgen -copyright_file=../../../../../LICENSE.header -destination=./mocks/mock.go -package=mocks . GraphSnapshotData
+package graphsnapshot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/specterops/bloodhound/analysis/snapshot"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/log"
+	"github.com/specterops/bloodhound/src/model"
+)
+
+// RetainedSnapshots is the number of graph snapshots kept available for diffing
+const RetainedSnapshots = 30
+
+type GraphSnapshotData interface {
+	SaveGraphSnapshot(ctx context.Context, snapshot model.GraphSnapshot, retained int) error
+}
+
+// SaveGraphSnapshot captures a summary of the graph and stores it against the given analysis run
+func SaveGraphSnapshot(ctx context.Context, db GraphSnapshotData, graphDB graph.Database, analysisRunID int64) error {
+	defer log.Measure(log.LevelInfo, "Saved graph snapshot")()
+
+	if captured, err := snapshot.Capture(ctx, graphDB); err != nil {
+		return fmt.Errorf("could not capture graph snapshot: %w", err)
+	} else if content, err := snapshot.Encode(captured); err != nil {
+		return fmt.Errorf("could not encode graph snapshot: %w", err)
+	} else if err := db.SaveGraphSnapshot(ctx, model.GraphSnapshot{
+		AnalysisRunID: analysisRunID,
+		NodeCount:     len(captured.Nodes),
+		EdgeCount:     len(captured.Edges),
+		Content:       content,
+	}, RetainedSnapshots); err != nil {
+		return fmt.Errorf("could not save graph snapshot: %w", err)
+	}
+
+	return nil
+}
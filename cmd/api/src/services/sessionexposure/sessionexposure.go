@@ -0,0 +1,149 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate go run go.uber.org/mock/mockgen -copyright_file=../../../../../LICENSE.header -destination=./mocks/mock.go -package=mocks . SessionExposureData
+package sessionexposure
+
+import (
+	"context"
+	"fmt"
+
+	adAnalysis "github.com/specterops/bloodhound/analysis/ad"
+	"github.com/specterops/bloodhound/dawgs/cardinality"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/ops"
+	"github.com/specterops/bloodhound/dawgs/query"
+	"github.com/specterops/bloodhound/graphschema/ad"
+	"github.com/specterops/bloodhound/graphschema/common"
+	"github.com/specterops/bloodhound/log"
+	"github.com/specterops/bloodhound/src/model"
+	"github.com/specterops/bloodhound/src/model/appcfg"
+)
+
+type SessionExposureData interface {
+	appcfg.ParameterService
+
+	GetAllAssetGroups(ctx context.Context, order string, filter model.SQLFilter) (model.AssetGroups, error)
+	ReplacePrivilegedSessionExposures(ctx context.Context, exposures model.PrivilegedSessionExposures) error
+}
+
+// SavePrivilegedSessionExposures finds every computer outside of Tier Zero holding a session of a user in the
+// configured asset group and stores the exposures, along with the number of principals that can reach each computer.
+func SavePrivilegedSessionExposures(ctx context.Context, db SessionExposureData, graphDB graph.Database) error {
+	defer log.Measure(log.LevelInfo, "Saved privileged session exposures")()
+
+	var (
+		parameters  = appcfg.GetSessionExposureParameters(ctx, db)
+		tagProperty = common.SystemTags.String()
+	)
+
+	if assetGroups, err := db.GetAllAssetGroups(ctx, "", model.SQLFilter{}); err != nil {
+		return fmt.Errorf("could not fetch asset groups: %w", err)
+	} else if assetGroup, found := findAssetGroupByTag(assetGroups, parameters.AssetGroupTag); !found {
+		return fmt.Errorf("unable to find an asset group with tag %s", parameters.AssetGroupTag)
+	} else {
+		if !assetGroup.SystemGroup {
+			tagProperty = common.UserTags.String()
+		}
+
+		if groupExpansions, err := adAnalysis.ResolveAllGroupMemberships(ctx, graphDB); err != nil {
+			return fmt.Errorf("could not expand group memberships: %w", err)
+		} else if exposures, err := adAnalysis.FetchPrivilegedSessionExposures(ctx, graphDB, groupExpansions, tagProperty, assetGroup.Tag); err != nil {
+			return fmt.Errorf("could not compute privileged session exposures: %w", err)
+		} else if entries, err := resolveExposures(ctx, graphDB, exposures, assetGroup.Tag); err != nil {
+			return fmt.Errorf("could not resolve privileged session exposures: %w", err)
+		} else if err := db.ReplacePrivilegedSessionExposures(ctx, entries); err != nil {
+			return fmt.Errorf("could not save privileged session exposures: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func findAssetGroupByTag(assetGroups model.AssetGroups, tag string) (model.AssetGroup, bool) {
+	for _, assetGroup := range assetGroups {
+		if assetGroup.Tag == tag {
+			return assetGroup, true
+		}
+	}
+
+	return model.AssetGroup{}, false
+}
+
+func resolveExposures(ctx context.Context, graphDB graph.Database, exposures []adAnalysis.PrivilegedSessionExposure, assetGroupTag string) (model.PrivilegedSessionExposures, error) {
+	var (
+		entries = make(model.PrivilegedSessionExposures, 0, len(exposures))
+		userIDs = cardinality.NewBitmap32()
+	)
+
+	if len(exposures) == 0 {
+		return entries, nil
+	}
+
+	for _, exposure := range exposures {
+		for _, userID := range exposure.Users {
+			userIDs.Add(userID.Uint32())
+		}
+	}
+
+	return entries, graphDB.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		if users, err := ops.FetchNodeSet(tx.Nodes().Filterf(func() graph.Criteria {
+			return query.InIDs(query.NodeID(), cardinality.DuplexToGraphIDs(userIDs)...)
+		})); err != nil {
+			return err
+		} else {
+			for _, exposure := range exposures {
+				computer := exposure.Computer
+
+				if objectID, err := computer.Properties.Get(common.ObjectID.String()).String(); err != nil {
+					log.Errorf("Node %d that does not have valid %s property", computer.ID, common.ObjectID)
+				} else if domainSID, err := computer.Properties.Get(ad.DomainSID.String()).String(); err != nil {
+					log.Warnf("Computer %s does not have a valid %s property; skipping session exposure", objectID, ad.DomainSID)
+				} else {
+					var exposedUsers []string
+
+					// Computers without a valid name are identified by their object ID instead
+					computerName := objectID
+					if name, err := computer.Properties.Get(common.Name.String()).String(); err == nil {
+						computerName = name
+					}
+
+					for _, userID := range exposure.Users {
+						if user := users.Get(userID); user == nil {
+							continue
+						} else if userObjectID, err := user.Properties.Get(common.ObjectID.String()).String(); err != nil {
+							log.Errorf("Node %d that does not have valid %s property", user.ID, common.ObjectID)
+						} else {
+							exposedUsers = append(exposedUsers, userObjectID)
+						}
+					}
+
+					entries = append(entries, model.PrivilegedSessionExposure{
+						DomainSID:               domainSID,
+						ComputerObjectID:        objectID,
+						ComputerName:            computerName,
+						AssetGroupTag:           assetGroupTag,
+						ExposedUserCount:        len(exposedUsers),
+						ExposedUsers:            exposedUsers,
+						ReachablePrincipalCount: int64(exposure.ReachablePrincipals),
+					})
+				}
+			}
+
+			return nil
+		}
+	})
+}
@@ -0,0 +1,97 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sessionexposure_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/specterops/bloodhound/dawgs"
+	"github.com/specterops/bloodhound/dawgs/drivers/memory"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/graphschema/ad"
+	"github.com/specterops/bloodhound/graphschema/common"
+	"github.com/specterops/bloodhound/src/database/mocks"
+	"github.com/specterops/bloodhound/src/model"
+	"github.com/specterops/bloodhound/src/model/appcfg"
+	"github.com/specterops/bloodhound/src/services/sessionexposure"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// newExposureGraph creates a graph where a Tier Zero user has sessions on a named workstation and on a workstation
+// whose name is not a string
+func newExposureGraph(t *testing.T) graph.Database {
+	db, err := dawgs.Open(context.Background(), memory.DriverName, dawgs.Config{})
+	require.Nil(t, err)
+
+	require.Nil(t, db.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		admin, err := tx.CreateNode(graph.AsProperties(map[string]any{
+			common.ObjectID.String():   "S-1-5-21-500",
+			common.SystemTags.String(): ad.AdminTierZero,
+		}), ad.Entity, ad.User)
+		require.Nil(t, err)
+
+		for objectID, name := range map[string]any{
+			"S-1-5-21-1000": "WS1.TESTLAB.LOCAL",
+			"S-1-5-21-1001": 1001,
+		} {
+			computer, err := tx.CreateNode(graph.AsProperties(map[string]any{
+				common.ObjectID.String(): objectID,
+				common.Name.String():     name,
+				ad.DomainSID.String():    "S-1-5-21",
+			}), ad.Entity, ad.Computer)
+			require.Nil(t, err)
+
+			_, err = tx.CreateRelationshipByIDs(computer.ID, admin.ID, ad.HasSession, graph.NewProperties())
+			require.Nil(t, err)
+		}
+
+		return nil
+	}))
+
+	return db
+}
+
+func TestSavePrivilegedSessionExposures(t *testing.T) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		mockDB   = mocks.NewMockDatabase(mockCtrl)
+	)
+
+	mockDB.EXPECT().GetConfigurationParameter(gomock.Any(), appcfg.SessionExposure).Return(appcfg.Parameter{}, nil)
+	mockDB.EXPECT().GetAllAssetGroups(gomock.Any(), "", model.SQLFilter{}).Return(model.AssetGroups{{
+		Name:        model.TierZeroAssetGroupName,
+		Tag:         ad.AdminTierZero,
+		SystemGroup: true,
+	}}, nil)
+
+	// The workstation whose name is not a string is named after its object ID
+	mockDB.EXPECT().ReplacePrivilegedSessionExposures(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, exposures model.PrivilegedSessionExposures) error {
+		var computerNames []string
+
+		for _, exposure := range exposures {
+			require.Equal(t, []string{"S-1-5-21-500"}, []string(exposure.ExposedUsers))
+			computerNames = append(computerNames, exposure.ComputerName)
+		}
+
+		require.ElementsMatch(t, []string{"WS1.TESTLAB.LOCAL", "S-1-5-21-1001"}, computerNames)
+		return nil
+	})
+
+	require.Nil(t, sessionexposure.SavePrivilegedSessionExposures(context.Background(), mockDB, newExposureGraph(t)))
+}
@@ -0,0 +1,143 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tierzero
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/specterops/bloodhound/analysis"
+	adAnalysis "github.com/specterops/bloodhound/analysis/ad"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/ops"
+	"github.com/specterops/bloodhound/dawgs/query"
+	"github.com/specterops/bloodhound/graphschema/common"
+	"github.com/specterops/bloodhound/log"
+	"github.com/specterops/bloodhound/src/model"
+	"github.com/specterops/bloodhound/src/model/appcfg"
+	"github.com/specterops/bloodhound/src/services/agi"
+)
+
+type TierZeroData interface {
+	agi.AgiData
+	appcfg.GetFlagByKeyer
+
+	UpdateAssetGroupSelectors(ctx context.Context, assetGroup model.AssetGroup, selectorSpecs []model.AssetGroupSelectorSpec, systemSelector bool) (model.UpdatedAssetGroupSelectors, error)
+	CreateTierZeroViolationRun(ctx context.Context, violations model.TierZeroViolations) (model.TierZeroViolationRun, error)
+}
+
+// SaveTierZeroViolations computes the Tier Zero control closure of the graph and records every violation found as a
+// new violation run. If the tier zero violation selectors feature flag is enabled, violating principals are also added
+// as selectors to the Tier Zero asset group and tagged.
+func SaveTierZeroViolations(ctx context.Context, db TierZeroData, graphDB graph.Database) error {
+	defer log.Measure(log.LevelInfo, "Saved Tier Zero violations")()
+
+	if violations, err := adAnalysis.FetchTierZeroViolations(ctx, graphDB); err != nil {
+		return fmt.Errorf("could not compute tier zero violations: %w", err)
+	} else if entries, err := resolveViolations(ctx, graphDB, violations); err != nil {
+		return fmt.Errorf("could not resolve tier zero violations: %w", err)
+	} else if _, err := db.CreateTierZeroViolationRun(ctx, entries); err != nil {
+		return fmt.Errorf("could not save tier zero violations: %w", err)
+	} else if flag, err := db.GetFlagByKey(ctx, appcfg.FeatureTierZeroViolationSelectors); err != nil {
+		return fmt.Errorf("error retrieving tier zero violation selectors feature flag: %w", err)
+	} else if flag.Enabled && len(entries) > 0 {
+		return selectViolations(ctx, db, graphDB, entries)
+	}
+
+	return nil
+}
+
+func resolveViolations(ctx context.Context, graphDB graph.Database, violations adAnalysis.TierZeroViolations) (model.TierZeroViolations, error) {
+	var entries = make(model.TierZeroViolations, 0, len(violations))
+
+	if len(violations) == 0 {
+		return entries, nil
+	}
+
+	return entries, graphDB.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		if nodes, err := ops.FetchNodeSet(tx.Nodes().Filterf(func() graph.Criteria {
+			return query.InIDs(query.NodeID(), violations.NodeIDs()...)
+		})); err != nil {
+			return err
+		} else {
+			for _, violation := range violations {
+				var (
+					principal = nodes.Get(violation.PrincipalID)
+					tierZero  = nodes.Get(violation.TierZeroID)
+				)
+
+				if principal == nil || tierZero == nil {
+					log.Warnf("Tier Zero violation for node %d references a node that no longer exists", violation.PrincipalID)
+				} else if objectID, err := principal.Properties.Get(common.ObjectID.String()).String(); err != nil {
+					log.Errorf("Node %d that does not have valid %s property", principal.ID, common.ObjectID)
+				} else if tierZeroObjectID, err := tierZero.Properties.Get(common.ObjectID.String()).String(); err != nil {
+					log.Errorf("Node %d that does not have valid %s property", tierZero.ID, common.ObjectID)
+				} else if name, err := principal.Properties.GetOrDefault(common.Name.String(), objectID).String(); err != nil {
+					log.Errorf("Node %d that does not have valid %s property", principal.ID, common.Name)
+				} else if tierZeroName, err := tierZero.Properties.GetOrDefault(common.Name.String(), tierZeroObjectID).String(); err != nil {
+					log.Errorf("Node %d that does not have valid %s property", tierZero.ID, common.Name)
+				} else {
+					entries = append(entries, model.TierZeroViolation{
+						ObjectID:         objectID,
+						Name:             name,
+						NodeLabel:        analysis.GetNodeKindDisplayLabel(principal),
+						TierZeroObjectID: tierZeroObjectID,
+						TierZeroName:     tierZeroName,
+						RelationshipKind: violation.Kind.String(),
+						Depth:            violation.Depth,
+					})
+				}
+			}
+
+			return nil
+		}
+	})
+}
+
+func selectViolations(ctx context.Context, db TierZeroData, graphDB graph.Database, violations model.TierZeroViolations) error {
+	if assetGroups, err := db.GetAllAssetGroups(ctx, "", model.SQLFilter{}); err != nil {
+		return err
+	} else if tierZeroAssetGroup, found := assetGroups.FindByName(model.TierZeroAssetGroupName); !found {
+		return fmt.Errorf("unable to find the %s asset group", model.TierZeroAssetGroupName)
+	} else {
+		var (
+			selectorSpecs = make([]model.AssetGroupSelectorSpec, 0, len(violations))
+			selected      = make(map[string]struct{}, len(violations))
+		)
+
+		// Selectors are keyed by object ID since names are not unique and asset group selectors are deduplicated by name
+		for _, violation := range violations {
+			if _, isSelected := selected[violation.ObjectID]; isSelected {
+				continue
+			}
+
+			selected[violation.ObjectID] = struct{}{}
+			selectorSpecs = append(selectorSpecs, model.AssetGroupSelectorSpec{
+				SelectorName:   violation.ObjectID,
+				EntityObjectID: violation.ObjectID,
+				Action:         model.SelectorSpecActionAdd,
+			})
+		}
+
+		// Selectors are added as user selectors so that they remain removable from the asset group
+		if _, err := db.UpdateAssetGroupSelectors(ctx, tierZeroAssetGroup, selectorSpecs, false); err != nil {
+			return fmt.Errorf("could not add tier zero violation selectors: %w", err)
+		}
+
+		return agi.UpdateAssetGroupIsolationTags(ctx, db, graphDB)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tierzero_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/specterops/bloodhound/dawgs"
+	"github.com/specterops/bloodhound/dawgs/drivers/memory"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/graphschema/ad"
+	"github.com/specterops/bloodhound/graphschema/common"
+	"github.com/specterops/bloodhound/src/database/mocks"
+	"github.com/specterops/bloodhound/src/model"
+	"github.com/specterops/bloodhound/src/model/appcfg"
+	"github.com/specterops/bloodhound/src/services/tierzero"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// newViolationGraph creates a graph where two users that share a name and a user with an invalid name are all members
+// of Domain Admins
+func newViolationGraph(t *testing.T) graph.Database {
+	db, err := dawgs.Open(context.Background(), memory.DriverName, dawgs.Config{})
+	require.Nil(t, err)
+
+	require.Nil(t, db.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		domainAdmins, err := tx.CreateNode(graph.AsProperties(map[string]any{
+			common.ObjectID.String():   "S-1-5-21-512",
+			common.Name.String():       "DOMAIN ADMINS@TESTLAB.LOCAL",
+			common.SystemTags.String(): ad.AdminTierZero,
+		}), ad.Entity, ad.Group)
+		require.Nil(t, err)
+
+		for objectID, name := range map[string]any{
+			"S-1-5-21-1000": "JOHN@TESTLAB.LOCAL",
+			"S-1-5-21-1001": "JOHN@TESTLAB.LOCAL",
+			"S-1-5-21-1002": 1002,
+		} {
+			user, err := tx.CreateNode(graph.AsProperties(map[string]any{
+				common.ObjectID.String(): objectID,
+				common.Name.String():     name,
+			}), ad.Entity, ad.User)
+			require.Nil(t, err)
+
+			_, err = tx.CreateRelationshipByIDs(user.ID, domainAdmins.ID, ad.MemberOf, graph.NewProperties())
+			require.Nil(t, err)
+		}
+
+		return nil
+	}))
+
+	return db
+}
+
+func newViolation(objectID string) model.TierZeroViolation {
+	return model.TierZeroViolation{
+		ObjectID:         objectID,
+		Name:             "JOHN@TESTLAB.LOCAL",
+		NodeLabel:        ad.User.String(),
+		TierZeroObjectID: "S-1-5-21-512",
+		TierZeroName:     "DOMAIN ADMINS@TESTLAB.LOCAL",
+		RelationshipKind: ad.MemberOf.String(),
+		Depth:            1,
+	}
+}
+
+func TestSaveTierZeroViolations(t *testing.T) {
+	var (
+		ctx           = context.Background()
+		mockCtrl      = gomock.NewController(t)
+		mockDB        = mocks.NewMockDatabase(mockCtrl)
+		graphDB       = newViolationGraph(t)
+		tierZeroGroup = model.AssetGroup{
+			Name:        model.TierZeroAssetGroupName,
+			Tag:         ad.AdminTierZero,
+			SystemGroup: true,
+		}
+	)
+
+	// The user with an invalid name is skipped rather than failing the run
+	mockDB.EXPECT().CreateTierZeroViolationRun(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, violations model.TierZeroViolations) (model.TierZeroViolationRun, error) {
+		require.ElementsMatch(t, model.TierZeroViolations{newViolation("S-1-5-21-1000"), newViolation("S-1-5-21-1001")}, violations)
+		return model.TierZeroViolationRun{ViolationCount: len(violations)}, nil
+	})
+
+	mockDB.EXPECT().GetFlagByKey(gomock.Any(), appcfg.FeatureTierZeroViolationSelectors).Return(appcfg.FeatureFlag{Enabled: true}, nil)
+	mockDB.EXPECT().GetAllAssetGroups(gomock.Any(), "", model.SQLFilter{}).Return(model.AssetGroups{tierZeroGroup}, nil).Times(2)
+
+	// Principals that share a name must each receive their own selector
+	mockDB.EXPECT().UpdateAssetGroupSelectors(gomock.Any(), tierZeroGroup, gomock.Any(), false).DoAndReturn(func(_ context.Context, _ model.AssetGroup, selectorSpecs []model.AssetGroupSelectorSpec, _ bool) (model.UpdatedAssetGroupSelectors, error) {
+		require.ElementsMatch(t, []model.AssetGroupSelectorSpec{
+			{SelectorName: "S-1-5-21-1000", EntityObjectID: "S-1-5-21-1000", Action: model.SelectorSpecActionAdd},
+			{SelectorName: "S-1-5-21-1001", EntityObjectID: "S-1-5-21-1001", Action: model.SelectorSpecActionAdd},
+		}, selectorSpecs)
+
+		return model.UpdatedAssetGroupSelectors{}, nil
+	})
+
+	require.Nil(t, tierzero.SaveTierZeroViolations(ctx, mockDB, graphDB))
+}
+
+func TestSaveTierZeroViolations_SelectorsDisabled(t *testing.T) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		mockDB   = mocks.NewMockDatabase(mockCtrl)
+	)
+
+	mockDB.EXPECT().CreateTierZeroViolationRun(gomock.Any(), gomock.Len(2)).Return(model.TierZeroViolationRun{}, nil)
+	mockDB.EXPECT().GetFlagByKey(gomock.Any(), appcfg.FeatureTierZeroViolationSelectors).Return(appcfg.FeatureFlag{}, nil)
+
+	require.Nil(t, tierzero.SaveTierZeroViolations(context.Background(), mockDB, newViolationGraph(t)))
+}
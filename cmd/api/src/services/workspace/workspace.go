@@ -0,0 +1,104 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate go run go.uber.org/mock/mockgen -copyright_file=../../../../../LICENSE.header -destination=./mocks/mock.go -package=mocks . WorkspaceData
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/graphschema"
+	"github.com/specterops/bloodhound/src/auth"
+	"github.com/specterops/bloodhound/src/ctx"
+	"github.com/specterops/bloodhound/src/model"
+)
+
+const maxNameLength = 128
+
+type WorkspaceData interface {
+	GetAllWorkspaces(ctx context.Context) (model.Workspaces, error)
+	GetWorkspace(ctx context.Context, id int32) (model.Workspace, error)
+}
+
+// Get returns the workspace with the given ID. The default workspace is implicit and is never looked up.
+func Get(ctx context.Context, db WorkspaceData, id int32) (model.Workspace, error) {
+	if id == model.DefaultWorkspaceID {
+		return model.DefaultWorkspace(), nil
+	}
+
+	return db.GetWorkspace(ctx, id)
+}
+
+// All returns the default workspace followed by every named workspace.
+func All(ctx context.Context, db WorkspaceData) (model.Workspaces, error) {
+	if workspaces, err := db.GetAllWorkspaces(ctx); err != nil {
+		return nil, err
+	} else {
+		return append(model.Workspaces{model.DefaultWorkspace()}, workspaces...), nil
+	}
+}
+
+// Accessible returns true if the actor of the given auth context may select the given workspace. The default workspace
+// is open to every actor while named workspaces are limited to their members, or to their creator if they have no
+// members. Users that may manage other users may select any workspace.
+func Accessible(authorizer auth.Authorizer, authCtx auth.Context, workspace model.Workspace) bool {
+	if workspace.IsDefault() {
+		return true
+	} else if user, isUser := auth.GetUserFromAuthCtx(authCtx); !isUser {
+		return false
+	} else {
+		return workspace.AllowsUser(user.ID) || authorizer.AllowsPermission(authCtx, auth.Permissions().AuthManageUsers)
+	}
+}
+
+// GraphSchema returns the schema of the graph that backs the given workspace.
+func GraphSchema(workspace model.Workspace) graph.Graph {
+	if workspace.IsDefault() {
+		return graphschema.DefaultGraph()
+	}
+
+	return graphschema.CombinedGraphSchema(workspace.GraphName)
+}
+
+// Scope returns a copy of the given context that is scoped to the given workspace. Database operations on workspace
+// owned rows only see rows of the workspace and graph operations run through a graph.DatabaseSwitch target the graph of
+// the workspace.
+func Scope(parentCtx context.Context, workspace model.Workspace) context.Context {
+	scopedCtx := ctx.WithWorkspace(parentCtx, workspace.ID)
+
+	if workspace.IsDefault() {
+		// The default graph is already the target of unscoped graph operations
+		return scopedCtx
+	}
+
+	return graph.ContextWithGraph(scopedCtx, GraphSchema(workspace))
+}
+
+// Validate checks that the user-supplied fields of a workspace are well-formed.
+func Validate(workspace model.Workspace) error {
+	if name := strings.TrimSpace(workspace.Name); name == "" {
+		return fmt.Errorf("workspace name must not be empty")
+	} else if len(name) > maxNameLength {
+		return fmt.Errorf("workspace name must not be longer than %d characters", maxNameLength)
+	} else if strings.EqualFold(name, model.DefaultWorkspaceName) {
+		return fmt.Errorf("workspace name %s is reserved", model.DefaultWorkspaceName)
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package workspace_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/src/auth"
+	"github.com/specterops/bloodhound/src/ctx"
+	"github.com/specterops/bloodhound/src/model"
+	"github.com/specterops/bloodhound/src/services/workspace"
+	"github.com/specterops/bloodhound/src/services/workspace/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestValidate(t *testing.T) {
+	require.Nil(t, workspace.Validate(model.Workspace{Name: "Engagement"}))
+	require.ErrorContains(t, workspace.Validate(model.Workspace{Name: " "}), "must not be empty")
+	require.ErrorContains(t, workspace.Validate(model.Workspace{Name: strings.Repeat("a", 129)}), "must not be longer")
+	require.ErrorContains(t, workspace.Validate(model.Workspace{Name: "DEFAULT"}), "is reserved")
+}
+
+func TestAccessible(t *testing.T) {
+	var (
+		authorizer = auth.NewAuthorizer(nil)
+		member     = model.User{Unique: model.Unique{ID: uuid.Must(uuid.NewV4())}}
+		outsider   = model.User{Unique: model.Unique{ID: uuid.Must(uuid.NewV4())}}
+		restricted = model.Workspace{UserIDs: []uuid.UUID{member.ID}, Serial: model.Serial{ID: 1}}
+		memberless = model.Workspace{CreatedBy: uuid.NullUUID{UUID: member.ID, Valid: true}, Serial: model.Serial{ID: 2}}
+		admin      = model.User{
+			Unique: model.Unique{ID: uuid.Must(uuid.NewV4())},
+			Roles: model.Roles{{
+				Permissions: model.Permissions{auth.Permissions().AuthManageUsers},
+			}},
+		}
+	)
+
+	require.True(t, workspace.Accessible(authorizer, auth.Context{Owner: outsider}, model.DefaultWorkspace()))
+	require.True(t, workspace.Accessible(authorizer, auth.Context{Owner: member}, memberless))
+	require.False(t, workspace.Accessible(authorizer, auth.Context{Owner: outsider}, memberless))
+	require.False(t, workspace.Accessible(authorizer, auth.Context{Owner: outsider}, model.Workspace{Serial: model.Serial{ID: 3}}))
+	require.True(t, workspace.Accessible(authorizer, auth.Context{Owner: admin}, memberless))
+	require.True(t, workspace.Accessible(authorizer, auth.Context{Owner: member}, restricted))
+	require.False(t, workspace.Accessible(authorizer, auth.Context{Owner: outsider}, restricted))
+	require.False(t, workspace.Accessible(authorizer, auth.Context{}, restricted))
+}
+
+func TestGet_DefaultWorkspace(t *testing.T) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		mockDB   = mocks.NewMockWorkspaceData(mockCtrl)
+	)
+	defer mockCtrl.Finish()
+
+	// The default workspace is implicit and must never be looked up
+	defaultWorkspace, err := workspace.Get(context.Background(), mockDB, model.DefaultWorkspaceID)
+	require.Nil(t, err)
+	require.Equal(t, model.DefaultWorkspaceGraphName, defaultWorkspace.GraphName)
+}
+
+func TestScope(t *testing.T) {
+	defaultCtx := workspace.Scope(context.Background(), model.DefaultWorkspace())
+
+	workspaceID, isScoped := ctx.WorkspaceID(defaultCtx)
+	require.True(t, isScoped)
+	require.Equal(t, model.DefaultWorkspaceID, workspaceID)
+
+	_, hasGraph := graph.GraphFromContext(defaultCtx)
+	require.False(t, hasGraph)
+
+	scopedCtx := workspace.Scope(context.Background(), model.Workspace{GraphName: "workspace_7", Serial: model.Serial{ID: 7}})
+
+	workspaceID, isScoped = ctx.WorkspaceID(scopedCtx)
+	require.True(t, isScoped)
+	require.Equal(t, int32(7), workspaceID)
+
+	graphTarget, hasGraph := graph.GraphFromContext(scopedCtx)
+	require.True(t, hasGraph)
+	require.Equal(t, "workspace_7", graphTarget.Name)
+	require.NotEmpty(t, graphTarget.Nodes)
+}
@@ -0,0 +1,123 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+// +build integration
+
+package integration_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/specterops/bloodhound/dawgs/drivers/pg"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/graphschema/ad"
+	"github.com/specterops/bloodhound/graphschema/common"
+	"github.com/specterops/bloodhound/src/test/integration"
+	"github.com/stretchr/testify/require"
+)
+
+const bulkLoadBenchmarkNodes = 10_000
+
+func openBulkLoadBenchmarkDB(b *testing.B, bulkLoad bool) *pg.Driver {
+	graphDB, isPG := integration.OpenGraphDBWithDriver(b, pg.DriverName).(*pg.Driver)
+	require.True(b, isPG)
+
+	require.Nil(b, graphDB.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		return tx.Nodes().Delete()
+	}))
+
+	graphDB.SetBulkLoad(bulkLoad)
+	b.Cleanup(func() {
+		graphDB.SetBulkLoad(false)
+	})
+
+	return graphDB
+}
+
+func benchmarkNodeCreate(b *testing.B, bulkLoad bool) {
+	graphDB := openBulkLoadBenchmarkDB(b, bulkLoad)
+
+	b.ResetTimer()
+
+	for iteration := 0; iteration < b.N; iteration++ {
+		require.Nil(b, graphDB.BatchOperation(context.Background(), func(batch graph.Batch) error {
+			for nodeIdx := 0; nodeIdx < bulkLoadBenchmarkNodes; nodeIdx++ {
+				objectID := strconv.Itoa(iteration) + "-" + strconv.Itoa(nodeIdx)
+
+				if err := batch.CreateNode(graph.PrepareNode(graph.AsProperties(graph.PropertyMap{
+					common.Name:     objectID,
+					common.ObjectID: objectID,
+				}), ad.Entity, ad.Computer)); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}))
+	}
+}
+
+// benchmarkNodeUpsert upserts the same number of nodes every iteration where half of the nodes were written by the
+// previous iteration and are updated while the other half are new and are inserted.
+func benchmarkNodeUpsert(b *testing.B, bulkLoad bool) {
+	graphDB := openBulkLoadBenchmarkDB(b, bulkLoad)
+
+	b.ResetTimer()
+
+	for iteration := 0; iteration < b.N; iteration++ {
+		require.Nil(b, graphDB.BatchOperation(context.Background(), func(batch graph.Batch) error {
+			for nodeIdx := 0; nodeIdx < bulkLoadBenchmarkNodes; nodeIdx++ {
+				objectID := strconv.Itoa(iteration*bulkLoadBenchmarkNodes/2 + nodeIdx)
+
+				if err := batch.UpdateNodeBy(graph.NodeUpdate{
+					Node: graph.PrepareNode(graph.AsProperties(graph.PropertyMap{
+						common.Name:     objectID,
+						common.ObjectID: objectID,
+					}), ad.Computer),
+					IdentityKind:       ad.Entity,
+					IdentityProperties: []string{common.ObjectID.String()},
+				}); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}))
+	}
+}
+
+func BenchmarkNodeCreate(b *testing.B) {
+	b.Run("Batch", func(b *testing.B) {
+		benchmarkNodeCreate(b, false)
+	})
+
+	b.Run("COPY", func(b *testing.B) {
+		benchmarkNodeCreate(b, true)
+	})
+}
+
+func BenchmarkNodeUpsert(b *testing.B) {
+	b.Run("Batch", func(b *testing.B) {
+		benchmarkNodeUpsert(b, false)
+	})
+
+	b.Run("COPY", func(b *testing.B) {
+		benchmarkNodeUpsert(b, true)
+	})
+}
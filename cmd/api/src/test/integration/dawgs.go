@@ -19,6 +19,7 @@ package integration
 import (
 	"context"
 	"github.com/specterops/bloodhound/dawgs"
+	"github.com/specterops/bloodhound/dawgs/drivers/memory"
 	"github.com/specterops/bloodhound/dawgs/drivers/neo4j"
 	"github.com/specterops/bloodhound/dawgs/drivers/pg"
 	"github.com/specterops/bloodhound/dawgs/graph"
@@ -39,25 +40,34 @@ func LoadConfiguration(testCtrl test.Controller) config.Configuration {
 }
 
 func OpenGraphDB(testCtrl test.Controller) graph.Database {
+	return OpenGraphDBWithDriver(testCtrl, LoadConfiguration(testCtrl).GraphDriver)
+}
+
+// OpenGraphDBWithDriver opens the graph database backed by the given driver regardless of the configured graph driver.
+// This is useful for tests that compare the behavior of drivers against each other.
+func OpenGraphDBWithDriver(testCtrl test.Controller, driverName string) graph.Database {
 	var (
 		cfg           = LoadConfiguration(testCtrl)
 		graphDatabase graph.Database
 		err           error
 	)
 
-	switch cfg.GraphDriver {
+	switch driverName {
 	case pg.DriverName:
-		graphDatabase, err = dawgs.Open(context.TODO(), cfg.GraphDriver, dawgs.Config{
+		graphDatabase, err = dawgs.Open(context.TODO(), driverName, dawgs.Config{
 			DriverCfg: cfg.Database.PostgreSQLConnectionString(),
 		})
 
 	case neo4j.DriverName:
-		graphDatabase, err = dawgs.Open(context.TODO(), cfg.GraphDriver, dawgs.Config{
+		graphDatabase, err = dawgs.Open(context.TODO(), driverName, dawgs.Config{
 			DriverCfg: cfg.Neo4J.Neo4jConnectionString(),
 		})
 
+	case memory.DriverName:
+		graphDatabase, err = dawgs.Open(context.TODO(), driverName, dawgs.Config{})
+
 	default:
-		testCtrl.Fatalf("unsupported graph driver name %s", cfg.GraphDriver)
+		testCtrl.Fatalf("unsupported graph driver name %s", driverName)
 	}
 
 	test.RequireNilErrf(testCtrl, err, "Failed connecting to graph database: %v", err)
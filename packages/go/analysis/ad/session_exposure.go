@@ -0,0 +1,155 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ad
+
+import (
+	"context"
+	"strings"
+
+	"github.com/specterops/bloodhound/analysis/impact"
+	"github.com/specterops/bloodhound/dawgs/cardinality"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/ops"
+	"github.com/specterops/bloodhound/dawgs/query"
+	"github.com/specterops/bloodhound/graphschema/ad"
+	"github.com/specterops/bloodhound/graphschema/common"
+	"github.com/specterops/bloodhound/log"
+)
+
+// SessionExposureRelationships returns the relationship kinds that allow a principal to reach a computer in a way that
+// exposes the credentials of users logged on to it.
+func SessionExposureRelationships() []graph.Kind {
+	return []graph.Kind{ad.AdminTo, ad.CanRDP, ad.ExecuteDCOM}
+}
+
+// PrivilegedSessionExposure describes a computer outside of Tier Zero that holds at least one session of a privileged
+// user.
+type PrivilegedSessionExposure struct {
+	// Computer is the exposed computer
+	Computer *graph.Node
+
+	// Users contains the IDs of the privileged users with a session on the computer
+	Users []graph.ID
+
+	// ReachablePrincipals is the number of principals, including expanded group members, that can reach the computer
+	ReachablePrincipals uint64
+}
+
+// FetchPrivilegedSessionExposures finds every computer that is not tagged as Tier Zero and holds a session of a user
+// whose tag property contains the given tag. For each computer the number of principals that can reach it through
+// SessionExposureRelationships is computed with the group expansions given.
+func FetchPrivilegedSessionExposures(ctx context.Context, db graph.Database, groupExpansions impact.PathAggregator, tagProperty, tag string) ([]PrivilegedSessionExposure, error) {
+	defer log.LogAndMeasure(log.LevelInfo, "FetchPrivilegedSessionExposures")()
+
+	var exposures []PrivilegedSessionExposure
+
+	return exposures, db.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		if privilegedUserIDs, err := ops.FetchNodeIDs(tx.Nodes().Filterf(func() graph.Criteria {
+			return query.And(
+				query.Kind(query.Node(), ad.User),
+				query.StringContains(query.NodeProperty(tagProperty), tag),
+			)
+		})); err != nil {
+			return err
+		} else if len(privilegedUserIDs) == 0 {
+			return nil
+		} else if sessions, err := ops.FetchPathSet(tx.Relationships().Filterf(func() graph.Criteria {
+			return query.And(
+				query.Kind(query.Start(), ad.Computer),
+				query.Kind(query.Relationship(), ad.HasSession),
+				query.InIDs(query.EndID(), privilegedUserIDs...),
+			)
+		})); err != nil {
+			return err
+		} else {
+			var (
+				computers    = graph.NewNodeSet()
+				sessionUsers = map[graph.ID][]graph.ID{}
+			)
+
+			for _, session := range sessions {
+				var (
+					computer = session.Root()
+					user     = session.Terminal()
+				)
+
+				if tags, _ := computer.Properties.GetOrDefault(common.SystemTags.String(), "").String(); tagsContain(tags, ad.AdminTierZero) {
+					continue
+				}
+
+				computers.Add(computer)
+				sessionUsers[computer.ID] = append(sessionUsers[computer.ID], user.ID)
+			}
+
+			if computers.Len() == 0 {
+				return nil
+			}
+
+			reachable := map[graph.ID]cardinality.Duplex[uint32]{}
+
+			if err := tx.Relationships().Filterf(func() graph.Criteria {
+				return query.And(
+					query.KindIn(query.Relationship(), SessionExposureRelationships()...),
+					query.InIDs(query.EndID(), computers.IDs()...),
+				)
+			}).FetchTriples(func(cursor graph.Cursor[graph.RelationshipTripleResult]) error {
+				for next := range cursor.Chan() {
+					principals, found := reachable[next.EndID]
+
+					if !found {
+						principals = cardinality.NewBitmap32()
+						reachable[next.EndID] = principals
+					}
+
+					// Groups are expanded to their effective members so that the count reflects every principal
+					// with a path to the computer
+					principals.Add(next.StartID.Uint32())
+					principals.Or(groupExpansions.Cardinality(next.StartID.Uint32()))
+				}
+
+				return cursor.Error()
+			}); err != nil {
+				return err
+			}
+
+			for _, computer := range computers {
+				exposure := PrivilegedSessionExposure{
+					Computer: computer,
+					Users:    sessionUsers[computer.ID],
+				}
+
+				if principals, found := reachable[computer.ID]; found {
+					exposure.ReachablePrincipals = principals.Cardinality()
+				}
+
+				exposures = append(exposures, exposure)
+			}
+
+			return nil
+		}
+	})
+}
+
+func tagsContain(tags, tag string) bool {
+	for _, next := range strings.Fields(tags) {
+		if next == tag {
+			return true
+		}
+	}
+
+	return false
+}
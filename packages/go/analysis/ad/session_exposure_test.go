@@ -0,0 +1,134 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ad_test
+
+import (
+	"context"
+	"testing"
+
+	adAnalysis "github.com/specterops/bloodhound/analysis/ad"
+	"github.com/specterops/bloodhound/dawgs"
+	"github.com/specterops/bloodhound/dawgs/drivers/memory"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/graphschema/ad"
+	"github.com/specterops/bloodhound/graphschema/common"
+	"github.com/stretchr/testify/require"
+)
+
+// sessionExposureFixture is a small Active Directory graph where a Tier Zero user has sessions on a workstation and on
+// a domain controller
+type sessionExposureFixture struct {
+	TierZeroAdmin    *graph.Node
+	Bob              *graph.Node
+	Workstation      *graph.Node
+	Server           *graph.Node
+	DomainController *graph.Node
+}
+
+func newSessionExposureFixture(t *testing.T) (graph.Database, sessionExposureFixture) {
+	var (
+		ctx     = context.Background()
+		fixture sessionExposureFixture
+	)
+
+	db, err := dawgs.Open(ctx, memory.DriverName, dawgs.Config{})
+	require.Nil(t, err)
+
+	require.Nil(t, db.WriteTransaction(ctx, func(tx graph.Transaction) error {
+		newNode := func(objectID string, tierZero bool, kinds ...graph.Kind) *graph.Node {
+			properties := graph.NewProperties().Set(common.ObjectID.String(), objectID)
+
+			if tierZero {
+				properties.Set(common.SystemTags.String(), ad.AdminTierZero)
+			}
+
+			node, err := tx.CreateNode(properties, append(kinds, ad.Entity)...)
+			require.Nil(t, err)
+
+			return node
+		}
+
+		newRelationship := func(start, end *graph.Node, kind graph.Kind) {
+			_, err := tx.CreateRelationshipByIDs(start.ID, end.ID, kind, graph.NewProperties())
+			require.Nil(t, err)
+		}
+
+		var (
+			helpdesk = newNode("HELPDESK", false, ad.Group)
+			carol    = newNode("CAROL", false, ad.User)
+			dave     = newNode("DAVE", false, ad.User)
+			eve      = newNode("EVE", false, ad.User)
+		)
+
+		fixture.TierZeroAdmin = newNode("ADMIN", true, ad.User)
+		fixture.Bob = newNode("BOB", false, ad.User)
+		fixture.Workstation = newNode("WS", false, ad.Computer)
+		fixture.Server = newNode("SRV", false, ad.Computer)
+		fixture.DomainController = newNode("DC", true, ad.Computer)
+
+		// The workstation exposes the Tier Zero admin to the members of Helpdesk and to Eve
+		newRelationship(fixture.Workstation, fixture.TierZeroAdmin, ad.HasSession)
+		newRelationship(carol, helpdesk, ad.MemberOf)
+		newRelationship(dave, helpdesk, ad.MemberOf)
+		newRelationship(helpdesk, fixture.Workstation, ad.AdminTo)
+		newRelationship(eve, fixture.Workstation, ad.CanRDP)
+
+		// Sessions of users outside of Tier Zero are not exposures
+		newRelationship(fixture.Server, fixture.Bob, ad.HasSession)
+		newRelationship(eve, fixture.Server, ad.AdminTo)
+
+		// Sessions on Tier Zero computers are not exposures
+		newRelationship(fixture.DomainController, fixture.TierZeroAdmin, ad.HasSession)
+
+		return nil
+	}))
+
+	return db, fixture
+}
+
+func TestFetchPrivilegedSessionExposures(t *testing.T) {
+	var (
+		ctx         = context.Background()
+		db, fixture = newSessionExposureFixture(t)
+	)
+
+	groupExpansions, err := adAnalysis.ResolveAllGroupMemberships(ctx, db)
+	require.Nil(t, err)
+
+	exposures, err := adAnalysis.FetchPrivilegedSessionExposures(ctx, db, groupExpansions, common.SystemTags.String(), ad.AdminTierZero)
+	require.Nil(t, err)
+	require.Len(t, exposures, 1)
+
+	// Helpdesk, both of its members and Eve can reach the workstation
+	require.Equal(t, fixture.Workstation.ID, exposures[0].Computer.ID)
+	require.Equal(t, []graph.ID{fixture.TierZeroAdmin.ID}, exposures[0].Users)
+	require.Equal(t, uint64(4), exposures[0].ReachablePrincipals)
+}
+
+func TestFetchPrivilegedSessionExposures_NoPrivilegedUsers(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		db, _ = newSessionExposureFixture(t)
+	)
+
+	groupExpansions, err := adAnalysis.ResolveAllGroupMemberships(ctx, db)
+	require.Nil(t, err)
+
+	exposures, err := adAnalysis.FetchPrivilegedSessionExposures(ctx, db, groupExpansions, common.UserTags.String(), "engagement_targets")
+	require.Nil(t, err)
+	require.Empty(t, exposures)
+}
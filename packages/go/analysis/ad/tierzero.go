@@ -0,0 +1,191 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ad
+
+import (
+	"context"
+
+	"github.com/specterops/bloodhound/dawgs/cardinality"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/ops"
+	"github.com/specterops/bloodhound/dawgs/query"
+	"github.com/specterops/bloodhound/graphschema/ad"
+	"github.com/specterops/bloodhound/graphschema/common"
+	"github.com/specterops/bloodhound/log"
+	"github.com/specterops/bloodhound/slicesext"
+)
+
+// tierZeroFrontierBatchSize caps the number of frontier node IDs sent with each inbound control relationship fetch
+const tierZeroFrontierBatchSize = 10_000
+
+// TierZeroControlRelationships returns the relationship kinds that grant control over the entity they terminate at.
+// Walking these relationships inbound from a Tier Zero asset yields its inbound control closure.
+func TierZeroControlRelationships() []graph.Kind {
+	return slicesext.Concat(ad.ACLRelationships(), []graph.Kind{
+		ad.MemberOf,
+		ad.Contains,
+		ad.GPLink,
+		ad.AdminTo,
+		ad.HasSIDHistory,
+		ad.AllowedToAct,
+		ad.AllowedToDelegate,
+		ad.GoldenCert,
+		ad.ADCSESC1,
+		ad.ADCSESC3,
+		ad.ADCSESC4,
+		ad.ADCSESC5,
+		ad.ADCSESC6a,
+		ad.ADCSESC6b,
+		ad.ADCSESC7,
+		ad.ADCSESC9a,
+		ad.ADCSESC9b,
+		ad.ADCSESC10a,
+		ad.ADCSESC10b,
+		ad.ADCSESC13,
+	})
+}
+
+// TierZeroViolation describes a principal that is not tagged as Tier Zero but has a direct or transitive control path
+// to a Tier Zero asset.
+type TierZeroViolation struct {
+	// PrincipalID is the ID of the principal that is in violation
+	PrincipalID graph.ID
+
+	// TierZeroID is the ID of the Tier Zero asset the control path terminates at
+	TierZeroID graph.ID
+
+	// Kind is the kind of the first relationship in the control path
+	Kind graph.Kind
+
+	// Depth is the number of relationships between the principal and the Tier Zero asset
+	Depth int
+}
+
+type TierZeroViolations []TierZeroViolation
+
+// NodeIDs returns the IDs of all nodes referenced by the violations, both principals and Tier Zero assets.
+func (s TierZeroViolations) NodeIDs() []graph.ID {
+	ids := cardinality.NewBitmap32()
+
+	for _, violation := range s {
+		ids.Add(violation.PrincipalID.Uint32())
+		ids.Add(violation.TierZeroID.Uint32())
+	}
+
+	return cardinality.DuplexToGraphIDs(ids)
+}
+
+func FetchTierZeroTaggedNodes(ctx context.Context, db graph.Database) (graph.NodeSet, error) {
+	var (
+		nodes graph.NodeSet
+		err   error
+	)
+
+	return nodes, db.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		nodes, err = ops.FetchNodeSet(tx.Nodes().Filterf(func() graph.Criteria {
+			return query.And(
+				query.Kind(query.Node(), ad.Entity),
+				query.StringContains(query.NodeProperty(common.SystemTags.String()), ad.AdminTierZero),
+			)
+		}))
+
+		return err
+	})
+}
+
+// FetchTierZeroViolations computes the inbound control closure of all Tier Zero tagged Active Directory entities and
+// returns every principal in the closure that is not itself tagged as Tier Zero. The closure is expanded as a single
+// breadth first search rooted at every Tier Zero asset at once so that each principal is reported exactly once, for the
+// nearest Tier Zero asset it controls and at the depth of its shortest control path. The inbound control relationships
+// of each depth are fetched in batches of frontier nodes to bound the size of each query.
+func FetchTierZeroViolations(ctx context.Context, db graph.Database) (TierZeroViolations, error) {
+	defer log.LogAndMeasure(log.LevelInfo, "FetchTierZeroViolations")()
+
+	if tierZeroNodes, err := FetchTierZeroTaggedNodes(ctx, db); err != nil {
+		return nil, err
+	} else {
+		var (
+			controlRelationships = TierZeroControlRelationships()
+			visited              = cardinality.NewBitmap32()
+			violations           TierZeroViolations
+
+			// frontier maps each node of the current depth to the Tier Zero asset its control path terminates at
+			frontier = make(map[graph.ID]graph.ID, len(tierZeroNodes))
+		)
+
+		// Tier Zero assets are never violations themselves and are expanded as roots of the search
+		for _, tierZeroNode := range tierZeroNodes {
+			visited.Add(tierZeroNode.ID.Uint32())
+			frontier[tierZeroNode.ID] = tierZeroNode.ID
+		}
+
+		for depth := 1; len(frontier) > 0; depth++ {
+			var (
+				frontierIDs  = make([]graph.ID, 0, len(frontier))
+				nextFrontier = map[graph.ID]graph.ID{}
+			)
+
+			for nodeID := range frontier {
+				frontierIDs = append(frontierIDs, nodeID)
+			}
+
+			if err := db.ReadTransaction(ctx, func(tx graph.Transaction) error {
+				for batchStart := 0; batchStart < len(frontierIDs); batchStart += tierZeroFrontierBatchSize {
+					batchIDs := frontierIDs[batchStart:min(batchStart+tierZeroFrontierBatchSize, len(frontierIDs))]
+
+					if err := ops.ForEachStartNode(tx.Relationships().Filterf(func() graph.Criteria {
+						return query.And(
+							query.InIDs(query.EndID(), batchIDs...),
+							query.KindIn(query.Relationship(), controlRelationships...),
+						)
+					}), func(relationship *graph.Relationship, node *graph.Node) error {
+						// Anything already seen was reached by a path at least as short as this one
+						if !visited.CheckedAdd(node.ID.Uint32()) {
+							return nil
+						}
+
+						tierZeroID := frontier[relationship.EndID]
+
+						if node.Kinds.ContainsOneOf(ad.User, ad.Group, ad.Computer) {
+							violations = append(violations, TierZeroViolation{
+								PrincipalID: node.ID,
+								TierZeroID:  tierZeroID,
+								Kind:        relationship.Kind,
+								Depth:       depth,
+							})
+						}
+
+						// Descend through everything in the closure so that control of intermediate objects such as
+						// OUs and GPOs is attributed to the principals behind them
+						nextFrontier[node.ID] = tierZeroID
+						return nil
+					}); err != nil {
+						return err
+					}
+				}
+
+				return nil
+			}); err != nil {
+				return nil, err
+			}
+
+			frontier = nextFrontier
+		}
+
+		return violations, nil
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ad_test
+
+import (
+	"context"
+	"testing"
+
+	adAnalysis "github.com/specterops/bloodhound/analysis/ad"
+	"github.com/specterops/bloodhound/dawgs"
+	"github.com/specterops/bloodhound/dawgs/drivers/memory"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/graphschema/ad"
+	"github.com/specterops/bloodhound/graphschema/common"
+	"github.com/stretchr/testify/require"
+)
+
+// tierZeroFixture is a small Active Directory graph with two Tier Zero assets and a mix of principals that do and do
+// not control them
+type tierZeroFixture struct {
+	DomainAdmins     *graph.Node
+	DomainController *graph.Node
+	TierZeroAdmin    *graph.Node
+	OU               *graph.Node
+	Alice            *graph.Node
+	Bob              *graph.Node
+	Carol            *graph.Node
+	Dave             *graph.Node
+}
+
+func newTierZeroFixture(t *testing.T) (graph.Database, tierZeroFixture) {
+	var (
+		ctx     = context.Background()
+		fixture tierZeroFixture
+	)
+
+	db, err := dawgs.Open(ctx, memory.DriverName, dawgs.Config{})
+	require.Nil(t, err)
+
+	require.Nil(t, db.WriteTransaction(ctx, func(tx graph.Transaction) error {
+		newNode := func(objectID string, tierZero bool, kinds ...graph.Kind) *graph.Node {
+			properties := graph.NewProperties().Set(common.ObjectID.String(), objectID)
+
+			if tierZero {
+				properties.Set(common.SystemTags.String(), ad.AdminTierZero)
+			}
+
+			node, err := tx.CreateNode(properties, append(kinds, ad.Entity)...)
+			require.Nil(t, err)
+
+			return node
+		}
+
+		newRelationship := func(start, end *graph.Node, kind graph.Kind) {
+			_, err := tx.CreateRelationshipByIDs(start.ID, end.ID, kind, graph.NewProperties())
+			require.Nil(t, err)
+		}
+
+		fixture.DomainAdmins = newNode("DOMAIN ADMINS", true, ad.Group)
+		fixture.DomainController = newNode("DC", true, ad.Computer)
+		fixture.TierZeroAdmin = newNode("ADMIN", true, ad.User)
+		fixture.OU = newNode("OU", false, ad.OU)
+		fixture.Alice = newNode("ALICE", false, ad.User)
+		fixture.Bob = newNode("BOB", false, ad.User)
+		fixture.Carol = newNode("CAROL", false, ad.User)
+		fixture.Dave = newNode("DAVE", false, ad.User)
+
+		// Tier Zero assets that control each other are not violations
+		newRelationship(fixture.TierZeroAdmin, fixture.DomainAdmins, ad.MemberOf)
+		newRelationship(fixture.DomainAdmins, fixture.DomainController, ad.AdminTo)
+
+		// Alice is a direct member of Domain Admins
+		newRelationship(fixture.Alice, fixture.DomainAdmins, ad.MemberOf)
+
+		// Bob controls the OU that contains the domain controller
+		newRelationship(fixture.OU, fixture.DomainController, ad.Contains)
+		newRelationship(fixture.Bob, fixture.OU, ad.GenericAll)
+
+		// Carol controls the OU but also administers the domain controller directly
+		newRelationship(fixture.Carol, fixture.OU, ad.GenericAll)
+		newRelationship(fixture.Carol, fixture.DomainController, ad.AdminTo)
+
+		// A session does not grant control over the domain controller
+		newRelationship(fixture.DomainController, fixture.Dave, ad.HasSession)
+
+		return nil
+	}))
+
+	return db, fixture
+}
+
+func TestFetchTierZeroViolations(t *testing.T) {
+	db, fixture := newTierZeroFixture(t)
+
+	violations, err := adAnalysis.FetchTierZeroViolations(context.Background(), db)
+	require.Nil(t, err)
+
+	violationsByPrincipal := map[graph.ID]adAnalysis.TierZeroViolation{}
+
+	for _, violation := range violations {
+		_, seen := violationsByPrincipal[violation.PrincipalID]
+		require.False(t, seen, "principal %d reported more than once", violation.PrincipalID)
+
+		violationsByPrincipal[violation.PrincipalID] = violation
+	}
+
+	require.Equal(t, map[graph.ID]adAnalysis.TierZeroViolation{
+		fixture.Alice.ID: {
+			PrincipalID: fixture.Alice.ID,
+			TierZeroID:  fixture.DomainAdmins.ID,
+			Kind:        ad.MemberOf,
+			Depth:       1,
+		},
+		fixture.Bob.ID: {
+			PrincipalID: fixture.Bob.ID,
+			TierZeroID:  fixture.DomainController.ID,
+			Kind:        ad.GenericAll,
+			Depth:       2,
+		},
+		fixture.Carol.ID: {
+			PrincipalID: fixture.Carol.ID,
+			TierZeroID:  fixture.DomainController.ID,
+			Kind:        ad.AdminTo,
+			Depth:       1,
+		},
+	}, violationsByPrincipal)
+
+	require.ElementsMatch(t, []graph.ID{
+		fixture.Alice.ID, fixture.Bob.ID, fixture.Carol.ID, fixture.DomainAdmins.ID, fixture.DomainController.ID,
+	}, violations.NodeIDs())
+}
+
+func TestFetchTierZeroViolations_NoTierZero(t *testing.T) {
+	db, err := dawgs.Open(context.Background(), memory.DriverName, dawgs.Config{})
+	require.Nil(t, err)
+
+	violations, err := adAnalysis.FetchTierZeroViolations(context.Background(), db)
+	require.Nil(t, err)
+	require.Empty(t, violations)
+}
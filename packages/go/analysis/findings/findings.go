@@ -0,0 +1,263 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package findings
+
+import (
+	"context"
+	"strings"
+
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/ops"
+	"github.com/specterops/bloodhound/dawgs/query"
+	"github.com/specterops/bloodhound/graphschema/ad"
+	"github.com/specterops/bloodhound/graphschema/azure"
+	"github.com/specterops/bloodhound/graphschema/common"
+	"github.com/specterops/bloodhound/log"
+)
+
+type Severity string
+
+const (
+	SeverityCritical Severity = "critical"
+	SeverityHigh     Severity = "high"
+	SeverityModerate Severity = "moderate"
+	SeverityLow      Severity = "low"
+)
+
+const (
+	NonTierZeroDCSync                = "NonTierZeroDCSync"
+	ESC1ToDomain                     = "ESC1ToDomain"
+	KerberoastableTierZeroUsers      = "KerberoastableTierZeroUsers"
+	NonTierZeroGenericAllTierZeroGPO = "NonTierZeroGenericAllTierZeroGPO"
+	NonTierZeroResetPasswordAZUsers  = "NonTierZeroResetPasswordTierZeroAZUsers"
+)
+
+// PrincipalFetcher returns the principals impacted by a finding type within the given environment, which is either an
+// Active Directory domain or an Azure tenant.
+type PrincipalFetcher func(tx graph.Transaction, environment *graph.Node) (graph.NodeSet, error)
+
+// FindingType describes a single entry in the attack path finding catalog.
+type FindingType struct {
+	Name            string
+	Title           string
+	Severity        Severity
+	EnvironmentKind graph.Kind
+	Principals      PrincipalFetcher
+}
+
+// Catalog returns every finding type evaluated after analysis.
+func Catalog() []FindingType {
+	return []FindingType{{
+		Name:            NonTierZeroDCSync,
+		Title:           "Non Tier Zero Principals with DCSync Privileges",
+		Severity:        SeverityCritical,
+		EnvironmentKind: ad.Domain,
+		Principals:      fetchNonTierZeroDCSyncPrincipals,
+	}, {
+		Name:            ESC1ToDomain,
+		Title:           "Non Tier Zero Principals with ADCS ESC1 to the Domain",
+		Severity:        SeverityCritical,
+		EnvironmentKind: ad.Domain,
+		Principals:      fetchESC1ToDomainPrincipals,
+	}, {
+		Name:            KerberoastableTierZeroUsers,
+		Title:           "Kerberoastable Tier Zero Users",
+		Severity:        SeverityHigh,
+		EnvironmentKind: ad.Domain,
+		Principals:      fetchKerberoastableTierZeroUsers,
+	}, {
+		Name:            NonTierZeroGenericAllTierZeroGPO,
+		Title:           "Non Tier Zero Principals with GenericAll on Tier Zero GPOs",
+		Severity:        SeverityHigh,
+		EnvironmentKind: ad.Domain,
+		Principals:      fetchNonTierZeroGenericAllTierZeroGPOPrincipals,
+	}, {
+		Name:            NonTierZeroResetPasswordAZUsers,
+		Title:           "Non Tier Zero Principals with Reset Password on Tier Zero Azure Users",
+		Severity:        SeverityHigh,
+		EnvironmentKind: azure.Tenant,
+		Principals:      fetchNonTierZeroResetPasswordAZUserPrincipals,
+	}}
+}
+
+// Lookup returns the catalog entry with the given name.
+func Lookup(name string) (FindingType, bool) {
+	for _, findingType := range Catalog() {
+		if findingType.Name == name {
+			return findingType, true
+		}
+	}
+
+	return FindingType{}, false
+}
+
+// Finding is a single principal impacted by a finding type within an environment.
+type Finding struct {
+	Type        FindingType
+	Environment *graph.Node
+	Principal   *graph.Node
+}
+
+// Evaluate runs every catalog entry against every domain and tenant in the graph.
+func Evaluate(ctx context.Context, db graph.Database) ([]Finding, error) {
+	defer log.LogAndMeasure(log.LevelInfo, "Evaluate attack path findings")()
+
+	var results []Finding
+
+	return results, db.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		if environments, err := ops.FetchNodes(tx.Nodes().Filterf(func() graph.Criteria {
+			return query.KindIn(query.Node(), ad.Domain, azure.Tenant)
+		})); err != nil {
+			return err
+		} else {
+			for _, environment := range environments {
+				// An environment without an identifier can not be scoped so it is skipped without failing the others
+				if _, err := EnvironmentID(environment); err != nil {
+					log.Warnf("Environment node %d does not have a valid identifier; skipping attack path findings: %v", environment.ID, err)
+					continue
+				}
+
+				for _, findingType := range Catalog() {
+					if !environment.Kinds.ContainsOneOf(findingType.EnvironmentKind) {
+						continue
+					}
+
+					if principals, err := findingType.Principals(tx, environment); err != nil {
+						return err
+					} else {
+						for _, principal := range principals {
+							results = append(results, Finding{
+								Type:        findingType,
+								Environment: environment,
+								Principal:   principal,
+							})
+						}
+					}
+				}
+			}
+
+			return nil
+		}
+	})
+}
+
+// EnvironmentID returns the identifier of the given environment node: the tenant ID of an Azure tenant or the domain
+// SID of an Active Directory domain.
+func EnvironmentID(environment *graph.Node) (string, error) {
+	if environment.Kinds.ContainsOneOf(azure.Tenant) {
+		return environment.Properties.Get(azure.TenantID.String()).String()
+	}
+
+	return environment.Properties.Get(ad.DomainSID.String()).String()
+}
+
+func isTierZero(node *graph.Node) bool {
+	tags, _ := node.Properties.GetOrDefault(common.SystemTags.String(), "").String()
+
+	for _, tag := range strings.Fields(tags) {
+		if tag == ad.AdminTierZero {
+			return true
+		}
+	}
+
+	return false
+}
+
+func removeTierZero(nodes graph.NodeSet) graph.NodeSet {
+	for _, node := range nodes {
+		if isTierZero(node) {
+			nodes.Remove(node.ID)
+		}
+	}
+
+	return nodes
+}
+
+// fetchNonTierZeroStartNodes returns the principals outside of Tier Zero with a relationship of the given kind to any
+// of the target nodes.
+func fetchNonTierZeroStartNodes(tx graph.Transaction, targets []graph.ID, kind graph.Kind) (graph.NodeSet, error) {
+	if len(targets) == 0 {
+		return graph.NewNodeSet(), nil
+	}
+
+	if principals, err := ops.FetchStartNodes(tx.Relationships().Filterf(func() graph.Criteria {
+		return query.And(
+			query.Kind(query.Relationship(), kind),
+			query.InIDs(query.EndID(), targets...),
+		)
+	})); err != nil {
+		return nil, err
+	} else {
+		return removeTierZero(principals), nil
+	}
+}
+
+// fetchTierZeroMembers returns the IDs of the Tier Zero nodes of the given kind whose environment property matches the
+// one of the given environment node.
+func fetchTierZeroMembers(tx graph.Transaction, environment *graph.Node, kind graph.Kind, environmentProperty string) ([]graph.ID, error) {
+	if environmentID, err := environment.Properties.Get(environmentProperty).String(); err != nil {
+		return nil, err
+	} else {
+		return ops.FetchNodeIDs(tx.Nodes().Filterf(func() graph.Criteria {
+			return query.And(
+				query.Kind(query.Node(), kind),
+				query.Equals(query.NodeProperty(environmentProperty), environmentID),
+				query.StringContains(query.NodeProperty(common.SystemTags.String()), ad.AdminTierZero),
+			)
+		}))
+	}
+}
+
+func fetchNonTierZeroDCSyncPrincipals(tx graph.Transaction, domain *graph.Node) (graph.NodeSet, error) {
+	return fetchNonTierZeroStartNodes(tx, []graph.ID{domain.ID}, ad.DCSync)
+}
+
+func fetchESC1ToDomainPrincipals(tx graph.Transaction, domain *graph.Node) (graph.NodeSet, error) {
+	return fetchNonTierZeroStartNodes(tx, []graph.ID{domain.ID}, ad.ADCSESC1)
+}
+
+func fetchKerberoastableTierZeroUsers(tx graph.Transaction, domain *graph.Node) (graph.NodeSet, error) {
+	if domainSID, err := domain.Properties.Get(ad.DomainSID.String()).String(); err != nil {
+		return nil, err
+	} else {
+		return ops.FetchNodeSet(tx.Nodes().Filterf(func() graph.Criteria {
+			return query.And(
+				query.Kind(query.Node(), ad.User),
+				query.Equals(query.NodeProperty(ad.DomainSID.String()), domainSID),
+				query.Equals(query.NodeProperty(ad.HasSPN.String()), true),
+				query.Equals(query.NodeProperty(common.Enabled.String()), true),
+				query.StringContains(query.NodeProperty(common.SystemTags.String()), ad.AdminTierZero),
+			)
+		}))
+	}
+}
+
+func fetchNonTierZeroGenericAllTierZeroGPOPrincipals(tx graph.Transaction, domain *graph.Node) (graph.NodeSet, error) {
+	if gpoIDs, err := fetchTierZeroMembers(tx, domain, ad.GPO, ad.DomainSID.String()); err != nil {
+		return nil, err
+	} else {
+		return fetchNonTierZeroStartNodes(tx, gpoIDs, ad.GenericAll)
+	}
+}
+
+func fetchNonTierZeroResetPasswordAZUserPrincipals(tx graph.Transaction, tenant *graph.Node) (graph.NodeSet, error) {
+	if userIDs, err := fetchTierZeroMembers(tx, tenant, azure.User, azure.TenantID.String()); err != nil {
+		return nil, err
+	} else {
+		return fetchNonTierZeroStartNodes(tx, userIDs, azure.ResetPassword)
+	}
+}
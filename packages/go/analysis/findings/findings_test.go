@@ -0,0 +1,216 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package findings
+
+import (
+	"context"
+	"testing"
+
+	"github.com/specterops/bloodhound/dawgs"
+	"github.com/specterops/bloodhound/dawgs/drivers/memory"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/graphschema/ad"
+	"github.com/specterops/bloodhound/graphschema/azure"
+	"github.com/specterops/bloodhound/graphschema/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalog_UniqueNames(t *testing.T) {
+	seen := map[string]struct{}{}
+
+	for _, findingType := range Catalog() {
+		_, duplicate := seen[findingType.Name]
+		require.False(t, duplicate, findingType.Name)
+		require.NotNil(t, findingType.Principals, findingType.Name)
+
+		seen[findingType.Name] = struct{}{}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	findingType, found := Lookup(NonTierZeroDCSync)
+	require.True(t, found)
+	require.Equal(t, SeverityCritical, findingType.Severity)
+
+	_, found = Lookup("NotAFinding")
+	require.False(t, found)
+}
+
+func TestRemoveTierZero(t *testing.T) {
+	var (
+		tierZero = graph.NewNode(1, graph.AsProperties(map[string]any{
+			common.SystemTags.String(): ad.AdminTierZero,
+		}), ad.User)
+		other = graph.NewNode(2, graph.AsProperties(map[string]any{
+			common.SystemTags.String(): "other_tag",
+		}), ad.User)
+		untagged = graph.NewNode(3, graph.NewProperties(), ad.Group)
+		nodes    = graph.NewNodeSet(tierZero, other, untagged)
+	)
+
+	removeTierZero(nodes)
+
+	require.Equal(t, 2, nodes.Len())
+	require.False(t, nodes.ContainsID(tierZero.ID))
+}
+
+// newFindingsGraph creates a graph with a principal that triggers each finding type, a Tier Zero principal with the same
+// access that must not trigger it and a domain without a domain SID whose findings can not be scoped
+func newFindingsGraph(t *testing.T) graph.Database {
+	db, err := dawgs.Open(context.Background(), memory.DriverName, dawgs.Config{})
+	require.Nil(t, err)
+
+	require.Nil(t, db.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		newNode := func(properties map[string]any, kinds ...graph.Kind) *graph.Node {
+			node, err := tx.CreateNode(graph.AsProperties(properties), kinds...)
+			require.Nil(t, err)
+
+			return node
+		}
+
+		newRelationship := func(start, end *graph.Node, kind graph.Kind) {
+			_, err := tx.CreateRelationshipByIDs(start.ID, end.ID, kind, graph.NewProperties())
+			require.Nil(t, err)
+		}
+
+		newPrincipal := func(objectID string, tierZero bool, kinds ...graph.Kind) *graph.Node {
+			properties := map[string]any{
+				common.ObjectID.String(): objectID,
+			}
+
+			if tierZero {
+				properties[common.SystemTags.String()] = ad.AdminTierZero
+			}
+
+			return newNode(properties, kinds...)
+		}
+
+		var (
+			domain = newNode(map[string]any{
+				common.ObjectID.String(): "S-1-5-21-1",
+				ad.DomainSID.String():    "S-1-5-21-1",
+			}, ad.Entity, ad.Domain)
+			unidentifiedDomain = newNode(map[string]any{
+				common.ObjectID.String(): "UNIDENTIFIED",
+			}, ad.Entity, ad.Domain)
+			tierZeroAdmin = newPrincipal("ADMIN", true, ad.Entity, ad.User)
+		)
+
+		newNode(map[string]any{
+			common.ObjectID.String(): "TENANT",
+			azure.TenantID.String():  "TENANT",
+		}, azure.Entity, azure.Tenant)
+
+		// NonTierZeroDCSync
+		newRelationship(newPrincipal("DCSYNC", false, ad.Entity, ad.User), domain, ad.DCSync)
+		newRelationship(tierZeroAdmin, domain, ad.DCSync)
+		newRelationship(newPrincipal("UNIDENTIFIED DCSYNC", false, ad.Entity, ad.User), unidentifiedDomain, ad.DCSync)
+
+		// ESC1ToDomain
+		newRelationship(newPrincipal("ESC1", false, ad.Entity, ad.Group), domain, ad.ADCSESC1)
+		newRelationship(tierZeroAdmin, domain, ad.ADCSESC1)
+
+		// KerberoastableTierZeroUsers
+		for objectID, enabled := range map[string]bool{"KERBEROASTABLE": true, "DISABLED KERBEROASTABLE": false} {
+			newNode(map[string]any{
+				common.ObjectID.String():   objectID,
+				common.SystemTags.String(): ad.AdminTierZero,
+				common.Enabled.String():    enabled,
+				ad.DomainSID.String():      "S-1-5-21-1",
+				ad.HasSPN.String():         true,
+			}, ad.Entity, ad.User)
+		}
+
+		// NonTierZeroGenericAllTierZeroGPO
+		tierZeroGPO := newNode(map[string]any{
+			common.ObjectID.String():   "GPO",
+			common.SystemTags.String(): ad.AdminTierZero,
+			ad.DomainSID.String():      "S-1-5-21-1",
+		}, ad.Entity, ad.GPO)
+
+		newRelationship(newPrincipal("GENERICALL", false, ad.Entity, ad.User), tierZeroGPO, ad.GenericAll)
+		newRelationship(tierZeroAdmin, tierZeroGPO, ad.GenericAll)
+
+		// NonTierZeroResetPasswordTierZeroAZUsers
+		tierZeroAZUser := newNode(map[string]any{
+			common.ObjectID.String():   "AZUSER",
+			common.SystemTags.String(): ad.AdminTierZero,
+			azure.TenantID.String():    "TENANT",
+		}, azure.Entity, azure.User)
+
+		newRelationship(newPrincipal("RESETPASSWORD", false, azure.Entity, azure.User), tierZeroAZUser, azure.ResetPassword)
+
+		return nil
+	}))
+
+	return db
+}
+
+func TestEvaluate(t *testing.T) {
+	results, err := Evaluate(context.Background(), newFindingsGraph(t))
+	require.Nil(t, err)
+
+	principalsByFinding := map[string][]string{}
+
+	for _, result := range results {
+		environmentID, err := EnvironmentID(result.Environment)
+		require.Nil(t, err)
+
+		objectID, err := result.Principal.Properties.Get(common.ObjectID.String()).String()
+		require.Nil(t, err)
+
+		principalsByFinding[result.Type.Name] = append(principalsByFinding[result.Type.Name], environmentID+"/"+objectID)
+	}
+
+	expected := map[string][]string{
+		NonTierZeroDCSync:                {"S-1-5-21-1/DCSYNC"},
+		ESC1ToDomain:                     {"S-1-5-21-1/ESC1"},
+		KerberoastableTierZeroUsers:      {"S-1-5-21-1/KERBEROASTABLE"},
+		NonTierZeroGenericAllTierZeroGPO: {"S-1-5-21-1/GENERICALL"},
+		NonTierZeroResetPasswordAZUsers:  {"TENANT/RESETPASSWORD"},
+	}
+
+	for _, findingType := range Catalog() {
+		t.Run(findingType.Name, func(t *testing.T) {
+			require.Contains(t, expected, findingType.Name, "finding type has no test fixture")
+			require.ElementsMatch(t, expected[findingType.Name], principalsByFinding[findingType.Name])
+		})
+	}
+}
+
+func TestEnvironmentID(t *testing.T) {
+	var (
+		domain = graph.NewNode(1, graph.AsProperties(map[string]any{
+			ad.DomainSID.String(): "S-1-5-21-1",
+		}), ad.Entity, ad.Domain)
+		tenant = graph.NewNode(2, graph.AsProperties(map[string]any{
+			azure.TenantID.String(): "TENANT",
+		}), azure.Entity, azure.Tenant)
+		unidentified = graph.NewNode(3, graph.NewProperties(), azure.Entity, azure.Tenant)
+	)
+
+	environmentID, err := EnvironmentID(domain)
+	require.Nil(t, err)
+	require.Equal(t, "S-1-5-21-1", environmentID)
+
+	environmentID, err = EnvironmentID(tenant)
+	require.Nil(t, err)
+	require.Equal(t, "TENANT", environmentID)
+
+	_, err = EnvironmentID(unidentified)
+	require.NotNil(t, err)
+}
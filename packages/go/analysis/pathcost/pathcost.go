@@ -0,0 +1,142 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package pathcost prices attack path relationships so that pathfinding can rank paths by how easy they are to abuse
+// rather than by how few hops they contain.
+package pathcost
+
+import (
+	"time"
+
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/graphschema/ad"
+	"github.com/specterops/bloodhound/graphschema/azure"
+	"github.com/specterops/bloodhound/graphschema/common"
+)
+
+const (
+	// DefaultCost is the cost of any relationship kind that the model does not price explicitly
+	DefaultCost = 3
+
+	hoursPerDay = 24
+)
+
+// AgeCost adds cost to a relationship kind based on the age of a timestamp property. This is used to model that a
+// stale relationship, like an old session, is less likely to still be abusable.
+type AgeCost struct {
+	Kind       graph.Kind
+	Property   string
+	CostPerDay float64
+	MaxCost    float64
+}
+
+func (s AgeCost) cost(relationship *graph.Relationship, now time.Time) float64 {
+	if relationship.Kind != s.Kind || relationship.Properties == nil {
+		return 0
+	} else if timestamp, err := relationship.Properties.Get(s.Property).Time(); err != nil {
+		// A missing or malformed timestamp is treated as the oldest possible value
+		return s.MaxCost
+	} else if ageInDays := now.Sub(timestamp).Hours() / hoursPerDay; ageInDays <= 0 {
+		return 0
+	} else {
+		return min(ageInDays*s.CostPerDay, s.MaxCost)
+	}
+}
+
+// Model is a configurable relationship cost model. Costs are never negative.
+type Model struct {
+	DefaultCost float64
+	KindCosts   map[graph.Kind]float64
+	AgeCosts    []AgeCost
+	Now         func() time.Time
+}
+
+// DefaultModel returns the default cost model. Structural relationships are free, direct object control is cheap,
+// relationships that require an active session or code execution cost more and the ADCS escalations, which require
+// several preconditions to hold at once, are the most expensive.
+func DefaultModel() Model {
+	model := Model{
+		DefaultCost: DefaultCost,
+		KindCosts:   map[graph.Kind]float64{},
+		AgeCosts: []AgeCost{{
+			Kind:       ad.HasSession,
+			Property:   common.LastSeen.String(),
+			CostPerDay: 0.1,
+			MaxCost:    5,
+		}},
+		Now: time.Now,
+	}
+
+	for _, kind := range []graph.Kind{ad.MemberOf, ad.Contains, azure.MemberOf, azure.Contains} {
+		model.KindCosts[kind] = 0
+	}
+
+	for _, kind := range []graph.Kind{ad.GenericAll, ad.Owns, ad.GenericWrite, ad.WriteOwner, ad.WriteDACL, ad.AddMember, ad.AddSelf, ad.ForceChangePassword, ad.AllExtendedRights, ad.AdminTo, ad.DCSync, ad.GPLink} {
+		model.KindCosts[kind] = 1
+	}
+
+	for _, kind := range []graph.Kind{ad.HasSession, ad.CanRDP, ad.CanPSRemote, ad.ExecuteDCOM, ad.SQLAdmin, ad.ReadLAPSPassword, ad.ReadGMSAPassword, ad.SyncLAPSPassword, ad.DumpSMSAPassword} {
+		model.KindCosts[kind] = 2
+	}
+
+	for _, kind := range []graph.Kind{ad.GoldenCert, ad.ADCSESC1, ad.ADCSESC3, ad.ADCSESC4, ad.ADCSESC5, ad.ADCSESC6a, ad.ADCSESC6b, ad.ADCSESC7, ad.ADCSESC9a, ad.ADCSESC9b, ad.ADCSESC10a, ad.ADCSESC10b, ad.ADCSESC13} {
+		model.KindCosts[kind] = 5
+	}
+
+	return model
+}
+
+// WithKindCosts returns a copy of the model with the given per-kind costs layered over its own. Negative costs are
+// ignored.
+func (s Model) WithKindCosts(kindCosts map[string]float64) Model {
+	merged := make(map[graph.Kind]float64, len(s.KindCosts)+len(kindCosts))
+
+	for kind, cost := range s.KindCosts {
+		merged[kind] = cost
+	}
+
+	for kindStr, cost := range kindCosts {
+		if cost >= 0 {
+			merged[graph.StringKind(kindStr)] = cost
+		}
+	}
+
+	s.KindCosts = merged
+	return s
+}
+
+// Cost returns the cost of traversing the given relationship. This function satisfies traversal.CostFunction.
+func (s Model) Cost(relationship *graph.Relationship) float64 {
+	cost, priced := s.KindCosts[relationship.Kind]
+
+	if !priced {
+		cost = s.DefaultCost
+	}
+
+	if len(s.AgeCosts) > 0 {
+		now := time.Now()
+
+		if s.Now != nil {
+			now = s.Now()
+		}
+
+		for _, ageCost := range s.AgeCosts {
+			cost += ageCost.cost(relationship, now)
+		}
+	}
+
+	return max(cost, 0)
+}
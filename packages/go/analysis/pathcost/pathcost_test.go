@@ -0,0 +1,71 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pathcost_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/specterops/bloodhound/analysis/pathcost"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/graphschema/ad"
+	"github.com/specterops/bloodhound/graphschema/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModel_Cost(t *testing.T) {
+	var (
+		now   = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+		model = pathcost.DefaultModel()
+	)
+
+	model.Now = func() time.Time {
+		return now
+	}
+
+	require.Equal(t, float64(0), model.Cost(graph.NewRelationship(1, 1, 2, graph.NewProperties(), ad.MemberOf)))
+	require.Less(t, model.Cost(graph.NewRelationship(1, 1, 2, graph.NewProperties(), ad.GenericAll)), model.Cost(graph.NewRelationship(1, 1, 2, graph.NewProperties(), ad.ADCSESC9a)))
+	require.Equal(t, float64(pathcost.DefaultCost), model.Cost(graph.NewRelationship(1, 1, 2, graph.NewProperties(), ad.TrustedBy)))
+
+	var (
+		freshSession = graph.NewRelationship(1, 1, 2, graph.NewProperties().Set(common.LastSeen.String(), now.Format(time.RFC3339)), ad.HasSession)
+		staleSession = graph.NewRelationship(1, 1, 2, graph.NewProperties().Set(common.LastSeen.String(), now.Add(-10*24*time.Hour).Format(time.RFC3339)), ad.HasSession)
+		oldSession   = graph.NewRelationship(1, 1, 2, graph.NewProperties().Set(common.LastSeen.String(), now.Add(-365*24*time.Hour).Format(time.RFC3339)), ad.HasSession)
+		unknownAge   = graph.NewRelationship(1, 1, 2, graph.NewProperties(), ad.HasSession)
+	)
+
+	require.Equal(t, float64(2), model.Cost(freshSession))
+	require.InDelta(t, 3, model.Cost(staleSession), 0.0001)
+	require.Equal(t, float64(7), model.Cost(oldSession))
+	require.Equal(t, float64(7), model.Cost(unknownAge))
+}
+
+func TestModel_WithKindCosts(t *testing.T) {
+	var (
+		model      = pathcost.DefaultModel()
+		overridden = model.WithKindCosts(map[string]float64{
+			ad.GenericAll.String(): 10,
+			ad.Owns.String():       -1,
+		})
+	)
+
+	require.Equal(t, float64(10), overridden.Cost(graph.NewRelationship(1, 1, 2, graph.NewProperties(), ad.GenericAll)))
+	require.Equal(t, float64(1), overridden.Cost(graph.NewRelationship(1, 1, 2, graph.NewProperties(), ad.Owns)))
+
+	// The original model must not be modified
+	require.Equal(t, float64(1), model.Cost(graph.NewRelationship(1, 1, 2, graph.NewProperties(), ad.GenericAll)))
+}
@@ -0,0 +1,270 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/log"
+)
+
+var (
+	ErrPostProcessorMissingName       = errors.New("post-processor is missing a name")
+	ErrPostProcessorMissingDelegate   = errors.New("post-processor is missing a delegate")
+	ErrPostProcessorAlreadyRegistered = errors.New("post-processor already registered")
+	ErrPostProcessorDependencyMissing = errors.New("post-processor dependency is not registered")
+	ErrPostProcessorDependencyCycle   = errors.New("post-processor dependency cycle detected")
+	ErrPostProcessorDependencyFailed  = errors.New("post-processor dependency failed")
+)
+
+// FeatureFlags is a snapshot of feature flag states, keyed by flag key, taken at the start of a post-processing run.
+type FeatureFlags map[string]bool
+
+// Enabled returns true if the flag with the given key is known and enabled.
+func (s FeatureFlags) Enabled(key string) bool {
+	return s[key]
+}
+
+// PostProcessorResult describes the outcome of a single post-processor during a post-processing run.
+type PostProcessorResult struct {
+	Name     string
+	Skipped  bool
+	Started  time.Time
+	Duration time.Duration
+	Stats    *AtomicPostProcessingStats
+	Err      error
+}
+
+// PostProcessorObserver is notified with the result of every post-processor in a post-processing run.
+type PostProcessorObserver func(result PostProcessorResult)
+
+type PostProcessorDelegate func(ctx context.Context, db graph.Database, flags FeatureFlags) (*AtomicPostProcessingStats, error)
+
+// PostProcessor describes a single post-processing step that creates relationships derived from the ingested graph.
+type PostProcessor struct {
+	// Name uniquely identifies the post-processor and is the value other post-processors use to depend on it.
+	Name string
+
+	// DependsOn lists the names of post-processors that must run before this one. If any of them fails or is skipped,
+	// this post-processor is skipped.
+	DependsOn []string
+
+	// FeatureFlag is the key of the feature flag that toggles this post-processor. Post-processors without a feature
+	// flag always run.
+	FeatureFlag string
+
+	// EntityKind is the kind that both ends of the post-processed relationships share. It bounds the cleanup of
+	// PostProcessedKinds to relationships between entities of this kind.
+	EntityKind graph.Kind
+
+	// PostProcessedKinds are the relationship kinds created by this post-processor. Relationships of these kinds are
+	// deleted before every post-processing run, even if the post-processor itself is disabled.
+	PostProcessedKinds []graph.Kind
+
+	// Delegate performs the post-processing.
+	Delegate PostProcessorDelegate
+}
+
+// PostProcessorRegistry holds the post-processors that make up a post-processing run. Post-processors are run in
+// registration order unless their declared dependencies require otherwise.
+type PostProcessorRegistry struct {
+	processors []PostProcessor
+	lock       *sync.RWMutex
+}
+
+func NewPostProcessorRegistry() *PostProcessorRegistry {
+	return &PostProcessorRegistry{
+		lock: &sync.RWMutex{},
+	}
+}
+
+// Register adds the given post-processors to the registry. Dependencies are not validated until the registry is
+// ordered so that post-processors may be registered in any order.
+func (s *PostProcessorRegistry) Register(processors ...PostProcessor) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, processor := range processors {
+		if processor.Name == "" {
+			return ErrPostProcessorMissingName
+		} else if processor.Delegate == nil {
+			return fmt.Errorf("%w: %s", ErrPostProcessorMissingDelegate, processor.Name)
+		}
+
+		for _, registered := range s.processors {
+			if registered.Name == processor.Name {
+				return fmt.Errorf("%w: %s", ErrPostProcessorAlreadyRegistered, processor.Name)
+			}
+		}
+
+		s.processors = append(s.processors, processor)
+	}
+
+	return nil
+}
+
+// Ordered returns all registered post-processors sorted so that every post-processor comes after its dependencies.
+// Post-processors without an ordering constraint between them keep their registration order.
+func (s *PostProcessorRegistry) Ordered() ([]PostProcessor, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var (
+		ordered = make([]PostProcessor, 0, len(s.processors))
+		byName  = make(map[string]PostProcessor, len(s.processors))
+		visited = make(map[string]bool, len(s.processors))
+		visit   func(processor PostProcessor, path []string) error
+	)
+
+	for _, processor := range s.processors {
+		byName[processor.Name] = processor
+	}
+
+	// visited tracks post-processors on the current path as false and fully ordered post-processors as true
+	visit = func(processor PostProcessor, path []string) error {
+		if done, seen := visited[processor.Name]; seen {
+			if !done {
+				return fmt.Errorf("%w: %v", ErrPostProcessorDependencyCycle, append(path, processor.Name))
+			}
+
+			return nil
+		}
+
+		visited[processor.Name] = false
+
+		for _, dependencyName := range processor.DependsOn {
+			if dependency, found := byName[dependencyName]; !found {
+				return fmt.Errorf("%w: %s depends on %s", ErrPostProcessorDependencyMissing, processor.Name, dependencyName)
+			} else if err := visit(dependency, append(path, processor.Name)); err != nil {
+				return err
+			}
+		}
+
+		visited[processor.Name] = true
+		ordered = append(ordered, processor)
+
+		return nil
+	}
+
+	for _, processor := range s.processors {
+		if err := visit(processor, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
+
+// Run deletes all previously post-processed relationships and then runs every enabled post-processor in dependency
+// order. A failed post-processor does not stop the run, however post-processors that depend on it are skipped. All
+// failures are joined into the returned error. Post-processors that depend on one disabled by its feature flag are
+// skipped as well, without error. Observers, if any, are notified after each post-processor.
+func (s *PostProcessorRegistry) Run(ctx context.Context, db graph.Database, flags FeatureFlags, observers ...PostProcessorObserver) (*AtomicPostProcessingStats, error) {
+	var (
+		aggregateStats = NewAtomicPostProcessingStats()
+		failed         = map[string]struct{}{}
+		skipped        = map[string]struct{}{}
+		errs           []error
+	)
+
+	if processors, err := s.Ordered(); err != nil {
+		return &aggregateStats, err
+	} else {
+		for _, processor := range processors {
+			if len(processor.PostProcessedKinds) == 0 {
+				continue
+			}
+
+			if stats, err := DeleteTransitEdges(ctx, db, processor.EntityKind, processor.EntityKind, processor.PostProcessedKinds...); err != nil {
+				return &aggregateStats, fmt.Errorf("failed deleting post-processed relationships for %s: %w", processor.Name, err)
+			} else {
+				aggregateStats.Merge(stats)
+			}
+		}
+
+		for _, processor := range processors {
+			result := PostProcessorResult{
+				Name:    processor.Name,
+				Started: time.Now(),
+			}
+
+			if processor.FeatureFlag != "" && !flags.Enabled(processor.FeatureFlag) {
+				log.Infof("Skipping post-processor %s: feature flag %s is disabled", processor.Name, processor.FeatureFlag)
+				result.Skipped = true
+			} else if failedDependency, hasFailedDependency := firstDependencyIn(processor, failed); hasFailedDependency {
+				result.Skipped = true
+				result.Err = fmt.Errorf("%w: %s depends on %s", ErrPostProcessorDependencyFailed, processor.Name, failedDependency)
+			} else if skippedDependency, hasSkippedDependency := firstDependencyIn(processor, skipped); hasSkippedDependency {
+				log.Infof("Skipping post-processor %s: dependency %s was skipped", processor.Name, skippedDependency)
+				result.Skipped = true
+			} else if stats, err := processor.Delegate(ctx, db, flags); err != nil {
+				result.Err = fmt.Errorf("post-processor %s failed: %w", processor.Name, err)
+				result.Stats = stats
+			} else if stats != nil {
+				result.Stats = stats
+				aggregateStats.Merge(stats)
+			}
+
+			if result.Err != nil {
+				failed[processor.Name] = struct{}{}
+				errs = append(errs, result.Err)
+			} else if result.Skipped {
+				skipped[processor.Name] = struct{}{}
+			}
+
+			result.Duration = time.Since(result.Started)
+
+			for _, observer := range observers {
+				observer(result)
+			}
+		}
+	}
+
+	return &aggregateStats, errors.Join(errs...)
+}
+
+// firstDependencyIn returns the first dependency of the given post-processor that is a member of the given set.
+func firstDependencyIn(processor PostProcessor, processorNames map[string]struct{}) (string, bool) {
+	for _, dependency := range processor.DependsOn {
+		if _, isMember := processorNames[dependency]; isMember {
+			return dependency, true
+		}
+	}
+
+	return "", false
+}
+
+var defaultPostProcessorRegistry = NewPostProcessorRegistry()
+
+// DefaultPostProcessorRegistry returns the registry used by the analysis pipeline.
+func DefaultPostProcessorRegistry() *PostProcessorRegistry {
+	return defaultPostProcessorRegistry
+}
+
+// RegisterPostProcessor adds the given post-processors to the default registry. It is intended to be called from a
+// package init function, similar to how graph drivers register themselves with dawgs, and panics if registration
+// fails.
+func RegisterPostProcessor(processors ...PostProcessor) {
+	if err := defaultPostProcessorRegistry.Register(processors...); err != nil {
+		panic(fmt.Sprintf("failed registering post-processor: %v", err))
+	}
+}
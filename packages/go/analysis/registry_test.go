@@ -0,0 +1,174 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/specterops/bloodhound/analysis"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/stretchr/testify/require"
+)
+
+func recordingPostProcessor(name string, runs *[]string, err error, dependsOn ...string) analysis.PostProcessor {
+	return analysis.PostProcessor{
+		Name:      name,
+		DependsOn: dependsOn,
+		Delegate: func(ctx context.Context, db graph.Database, flags analysis.FeatureFlags) (*analysis.AtomicPostProcessingStats, error) {
+			*runs = append(*runs, name)
+
+			stats := analysis.NewAtomicPostProcessingStats()
+			return &stats, err
+		},
+	}
+}
+
+func processorNames(processors []analysis.PostProcessor) []string {
+	names := make([]string, len(processors))
+
+	for idx, processor := range processors {
+		names[idx] = processor.Name
+	}
+
+	return names
+}
+
+func TestPostProcessorRegistry_Register(t *testing.T) {
+	var (
+		registry = analysis.NewPostProcessorRegistry()
+		runs     []string
+	)
+
+	require.Nil(t, registry.Register(recordingPostProcessor("a", &runs, nil)))
+	require.ErrorIs(t, registry.Register(recordingPostProcessor("a", &runs, nil)), analysis.ErrPostProcessorAlreadyRegistered)
+	require.ErrorIs(t, registry.Register(recordingPostProcessor("", &runs, nil)), analysis.ErrPostProcessorMissingName)
+	require.ErrorIs(t, registry.Register(analysis.PostProcessor{Name: "b"}), analysis.ErrPostProcessorMissingDelegate)
+}
+
+func TestPostProcessorRegistry_Ordered(t *testing.T) {
+	var (
+		registry = analysis.NewPostProcessorRegistry()
+		runs     []string
+	)
+
+	require.Nil(t, registry.Register(
+		recordingPostProcessor("c", &runs, nil, "b"),
+		recordingPostProcessor("a", &runs, nil),
+		recordingPostProcessor("b", &runs, nil, "a"),
+		recordingPostProcessor("d", &runs, nil),
+	))
+
+	ordered, err := registry.Ordered()
+	require.Nil(t, err)
+	require.Equal(t, []string{"a", "b", "c", "d"}, processorNames(ordered))
+}
+
+func TestPostProcessorRegistry_Ordered_MissingDependency(t *testing.T) {
+	var (
+		registry = analysis.NewPostProcessorRegistry()
+		runs     []string
+	)
+
+	require.Nil(t, registry.Register(recordingPostProcessor("a", &runs, nil, "missing")))
+
+	_, err := registry.Ordered()
+	require.ErrorIs(t, err, analysis.ErrPostProcessorDependencyMissing)
+}
+
+func TestPostProcessorRegistry_Ordered_Cycle(t *testing.T) {
+	var (
+		registry = analysis.NewPostProcessorRegistry()
+		runs     []string
+	)
+
+	require.Nil(t, registry.Register(
+		recordingPostProcessor("a", &runs, nil, "c"),
+		recordingPostProcessor("b", &runs, nil, "a"),
+		recordingPostProcessor("c", &runs, nil, "b"),
+	))
+
+	_, err := registry.Ordered()
+	require.ErrorIs(t, err, analysis.ErrPostProcessorDependencyCycle)
+}
+
+func TestPostProcessorRegistry_Run(t *testing.T) {
+	var (
+		registry   = analysis.NewPostProcessorRegistry()
+		runs       []string
+		errFailed  = errors.New("failed")
+		flagged    = recordingPostProcessor("flagged", &runs, nil)
+		flaggedOff = recordingPostProcessor("flagged_off", &runs, nil)
+	)
+
+	flagged.FeatureFlag = "enabled_flag"
+	flaggedOff.FeatureFlag = "disabled_flag"
+
+	require.Nil(t, registry.Register(
+		recordingPostProcessor("a", &runs, nil),
+		recordingPostProcessor("failing", &runs, errFailed),
+		recordingPostProcessor("dependent", &runs, nil, "failing"),
+		flagged,
+		flaggedOff,
+	))
+
+	var skipped []string
+
+	_, err := registry.Run(context.Background(), nil, analysis.FeatureFlags{
+		"enabled_flag":  true,
+		"disabled_flag": false,
+	}, func(result analysis.PostProcessorResult) {
+		if result.Skipped {
+			skipped = append(skipped, result.Name)
+		}
+	})
+
+	require.ErrorIs(t, err, errFailed)
+	require.ErrorIs(t, err, analysis.ErrPostProcessorDependencyFailed)
+	require.Equal(t, []string{"a", "failing", "flagged"}, runs)
+	require.Equal(t, []string{"dependent", "flagged_off"}, skipped)
+}
+
+func TestPostProcessorRegistry_Run_SkippedDependency(t *testing.T) {
+	var (
+		registry   = analysis.NewPostProcessorRegistry()
+		runs       []string
+		skipped    []string
+		flaggedOff = recordingPostProcessor("flagged_off", &runs, nil)
+	)
+
+	flaggedOff.FeatureFlag = "disabled_flag"
+
+	require.Nil(t, registry.Register(
+		flaggedOff,
+		recordingPostProcessor("dependent", &runs, nil, "flagged_off"),
+		recordingPostProcessor("transitive", &runs, nil, "dependent"),
+		recordingPostProcessor("independent", &runs, nil),
+	))
+
+	_, err := registry.Run(context.Background(), nil, analysis.FeatureFlags{}, func(result analysis.PostProcessorResult) {
+		if result.Skipped {
+			require.Nil(t, result.Err)
+			skipped = append(skipped, result.Name)
+		}
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, []string{"independent"}, runs)
+	require.Equal(t, []string{"flagged_off", "dependent", "transitive"}, skipped)
+}
@@ -0,0 +1,269 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package snapshot
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/graphschema/ad"
+	"github.com/specterops/bloodhound/graphschema/azure"
+	"github.com/specterops/bloodhound/graphschema/common"
+)
+
+// ignoredProperties are left out of Tier Zero property comparisons. The first three change on every collection
+// without representing a meaningful change to the object while Tier Zero membership changes are reported separately.
+var ignoredProperties = map[string]struct{}{
+	common.LastSeen.String():       {},
+	ad.LastLogon.String():          {},
+	ad.LastLogonTimestamp.String(): {},
+	common.SystemTags.String():     {},
+}
+
+// PropertyChange describes a single property of a Tier Zero object that differs between two snapshots. Before or
+// After is nil when the property was added or removed.
+type PropertyChange struct {
+	ObjectID string `json:"objectid"`
+	Name     string `json:"name,omitempty"`
+	Property string `json:"property"`
+	Before   any    `json:"before"`
+	After    any    `json:"after"`
+}
+
+// Summary holds the totals of a Delta
+type Summary struct {
+	NodesAdded              int            `json:"nodes_added"`
+	NodesRemoved            int            `json:"nodes_removed"`
+	EdgesAdded              map[string]int `json:"edges_added"`
+	EdgesRemoved            map[string]int `json:"edges_removed"`
+	TierZeroPropertyChanges int            `json:"tier_zero_property_changes"`
+	NewTierZeroAttackPaths  int            `json:"new_tier_zero_attack_paths"`
+}
+
+// Delta is the structured difference between two snapshots. Added and removed edges are grouped by edge kind.
+type Delta struct {
+	Summary                 Summary           `json:"summary"`
+	NodesAdded              []Node            `json:"nodes_added"`
+	NodesRemoved            []Node            `json:"nodes_removed"`
+	EdgesAdded              map[string][]Edge `json:"edges_added"`
+	EdgesRemoved            map[string][]Edge `json:"edges_removed"`
+	TierZeroPropertyChanges []PropertyChange  `json:"tier_zero_property_changes"`
+	NewTierZeroAttackPaths  []Edge            `json:"new_tier_zero_attack_paths"`
+}
+
+func indexNodes(nodes []Node) map[string]Node {
+	index := make(map[string]Node, len(nodes))
+
+	for _, node := range nodes {
+		index[node.ObjectID] = node
+	}
+
+	return index
+}
+
+func indexEdges(edges []Edge) map[string]Edge {
+	index := make(map[string]Edge, len(edges))
+
+	for _, edge := range edges {
+		index[edge.key()] = edge
+	}
+
+	return index
+}
+
+func valuesEqual(before, after any) bool {
+	// Values are compared by their JSON representation so that a freshly captured snapshot compares equal to one that
+	// has been round-tripped through Encode and Decode
+	beforeJSON, beforeErr := json.Marshal(before)
+	afterJSON, afterErr := json.Marshal(after)
+
+	return beforeErr == nil && afterErr == nil && bytes.Equal(beforeJSON, afterJSON)
+}
+
+func diffProperties(before, after Node) []PropertyChange {
+	var (
+		changes []PropertyChange
+		keys    = map[string]struct{}{}
+	)
+
+	for key := range before.Properties {
+		keys[key] = struct{}{}
+	}
+
+	for key := range after.Properties {
+		keys[key] = struct{}{}
+	}
+
+	for key := range keys {
+		if _, ignored := ignoredProperties[key]; ignored {
+			continue
+		}
+
+		beforeValue, inBefore := before.Properties[key]
+		afterValue, inAfter := after.Properties[key]
+
+		if inBefore != inAfter || !valuesEqual(beforeValue, afterValue) {
+			changes = append(changes, PropertyChange{
+				ObjectID: after.ObjectID,
+				Name:     after.Name,
+				Property: key,
+				Before:   beforeValue,
+				After:    afterValue,
+			})
+		}
+	}
+
+	return changes
+}
+
+func pathfindingKinds() map[string]struct{} {
+	kinds := map[string]struct{}{}
+
+	for _, kind := range append(ad.PathfindingRelationships(), azure.PathfindingRelationships()...) {
+		kinds[kind.String()] = struct{}{}
+	}
+
+	return kinds
+}
+
+// entersTierZero returns true if the edge is traversable and leads from outside of Tier Zero into Tier Zero
+func entersTierZero(edge Edge, nodes map[string]Node, traversable map[string]struct{}) bool {
+	if _, isTraversable := traversable[edge.Kind]; !isTraversable {
+		return false
+	}
+
+	start, hasStart := nodes[edge.Start]
+	end, hasEnd := nodes[edge.End]
+
+	return hasStart && hasEnd && !start.TierZero && end.TierZero
+}
+
+func sortEdges(edges []Edge) {
+	sort.Slice(edges, func(i, j int) bool {
+		return edges[i].key() < edges[j].key()
+	})
+}
+
+// Diff computes the delta required to get from one snapshot to the other. A new attack path to Tier Zero is any
+// traversable edge leading from a non-Tier Zero object into Tier Zero that did not do so in the earlier snapshot,
+// either because the edge is new or because one of its ends changed tier.
+func Diff(from, to Snapshot) Delta {
+	var (
+		fromNodes   = indexNodes(from.Nodes)
+		toNodes     = indexNodes(to.Nodes)
+		fromEdges   = indexEdges(from.Edges)
+		toEdges     = indexEdges(to.Edges)
+		traversable = pathfindingKinds()
+		delta       = Delta{
+			NodesAdded:              []Node{},
+			NodesRemoved:            []Node{},
+			EdgesAdded:              map[string][]Edge{},
+			EdgesRemoved:            map[string][]Edge{},
+			TierZeroPropertyChanges: []PropertyChange{},
+			NewTierZeroAttackPaths:  []Edge{},
+		}
+	)
+
+	for _, node := range to.Nodes {
+		if previous, existed := fromNodes[node.ObjectID]; !existed {
+			delta.NodesAdded = append(delta.NodesAdded, node)
+		} else if previous.TierZero && node.TierZero {
+			delta.TierZeroPropertyChanges = append(delta.TierZeroPropertyChanges, diffProperties(previous, node)...)
+		} else if previous.TierZero != node.TierZero {
+			delta.TierZeroPropertyChanges = append(delta.TierZeroPropertyChanges, PropertyChange{
+				ObjectID: node.ObjectID,
+				Name:     node.Name,
+				Property: common.SystemTags.String(),
+				Before:   previous.SystemTags,
+				After:    node.SystemTags,
+			})
+		}
+	}
+
+	for _, node := range from.Nodes {
+		if _, exists := toNodes[node.ObjectID]; !exists {
+			delta.NodesRemoved = append(delta.NodesRemoved, node)
+		}
+	}
+
+	for key, edge := range toEdges {
+		_, existed := fromEdges[key]
+
+		if !existed {
+			delta.EdgesAdded[edge.Kind] = append(delta.EdgesAdded[edge.Kind], edge)
+		}
+
+		if entersTierZero(edge, toNodes, traversable) && (!existed || !entersTierZero(edge, fromNodes, traversable)) {
+			delta.NewTierZeroAttackPaths = append(delta.NewTierZeroAttackPaths, edge)
+		}
+	}
+
+	for key, edge := range fromEdges {
+		if _, exists := toEdges[key]; !exists {
+			delta.EdgesRemoved[edge.Kind] = append(delta.EdgesRemoved[edge.Kind], edge)
+		}
+	}
+
+	for _, edges := range delta.EdgesAdded {
+		sortEdges(edges)
+	}
+
+	for _, edges := range delta.EdgesRemoved {
+		sortEdges(edges)
+	}
+
+	sortEdges(delta.NewTierZeroAttackPaths)
+
+	sort.Slice(delta.TierZeroPropertyChanges, func(i, j int) bool {
+		if delta.TierZeroPropertyChanges[i].ObjectID != delta.TierZeroPropertyChanges[j].ObjectID {
+			return delta.TierZeroPropertyChanges[i].ObjectID < delta.TierZeroPropertyChanges[j].ObjectID
+		}
+
+		return delta.TierZeroPropertyChanges[i].Property < delta.TierZeroPropertyChanges[j].Property
+	})
+
+	delta.Summary = summarize(delta)
+	return delta
+}
+
+func summarize(delta Delta) Summary {
+	summary := Summary{
+		NodesAdded:              len(delta.NodesAdded),
+		NodesRemoved:            len(delta.NodesRemoved),
+		EdgesAdded:              make(map[string]int, len(delta.EdgesAdded)),
+		EdgesRemoved:            make(map[string]int, len(delta.EdgesRemoved)),
+		TierZeroPropertyChanges: len(delta.TierZeroPropertyChanges),
+		NewTierZeroAttackPaths:  len(delta.NewTierZeroAttackPaths),
+	}
+
+	for kind, edges := range delta.EdgesAdded {
+		summary.EdgesAdded[kind] = len(edges)
+	}
+
+	for kind, edges := range delta.EdgesRemoved {
+		summary.EdgesRemoved[kind] = len(edges)
+	}
+
+	return summary
+}
+
+// GraphKinds returns the kinds of the node as graph kinds
+func (s Node) GraphKinds() graph.Kinds {
+	return graph.StringsToKinds(s.Kinds)
+}
@@ -0,0 +1,181 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package snapshot captures compact summaries of the graph at the end of an analysis run and computes the delta between
+// two of them. Snapshots identify entities by object ID so that they may be compared across re-collections where the
+// underlying graph IDs are not stable.
+package snapshot
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/graphschema/ad"
+	"github.com/specterops/bloodhound/graphschema/common"
+)
+
+// Node is the summary of a single graph node. Properties are only retained for Tier Zero nodes.
+type Node struct {
+	ObjectID   string         `json:"objectid"`
+	Name       string         `json:"name,omitempty"`
+	Kinds      []string       `json:"kinds"`
+	SystemTags string         `json:"system_tags,omitempty"`
+	TierZero   bool           `json:"tier_zero,omitempty"`
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
+// Edge is the summary of a single graph edge with both ends identified by object ID.
+type Edge struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+	Kind  string `json:"kind"`
+}
+
+func (s Edge) key() string {
+	return s.Start + "\x00" + s.Kind + "\x00" + s.End
+}
+
+type Snapshot struct {
+	CreatedAt time.Time `json:"created_at"`
+	Nodes     []Node    `json:"nodes"`
+	Edges     []Edge    `json:"edges"`
+}
+
+func isTierZero(systemTags string) bool {
+	for _, tag := range strings.Fields(systemTags) {
+		if tag == ad.AdminTierZero {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Capture summarizes the current contents of the graph. Nodes without an object ID can not be correlated between
+// snapshots and are left out, as are any edges attached to them.
+func Capture(ctx context.Context, db graph.Database) (Snapshot, error) {
+	snapshot := Snapshot{
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if err := db.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		objectIDs := map[graph.ID]string{}
+
+		if err := tx.Nodes().Fetch(func(cursor graph.Cursor[*graph.Node]) error {
+			for next := range cursor.Chan() {
+				objectID, err := next.Properties.Get(common.ObjectID.String()).String()
+				if err != nil || objectID == "" {
+					continue
+				}
+
+				var (
+					systemTags, _ = next.Properties.GetOrDefault(common.SystemTags.String(), "").String()
+					name, _       = next.Properties.GetOrDefault(common.Name.String(), "").String()
+					node          = Node{
+						ObjectID:   objectID,
+						Name:       name,
+						Kinds:      next.Kinds.Strings(),
+						SystemTags: systemTags,
+						TierZero:   isTierZero(systemTags),
+					}
+				)
+
+				if node.TierZero {
+					node.Properties = next.Properties.MapOrEmpty()
+				}
+
+				objectIDs[next.ID] = objectID
+				snapshot.Nodes = append(snapshot.Nodes, node)
+			}
+
+			return cursor.Error()
+		}); err != nil {
+			return fmt.Errorf("failed summarizing nodes: %w", err)
+		}
+
+		if err := tx.Relationships().FetchKinds(func(cursor graph.Cursor[graph.RelationshipKindsResult]) error {
+			for next := range cursor.Chan() {
+				start, hasStart := objectIDs[next.StartID]
+				end, hasEnd := objectIDs[next.EndID]
+
+				if hasStart && hasEnd {
+					snapshot.Edges = append(snapshot.Edges, Edge{
+						Start: start,
+						End:   end,
+						Kind:  next.Kind.String(),
+					})
+				}
+			}
+
+			return cursor.Error()
+		}); err != nil {
+			return fmt.Errorf("failed summarizing edges: %w", err)
+		}
+
+		return nil
+	}); err != nil {
+		return Snapshot{}, err
+	}
+
+	sort.Slice(snapshot.Nodes, func(i, j int) bool {
+		return snapshot.Nodes[i].ObjectID < snapshot.Nodes[j].ObjectID
+	})
+
+	sort.Slice(snapshot.Edges, func(i, j int) bool {
+		return snapshot.Edges[i].key() < snapshot.Edges[j].key()
+	})
+
+	return snapshot, nil
+}
+
+// Encode serializes the snapshot as compressed JSON.
+func Encode(snapshot Snapshot) ([]byte, error) {
+	var (
+		buffer           bytes.Buffer
+		compressedWriter = gzip.NewWriter(&buffer)
+	)
+
+	if err := json.NewEncoder(compressedWriter).Encode(snapshot); err != nil {
+		return nil, err
+	} else if err := compressedWriter.Close(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// Decode deserializes a snapshot previously serialized by Encode.
+func Decode(content []byte) (Snapshot, error) {
+	var snapshot Snapshot
+
+	if compressedReader, err := gzip.NewReader(bytes.NewReader(content)); err != nil {
+		return snapshot, fmt.Errorf("failed opening snapshot: %w", err)
+	} else if decompressed, err := io.ReadAll(compressedReader); err != nil {
+		return snapshot, fmt.Errorf("failed reading snapshot: %w", err)
+	} else if err := json.Unmarshal(decompressed, &snapshot); err != nil {
+		return snapshot, fmt.Errorf("failed decoding snapshot: %w", err)
+	}
+
+	return snapshot, nil
+}
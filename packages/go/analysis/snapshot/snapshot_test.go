@@ -0,0 +1,208 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package snapshot_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/specterops/bloodhound/analysis/snapshot"
+	"github.com/specterops/bloodhound/dawgs"
+	"github.com/specterops/bloodhound/dawgs/drivers/memory"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/query"
+	"github.com/specterops/bloodhound/graphschema/ad"
+	"github.com/specterops/bloodhound/graphschema/common"
+	"github.com/stretchr/testify/require"
+)
+
+func openGraph(t *testing.T) graph.Database {
+	db, err := dawgs.Open(context.Background(), memory.DriverName, dawgs.Config{})
+	require.Nil(t, err)
+
+	require.Nil(t, db.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		var (
+			user = graph.AsProperties(map[string]any{
+				common.ObjectID.String(): "S-1-5-21-1000",
+				common.Name.String():     "ALICE@TESTLAB.LOCAL",
+			})
+			group = graph.AsProperties(map[string]any{
+				common.ObjectID.String():   "S-1-5-21-512",
+				common.Name.String():       "DOMAIN ADMINS@TESTLAB.LOCAL",
+				common.SystemTags.String(): ad.AdminTierZero,
+				common.LastSeen.String():   "2024-01-01T00:00:00Z",
+				"admincount":               true,
+			})
+			unidentified = graph.NewProperties()
+		)
+
+		if userNode, err := tx.CreateNode(user, ad.Entity, ad.User); err != nil {
+			return err
+		} else if groupNode, err := tx.CreateNode(group, ad.Entity, ad.Group); err != nil {
+			return err
+		} else if unidentifiedNode, err := tx.CreateNode(unidentified, ad.Entity); err != nil {
+			return err
+		} else if _, err := tx.CreateRelationshipByIDs(userNode.ID, groupNode.ID, ad.MemberOf, graph.NewProperties()); err != nil {
+			return err
+		} else if _, err := tx.CreateRelationshipByIDs(unidentifiedNode.ID, groupNode.ID, ad.MemberOf, graph.NewProperties()); err != nil {
+			return err
+		}
+
+		return nil
+	}))
+
+	return db
+}
+
+func capture(t *testing.T, db graph.Database) snapshot.Snapshot {
+	captured, err := snapshot.Capture(context.Background(), db)
+	require.Nil(t, err)
+
+	return captured
+}
+
+func TestCapture(t *testing.T) {
+	captured := capture(t, openGraph(t))
+
+	require.Len(t, captured.Nodes, 2)
+	require.Equal(t, "S-1-5-21-1000", captured.Nodes[0].ObjectID)
+	require.False(t, captured.Nodes[0].TierZero)
+	require.Nil(t, captured.Nodes[0].Properties)
+	require.Equal(t, "S-1-5-21-512", captured.Nodes[1].ObjectID)
+	require.True(t, captured.Nodes[1].TierZero)
+	require.Equal(t, true, captured.Nodes[1].Properties["admincount"])
+
+	// The edge from the node without an object ID can not be correlated and is left out
+	require.Equal(t, []snapshot.Edge{{Start: "S-1-5-21-1000", End: "S-1-5-21-512", Kind: ad.MemberOf.String()}}, captured.Edges)
+}
+
+func TestEncodeDecode(t *testing.T) {
+	captured := capture(t, openGraph(t))
+
+	encoded, err := snapshot.Encode(captured)
+	require.Nil(t, err)
+
+	decoded, err := snapshot.Decode(encoded)
+	require.Nil(t, err)
+	require.Equal(t, captured.Edges, decoded.Edges)
+	require.Len(t, decoded.Nodes, len(captured.Nodes))
+
+	_, err = snapshot.Decode([]byte("not a snapshot"))
+	require.NotNil(t, err)
+}
+
+func TestDiff_Unchanged(t *testing.T) {
+	var (
+		db     = openGraph(t)
+		before = capture(t, db)
+	)
+
+	// Volatile properties are not reported as changes
+	require.Nil(t, db.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		return tx.Nodes().Filter(query.Equals(query.NodeProperty(common.ObjectID.String()), "S-1-5-21-512")).Update(graph.NewProperties().Set(common.LastSeen.String(), "2024-02-01T00:00:00Z"))
+	}))
+
+	encoded, err := snapshot.Encode(before)
+	require.Nil(t, err)
+
+	decoded, err := snapshot.Decode(encoded)
+	require.Nil(t, err)
+
+	delta := snapshot.Diff(decoded, capture(t, db))
+	require.Equal(t, snapshot.Summary{
+		EdgesAdded:   map[string]int{},
+		EdgesRemoved: map[string]int{},
+	}, delta.Summary)
+}
+
+func TestDiff(t *testing.T) {
+	var (
+		db     = openGraph(t)
+		before = capture(t, db)
+	)
+
+	require.Nil(t, db.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		computer, err := tx.CreateNode(graph.AsProperties(map[string]any{
+			common.ObjectID.String(): "S-1-5-21-2000",
+			common.Name.String():     "WS01.TESTLAB.LOCAL",
+		}), ad.Entity, ad.Computer)
+		if err != nil {
+			return err
+		}
+
+		if group, err := tx.Nodes().Filter(query.Equals(query.NodeProperty(common.ObjectID.String()), "S-1-5-21-512")).First(); err != nil {
+			return err
+		} else if _, err := tx.CreateRelationshipByIDs(computer.ID, group.ID, ad.GenericAll, graph.NewProperties()); err != nil {
+			return err
+		} else if err := tx.Nodes().Filter(query.Equals(query.NodeID(), group.ID)).Update(graph.NewProperties().Set("admincount", false)); err != nil {
+			return err
+		}
+
+		return tx.Nodes().Filter(query.Equals(query.NodeProperty(common.ObjectID.String()), "S-1-5-21-1000")).Delete()
+	}))
+
+	delta := snapshot.Diff(before, capture(t, db))
+
+	require.Equal(t, 1, delta.Summary.NodesAdded)
+	require.Equal(t, "S-1-5-21-2000", delta.NodesAdded[0].ObjectID)
+	require.Equal(t, 1, delta.Summary.NodesRemoved)
+	require.Equal(t, "S-1-5-21-1000", delta.NodesRemoved[0].ObjectID)
+
+	require.Equal(t, map[string]int{ad.GenericAll.String(): 1}, delta.Summary.EdgesAdded)
+	require.Equal(t, map[string]int{ad.MemberOf.String(): 1}, delta.Summary.EdgesRemoved)
+
+	require.Equal(t, []snapshot.PropertyChange{{
+		ObjectID: "S-1-5-21-512",
+		Name:     "DOMAIN ADMINS@TESTLAB.LOCAL",
+		Property: "admincount",
+		Before:   true,
+		After:    false,
+	}}, delta.TierZeroPropertyChanges)
+
+	require.Equal(t, []snapshot.Edge{{Start: "S-1-5-21-2000", End: "S-1-5-21-512", Kind: ad.GenericAll.String()}}, delta.NewTierZeroAttackPaths)
+}
+
+func TestDiff_TierZeroMembershipChange(t *testing.T) {
+	var (
+		db     = openGraph(t)
+		before = capture(t, db)
+	)
+
+	// Demoting the group out of Tier Zero is reported as a change of its system tags while promoting the user creates
+	// an attack path through the existing MemberOf edge
+	require.Nil(t, db.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		if err := tx.Nodes().Filter(query.Equals(query.NodeProperty(common.ObjectID.String()), "S-1-5-21-512")).Update(graph.NewProperties().Set(common.SystemTags.String(), "")); err != nil {
+			return err
+		}
+
+		return tx.Nodes().Filter(query.Equals(query.NodeProperty(common.ObjectID.String()), "S-1-5-21-1000")).Update(graph.NewProperties().Set(common.SystemTags.String(), ad.AdminTierZero))
+	}))
+
+	delta := snapshot.Diff(before, capture(t, db))
+
+	require.Len(t, delta.TierZeroPropertyChanges, 2)
+	require.Equal(t, common.SystemTags.String(), delta.TierZeroPropertyChanges[0].Property)
+	require.Equal(t, "", delta.TierZeroPropertyChanges[0].Before)
+	require.Equal(t, ad.AdminTierZero, delta.TierZeroPropertyChanges[0].After)
+	require.Empty(t, delta.NewTierZeroAttackPaths)
+
+	// Flip the direction so that the non-Tier Zero group leads into the newly promoted user
+	after := capture(t, db)
+	after.Edges = append(after.Edges, snapshot.Edge{Start: "S-1-5-21-512", End: "S-1-5-21-1000", Kind: ad.GenericWrite.String()})
+
+	require.Equal(t, []snapshot.Edge{{Start: "S-1-5-21-512", End: "S-1-5-21-1000", Kind: ad.GenericWrite.String()}}, snapshot.Diff(before, after).NewTierZeroAttackPaths)
+}
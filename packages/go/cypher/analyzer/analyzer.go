@@ -19,6 +19,8 @@ package analyzer
 import (
 	"errors"
 	"fmt"
+	"sort"
+
 	"github.com/specterops/bloodhound/cypher/model"
 	"github.com/specterops/bloodhound/dawgs/graph"
 )
@@ -72,23 +74,87 @@ const (
 	Weight3
 )
 
+// MaxExpansionDepth is the deepest expansion that a variable-length relationship pattern may request. Backends that
+// must materialize expansions eagerly also use this value as the depth limit of unbounded patterns.
+const MaxExpansionDepth int64 = 15
+
+// ExpansionDepth returns the inclusive minimum and maximum expansion depth of the given variable-length pattern
+// range. Patterns without a floor default to a single hop and patterns without a ceiling are limited to
+// MaxExpansionDepth.
+func ExpansionDepth(patternRange *model.PatternRange) (int64, int64, error) {
+	var (
+		minDepth int64 = 1
+		maxDepth       = MaxExpansionDepth
+	)
+
+	if patternRange.StartIndex != nil {
+		minDepth = *patternRange.StartIndex
+	}
+
+	if patternRange.EndIndex != nil {
+		maxDepth = *patternRange.EndIndex
+	}
+
+	if maxDepth > MaxExpansionDepth {
+		return 0, 0, fmt.Errorf("variable-length pattern depth %d exceeds the maximum depth of %d", maxDepth, MaxExpansionDepth)
+	}
+
+	if minDepth > maxDepth {
+		return 0, 0, fmt.Errorf("variable-length pattern minimum depth %d is greater than its maximum depth %d", minDepth, maxDepth)
+	}
+
+	return minDepth, maxDepth, nil
+}
+
+// Query constructs that may contribute to the complexity of a query
+const (
+	ConstructFunctionInvocation = "function_invocation"
+	ConstructQuantifier         = "quantifier"
+	ConstructFilterExpression   = "filter_expression"
+	ConstructPattern            = "pattern"
+	ConstructSort               = "sort"
+	ConstructProjection         = "projection"
+	ConstructComparison         = "comparison"
+	ConstructNodePattern        = "node_pattern"
+	ConstructRelationship       = "relationship_pattern"
+)
+
+// ComplexityContribution records the weight that a single query construct added to the complexity of a query
+type ComplexityContribution struct {
+	Construct string
+	Reason    string
+	Weight    float64
+}
+
 type ComplexityMeasure struct {
-	Weight float64
+	Weight        float64
+	Contributions []ComplexityContribution
 
 	numPatterns     float64
 	numProjections  float64
 	nodeLookupKinds map[string]graph.Kinds
 }
 
+func (s *ComplexityMeasure) add(construct, reason string, weight float64) {
+	if weight > 0 {
+		s.Weight += weight
+		s.Contributions = append(s.Contributions, ComplexityContribution{
+			Construct: construct,
+			Reason:    reason,
+			Weight:    weight,
+		})
+	}
+}
+
 func (s *ComplexityMeasure) onFunctionInvocation(_ *model.WalkStack, node *model.FunctionInvocation) error {
 	switch node.Name {
 	case "collect":
 		// Collect will force an eager aggregation
-		s.Weight += Weight2
+		s.add(ConstructFunctionInvocation, "collect forces an eager aggregation", Weight2)
 
 	case "type":
 		// Calling for a relationship's type is highly likely to be inefficient and should add weight
-		s.Weight += Weight2
+		s.add(ConstructFunctionInvocation, "matching on a relationship's type is likely to be inefficient", Weight2)
 	}
 
 	return nil
@@ -97,14 +163,14 @@ func (s *ComplexityMeasure) onFunctionInvocation(_ *model.WalkStack, node *model
 func (s *ComplexityMeasure) onQuantifier(_ *model.WalkStack, _ *model.Quantifier) error {
 	// Quantifier expressions may increase the size of an inline projection to apply its contained filter and should
 	// be weighted
-	s.Weight += Weight1
+	s.add(ConstructQuantifier, "quantifiers may increase the size of an inline projection", Weight1)
 	return nil
 }
 
 func (s *ComplexityMeasure) onFilterExpression(_ *model.WalkStack, _ *model.FilterExpression) error {
 	// Filter expressions convert directly into a filter in the query plan which may or may not take advantage
 	// of indexes and should be weighted accordingly
-	s.Weight += Weight1
+	s.add(ConstructFilterExpression, "filter expressions may not take advantage of indexes", Weight1)
 	return nil
 }
 
@@ -121,17 +187,17 @@ func (s *ComplexityMeasure) onKindMatcher(_ *model.WalkStack, node *model.KindMa
 func (s *ComplexityMeasure) onPatternPart(_ *model.WalkStack, node *model.PatternPart) error {
 	// All pattern parts incur a compounding weight
 	s.numPatterns += 1
-	s.Weight += s.numPatterns
+	s.add(ConstructPattern, "each additional pattern compounds the cost of the query", s.numPatterns)
 
 	if node.ShortestPathPattern {
 		// Rendering the shortest path, while cheaper than rendering all shortest paths, still could incur a large
 		// search cost
-		s.Weight += Weight1
+		s.add(ConstructPattern, "shortest path searches may incur a large search cost", Weight1)
 	}
 
 	if node.AllShortestPathsPattern {
 		// Rendering all shortest paths could result in a large search
-		s.Weight += Weight2
+		s.add(ConstructPattern, "all shortest paths searches may result in a large search", Weight2)
 	}
 
 	return nil
@@ -139,18 +205,18 @@ func (s *ComplexityMeasure) onPatternPart(_ *model.WalkStack, node *model.Patter
 
 func (s *ComplexityMeasure) onSortItem(_ *model.WalkStack, _ *model.SortItem) error {
 	// Sorting incurs a weight since it will change how the projection is materialized
-	s.Weight += Weight1
+	s.add(ConstructSort, "sorting changes how the projection is materialized", Weight1)
 	return nil
 }
 
 func (s *ComplexityMeasure) onProjection(_ *model.WalkStack, node *model.Projection) error {
 	// We want to capture the cost of additional inline projections so ignore the first projection
-	s.Weight += s.numProjections
+	s.add(ConstructProjection, "each additional inline projection must be materialized", s.numProjections)
 	s.numProjections += 1
 
 	if node.Distinct {
 		// Distinct incurs a weight since it will change how the projection is materialized
-		s.Weight += Weight1
+		s.add(ConstructProjection, "distinct changes how the projection is materialized", Weight1)
 	}
 
 	return nil
@@ -161,7 +227,7 @@ func (s *ComplexityMeasure) onPartialComparison(_ *model.WalkStack, node *model.
 	case model.OperatorRegexMatch:
 		// Regular expression matching incurs a weight since it can be far more involved than any of the other
 		// string operators
-		s.Weight += Weight1
+		s.add(ConstructComparison, "regular expression matching is more involved than other string operators", Weight1)
 	}
 
 	return nil
@@ -171,7 +237,7 @@ func (s *ComplexityMeasure) onNodePattern(_ *model.WalkStack, node *model.NodePa
 	if node.Binding == nil {
 		if len(node.Kinds) == 0 {
 			// Unlabeled, unbound nodes will incur a lookup of all nodes in the graph
-			s.Weight += Weight2
+			s.add(ConstructNodePattern, "unlabeled, unbound nodes require a lookup of all nodes", Weight2)
 		}
 	} else if nodePatternBinding, typeOK := node.Binding.(*model.Variable); !typeOK {
 		return fmt.Errorf("expected variable for node pattern binding but got: %T", node.Binding)
@@ -195,35 +261,35 @@ func (s *ComplexityMeasure) onRelationshipPattern(_ *model.WalkStack, node *mode
 	numKindMatchers := len(node.Kinds)
 
 	// All relationship lookups incur a weight
-	s.Weight += Weight1
+	s.add(ConstructRelationship, "relationship lookups", Weight1)
 
 	if node.Direction == graph.DirectionBoth {
 		// Bidirectional searches add weight
-		s.Weight += Weight1
+		s.add(ConstructRelationship, "bidirectional relationship searches", Weight1)
 	}
 
 	if numKindMatchers == 0 {
 		// If user is expanding all relationship types add weight
-		s.Weight += Weight2
+		s.add(ConstructRelationship, "relationship patterns without a type expand all relationship types", Weight2)
 	}
 
 	if node.Range != nil {
 		if numKindMatchers > 2 {
 			// If we're matching on more than two relationship types add weight
-			s.Weight += Weight1
+			s.add(ConstructRelationship, "variable-length patterns matching more than two relationship types", Weight1)
 		}
 
 		if node.Range.StartIndex != nil && *node.Range.StartIndex > 1 {
 			// Patterns that must have a floor greater than 1 may result in large expansions
-			s.Weight += Weight1
+			s.add(ConstructRelationship, "variable-length patterns with a minimum depth greater than 1 may result in large expansions", Weight1)
 		}
 
 		if node.Range.EndIndex == nil {
 			// Unbounded range literals are likely to result in large expansions
-			s.Weight += Weight3
+			s.add(ConstructRelationship, "unbounded variable-length patterns are likely to result in large expansions", Weight3)
 		} else if *node.Range.EndIndex > 1 {
 			// Patterns that must have a ceiling greater than 1 may result in large expansions
-			s.Weight += Weight1
+			s.add(ConstructRelationship, "variable-length patterns with a maximum depth greater than 1 may result in large expansions", Weight1)
 		}
 	}
 
@@ -231,10 +297,19 @@ func (s *ComplexityMeasure) onRelationshipPattern(_ *model.WalkStack, node *mode
 }
 
 func (s *ComplexityMeasure) onExit() {
-	for _, kindMatchers := range s.nodeLookupKinds {
-		if len(kindMatchers) == 0 {
+	// Visit bindings in a stable order so that contributions are reported consistently
+	symbols := make([]string, 0, len(s.nodeLookupKinds))
+
+	for symbol := range s.nodeLookupKinds {
+		symbols = append(symbols, symbol)
+	}
+
+	sort.Strings(symbols)
+
+	for _, symbol := range symbols {
+		if len(s.nodeLookupKinds[symbol]) == 0 {
 			// Unlabeled nodes will incur a lookup of all nodes in the graph
-			s.Weight += Weight2
+			s.add(ConstructNodePattern, fmt.Sprintf("unlabeled node %s requires a lookup of all nodes", symbol), Weight2)
 		}
 	}
 }
@@ -21,6 +21,7 @@ import (
 
 	"github.com/specterops/bloodhound/cypher/analyzer"
 	"github.com/specterops/bloodhound/cypher/frontend"
+	"github.com/specterops/bloodhound/cypher/model"
 	"github.com/specterops/bloodhound/cypher/test"
 	"github.com/stretchr/testify/require"
 )
@@ -49,8 +50,114 @@ func TestQueryComplexity(t *testing.T) {
 					}
 
 					require.Equal(t, *details.Complexity, complexity.Weight)
+
+					// The contributions of a query must account for its entire weight
+					contributedWeight := float64(0)
+
+					for _, contribution := range complexity.Contributions {
+						contributedWeight += contribution.Weight
+					}
+
+					require.Equal(t, complexity.Weight, contributedWeight)
 				}
 			}
 		})
 	}
 }
+
+func TestQueryComplexity_Contributions(t *testing.T) {
+	queryModel, err := frontend.ParseCypher(frontend.NewContext(), "match (n)-[*..]->(m:Group) return n")
+	require.Nil(t, err)
+
+	complexity, err := analyzer.QueryComplexity(queryModel)
+	require.Nil(t, err)
+
+	require.Equal(t, []analyzer.ComplexityContribution{{
+		Construct: analyzer.ConstructPattern,
+		Reason:    "each additional pattern compounds the cost of the query",
+		Weight:    1,
+	}, {
+		Construct: analyzer.ConstructRelationship,
+		Reason:    "relationship lookups",
+		Weight:    1,
+	}, {
+		Construct: analyzer.ConstructRelationship,
+		Reason:    "relationship patterns without a type expand all relationship types",
+		Weight:    2,
+	}, {
+		Construct: analyzer.ConstructRelationship,
+		Reason:    "unbounded variable-length patterns are likely to result in large expansions",
+		Weight:    3,
+	}, {
+		Construct: analyzer.ConstructNodePattern,
+		Reason:    "unlabeled node n requires a lookup of all nodes",
+		Weight:    2,
+	}}, complexity.Contributions)
+	require.Equal(t, float64(9), complexity.Weight)
+}
+
+func TestExpansionDepth(t *testing.T) {
+	var (
+		zero  int64 = 0
+		two   int64 = 2
+		three int64 = 3
+		over        = analyzer.MaxExpansionDepth + 1
+	)
+
+	minDepth, maxDepth, err := analyzer.ExpansionDepth(model.NewPatternRange(nil, nil))
+	require.Nil(t, err)
+	require.Equal(t, int64(1), minDepth)
+	require.Equal(t, analyzer.MaxExpansionDepth, maxDepth)
+
+	minDepth, maxDepth, err = analyzer.ExpansionDepth(model.NewPatternRange(&zero, &two))
+	require.Nil(t, err)
+	require.Equal(t, int64(0), minDepth)
+	require.Equal(t, int64(2), maxDepth)
+
+	_, _, err = analyzer.ExpansionDepth(model.NewPatternRange(nil, &over))
+	require.NotNil(t, err)
+
+	_, _, err = analyzer.ExpansionDepth(model.NewPatternRange(&three, &two))
+	require.NotNil(t, err)
+}
+
+func TestSummarize(t *testing.T) {
+	queryModel, err := frontend.ParseCypher(frontend.NewContext(), "match (n:User)-[:MemberOf*..]->(g:Group) optional match (n)-[:HasSession]->(c) where c:Computer with n, count(c) as sessions return n, toLower(n.name), sessions")
+	require.Nil(t, err)
+
+	summary, err := analyzer.Summarize(queryModel)
+	require.Nil(t, err)
+
+	require.Equal(t, &analyzer.QuerySummary{
+		Clauses:                []string{"match", "optional match", "with", "return"},
+		PatternParts:           2,
+		NodePatterns:           4,
+		RelationshipPatterns:   2,
+		VariableLengthPatterns: 1,
+		Functions:              []string{"count", "toLower"},
+		Kinds:                  []string{"Computer", "Group", "HasSession", "MemberOf", "User"},
+		Properties:             []string{"name"},
+	}, summary)
+}
+
+func TestSummarize_UpdatingClauses(t *testing.T) {
+	queryModel, err := frontend.ParseCypher(frontend.NewContext(), "match (n:User) set n.name = 'user' remove n:Group")
+	require.Nil(t, err)
+
+	summary, err := analyzer.Summarize(queryModel)
+	require.Nil(t, err)
+
+	require.Equal(t, []string{"match", "set", "remove"}, summary.Clauses)
+	require.Equal(t, 2, summary.UpdatingClauses)
+	require.Equal(t, []string{"name"}, summary.Properties)
+}
+
+func TestSummarize_Properties(t *testing.T) {
+	queryModel, err := frontend.ParseCypher(frontend.NewContext(), "match (n:User {objectid: '1234'})-[r:MemberOf {isacl: false}]->(g) where n.enabled and g.properties.name = 'group' return n.name")
+	require.Nil(t, err)
+
+	summary, err := analyzer.Summarize(queryModel)
+	require.Nil(t, err)
+
+	require.Equal(t, []string{"enabled", "isacl", "name", "objectid", "properties"}, summary.Properties)
+}
@@ -0,0 +1,219 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package analyzer
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/specterops/bloodhound/cypher/model"
+	"github.com/specterops/bloodhound/dawgs/graph"
+)
+
+// QuerySummary describes the structure of a parsed query
+type QuerySummary struct {
+	// Clauses lists the clauses of the query in the order they appear
+	Clauses                []string
+	PatternParts           int
+	NodePatterns           int
+	RelationshipPatterns   int
+	VariableLengthPatterns int
+
+	// UpdatingClauses counts the clauses of the query that change the graph
+	UpdatingClauses int
+
+	// Functions, Kinds and Properties list the distinct functions, kinds and property keys that the query references
+	Functions  []string
+	Kinds      []string
+	Properties []string
+}
+
+type querySummarizer struct {
+	summary    *QuerySummary
+	functions  map[string]struct{}
+	kinds      map[string]struct{}
+	properties map[string]struct{}
+}
+
+func (s *querySummarizer) addKinds(kinds graph.Kinds) {
+	for _, kind := range kinds {
+		s.kinds[kind.String()] = struct{}{}
+	}
+}
+
+func (s *querySummarizer) addPatternProperties(expression model.Expression) {
+	if properties, isProperties := expression.(*model.Properties); isProperties && properties != nil {
+		for key := range properties.Map {
+			s.properties[key] = struct{}{}
+		}
+	}
+}
+
+func (s *querySummarizer) onMatch(_ *model.WalkStack, node *model.Match) error {
+	if node.Optional {
+		s.summary.Clauses = append(s.summary.Clauses, "optional match")
+	} else {
+		s.summary.Clauses = append(s.summary.Clauses, "match")
+	}
+
+	return nil
+}
+
+func (s *querySummarizer) onUnwind(_ *model.WalkStack, _ *model.Unwind) error {
+	s.summary.Clauses = append(s.summary.Clauses, "unwind")
+	return nil
+}
+
+func (s *querySummarizer) onWith(_ *model.WalkStack, _ *model.With) error {
+	s.summary.Clauses = append(s.summary.Clauses, "with")
+	return nil
+}
+
+func (s *querySummarizer) onReturn(_ *model.WalkStack, _ *model.Return) error {
+	s.summary.Clauses = append(s.summary.Clauses, "return")
+	return nil
+}
+
+func (s *querySummarizer) onUnion(_ *model.WalkStack, node *model.Union) error {
+	if node.All {
+		s.summary.Clauses = append(s.summary.Clauses, "union all")
+	} else {
+		s.summary.Clauses = append(s.summary.Clauses, "union")
+	}
+
+	return nil
+}
+
+func (s *querySummarizer) onUpdatingClause(_ *model.WalkStack, node *model.UpdatingClause) error {
+	s.summary.UpdatingClauses++
+
+	switch typedClause := node.Clause.(type) {
+	case *model.Create:
+		s.summary.Clauses = append(s.summary.Clauses, "create")
+
+	case *model.Merge:
+		s.summary.Clauses = append(s.summary.Clauses, "merge")
+
+	case *model.Set:
+		s.summary.Clauses = append(s.summary.Clauses, "set")
+
+	case *model.Remove:
+		s.summary.Clauses = append(s.summary.Clauses, "remove")
+
+	case *model.Delete:
+		if typedClause.Detach {
+			s.summary.Clauses = append(s.summary.Clauses, "detach delete")
+		} else {
+			s.summary.Clauses = append(s.summary.Clauses, "delete")
+		}
+	}
+
+	return nil
+}
+
+func (s *querySummarizer) onPatternPart(_ *model.WalkStack, _ *model.PatternPart) error {
+	s.summary.PatternParts++
+	return nil
+}
+
+func (s *querySummarizer) onNodePattern(_ *model.WalkStack, node *model.NodePattern) error {
+	s.summary.NodePatterns++
+	s.addKinds(node.Kinds)
+	s.addPatternProperties(node.Properties)
+
+	return nil
+}
+
+func (s *querySummarizer) onRelationshipPattern(_ *model.WalkStack, node *model.RelationshipPattern) error {
+	s.summary.RelationshipPatterns++
+	s.addKinds(node.Kinds)
+	s.addPatternProperties(node.Properties)
+
+	if node.Range != nil {
+		s.summary.VariableLengthPatterns++
+	}
+
+	return nil
+}
+
+func (s *querySummarizer) onKindMatcher(_ *model.WalkStack, node *model.KindMatcher) error {
+	s.addKinds(node.Kinds)
+	return nil
+}
+
+func (s *querySummarizer) onFunctionInvocation(_ *model.WalkStack, node *model.FunctionInvocation) error {
+	s.functions[strings.Join(append(append([]string{}, node.Namespace...), node.Name), ".")] = struct{}{}
+	return nil
+}
+
+func (s *querySummarizer) onPropertyLookup(_ *model.WalkStack, node *model.PropertyLookup) error {
+	// Only the first symbol of a lookup names a property, any further symbols index into its value
+	if len(node.Symbols) > 0 {
+		s.properties[node.Symbols[0]] = struct{}{}
+	}
+
+	return nil
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+
+	for key := range set {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+// Summarize walks the given query and describes its structure
+func Summarize(query *model.RegularQuery) (*QuerySummary, error) {
+	var (
+		analyzer   = &Analyzer{}
+		summarizer = &querySummarizer{
+			summary: &QuerySummary{
+				Clauses: []string{},
+			},
+			functions:  map[string]struct{}{},
+			kinds:      map[string]struct{}{},
+			properties: map[string]struct{}{},
+		}
+	)
+
+	WithVisitor(analyzer, summarizer.onMatch)
+	WithVisitor(analyzer, summarizer.onUnwind)
+	WithVisitor(analyzer, summarizer.onWith)
+	WithVisitor(analyzer, summarizer.onReturn)
+	WithVisitor(analyzer, summarizer.onUnion)
+	WithVisitor(analyzer, summarizer.onUpdatingClause)
+	WithVisitor(analyzer, summarizer.onPatternPart)
+	WithVisitor(analyzer, summarizer.onNodePattern)
+	WithVisitor(analyzer, summarizer.onRelationshipPattern)
+	WithVisitor(analyzer, summarizer.onKindMatcher)
+	WithVisitor(analyzer, summarizer.onFunctionInvocation)
+	WithVisitor(analyzer, summarizer.onPropertyLookup)
+
+	if err := analyzer.Analyze(query); err != nil {
+		return nil, err
+	}
+
+	summarizer.summary.Functions = sortedKeys(summarizer.functions)
+	summarizer.summary.Kinds = sortedKeys(summarizer.kinds)
+	summarizer.summary.Properties = sortedKeys(summarizer.properties)
+
+	return summarizer.summary, nil
+}
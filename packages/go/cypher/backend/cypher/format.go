@@ -933,11 +933,40 @@ func (s Emitter) formatCreate(output io.Writer, create *model.Create) error {
 	return s.formatPattern(output, create.Pattern)
 }
 
+func (s Emitter) formatMerge(output io.Writer, merge *model.Merge) error {
+	if _, err := io.WriteString(output, "merge "); err != nil {
+		return err
+	}
+
+	if err := s.formatPatternPart(output, merge.PatternPart); err != nil {
+		return err
+	}
+
+	for _, mergeAction := range merge.Actions {
+		if mergeAction.OnCreate {
+			if _, err := io.WriteString(output, " on create "); err != nil {
+				return err
+			}
+		} else if _, err := io.WriteString(output, " on match "); err != nil {
+			return err
+		}
+
+		if err := s.formatSet(output, mergeAction.Set); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (s Emitter) formatUpdatingClause(output io.Writer, updatingClause *model.UpdatingClause) error {
 	switch typedClause := updatingClause.Clause.(type) {
 	case *model.Create:
 		return s.formatCreate(output, typedClause)
 
+	case *model.Merge:
+		return s.formatMerge(output, typedClause)
+
 	case *model.Remove:
 		return s.formatRemove(output, typedClause)
 
@@ -1122,14 +1151,49 @@ func (s Emitter) formatMultiPartQuery(output io.Writer, multiPartQuery *model.Mu
 		}
 	}
 
-	if multiPartQuery.SinglePartQuery != nil {
-		if len(multiPartQuery.Parts) > 0 {
+	if singlePartQuery := multiPartQuery.SinglePartQuery; singlePartQuery != nil {
+		// The return clause writes its own leading whitespace
+		if len(multiPartQuery.Parts) > 0 && len(singlePartQuery.ReadingClauses)+len(singlePartQuery.UpdatingClauses) > 0 {
 			if _, err := io.WriteString(output, " "); err != nil {
 				return err
 			}
 		}
 
-		return s.formatSinglePartQuery(output, multiPartQuery.SinglePartQuery)
+		return s.formatSinglePartQuery(output, singlePartQuery)
+	}
+
+	return nil
+}
+
+func (s Emitter) formatSingleQuery(output io.Writer, singleQuery *model.SingleQuery) error {
+	if singleQuery.MultiPartQuery != nil {
+		if err := s.formatMultiPartQuery(output, singleQuery.MultiPartQuery); err != nil {
+			return err
+		}
+	}
+
+	if singleQuery.SinglePartQuery != nil {
+		if err := s.formatSinglePartQuery(output, singleQuery.SinglePartQuery); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s Emitter) formatUnion(output io.Writer, union *model.Union) error {
+	if _, err := io.WriteString(output, " union "); err != nil {
+		return err
+	}
+
+	if union.All {
+		if _, err := io.WriteString(output, "all "); err != nil {
+			return err
+		}
+	}
+
+	if union.SingleQuery != nil {
+		return s.formatSingleQuery(output, union.SingleQuery)
 	}
 
 	return nil
@@ -1137,16 +1201,14 @@ func (s Emitter) formatMultiPartQuery(output io.Writer, multiPartQuery *model.Mu
 
 func (s Emitter) Write(regularQuery *model.RegularQuery, writer io.Writer) error {
 	if regularQuery.SingleQuery != nil {
-		if regularQuery.SingleQuery.MultiPartQuery != nil {
-			if err := s.formatMultiPartQuery(writer, regularQuery.SingleQuery.MultiPartQuery); err != nil {
-				return err
-			}
+		if err := s.formatSingleQuery(writer, regularQuery.SingleQuery); err != nil {
+			return err
 		}
+	}
 
-		if regularQuery.SingleQuery.SinglePartQuery != nil {
-			if err := s.formatSinglePartQuery(writer, regularQuery.SingleQuery.SinglePartQuery); err != nil {
-				return err
-			}
+	for _, union := range regularQuery.Unions {
+		if err := s.formatUnion(writer, union); err != nil {
+			return err
 		}
 	}
 
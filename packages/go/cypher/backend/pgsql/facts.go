@@ -18,6 +18,11 @@ package pgsql
 
 const (
 	cypherCountFunction         = "count"
+	cypherCollectFunction       = "collect"
+	cypherMinFunction           = "min"
+	cypherMaxFunction           = "max"
+	cypherSumFunction           = "sum"
+	cypherAvgFunction           = "avg"
 	cypherDateFunction          = "date"
 	cypherTimeFunction          = "time"
 	cypherLocalTimeFunction     = "localtime"
@@ -26,10 +31,85 @@ const (
 	cypherDurationFunction      = "duration"
 	cypherIdentityFunction      = "id"
 	cypherToLowerFunction       = "toLower"
+	cypherToUpperFunction       = "toUpper"
+	cypherSplitFunction         = "split"
+	cypherSubstringFunction     = "substring"
+	cypherReplaceFunction       = "replace"
+	cypherTrimFunction          = "trim"
+	cypherCoalesceFunction      = "coalesce"
+	cypherSizeFunction          = "size"
+	cypherLengthFunction        = "length"
+	cypherHeadFunction          = "head"
+	cypherLastFunction          = "last"
+	cypherToStringFunction      = "toString"
+	cypherToIntegerFunction     = "toInteger"
+	cypherToFloatFunction       = "toFloat"
+	cypherExistsFunction        = "exists"
+	cypherAbsFunction           = "abs"
+	cypherKeysFunction          = "keys"
+	cypherNodesFunction         = "nodes"
+	cypherRelationshipsFunction = "relationships"
 	cypherNodeLabelsFunction    = "labels"
 	cypherEdgeTypeFunction      = "type"
 
-	pgsqlAnyFunction     = "any"
-	pgsqlToJSONBFunction = "to_jsonb"
-	pgsqlToLowerFunction = "lower"
+	cypherEpochSecondsAccessor = "epochseconds"
+	cypherEpochMillisAccessor  = "epochmillis"
+
+	pgsqlAnyFunction              = "any"
+	pgsqlArrayAggFunction         = "array_agg"
+	pgsqlToJSONBFunction          = "to_jsonb"
+	pgsqlToLowerFunction          = "lower"
+	pgsqlToUpperFunction          = "upper"
+	pgsqlStringToArrayFunction    = "string_to_array"
+	pgsqlSubstringFunction        = "substr"
+	pgsqlReplaceFunction          = "replace"
+	pgsqlTrimFunction             = "trim"
+	pgsqlCoalesceFunction         = "coalesce"
+	pgsqlAbsFunction              = "abs"
+	pgsqlCardinalityFunction      = "cardinality"
+	pgsqlCharLengthFunction       = "char_length"
+	pgsqlJSONBArrayLengthFunction = "jsonb_array_length"
+	pgsqlJSONBObjectKeysFunction  = "jsonb_object_keys"
+	pgsqlToTimestampFunction      = "to_timestamp"
+	pgsqlMakeIntervalFunction     = "make_interval"
 )
+
+// SupportedFunctions returns the names of the cypher functions that can be translated to pgsql
+func SupportedFunctions() []string {
+	return []string{
+		cypherAbsFunction,
+		cypherAvgFunction,
+		cypherCoalesceFunction,
+		cypherCollectFunction,
+		cypherCountFunction,
+		cypherDateFunction,
+		cypherDateTimeFunction,
+		cypherDurationFunction,
+		cypherExistsFunction,
+		cypherHeadFunction,
+		cypherIdentityFunction,
+		cypherKeysFunction,
+		cypherNodeLabelsFunction,
+		cypherLastFunction,
+		cypherLengthFunction,
+		cypherLocalDateTimeFunction,
+		cypherLocalTimeFunction,
+		cypherMaxFunction,
+		cypherMinFunction,
+		cypherNodesFunction,
+		cypherRelationshipsFunction,
+		cypherReplaceFunction,
+		cypherSizeFunction,
+		cypherSplitFunction,
+		cypherSubstringFunction,
+		cypherSumFunction,
+		cypherTimeFunction,
+		cypherToFloatFunction,
+		cypherToIntegerFunction,
+		cypherToLowerFunction,
+		cypherToStringFunction,
+		cypherToUpperFunction,
+		cypherTrimFunction,
+		cypherEdgeTypeFunction,
+	}
+}
@@ -18,12 +18,15 @@ package pgsql
 
 import (
 	"fmt"
+	"github.com/specterops/bloodhound/cypher/analyzer"
 	"github.com/specterops/bloodhound/cypher/model"
 	pgModel "github.com/specterops/bloodhound/cypher/model/pg"
 	pgDriverModel "github.com/specterops/bloodhound/dawgs/drivers/pg/model"
 	"github.com/specterops/bloodhound/dawgs/graph"
 	"io"
+	"sort"
 	"strconv"
+	"strings"
 )
 
 const strippedLiteral = "$STRIPPED"
@@ -32,47 +35,93 @@ type KindMapper interface {
 	MapKinds(kinds graph.Kinds) ([]int16, graph.Kinds)
 }
 
+// stageColumn is a column of a query stage that carries a symbol projected by a with clause into the next stage
+type stageColumn struct {
+	stage    string
+	dataType pgModel.DataType
+}
+
 type Emitter struct {
 	StripLiterals bool
 	kindMapper    KindMapper
+	graphTarget   *pgDriverModel.Graph
+	nodeTable     string
+	edgeTable     string
+	expansions    map[string]*pgModel.Expansion
+	paths         map[string][]*model.PatternElement
+	carried       map[string]stageColumn
+	symbols       map[string]struct{}
+	optional      map[string]struct{}
+	nextStageID   int
+
+	// summarizeNodeDeletes is set when node deletions must report the number of nodes and edges they removed
+	summarizeNodeDeletes bool
 }
 
 func NewEmitter(stripLiterals bool, kindMapper KindMapper) *Emitter {
 	return &Emitter{
 		StripLiterals: stripLiterals,
 		kindMapper:    kindMapper,
+		nodeTable:     pgDriverModel.NodeTable,
+		edgeTable:     pgDriverModel.EdgeTable,
+		expansions:    map[string]*pgModel.Expansion{},
+		paths:         map[string][]*model.PatternElement{},
+		carried:       map[string]stageColumn{},
+		symbols:       map[string]struct{}{},
+		optional:      map[string]struct{}{},
 	}
 }
 
+// WithNodeDeleteSummary writes node deletions as statements that return a single row with the number of nodes deleted
+// followed by the number of edges attached to them. Attached edges are removed along with each deleted node by the
+// delete_node_edges trigger so their number is counted before the trigger runs.
+func (s *Emitter) WithNodeDeleteSummary() *Emitter {
+	s.summarizeNodeDeletes = true
+	return s
+}
+
+// WithGraph scopes the emitted SQL to the node and edge partitions of the given graph. By default, emitted SQL reads
+// from the parent node and edge tables which span every graph.
+func (s *Emitter) WithGraph(graphTarget pgDriverModel.Graph) *Emitter {
+	s.graphTarget = &graphTarget
+	s.nodeTable = graphTarget.Partitions.Node.Name
+	s.edgeTable = graphTarget.Partitions.Edge.Name
+
+	return s
+}
+
+// formatMapLiteral writes the given map literal as a jsonb object. Keys are written in sorted order so that the same
+// map literal always produces the same SQL.
 func (s *Emitter) formatMapLiteral(output io.Writer, mapLiteral model.MapLiteral) error {
-	if _, err := io.WriteString(output, "{"); err != nil {
+	keys := make([]string, 0, len(mapLiteral))
+
+	for key := range mapLiteral {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	if _, err := io.WriteString(output, "jsonb_build_object("); err != nil {
 		return err
 	}
 
-	first := true
-	for key, subExpression := range mapLiteral {
-		if !first {
+	for idx, key := range keys {
+		if idx > 0 {
 			if _, err := io.WriteString(output, ", "); err != nil {
 				return err
 			}
-		} else {
-			first = false
 		}
 
-		if _, err := io.WriteString(output, key); err != nil {
+		if _, err := WriteStrings(output, "'", strings.ReplaceAll(key, "'", "''"), "', "); err != nil {
 			return err
 		}
 
-		if _, err := io.WriteString(output, ": "); err != nil {
-			return err
-		}
-
-		if err := s.WriteExpression(output, subExpression); err != nil {
+		if err := s.WriteExpression(output, mapLiteral[key]); err != nil {
 			return err
 		}
 	}
 
-	if _, err := io.WriteString(output, "}"); err != nil {
+	if _, err := io.WriteString(output, ")"); err != nil {
 		return err
 	}
 
@@ -203,25 +252,7 @@ func (s *Emitter) formatLiteral(output io.Writer, literal *model.Literal) error
 }
 
 func (s *Emitter) writeReturn(writer io.Writer, returnClause *model.Return) error {
-	if returnClause.Projection.Distinct {
-		if _, err := WriteStrings(writer, "distinct "); err != nil {
-			return err
-		}
-	}
-
-	for idx, projectionItem := range returnClause.Projection.Items {
-		if idx > 0 {
-			if _, err := io.WriteString(writer, ", "); err != nil {
-				return nil
-			}
-		}
-
-		if err := s.WriteExpression(writer, projectionItem); err != nil {
-			return err
-		}
-	}
-
-	return nil
+	return s.writeProjection(writer, returnClause.Projection)
 }
 
 func (s *Emitter) writeWhere(writer io.Writer, whereClause *model.Where) error {
@@ -244,7 +275,7 @@ func (s *Emitter) writePatternElements(writer io.Writer, patternElements []*mode
 	for idx, patternElement := range patternElements {
 		if nodePattern, isNodePattern := patternElement.AsNodePattern(); isNodePattern {
 			if idx == 0 {
-				if _, err := io.WriteString(writer, pgDriverModel.NodeTable); err != nil {
+				if _, err := io.WriteString(writer, s.nodeTable); err != nil {
 					return nil
 				}
 
@@ -255,10 +286,30 @@ func (s *Emitter) writePatternElements(writer io.Writer, patternElements []*mode
 				if err := s.WriteExpression(writer, nodePattern.Binding); err != nil {
 					return nil
 				}
+			} else if previousExpansion, isExpansion := patternElements[idx-1].Element.(*pgModel.Expansion); isExpansion {
+				if _, err := WriteStrings(writer, " join ", s.nodeTable, " "); err != nil {
+					return err
+				}
+
+				if err := s.WriteExpression(writer, nodePattern.Binding); err != nil {
+					return err
+				}
+
+				if _, err := WriteStrings(writer, " on "); err != nil {
+					return err
+				}
+
+				if err := s.WriteExpression(writer, nodePattern.Binding); err != nil {
+					return err
+				}
+
+				if _, err := WriteStrings(writer, ".id = ", previousExpansion.Binding.Symbol, ".next_id"); err != nil {
+					return err
+				}
 			} else {
 				previousRelationshipPattern, _ := patternElements[idx-1].AsRelationshipPattern()
 
-				if _, err := WriteStrings(writer, " join ", pgDriverModel.NodeTable, " "); err != nil {
+				if _, err := WriteStrings(writer, " join ", s.nodeTable, " "); err != nil {
 					return err
 				}
 
@@ -339,11 +390,21 @@ func (s *Emitter) writePatternElements(writer io.Writer, patternElements []*mode
 					}
 				}
 			}
+		} else if expansion, isExpansion := patternElement.Element.(*pgModel.Expansion); isExpansion {
+			if idx == 0 {
+				return fmt.Errorf("variable-length relationship patterns must follow a node pattern")
+			}
+
+			previousNodePattern, _ := patternElements[idx-1].AsNodePattern()
+
+			if err := s.writeExpansion(writer, previousNodePattern, expansion); err != nil {
+				return err
+			}
 		} else {
 			relationshipPattern, _ := patternElement.AsRelationshipPattern()
 
 			if idx == 0 {
-				if _, err := io.WriteString(writer, pgDriverModel.EdgeTable); err != nil {
+				if _, err := io.WriteString(writer, s.edgeTable); err != nil {
 					return nil
 				}
 
@@ -357,7 +418,7 @@ func (s *Emitter) writePatternElements(writer io.Writer, patternElements []*mode
 			} else {
 				previousNodePattern, _ := patternElements[idx-1].AsNodePattern()
 
-				if _, err := WriteStrings(writer, " join ", pgDriverModel.EdgeTable, " "); err != nil {
+				if _, err := WriteStrings(writer, " join ", s.edgeTable, " "); err != nil {
 					return err
 				}
 
@@ -447,370 +508,369 @@ func (s *Emitter) writePatternElements(writer io.Writer, patternElements []*mode
 	return nil
 }
 
-func (s *Emitter) writeMatch(writer io.Writer, matchClause *model.Match) error {
-	for idx, pattern := range matchClause.Pattern {
-		if idx > 0 {
-			if _, err := io.WriteString(writer, ", "); err != nil {
-				return err
-			}
-		}
+// writeExpansion joins the rows of a recursive CTE that expands outward from the given root node pattern. Each row
+// carries the ID of the node reached, the depth of the expansion and the ordered IDs of the relationships traversed.
+// Relationships may only be traversed once per row which also protects the expansion against cycles.
+func (s *Emitter) writeExpansion(writer io.Writer, rootNodePattern *model.NodePattern, expansion *pgModel.Expansion) error {
+	var (
+		expansionSymbol    = expansion.Binding.Symbol
+		relationshipSymbol = expansion.Relationship.Symbol
+		nextID             string
+		joinCondition      string
+	)
+
+	switch expansion.Direction {
+	case graph.DirectionOutbound:
+		nextID = relationshipSymbol + ".end_id"
+		joinCondition = relationshipSymbol + ".start_id = " + expansionSymbol + ".next_id"
+
+	case graph.DirectionInbound:
+		nextID = relationshipSymbol + ".start_id"
+		joinCondition = relationshipSymbol + ".end_id = " + expansionSymbol + ".next_id"
+
+	case graph.DirectionBoth:
+		nextID = "case when " + relationshipSymbol + ".start_id = " + expansionSymbol + ".next_id then " + relationshipSymbol + ".end_id else " + relationshipSymbol + ".start_id end"
+		joinCondition = "(" + relationshipSymbol + ".start_id = " + expansionSymbol + ".next_id or " + relationshipSymbol + ".end_id = " + expansionSymbol + ".next_id)"
 
-		if err := s.writePatternElements(writer, pattern.PatternElements); err != nil {
-			return err
-		}
+	default:
+		return fmt.Errorf("unsupported pattern direction: %s", expansion.Direction)
 	}
 
-	if matchClause.Where != nil {
-		if err := s.writeWhere(writer, matchClause.Where); err != nil {
-			return err
-		}
+	if _, err := WriteStrings(writer, " join lateral (with recursive ", expansionSymbol, "(next_id, depth, path) as (select "); err != nil {
+		return err
 	}
 
-	return nil
-}
+	if err := s.WriteExpression(writer, rootNodePattern.Binding); err != nil {
+		return err
+	}
 
-func (s *Emitter) writeSelect(writer io.Writer, singlePartQuery *model.SinglePartQuery) error {
-	if _, err := io.WriteString(writer, "select "); err != nil {
+	if _, err := WriteStrings(writer,
+		".id, 0, array[]::int4[] union all select ", nextID, ", ", expansionSymbol, ".depth + 1, ", expansionSymbol, ".path || ", relationshipSymbol, ".id",
+		" from ", expansionSymbol, " join ", s.edgeTable, " ", relationshipSymbol, " on ", joinCondition,
+		" where ", expansionSymbol, ".depth < ", strconv.FormatInt(expansion.MaxDepth, 10),
+		" and not ", relationshipSymbol, ".id = any(", expansionSymbol, ".path)",
+	); err != nil {
 		return err
 	}
 
-	if singlePartQuery.Return != nil {
-		if err := s.writeReturn(writer, singlePartQuery.Return); err != nil {
+	if expansion.Criteria != nil {
+		if _, err := WriteStrings(writer, " and "); err != nil {
+			return err
+		}
+
+		if err := s.WriteExpression(writer, expansion.Criteria); err != nil {
 			return err
 		}
 	}
 
-	if _, err := io.WriteString(writer, " from "); err != nil {
+	if _, err := WriteStrings(writer, ") select ", expansionSymbol, ".next_id, ", expansionSymbol, ".path from ", expansionSymbol); err != nil {
 		return err
 	}
 
-	for _, readingClause := range singlePartQuery.ReadingClauses {
-		if readingClause.Match != nil {
-			if err := s.writeMatch(writer, readingClause.Match); err != nil {
+	if expansion.MinDepth > 0 {
+		if _, err := WriteStrings(writer, " where ", expansionSymbol, ".depth >= ", strconv.FormatInt(expansion.MinDepth, 10)); err != nil {
+			return err
+		}
+	}
+
+	_, err := WriteStrings(writer, ") ", expansionSymbol, " on true")
+	return err
+}
+
+// writePathEdgeIDs writes an int4[] expression containing the IDs of the relationships of a path pattern in
+// traversal order.
+func (s *Emitter) writePathEdgeIDs(writer io.Writer, patternElements []*model.PatternElement) error {
+	first := true
+
+	for _, patternElement := range patternElements {
+		var edgeIDs string
+
+		if expansion, isExpansion := patternElement.Element.(*pgModel.Expansion); isExpansion {
+			edgeIDs = expansion.Binding.Symbol + ".path"
+		} else if relationshipPattern, isRelationshipPattern := patternElement.AsRelationshipPattern(); isRelationshipPattern {
+			if relationshipSymbol, err := GetSymbol(relationshipPattern.Binding); err != nil {
 				return err
+			} else {
+				edgeIDs = "array[" + relationshipSymbol + ".id]"
 			}
+		} else {
+			continue
 		}
-	}
 
-	if singlePartQuery.Return != nil {
-		if order := singlePartQuery.Return.Projection.Order; order != nil {
-			if _, err := WriteStrings(writer, " order by "); err != nil {
+		if !first {
+			if _, err := WriteStrings(writer, " || "); err != nil {
 				return err
 			}
+		} else {
+			first = false
+		}
 
-			for idx, orderItem := range order.Items {
-				if idx > 0 {
-					if _, err := WriteStrings(writer, ", "); err != nil {
-						return err
-					}
-				}
+		if _, err := WriteStrings(writer, edgeIDs); err != nil {
+			return err
+		}
+	}
+
+	if first {
+		_, err := WriteStrings(writer, "array[]::int4[]")
+		return err
+	}
+
+	return nil
+}
 
-				if err := s.WriteExpression(writer, orderItem.Expression); err != nil {
+// collectPatterns records the expansions and path bindings of the given reading clauses so that projections may
+// reference them before the from clause that defines them is written
+func (s *Emitter) collectPatterns(readingClauses []*model.ReadingClause) error {
+	for _, readingClause := range readingClauses {
+		if readingClause.Match == nil {
+			continue
+		}
+
+		for _, pattern := range readingClause.Match.Pattern {
+			if pattern.Binding != nil {
+				if pathSymbol, err := GetSymbol(pattern.Binding); err != nil {
 					return err
-				}
+				} else {
+					s.paths[pathSymbol] = pattern.PatternElements
 
-				if orderItem.Ascending {
-					if _, err := WriteStrings(writer, " asc"); err != nil {
-						return err
+					if readingClause.Match.Optional {
+						s.optional[pathSymbol] = struct{}{}
 					}
-				} else {
-					if _, err := WriteStrings(writer, " desc"); err != nil {
+				}
+			}
+
+			for _, patternElement := range pattern.PatternElements {
+				if expansion, isExpansion := patternElement.Element.(*pgModel.Expansion); isExpansion {
+					s.expansions[expansion.Relationship.Symbol] = expansion
+				} else if readingClause.Match.Optional {
+					if err := s.collectOptionalElement(patternElement); err != nil {
 						return err
 					}
 				}
 			}
 		}
+	}
 
-		if skip := singlePartQuery.Return.Projection.Skip; skip != nil {
-			if _, err := WriteStrings(writer, " offset "); err != nil {
-				return err
-			}
+	return nil
+}
 
-			if err := s.WriteExpression(writer, skip.Value); err != nil {
-				return err
-			}
-		}
+// collectOptionalElement records the binding of a pattern element of an optional match. Bindings of an optional match
+// are null for rows where the optional match found nothing.
+func (s *Emitter) collectOptionalElement(patternElement *model.PatternElement) error {
+	var binding model.Expression
 
-		if limit := singlePartQuery.Return.Projection.Limit; limit != nil {
-			if _, err := WriteStrings(writer, " limit "); err != nil {
-				return err
-			}
+	if nodePattern, isNodePattern := patternElement.AsNodePattern(); isNodePattern {
+		binding = nodePattern.Binding
+	} else {
+		relationshipPattern, _ := patternElement.AsRelationshipPattern()
+		binding = relationshipPattern.Binding
+	}
 
-			if err := s.WriteExpression(writer, limit.Value); err != nil {
-				return err
-			}
-		}
+	if symbol, err := GetSymbol(binding); err != nil {
+		return err
+	} else {
+		s.optional[symbol] = struct{}{}
 	}
 
 	return nil
 }
 
-func (s *Emitter) writeDelete(writer io.Writer, singlePartQuery *model.SinglePartQuery, delete *pgModel.Delete) error {
-	if delete.NodeDelete {
-		if _, err := WriteStrings(writer, "delete from ", pgDriverModel.NodeTable, " as ", delete.Binding.Symbol); err != nil {
-			return err
-		}
-
-		first := true
+// optionalGuardSymbol returns the symbol whose id column is null when the given entity was bound by an optional match
+// that found nothing
+func (s *Emitter) optionalGuardSymbol(entity *pgModel.Entity) (string, bool, error) {
+	// Carried entities were guarded by the stage that projected them
+	if _, isCarried := s.carried[entity.Binding.Symbol]; isCarried {
+		return "", false, nil
+	} else if _, isOptional := s.optional[entity.Binding.Symbol]; !isOptional {
+		return "", false, nil
+	}
 
-		for _, readingClause := range singlePartQuery.ReadingClauses {
-			if matchClause := readingClause.Match; matchClause != nil {
-				for _, pattern := range matchClause.Pattern {
-					for _, patternElement := range pattern.PatternElements {
-						if nodePattern, isNodePattern := patternElement.AsNodePattern(); isNodePattern {
-							switch typedBinding := nodePattern.Binding.(type) {
-							case *pgModel.AnnotatedVariable:
-								if typedBinding.Symbol == delete.Binding.Symbol {
-									continue
-								}
-							}
+	switch entity.Binding.Type {
+	case pgModel.Node, pgModel.Edge:
+		return entity.Binding.Symbol, true, nil
+
+	case pgModel.Path:
+		if patternElements, hasPath := s.paths[entity.Binding.Symbol]; !hasPath || len(patternElements) == 0 {
+			return "", false, fmt.Errorf("unable to find the pattern bound to path %s", entity.Binding.Symbol)
+		} else if nodePattern, isNodePattern := patternElements[0].AsNodePattern(); !isNodePattern {
+			return "", false, fmt.Errorf("expected path %s to start with a node pattern", entity.Binding.Symbol)
+		} else if symbol, err := GetSymbol(nodePattern.Binding); err != nil {
+			return "", false, err
+		} else {
+			return symbol, true, nil
+		}
+	}
 
-							if !first {
-								if _, err := WriteStrings(writer, ", "); err != nil {
-									return err
-								}
-							} else {
-								if _, err := WriteStrings(writer, " using "); err != nil {
-									return err
-								}
+	return "", false, nil
+}
 
-								first = false
-							}
+// referencesOptional returns true if the given expression references a binding of an optional match
+func (s *Emitter) referencesOptional(expression model.Expression) (bool, error) {
+	referencesOptional := false
 
-							if _, err := WriteStrings(writer, pgDriverModel.NodeTable, " as "); err != nil {
-								return err
-							}
+	err := analyzer.Analyze(expression, func(analyzerInst *analyzer.Analyzer) {
+		analyzer.WithVisitor(analyzerInst, func(_ *model.WalkStack, node *model.Variable) error {
+			_, isOptional := s.optional[node.Symbol]
+			referencesOptional = referencesOptional || isOptional
+			return nil
+		})
 
-							if err := s.WriteExpression(writer, nodePattern.Binding); err != nil {
-								return err
-							}
-						} else {
-							relationshipPattern, _ := patternElement.AsRelationshipPattern()
+		analyzer.WithVisitor(analyzerInst, func(_ *model.WalkStack, node *pgModel.AnnotatedVariable) error {
+			_, isOptional := s.optional[node.Symbol]
+			referencesOptional = referencesOptional || isOptional
+			return nil
+		})
+	}, pgModel.CollectPGSQLTypes)
 
-							switch typedBinding := relationshipPattern.Binding.(type) {
-							case *pgModel.AnnotatedVariable:
-								if typedBinding.Symbol == delete.Binding.Symbol {
-									continue
-								}
-							}
+	return referencesOptional, err
+}
 
-							if !first {
-								if _, err := WriteStrings(writer, ", "); err != nil {
-									return err
-								}
-							} else {
-								if _, err := WriteStrings(writer, " using "); err != nil {
-									return err
-								}
+func hasOptionalMatches(readingClauses []*model.ReadingClause) bool {
+	for _, readingClause := range readingClauses {
+		if readingClause.Match != nil && readingClause.Match.Optional {
+			return true
+		}
+	}
 
-								first = false
-							}
+	return false
+}
 
-							if _, err := WriteStrings(writer, pgDriverModel.EdgeTable, " as "); err != nil {
-								return err
-							}
+func hasExpansions(readingClauses []*model.ReadingClause) bool {
+	for _, readingClause := range readingClauses {
+		if readingClause.Match == nil {
+			continue
+		}
 
-							if err := s.WriteExpression(writer, relationshipPattern.Binding); err != nil {
-								return err
-							}
-						}
-					}
+		for _, pattern := range readingClause.Match.Pattern {
+			for _, patternElement := range pattern.PatternElements {
+				if _, isExpansion := patternElement.Element.(*pgModel.Expansion); isExpansion {
+					return true
 				}
 			}
 		}
-	} else {
-		if _, err := WriteStrings(writer, "delete from ", pgDriverModel.EdgeTable, " as ", delete.Binding.Symbol); err != nil {
-			return err
-		}
-
-		first := true
+	}
 
-		for _, readingClause := range singlePartQuery.ReadingClauses {
-			if matchClause := readingClause.Match; matchClause != nil {
-				for _, pattern := range matchClause.Pattern {
-					for _, patternElement := range pattern.PatternElements {
-						if nodePattern, isNodePattern := patternElement.AsNodePattern(); isNodePattern {
-							if !first {
-								if _, err := WriteStrings(writer, ", "); err != nil {
-									return err
-								}
-							} else {
-								if _, err := WriteStrings(writer, " using "); err != nil {
-									return err
-								}
+	return false
+}
 
-								first = false
-							}
+// isAggregate returns true if the given projection expression aggregates rows
+func isAggregate(expression model.Expression) bool {
+	if functionInvocation, isFunctionInvocation := expression.(*model.FunctionInvocation); isFunctionInvocation {
+		switch functionInvocation.Name {
+		case cypherCountFunction, cypherCollectFunction, cypherMinFunction, cypherMaxFunction, cypherSumFunction, cypherAvgFunction:
+			return true
+		}
+	}
 
-							if _, err := WriteStrings(writer, pgDriverModel.NodeTable, " as "); err != nil {
-								return err
-							}
+	return false
+}
 
-							if err := s.WriteExpression(writer, nodePattern.Binding); err != nil {
-								return err
-							}
-						}
-					}
-				}
-			}
+func (s *Emitter) writeProjection(writer io.Writer, projection *model.Projection) error {
+	if projection.Distinct {
+		if _, err := WriteStrings(writer, "distinct "); err != nil {
+			return err
 		}
 	}
 
-	for _, readingClause := range singlePartQuery.ReadingClauses {
-		if matchClause := readingClause.Match; matchClause != nil {
-			if matchClause.Where != nil {
-				if err := s.writeWhere(writer, matchClause.Where); err != nil {
-					return err
-				}
+	for idx, projectionItem := range projection.Items {
+		if idx > 0 {
+			if _, err := io.WriteString(writer, ", "); err != nil {
+				return err
 			}
 		}
+
+		if err := s.WriteExpression(writer, projectionItem); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-func (s *Emitter) writeUpdates(writer io.Writer, singlePartQuery *model.SinglePartQuery) error {
-	if _, err := io.WriteString(writer, "update "); err != nil {
-		return err
+// writeGrouping groups the rows of a projection by its non-aggregate items if the projection contains at least one
+// aggregate item. Items are referenced by their ordinal position in the projection.
+func (s *Emitter) writeGrouping(writer io.Writer, projection *model.Projection) error {
+	var (
+		groupingItems []string
+		hasAggregate  = false
+	)
+
+	for idx, item := range projection.Items {
+		if projectionItem, typeOK := item.(*model.ProjectionItem); !typeOK {
+			return fmt.Errorf("unexpected projection item type: %T", item)
+		} else if isAggregate(projectionItem.Expression) {
+			hasAggregate = true
+		} else {
+			groupingItems = append(groupingItems, strconv.Itoa(idx+1))
+		}
 	}
 
-	for _, readingClause := range singlePartQuery.ReadingClauses {
-		if matchClause := readingClause.Match; matchClause != nil {
-			for idx, pattern := range matchClause.Pattern {
-				if idx > 0 {
-					if _, err := io.WriteString(writer, ", "); err != nil {
-						return err
-					}
-				}
+	if hasAggregate && len(groupingItems) > 0 {
+		if _, err := WriteStrings(writer, " group by "); err != nil {
+			return err
+		}
 
-				if err := s.writePatternElements(writer, pattern.PatternElements); err != nil {
+		for idx, groupingItem := range groupingItems {
+			if idx > 0 {
+				if _, err := WriteStrings(writer, ", "); err != nil {
 					return err
 				}
 			}
-		}
-	}
-
-	if _, err := WriteStrings(writer, " set "); err != nil {
-		return err
-	}
 
-	for idx, item := range singlePartQuery.UpdatingClauses {
-		if idx > 0 {
-			if _, err := WriteStrings(writer, ", "); err != nil {
+			if _, err := WriteStrings(writer, groupingItem); err != nil {
 				return err
 			}
 		}
+	}
 
-		switch typedUpdateItem := item.(type) {
-		case *pgModel.Delete:
-			if err := s.writeDelete(writer, singlePartQuery, typedUpdateItem); err != nil {
-				return err
-			}
-
-		case *pgModel.PropertyMutation:
-			// Can't use aliased names in the set clauses of the SQL statement so default to just the raw
-			// column names
-			if _, err := WriteStrings(writer, "properties = properties"); err != nil {
-				return err
-			}
-
-			if typedUpdateItem.Additions != nil {
-				if typedUpdateItem.Removals != nil {
-					if _, err := WriteStrings(writer, " - "); err != nil {
-						return err
-					}
-
-					if err := s.WriteExpression(writer, typedUpdateItem.Removals); err != nil {
-						return err
-					}
-
-					if _, err := WriteStrings(writer, "::text[]"); err != nil {
-						return err
-					}
-				}
-
-				if _, err := WriteStrings(writer, " || "); err != nil {
-					return err
-				}
-
-				if err := s.WriteExpression(writer, typedUpdateItem.Additions); err != nil {
-					return err
-				}
-			} else if typedUpdateItem.Removals != nil {
-				if _, err := WriteStrings(writer, " - "); err != nil {
-					return err
-				}
+	return nil
+}
 
-				if err := s.WriteExpression(writer, typedUpdateItem.Removals); err != nil {
-					return err
-				}
+func (s *Emitter) writeProjectionModifiers(writer io.Writer, projection *model.Projection) error {
+	if order := projection.Order; order != nil {
+		if _, err := WriteStrings(writer, " order by "); err != nil {
+			return err
+		}
 
-				if _, err := WriteStrings(writer, "::text[]"); err != nil {
+		for idx, orderItem := range order.Items {
+			if idx > 0 {
+				if _, err := WriteStrings(writer, ", "); err != nil {
 					return err
 				}
 			}
 
-		case *pgModel.KindMutation:
-			// Cypher and therefore this translation does not support kind mutation of relationships
-			if typedUpdateItem.Variable.Type != pgModel.Node {
-				return fmt.Errorf("unsupported SQL type for kind mutation: %s", typedUpdateItem.Variable.Type)
-			}
-
-			// Can't use aliased names in the set clauses of the SQL statement so default to just the raw
-			// column names
-			if _, err := WriteStrings(writer, "kind_ids = kind_ids"); err != nil {
+			if err := s.WriteExpression(writer, orderItem.Expression); err != nil {
 				return err
 			}
 
-			if typedUpdateItem.Additions != nil {
-				if typedUpdateItem.Removals != nil {
-					if _, err := WriteStrings(writer, " - "); err != nil {
-						return err
-					}
-
-					if err := s.WriteExpression(writer, typedUpdateItem.Removals); err != nil {
-						return err
-					}
-				}
-
-				if _, err := WriteStrings(writer, " || "); err != nil {
-					return err
-				}
-
-				if err := s.WriteExpression(writer, typedUpdateItem.Additions); err != nil {
-					return err
-				}
-			} else if typedUpdateItem.Removals != nil {
-				if _, err := WriteStrings(writer, " - "); err != nil {
+			if orderItem.Ascending {
+				if _, err := WriteStrings(writer, " asc"); err != nil {
 					return err
 				}
-
-				if err := s.WriteExpression(writer, typedUpdateItem.Removals); err != nil {
+			} else {
+				if _, err := WriteStrings(writer, " desc"); err != nil {
 					return err
 				}
 			}
-
-		default:
-			return fmt.Errorf("unsupported update clause item: %T", item)
 		}
 	}
 
-	for _, readingClause := range singlePartQuery.ReadingClauses {
-		if matchClause := readingClause.Match; matchClause != nil {
-			if matchClause.Where != nil {
-				if err := s.writeWhere(writer, matchClause.Where); err != nil {
-					return err
-				}
-			}
+	if skip := projection.Skip; skip != nil {
+		if _, err := WriteStrings(writer, " offset "); err != nil {
+			return err
+		}
+
+		if err := s.WriteExpression(writer, skip.Value); err != nil {
+			return err
 		}
 	}
 
-	if singlePartQuery.Return != nil {
-		if _, err := WriteStrings(writer, " returning "); err != nil {
+	if limit := projection.Limit; limit != nil {
+		if _, err := WriteStrings(writer, " limit "); err != nil {
 			return err
 		}
 
-		if err := s.writeReturn(writer, singlePartQuery.Return); err != nil {
+		if err := s.WriteExpression(writer, limit.Value); err != nil {
 			return err
 		}
 	}
@@ -818,23 +878,34 @@ func (s *Emitter) writeUpdates(writer io.Writer, singlePartQuery *model.SinglePa
 	return nil
 }
 
-func (s *Emitter) writeUpdatingClauses(writer io.Writer, singlePartQuery *model.SinglePartQuery) error {
-	// Delete statements must be rendered as their own outputs
-	numDeletes := 0
+func (s *Emitter) writeUnwind(writer io.Writer, unwind *model.Unwind) error {
+	if _, err := WriteStrings(writer, "unnest("); err != nil {
+		return err
+	}
 
-	for _, updateClause := range singlePartQuery.UpdatingClauses {
-		switch typedClause := updateClause.(type) {
-		case *pgModel.Delete:
-			numDeletes++
+	if err := s.WriteExpression(writer, unwind.Expression); err != nil {
+		return err
+	}
 
-			if err := s.writeDelete(writer, singlePartQuery, typedClause); err != nil {
+	_, err := WriteStrings(writer, ") as ", unwind.Binding.Symbol)
+	return err
+}
+
+// writeCriteria writes the given criteria as a conjunction
+func (s *Emitter) writeCriteria(writer io.Writer, criteria []model.Expression) error {
+	for idx, criterion := range criteria {
+		if idx > 0 {
+			if _, err := WriteStrings(writer, " and "); err != nil {
 				return err
 			}
 		}
-	}
 
-	if len(singlePartQuery.UpdatingClauses) > numDeletes {
-		if err := s.writeUpdates(writer, singlePartQuery); err != nil {
+		// Disjunctions must be wrapped to preserve their precedence when joined with other criteria
+		if _, isDisjunction := criterion.(*model.Disjunction); isDisjunction && len(criteria) > 1 {
+			criterion = model.NewParenthetical(criterion)
+		}
+
+		if err := s.WriteExpression(writer, criterion); err != nil {
 			return err
 		}
 	}
@@ -842,397 +913,1948 @@ func (s *Emitter) writeUpdatingClauses(writer io.Writer, singlePartQuery *model.
 	return nil
 }
 
-func (s *Emitter) writeSinglePartQuery(writer io.Writer, singlePartQuery *model.SinglePartQuery) error {
-	if len(singlePartQuery.UpdatingClauses) > 0 {
-		return s.writeUpdatingClauses(writer, singlePartQuery)
-	} else {
-		return s.writeSelect(writer, singlePartQuery)
-	}
-}
+// writeOptionalMatch left joins the patterns of an optional match. The criteria of the optional match are the join
+// condition so that rows that fail them are kept with null bindings rather than filtered.
+func (s *Emitter) writeOptionalMatch(writer io.Writer, match *model.Match) error {
+	// Join trees must be wrapped so that the join condition applies to the optional match as a whole
+	isJoinTree := len(match.Pattern) > 1 || len(match.Pattern[0].PatternElements) > 1
 
-func (s *Emitter) writeSubquery(writer io.Writer, subquery *pgModel.Subquery) error {
-	if _, err := io.WriteString(writer, "exists(select * from "); err != nil {
+	if _, err := WriteStrings(writer, " left join "); err != nil {
 		return err
 	}
 
-	if err := s.writePatternElements(writer, subquery.PatternElements); err != nil {
-		return err
+	if isJoinTree {
+		if _, err := WriteStrings(writer, "("); err != nil {
+			return err
+		}
 	}
 
-	if subquery.Filter != nil {
-		subQueryWhereClause := model.NewWhere()
-		subQueryWhereClause.Add(subquery.Filter)
+	for idx, pattern := range match.Pattern {
+		if idx > 0 {
+			if _, err := WriteStrings(writer, " cross join "); err != nil {
+				return err
+			}
+		}
 
-		if err := s.writeWhere(writer, subQueryWhereClause); err != nil {
+		if err := s.writePatternElements(writer, pattern.PatternElements); err != nil {
 			return err
 		}
 	}
 
-	if _, err := io.WriteString(writer, " limit 1)"); err != nil {
+	if isJoinTree {
+		if _, err := WriteStrings(writer, ")"); err != nil {
+			return err
+		}
+	}
+
+	if _, err := WriteStrings(writer, " on "); err != nil {
 		return err
 	}
 
-	return nil
+	if match.Where == nil || len(match.Where.Expressions) == 0 {
+		_, err := WriteStrings(writer, "true")
+		return err
+	}
+
+	return s.writeCriteria(writer, match.Where.Expressions)
 }
 
-func (s *Emitter) WriteExpression(writer io.Writer, expression model.Expression) error {
-	switch typedExpression := expression.(type) {
-	case *pgModel.Subquery:
-		if err := s.writeSubquery(writer, typedExpression); err != nil {
+// writeFrom writes the from and where clauses of a query stage. The source, if set, names the stage that precedes
+// this one. Filters are the criteria of the with clause that closed the preceding stage.
+func (s *Emitter) writeFrom(writer io.Writer, source string, readingClauses []*model.ReadingClause, filters []model.Expression) error {
+	var (
+		numFromItems  = 0
+		criteria      = filters
+		itemSeparator = ", "
+	)
+
+	// Optional matches are joined to the from items that precede them. Commas bind more loosely than joins so the
+	// from items must be cross joined instead.
+	if hasOptionalMatches(readingClauses) {
+		itemSeparator = " cross join "
+	}
+
+	nextFromItem := func() error {
+		if numFromItems == 0 {
+			if _, err := WriteStrings(writer, " from "); err != nil {
+				return err
+			}
+		} else if _, err := WriteStrings(writer, itemSeparator); err != nil {
 			return err
 		}
 
-	case *model.Negation:
-		if _, err := io.WriteString(writer, "not "); err != nil {
+		numFromItems++
+		return nil
+	}
+
+	if source != "" {
+		if err := nextFromItem(); err != nil {
 			return err
 		}
 
-		if err := s.WriteExpression(writer, typedExpression.Expression); err != nil {
+		if _, err := WriteStrings(writer, source); err != nil {
 			return err
 		}
+	}
 
-	case *model.Disjunction:
-		for idx, joinedExpression := range typedExpression.Expressions {
-			if idx > 0 {
-				if _, err := io.WriteString(writer, " or "); err != nil {
+	for _, readingClause := range readingClauses {
+		if readingClause.Match != nil && readingClause.Match.Optional {
+			// An optional match that leads a query still produces a single row when it finds nothing
+			if numFromItems == 0 {
+				if err := nextFromItem(); err != nil {
+					return err
+				}
+
+				if _, err := WriteStrings(writer, "(select 1) as ", s.newStageSymbol()); err != nil {
 					return err
 				}
 			}
 
-			if err := s.WriteExpression(writer, joinedExpression); err != nil {
+			if err := s.writeOptionalMatch(writer, readingClause.Match); err != nil {
 				return err
 			}
-		}
+		} else if readingClause.Match != nil {
+			for _, pattern := range readingClause.Match.Pattern {
+				if err := nextFromItem(); err != nil {
+					return err
+				}
 
-	case *model.Conjunction:
-		for idx, joinedExpression := range typedExpression.Expressions {
-			if idx > 0 {
-				if _, err := io.WriteString(writer, " and "); err != nil {
+				if err := s.writePatternElements(writer, pattern.PatternElements); err != nil {
 					return err
 				}
 			}
 
-			if err := s.WriteExpression(writer, joinedExpression); err != nil {
+			if readingClause.Match.Where != nil {
+				criteria = append(criteria, readingClause.Match.Where.Expressions...)
+			}
+		} else if readingClause.Unwind != nil {
+			if err := nextFromItem(); err != nil {
 				return err
 			}
-		}
-
-	case *model.Comparison:
-		if err := s.WriteExpression(writer, typedExpression.Left); err != nil {
-			return err
-		}
 
-		for _, nextPart := range typedExpression.Partials {
-			if err := s.WriteExpression(writer, nextPart); err != nil {
+			if err := s.writeUnwind(writer, readingClause.Unwind); err != nil {
 				return err
 			}
 		}
+	}
 
-	case *model.PartialComparison:
-		if _, err := WriteStrings(writer, " ", typedExpression.Operator.String(), " "); err != nil {
+	if len(criteria) > 0 {
+		if _, err := WriteStrings(writer, " where "); err != nil {
 			return err
 		}
 
-		if err := s.WriteExpression(writer, typedExpression.Right); err != nil {
-			return err
-		}
+		return s.writeCriteria(writer, criteria)
+	}
 
-	case *pgModel.AnnotatedLiteral:
-		if err := s.WriteExpression(writer, &typedExpression.Literal); err != nil {
-			return err
-		}
+	return nil
+}
 
-	case *model.Literal:
-		if !s.StripLiterals {
-			return s.formatLiteral(writer, typedExpression)
-		} else {
-			_, err := io.WriteString(writer, strippedLiteral)
-			return err
-		}
+func (s *Emitter) writeSelect(writer io.Writer, source string, filters []model.Expression, singlePartQuery *model.SinglePartQuery) error {
+	if singlePartQuery.Return == nil {
+		return fmt.Errorf("expected a return clause")
+	}
 
-	case *model.Variable:
-		if _, err := io.WriteString(writer, typedExpression.Symbol); err != nil {
-			return err
-		}
+	return s.writeStage(writer, source, filters, singlePartQuery.ReadingClauses, singlePartQuery.Return.Projection)
+}
 
-	case *pgModel.AnnotatedVariable:
-		if _, err := io.WriteString(writer, typedExpression.Symbol); err != nil {
-			return err
-		}
+// writeStage writes a select statement for the reading clauses and projection of a query part
+func (s *Emitter) writeStage(writer io.Writer, source string, filters []model.Expression, readingClauses []*model.ReadingClause, projection *model.Projection) error {
+	if err := s.collectPatterns(readingClauses); err != nil {
+		return err
+	}
 
-	case *pgModel.Entity:
-		switch typedExpression.Binding.Type {
-		case pgModel.Node:
-			if _, err := WriteStrings(writer, "(", typedExpression.Binding.Symbol, ".id, ", typedExpression.Binding.Symbol, ".kind_ids, ", typedExpression.Binding.Symbol, ".properties)::nodeComposite"); err != nil {
-				return err
-			}
+	if _, err := io.WriteString(writer, "select "); err != nil {
+		return err
+	}
 
-		case pgModel.Edge:
-			if _, err := WriteStrings(writer, "(", typedExpression.Binding.Symbol, ".id, ", typedExpression.Binding.Symbol, ".start_id, ", typedExpression.Binding.Symbol, ".end_id, ", typedExpression.Binding.Symbol, ".kind_id, ", typedExpression.Binding.Symbol, ".properties)::edgeComposite"); err != nil {
-				return err
-			}
+	if err := s.writeProjection(writer, projection); err != nil {
+		return err
+	}
+
+	if err := s.writeFrom(writer, source, readingClauses, filters); err != nil {
+		return err
+	}
+
+	if err := s.writeGrouping(writer, projection); err != nil {
+		return err
+	}
+
+	return s.writeProjectionModifiers(writer, projection)
+}
+
+func (s *Emitter) writeDelete(writer io.Writer, singlePartQuery *model.SinglePartQuery, delete *pgModel.Delete) error {
+	if delete.NodeDelete {
+		if _, err := WriteStrings(writer, "delete from ", s.nodeTable, " as ", delete.Binding.Symbol); err != nil {
+			return err
+		}
+
+		first := true
+
+		for _, readingClause := range singlePartQuery.ReadingClauses {
+			if matchClause := readingClause.Match; matchClause != nil {
+				for _, pattern := range matchClause.Pattern {
+					for _, patternElement := range pattern.PatternElements {
+						if nodePattern, isNodePattern := patternElement.AsNodePattern(); isNodePattern {
+							switch typedBinding := nodePattern.Binding.(type) {
+							case *pgModel.AnnotatedVariable:
+								if typedBinding.Symbol == delete.Binding.Symbol {
+									continue
+								}
+							}
+
+							if !first {
+								if _, err := WriteStrings(writer, ", "); err != nil {
+									return err
+								}
+							} else {
+								if _, err := WriteStrings(writer, " using "); err != nil {
+									return err
+								}
+
+								first = false
+							}
+
+							if _, err := WriteStrings(writer, s.nodeTable, " as "); err != nil {
+								return err
+							}
+
+							if err := s.WriteExpression(writer, nodePattern.Binding); err != nil {
+								return err
+							}
+						} else {
+							relationshipPattern, _ := patternElement.AsRelationshipPattern()
+
+							switch typedBinding := relationshipPattern.Binding.(type) {
+							case *pgModel.AnnotatedVariable:
+								if typedBinding.Symbol == delete.Binding.Symbol {
+									continue
+								}
+							}
+
+							if !first {
+								if _, err := WriteStrings(writer, ", "); err != nil {
+									return err
+								}
+							} else {
+								if _, err := WriteStrings(writer, " using "); err != nil {
+									return err
+								}
+
+								first = false
+							}
+
+							if _, err := WriteStrings(writer, s.edgeTable, " as "); err != nil {
+								return err
+							}
+
+							if err := s.WriteExpression(writer, relationshipPattern.Binding); err != nil {
+								return err
+							}
+						}
+					}
+				}
+			}
+		}
+	} else {
+		if _, err := WriteStrings(writer, "delete from ", s.edgeTable, " as ", delete.Binding.Symbol); err != nil {
+			return err
+		}
+
+		first := true
+
+		for _, readingClause := range singlePartQuery.ReadingClauses {
+			if matchClause := readingClause.Match; matchClause != nil {
+				for _, pattern := range matchClause.Pattern {
+					for _, patternElement := range pattern.PatternElements {
+						if nodePattern, isNodePattern := patternElement.AsNodePattern(); isNodePattern {
+							if !first {
+								if _, err := WriteStrings(writer, ", "); err != nil {
+									return err
+								}
+							} else {
+								if _, err := WriteStrings(writer, " using "); err != nil {
+									return err
+								}
+
+								first = false
+							}
+
+							if _, err := WriteStrings(writer, s.nodeTable, " as "); err != nil {
+								return err
+							}
+
+							if err := s.WriteExpression(writer, nodePattern.Binding); err != nil {
+								return err
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	for _, readingClause := range singlePartQuery.ReadingClauses {
+		if matchClause := readingClause.Match; matchClause != nil {
+			if matchClause.Where != nil {
+				if err := s.writeWhere(writer, matchClause.Where); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Emitter) writeUpdates(writer io.Writer, singlePartQuery *model.SinglePartQuery) error {
+	if _, err := io.WriteString(writer, "update "); err != nil {
+		return err
+	}
+
+	for _, readingClause := range singlePartQuery.ReadingClauses {
+		if matchClause := readingClause.Match; matchClause != nil {
+			for idx, pattern := range matchClause.Pattern {
+				if idx > 0 {
+					if _, err := io.WriteString(writer, ", "); err != nil {
+						return err
+					}
+				}
+
+				if err := s.writePatternElements(writer, pattern.PatternElements); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if _, err := WriteStrings(writer, " set "); err != nil {
+		return err
+	}
+
+	for idx, item := range singlePartQuery.UpdatingClauses {
+		if idx > 0 {
+			if _, err := WriteStrings(writer, ", "); err != nil {
+				return err
+			}
+		}
+
+		switch typedUpdateItem := item.(type) {
+		case *pgModel.Delete:
+			if err := s.writeDelete(writer, singlePartQuery, typedUpdateItem); err != nil {
+				return err
+			}
+
+		case *pgModel.PropertyMutation:
+			// Can't use aliased names in the set clauses of the SQL statement so default to just the raw
+			// column names
+			if _, err := WriteStrings(writer, "properties = properties"); err != nil {
+				return err
+			}
+
+			if typedUpdateItem.Additions != nil {
+				if typedUpdateItem.Removals != nil {
+					if _, err := WriteStrings(writer, " - "); err != nil {
+						return err
+					}
+
+					if err := s.WriteExpression(writer, typedUpdateItem.Removals); err != nil {
+						return err
+					}
+
+					if _, err := WriteStrings(writer, "::text[]"); err != nil {
+						return err
+					}
+				}
+
+				if _, err := WriteStrings(writer, " || "); err != nil {
+					return err
+				}
+
+				if err := s.WriteExpression(writer, typedUpdateItem.Additions); err != nil {
+					return err
+				}
+			} else if typedUpdateItem.Removals != nil {
+				if _, err := WriteStrings(writer, " - "); err != nil {
+					return err
+				}
+
+				if err := s.WriteExpression(writer, typedUpdateItem.Removals); err != nil {
+					return err
+				}
+
+				if _, err := WriteStrings(writer, "::text[]"); err != nil {
+					return err
+				}
+			}
+
+		case *pgModel.KindMutation:
+			// Cypher and therefore this translation does not support kind mutation of relationships
+			if typedUpdateItem.Variable.Type != pgModel.Node {
+				return fmt.Errorf("unsupported SQL type for kind mutation: %s", typedUpdateItem.Variable.Type)
+			}
+
+			// Can't use aliased names in the set clauses of the SQL statement so default to just the raw
+			// column names
+			if _, err := WriteStrings(writer, "kind_ids = kind_ids"); err != nil {
+				return err
+			}
+
+			if typedUpdateItem.Additions != nil {
+				if typedUpdateItem.Removals != nil {
+					if _, err := WriteStrings(writer, " - "); err != nil {
+						return err
+					}
+
+					if err := s.WriteExpression(writer, typedUpdateItem.Removals); err != nil {
+						return err
+					}
+				}
+
+				if _, err := WriteStrings(writer, " || "); err != nil {
+					return err
+				}
+
+				if err := s.WriteExpression(writer, typedUpdateItem.Additions); err != nil {
+					return err
+				}
+			} else if typedUpdateItem.Removals != nil {
+				if _, err := WriteStrings(writer, " - "); err != nil {
+					return err
+				}
+
+				if err := s.WriteExpression(writer, typedUpdateItem.Removals); err != nil {
+					return err
+				}
+			}
+
+		default:
+			return fmt.Errorf("unsupported update clause item: %T", item)
+		}
+	}
+
+	for _, readingClause := range singlePartQuery.ReadingClauses {
+		if matchClause := readingClause.Match; matchClause != nil {
+			if matchClause.Where != nil {
+				if err := s.writeWhere(writer, matchClause.Where); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if singlePartQuery.Return != nil {
+		if _, err := WriteStrings(writer, " returning "); err != nil {
+			return err
+		}
+
+		if err := s.writeReturn(writer, singlePartQuery.Return); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeCreate writes an insert statement that creates a node or an edge for each row matched by the reading clauses of
+// the given query. Merges only insert rows for which no matching node or edge exists.
+func (s *Emitter) writeCreate(writer io.Writer, singlePartQuery *model.SinglePartQuery, creation model.Expression) error {
+	if s.graphTarget == nil {
+		return fmt.Errorf("creating nodes and relationships requires a target graph")
+	}
+
+	var (
+		graphID    = strconv.Itoa(int(s.graphTarget.ID))
+		mergeTable string
+		binding    *pgModel.AnnotatedVariable
+		criteria   []model.Expression
+	)
+
+	switch typedCreation := creation.(type) {
+	case *pgModel.NodeCreate:
+		if _, err := WriteStrings(writer, "insert into ", s.nodeTable, " (graph_id, kind_ids, properties) select ", graphID, ", "); err != nil {
+			return err
+		}
+
+		if err := s.WriteExpression(writer, typedCreation.Kinds); err != nil {
+			return err
+		}
+
+		if _, err := WriteStrings(writer, ", "); err != nil {
+			return err
+		}
+
+		if err := s.WriteExpression(writer, typedCreation.Properties); err != nil {
+			return err
+		}
+
+		if typedCreation.Merge {
+			mergeTable = s.nodeTable
+			binding = typedCreation.Binding
+			criteria = []model.Expression{
+				model.NewComparison(model.NewVariableWithSymbol(binding.Symbol+".kind_ids"), OperatorContains, typedCreation.Kinds),
+				model.NewComparison(model.NewVariableWithSymbol(binding.Symbol+".properties"), OperatorContains, typedCreation.Properties),
+			}
+		}
+
+	case *pgModel.EdgeCreate:
+		if _, err := WriteStrings(writer, "insert into ", s.edgeTable, " (graph_id, start_id, end_id, kind_id, properties) select ", graphID, ", ", typedCreation.Start.Symbol, ".id, ", typedCreation.End.Symbol, ".id, "); err != nil {
+			return err
+		}
+
+		if err := s.WriteExpression(writer, typedCreation.Kind); err != nil {
+			return err
+		}
+
+		if _, err := WriteStrings(writer, ", "); err != nil {
+			return err
+		}
+
+		if err := s.WriteExpression(writer, typedCreation.Properties); err != nil {
+			return err
+		}
+
+		if typedCreation.Merge {
+			mergeTable = s.edgeTable
+			binding = typedCreation.Binding
+			criteria = []model.Expression{
+				model.NewComparison(model.NewVariableWithSymbol(binding.Symbol+".start_id"), model.OperatorEquals, model.NewVariableWithSymbol(typedCreation.Start.Symbol+".id")),
+				model.NewComparison(model.NewVariableWithSymbol(binding.Symbol+".end_id"), model.OperatorEquals, model.NewVariableWithSymbol(typedCreation.End.Symbol+".id")),
+				model.NewComparison(model.NewVariableWithSymbol(binding.Symbol+".kind_id"), model.OperatorEquals, typedCreation.Kind),
+				model.NewComparison(model.NewVariableWithSymbol(binding.Symbol+".properties"), OperatorContains, typedCreation.Properties),
+			}
+		}
+
+	default:
+		return fmt.Errorf("unsupported create clause item: %T", creation)
+	}
+
+	var (
+		first     = true
+		whereExpr []model.Expression
+	)
+
+	for _, readingClause := range singlePartQuery.ReadingClauses {
+		if matchClause := readingClause.Match; matchClause != nil {
+			for _, pattern := range matchClause.Pattern {
+				if first {
+					if _, err := WriteStrings(writer, " from "); err != nil {
+						return err
+					}
+
+					first = false
+				} else if _, err := WriteStrings(writer, ", "); err != nil {
+					return err
+				}
+
+				if err := s.writePatternElements(writer, pattern.PatternElements); err != nil {
+					return err
+				}
+			}
+
+			if matchClause.Where != nil {
+				whereExpr = append(whereExpr, matchClause.Where.Expressions...)
+			}
+		}
+	}
+
+	if len(whereExpr) > 1 {
+		whereExpr = []model.Expression{model.NewConjunction(whereExpr...)}
+	}
+
+	whereClause := model.NewWhere()
+
+	for _, expression := range whereExpr {
+		whereClause.Add(expression)
+	}
+
+	if err := s.writeWhere(writer, whereClause); err != nil {
+		return err
+	}
+
+	if mergeTable != "" {
+		if len(whereExpr) > 0 {
+			if _, err := WriteStrings(writer, " and "); err != nil {
+				return err
+			}
+		} else if _, err := WriteStrings(writer, " where "); err != nil {
+			return err
+		}
+
+		if _, err := WriteStrings(writer, "not exists(select 1 from ", mergeTable, " as ", binding.Symbol, " where "); err != nil {
+			return err
+		}
+
+		if err := s.WriteExpression(writer, model.NewConjunction(criteria...)); err != nil {
+			return err
+		}
+
+		if _, err := WriteStrings(writer, ")"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Emitter) writeUpdatingClauses(writer io.Writer, singlePartQuery *model.SinglePartQuery) error {
+	if hasExpansions(singlePartQuery.ReadingClauses) {
+		return fmt.Errorf("variable-length relationship patterns are not supported in updating queries")
+	}
+
+	if hasOptionalMatches(singlePartQuery.ReadingClauses) {
+		return fmt.Errorf("optional matches are not supported in updating queries")
+	}
+
+	// Create and merge clauses are rewritten into a single insert statement
+	for _, updateClause := range singlePartQuery.UpdatingClauses {
+		switch updateClause.(type) {
+		case *pgModel.NodeCreate, *pgModel.EdgeCreate:
+			return s.writeCreate(writer, singlePartQuery, updateClause)
+		}
+	}
+
+	// Delete statements can not be combined with the update statement of other updating clauses
+	for _, updateClause := range singlePartQuery.UpdatingClauses {
+		switch typedClause := updateClause.(type) {
+		case *pgModel.Delete:
+			if len(singlePartQuery.UpdatingClauses) > 1 {
+				return fmt.Errorf("delete clauses may not be combined with other updating clauses")
+			}
+
+			if typedClause.NodeDelete && s.summarizeNodeDeletes {
+				return s.writeNodeDeleteSummary(writer, singlePartQuery, typedClause)
+			}
+
+			return s.writeDelete(writer, singlePartQuery, typedClause)
+		}
+	}
+
+	return s.writeUpdates(writer, singlePartQuery)
+}
+
+// writeNodeDeleteSummary writes the given node deletion as a data-modifying CTE. Every part of the statement reads the
+// same snapshot of the graph so the edges removed by the delete_node_edges trigger are still visible to the final
+// select. Each attached edge is counted once, including edges between two deleted nodes.
+func (s *Emitter) writeNodeDeleteSummary(writer io.Writer, singlePartQuery *model.SinglePartQuery, delete *pgModel.Delete) error {
+	if _, err := io.WriteString(writer, "with deleted as ("); err != nil {
+		return err
+	}
+
+	if err := s.writeDelete(writer, singlePartQuery, delete); err != nil {
+		return err
+	}
+
+	_, err := WriteStrings(writer,
+		" returning ", delete.Binding.Symbol, ".id) select count(*) as nodes_deleted, (select count(*) from ", s.edgeTable,
+		" as e where e.start_id in (select id from deleted) or e.end_id in (select id from deleted)) as relationships_deleted from deleted")
+
+	return err
+}
+
+func (s *Emitter) writeSinglePartQuery(writer io.Writer, singlePartQuery *model.SinglePartQuery) error {
+	if len(singlePartQuery.UpdatingClauses) > 0 {
+		return s.writeUpdatingClauses(writer, singlePartQuery)
+	} else {
+		return s.writeSelect(writer, "", nil, singlePartQuery)
+	}
+}
+
+func (s *Emitter) writeSubquery(writer io.Writer, subquery *pgModel.Subquery) error {
+	if _, err := io.WriteString(writer, "exists(select * from "); err != nil {
+		return err
+	}
+
+	if err := s.writePatternElements(writer, subquery.PatternElements); err != nil {
+		return err
+	}
+
+	if subquery.Filter != nil {
+		subQueryWhereClause := model.NewWhere()
+		subQueryWhereClause.Add(subquery.Filter)
+
+		if err := s.writeWhere(writer, subQueryWhereClause); err != nil {
+			return err
+		}
+	}
+
+	if _, err := io.WriteString(writer, " limit 1)"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Emitter) WriteExpression(writer io.Writer, expression model.Expression) error {
+	switch typedExpression := expression.(type) {
+	case *pgModel.Subquery:
+		if err := s.writeSubquery(writer, typedExpression); err != nil {
+			return err
+		}
+
+	case *model.Negation:
+		if _, err := io.WriteString(writer, "not "); err != nil {
+			return err
+		}
+
+		if err := s.WriteExpression(writer, typedExpression.Expression); err != nil {
+			return err
+		}
+
+	case *model.Disjunction:
+		for idx, joinedExpression := range typedExpression.Expressions {
+			if idx > 0 {
+				if _, err := io.WriteString(writer, " or "); err != nil {
+					return err
+				}
+			}
+
+			if err := s.WriteExpression(writer, joinedExpression); err != nil {
+				return err
+			}
+		}
+
+	case *model.Conjunction:
+		for idx, joinedExpression := range typedExpression.Expressions {
+			if idx > 0 {
+				if _, err := io.WriteString(writer, " and "); err != nil {
+					return err
+				}
+			}
+
+			if err := s.WriteExpression(writer, joinedExpression); err != nil {
+				return err
+			}
+		}
+
+	case *model.Comparison:
+		if err := s.WriteExpression(writer, typedExpression.Left); err != nil {
+			return err
+		}
+
+		for _, nextPart := range typedExpression.Partials {
+			if err := s.WriteExpression(writer, nextPart); err != nil {
+				return err
+			}
+		}
+
+	case *model.PartialComparison:
+		if _, err := WriteStrings(writer, " ", typedExpression.Operator.String(), " "); err != nil {
+			return err
+		}
+
+		if err := s.WriteExpression(writer, typedExpression.Right); err != nil {
+			return err
+		}
+
+	case *pgModel.AnnotatedLiteral:
+		if err := s.WriteExpression(writer, &typedExpression.Literal); err != nil {
+			return err
+		}
+
+	case *model.Literal:
+		if !s.StripLiterals {
+			return s.formatLiteral(writer, typedExpression)
+		} else {
+			_, err := io.WriteString(writer, strippedLiteral)
+			return err
+		}
+
+	case *model.Variable:
+		if isCarried, err := s.writeCarried(writer, typedExpression.Symbol); err != nil || isCarried {
+			return err
+		}
+
+		if _, err := io.WriteString(writer, typedExpression.Symbol); err != nil {
+			return err
+		}
+
+	case *pgModel.AnnotatedVariable:
+		if isCarried, err := s.writeCarried(writer, typedExpression.Symbol); err != nil || isCarried {
+			return err
+		}
+
+		if _, err := io.WriteString(writer, typedExpression.Symbol); err != nil {
+			return err
+		}
+
+	case *pgModel.Entity:
+		// Entities carried from a previous query stage have already been projected as their composite type
+		if isCarried, err := s.writeCarried(writer, typedExpression.Binding.Symbol); err != nil || isCarried {
+			return err
+		}
+
+		// Composites of null fields are not null themselves so entities of optional matches must be guarded
+		guardSymbol, isGuarded, err := s.optionalGuardSymbol(typedExpression)
+
+		if err != nil {
+			return err
+		} else if isGuarded {
+			if _, err := WriteStrings(writer, "case when ", guardSymbol, ".id is null then null else "); err != nil {
+				return err
+			}
+		}
+
+		switch typedExpression.Binding.Type {
+		case pgModel.Node:
+			if _, err := WriteStrings(writer, "(", typedExpression.Binding.Symbol, ".id, ", typedExpression.Binding.Symbol, ".kind_ids, ", typedExpression.Binding.Symbol, ".properties)::nodeComposite"); err != nil {
+				return err
+			}
+
+		case pgModel.Edge:
+			if _, err := WriteStrings(writer, "(", typedExpression.Binding.Symbol, ".id, ", typedExpression.Binding.Symbol, ".start_id, ", typedExpression.Binding.Symbol, ".end_id, ", typedExpression.Binding.Symbol, ".kind_id, ", typedExpression.Binding.Symbol, ".properties)::edgeComposite"); err != nil {
+				return err
+			}
+
+		case pgModel.EdgeArray:
+			if expansion, hasExpansion := s.expansions[typedExpression.Binding.Symbol]; !hasExpansion {
+				return fmt.Errorf("unable to find the variable-length pattern bound to %s", typedExpression.Binding.Symbol)
+			} else if _, err := WriteStrings(writer,
+				"(select array_agg((", typedExpression.Binding.Symbol, ".id, ", typedExpression.Binding.Symbol, ".start_id, ", typedExpression.Binding.Symbol, ".end_id, ", typedExpression.Binding.Symbol, ".kind_id, ", typedExpression.Binding.Symbol, ".properties)::edgeComposite order by hop.ordinality)",
+				" from unnest(", expansion.Binding.Symbol, ".path) with ordinality as hop(id, ordinality) join ", s.edgeTable, " ", typedExpression.Binding.Symbol, " on ", typedExpression.Binding.Symbol, ".id = hop.id)",
+			); err != nil {
+				return err
+			}
+
+		case pgModel.Path:
+			if _, err := WriteStrings(writer, "edges_to_path(variadic "); err != nil {
+				return err
+			}
+
+			if patternElements, hasPath := s.paths[typedExpression.Binding.Symbol]; !hasPath {
+				return fmt.Errorf("unable to find the pattern bound to path %s", typedExpression.Binding.Symbol)
+			} else if err := s.writePathEdgeIDs(writer, patternElements); err != nil {
+				return err
+			}
+
+			if _, err := WriteStrings(writer, ")"); err != nil {
+				return err
+			}
+
+		default:
+			// Values that are not graph entities are projected as they are
+			if err := s.WriteExpression(writer, typedExpression.Binding); err != nil {
+				return err
+			}
+		}
+
+		if isGuarded {
+			if _, err := WriteStrings(writer, " end"); err != nil {
+				return err
+			}
+		}
+
+	case *pgModel.NodeKindsReference:
+		// Nodes store the IDs of their kinds. The labels function returns the names of the kinds in the order they are
+		// stored, the same as Neo4j. The kind IDs are unnested before the kind table is joined so that the reference
+		// to the node can not be shadowed by the kind table.
+		if _, err := WriteStrings(writer, "array(select kind.name from unnest("); err != nil {
+			return err
+		}
+
+		if err := s.WriteExpression(writer, typedExpression.Variable); err != nil {
+			return err
+		}
+
+		if _, err := WriteStrings(writer, ".kind_ids) with ordinality as kind_ref(id, idx) join kind on kind.id = kind_ref.id order by kind_ref.idx)"); err != nil {
+			return err
+		}
+
+	case *pgModel.EdgeKindReference:
+		// Edges store the ID of their kind. The type function returns the name of the kind, the same as Neo4j.
+		if _, err := WriteStrings(writer, "(select kind.name from unnest(array["); err != nil {
+			return err
+		}
+
+		if err := s.WriteExpression(writer, typedExpression.Variable); err != nil {
+			return err
+		}
+
+		if _, err := WriteStrings(writer, ".kind_id]) as kind_ref(id) join kind on kind.id = kind_ref.id)"); err != nil {
+			return err
+		}
+
+	case *pgModel.AnnotatedPropertyLookup:
+		if typedExpression.Type == pgModel.TimestampWithTimeZone || typedExpression.Type == pgModel.TimestampWithoutTimeZone {
+			return s.writeTimestampPropertyLookup(writer, typedExpression)
+		}
+
+		if _, err := io.WriteString(writer, "("); err != nil {
+			return nil
+		}
+
+		if err := s.WriteExpression(writer, typedExpression.Atom); err != nil {
+			return err
+		}
+
+		switch typedExpression.Type {
+		case
+			// We can't directly cast from JSONB types to time types since they require parsing first. The '->>'
+			// operator coerces the underlying JSONB value to text before type casting
+			pgModel.Date, pgModel.TimeWithTimeZone, pgModel.TimeWithoutTimeZone,
+
+			// Text types also require the `->>' operator otherwise type casting clobbers itself
+			pgModel.Text:
+
+			if _, err := io.WriteString(writer, ".properties->>'"); err != nil {
+				return nil
+			}
+
+		default:
+			if _, err := io.WriteString(writer, ".properties->'"); err != nil {
+				return nil
+			}
+		}
+
+		if _, err := WriteStrings(writer, typedExpression.Symbols[0], "')::", typedExpression.Type.String()); err != nil {
+			return nil
+		}
+
+	case *model.PropertyLookup:
+		if isTemporalExpression(typedExpression.Atom) {
+			return s.writeTemporalAccessor(writer, typedExpression.Atom, typedExpression.Symbols[0])
+		}
+
+		if err := s.WriteExpression(writer, typedExpression.Atom); err != nil {
+			return err
+		}
+
+		if _, err := WriteStrings(writer, ".properties->'", typedExpression.Symbols[0], "'"); err != nil {
+			return nil
+		}
+
+	case *pgModel.AnnotatedKindMatcher:
+		if err := s.WriteExpression(writer, typedExpression.Reference); err != nil {
+			return err
+		}
+
+		if mappedKinds, missingKinds := s.kindMapper.MapKinds(typedExpression.Kinds); len(missingKinds) > 0 {
+			return fmt.Errorf("query references the following undefined kinds: %v", missingKinds.Strings())
+		} else {
+			mappedKindStr := JoinInt(mappedKinds, ", ")
+
+			switch typedExpression.Type {
+			case pgModel.Node:
+				if _, err := WriteStrings(writer, ".kind_ids operator(pg_catalog.&&) array[", mappedKindStr, "]::int2[]"); err != nil {
+					return err
+				}
+
+			case pgModel.Edge:
+				if _, err := WriteStrings(writer, ".kind_id = any(array[", mappedKindStr, "]::int2[])"); err != nil {
+					return err
+				}
+			}
+		}
+
+	case *model.FunctionInvocation:
+		if err := s.translateFunctionInvocation(writer, typedExpression); err != nil {
+			return err
+		}
+
+	case *model.Parameter:
+		if _, err := WriteStrings(writer, "@", typedExpression.Symbol); err != nil {
+			return err
+		}
+
+	case *pgModel.AnnotatedParameter:
+		if _, err := WriteStrings(writer, "@", typedExpression.Symbol); err != nil {
+			return err
+		}
+
+	case *model.ArithmeticExpression:
+		if err := s.WriteExpression(writer, typedExpression.Left); err != nil {
+			return err
+		}
+
+		for _, partial := range typedExpression.Partials {
+			if err := s.WriteExpression(writer, partial); err != nil {
+				return err
+			}
+		}
+
+	case *model.PartialArithmeticExpression:
+		if _, err := WriteStrings(writer, " ", typedExpression.Operator.String(), " "); err != nil {
+			return err
+		}
+
+		if err := s.WriteExpression(writer, typedExpression.Right); err != nil {
+			return err
+		}
+
+	case *model.Parenthetical:
+		if _, err := WriteStrings(writer, "("); err != nil {
+			return err
+		}
+
+		if err := s.WriteExpression(writer, typedExpression.Expression); err != nil {
+			return err
+		}
+
+		if _, err := WriteStrings(writer, ")"); err != nil {
+			return err
+		}
+
+	case *pgModel.PropertiesReference:
+		if err := s.WriteExpression(writer, typedExpression.Reference); err != nil {
+			return err
+		}
+
+		if _, err := WriteStrings(writer, ".properties"); err != nil {
+			return err
+		}
+
+	case *model.ProjectionItem:
+		if err := s.WriteExpression(writer, typedExpression.Expression); err != nil {
+			return err
+		}
+
+		if _, err := WriteStrings(writer, " as "); err != nil {
+			return err
+		}
+
+		if typedExpression.Binding != nil {
+			// Bindings name the projected column and must not be written as references to a carried column
+			if symbol, err := GetSymbol(typedExpression.Binding); err != nil {
+				return err
+			} else if _, err := WriteStrings(writer, symbol); err != nil {
+				return err
+			}
+		} else if err := s.writeDerivedColumnName(writer, typedExpression); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unexpected expression type for string formatting: %T", expression)
+	}
+
+	return nil
+}
+
+// writeDerivedColumnName writes a column name for a projection item that has no binding. Column names are derived
+// from the symbols of the query rather than references to carried columns or the guards of optional bindings.
+func (s *Emitter) writeDerivedColumnName(writer io.Writer, projectionItem *model.ProjectionItem) error {
+	var (
+		carried  = s.carried
+		optional = s.optional
+	)
+
+	s.carried = nil
+	s.optional = nil
+
+	defer func() {
+		s.carried = carried
+		s.optional = optional
+	}()
+
+	if _, err := WriteStrings(writer, "\""); err != nil {
+		return err
+	}
+
+	switch typedProjectionExpression := projectionItem.Expression.(type) {
+	case *pgModel.NodeKindsReference:
+		if _, err := WriteStrings(writer, cypherNodeLabelsFunction, "("); err != nil {
+			return err
+		}
+
+		if err := s.WriteExpression(writer, typedProjectionExpression.Variable); err != nil {
+			return err
+		}
+
+		if _, err := WriteStrings(writer, ")"); err != nil {
+			return err
+		}
+
+	case *pgModel.EdgeKindReference:
+		if _, err := WriteStrings(writer, cypherEdgeTypeFunction, "("); err != nil {
+			return err
+		}
+
+		if err := s.WriteExpression(writer, typedProjectionExpression.Variable); err != nil {
+			return err
+		}
+
+		if _, err := WriteStrings(writer, ")"); err != nil {
+			return err
+		}
+
+	case *model.FunctionInvocation:
+		if err := s.WriteExpression(writer, typedProjectionExpression); err != nil {
+			return err
+		}
+
+	case *model.PropertyLookup:
+		if err := s.WriteExpression(writer, typedProjectionExpression.Atom); err != nil {
+			return err
+		}
+
+		if _, err := WriteStrings(writer, ".", typedProjectionExpression.Symbols[0]); err != nil {
+			return err
+		}
+
+	case *pgModel.AnnotatedPropertyLookup:
+		if err := s.WriteExpression(writer, typedProjectionExpression.Atom); err != nil {
+			return err
+		}
+
+		if _, err := WriteStrings(writer, ".", typedProjectionExpression.Symbols[0]); err != nil {
+			return err
+		}
+
+	case *pgModel.AnnotatedVariable:
+		if err := s.WriteExpression(writer, typedProjectionExpression.Symbol); err != nil {
+			return err
+		}
+
+	case *pgModel.Entity:
+		if err := s.WriteExpression(writer, typedProjectionExpression.Binding); err != nil {
+			return err
+		}
+
+	default:
+		return fmt.Errorf("unexpected projection item for binding formatting: %T", projectionItem.Expression)
+	}
+
+	if _, err := WriteStrings(writer, "\""); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Emitter) translateFunctionInvocation(writer io.Writer, functionInvocation *model.FunctionInvocation) error {
+	switch functionInvocation.Name {
+	case cypherIdentityFunction:
+		if err := s.WriteExpression(writer, functionInvocation.Arguments[0]); err != nil {
+			return err
+		}
+
+		if _, err := io.WriteString(writer, ".id"); err != nil {
+			return err
+		}
+
+	case cypherDateFunction:
+		if len(functionInvocation.Arguments) > 0 {
+			if err := s.WriteExpression(writer, functionInvocation.Arguments[0]); err != nil {
+				return err
+			}
+
+			if _, err := io.WriteString(writer, "::date"); err != nil {
+				return err
+			}
+		} else if _, err := io.WriteString(writer, "current_date"); err != nil {
+			return err
+		}
+
+	case cypherTimeFunction:
+		if len(functionInvocation.Arguments) > 0 {
+			if err := s.WriteExpression(writer, functionInvocation.Arguments[0]); err != nil {
+				return err
+			}
+
+			if _, err := io.WriteString(writer, "::time with time zone"); err != nil {
+				return err
+			}
+		} else if _, err := io.WriteString(writer, "current_time"); err != nil {
+			return err
+		}
+
+	case cypherLocalTimeFunction:
+		if len(functionInvocation.Arguments) > 0 {
+			if err := s.WriteExpression(writer, functionInvocation.Arguments[0]); err != nil {
+				return err
+			}
+
+			if _, err := io.WriteString(writer, "::time without time zone"); err != nil {
+				return err
+			}
+		} else if _, err := io.WriteString(writer, "localtime"); err != nil {
+			return err
+		}
+
+	case cypherDateTimeFunction:
+		if len(functionInvocation.Arguments) > 0 {
+			if mapLiteral, isMapLiteral := asMapLiteral(functionInvocation.Arguments[0]); isMapLiteral {
+				return s.writeEpochDateTime(writer, mapLiteral)
+			}
+
+			if err := s.WriteExpression(writer, functionInvocation.Arguments[0]); err != nil {
+				return err
+			}
+
+			if _, err := io.WriteString(writer, "::timestamp with time zone"); err != nil {
+				return err
+			}
+		} else if _, err := io.WriteString(writer, "now()"); err != nil {
+			return err
+		}
+
+	case cypherLocalDateTimeFunction:
+		if len(functionInvocation.Arguments) > 0 {
+			if err := s.WriteExpression(writer, functionInvocation.Arguments[0]); err != nil {
+				return err
+			}
+
+			if _, err := io.WriteString(writer, "::timestamp without time zone"); err != nil {
+				return err
+			}
+		} else if _, err := io.WriteString(writer, "localtimestamp"); err != nil {
+			return err
+		}
+
+	case cypherCollectFunction:
+		if err := s.writeFunction(writer, pgsqlArrayAggFunction, functionInvocation); err != nil {
+			return err
+		}
+
+		// Collect ignores null values. Bindings of an optional match are null for rows where it found nothing.
+		if referencesOptional, err := s.referencesOptional(functionInvocation.Arguments[0]); err != nil {
+			return err
+		} else if referencesOptional {
+			if _, err := WriteStrings(writer, " filter (where "); err != nil {
+				return err
+			}
+
+			if entity, isEntity := functionInvocation.Arguments[0].(*pgModel.Entity); !isEntity {
+				if err := s.WriteExpression(writer, functionInvocation.Arguments[0]); err != nil {
+					return err
+				}
+			} else if guardSymbol, isGuarded, err := s.optionalGuardSymbol(entity); err != nil {
+				return err
+			} else if isGuarded {
+				if _, err := WriteStrings(writer, guardSymbol, ".id"); err != nil {
+					return err
+				}
+			} else if err := s.WriteExpression(writer, entity); err != nil {
+				return err
+			}
+
+			if _, err := WriteStrings(writer, " is not null)"); err != nil {
+				return err
+			}
+		}
+
+	case cypherDurationFunction:
+		if err := s.writeDuration(writer, functionInvocation); err != nil {
+			return err
+		}
+
+	case cypherSubstringFunction:
+		// Cypher string offsets start at 0 whereas pgsql string offsets start at 1
+		if len(functionInvocation.Arguments) < 2 {
+			return fmt.Errorf("expected at least two arguments for function %s", functionInvocation.Name)
+		}
+
+		if _, err := WriteStrings(writer, pgsqlSubstringFunction, "("); err != nil {
+			return err
+		}
+
+		if err := s.WriteExpression(writer, functionInvocation.Arguments[0]); err != nil {
+			return err
+		}
+
+		if _, err := WriteStrings(writer, ", "); err != nil {
+			return err
+		}
+
+		if err := s.WriteExpression(writer, functionInvocation.Arguments[1]); err != nil {
+			return err
+		}
 
-		case pgModel.Path:
-			if _, err := WriteStrings(writer, "edges_to_path(", ")"); err != nil {
+		if _, err := WriteStrings(writer, " + 1"); err != nil {
+			return err
+		}
+
+		if len(functionInvocation.Arguments) > 2 {
+			if _, err := WriteStrings(writer, ", "); err != nil {
+				return err
+			}
+
+			if err := s.WriteExpression(writer, functionInvocation.Arguments[2]); err != nil {
 				return err
 			}
+		}
+
+		if _, err := WriteStrings(writer, ")"); err != nil {
+			return err
+		}
+
+	case cypherSizeFunction:
+		switch valueCategoryOf(functionInvocation.Arguments[0]) {
+		case textValue:
+			return s.writeFunction(writer, pgsqlCharLengthFunction, functionInvocation)
+
+		case jsonbValue:
+			return s.writeJSONBSize(writer, functionInvocation.Arguments[0])
 
 		default:
-			return fmt.Errorf("unsupported entity type %s", typedExpression.Binding.Type)
+			return s.writeFunction(writer, pgsqlCardinalityFunction, functionInvocation)
 		}
 
-	case *pgModel.NodeKindsReference:
-		if err := s.WriteExpression(writer, typedExpression.Variable); err != nil {
+	case cypherLengthFunction:
+		if entity, isEntity := functionInvocation.Arguments[0].(*pgModel.Entity); !isEntity || entity.Binding.Type != pgModel.Path {
+			return s.writeFunction(writer, pgsqlCharLengthFunction, functionInvocation)
+		} else if _, isCarried := s.carried[entity.Binding.Symbol]; isCarried {
+			// The length of a path is the number of relationships it contains
+			if _, err := WriteStrings(writer, pgsqlCardinalityFunction, "(("); err != nil {
+				return err
+			}
+
+			if err := s.WriteExpression(writer, entity); err != nil {
+				return err
+			}
+
+			if _, err := WriteStrings(writer, ").edges)"); err != nil {
+				return err
+			}
+		} else if patternElements, hasPath := s.paths[entity.Binding.Symbol]; !hasPath {
+			return fmt.Errorf("unable to find the pattern bound to path %s", entity.Binding.Symbol)
+		} else {
+			if _, err := WriteStrings(writer, pgsqlCardinalityFunction, "("); err != nil {
+				return err
+			}
+
+			if err := s.writePathEdgeIDs(writer, patternElements); err != nil {
+				return err
+			}
+
+			if _, err := WriteStrings(writer, ")"); err != nil {
+				return err
+			}
+		}
+
+	case cypherHeadFunction, cypherLastFunction:
+		if err := s.writeListElement(writer, functionInvocation); err != nil {
+			return err
+		}
+
+	case cypherNodesFunction, cypherRelationshipsFunction:
+		if entity, isEntity := functionInvocation.Arguments[0].(*pgModel.Entity); !isEntity || entity.Binding.Type != pgModel.Path {
+			return fmt.Errorf("expected a path as the first argument in %s function", functionInvocation.Name)
+		}
+
+		if _, err := WriteStrings(writer, "("); err != nil {
 			return err
 		}
 
-		if _, err := WriteStrings(writer, ".kind_ids"); err != nil {
+		if err := s.WriteExpression(writer, functionInvocation.Arguments[0]); err != nil {
 			return err
 		}
 
-	case *pgModel.EdgeKindReference:
-		if err := s.WriteExpression(writer, typedExpression.Variable); err != nil {
+		if functionInvocation.Name == cypherNodesFunction {
+			if _, err := WriteStrings(writer, ").nodes"); err != nil {
+				return err
+			}
+		} else if _, err := WriteStrings(writer, ").edges"); err != nil {
+			return err
+		}
+
+	case cypherKeysFunction:
+		if _, err := WriteStrings(writer, "array(select ", pgsqlJSONBObjectKeysFunction, "("); err != nil {
+			return err
+		}
+
+		if err := s.WriteExpression(writer, functionInvocation.Arguments[0]); err != nil {
+			return err
+		}
+
+		if _, err := WriteStrings(writer, "))"); err != nil {
+			return err
+		}
+
+	case cypherToStringFunction:
+		if err := s.writeCast(writer, functionInvocation.Arguments[0], pgModel.Text); err != nil {
+			return err
+		}
+
+	case cypherToFloatFunction:
+		if err := s.writeNumericCast(writer, functionInvocation.Arguments[0], pgModel.Float8); err != nil {
 			return err
 		}
 
-		if _, err := WriteStrings(writer, ".kind_id"); err != nil {
+	case cypherToIntegerFunction:
+		// Cypher truncates fractional values when converting them to integers whereas pgsql rounds them
+		if _, err := WriteStrings(writer, "trunc("); err != nil {
+			return err
+		}
+
+		if err := s.writeNumericCast(writer, functionInvocation.Arguments[0], pgModel.Numeric); err != nil {
+			return err
+		}
+
+		if _, err := WriteStrings(writer, ")::", pgModel.Int8.String()); err != nil {
+			return err
+		}
+
+	case cypherExistsFunction:
+		// Pattern predicates are written as exists subqueries
+		if err := s.WriteExpression(writer, functionInvocation.Arguments[0]); err != nil {
+			return err
+		}
+
+	default:
+		if pgsqlFunction, isSupported := pgsqlFunctions[functionInvocation.Name]; !isSupported {
+			return fmt.Errorf("unsupported function invocation %s", functionInvocation.Name)
+		} else if err := s.writeFunction(writer, pgsqlFunction, functionInvocation); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// pgsqlFunctions contains the cypher functions that translate to a pgsql function with the same arguments
+var pgsqlFunctions = map[string]string{
+	cypherCountFunction:    cypherCountFunction,
+	cypherMinFunction:      cypherMinFunction,
+	cypherMaxFunction:      cypherMaxFunction,
+	cypherSumFunction:      cypherSumFunction,
+	cypherAvgFunction:      cypherAvgFunction,
+	cypherToLowerFunction:  pgsqlToLowerFunction,
+	cypherToUpperFunction:  pgsqlToUpperFunction,
+	cypherSplitFunction:    pgsqlStringToArrayFunction,
+	cypherReplaceFunction:  pgsqlReplaceFunction,
+	cypherTrimFunction:     pgsqlTrimFunction,
+	cypherCoalesceFunction: pgsqlCoalesceFunction,
+	cypherAbsFunction:      pgsqlAbsFunction,
+	pgsqlAnyFunction:       pgsqlAnyFunction,
+	pgsqlToJSONBFunction:   pgsqlToJSONBFunction,
+}
+
+// writeFunction writes an invocation of the named pgsql function with the arguments of the given cypher function
+// invocation
+func (s *Emitter) writeFunction(writer io.Writer, pgsqlFunction string, functionInvocation *model.FunctionInvocation) error {
+	if _, err := WriteStrings(writer, pgsqlFunction, "("); err != nil {
+		return err
+	}
+
+	if functionInvocation.Distinct {
+		if _, err := WriteStrings(writer, "distinct "); err != nil {
+			return err
+		}
+	}
+
+	for idx, argument := range functionInvocation.Arguments {
+		if idx > 0 {
+			if _, err := WriteStrings(writer, ", "); err != nil {
+				return err
+			}
+		}
+
+		// The asterisk of count(*) is parsed as a range quantifier
+		if rangeQuantifier, isRangeQuantifier := argument.(*model.RangeQuantifier); isRangeQuantifier {
+			if _, err := WriteStrings(writer, rangeQuantifier.Value); err != nil {
+				return err
+			}
+		} else if err := s.WriteExpression(writer, argument); err != nil {
+			return err
+		}
+	}
+
+	_, err := WriteStrings(writer, ")")
+	return err
+}
+
+// writeCast writes the given expression cast to the given type. Property lookups are converted to text first so that
+// string values are cast by their content rather than their jsonb representation.
+func (s *Emitter) writeCast(writer io.Writer, expression model.Expression, dataType pgModel.DataType) error {
+	if propertyLookup, isPropertyLookup := expression.(*model.PropertyLookup); isPropertyLookup {
+		expression = pgModel.NewAnnotatedPropertyLookup(propertyLookup, pgModel.Text)
+	}
+
+	if _, err := WriteStrings(writer, "("); err != nil {
+		return err
+	}
+
+	if err := s.WriteExpression(writer, expression); err != nil {
+		return err
+	}
+
+	_, err := WriteStrings(writer, ")::", dataType.String())
+	return err
+}
+
+// numericTextPattern matches the text representations of numbers that may be cast to a numeric type
+const numericTextPattern = `^\s*[-+]?[0-9]*\.?[0-9]+([eE][-+]?[0-9]+)?\s*$`
+
+// writeNumericCast writes the given expression cast to the given numeric type. Values that do not represent a number
+// convert to null instead of failing the query, as they do in cypher.
+func (s *Emitter) writeNumericCast(writer io.Writer, expression model.Expression, dataType pgModel.DataType) error {
+	if _, err := WriteStrings(writer, "case when "); err != nil {
+		return err
+	}
+
+	switch typedExpression := expression.(type) {
+	case *model.PropertyLookup:
+		if err := s.WriteExpression(writer, pgModel.NewAnnotatedPropertyLookup(typedExpression, pgModel.Text)); err != nil {
 			return err
 		}
 
 	case *pgModel.AnnotatedPropertyLookup:
-		if _, err := io.WriteString(writer, "("); err != nil {
-			return nil
+		if typedExpression.Type != pgModel.Text {
+			if err := s.writeCast(writer, expression, pgModel.Text); err != nil {
+				return err
+			}
+		} else if err := s.WriteExpression(writer, typedExpression); err != nil {
+			return err
 		}
 
-		if err := s.WriteExpression(writer, typedExpression.Atom); err != nil {
+	default:
+		if err := s.writeCast(writer, expression, pgModel.Text); err != nil {
 			return err
 		}
+	}
 
-		switch typedExpression.Type {
-		case
-			// We can't directly cast from JSONB types to time types since they require parsing first. The '->>'
-			// operator coerces the underlying JSONB value to text before type casting
-			pgModel.Date, pgModel.TimeWithTimeZone, pgModel.TimeWithoutTimeZone, pgModel.TimestampWithTimeZone, pgModel.TimestampWithoutTimeZone,
+	if _, err := WriteStrings(writer, " ~ '", numericTextPattern, "' then "); err != nil {
+		return err
+	}
 
-			// Text types also require the `->>' operator otherwise type casting clobbers itself
-			pgModel.Text:
+	if err := s.writeCast(writer, expression, dataType); err != nil {
+		return err
+	}
+
+	_, err := WriteStrings(writer, " end")
+	return err
+}
+
+// valueCategory describes how a value that may either be a string or a list must be operated on
+type valueCategory int
+
+const (
+	arrayValue valueCategory = iota
+	textValue
+	jsonbValue
+)
+
+// valueCategoryOf returns the category of the given function argument
+func valueCategoryOf(expression model.Expression) valueCategory {
+	switch typedExpression := expression.(type) {
+	case *model.PropertyLookup:
+		return jsonbValue
+
+	case *pgModel.AnnotatedPropertyLookup:
+		return valueCategoryOfType(typedExpression.Type)
+
+	case *pgModel.AnnotatedLiteral:
+		return valueCategoryOfType(typedExpression.Type)
+
+	case *pgModel.AnnotatedVariable:
+		return valueCategoryOfType(typedExpression.Type)
+
+	case *model.FunctionInvocation:
+		switch typedExpression.Name {
+		case cypherToLowerFunction, cypherToUpperFunction, cypherTrimFunction, cypherReplaceFunction, cypherSubstringFunction, cypherToStringFunction:
+			return textValue
+		}
+	}
+
+	return arrayValue
+}
+
+func valueCategoryOfType(dataType pgModel.DataType) valueCategory {
+	switch dataType {
+	case pgModel.Text:
+		return textValue
+
+	case pgModel.JSONB, pgModel.UnknownDataType:
+		// Values of an unknown type are property values carried from a previous query stage
+		return jsonbValue
+
+	default:
+		return arrayValue
+	}
+}
+
+// writeJSONBSize writes the size of a jsonb value which is either the number of elements of an array or the number of
+// characters of a string
+func (s *Emitter) writeJSONBSize(writer io.Writer, expression model.Expression) error {
+	if _, err := WriteStrings(writer, "case jsonb_typeof("); err != nil {
+		return err
+	}
+
+	if err := s.WriteExpression(writer, expression); err != nil {
+		return err
+	}
+
+	if _, err := WriteStrings(writer, ") when 'array' then ", pgsqlJSONBArrayLengthFunction, "("); err != nil {
+		return err
+	}
+
+	if err := s.WriteExpression(writer, expression); err != nil {
+		return err
+	}
+
+	if _, err := WriteStrings(writer, ") when 'string' then ", pgsqlCharLengthFunction, "("); err != nil {
+		return err
+	}
+
+	if err := s.WriteExpression(writer, expression); err != nil {
+		return err
+	}
+
+	_, err := WriteStrings(writer, " #>> '{}') end")
+	return err
+}
+
+// writeListElement writes the first or last element of a list. Lists stored as properties are jsonb arrays which
+// support negative offsets whereas pgsql arrays do not.
+func (s *Emitter) writeListElement(writer io.Writer, functionInvocation *model.FunctionInvocation) error {
+	list := functionInvocation.Arguments[0]
+
+	if valueCategoryOf(list) == jsonbValue {
+		if err := s.WriteExpression(writer, list); err != nil {
+			return err
+		}
+
+		if functionInvocation.Name == cypherHeadFunction {
+			_, err := WriteStrings(writer, "->0")
+			return err
+		}
+
+		_, err := WriteStrings(writer, "->-1")
+		return err
+	}
+
+	if _, err := WriteStrings(writer, "("); err != nil {
+		return err
+	}
+
+	if err := s.WriteExpression(writer, list); err != nil {
+		return err
+	}
+
+	if functionInvocation.Name == cypherHeadFunction {
+		_, err := WriteStrings(writer, ")[1]")
+		return err
+	}
+
+	if _, err := WriteStrings(writer, ")[", pgsqlCardinalityFunction, "("); err != nil {
+		return err
+	}
+
+	if err := s.WriteExpression(writer, list); err != nil {
+		return err
+	}
+
+	_, err := WriteStrings(writer, ")]")
+	return err
+}
+
+func asMapLiteral(expression model.Expression) (model.MapLiteral, bool) {
+	switch typedExpression := expression.(type) {
+	case *model.Literal:
+		mapLiteral, isMapLiteral := typedExpression.Value.(model.MapLiteral)
+		return mapLiteral, isMapLiteral
+
+	case *pgModel.AnnotatedLiteral:
+		mapLiteral, isMapLiteral := typedExpression.Value.(model.MapLiteral)
+		return mapLiteral, isMapLiteral
+	}
+
+	return nil, false
+}
+
+// writeEpochDateTime writes a timestamp for the seconds or milliseconds since the unix epoch given by the map
+// argument of the datetime function
+func (s *Emitter) writeEpochDateTime(writer io.Writer, mapLiteral model.MapLiteral) error {
+	if len(mapLiteral) != 1 {
+		return fmt.Errorf("expected a single epoch component for function %s", cypherDateTimeFunction)
+	}
+
+	for key, value := range mapLiteral {
+		if _, err := WriteStrings(writer, pgsqlToTimestampFunction, "("); err != nil {
+			return err
+		}
+
+		if valueCategoryOf(value) == jsonbValue {
+			// Epoch values read from properties are jsonb numbers which have to be cast before to_timestamp accepts them
+			if _, err := WriteStrings(writer, "("); err != nil {
+				return err
+			}
+
+			if err := s.WriteExpression(writer, value); err != nil {
+				return err
+			}
+
+			if _, err := WriteStrings(writer, ")::", pgModel.Numeric.String()); err != nil {
+				return err
+			}
+		} else if err := s.WriteExpression(writer, value); err != nil {
+			return err
+		}
+
+		switch strings.ToLower(key) {
+		case cypherEpochSecondsAccessor:
+			if _, err := WriteStrings(writer, ")"); err != nil {
+				return err
+			}
+
+		case cypherEpochMillisAccessor:
+			if _, err := WriteStrings(writer, " / 1000.0)"); err != nil {
+				return err
+			}
+
+		default:
+			return fmt.Errorf("unsupported component %s for function %s", key, cypherDateTimeFunction)
+		}
+	}
+
+	return nil
+}
+
+// durationComponents maps the components of a cypher duration to the arguments of the pgsql make_interval function
+var durationComponents = map[string]string{
+	"years":   "years",
+	"months":  "months",
+	"weeks":   "weeks",
+	"days":    "days",
+	"hours":   "hours",
+	"minutes": "mins",
+	"seconds": "secs",
+}
+
+// writeDuration writes an interval for either an ISO 8601 duration string or a map of duration components
+func (s *Emitter) writeDuration(writer io.Writer, functionInvocation *model.FunctionInvocation) error {
+	if len(functionInvocation.Arguments) != 1 {
+		return fmt.Errorf("expected a single argument for function %s", functionInvocation.Name)
+	}
 
-			if _, err := io.WriteString(writer, ".properties->>'"); err != nil {
-				return nil
-			}
+	mapLiteral, isMapLiteral := asMapLiteral(functionInvocation.Arguments[0])
 
-		default:
-			if _, err := io.WriteString(writer, ".properties->'"); err != nil {
-				return nil
-			}
-		}
+	if !isMapLiteral {
+		// ISO 8601 durations, such as 'P90D', are valid interval input
+		return s.writeCast(writer, functionInvocation.Arguments[0], pgModel.Interval)
+	}
 
-		if _, err := WriteStrings(writer, typedExpression.Symbols[0], "')::", typedExpression.Type.String()); err != nil {
-			return nil
-		}
+	// Components are written in a stable order
+	keys := make([]string, 0, len(mapLiteral))
 
-	case *model.PropertyLookup:
-		if err := s.WriteExpression(writer, typedExpression.Atom); err != nil {
-			return err
-		}
+	for key := range mapLiteral {
+		keys = append(keys, key)
+	}
 
-		if _, err := WriteStrings(writer, ".properties->'", typedExpression.Symbols[0], "'"); err != nil {
-			return nil
-		}
+	sort.Strings(keys)
 
-	case *pgModel.AnnotatedKindMatcher:
-		if err := s.WriteExpression(writer, typedExpression.Reference); err != nil {
-			return err
-		}
+	if _, err := WriteStrings(writer, pgsqlMakeIntervalFunction, "("); err != nil {
+		return err
+	}
 
-		if mappedKinds, missingKinds := s.kindMapper.MapKinds(typedExpression.Kinds); len(missingKinds) > 0 {
-			return fmt.Errorf("query references the following undefined kinds: %v", missingKinds.Strings())
+	for idx, key := range keys {
+		if component, isComponent := durationComponents[strings.ToLower(key)]; !isComponent {
+			return fmt.Errorf("unsupported component %s for function %s", key, functionInvocation.Name)
 		} else {
-			mappedKindStr := JoinInt(mappedKinds, ", ")
-
-			switch typedExpression.Type {
-			case pgModel.Node:
-				if _, err := WriteStrings(writer, ".kind_ids operator(pg_catalog.&&) array[", mappedKindStr, "]::int2[]"); err != nil {
+			if idx > 0 {
+				if _, err := WriteStrings(writer, ", "); err != nil {
 					return err
 				}
+			}
 
-			case pgModel.Edge:
-				if _, err := WriteStrings(writer, ".kind_id = any(array[", mappedKindStr, "]::int2[])"); err != nil {
-					return err
-				}
+			if _, err := WriteStrings(writer, component, " => "); err != nil {
+				return err
 			}
-		}
 
-	case *model.FunctionInvocation:
-		if err := s.translateFunctionInvocation(writer, typedExpression); err != nil {
-			return err
+			if err := s.WriteExpression(writer, mapLiteral[key]); err != nil {
+				return err
+			}
 		}
+	}
 
-	case *model.Parameter:
-		if _, err := WriteStrings(writer, "@", typedExpression.Symbol); err != nil {
-			return err
-		}
+	_, err := WriteStrings(writer, ")")
+	return err
+}
 
-	case *pgModel.AnnotatedParameter:
-		if _, err := WriteStrings(writer, "@", typedExpression.Symbol); err != nil {
+// writeTimestampPropertyLookup writes a property as a timestamp. Timestamps such as lastlogontimestamp and pwdlastset
+// are stored as seconds since the unix epoch while other timestamps are stored as strings, so the conversion depends
+// on the JSONB type of the property value.
+func (s *Emitter) writeTimestampPropertyLookup(writer io.Writer, propertyLookup *pgModel.AnnotatedPropertyLookup) error {
+	writeProperty := func(operator string) error {
+		if err := s.WriteExpression(writer, propertyLookup.Atom); err != nil {
 			return err
 		}
 
-	case *model.Parenthetical:
-		if _, err := WriteStrings(writer, "("); err != nil {
-			return err
-		}
+		_, err := WriteStrings(writer, ".properties", operator, "'", propertyLookup.Symbols[0], "'")
+		return err
+	}
 
-		if err := s.WriteExpression(writer, typedExpression.Expression); err != nil {
-			return err
-		}
+	if _, err := WriteStrings(writer, "case jsonb_typeof("); err != nil {
+		return err
+	} else if err := writeProperty("->"); err != nil {
+		return err
+	} else if _, err := WriteStrings(writer, ") when 'number' then ", pgsqlToTimestampFunction, "(("); err != nil {
+		return err
+	} else if err := writeProperty("->"); err != nil {
+		return err
+	} else if _, err := WriteStrings(writer, ")::", pgModel.Numeric.String(), ")"); err != nil {
+		return err
+	}
 
-		if _, err := WriteStrings(writer, ")"); err != nil {
+	if propertyLookup.Type == pgModel.TimestampWithoutTimeZone {
+		if _, err := WriteStrings(writer, "::", pgModel.TimestampWithoutTimeZone.String()); err != nil {
 			return err
 		}
+	}
 
-	case *pgModel.PropertiesReference:
-		if err := s.WriteExpression(writer, typedExpression.Reference); err != nil {
-			return err
-		}
+	if _, err := WriteStrings(writer, " else ("); err != nil {
+		return err
+	} else if err := writeProperty("->>"); err != nil {
+		return err
+	}
 
-		if _, err := WriteStrings(writer, ".properties"); err != nil {
-			return err
-		}
+	_, err := WriteStrings(writer, ")::", propertyLookup.Type.String(), " end")
+	return err
+}
 
-	case *model.ProjectionItem:
-		if err := s.WriteExpression(writer, typedExpression.Expression); err != nil {
-			return err
+// isTemporalExpression returns true if the given expression evaluates to a temporal value, such as datetime() or
+// datetime() - duration('P90D'), whose components may be accessed
+func isTemporalExpression(expression model.Expression) bool {
+	switch typedExpression := expression.(type) {
+	case *model.FunctionInvocation:
+		switch typedExpression.Name {
+		case cypherDateFunction, cypherTimeFunction, cypherLocalTimeFunction, cypherDateTimeFunction, cypherLocalDateTimeFunction:
+			return true
 		}
 
-		if _, err := WriteStrings(writer, " as "); err != nil {
-			return err
-		}
+	case *model.Parenthetical:
+		return isTemporalExpression(typedExpression.Expression)
 
-		if typedExpression.Binding != nil {
-			if err := s.WriteExpression(writer, typedExpression.Binding); err != nil {
-				return err
-			}
-		} else {
-			if _, err := WriteStrings(writer, "\""); err != nil {
-				return err
-			}
+	case *model.ArithmeticExpression:
+		// Offsetting a temporal value by a duration does not change its type
+		return isTemporalExpression(typedExpression.Left)
+	}
 
-			switch typedProjectionExpression := typedExpression.Expression.(type) {
-			case *pgModel.NodeKindsReference:
-				if err := s.WriteExpression(writer, typedProjectionExpression); err != nil {
-					return err
-				}
+	return false
+}
 
-			case *pgModel.EdgeKindReference:
-				if err := s.WriteExpression(writer, typedProjectionExpression); err != nil {
-					return err
-				}
+// writeTemporalAccessor writes a component of a temporal value such as datetime().epochseconds
+func (s *Emitter) writeTemporalAccessor(writer io.Writer, temporal model.Expression, accessor string) error {
+	if _, err := WriteStrings(writer, "extract(epoch from "); err != nil {
+		return err
+	}
 
-			case *model.FunctionInvocation:
-				if err := s.WriteExpression(writer, typedProjectionExpression); err != nil {
-					return err
-				}
+	if err := s.WriteExpression(writer, temporal); err != nil {
+		return err
+	}
 
-			case *model.PropertyLookup:
-				if err := s.WriteExpression(writer, typedProjectionExpression.Atom); err != nil {
-					return err
-				}
+	switch strings.ToLower(accessor) {
+	case cypherEpochSecondsAccessor:
+		_, err := WriteStrings(writer, ")::", pgModel.Int8.String())
+		return err
 
-				if _, err := WriteStrings(writer, ".", typedProjectionExpression.Symbols[0]); err != nil {
-					return err
-				}
+	case cypherEpochMillisAccessor:
+		_, err := WriteStrings(writer, " * 1000)::", pgModel.Int8.String())
+		return err
 
-			case *pgModel.AnnotatedPropertyLookup:
-				if err := s.WriteExpression(writer, typedProjectionExpression.Atom); err != nil {
-					return err
-				}
+	default:
+		return fmt.Errorf("unsupported temporal accessor %s", accessor)
+	}
+}
 
-				if _, err := WriteStrings(writer, ".", typedProjectionExpression.Symbols[0]); err != nil {
-					return err
-				}
+// collectSymbols records every symbol referenced by the query so that the names of query stages never collide with
+// them
+func (s *Emitter) collectSymbols(regularQuery *model.RegularQuery) error {
+	s.symbols = map[string]struct{}{}
+	s.nextStageID = 0
 
-			case *pgModel.AnnotatedVariable:
-				if err := s.WriteExpression(writer, typedProjectionExpression.Symbol); err != nil {
-					return err
-				}
+	return analyzer.Analyze(regularQuery, func(analyzerInst *analyzer.Analyzer) {
+		analyzer.WithVisitor(analyzerInst, func(_ *model.WalkStack, node *model.Variable) error {
+			s.symbols[node.Symbol] = struct{}{}
+			return nil
+		})
 
-			case *pgModel.Entity:
-				if err := s.WriteExpression(writer, typedProjectionExpression.Binding); err != nil {
-					return err
-				}
+		analyzer.WithVisitor(analyzerInst, func(_ *model.WalkStack, node *pgModel.AnnotatedVariable) error {
+			s.symbols[node.Symbol] = struct{}{}
+			return nil
+		})
+	}, pgModel.CollectPGSQLTypes)
+}
 
-			default:
-				return fmt.Errorf("unexpected projection item for binding formatting: %T", typedExpression.Expression)
-			}
+func (s *Emitter) newStageSymbol() string {
+	for {
+		stageSymbol := "s" + strconv.Itoa(s.nextStageID)
+		s.nextStageID++
 
-			if _, err := WriteStrings(writer, "\""); err != nil {
-				return err
-			}
+		if _, isReferenced := s.symbols[stageSymbol]; !isReferenced {
+			return stageSymbol
 		}
-	default:
-		return fmt.Errorf("unexpected expression type for string formatting: %T", expression)
 	}
-
-	return nil
 }
 
-func (s *Emitter) translateFunctionInvocation(writer io.Writer, functionInvocation *model.FunctionInvocation) error {
-	switch functionInvocation.Name {
-	case cypherIdentityFunction:
-		if err := s.WriteExpression(writer, functionInvocation.Arguments[0]); err != nil {
-			return err
-		}
+// writeCarried writes a reference to the stage column that carries the given symbol, if any. Composite values are
+// wrapped so that their fields may be accessed.
+func (s *Emitter) writeCarried(writer io.Writer, symbol string) (bool, error) {
+	if column, isCarried := s.carried[symbol]; !isCarried {
+		return false, nil
+	} else {
+		switch column.dataType {
+		case pgModel.Node, pgModel.Edge, pgModel.Path:
+			_, err := WriteStrings(writer, "(", column.stage, ".", symbol, ")")
+			return true, err
 
-		if _, err := io.WriteString(writer, ".id"); err != nil {
-			return err
+		default:
+			_, err := WriteStrings(writer, column.stage, ".", symbol)
+			return true, err
 		}
+	}
+}
 
-	case cypherDateFunction:
-		if len(functionInvocation.Arguments) > 0 {
-			if err := s.WriteExpression(writer, functionInvocation.Arguments[0]); err != nil {
-				return err
-			}
+// carryProjection makes the items of the given with clause projection visible to the next stage as columns of the
+// given stage
+func (s *Emitter) carryProjection(stage string, projection *model.Projection) error {
+	carried := make(map[string]stageColumn, len(projection.Items))
 
-			if _, err := io.WriteString(writer, "::date"); err != nil {
-				return err
+	for _, item := range projection.Items {
+		if projectionItem, typeOK := item.(*model.ProjectionItem); !typeOK {
+			return fmt.Errorf("unexpected projection item type: %T", item)
+		} else if binding, typeOK := projectionItem.Binding.(*pgModel.AnnotatedVariable); !typeOK {
+			return fmt.Errorf("unexpected projection item binding type: %T", projectionItem.Binding)
+		} else {
+			carried[binding.Symbol] = stageColumn{
+				stage:    stage,
+				dataType: binding.Type,
 			}
-		} else if _, err := io.WriteString(writer, "current_date"); err != nil {
-			return err
 		}
+	}
 
-	case cypherTimeFunction:
-		if len(functionInvocation.Arguments) > 0 {
-			if err := s.WriteExpression(writer, functionInvocation.Arguments[0]); err != nil {
-				return err
-			}
+	s.carried = carried
+	return nil
+}
 
-			if _, err := io.WriteString(writer, "::time with time zone"); err != nil {
-				return err
-			}
-		} else if _, err := io.WriteString(writer, "current_time"); err != nil {
-			return err
+// writeMultiPartQuery writes each part of a multi-part query as a common table expression that selects from the
+// common table expression of the part that precedes it
+func (s *Emitter) writeMultiPartQuery(writer io.Writer, multiPartQuery *model.MultiPartQuery) error {
+	var (
+		source  string
+		filters []model.Expression
+	)
+
+	// Reset the carried columns once the query is written
+	defer func() {
+		s.carried = map[string]stageColumn{}
+	}()
+
+	if _, err := WriteStrings(writer, "with "); err != nil {
+		return err
+	}
+
+	for idx, part := range multiPartQuery.Parts {
+		if len(part.UpdatingClauses) > 0 {
+			return fmt.Errorf("updating clauses are not supported in multi-part queries")
 		}
 
-	case cypherLocalTimeFunction:
-		if len(functionInvocation.Arguments) > 0 {
-			if err := s.WriteExpression(writer, functionInvocation.Arguments[0]); err != nil {
-				return err
-			}
+		if part.With == nil {
+			return fmt.Errorf("expected a with clause")
+		}
 
-			if _, err := io.WriteString(writer, "::time without time zone"); err != nil {
+		stage := s.newStageSymbol()
+
+		if idx > 0 {
+			if _, err := WriteStrings(writer, ", "); err != nil {
 				return err
 			}
-		} else if _, err := io.WriteString(writer, "localtime"); err != nil {
-			return err
 		}
 
-	case cypherToLowerFunction:
-		if _, err := WriteStrings(writer, pgsqlToLowerFunction, "("); err != nil {
+		if _, err := WriteStrings(writer, stage, " as ("); err != nil {
 			return err
 		}
 
-		if err := s.WriteExpression(writer, functionInvocation.Arguments[0]); err != nil {
+		if err := s.writeStage(writer, source, filters, part.ReadingClauses, part.With.Projection); err != nil {
 			return err
 		}
 
@@ -1240,78 +2862,103 @@ func (s *Emitter) translateFunctionInvocation(writer io.Writer, functionInvocati
 			return err
 		}
 
-	case cypherDateTimeFunction:
-		if len(functionInvocation.Arguments) > 0 {
-			if err := s.WriteExpression(writer, functionInvocation.Arguments[0]); err != nil {
-				return err
-			}
-
-			if _, err := io.WriteString(writer, "::timestamp with time zone"); err != nil {
-				return err
-			}
-		} else if _, err := io.WriteString(writer, "now()"); err != nil {
+		if err := s.carryProjection(stage, part.With.Projection); err != nil {
 			return err
 		}
 
-	case cypherLocalDateTimeFunction:
-		if len(functionInvocation.Arguments) > 0 {
-			if err := s.WriteExpression(writer, functionInvocation.Arguments[0]); err != nil {
-				return err
-			}
+		source = stage
+		filters = nil
 
-			if _, err := io.WriteString(writer, "::timestamp without time zone"); err != nil {
-				return err
-			}
-		} else if _, err := io.WriteString(writer, "localtimestamp"); err != nil {
-			return err
+		// The criteria of a with clause filter the projected rows and therefore apply to the next stage
+		if part.With.Where != nil {
+			filters = part.With.Where.Expressions
 		}
+	}
 
-	case cypherCountFunction:
-		if _, err := WriteStrings(writer, "count("); err != nil {
-			return err
+	if multiPartQuery.SinglePartQuery == nil {
+		return fmt.Errorf("expected a final query part")
+	}
+
+	if len(multiPartQuery.SinglePartQuery.UpdatingClauses) > 0 {
+		return fmt.Errorf("updating clauses are not supported in multi-part queries")
+	}
+
+	if _, err := WriteStrings(writer, " "); err != nil {
+		return err
+	}
+
+	return s.writeSelect(writer, source, filters, multiPartQuery.SinglePartQuery)
+}
+
+func (s *Emitter) writeSingleQuery(writer io.Writer, singleQuery *model.SingleQuery) error {
+	if singleQuery.MultiPartQuery != nil {
+		return s.writeMultiPartQuery(writer, singleQuery.MultiPartQuery)
+	}
+
+	if singleQuery.SinglePartQuery != nil {
+		return s.writeSinglePartQuery(writer, singleQuery.SinglePartQuery)
+	}
+
+	return nil
+}
+
+// writeUnion wraps each single query of a union in parenthesis so that the ordering, skip and limit of each single
+// query applies only to its own results
+func (s *Emitter) writeUnion(writer io.Writer, regularQuery *model.RegularQuery) error {
+	singleQueries := []*model.SingleQuery{regularQuery.SingleQuery}
+
+	for _, union := range regularQuery.Unions {
+		singleQueries = append(singleQueries, union.SingleQuery)
+	}
+
+	for idx, singleQuery := range singleQueries {
+		if singleQuery == nil {
+			return fmt.Errorf("expected a query for each side of the union")
 		}
 
-		for _, argument := range functionInvocation.Arguments {
-			if err := s.WriteExpression(writer, argument); err != nil {
+		if singleQuery.SinglePartQuery != nil && len(singleQuery.SinglePartQuery.UpdatingClauses) > 0 {
+			return fmt.Errorf("updating clauses are not supported in union queries")
+		}
+
+		if idx > 0 {
+			if _, err := WriteStrings(writer, " union "); err != nil {
 				return err
 			}
-		}
 
-		if _, err := WriteStrings(writer, ")"); err != nil {
-			return err
+			if regularQuery.Unions[idx-1].All {
+				if _, err := WriteStrings(writer, "all "); err != nil {
+					return err
+				}
+			}
 		}
 
-	case pgsqlAnyFunction, pgsqlToJSONBFunction:
-		if _, err := WriteStrings(writer, functionInvocation.Name, "("); err != nil {
+		if _, err := WriteStrings(writer, "("); err != nil {
 			return err
 		}
 
-		if err := s.WriteExpression(writer, functionInvocation.Arguments[0]); err != nil {
+		if err := s.writeSingleQuery(writer, singleQuery); err != nil {
 			return err
 		}
 
-		if _, err := io.WriteString(writer, ")"); err != nil {
+		if _, err := WriteStrings(writer, ")"); err != nil {
 			return err
 		}
-
-	default:
-		return fmt.Errorf("unsupported function invocation %s", functionInvocation.Name)
 	}
 
 	return nil
 }
 
 func (s *Emitter) Write(regularQuery *model.RegularQuery, writer io.Writer) error {
-	if regularQuery.SingleQuery != nil {
-		if regularQuery.SingleQuery.MultiPartQuery != nil {
-			return fmt.Errorf("not supported yet")
-		}
+	if err := s.collectSymbols(regularQuery); err != nil {
+		return err
+	}
 
-		if regularQuery.SingleQuery.SinglePartQuery != nil {
-			if err := s.writeSinglePartQuery(writer, regularQuery.SingleQuery.SinglePartQuery); err != nil {
-				return err
-			}
-		}
+	if len(regularQuery.Unions) > 0 {
+		return s.writeUnion(writer, regularQuery)
+	}
+
+	if regularQuery.SingleQuery != nil {
+		return s.writeSingleQuery(writer, regularQuery.SingleQuery)
 	}
 
 	return nil
@@ -23,6 +23,7 @@ import (
 	"github.com/specterops/bloodhound/cypher/backend/pgsql"
 	"github.com/specterops/bloodhound/cypher/frontend"
 	"github.com/specterops/bloodhound/cypher/model"
+	pgDriverModel "github.com/specterops/bloodhound/dawgs/drivers/pg/model"
 	"github.com/specterops/bloodhound/dawgs/graph"
 	"github.com/specterops/bloodhound/dawgs/query"
 	"github.com/specterops/bloodhound/graphschema/ad"
@@ -67,6 +68,7 @@ type TestCase struct {
 	ID                 int
 	Source             string
 	Query              *query.Builder
+	Parameters         map[string]any
 	Expected           string
 	ExpectedParameters map[string]any
 	Exclusive          bool
@@ -164,7 +166,7 @@ func Suite() []TestCase {
 		{
 			ID:       18,
 			Source:   "match (s) where s.created_at = datetime() return s",
-			Expected: "select (s.id, s.kind_ids, s.properties)::nodeComposite as s from node as s where (s.properties->>'created_at')::timestamp with time zone = now()",
+			Expected: "select (s.id, s.kind_ids, s.properties)::nodeComposite as s from node as s where case jsonb_typeof(s.properties->'created_at') when 'number' then to_timestamp((s.properties->'created_at')::numeric) else (s.properties->>'created_at')::timestamp with time zone end = now()",
 		},
 		{
 			ID:       19,
@@ -174,7 +176,7 @@ func Suite() []TestCase {
 		{
 			ID:       20,
 			Source:   "match (s) where s.created_at = datetime('2019-06-01T18:40:32.142+0100') return s",
-			Expected: "select (s.id, s.kind_ids, s.properties)::nodeComposite as s from node as s where (s.properties->>'created_at')::timestamp with time zone = '2019-06-01T18:40:32.142+0100'::timestamp with time zone",
+			Expected: "select (s.id, s.kind_ids, s.properties)::nodeComposite as s from node as s where case jsonb_typeof(s.properties->'created_at') when 'number' then to_timestamp((s.properties->'created_at')::numeric) else (s.properties->>'created_at')::timestamp with time zone end = '2019-06-01T18:40:32.142+0100'::timestamp with time zone",
 		},
 		{
 			ID:       21,
@@ -184,12 +186,12 @@ func Suite() []TestCase {
 		{
 			ID:       22,
 			Source:   "match (s) where s.created_at = localdatetime() return s",
-			Expected: "select (s.id, s.kind_ids, s.properties)::nodeComposite as s from node as s where (s.properties->>'created_at')::timestamp without time zone = localtimestamp",
+			Expected: "select (s.id, s.kind_ids, s.properties)::nodeComposite as s from node as s where case jsonb_typeof(s.properties->'created_at') when 'number' then to_timestamp((s.properties->'created_at')::numeric)::timestamp without time zone else (s.properties->>'created_at')::timestamp without time zone end = localtimestamp",
 		},
 		{
 			ID:       23,
 			Source:   "match (s) where s.created_at = localdatetime('2019-06-01T18:40:32.142') return s",
-			Expected: "select (s.id, s.kind_ids, s.properties)::nodeComposite as s from node as s where (s.properties->>'created_at')::timestamp without time zone = '2019-06-01T18:40:32.142'::timestamp without time zone",
+			Expected: "select (s.id, s.kind_ids, s.properties)::nodeComposite as s from node as s where case jsonb_typeof(s.properties->'created_at') when 'number' then to_timestamp((s.properties->'created_at')::numeric)::timestamp without time zone else (s.properties->>'created_at')::timestamp without time zone end = '2019-06-01T18:40:32.142'::timestamp without time zone",
 		},
 		{
 			ID:       24,
@@ -239,18 +241,17 @@ func Suite() []TestCase {
 		{
 			ID:       33,
 			Source:   "match (s) where s.name = '1234' return labels(s)",
-			Expected: "select s.kind_ids as \"s.kind_ids\" from node as s where (s.properties->>'name')::text = '1234'",
+			Expected: "select array(select kind.name from unnest(s.kind_ids) with ordinality as kind_ref(id, idx) join kind on kind.id = kind_ref.id order by kind_ref.idx) as \"labels(s)\" from node as s where (s.properties->>'name')::text = '1234'",
 		},
 		{
 			ID:       34,
 			Source:   "match ()-[r]->() where r.name = '1234' return type(r)",
-			Expected: "select r.kind_id as \"r.kind_id\" from node as n0 join edge r on r.start_id = n0.id join node n1 on n1.id = r.end_id where (r.properties->>'name')::text = '1234'",
+			Expected: "select (select kind.name from unnest(array[r.kind_id]) as kind_ref(id) join kind on kind.id = kind_ref.id) as \"type(r)\" from node as n0 join edge r on r.start_id = n0.id join node n1 on n1.id = r.end_id where (r.properties->>'name')::text = '1234'",
 		},
 		{
 			ID:       35,
-			Ignored:  true,
 			Source:   "match p = (s:NodeKindA)-[:EdgeKindA*..]->(:NodeKindB) where id(s) = 5 return p",
-			Expected: "select edges_to_path() as p from node as s join edge e0 on e0.start_id = s.id join node n1 on n1.id = e0.end_id where s.kind_ids operator(pg_catalog.&&) array[1]::int2[] and e0.kind_id = any(array[100]::int2[]) and n1.kind_ids operator(pg_catalog.&&) array[2]::int2[] and s.id = 5",
+			Expected: "select edges_to_path(variadic ep2.path) as p from node as s join lateral (with recursive ep2(next_id, depth, path) as (select s.id, 0, array[]::int4[] union all select e0.end_id, ep2.depth + 1, ep2.path || e0.id from ep2 join edge e0 on e0.start_id = ep2.next_id where ep2.depth < 15 and not e0.id = any(ep2.path) and e0.kind_id = any(array[100]::int2[])) select ep2.next_id, ep2.path from ep2 where ep2.depth >= 1) ep2 on true join node n1 on n1.id = ep2.next_id where s.kind_ids operator(pg_catalog.&&) array[1]::int2[] and n1.kind_ids operator(pg_catalog.&&) array[2]::int2[] and s.id = 5",
 		},
 		{
 			ID:       36,
@@ -434,7 +435,7 @@ func Suite() []TestCase {
 				),
 				query.Returning(query.Relationship()),
 			),
-			Expected: "select (r.id, r.start_id, r.end_id, r.kind_id, r.properties)::edgeComposite as r from node as n0 join edge r on r.start_id = n0.id join node n1 on n1.id = r.end_id where (r.kind_id = any(array[100, 101]::int2[])) and (not r.properties ? 'lastseen' or (r.properties->>'lastseen')::timestamp with time zone < @p0)",
+			Expected: "select (r.id, r.start_id, r.end_id, r.kind_id, r.properties)::edgeComposite as r from node as n0 join edge r on r.start_id = n0.id join node n1 on n1.id = r.end_id where (r.kind_id = any(array[100, 101]::int2[])) and (not r.properties ? 'lastseen' or case jsonb_typeof(r.properties->'lastseen') when 'number' then to_timestamp((r.properties->'lastseen')::numeric) else (r.properties->>'lastseen')::timestamp with time zone end < @p0)",
 		},
 		{
 			ID: 56,
@@ -558,6 +559,12 @@ func Suite() []TestCase {
 			ID:       160,
 			Source:   "match (s) set s.name = 'new name', s:NodeKindA return s",
 			Expected: "update node as s set properties = properties || @p0, kind_ids = kind_ids || @p1 returning (s.id, s.kind_ids, s.properties)::nodeComposite as s",
+			ExpectedParameters: map[string]any{
+				"p0": *MustMarshalToJSONB(map[string]any{
+					"name": "new name",
+				}),
+				"p1": []int16{1},
+			},
 		},
 		{
 			ID:       161,
@@ -570,6 +577,367 @@ func Suite() []TestCase {
 			Expected: "update node as s set properties = properties - @p1::text[] || @p0 where s.kind_ids operator(pg_catalog.&&) array[1]::int2[] returning (s.id, s.kind_ids, s.properties)::nodeComposite as s",
 		},
 
+		// Variable-length relationship patterns are expanded by recursive CTEs
+		{
+			ID:       64,
+			Source:   "match (s)-[r:EdgeKindA|EdgeKindB*1..3]->(e) where s.name = 'a' return e",
+			Expected: "select (e.id, e.kind_ids, e.properties)::nodeComposite as e from node as s join lateral (with recursive ep0(next_id, depth, path) as (select s.id, 0, array[]::int4[] union all select r.end_id, ep0.depth + 1, ep0.path || r.id from ep0 join edge r on r.start_id = ep0.next_id where ep0.depth < 3 and not r.id = any(ep0.path) and r.kind_id = any(array[100, 101]::int2[])) select ep0.next_id, ep0.path from ep0 where ep0.depth >= 1) ep0 on true join node e on e.id = ep0.next_id where (s.properties->>'name')::text = 'a'",
+		},
+		{
+			ID:       65,
+			Source:   "match (s)<-[r:EdgeKindA*2..]-(e) return s, r, e",
+			Expected: "select (s.id, s.kind_ids, s.properties)::nodeComposite as s, (select array_agg((r.id, r.start_id, r.end_id, r.kind_id, r.properties)::edgeComposite order by hop.ordinality) from unnest(ep0.path) with ordinality as hop(id, ordinality) join edge r on r.id = hop.id) as r, (e.id, e.kind_ids, e.properties)::nodeComposite as e from node as s join lateral (with recursive ep0(next_id, depth, path) as (select s.id, 0, array[]::int4[] union all select r.start_id, ep0.depth + 1, ep0.path || r.id from ep0 join edge r on r.end_id = ep0.next_id where ep0.depth < 15 and not r.id = any(ep0.path) and r.kind_id = any(array[100]::int2[])) select ep0.next_id, ep0.path from ep0 where ep0.depth >= 2) ep0 on true join node e on e.id = ep0.next_id",
+		},
+		{
+			ID:       66,
+			Source:   "match p = (s)-[:EdgeKindA*0..2]-(e)-[r:EdgeKindB]->(f) return p",
+			Expected: "select edges_to_path(variadic ep1.path || array[r.id]) as p from node as s join lateral (with recursive ep1(next_id, depth, path) as (select s.id, 0, array[]::int4[] union all select case when e0.start_id = ep1.next_id then e0.end_id else e0.start_id end, ep1.depth + 1, ep1.path || e0.id from ep1 join edge e0 on (e0.start_id = ep1.next_id or e0.end_id = ep1.next_id) where ep1.depth < 2 and not e0.id = any(ep1.path) and e0.kind_id = any(array[100]::int2[])) select ep1.next_id, ep1.path from ep1) ep1 on true join node e on e.id = ep1.next_id join edge r on r.start_id = e.id join node f on f.id = r.end_id where r.kind_id = any(array[101]::int2[])",
+		},
+		{
+			ID:       67,
+			Source:   "match (s)-[r*..2 {enabled: true}]->(e) return e.name",
+			Expected: "select e.properties->'name' as \"e.name\" from node as s join lateral (with recursive ep0(next_id, depth, path) as (select s.id, 0, array[]::int4[] union all select r.end_id, ep0.depth + 1, ep0.path || r.id from ep0 join edge r on r.start_id = ep0.next_id where ep0.depth < 2 and not r.id = any(ep0.path) and (r.properties->'enabled')::bool = true) select ep0.next_id, ep0.path from ep0 where ep0.depth >= 1) ep0 on true join node e on e.id = ep0.next_id",
+		},
+		{
+			ID:       68,
+			Source:   "match (s) where (s)-[:EdgeKindA*1..]->(:NodeKindB) return s",
+			Expected: "select (s.id, s.kind_ids, s.properties)::nodeComposite as s from node as s where exists(select * from node as n2 join lateral (with recursive ep3(next_id, depth, path) as (select n2.id, 0, array[]::int4[] union all select e0.end_id, ep3.depth + 1, ep3.path || e0.id from ep3 join edge e0 on e0.start_id = ep3.next_id where ep3.depth < 15 and not e0.id = any(ep3.path) and e0.kind_id = any(array[100]::int2[])) select ep3.next_id, ep3.path from ep3 where ep3.depth >= 1) ep3 on true join node n1 on n1.id = ep3.next_id where s.id = n2.id and n1.kind_ids operator(pg_catalog.&&) array[2]::int2[] limit 1)",
+		},
+		{
+			ID:       69,
+			Source:   "match p = (s)-[r]->(e) return p",
+			Expected: "select edges_to_path(variadic array[r.id]) as p from node as s join edge r on r.start_id = s.id join node e on e.id = r.end_id",
+		},
+
+		// Multi-part queries are translated into chained common table expressions. Symbols projected by a with clause
+		// are carried into the next stage as columns.
+		{
+			ID:       70,
+			Source:   "match (s:NodeKindA)-[:EdgeKindA]->(g:NodeKindB) with g, count(s) as members where members > 100 return g, members",
+			Expected: "with s0 as (select (g.id, g.kind_ids, g.properties)::nodeComposite as g, count(s) as members from node as s join edge e0 on e0.start_id = s.id join node g on g.id = e0.end_id where s.kind_ids operator(pg_catalog.&&) array[1]::int2[] and e0.kind_id = any(array[100]::int2[]) and g.kind_ids operator(pg_catalog.&&) array[2]::int2[] group by 1) select (s0.g) as g, s0.members as members from s0 where s0.members > 100",
+		},
+		{
+			ID:       71,
+			Source:   "match (s:NodeKindA)-[:EdgeKindA]->(g:NodeKindB) with g, count(s) as members match (g)-[:EdgeKindB]->(e) return g.name, members, e",
+			Expected: "with s0 as (select (g.id, g.kind_ids, g.properties)::nodeComposite as g, count(s) as members from node as s join edge e0 on e0.start_id = s.id join node g on g.id = e0.end_id where s.kind_ids operator(pg_catalog.&&) array[1]::int2[] and e0.kind_id = any(array[100]::int2[]) and g.kind_ids operator(pg_catalog.&&) array[2]::int2[] group by 1) select (s0.g).properties->'name' as \"g.name\", s0.members as members, (e.id, e.kind_ids, e.properties)::nodeComposite as e from s0, node as n2 join edge e1 on e1.start_id = n2.id join node e on e.id = e1.end_id where (s0.g).id = n2.id and e1.kind_id = any(array[101]::int2[])",
+		},
+		{
+			ID:       72,
+			Source:   "match (s:NodeKindA)-[:EdgeKindA]->(g:NodeKindB) with s, collect(g) as groups unwind groups as grp return s, grp.name",
+			Expected: "with s0 as (select (s.id, s.kind_ids, s.properties)::nodeComposite as s, array_agg((g.id, g.kind_ids, g.properties)::nodeComposite) as groups from node as s join edge e0 on e0.start_id = s.id join node g on g.id = e0.end_id where s.kind_ids operator(pg_catalog.&&) array[1]::int2[] and e0.kind_id = any(array[100]::int2[]) and g.kind_ids operator(pg_catalog.&&) array[2]::int2[] group by 1) select (s0.s) as s, grp.properties->'name' as \"grp.name\" from s0, unnest(s0.groups) as grp",
+		},
+
+		// Unwind clauses expand a list into rows
+		{
+			ID:       73,
+			Source:   "unwind [1, 2, 3] as x return x",
+			Expected: "select x as x from unnest(array[1, 2, 3]) as x",
+		},
+
+		// Each single query of a union is written as its own parenthesized select statement
+		{
+			ID:       74,
+			Source:   "match (s:NodeKindA) return s.name as name union match (s:NodeKindB) return s.name as name",
+			Expected: "(select s.properties->'name' as name from node as s where s.kind_ids operator(pg_catalog.&&) array[1]::int2[]) union (select s.properties->'name' as name from node as s where s.kind_ids operator(pg_catalog.&&) array[2]::int2[])",
+		},
+		{
+			ID:       75,
+			Source:   "match (s:NodeKindA) return s union all match (s:NodeKindB) with s limit 10 return s",
+			Expected: "(select (s.id, s.kind_ids, s.properties)::nodeComposite as s from node as s where s.kind_ids operator(pg_catalog.&&) array[1]::int2[]) union all (with s0 as (select (s.id, s.kind_ids, s.properties)::nodeComposite as s from node as s where s.kind_ids operator(pg_catalog.&&) array[2]::int2[] limit 10) select (s0.s) as s from s0)",
+		},
+		{
+			ID:       76,
+			Source:   "match (s) with s order by s.name limit 10 where s.enabled = true return s",
+			Expected: "with s0 as (select (s.id, s.kind_ids, s.properties)::nodeComposite as s from node as s order by s.properties->'name' asc limit 10) select (s0.s) as s from s0 where ((s0.s).properties->'enabled')::bool = true",
+		},
+		{
+			ID:       77,
+			Source:   "match (s0) with s0 return s0",
+			Expected: "with s1 as (select (s0.id, s0.kind_ids, s0.properties)::nodeComposite as s0 from node as s0) select (s1.s0) as s0 from s1",
+		},
+
+		// Projections that aggregate are grouped by their non-aggregate items
+		{
+			ID:       78,
+			Source:   "match (s) return s, count(s)",
+			Expected: "select (s.id, s.kind_ids, s.properties)::nodeComposite as s, count(s) as \"count(s)\" from node as s group by 1",
+		},
+		{
+			ID:       79,
+			Source:   "match (s) with s where (s)-[:EdgeKindA]->() return s",
+			Expected: "with s0 as (select (s.id, s.kind_ids, s.properties)::nodeComposite as s from node as s) select (s0.s) as s from s0 where exists(select * from node as n2 join edge e0 on e0.start_id = n2.id join node n1 on n1.id = e0.end_id where (s0.s).id = n2.id and e0.kind_id = any(array[100]::int2[]) limit 1)",
+		},
+
+		// Optional matches are left joined with their criteria as the join condition
+		{
+			ID:       80,
+			Source:   "match (n:NodeKindA) optional match (n)-[r:EdgeKindA]->(m:NodeKindB) return n, m",
+			Expected: "select (n.id, n.kind_ids, n.properties)::nodeComposite as n, case when m.id is null then null else (m.id, m.kind_ids, m.properties)::nodeComposite end as m from node as n left join (node as n0 join edge r on r.start_id = n0.id join node m on m.id = r.end_id) on n.id = n0.id and r.kind_id = any(array[100]::int2[]) and m.kind_ids operator(pg_catalog.&&) array[2]::int2[] where n.kind_ids operator(pg_catalog.&&) array[1]::int2[]",
+		},
+
+		{
+			ID:       81,
+			Source:   "match (n) optional match (n)-[r]->(m) where m.name = 'x' return n.name, count(m)",
+			Expected: "select n.properties->'name' as \"n.name\", count(m) as \"count(m)\" from node as n left join (node as n0 join edge r on r.start_id = n0.id join node m on m.id = r.end_id) on n.id = n0.id and (m.properties->>'name')::text = 'x' group by 1",
+		},
+
+		// Optional matches that lead a query still produce a row when they find nothing
+		{
+			ID:       82,
+			Source:   "optional match (n:NodeKindA) return n",
+			Expected: "select case when n.id is null then null else (n.id, n.kind_ids, n.properties)::nodeComposite end as n from (select 1) as s0 left join node as n on n.kind_ids operator(pg_catalog.&&) array[1]::int2[]",
+		},
+
+		// Collect ignores the null bindings of an optional match
+		{
+			ID:       83,
+			Source:   "match (n) optional match (n)-[r]->(m) with n, collect(m) as ms return n, ms",
+			Expected: "with s0 as (select (n.id, n.kind_ids, n.properties)::nodeComposite as n, array_agg(case when m.id is null then null else (m.id, m.kind_ids, m.properties)::nodeComposite end) filter (where m.id is not null) as ms from node as n left join (node as n0 join edge r on r.start_id = n0.id join node m on m.id = r.end_id) on n.id = n0.id group by 1) select (s0.n) as n, s0.ms as ms from s0",
+		},
+
+		// Null bindings of an optional match propagate through with clauses
+		{
+			ID:       84,
+			Source:   "match (n) optional match (n)-[r]->(m) with n, m where m is null return n",
+			Expected: "with s0 as (select (n.id, n.kind_ids, n.properties)::nodeComposite as n, case when m.id is null then null else (m.id, m.kind_ids, m.properties)::nodeComposite end as m from node as n left join (node as n0 join edge r on r.start_id = n0.id join node m on m.id = r.end_id) on n.id = n0.id) select (s0.n) as n from s0 where (s0.m) is null",
+		},
+
+		{
+			ID:       85,
+			Source:   "match (n) optional match p = (n)-[:EdgeKindA*1..]->(m) return p",
+			Expected: "select case when n1.id is null then null else edges_to_path(variadic ep2.path) end as p from node as n left join (node as n1 join lateral (with recursive ep2(next_id, depth, path) as (select n1.id, 0, array[]::int4[] union all select e0.end_id, ep2.depth + 1, ep2.path || e0.id from ep2 join edge e0 on e0.start_id = ep2.next_id where ep2.depth < 15 and not e0.id = any(ep2.path) and e0.kind_id = any(array[100]::int2[])) select ep2.next_id, ep2.path from ep2 where ep2.depth >= 1) ep2 on true join node m on m.id = ep2.next_id) on n.id = n1.id",
+		},
+
+		// Optional matches are joined to the from items that precede them
+		{
+			ID:       86,
+			Source:   "match (a), (b) optional match (a)-[r]->(b) return a, b, r",
+			Expected: "select (a.id, a.kind_ids, a.properties)::nodeComposite as a, (b.id, b.kind_ids, b.properties)::nodeComposite as b, case when r.id is null then null else (r.id, r.start_id, r.end_id, r.kind_id, r.properties)::edgeComposite end as r from node as a cross join node as b left join (node as n0 join edge r on r.start_id = n0.id join node n1 on n1.id = r.end_id) on a.id = n0.id and b.id = n1.id",
+		},
+
+		{
+			ID:       87,
+			Source:   "match (n) optional match (m:NodeKindA) return n, m",
+			Expected: "select (n.id, n.kind_ids, n.properties)::nodeComposite as n, case when m.id is null then null else (m.id, m.kind_ids, m.properties)::nodeComposite end as m from node as n left join node as m on m.kind_ids operator(pg_catalog.&&) array[1]::int2[]",
+		},
+
+		{
+			ID:       88,
+			Source:   "match (n) where toUpper(n.name) = 'ADMIN' return n",
+			Expected: "select (n.id, n.kind_ids, n.properties)::nodeComposite as n from node as n where upper((n.properties->>'name')::text) = 'ADMIN'",
+		},
+
+		{
+			ID:       89,
+			Source:   "match (n) return split(n.name, '@')",
+			Expected: "select string_to_array((n.properties->>'name')::text, '@') as \"string_to_array((n.properties->>'name')::text, '@')\" from node as n",
+		},
+
+		{
+			ID:       90,
+			Source:   "match (n) where substring(n.name, 0, 5) = 'ADMIN' return n",
+			Expected: "select (n.id, n.kind_ids, n.properties)::nodeComposite as n from node as n where substr((n.properties->>'name')::text, 0 + 1, 5) = 'ADMIN'",
+		},
+
+		{
+			ID:       91,
+			Source:   "match (n) return replace(n.name, '@', '.')",
+			Expected: "select replace((n.properties->>'name')::text, '@', '.') as \"replace((n.properties->>'name')::text, '@', '.')\" from node as n",
+		},
+
+		{
+			ID:       92,
+			Source:   "match (n) where trim(n.name) = 'a' return n",
+			Expected: "select (n.id, n.kind_ids, n.properties)::nodeComposite as n from node as n where trim((n.properties->>'name')::text) = 'a'",
+		},
+
+		{
+			ID:       93,
+			Source:   "match (n) return coalesce(n.name, 'unknown')",
+			Expected: "select coalesce((n.properties->>'name')::text, 'unknown') as \"coalesce((n.properties->>'name')::text, 'unknown')\" from node as n",
+		},
+
+		{
+			ID:       94,
+			Source:   "match (n) where size(n.spns) > 0 return n",
+			Expected: "select (n.id, n.kind_ids, n.properties)::nodeComposite as n from node as n where case jsonb_typeof(n.properties->'spns') when 'array' then jsonb_array_length(n.properties->'spns') when 'string' then char_length(n.properties->'spns' #>> '{}') end > 0",
+		},
+
+		{
+			ID:       95,
+			Source:   "match (n) where size(toLower(n.name)) > 3 return n",
+			Expected: "select (n.id, n.kind_ids, n.properties)::nodeComposite as n from node as n where char_length(lower((n.properties->>'name')::text)) > 3",
+		},
+
+		{
+			ID:       96,
+			Source:   "match (n) return keys(n)",
+			Expected: "select array(select jsonb_object_keys(n.properties)) as \"array(select jsonb_object_keys(n.properties))\" from node as n",
+		},
+
+		{
+			ID:       97,
+			Source:   "match p = (n)-[r]->(m) return nodes(p), relationships(p), length(p)",
+			Expected: "select (edges_to_path(variadic array[r.id])).nodes as \"(edges_to_path(variadic array[r.id])).nodes\", (edges_to_path(variadic array[r.id])).edges as \"(edges_to_path(variadic array[r.id])).edges\", cardinality(array[r.id]) as \"cardinality(array[r.id])\" from node as n join edge r on r.start_id = n.id join node m on m.id = r.end_id",
+		},
+
+		{
+			ID:       98,
+			Source:   "match (n) return head(n.spns), last(n.spns)",
+			Expected: "select n.properties->'spns'->0 as \"n.properties->'spns'->0\", n.properties->'spns'->-1 as \"n.properties->'spns'->-1\" from node as n",
+		},
+
+		{
+			ID:       99,
+			Source:   "match (n) return toString(n.objectid), toInteger(n.count), toFloat(n.score)",
+			Expected: "select (n.properties->>'objectid')::text as \"n.objectid\", trunc(case when (n.properties->>'count')::text ~ '^\\s*[-+]?[0-9]*\\.?[0-9]+([eE][-+]?[0-9]+)?\\s*$' then ((n.properties->>'count')::text)::numeric end)::int8 as \"trunc(case when (n.properties->>'count')::text ~ '^\\s*[-+]?[0-9]*\\.?[0-9]+([eE][-+]?[0-9]+)?\\s*$' then ((n.properties->>'count')::text)::numeric end)::int8\", case when (n.properties->>'score')::text ~ '^\\s*[-+]?[0-9]*\\.?[0-9]+([eE][-+]?[0-9]+)?\\s*$' then ((n.properties->>'score')::text)::float8 end as \"case when (n.properties->>'score')::text ~ '^\\s*[-+]?[0-9]*\\.?[0-9]+([eE][-+]?[0-9]+)?\\s*$' then ((n.properties->>'score')::text)::float8 end\" from node as n",
+		},
+
+		{
+			ID:       100,
+			Source:   "match (n) where abs(n.value) > 5 return n",
+			Expected: "select (n.id, n.kind_ids, n.properties)::nodeComposite as n from node as n where abs((n.properties->'value')::float8) > 5",
+		},
+
+		{
+			ID:       101,
+			Source:   "match (n) return n.domain, min(n.value), max(n.value), sum(n.value), avg(n.value)",
+			Expected: "select n.properties->'domain' as \"n.domain\", min((n.properties->'value')::float8) as \"min((n.properties->'value')::float8)\", max((n.properties->'value')::float8) as \"max((n.properties->'value')::float8)\", sum((n.properties->'value')::float8) as \"sum((n.properties->'value')::float8)\", avg((n.properties->'value')::float8) as \"avg((n.properties->'value')::float8)\" from node as n group by 1",
+		},
+
+		{
+			ID:       102,
+			Source:   "match (n) return count(distinct n.domain)",
+			Expected: "select count(distinct n.properties->'domain') as \"count(distinct n.properties->'domain')\" from node as n",
+		},
+
+		{
+			ID:       103,
+			Source:   "match (n) return count(*)",
+			Expected: "select count(*) as \"count(*)\" from node as n",
+		},
+
+		{
+			ID:       104,
+			Source:   "match (n)-[r]->(m) where type(r) = 'EdgeKindA' return r",
+			Expected: "select (r.id, r.start_id, r.end_id, r.kind_id, r.properties)::edgeComposite as r from node as n join edge r on r.start_id = n.id join node m on m.id = r.end_id where r.kind_id = any(array[100]::int2[])",
+		},
+
+		{
+			ID:       105,
+			Source:   "match (n)-[r]->(m) where type(r) in ['EdgeKindA', 'EdgeKindB'] return r",
+			Expected: "select (r.id, r.start_id, r.end_id, r.kind_id, r.properties)::edgeComposite as r from node as n join edge r on r.start_id = n.id join node m on m.id = r.end_id where r.kind_id = any(array[100, 101]::int2[])",
+		},
+
+		{
+			ID:       106,
+			Source:   "match (n) where 'NodeKindA' in labels(n) return n",
+			Expected: "select (n.id, n.kind_ids, n.properties)::nodeComposite as n from node as n where n.kind_ids operator(pg_catalog.&&) array[1]::int2[]",
+		},
+
+		{
+			ID:       107,
+			Source:   "match (u) where u.lastlogontimestamp < datetime().epochseconds - (90 * 86400) return u",
+			Expected: "select (u.id, u.kind_ids, u.properties)::nodeComposite as u from node as u where (u.properties->'lastlogontimestamp')::int8 < extract(epoch from now())::int8 - (90 * 86400)",
+		},
+
+		{
+			ID:       108,
+			Source:   "match (u) where datetime({epochSeconds: toInteger(u.lastlogontimestamp)}) < datetime() - duration('P90D') return u",
+			Expected: "select (u.id, u.kind_ids, u.properties)::nodeComposite as u from node as u where to_timestamp(trunc(case when (u.properties->>'lastlogontimestamp')::text ~ '^\\s*[-+]?[0-9]*\\.?[0-9]+([eE][-+]?[0-9]+)?\\s*$' then ((u.properties->>'lastlogontimestamp')::text)::numeric end)::int8) < now() - ('P90D')::interval",
+		},
+
+		{
+			ID:       109,
+			Source:   "match (u) where u.pwdlastset < datetime() - duration({days: 90, hours: 12}) return u",
+			Expected: "select (u.id, u.kind_ids, u.properties)::nodeComposite as u from node as u where case jsonb_typeof(u.properties->'pwdlastset') when 'number' then to_timestamp((u.properties->'pwdlastset')::numeric) else (u.properties->>'pwdlastset')::timestamp with time zone end < now() - make_interval(days => 90, hours => 12)",
+		},
+
+		{
+			ID:       113,
+			Source:   "match (u) where u.lastlogontimestamp < datetime() - duration('P90D') return u",
+			Expected: "select (u.id, u.kind_ids, u.properties)::nodeComposite as u from node as u where case jsonb_typeof(u.properties->'lastlogontimestamp') when 'number' then to_timestamp((u.properties->'lastlogontimestamp')::numeric) else (u.properties->>'lastlogontimestamp')::timestamp with time zone end < now() - ('P90D')::interval",
+		},
+		{
+			ID:       114,
+			Source:   "match (u) where datetime({epochSeconds: u.lastlogontimestamp}) < datetime() - duration('P90D') return u",
+			Expected: "select (u.id, u.kind_ids, u.properties)::nodeComposite as u from node as u where to_timestamp((u.properties->'lastlogontimestamp')::numeric) < now() - ('P90D')::interval",
+		},
+		{
+			ID:       115,
+			Source:   "match (u) where datetime({epochMillis: u.lastlogon}) < datetime() return u",
+			Expected: "select (u.id, u.kind_ids, u.properties)::nodeComposite as u from node as u where to_timestamp((u.properties->'lastlogon')::numeric / 1000.0) < now()",
+		},
+		{
+			ID:       116,
+			Source:   "match (u) where u.lastlogontimestamp < (datetime() - duration('P90D')).epochseconds return u",
+			Expected: "select (u.id, u.kind_ids, u.properties)::nodeComposite as u from node as u where (u.properties->'lastlogontimestamp')::int8 < extract(epoch from (now() - ('P90D')::interval))::int8",
+		},
+
+		{
+			ID:       117,
+			Source:   "unwind ['a', 'b'] as x match (n {name: x}) return n",
+			Expected: "select (n.id, n.kind_ids, n.properties)::nodeComposite as n from unnest(array['a', 'b']) as x, node as n where (n.properties->>'name')::text = x",
+		},
+		{
+			ID:       118,
+			Source:   "match (m) with collect(m.name) as names unwind names as x match (n {name: x}) return n",
+			Expected: "with s0 as (select array_agg(m.properties->'name') as names from node as m) select (n.id, n.kind_ids, n.properties)::nodeComposite as n from s0, unnest(s0.names) as x, node as n where n.properties->'name' = x",
+		},
+		{
+			ID:       119,
+			Source:   "match (n) where toInteger(n.count) > 1 return n",
+			Expected: "select (n.id, n.kind_ids, n.properties)::nodeComposite as n from node as n where trunc(case when (n.properties->>'count')::text ~ '^\\s*[-+]?[0-9]*\\.?[0-9]+([eE][-+]?[0-9]+)?\\s*$' then ((n.properties->>'count')::text)::numeric end)::int8 > 1",
+		},
+		{
+			ID:       120,
+			Source:   "match (n) with n, n.score as score return toFloat(score)",
+			Expected: "with s0 as (select (n.id, n.kind_ids, n.properties)::nodeComposite as n, n.properties->'score' as score from node as n) select case when (s0.score)::text ~ '^\\s*[-+]?[0-9]*\\.?[0-9]+([eE][-+]?[0-9]+)?\\s*$' then (s0.score)::float8 end as \"case when (score)::text ~ '^\\s*[-+]?[0-9]*\\.?[0-9]+([eE][-+]?[0-9]+)?\\s*$' then (score)::float8 end\" from s0",
+		},
+
+		{
+			ID:       110,
+			Source:   "match (n) return collect(distinct n.name)",
+			Expected: "select array_agg(distinct n.properties->'name') as \"array_agg(distinct n.properties->'name')\" from node as n",
+		},
+
+		{
+			ID: 111,
+			Query: query.NewBuilderWithCriteria(
+				query.Where(query.And(
+					query.Kind(query.Node(), graph.StringKind("NodeKindA")),
+					model.NewSimpleFunctionInvocation("exists", query.NodeProperty(common.Name.String())),
+				)),
+				query.Returning(query.NodeID()),
+			),
+			Expected: "select n.id as \"n.id\" from node as n where n.kind_ids operator(pg_catalog.&&) array[1]::int2[] and n.properties ? 'name'",
+		},
+		{
+			ID:     112,
+			Source: "match (n) where n.name = $name and n.objectid in $ids return n",
+			Parameters: map[string]any{
+				"name": "lol",
+				"ids":  []string{"1234", "5678"},
+			},
+			Expected: "select (n.id, n.kind_ids, n.properties)::nodeComposite as n from node as n where (n.properties->>'name')::text = @p0 and (n.properties->>'objectid')::text = any(@p1)",
+			ExpectedParameters: map[string]any{
+				"p0": "lol",
+				"p1": []string{"1234", "5678"},
+			},
+		},
+
+		// Reading clauses and the patterns of a match are combined as a cross join
+		{
+			ID:       300,
+			Source:   "match (s), (e)-[]->(o) where s.name = '123' and e.name = 'lol' return s.name, e, o",
+			Expected: "select s.properties->'name' as \"s.name\", (e.id, e.kind_ids, e.properties)::nodeComposite as e, (o.id, o.kind_ids, o.properties)::nodeComposite as o from node as s, node as e join edge e0 on e0.start_id = e.id join node o on o.id = e0.end_id where (s.properties->>'name')::text = '123' and (e.properties->>'name')::text = 'lol'",
+		},
+		{
+			ID:       301,
+			Source:   "match (s) where s.name = '123' match (e) where e.name = 'lol' return s.name, e",
+			Expected: "select s.properties->'name' as \"s.name\", (e.id, e.kind_ids, e.properties)::nodeComposite as e from node as s, node as e where (s.properties->>'name')::text = '123' and (e.properties->>'name')::text = 'lol'",
+		},
+
 		// TODO: This is commented because all shortest paths is not directly supported by the cypher-to-pg translation
 		//       but should be. Future effort should enable this test case as native pathfinding in the pg database
 		//		 is now formally supported.
@@ -589,20 +957,132 @@ func Suite() []TestCase {
 			Error:  true,
 		},
 
-		// UNSUPPORTED CASES
+		// Variable-length patterns may not expand deeper than the analyzer's maximum expansion depth
+		{
+			ID:     201,
+			Source: "match (s)-[*1..16]->(e) return e",
+			Error:  true,
+		},
+		{
+			ID:     202,
+			Source: "match (s)-[*3..2]->(e) return e",
+			Error:  true,
+		},
+
+		// Variable-length patterns are not supported in updating queries
+		{
+			ID:     203,
+			Source: "match (s)-[:EdgeKindA*..]->(e) delete e",
+			Error:  true,
+		},
+
+		// Updating clauses are not supported in multi-part or union queries
+		{
+			ID:     204,
+			Source: "match (s) with s set s.name = 'a' return s",
+			Error:  true,
+		},
+		{
+			ID:     205,
+			Source: "match (s) set s.name = 'a' return s union match (s) return s",
+			Error:  true,
+		},
+		{
+			ID:     206,
+			Source: "optional match (s) set s.name = 'a'",
+			Error:  true,
+		},
 
-		// The following queries are going to require running each match as a distinct select statements with a left
-		// outer join to combine result sets. This is pretty ill-defined and a stupid feature if you ask me, so I'm
-		// going to leave it out for now.
 		{
-			ID:      300,
-			Source:  "match (s), (e)-[]->(o) where s.name = '123' and e.name = 'lol' return s.name, e, o",
-			Ignored: true,
+			ID:     207,
+			Source: "match (n) return foo(n)",
+			Error:  true,
 		},
+
 		{
-			ID:      301,
-			Source:  "match (s) where s.name = '123' match (e) where e.name = 'lol' return s.name, e",
-			Ignored: true,
+			ID:     209,
+			Source: "match (n) where n.name = $name return n",
+			Parameters: map[string]any{
+				"name": []any{"lol"},
+			},
+			Error: true,
+		},
+		{
+			ID:     208,
+			Source: "match (u) where u.pwdlastset < datetime() - duration({fortnights: 2}) return u",
+			Error:  true,
+		},
+
+		// Create and merge clauses are limited to a single node or a single relationship between matched nodes and
+		// require a target graph
+		{
+			ID:     210,
+			Source: "create (n:NodeKindA {name: 'a'})",
+			Error:  true,
+		},
+		{
+			ID:     211,
+			Source: "match (s) create (s)-[:EdgeKindA]->(e)",
+			Error:  true,
+		},
+		{
+			ID:     212,
+			Source: "match (s), (e) create (s)-[:EdgeKindA]-(e)",
+			Error:  true,
+		},
+		{
+			ID:     213,
+			Source: "match (s), (e) create (s)-[:EdgeKindA|EdgeKindB]->(e)",
+			Error:  true,
+		},
+		{
+			ID:     214,
+			Source: "merge (n:NodeKindA {objectid: '1234'}) on create set n.created = true",
+			Error:  true,
+		},
+		{
+			ID:     215,
+			Source: "match (s), (e) create (s)-[:EdgeKindA]->(e) return s",
+			Error:  true,
+		},
+		{
+			ID:     216,
+			Source: "match (s), (e) set s.name = 'a' create (s)-[:EdgeKindA]->(e)",
+			Error:  true,
+		},
+		{
+			ID:     217,
+			Source: "create (n:UnknownKind)",
+			Error:  true,
+		},
+		{
+			ID:       218,
+			Source:   "match (n)-[r]->(m) return [type(r)] as kinds, {labels: labels(n), name: n.name} as entry",
+			Expected: "select array[(select kind.name from unnest(array[r.kind_id]) as kind_ref(id) join kind on kind.id = kind_ref.id)] as kinds, jsonb_build_object('labels', array(select kind.name from unnest(n.kind_ids) with ordinality as kind_ref(id, idx) join kind on kind.id = kind_ref.id order by kind_ref.idx), 'name', n.properties->'name') as entry from node as n join edge r on r.start_id = n.id join node m on m.id = r.end_id",
+		},
+		{
+			ID:         219,
+			Source:     "match (n)-[r]->(m) where type(r) = $kind return r",
+			Parameters: map[string]any{"kind": "EdgeKindA"},
+			Expected:   "select (r.id, r.start_id, r.end_id, r.kind_id, r.properties)::edgeComposite as r from node as n join edge r on r.start_id = n.id join node m on m.id = r.end_id where (select kind.name from unnest(array[r.kind_id]) as kind_ref(id) join kind on kind.id = kind_ref.id) = @p0",
+			ExpectedParameters: map[string]any{
+				"p0": "EdgeKindA",
+			},
+		},
+		{
+			ID:     220,
+			Source: "match (n) set n.name = 'a' delete n",
+			Error:  true,
+		},
+		{
+			ID:     221,
+			Source: "match (n)-[r]->() remove n.name delete r",
+			Error:  true,
+		},
+		{
+			ID:     222,
+			Source: "match (s), (e) set s.name = 'a', e.name = 'b'",
+			Error:  true,
 		},
 	}
 }
@@ -646,6 +1126,10 @@ func TestPGSQLEmitter(t *testing.T) {
 			regularQuery = parsedQuery
 		}
 
+		if testCase.Parameters != nil {
+			require.Nilf(t, model.BindParameters(regularQuery, testCase.Parameters), "test case %d", testCase.ID)
+		}
+
 		var (
 			buffer     = &bytes.Buffer{}
 			kindMapper = KindMapper{
@@ -691,6 +1175,106 @@ func TestPGSQLEmitter(t *testing.T) {
 	}
 }
 
+func TestPGSQLEmitterWithGraph(t *testing.T) {
+	var (
+		buffer     = &bytes.Buffer{}
+		kindMapper = KindMapper{
+			known: map[string]int16{
+				"NodeKindA": 1,
+				"EdgeKindA": 100,
+			},
+		}
+		graphTarget = pgDriverModel.Graph{
+			ID:   2,
+			Name: "workspace",
+			Partitions: pgDriverModel.GraphPartitions{
+				Node: pgDriverModel.NewGraphPartition(pgDriverModel.NodePartitionTableName(2)),
+				Edge: pgDriverModel.NewGraphPartition(pgDriverModel.EdgePartitionTableName(2)),
+			},
+		}
+	)
+
+	for _, testCase := range []struct {
+		Source   string
+		Expected string
+	}{
+		{
+			Source:   "match (s)-[r]->(e) where s.name = '1234' return s, r, e",
+			Expected: "select (s.id, s.kind_ids, s.properties)::nodeComposite as s, (r.id, r.start_id, r.end_id, r.kind_id, r.properties)::edgeComposite as r, (e.id, e.kind_ids, e.properties)::nodeComposite as e from node_2 as s join edge_2 r on r.start_id = s.id join node_2 e on e.id = r.end_id where (s.properties->>'name')::text = '1234'",
+		},
+		{
+			Source:   "match (s)-[r]->(e) where s.name = '1234' delete r",
+			Expected: "delete from edge_2 as r using node_2 as s, node_2 as e where (s.properties->>'name')::text = '1234' and s.id = r.start_id and e.id = r.end_id",
+		},
+		{
+			Source:   "create (n:NodeKindA {name: 'new node', enabled: true})",
+			Expected: "insert into node_2 (graph_id, kind_ids, properties) select 2, @p0, @p1",
+		},
+		{
+			Source:   "merge (n:NodeKindA {objectid: '1234'})",
+			Expected: "insert into node_2 (graph_id, kind_ids, properties) select 2, @p0, @p1 where not exists(select 1 from node_2 as n where n.kind_ids @> @p0 and n.properties @> @p1)",
+		},
+		{
+			Source:   "match (s), (e) where s.name = 'a' and e.name = 'b' create (s)-[:EdgeKindA {source: 'manual'}]->(e)",
+			Expected: "insert into edge_2 (graph_id, start_id, end_id, kind_id, properties) select 2, s.id, e.id, @p0, @p1 from node_2 as s, node_2 as e where (s.properties->>'name')::text = 'a' and (e.properties->>'name')::text = 'b'",
+		},
+		{
+			Source:   "match (s), (e) where s.name = 'a' and e.name = 'b' merge (e)<-[:EdgeKindA]-(s)",
+			Expected: "insert into edge_2 (graph_id, start_id, end_id, kind_id, properties) select 2, s.id, e.id, @p0, @p1 from node_2 as s, node_2 as e where (s.properties->>'name')::text = 'a' and (e.properties->>'name')::text = 'b' and not exists(select 1 from edge_2 as e0 where e0.start_id = s.id and e0.end_id = e.id and e0.kind_id = @p0 and e0.properties @> @p1)",
+		},
+		{
+			Source:   "match (s)-[:EdgeKindA]->(e) merge (s)<-[:EdgeKindA]-(e)",
+			Expected: "insert into edge_2 (graph_id, start_id, end_id, kind_id, properties) select 2, e.id, s.id, @p0, @p1 from node_2 as s join edge_2 e0 on e0.start_id = s.id join node_2 e on e.id = e0.end_id where e0.kind_id = any(array[100]::int2[]) and not exists(select 1 from edge_2 as e1 where e1.start_id = e.id and e1.end_id = s.id and e1.kind_id = @p0 and e1.properties @> @p1)",
+		},
+	} {
+		regularQuery, err := frontend.ParseCypher(frontend.NewContext(), testCase.Source)
+		require.Nil(t, err)
+
+		_, err = pgsql.Translate(regularQuery, kindMapper)
+		require.Nil(t, err)
+
+		buffer.Reset()
+		require.Nil(t, pgsql.NewEmitter(false, kindMapper).WithGraph(graphTarget).Write(regularQuery, buffer))
+		require.Equal(t, testCase.Expected, buffer.String())
+	}
+}
+
+func TestPGSQLEmitter_NodeDeleteSummary(t *testing.T) {
+	var (
+		buffer     = &bytes.Buffer{}
+		kindMapper = KindMapper{
+			known: map[string]int16{
+				"EdgeKindA": 100,
+			},
+		}
+	)
+
+	for _, testCase := range []struct {
+		Source   string
+		Expected string
+	}{
+		{
+			Source:   "match (s) where s.name = '1234' detach delete s",
+			Expected: "with deleted as (delete from node as s where (s.properties->>'name')::text = '1234' returning s.id) select count(*) as nodes_deleted, (select count(*) from edge as e where e.start_id in (select id from deleted) or e.end_id in (select id from deleted)) as relationships_deleted from deleted",
+		},
+		{
+			// Edge deletions report the number of edges they removed as the rows they affected
+			Source:   "match ()-[r:EdgeKindA]->() delete r",
+			Expected: "delete from edge as r using node as n0, node as n1 where r.kind_id = any(array[100]::int2[]) and n0.id = r.start_id and n1.id = r.end_id",
+		},
+	} {
+		regularQuery, err := frontend.ParseCypher(frontend.NewContext(), testCase.Source)
+		require.Nil(t, err)
+
+		_, err = pgsql.Translate(regularQuery, kindMapper)
+		require.Nil(t, err)
+
+		buffer.Reset()
+		require.Nil(t, pgsql.NewEmitter(false, kindMapper).WithNodeDeleteSummary().Write(regularQuery, buffer))
+		require.Equal(t, testCase.Expected, buffer.String())
+	}
+}
+
 func TestBinder(t *testing.T) {
 	var (
 		binder                 = pgsql.NewBinder()
@@ -20,12 +20,14 @@ import (
 	"fmt"
 	cypherModel "github.com/specterops/bloodhound/cypher/model"
 	"github.com/specterops/bloodhound/cypher/model/pg"
+	"strings"
 
 	"github.com/specterops/bloodhound/dawgs/graph"
 )
 
 const (
 	OperatorJSONBFieldExists    cypherModel.Operator = "?"
+	OperatorContains            cypherModel.Operator = "@>"
 	OperatorLike                cypherModel.Operator = "like"
 	OperatorLikeCaseInsensitive cypherModel.Operator = "ilike"
 )
@@ -34,6 +36,7 @@ type UpdatingClauseRewriter struct {
 	kindMapper               KindMapper
 	binder                   *Binder
 	deletion                 *pg.Delete
+	creation                 cypherModel.Expression
 	propertyReferenceSymbols map[string]struct{}
 	propertyAdditions        map[string]map[string]any
 	propertyRemovals         map[string][]string
@@ -85,6 +88,10 @@ func (s *UpdatingClauseRewriter) ToUpdatingClause() ([]cypherModel.Expression, e
 		updatingClauses = append(updatingClauses, s.deletion)
 	}
 
+	if s.creation != nil {
+		updatingClauses = append(updatingClauses, s.creation)
+	}
+
 	for referenceSymbol := range s.propertyReferenceSymbols {
 		propertyMutation, err := s.newPropertyMutation(referenceSymbol)
 
@@ -182,6 +189,10 @@ func (s *UpdatingClauseRewriter) rewriteDeleteClause(singlePartQuery *cypherMode
 		return fmt.Errorf("mixed deletions are not supported")
 	}
 
+	if deleteClause.Detach {
+		s.deletion.Detach = true
+	}
+
 	for _, readingClause := range singlePartQuery.ReadingClauses {
 		if matchClause := readingClause.Match; matchClause != nil {
 			var additionalWhereClauses []cypherModel.Expression
@@ -273,6 +284,199 @@ func (s *UpdatingClauseRewriter) rewriteDeleteClause(singlePartQuery *cypherMode
 	return nil
 }
 
+// literalPropertyValue returns the value a literal stores in a property. String literals keep the quotes they were
+// written with and must be unquoted before they are stored.
+func literalPropertyValue(value any) any {
+	if stringValue, isString := value.(string); isString && len(stringValue) >= 2 {
+		if quote := stringValue[0]; (quote == '\'' || quote == '"') && stringValue[len(stringValue)-1] == quote {
+			return strings.NewReplacer(`\\`, `\`, `\'`, `'`, `\"`, `"`).Replace(stringValue[1 : len(stringValue)-1])
+		}
+	}
+
+	return value
+}
+
+// creationProperties returns the properties of a node or relationship pattern being created
+func creationProperties(properties cypherModel.Expression) (map[string]any, error) {
+	values := map[string]any{}
+
+	switch typedProperties := properties.(type) {
+	case nil:
+		return values, nil
+
+	case *cypherModel.Properties:
+		if typedProperties.Parameter != nil {
+			if parameterValues, isMap := typedProperties.Parameter.Value.(map[string]any); isMap {
+				return parameterValues, nil
+			}
+
+			return nil, fmt.Errorf("expected a map value for properties parameter $%s", typedProperties.Parameter.Symbol)
+		}
+
+		for key, value := range typedProperties.Map {
+			switch typedValue := value.(type) {
+			case *cypherModel.Literal:
+				values[key] = literalPropertyValue(typedValue.Value)
+
+			case *pg.AnnotatedLiteral:
+				values[key] = literalPropertyValue(typedValue.Value)
+
+			case *cypherModel.Parameter:
+				values[key] = typedValue.Value
+
+			case *pg.AnnotatedParameter:
+				values[key] = typedValue.Value
+
+			default:
+				return nil, fmt.Errorf("unexpected value type %T for property %s", value, key)
+			}
+		}
+
+		return values, nil
+	}
+
+	return nil, fmt.Errorf("unexpected properties expression for created pattern: %T", properties)
+}
+
+// matchedNodeBinding returns the binding of the given node pattern if the node was matched by the reading clauses of
+// the given query. Created relationships may only connect nodes that already exist.
+func matchedNodeBinding(singlePartQuery *cypherModel.SinglePartQuery, nodePattern *cypherModel.NodePattern) (*pg.AnnotatedVariable, error) {
+	binding, typeOK := nodePattern.Binding.(*pg.AnnotatedVariable)
+
+	if !typeOK {
+		return nil, fmt.Errorf("unexpected variable for node pattern binding: %T", nodePattern.Binding)
+	} else if len(nodePattern.Kinds) > 0 || nodePattern.Properties != nil {
+		return nil, fmt.Errorf("created relationships must connect matched nodes but node %s specifies kinds or properties", binding.Symbol)
+	}
+
+	for _, readingClause := range singlePartQuery.ReadingClauses {
+		if matchClause := readingClause.Match; matchClause != nil {
+			for _, pattern := range matchClause.Pattern {
+				for _, patternElement := range pattern.PatternElements {
+					if matchedNodePattern, isNodePattern := patternElement.AsNodePattern(); isNodePattern {
+						if matchedBinding, typeOK := matchedNodePattern.Binding.(*pg.AnnotatedVariable); typeOK && matchedBinding.Symbol == binding.Symbol {
+							return binding, nil
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("created relationships must connect matched nodes but node %s is not matched", binding.Symbol)
+}
+
+func (s *UpdatingClauseRewriter) rewriteCreate(singlePartQuery *cypherModel.SinglePartQuery, patternPart *cypherModel.PatternPart, merge bool) error {
+	if s.creation != nil {
+		return fmt.Errorf("multiple create and merge clauses are not supported")
+	}
+
+	if singlePartQuery.Return != nil {
+		return fmt.Errorf("create and merge clauses may not return results")
+	}
+
+	if patternPart.Binding != nil {
+		return fmt.Errorf("path bindings are not supported for created patterns")
+	}
+
+	switch len(patternPart.PatternElements) {
+	case 1:
+		nodePattern, _ := patternPart.PatternElements[0].AsNodePattern()
+
+		if binding, typeOK := nodePattern.Binding.(*pg.AnnotatedVariable); !typeOK {
+			return fmt.Errorf("unexpected variable for node pattern binding: %T", nodePattern.Binding)
+		} else if kindIDs, missingKinds := s.kindMapper.MapKinds(nodePattern.Kinds); len(missingKinds) > 0 {
+			return fmt.Errorf("created node references the following unknown kinds: %v", missingKinds.Strings())
+		} else if kindsParameter, err := s.binder.NewParameter(kindIDs); err != nil {
+			return err
+		} else if properties, err := creationProperties(nodePattern.Properties); err != nil {
+			return err
+		} else if propertiesJSONB, err := MapStringAnyToJSONB(properties); err != nil {
+			return err
+		} else if propertiesParameter, err := s.binder.NewParameter(propertiesJSONB); err != nil {
+			return err
+		} else {
+			s.creation = &pg.NodeCreate{
+				Binding:    binding,
+				Kinds:      kindsParameter,
+				Properties: propertiesParameter,
+				Merge:      merge,
+			}
+		}
+
+	case 3:
+		var (
+			leftNodePattern, _     = patternPart.PatternElements[0].AsNodePattern()
+			relationshipPattern, _ = patternPart.PatternElements[1].AsRelationshipPattern()
+			rightNodePattern, _    = patternPart.PatternElements[2].AsNodePattern()
+		)
+
+		if relationshipPattern.Range != nil {
+			return fmt.Errorf("variable-length relationship patterns may not be created")
+		} else if len(relationshipPattern.Kinds) != 1 {
+			return fmt.Errorf("created relationships must have exactly one kind")
+		}
+
+		binding, typeOK := relationshipPattern.Binding.(*pg.AnnotatedVariable)
+
+		if !typeOK {
+			return fmt.Errorf("unexpected variable for relationship pattern binding: %T", relationshipPattern.Binding)
+		}
+
+		leftBinding, err := matchedNodeBinding(singlePartQuery, leftNodePattern)
+
+		if err != nil {
+			return err
+		}
+
+		rightBinding, err := matchedNodeBinding(singlePartQuery, rightNodePattern)
+
+		if err != nil {
+			return err
+		}
+
+		edgeCreate := &pg.EdgeCreate{
+			Binding: binding,
+			Merge:   merge,
+		}
+
+		switch relationshipPattern.Direction {
+		case graph.DirectionOutbound:
+			edgeCreate.Start = leftBinding
+			edgeCreate.End = rightBinding
+
+		case graph.DirectionInbound:
+			edgeCreate.Start = rightBinding
+			edgeCreate.End = leftBinding
+
+		default:
+			return fmt.Errorf("created relationships must have a direction")
+		}
+
+		if kindIDs, missingKinds := s.kindMapper.MapKinds(relationshipPattern.Kinds); len(missingKinds) > 0 {
+			return fmt.Errorf("created relationship references the following unknown kinds: %v", missingKinds.Strings())
+		} else if kindParameter, err := s.binder.NewParameter(kindIDs[0]); err != nil {
+			return err
+		} else if properties, err := creationProperties(relationshipPattern.Properties); err != nil {
+			return err
+		} else if propertiesJSONB, err := MapStringAnyToJSONB(properties); err != nil {
+			return err
+		} else if propertiesParameter, err := s.binder.NewParameter(propertiesJSONB); err != nil {
+			return err
+		} else {
+			edgeCreate.Kind = kindParameter
+			edgeCreate.Properties = propertiesParameter
+		}
+
+		s.creation = edgeCreate
+
+	default:
+		return fmt.Errorf("create and merge clauses must describe a single node or a single relationship")
+	}
+
+	return nil
+}
+
 func (s *UpdatingClauseRewriter) RewriteUpdatingClauses(singlePartQuery *cypherModel.SinglePartQuery) error {
 	for _, updatingClause := range singlePartQuery.UpdatingClauses {
 		typedUpdatingClause, isUpdatingClause := updatingClause.(*cypherModel.UpdatingClause)
@@ -283,7 +487,22 @@ func (s *UpdatingClauseRewriter) RewriteUpdatingClauses(singlePartQuery *cypherM
 
 		switch typedClause := typedUpdatingClause.Clause.(type) {
 		case *cypherModel.Create:
-			return fmt.Errorf("create unsupported")
+			if len(typedClause.Pattern) != 1 {
+				return fmt.Errorf("create clauses must contain exactly one pattern")
+			}
+
+			if err := s.rewriteCreate(singlePartQuery, typedClause.Pattern[0], false); err != nil {
+				return err
+			}
+
+		case *cypherModel.Merge:
+			if len(typedClause.Actions) > 0 {
+				return fmt.Errorf("merge actions are not supported")
+			}
+
+			if err := s.rewriteCreate(singlePartQuery, typedClause.PatternPart, true); err != nil {
+				return err
+			}
 
 		case *cypherModel.Delete:
 			if err := s.rewriteDeleteClause(singlePartQuery, typedClause); err != nil {
@@ -314,10 +533,10 @@ func (s *UpdatingClauseRewriter) RewriteUpdatingClauses(singlePartQuery *cypherM
 						switch rightHandOperand := setItem.Right.(type) {
 						case *cypherModel.Literal:
 							// TODO: Negotiate null literals
-							s.TrackPropertyAddition(referenceSymbol, propertyName, rightHandOperand.Value)
+							s.TrackPropertyAddition(referenceSymbol, propertyName, literalPropertyValue(rightHandOperand.Value))
 
 						case *pg.AnnotatedLiteral:
-							s.TrackPropertyAddition(referenceSymbol, propertyName, rightHandOperand.Value)
+							s.TrackPropertyAddition(referenceSymbol, propertyName, literalPropertyValue(rightHandOperand.Value))
 
 						case *cypherModel.Parameter:
 							s.TrackPropertyAddition(referenceSymbol, propertyName, rightHandOperand.Value)
@@ -360,6 +579,19 @@ func (s *UpdatingClauseRewriter) RewriteUpdatingClauses(singlePartQuery *cypherM
 		}
 	}
 
+	// Each kind of updating clause is written as a single SQL statement so they may not be combined
+	if s.creation != nil && (s.HasChanges() || s.deletion.NodeDelete || s.deletion.EdgeDelete) {
+		return fmt.Errorf("create and merge clauses may not be combined with other updating clauses")
+	}
+
+	if (s.deletion.NodeDelete || s.deletion.EdgeDelete) && s.HasChanges() {
+		return fmt.Errorf("delete clauses may not be combined with set or remove clauses")
+	}
+
+	if numUpdated := len(s.updatedSymbols()); numUpdated > 1 {
+		return fmt.Errorf("set and remove clauses may only update a single variable but %d are updated", numUpdated)
+	}
+
 	if updatingClauses, err := s.ToUpdatingClause(); err != nil {
 		return err
 	} else {
@@ -369,6 +601,21 @@ func (s *UpdatingClauseRewriter) RewriteUpdatingClauses(singlePartQuery *cypherM
 	return nil
 }
 
+// updatedSymbols returns the symbols of the variables whose properties or kinds are updated
+func (s *UpdatingClauseRewriter) updatedSymbols() map[string]struct{} {
+	updatedSymbols := make(map[string]struct{}, len(s.propertyReferenceSymbols)+len(s.kindReferenceSymbols))
+
+	for symbol := range s.propertyReferenceSymbols {
+		updatedSymbols[symbol] = struct{}{}
+	}
+
+	for symbol := range s.kindReferenceSymbols {
+		updatedSymbols[symbol] = struct{}{}
+	}
+
+	return updatedSymbols
+}
+
 func (s *UpdatingClauseRewriter) HasAdditions() bool {
 	return len(s.propertyAdditions) > 0 || len(s.kindAdditions) > 0
 }
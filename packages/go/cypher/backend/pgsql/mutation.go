@@ -0,0 +1,135 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pgsql
+
+import (
+	"fmt"
+
+	"github.com/jackc/pgtype"
+	cypherModel "github.com/specterops/bloodhound/cypher/model"
+	"github.com/specterops/bloodhound/cypher/model/pg"
+	"github.com/specterops/bloodhound/dawgs/graph"
+)
+
+// MutationEffects returns the changes a translated updating query makes to each row it affects. Translated updating
+// queries are written as a single statement so multiplying the effects by the number of rows the statement affected
+// summarizes the changes the statement made to the graph. This does not hold for node deletions, which also remove the
+// edges attached to each deleted node; see NodeDeletion.
+func MutationEffects(regularQuery *cypherModel.RegularQuery) (graph.MutationSummary, error) {
+	var effects graph.MutationSummary
+
+	if regularQuery.SingleQuery == nil || regularQuery.SingleQuery.SinglePartQuery == nil {
+		return effects, nil
+	}
+
+	for _, updatingClause := range regularQuery.SingleQuery.SinglePartQuery.UpdatingClauses {
+		switch typedClause := updatingClause.(type) {
+		case *pg.Delete:
+			if typedClause.NodeDelete {
+				effects.NodesDeleted++
+			} else if typedClause.EdgeDelete {
+				effects.RelationshipsDeleted++
+			}
+
+		case *pg.PropertyMutation:
+			if numAdditions, err := numParameterElements(typedClause.Additions); err != nil {
+				return effects, err
+			} else if numRemovals, err := numParameterElements(typedClause.Removals); err != nil {
+				return effects, err
+			} else {
+				effects.PropertiesSet += numAdditions + numRemovals
+			}
+
+		case *pg.KindMutation:
+			if numAdditions, err := numParameterElements(typedClause.Additions); err != nil {
+				return effects, err
+			} else if numRemovals, err := numParameterElements(typedClause.Removals); err != nil {
+				return effects, err
+			} else {
+				effects.LabelsAdded += numAdditions
+				effects.LabelsRemoved += numRemovals
+			}
+
+		case *pg.NodeCreate:
+			effects.NodesCreated++
+
+			if numKinds, err := numParameterElements(typedClause.Kinds); err != nil {
+				return effects, err
+			} else if numProperties, err := numParameterElements(typedClause.Properties); err != nil {
+				return effects, err
+			} else {
+				effects.LabelsAdded += numKinds
+				effects.PropertiesSet += numProperties
+			}
+
+		case *pg.EdgeCreate:
+			effects.RelationshipsCreated++
+
+			if numProperties, err := numParameterElements(typedClause.Properties); err != nil {
+				return effects, err
+			} else {
+				effects.PropertiesSet += numProperties
+			}
+		}
+	}
+
+	return effects, nil
+}
+
+// NodeDeletion returns the node deletion of the given translated updating query, if it has one. Node deletions
+// written by an Emitter configured with WithNodeDeleteSummary return the number of nodes and edges they removed.
+func NodeDeletion(regularQuery *cypherModel.RegularQuery) (*pg.Delete, bool) {
+	if regularQuery.SingleQuery == nil || regularQuery.SingleQuery.SinglePartQuery == nil {
+		return nil, false
+	}
+
+	for _, updatingClause := range regularQuery.SingleQuery.SinglePartQuery.UpdatingClauses {
+		if deletion, isDelete := updatingClause.(*pg.Delete); isDelete && deletion.NodeDelete {
+			return deletion, true
+		}
+	}
+
+	return nil, false
+}
+
+// numParameterElements returns the number of kinds, properties or property names in the value of the given mutation
+// parameter
+func numParameterElements(parameter *pg.AnnotatedParameter) (int, error) {
+	if parameter == nil {
+		return 0, nil
+	}
+
+	switch typedValue := parameter.Value.(type) {
+	case []int16:
+		return len(typedValue), nil
+
+	case pgtype.TextArray:
+		return len(typedValue.Elements), nil
+
+	case pgtype.JSONB:
+		properties := map[string]any{}
+
+		if err := typedValue.AssignTo(&properties); err != nil {
+			return 0, err
+		}
+
+		return len(properties), nil
+
+	default:
+		return 0, fmt.Errorf("unexpected mutation parameter type: %T", parameter.Value)
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pgsql_test
+
+import (
+	"testing"
+
+	"github.com/specterops/bloodhound/cypher/backend/pgsql"
+	"github.com/specterops/bloodhound/cypher/frontend"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMutationEffects(t *testing.T) {
+	kindMapper := KindMapper{
+		known: map[string]int16{
+			"NodeKindA": 1,
+			"NodeKindB": 2,
+			"EdgeKindA": 100,
+		},
+	}
+
+	for _, testCase := range []struct {
+		Source   string
+		Expected graph.MutationSummary
+	}{
+		{
+			Source:   "match (s) return s",
+			Expected: graph.MutationSummary{},
+		},
+		{
+			Source: "match (s) where s.name = 'a' set s.owned = true, s.note = 'b', s:NodeKindA remove s.other, s:NodeKindB",
+			Expected: graph.MutationSummary{
+				PropertiesSet: 3,
+				LabelsAdded:   1,
+				LabelsRemoved: 1,
+			},
+		},
+		{
+			Source: "match (s)-[r:EdgeKindA]->(e) delete r",
+			Expected: graph.MutationSummary{
+				RelationshipsDeleted: 1,
+			},
+		},
+		{
+			Source: "match (s) detach delete s",
+			Expected: graph.MutationSummary{
+				NodesDeleted: 1,
+			},
+		},
+		{
+			Source: "create (n:NodeKindA:NodeKindB {name: 'a'})",
+			Expected: graph.MutationSummary{
+				NodesCreated:  1,
+				LabelsAdded:   2,
+				PropertiesSet: 1,
+			},
+		},
+		{
+			Source: "match (s), (e) merge (s)-[:EdgeKindA]->(e)",
+			Expected: graph.MutationSummary{
+				RelationshipsCreated: 1,
+			},
+		},
+	} {
+		regularQuery, err := frontend.ParseCypher(frontend.NewContext(), testCase.Source)
+		require.Nil(t, err)
+
+		_, err = pgsql.Translate(regularQuery, kindMapper)
+		require.Nil(t, err)
+
+		effects, err := pgsql.MutationEffects(regularQuery)
+		require.Nil(t, err)
+		require.Equalf(t, testCase.Expected, effects, "test case: %s", testCase.Source)
+	}
+}
+
+func TestNodeDeletion(t *testing.T) {
+	kindMapper := KindMapper{
+		known: map[string]int16{
+			"EdgeKindA": 100,
+		},
+	}
+
+	for _, testCase := range []struct {
+		Source         string
+		IsNodeDeletion bool
+		Detach         bool
+	}{
+		{
+			Source: "match (s) return s",
+		},
+		{
+			Source: "match (s)-[r:EdgeKindA]->(e) delete r",
+		},
+		{
+			Source:         "match (s) delete s",
+			IsNodeDeletion: true,
+		},
+		{
+			Source:         "match (s) detach delete s",
+			IsNodeDeletion: true,
+			Detach:         true,
+		},
+	} {
+		regularQuery, err := frontend.ParseCypher(frontend.NewContext(), testCase.Source)
+		require.Nil(t, err)
+
+		_, err = pgsql.Translate(regularQuery, kindMapper)
+		require.Nil(t, err)
+
+		nodeDeletion, isNodeDeletion := pgsql.NodeDeletion(regularQuery)
+		require.Equalf(t, testCase.IsNodeDeletion, isNodeDeletion, "test case: %s", testCase.Source)
+
+		if isNodeDeletion {
+			require.Equalf(t, testCase.Detach, nodeDeletion.Detach, "test case: %s", testCase.Source)
+		}
+	}
+}
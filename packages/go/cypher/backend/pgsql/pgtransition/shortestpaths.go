@@ -177,6 +177,10 @@ func TranslateAllShortestPaths(regularQuery *model.RegularQuery, kindMapper pgsq
 		return aspArguments, fmt.Errorf("multi-part queries not supported")
 	}
 
+	if len(regularQuery.Unions) > 0 {
+		return aspArguments, fmt.Errorf("unions not supported")
+	}
+
 	if numReadingClauses := len(regularQuery.SingleQuery.SinglePartQuery.ReadingClauses); numReadingClauses != 1 {
 		return aspArguments, fmt.Errorf("expected one reading clause but saw %d", numReadingClauses)
 	}
@@ -19,6 +19,7 @@ package pgsql
 import (
 	"fmt"
 	"github.com/specterops/bloodhound/cypher/model"
+	"github.com/specterops/bloodhound/cypher/model/pg"
 )
 
 func rewrite(stack *model.WalkStack, original, rewritten model.Expression) error {
@@ -78,6 +79,40 @@ func rewrite(stack *model.WalkStack, original, rewritten model.Expression) error
 			typedTrunk.Expression = rewritten
 		}
 
+	case *model.Unwind:
+		typedTrunk.Expression = rewritten
+
+	case *model.SortItem:
+		typedTrunk.Expression = rewritten
+
+	case *model.ArithmeticExpression:
+		if typedTrunk.Left == original {
+			typedTrunk.Left = rewritten
+		}
+
+	case *model.PartialArithmeticExpression:
+		typedTrunk.Right = rewritten
+
+	case *pg.AnnotatedLiteral:
+		switch typedValue := typedTrunk.Value.(type) {
+		case *model.ListLiteral:
+			for idx, expression := range *typedValue {
+				if expression == original {
+					(*typedValue)[idx] = rewritten
+				}
+			}
+
+		case model.MapLiteral:
+			for key, expression := range typedValue {
+				if expression == original {
+					typedValue[key] = rewritten
+				}
+			}
+
+		default:
+			return fmt.Errorf("unable to replace expression for literal type %T", typedTrunk.Value)
+		}
+
 	default:
 		return fmt.Errorf("unable to replace expression for trunk type %T", stack.Trunk())
 	}
@@ -22,6 +22,7 @@ import (
 	"github.com/specterops/bloodhound/cypher/analyzer"
 	"github.com/specterops/bloodhound/cypher/model"
 	"github.com/specterops/bloodhound/cypher/model/pg"
+	"github.com/specterops/bloodhound/dawgs/graph"
 	"strconv"
 	"strings"
 )
@@ -192,6 +193,65 @@ func (s *Binder) NewAlias(originalSymbol string, alias *model.Variable) *pg.Anno
 	return s.BindVariable(alias, pg.UnknownDataType)
 }
 
+// projectedType returns the type of the given projection expression if it can be determined ahead of execution
+func (s *Binder) projectedType(expression model.Expression) pg.DataType {
+	switch typedExpression := expression.(type) {
+	case *model.FunctionInvocation:
+		switch typedExpression.Name {
+		case cypherCountFunction:
+			return pg.Int8
+
+		case cypherCollectFunction:
+			if len(typedExpression.Arguments) == 1 {
+				if variable, isVariable := typedExpression.Arguments[0].(*model.Variable); isVariable {
+					if bindingType, isBound := s.BindingType(variable.Symbol); isBound {
+						switch bindingType {
+						case pg.Node:
+							return pg.NodeArray
+
+						case pg.Edge:
+							return pg.EdgeArray
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return pg.UnknownDataType
+}
+
+// unwoundType returns the type of the elements of the given unwind expression if it can be determined ahead of
+// execution
+func (s *Binder) unwoundType(expression model.Expression) pg.DataType {
+	switch typedExpression := expression.(type) {
+	case *model.Variable:
+		if bindingType, isBound := s.BindingType(typedExpression.Symbol); isBound {
+			switch bindingType {
+			case pg.NodeArray:
+				return pg.Node
+
+			case pg.EdgeArray:
+				return pg.Edge
+			}
+
+			if baseType, err := bindingType.ArrayBaseType(); err == nil {
+				return baseType
+			}
+		}
+
+	case *model.Literal:
+		// List literals are annotated with the type of their elements
+		if _, isList := typedExpression.Value.(*model.ListLiteral); isList {
+			if annotation, err := pg.NewSQLTypeAnnotationFromLiteral(typedExpression); err == nil && annotation != nil {
+				return annotation.Type
+			}
+		}
+	}
+
+	return pg.UnknownDataType
+}
+
 func (s *Binder) Scan(regularQuery *model.RegularQuery) error {
 	if err := analyzer.Analyze(regularQuery, func(analyzerInst *analyzer.Analyzer) {
 		analyzer.WithVisitor(analyzerInst, func(stack *model.WalkStack, node *model.Parameter) error {
@@ -236,16 +296,24 @@ func (s *Binder) Scan(regularQuery *model.RegularQuery) error {
 					nodePattern.Binding = s.BindPatternVariable(bindingVariable, pg.Node)
 				}
 			} else {
-				relationshipPattern, _ := patternElement.AsRelationshipPattern()
+				var (
+					relationshipPattern, _ = patternElement.AsRelationshipPattern()
+					bindingType            = pg.Edge
+				)
+
+				// Variable-length relationship patterns bind to the list of relationships traversed
+				if relationshipPattern.Range != nil {
+					bindingType = pg.EdgeArray
+				}
 
 				if relationshipPattern.Binding == nil {
-					relationshipPattern.Binding = s.NewAnnotatedVariable("e", pg.Edge)
+					relationshipPattern.Binding = s.NewAnnotatedVariable("e", bindingType)
 				} else if bindingVariable, typeOK := relationshipPattern.Binding.(*model.Variable); !typeOK {
 					return fmt.Errorf("expected variable for relationship pattern binding but got: %T", relationshipPattern.Binding)
 				} else if _, isPatternPredicate := stack.Trunk().(*model.PatternPredicate); isPatternPredicate {
-					relationshipPattern.Binding = s.BindVariable(bindingVariable, pg.Edge)
+					relationshipPattern.Binding = s.BindVariable(bindingVariable, bindingType)
 				} else {
-					relationshipPattern.Binding = s.BindPatternVariable(bindingVariable, pg.Edge)
+					relationshipPattern.Binding = s.BindPatternVariable(bindingVariable, bindingType)
 				}
 			}
 
@@ -256,12 +324,24 @@ func (s *Binder) Scan(regularQuery *model.RegularQuery) error {
 			if bindingVariable, isVariable := node.Binding.(*model.Variable); node.Binding != nil && isVariable {
 				if projectionVariable, isVariable := node.Expression.(*model.Variable); isVariable {
 					node.Binding = s.NewAlias(projectionVariable.Symbol, bindingVariable)
+				} else {
+					// Bind the alias so that it may be referenced by the clauses that follow the projection
+					node.Binding = s.BindVariable(bindingVariable, s.projectedType(node.Expression))
 				}
 			}
 
 			return nil
 		})
 
+		analyzer.WithVisitor(analyzerInst, func(_ *model.WalkStack, node *model.Unwind) error {
+			if node.Binding == nil {
+				return fmt.Errorf("unwind clause must be bound to a variable")
+			}
+
+			s.BindVariable(node.Binding, s.unwoundType(node.Expression))
+			return nil
+		})
+
 		analyzer.WithVisitor(analyzerInst, func(_ *model.WalkStack, node *model.Delete) error {
 			for idx, expression := range node.Expressions {
 				switch typedExpression := expression.(type) {
@@ -288,6 +368,10 @@ type Translator struct {
 	Bindings     *Binder
 	kindMapper   KindMapper
 	regularQuery *model.RegularQuery
+
+	// scope contains the symbols that are visible to the clause being translated. This includes symbols bound by
+	// earlier clauses of the same query part and symbols projected by the with clause of the preceding query part.
+	scope map[string]struct{}
 }
 
 func NewTranslator(kindMapper KindMapper, bindings *Binder, regularQuery *model.RegularQuery) *Translator {
@@ -296,13 +380,145 @@ func NewTranslator(kindMapper KindMapper, bindings *Binder, regularQuery *model.
 		kindMapper:   kindMapper,
 		Bindings:     bindings,
 		regularQuery: regularQuery,
+		scope:        map[string]struct{}{},
+	}
+}
+
+func (s *Translator) enterSingleQuery(_ *model.WalkStack, _ *model.SingleQuery) error {
+	// Each single query of a union is translated with an empty scope
+	s.scope = map[string]struct{}{}
+	return nil
+}
+
+func (s *Translator) scopeWith(_ *model.WalkStack, with *model.With) error {
+	projectedScope := make(map[string]struct{}, len(with.Projection.Items))
+
+	for _, item := range with.Projection.Items {
+		projectionItem, typeOK := item.(*model.ProjectionItem)
+
+		if !typeOK {
+			return fmt.Errorf("unexpected projection item type: %T", item)
+		}
+
+		symbolSource := projectionItem.Binding
+
+		if symbolSource == nil {
+			if _, isVariable := projectionItem.Expression.(*model.Variable); !isVariable {
+				return fmt.Errorf("with clause expressions must be aliased")
+			}
+
+			symbolSource = projectionItem.Expression
+		}
+
+		if symbol, err := GetSymbol(symbolSource); err != nil {
+			return err
+		} else {
+			projectedScope[symbol] = struct{}{}
+		}
+	}
+
+	// Only the symbols projected by the with clause are visible to the query part that follows it
+	s.scope = projectedScope
+	return nil
+}
+
+func (s *Translator) scopeUnwind(_ *model.WalkStack, unwind *model.Unwind) error {
+	s.scope[unwind.Binding.Symbol] = struct{}{}
+	return nil
+}
+
+// rebindScopedVariable creates a new binding for a pattern element that references a symbol that is already in scope.
+// Pattern elements of a match are joined as new table references and therefore must match the existing binding by
+// its identity.
+func (s *Translator) rebindScopedVariable(binding model.Expression, prefix string) (*pg.AnnotatedVariable, model.Expression, error) {
+	if bindingVariable, typeOK := binding.(*pg.AnnotatedVariable); !typeOK {
+		return nil, nil, fmt.Errorf("unexpected pattern element binding type: %T", binding)
+	} else if _, inScope := s.scope[bindingVariable.Symbol]; !inScope {
+		return nil, nil, nil
+	} else {
+		newBinding := s.Bindings.NewAnnotatedVariable(prefix, bindingVariable.Type)
+
+		return newBinding, model.NewComparison(
+			model.NewSimpleFunctionInvocation(
+				cypherIdentityFunction,
+				bindingVariable,
+			),
+			model.OperatorEquals,
+			model.NewSimpleFunctionInvocation(
+				cypherIdentityFunction,
+				newBinding,
+			),
+		), nil
+	}
+}
+
+func (s *Translator) scopeMatch(match *model.Match) error {
+	for _, patternPart := range match.Pattern {
+		if patternPart.Binding != nil {
+			if symbol, err := GetSymbol(patternPart.Binding); err != nil {
+				return err
+			} else {
+				s.scope[symbol] = struct{}{}
+			}
+		}
+
+		for _, patternElement := range patternPart.PatternElements {
+			var symbolSource model.Expression
+
+			if expansion, isExpansion := patternElement.Element.(*pg.Expansion); isExpansion {
+				symbolSource = expansion.Relationship
+			} else if nodePattern, isNodePattern := patternElement.AsNodePattern(); isNodePattern {
+				symbolSource = nodePattern.Binding
+			} else {
+				relationshipPattern, _ := patternElement.AsRelationshipPattern()
+				symbolSource = relationshipPattern.Binding
+			}
+
+			if symbol, err := GetSymbol(symbolSource); err != nil {
+				return err
+			} else {
+				s.scope[symbol] = struct{}{}
+			}
+		}
 	}
+
+	return nil
 }
 
 func (s *Translator) rewriteUpdatingClauses(_ *model.WalkStack, singlePartQuery *model.SinglePartQuery) error {
 	return NewUpdateClauseRewriter(s.Bindings, s.kindMapper).RewriteUpdatingClauses(singlePartQuery)
 }
 
+// newPropertyMatcherComparison creates the criteria for a pattern property matcher. Variable references, such as the
+// binding of a preceding unwind clause, take the type of their binding. Values of unknown type are compared against
+// the property without a type annotation, as comparisons in a where clause are.
+func (s *Translator) newPropertyMatcherComparison(symbol, propertyName string, matcherValue model.Expression) (model.Expression, error) {
+	var (
+		propertyLookup = model.NewPropertyLookup(symbol, propertyName)
+		annotatedValue = matcherValue
+	)
+
+	if variable, isVariable := matcherValue.(*model.Variable); isVariable {
+		if annotatedVariable, isBound := s.Bindings.LookupVariable(variable.Symbol); !isBound {
+			return nil, fmt.Errorf("unable to look up type annotation for variable reference: %s", variable.Symbol)
+		} else {
+			annotatedValue = annotatedVariable
+		}
+	}
+
+	if annotation, err := pg.NewSQLTypeAnnotationFromExpression(annotatedValue); err != nil {
+		return nil, err
+	} else if annotation.Type == pg.UnknownDataType {
+		return model.NewComparison(propertyLookup, model.OperatorEquals, matcherValue), nil
+	} else {
+		return model.NewComparison(
+			pg.NewAnnotatedPropertyLookup(propertyLookup, annotation.Type),
+			model.OperatorEquals,
+			matcherValue,
+		), nil
+	}
+}
+
 func (s *Translator) liftNodePatternCriteria(_ *model.WalkStack, nodePattern *model.NodePattern) ([]model.Expression, error) {
 	var criteria []model.Expression
 
@@ -326,16 +542,10 @@ func (s *Translator) liftNodePatternCriteria(_ *model.WalkStack, nodePattern *mo
 			if bindingVariable, typeOK := nodePattern.Binding.(*pg.AnnotatedVariable); !typeOK {
 				return nil, fmt.Errorf("unexpected node pattern binding type for node pattern: %T", nodePattern.Binding)
 			} else {
-				propertyLookup := model.NewPropertyLookup(bindingVariable.Symbol, propertyName)
-
-				if annotation, err := pg.NewSQLTypeAnnotationFromExpression(matcherValue); err != nil {
+				if comparison, err := s.newPropertyMatcherComparison(bindingVariable.Symbol, propertyName, matcherValue); err != nil {
 					return nil, err
 				} else {
-					criteria = append(criteria, model.NewComparison(
-						pg.NewAnnotatedPropertyLookup(propertyLookup, annotation.Type),
-						model.OperatorEquals,
-						matcherValue,
-					))
+					criteria = append(criteria, comparison)
 				}
 			}
 		}
@@ -367,16 +577,10 @@ func (s *Translator) liftRelationshipPatternCriteria(_ *model.WalkStack, relatio
 			if bindingVariable, typeOK := relationshipPattern.Binding.(*pg.AnnotatedVariable); !typeOK {
 				return nil, fmt.Errorf("unexpected relationship pattern binding type: %T", relationshipPattern.Binding)
 			} else {
-				propertyLookup := model.NewPropertyLookup(bindingVariable.Symbol, propertyName)
-
-				if annotation, err := pg.NewSQLTypeAnnotationFromExpression(matcherValue); err != nil {
+				if comparison, err := s.newPropertyMatcherComparison(bindingVariable.Symbol, propertyName, matcherValue); err != nil {
 					return nil, err
 				} else {
-					criteria = append(criteria, model.NewComparison(
-						pg.NewAnnotatedPropertyLookup(propertyLookup, annotation.Type),
-						model.OperatorEquals,
-						matcherValue,
-					))
+					criteria = append(criteria, comparison)
 				}
 			}
 		}
@@ -385,6 +589,32 @@ func (s *Translator) liftRelationshipPatternCriteria(_ *model.WalkStack, relatio
 	return criteria, nil
 }
 
+// newExpansion lifts the criteria of a variable-length relationship pattern into a new expansion. The criteria of an
+// expansion apply to every relationship it traverses and therefore can not be lifted into the where clause.
+func (s *Translator) newExpansion(stack *model.WalkStack, relationshipPattern *model.RelationshipPattern) (*pg.Expansion, error) {
+	if relationshipBinding, typeOK := relationshipPattern.Binding.(*pg.AnnotatedVariable); !typeOK {
+		return nil, fmt.Errorf("unexpected relationship pattern binding type: %T", relationshipPattern.Binding)
+	} else if minDepth, maxDepth, err := analyzer.ExpansionDepth(relationshipPattern.Range); err != nil {
+		return nil, err
+	} else if criteria, err := s.liftRelationshipPatternCriteria(stack, relationshipPattern); err != nil {
+		return nil, err
+	} else {
+		expansion := &pg.Expansion{
+			Binding:      s.Bindings.NewAnnotatedVariable("ep", pg.Reference),
+			Relationship: relationshipBinding,
+			Direction:    relationshipPattern.Direction,
+			MinDepth:     minDepth,
+			MaxDepth:     maxDepth,
+		}
+
+		if len(criteria) > 0 {
+			expansion.Criteria = model.NewConjunction(criteria...)
+		}
+
+		return expansion, nil
+	}
+}
+
 func (s *Translator) liftPatternElementCriteria(stack *model.WalkStack, patternElement *model.PatternElement) ([]model.Expression, error) {
 	if nodePattern, isNodePattern := patternElement.AsNodePattern(); isNodePattern {
 		return s.liftNodePatternCriteria(stack, nodePattern)
@@ -397,6 +627,7 @@ func (s *Translator) liftPatternElementCriteria(stack *model.WalkStack, patternE
 func (s *Translator) translatePatternPredicates(stack *model.WalkStack, patternPredicate *model.PatternPredicate) error {
 	var (
 		subqueryFilters []model.Expression
+		hasExpansion    = false
 		subquery        = &pg.Subquery{
 			PatternElements: patternPredicate.PatternElements,
 		}
@@ -437,6 +668,17 @@ func (s *Translator) translatePatternPredicates(stack *model.WalkStack, patternP
 		} else {
 			relationshipPattern, _ := patternElement.AsRelationshipPattern()
 
+			if relationshipPattern.Range != nil {
+				if expansion, err := s.newExpansion(stack, relationshipPattern); err != nil {
+					return err
+				} else {
+					patternElement.Element = expansion
+					hasExpansion = true
+				}
+
+				continue
+			}
+
 			// Is the relationship pattern bound to a variable and was that variable bound earlier in the AST?
 			if bindingVariable, typeOK := relationshipPattern.Binding.(*pg.AnnotatedVariable); !typeOK {
 				return fmt.Errorf("unexpected relationship pattern binding type: %T", relationshipPattern.Binding)
@@ -473,7 +715,9 @@ func (s *Translator) translatePatternPredicates(stack *model.WalkStack, patternP
 
 	if len(subqueryFilters) > 0 {
 		subquery.Filter = model.NewConjunction(subqueryFilters...)
+	}
 
+	if subquery.Filter != nil || hasExpansion {
 		return rewrite(stack, patternPredicate, subquery)
 	}
 
@@ -485,7 +729,29 @@ func (s *Translator) liftMatchCriteria(stack *model.WalkStack, match *model.Matc
 
 	for _, patternPart := range match.Pattern {
 		for _, patternElement := range patternPart.PatternElements {
-			if patternElementCriteria, err := s.liftPatternElementCriteria(stack, patternElement); err != nil {
+			if nodePattern, isNodePattern := patternElement.AsNodePattern(); isNodePattern {
+				if newBinding, identityCriteria, err := s.rebindScopedVariable(nodePattern.Binding, "n"); err != nil {
+					return err
+				} else if newBinding != nil {
+					nodePattern.Binding = newBinding
+					additionalCriteria = append(additionalCriteria, identityCriteria)
+				}
+			} else if relationshipPattern, _ := patternElement.AsRelationshipPattern(); relationshipPattern.Range == nil {
+				if newBinding, identityCriteria, err := s.rebindScopedVariable(relationshipPattern.Binding, "e"); err != nil {
+					return err
+				} else if newBinding != nil {
+					relationshipPattern.Binding = newBinding
+					additionalCriteria = append(additionalCriteria, identityCriteria)
+				}
+			}
+
+			if relationshipPattern, isRelationshipPattern := patternElement.AsRelationshipPattern(); isRelationshipPattern && relationshipPattern.Range != nil {
+				if expansion, err := s.newExpansion(stack, relationshipPattern); err != nil {
+					return err
+				} else {
+					patternElement.Element = expansion
+				}
+			} else if patternElementCriteria, err := s.liftPatternElementCriteria(stack, patternElement); err != nil {
 				return err
 			} else {
 				additionalCriteria = append(additionalCriteria, patternElementCriteria...)
@@ -503,7 +769,7 @@ func (s *Translator) liftMatchCriteria(stack *model.WalkStack, match *model.Matc
 		}
 	}
 
-	return nil
+	return s.scopeMatch(match)
 }
 
 func (s *Translator) annotateKindMatchers(stack *model.WalkStack, kindMatcher *model.KindMatcher) error {
@@ -523,7 +789,108 @@ func (s *Translator) annotateKindMatchers(stack *model.WalkStack, kindMatcher *m
 	}
 }
 
+// kindComparisonKinds returns the kinds named by the right operand of a comparison against the kinds of an entity
+func kindComparisonKinds(operand model.Expression) (graph.Kinds, bool) {
+	var value any
+
+	// The elements of a list literal are not annotated
+	switch typedOperand := operand.(type) {
+	case *pg.AnnotatedLiteral:
+		value = typedOperand.Value
+
+	case *model.Literal:
+		value = typedOperand.Value
+
+	default:
+		return nil, false
+	}
+
+	switch typedValue := value.(type) {
+	case string:
+		// Strip the wrapping single quotes first
+		return graph.Kinds{graph.StringKind(typedValue[1 : len(typedValue)-1])}, true
+
+	case *model.ListLiteral:
+		kinds := make(graph.Kinds, 0, len(*typedValue))
+
+		for _, element := range *typedValue {
+			if elementKinds, isKindName := kindComparisonKinds(element); !isKindName {
+				return nil, false
+			} else {
+				kinds = append(kinds, elementKinds...)
+			}
+		}
+
+		return kinds, true
+	}
+
+	return nil, false
+}
+
+// rewriteKindComparison rewrites comparisons of the result of the type and labels functions against kind names as kind
+// matchers. Kind matchers compare the stored kind IDs directly instead of looking up the name of each kind.
+func (s *Translator) rewriteKindComparison(stack *model.WalkStack, comparison *model.Comparison) (bool, error) {
+	if len(comparison.Partials) != 1 {
+		return false, nil
+	}
+
+	var (
+		partial              = comparison.Partials[0]
+		functionInvocation   *model.FunctionInvocation
+		kindOperand          model.Expression
+		isFunctionInvocation bool
+	)
+
+	switch partial.Operator {
+	case model.OperatorEquals:
+		// type(r) = 'Kind'
+		functionInvocation, isFunctionInvocation = comparison.Left.(*model.FunctionInvocation)
+		kindOperand = partial.Right
+
+		if !isFunctionInvocation || functionInvocation.Name != cypherEdgeTypeFunction {
+			return false, nil
+		}
+
+	case model.OperatorIn:
+		// type(r) in ['KindA', 'KindB'] or 'Kind' in labels(n)
+		if functionInvocation, isFunctionInvocation = comparison.Left.(*model.FunctionInvocation); isFunctionInvocation && functionInvocation.Name == cypherEdgeTypeFunction {
+			kindOperand = partial.Right
+		} else if functionInvocation, isFunctionInvocation = partial.Right.(*model.FunctionInvocation); isFunctionInvocation && functionInvocation.Name == cypherNodeLabelsFunction {
+			kindOperand = comparison.Left
+		} else {
+			return false, nil
+		}
+
+	default:
+		return false, nil
+	}
+
+	kinds, isKindName := kindComparisonKinds(kindOperand)
+
+	if !isKindName {
+		return false, nil
+	}
+
+	variable, isVariable := functionInvocation.Arguments[0].(*model.Variable)
+
+	if !isVariable {
+		return false, fmt.Errorf("expected a variable as the first argument in %s function", functionInvocation.Name)
+	}
+
+	annotatedVariable, isBound := s.Bindings.LookupVariable(variable.Symbol)
+
+	if !isBound {
+		return false, fmt.Errorf("variable %s for function %s is not bound", variable.Symbol, functionInvocation.Name)
+	}
+
+	return true, rewrite(stack, comparison, pg.NewAnnotatedKindMatcher(model.NewKindMatcher(annotatedVariable, kinds), annotatedVariable.Type))
+}
+
 func (s *Translator) rewriteComparison(stack *model.WalkStack, comparison *model.Comparison) (bool, error) {
+	if rewritten, err := s.rewriteKindComparison(stack, comparison); err != nil || rewritten {
+		return rewritten, err
+	}
+
 	// Is this a property lookup comparison?
 	switch typedLeftOperand := comparison.Left.(type) {
 	case *model.PropertyLookup:
@@ -537,35 +904,16 @@ func (s *Translator) rewriteComparison(stack *model.WalkStack, comparison *model
 					// This is a null check for a property and must be rewritten for SQL
 					switch comparisonPartial.Operator {
 					case model.OperatorIsNot:
-						if leftOperandVariable, isVariable := typedLeftOperand.Atom.(*model.Variable); !isVariable {
-							return false, fmt.Errorf("unexpected expression as left operand %T", typedLeftOperand.Atom)
-						} else if leftOperandTypedVariable, isBound := s.Bindings.LookupVariable(leftOperandVariable.Symbol); !isBound {
-							return false, fmt.Errorf("left operand varaible %s is not bound", leftOperandTypedVariable.Symbol)
-						} else if err := rewrite(stack, comparison, model.NewComparison(
-							&pg.PropertiesReference{
-								// TODO: Might need a copy?
-								Reference: leftOperandTypedVariable,
-							},
-							OperatorJSONBFieldExists,
-							pg.NewStringLiteral(typedLeftOperand.Symbols[0]),
-						)); err != nil {
+						if propertyExistsComparison, err := s.newPropertyExistsComparison(typedLeftOperand); err != nil {
+							return false, err
+						} else if err := rewrite(stack, comparison, propertyExistsComparison); err != nil {
 							return false, err
 						}
 
 					case model.OperatorIs:
-						if leftOperandVariable, isVariable := typedLeftOperand.Atom.(*model.Variable); !isVariable {
-							return false, fmt.Errorf("unexpected expression as left operand %T", typedLeftOperand.Atom)
-						} else if leftOperandTypedVariable, isBound := s.Bindings.LookupVariable(leftOperandVariable.Symbol); !isBound {
-							return false, fmt.Errorf("left operand varaible %s is not bound", leftOperandTypedVariable.Symbol)
-						} else if err := rewrite(stack, comparison, model.NewNegation(
-							model.NewComparison(
-								&pg.PropertiesReference{
-									Reference: leftOperandTypedVariable,
-								},
-								OperatorJSONBFieldExists,
-								pg.NewStringLiteral(typedLeftOperand.Symbols[0]),
-							)),
-						); err != nil {
+						if propertyExistsComparison, err := s.newPropertyExistsComparison(typedLeftOperand); err != nil {
+							return false, err
+						} else if err := rewrite(stack, comparison, model.NewNegation(propertyExistsComparison)); err != nil {
 							return false, err
 						}
 					}
@@ -772,6 +1120,31 @@ func (s *Translator) annotateComparisons(stack *model.WalkStack, comparison *mod
 				return fmt.Errorf("comparison contains mixed types: %s and %s", typeAnnotation.Type, typedNode.Type)
 			}
 
+		case *model.Parenthetical:
+			comparisonWalkStack = append(comparisonWalkStack, typedNode.Expression)
+
+		case *model.ArithmeticExpression:
+			comparisonWalkStack = append(comparisonWalkStack, typedNode.Left)
+
+			for _, partial := range typedNode.Partials {
+				// Durations offset temporal values without changing their type
+				if functionInvocation, isFunctionInvocation := partial.Right.(*model.FunctionInvocation); !isFunctionInvocation || functionInvocation.Name != cypherDurationFunction {
+					comparisonWalkStack = append(comparisonWalkStack, partial.Right)
+				}
+			}
+
+		case *model.PropertyLookup:
+			// Temporal accessors, such as datetime().epochseconds, are integers
+			if isTemporalExpression(typedNode.Atom) {
+				if typeAnnotation == nil {
+					typeAnnotation = &pg.SQLTypeAnnotation{
+						Type: pg.Int8,
+					}
+				} else if typeAnnotation.Type != pg.Int8 {
+					return fmt.Errorf("comparison contains mixed types: %s and %s", typeAnnotation.Type, pg.Int8)
+				}
+			}
+
 		case *model.FunctionInvocation:
 			var functionInvocationTypeAnnotation *pg.SQLTypeAnnotation
 
@@ -806,6 +1179,14 @@ func (s *Translator) annotateComparisons(stack *model.WalkStack, comparison *mod
 					Type: pg.Interval,
 				}
 
+			case cypherToLowerFunction, cypherToUpperFunction, cypherTrimFunction, cypherReplaceFunction, cypherSubstringFunction, cypherToStringFunction:
+				functionInvocationTypeAnnotation = &pg.SQLTypeAnnotation{
+					Type: pg.Text,
+				}
+
+			case cypherCountFunction, cypherSizeFunction, cypherLengthFunction, cypherToIntegerFunction, cypherToFloatFunction, cypherSplitFunction, cypherKeysFunction:
+				// The result type of these functions does not depend on the type of their arguments
+
 			default:
 				// If we couldn't figure out a type from the function name then inspect the function's argument list
 				comparisonWalkStack = append(comparisonWalkStack, typedNode.Arguments...)
@@ -918,7 +1299,69 @@ func (s *Translator) rewriteStringNegations(stack *model.WalkStack, negation *mo
 	return nil
 }
 
+// functionArgumentTypes contains the types that the property lookup arguments of a function are annotated with.
+// Property lookups that are not annotated are written as jsonb values.
+var functionArgumentTypes = map[string][]pg.DataType{
+	cypherToLowerFunction:   {pg.Text},
+	cypherToUpperFunction:   {pg.Text},
+	cypherTrimFunction:      {pg.Text},
+	cypherSplitFunction:     {pg.Text, pg.Text},
+	cypherReplaceFunction:   {pg.Text, pg.Text, pg.Text},
+	cypherSubstringFunction: {pg.Text, pg.Int8, pg.Int8},
+	cypherToIntegerFunction: {pg.Text},
+	cypherToFloatFunction:   {pg.Text},
+	cypherAbsFunction:       {pg.Float8},
+	cypherMinFunction:       {pg.Float8},
+	cypherMaxFunction:       {pg.Float8},
+	cypherSumFunction:       {pg.Float8},
+	cypherAvgFunction:       {pg.Float8},
+}
+
+// annotateFunctionArgument annotates a variable argument of a function with its binding type. Graph entities are
+// written as their composite types.
+func (s *Translator) annotateFunctionArgument(functionInvocation *model.FunctionInvocation, argument model.Expression) (model.Expression, error) {
+	if variable, isVariable := argument.(*model.Variable); !isVariable {
+		return argument, nil
+	} else if bindingType, isBound := s.Bindings.BindingType(variable.Symbol); !isBound {
+		return nil, fmt.Errorf("variable %s for function %s is not bound", variable.Symbol, functionInvocation.Name)
+	} else {
+		switch bindingType {
+		case pg.Node, pg.Edge, pg.EdgeArray, pg.Path:
+			return pg.NewEntity(pg.NewAnnotatedVariable(variable, bindingType)), nil
+
+		default:
+			return pg.NewAnnotatedVariable(variable, bindingType), nil
+		}
+	}
+}
+
+// newPropertyExistsComparison creates a comparison that checks if the properties of an entity contain the key of the
+// given property lookup
+func (s *Translator) newPropertyExistsComparison(propertyLookup *model.PropertyLookup) (*model.Comparison, error) {
+	if variable, isVariable := propertyLookup.Atom.(*model.Variable); !isVariable {
+		return nil, fmt.Errorf("unexpected expression as property lookup atom %T", propertyLookup.Atom)
+	} else if annotatedVariable, isBound := s.Bindings.LookupVariable(variable.Symbol); !isBound {
+		return nil, fmt.Errorf("property lookup variable %s is not bound", variable.Symbol)
+	} else {
+		return model.NewComparison(
+			&pg.PropertiesReference{
+				Reference: annotatedVariable,
+			},
+			OperatorJSONBFieldExists,
+			pg.NewStringLiteral(propertyLookup.Symbols[0]),
+		), nil
+	}
+}
+
 func (s *Translator) rewriteFunctionInvocations(stack *model.WalkStack, functionInvocation *model.FunctionInvocation) error {
+	if argumentTypes, hasArgumentTypes := functionArgumentTypes[functionInvocation.Name]; hasArgumentTypes {
+		for idx, argument := range functionInvocation.Arguments {
+			if propertyLookup, isPropertyLookup := argument.(*model.PropertyLookup); isPropertyLookup && idx < len(argumentTypes) {
+				functionInvocation.Arguments[idx] = pg.NewAnnotatedPropertyLookup(propertyLookup, argumentTypes[idx])
+			}
+		}
+	}
+
 	switch functionInvocation.Name {
 	case cypherNodeLabelsFunction:
 		switch typedArgument := functionInvocation.Arguments[0].(type) {
@@ -944,10 +1387,69 @@ func (s *Translator) rewriteFunctionInvocations(stack *model.WalkStack, function
 			return fmt.Errorf("expected a variable as the first argument in %s function", functionInvocation.Name)
 		}
 
-	case cypherToLowerFunction:
-		switch typedArgument := functionInvocation.Arguments[0].(type) {
-		case *model.PropertyLookup:
-			functionInvocation.Arguments[0] = pg.NewAnnotatedPropertyLookup(typedArgument, pg.Text)
+	case cypherCollectFunction, cypherSizeFunction, cypherLengthFunction, cypherHeadFunction, cypherLastFunction, cypherNodesFunction, cypherRelationshipsFunction:
+		// The translation of these functions depends on the type of their argument
+		if len(functionInvocation.Arguments) != 1 {
+			return fmt.Errorf("expected a single argument for function %s", functionInvocation.Name)
+		} else if annotatedArgument, err := s.annotateFunctionArgument(functionInvocation, functionInvocation.Arguments[0]); err != nil {
+			return err
+		} else {
+			functionInvocation.Arguments[0] = annotatedArgument
+		}
+
+	case cypherKeysFunction:
+		if len(functionInvocation.Arguments) != 1 {
+			return fmt.Errorf("expected a single argument for function %s", functionInvocation.Name)
+		} else if variable, isVariable := functionInvocation.Arguments[0].(*model.Variable); !isVariable {
+			return fmt.Errorf("expected a variable as the first argument in %s function", functionInvocation.Name)
+		} else if annotatedVariable, isBound := s.Bindings.LookupVariable(variable.Symbol); !isBound {
+			return fmt.Errorf("variable %s for function %s is not bound", variable.Symbol, functionInvocation.Name)
+		} else {
+			functionInvocation.Arguments[0] = &pg.PropertiesReference{
+				Reference: annotatedVariable,
+			}
+		}
+
+	case cypherToStringFunction:
+		// Property lookups are converted to text by the jsonb text operator
+		if propertyLookup, isPropertyLookup := functionInvocation.Arguments[0].(*model.PropertyLookup); isPropertyLookup {
+			return rewrite(stack, functionInvocation, pg.NewAnnotatedPropertyLookup(propertyLookup, pg.Text))
+		}
+
+	case cypherExistsFunction:
+		// Pattern predicates are translated to subqueries that are already existence checks
+		if propertyLookup, isPropertyLookup := functionInvocation.Arguments[0].(*model.PropertyLookup); isPropertyLookup {
+			if propertyExistsComparison, err := s.newPropertyExistsComparison(propertyLookup); err != nil {
+				return err
+			} else {
+				return rewrite(stack, functionInvocation, propertyExistsComparison)
+			}
+		}
+
+	case cypherCoalesceFunction:
+		// Property lookup arguments must share the type of the first typed argument
+		var argumentType pg.DataType
+
+		for _, argument := range functionInvocation.Arguments {
+			switch typedArgument := argument.(type) {
+			case *pg.AnnotatedLiteral:
+				if !typedArgument.Null && argumentType == "" {
+					argumentType = typedArgument.Type
+				}
+
+			case *pg.AnnotatedParameter:
+				if argumentType == "" {
+					argumentType = typedArgument.Type
+				}
+			}
+		}
+
+		if argumentType != "" {
+			for idx, argument := range functionInvocation.Arguments {
+				if propertyLookup, isPropertyLookup := argument.(*model.PropertyLookup); isPropertyLookup {
+					functionInvocation.Arguments[idx] = pg.NewAnnotatedPropertyLookup(propertyLookup, argumentType)
+				}
+			}
 		}
 	}
 
@@ -1075,6 +1577,9 @@ func Translate(regularQuery *model.RegularQuery, kindMapper KindMapper) (map[str
 
 	// Rewrite phase
 	if err := analyzer.Analyze(regularQuery, func(analyzerInst *analyzer.Analyzer) {
+		analyzer.WithVisitor(analyzerInst, rewriter.enterSingleQuery)
+		analyzer.WithVisitor(analyzerInst, rewriter.scopeWith)
+		analyzer.WithVisitor(analyzerInst, rewriter.scopeUnwind)
 		analyzer.WithVisitor(analyzerInst, rewriter.rewriteStringNegations)
 		analyzer.WithVisitor(analyzerInst, rewriter.annotateProjectionItems)
 		analyzer.WithVisitor(analyzerInst, rewriter.validatePropertyLookups)
@@ -17,6 +17,8 @@
 package frontend
 
 import (
+	"strings"
+
 	"github.com/specterops/bloodhound/cypher/model"
 	"github.com/specterops/bloodhound/cypher/parser"
 )
@@ -189,6 +191,19 @@ func (s *AtomVisitor) ExitOC_Literal(ctx *parser.OC_LiteralContext) {
 	}
 }
 
+func (s *AtomVisitor) EnterOC_Parameter(ctx *parser.OC_ParameterContext) {
+	s.ctx.Enter(&SymbolicNameOrReservedWordVisitor{})
+}
+
+func (s *AtomVisitor) ExitOC_Parameter(ctx *parser.OC_ParameterContext) {
+	// Parameters may be referenced by index (e.g. $1) in which case there is no symbolic name to visit
+	if symbolicName := s.ctx.Exit().(*SymbolicNameOrReservedWordVisitor).Name; symbolicName == "" {
+		s.Atom = model.NewParameter(strings.TrimPrefix(ctx.GetText(), "$"), nil)
+	} else {
+		s.Atom = model.NewParameter(symbolicName, nil)
+	}
+}
+
 func (s *AtomVisitor) EnterOC_Variable(ctx *parser.OC_VariableContext) {
 	s.ctx.Enter(&SymbolicNameOrReservedWordVisitor{})
 }
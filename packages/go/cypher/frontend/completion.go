@@ -0,0 +1,175 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package frontend
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/specterops/bloodhound/cypher/parser"
+)
+
+// CompletionTarget is the kind of symbol that is expected at a position in a cypher query
+type CompletionTarget string
+
+const (
+	CompletionTargetNone             CompletionTarget = "none"
+	CompletionTargetNodeKind         CompletionTarget = "node_kind"
+	CompletionTargetRelationshipKind CompletionTarget = "relationship_kind"
+	CompletionTargetProperty         CompletionTarget = "property"
+	CompletionTargetExpression       CompletionTarget = "expression"
+)
+
+// Completion describes what may be written at a position in a cypher query. Prefix is the partially written symbol that
+// ends at the position and PrefixStart is the character offset that it starts at.
+type Completion struct {
+	Target      CompletionTarget
+	Prefix      string
+	PrefixStart int
+}
+
+// Keywords contains the cypher keywords that may be completed where an expression or clause is expected
+var Keywords = []string{
+	"AND", "AS", "ASC", "BY", "CONTAINS", "CREATE", "DELETE", "DESC", "DETACH", "DISTINCT", "ENDS", "FALSE", "IN", "IS",
+	"LIMIT", "MATCH", "MERGE", "NOT", "NULL", "OPTIONAL", "OR", "ORDER", "REMOVE", "RETURN", "SET", "SKIP", "STARTS",
+	"TRUE", "UNION", "UNWIND", "WHERE", "WITH", "XOR",
+}
+
+var symbolicNamePattern = regexp.MustCompile(`^[\p{L}_][\p{L}\p{N}_]*$`)
+
+func isInsignificantToken(token antlr.Token) bool {
+	switch token.GetTokenType() {
+	case parser.CypherLexerSP, parser.CypherLexerWHITESPACE:
+		return true
+	default:
+		return false
+	}
+}
+
+// previousSignificantToken returns the index of the last token before the given index that is not whitespace or -1
+// if there is no such token
+func previousSignificantToken(tokens []antlr.Token, index int) int {
+	for idx := index - 1; idx >= 0; idx-- {
+		if !isInsignificantToken(tokens[idx]) {
+			return idx
+		}
+	}
+
+	return -1
+}
+
+// enclosingBracket returns the index of the innermost bracket, brace or parenthesis before the given index that has
+// not been closed or -1 if there is none
+func enclosingBracket(tokens []antlr.Token, index int) int {
+	depth := 0
+
+	for idx := index - 1; idx >= 0; idx-- {
+		switch tokens[idx].GetText() {
+		case ")", "]", "}":
+			depth++
+
+		case "(", "[", "{":
+			if depth == 0 {
+				return idx
+			}
+
+			depth--
+		}
+	}
+
+	return -1
+}
+
+// isRelationshipPatternBracket returns true if the token at the given index opens the detail of a relationship pattern
+func isRelationshipPatternBracket(tokens []antlr.Token, index int) bool {
+	if index < 0 || tokens[index].GetText() != "[" {
+		return false
+	}
+
+	previous := previousSignificantToken(tokens, index)
+	return previous >= 0 && tokens[previous].GetText() == "-"
+}
+
+// CompletionAt describes what may be written at the given character offset of the given cypher query. Only the text
+// before the offset is considered so the remainder of the query may be incomplete or invalid.
+func CompletionAt(input string, offset int) (Completion, error) {
+	runes := []rune(input)
+
+	if offset < 0 || offset > len(runes) {
+		return Completion{}, fmt.Errorf("offset %d is outside of the query", offset)
+	}
+
+	var (
+		ctx        = NewContext()
+		lexer      = parser.NewCypherLexer(antlr.NewInputStream(string(runes[:offset])))
+		completion = Completion{
+			Target:      CompletionTargetExpression,
+			PrefixStart: offset,
+		}
+	)
+
+	lexer.RemoveErrorListeners()
+	lexer.AddErrorListener(ctx)
+
+	tokens := lexer.GetAllTokens()
+
+	// Lexer errors are caused by incomplete strings and escaped names which have no completions
+	if len(ctx.Errors) > 0 {
+		completion.Target = CompletionTargetNone
+		return completion, nil
+	}
+
+	last := len(tokens)
+
+	if last > 0 {
+		switch lastToken := tokens[last-1]; {
+		case lastToken.GetTokenType() == parser.CypherLexerComment || lastToken.GetTokenType() == parser.CypherLexerStringLiteral:
+			completion.Target = CompletionTargetNone
+			return completion, nil
+
+		case symbolicNamePattern.MatchString(lastToken.GetText()):
+			completion.Prefix = lastToken.GetText()
+			completion.PrefixStart = lastToken.GetStart()
+			last--
+		}
+	}
+
+	if previous := previousSignificantToken(tokens, last); previous >= 0 {
+		switch tokens[previous].GetText() {
+		case ":", "|":
+			if enclosing := enclosingBracket(tokens, previous); isRelationshipPatternBracket(tokens, enclosing) {
+				completion.Target = CompletionTargetRelationshipKind
+			} else if tokens[previous].GetText() == ":" && (enclosing < 0 || tokens[enclosing].GetText() == "(") {
+				completion.Target = CompletionTargetNodeKind
+			}
+
+		case ".":
+			completion.Target = CompletionTargetProperty
+
+		case "{", ",":
+			if enclosing := enclosingBracket(tokens, last); enclosing >= 0 && tokens[enclosing].GetText() == "{" {
+				completion.Target = CompletionTargetProperty
+			}
+
+		case "$":
+			completion.Target = CompletionTargetNone
+		}
+	}
+
+	return completion, nil
+}
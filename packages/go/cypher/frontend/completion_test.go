@@ -0,0 +1,67 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package frontend_test
+
+import (
+	"testing"
+
+	"github.com/specterops/bloodhound/cypher/frontend"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompletionAt(t *testing.T) {
+	testCases := []struct {
+		Query    string
+		Expected frontend.Completion
+	}{
+		{"", frontend.Completion{Target: frontend.CompletionTargetExpression}},
+		{"mat", frontend.Completion{Target: frontend.CompletionTargetExpression, Prefix: "mat"}},
+		{"match (n:", frontend.Completion{Target: frontend.CompletionTargetNodeKind, PrefixStart: 9}},
+		{"match (n:Us", frontend.Completion{Target: frontend.CompletionTargetNodeKind, Prefix: "Us", PrefixStart: 9}},
+		{"match (n:User:Gr", frontend.Completion{Target: frontend.CompletionTargetNodeKind, Prefix: "Gr", PrefixStart: 14}},
+		{"match (n) where n:Comp", frontend.Completion{Target: frontend.CompletionTargetNodeKind, Prefix: "Comp", PrefixStart: 18}},
+		{"match (n)-[:Mem", frontend.Completion{Target: frontend.CompletionTargetRelationshipKind, Prefix: "Mem", PrefixStart: 12}},
+		{"match (n)<-[r:MemberOf|Has", frontend.Completion{Target: frontend.CompletionTargetRelationshipKind, Prefix: "Has", PrefixStart: 23}},
+		{"match (n) where n.na", frontend.Completion{Target: frontend.CompletionTargetProperty, Prefix: "na", PrefixStart: 18}},
+		{"match (n {obj", frontend.Completion{Target: frontend.CompletionTargetProperty, Prefix: "obj", PrefixStart: 10}},
+		{"match (n {objectid: '1', ", frontend.Completion{Target: frontend.CompletionTargetProperty, PrefixStart: 25}},
+		{"match (n {objectid: ", frontend.Completion{Target: frontend.CompletionTargetExpression, PrefixStart: 20}},
+		{"match (n) where n.name = 'us", frontend.Completion{Target: frontend.CompletionTargetNone, PrefixStart: 28}},
+		{"match (n) where n.name = 'user' ret", frontend.Completion{Target: frontend.CompletionTargetExpression, Prefix: "ret", PrefixStart: 32}},
+		{"match (n) where n.name = $na", frontend.Completion{Target: frontend.CompletionTargetNone, Prefix: "na", PrefixStart: 26}},
+		{"match (n) return [x in collect(n) | x.", frontend.Completion{Target: frontend.CompletionTargetProperty, PrefixStart: 38}},
+	}
+
+	for _, testCase := range testCases {
+		completion, err := frontend.CompletionAt(testCase.Query, len([]rune(testCase.Query)))
+		require.Nil(t, err, testCase.Query)
+
+		if testCase.Expected.PrefixStart == 0 && testCase.Expected.Prefix == "" {
+			testCase.Expected.PrefixStart = len([]rune(testCase.Query))
+		}
+
+		require.Equal(t, testCase.Expected, completion, testCase.Query)
+	}
+
+	// Only the text before the offset is considered
+	completion, err := frontend.CompletionAt("match (n:Us) return n", 11)
+	require.Nil(t, err)
+	require.Equal(t, frontend.Completion{Target: frontend.CompletionTargetNodeKind, Prefix: "Us", PrefixStart: 9}, completion)
+
+	_, err = frontend.CompletionAt("match (n) return n", 19)
+	require.NotNil(t, err)
+}
@@ -23,12 +23,32 @@ import (
 // TODO: Review if relying on a deny model is less secure than explicit allow
 type UnsupportedOperationFilter struct {
 	BaseVisitor
+
+	allowParameters bool
+	allowUpdates    bool
 }
 
 func NewUnsupportedOperationFilter() Visitor {
 	return &UnsupportedOperationFilter{}
 }
 
+// NewParameterizedOperationFilter returns an UnsupportedOperationFilter that accepts user-specified parameters. Callers
+// are responsible for supplying a value for each parameter the query references.
+func NewParameterizedOperationFilter() Visitor {
+	return &UnsupportedOperationFilter{
+		allowParameters: true,
+	}
+}
+
+// NewMutationOperationFilter returns an UnsupportedOperationFilter that accepts user-specified parameters and updating
+// clauses. Callers are responsible for authorizing the mutation of the graph before running the query.
+func NewMutationOperationFilter() Visitor {
+	return &UnsupportedOperationFilter{
+		allowParameters: true,
+		allowUpdates:    true,
+	}
+}
+
 func (s *UnsupportedOperationFilter) EnterOC_ExplicitProcedureInvocation(ctx *parser.OC_ExplicitProcedureInvocationContext) {
 	s.ctx.AddErrors(ErrProcedureInvocationNotSupported)
 }
@@ -38,9 +58,17 @@ func (s *UnsupportedOperationFilter) EnterOC_ImplicitProcedureInvocation(ctx *pa
 }
 
 func (s *UnsupportedOperationFilter) EnterOC_Parameter(ctx *parser.OC_ParameterContext) {
-	s.ctx.AddErrors(ErrUserSpecifiedParametersNotSupported)
+	if !s.allowParameters {
+		s.ctx.AddErrors(ErrUserSpecifiedParametersNotSupported)
+	}
 }
 
 func (s *UnsupportedOperationFilter) EnterOC_UpdatingClause(ctx *parser.OC_UpdatingClauseContext) {
-	s.ctx.AddErrors(ErrUpdateClauseNotSupported)
+	if !s.allowUpdates {
+		s.ctx.AddErrors(ErrUpdateClauseNotSupported)
+	}
+}
+
+// EnterOC_Union accepts unions. The queries of a union are validated against each other when the query model is built.
+func (s *UnsupportedOperationFilter) EnterOC_Union(ctx *parser.OC_UnionContext) {
 }
@@ -19,9 +19,36 @@ package frontend_test
 import (
 	"testing"
 
+	"github.com/specterops/bloodhound/cypher/frontend"
 	"github.com/specterops/bloodhound/cypher/test"
+	"github.com/stretchr/testify/require"
 )
 
 func TestUnsupportedOperationFilter(t *testing.T) {
 	test.LoadFixture(t, test.FilteringTestCases).Run(t)
 }
+
+func TestParameterizedOperationFilter(t *testing.T) {
+	queryModel, err := frontend.ParseCypher(frontend.ParameterizedCypherContext(), "match (b) where b.name = $name return b")
+	require.Nil(t, err)
+	require.NotNil(t, queryModel)
+
+	_, err = frontend.ParseCypher(frontend.ParameterizedCypherContext(), "match (b) where b.name = $name set b.prop = false return b")
+	require.ErrorIs(t, err, frontend.ErrUpdateClauseNotSupported)
+
+	_, err = frontend.ParseCypher(frontend.ParameterizedCypherContext(), "call io.specterops.blow_up_the_world($name)")
+	require.ErrorIs(t, err, frontend.ErrProcedureInvocationNotSupported)
+}
+
+func TestMutationOperationFilter(t *testing.T) {
+	queryModel, err := frontend.ParseCypher(frontend.MutationCypherContext(), "match (b) where b.name = $name set b.owned = true return b")
+	require.Nil(t, err)
+	require.NotNil(t, queryModel)
+
+	queryModel, err = frontend.ParseCypher(frontend.MutationCypherContext(), "match (c:Computer)-[r:HasSession]->(u:User) where c.name = $computer delete r")
+	require.Nil(t, err)
+	require.NotNil(t, queryModel)
+
+	_, err = frontend.ParseCypher(frontend.MutationCypherContext(), "call io.specterops.blow_up_the_world($name)")
+	require.ErrorIs(t, err, frontend.ErrProcedureInvocationNotSupported)
+}
@@ -32,6 +32,22 @@ func DefaultCypherContext() *Context {
 	)
 }
 
+// ParameterizedCypherContext returns a context that accepts the same queries as DefaultCypherContext along with
+// user-specified parameters
+func ParameterizedCypherContext() *Context {
+	return NewContext(
+		NewParameterizedOperationFilter(),
+	)
+}
+
+// MutationCypherContext returns a context that accepts the same queries as ParameterizedCypherContext along with
+// updating clauses
+func MutationCypherContext() *Context {
+	return NewContext(
+		NewMutationOperationFilter(),
+	)
+}
+
 func parseCypher(ctx *Context, input string) (*model.RegularQuery, error) {
 	var (
 		queryBuffer     = bytes.NewBufferString(input)
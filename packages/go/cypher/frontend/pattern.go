@@ -170,6 +170,9 @@ func (s *RelationshipPatternVisitor) EnterOC_RangeLiteral(ctx *parser.OC_RangeLi
 			case TokenTypeRange:
 				state = stateSecondIndex
 
+			case parser.CypherParserSP:
+				// Whitespace is permitted between the elements of a range literal
+
 			default:
 				s.ctx.AddErrors(fmt.Errorf("unexpected token in pattern range: %s", typedTokenLeaf.GetText()))
 			}
@@ -18,7 +18,9 @@ package frontend
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/specterops/bloodhound/cypher/model"
 	"github.com/specterops/bloodhound/cypher/parser"
@@ -379,9 +381,21 @@ func NewMultiPartQueryVisitor() *MultiPartQueryVisitor {
 	}
 }
 
-func (s *MultiPartQueryVisitor) EnterOC_ReadingClause(ctx *parser.OC_ReadingClauseContext) {
+func (s *MultiPartQueryVisitor) startPart() {
 	s.currentPart = model.NewMultiPartQueryPart()
 	s.Query.Parts = append(s.Query.Parts, s.currentPart)
+}
+
+// partClosed returns true if the current part has already been closed by a with clause, in which case any following
+// clause belongs to a new part.
+func (s *MultiPartQueryVisitor) partClosed() bool {
+	return s.currentPart != nil && s.currentPart.With != nil
+}
+
+func (s *MultiPartQueryVisitor) EnterOC_ReadingClause(ctx *parser.OC_ReadingClauseContext) {
+	if s.currentPart == nil || s.partClosed() {
+		s.startPart()
+	}
 
 	s.ctx.Enter(NewReadingClauseVisitor())
 }
@@ -395,6 +409,10 @@ func (s *MultiPartQueryVisitor) ExitOC_ReadingClause(ctx *parser.OC_ReadingClaus
 }
 
 func (s *MultiPartQueryVisitor) EnterOC_UpdatingClause(ctx *parser.OC_UpdatingClauseContext) {
+	if s.partClosed() {
+		s.startPart()
+	}
+
 	s.ctx.Enter(NewUpdatingClauseVisitor())
 }
 
@@ -407,6 +425,10 @@ func (s *MultiPartQueryVisitor) ExitOC_UpdatingClause(ctx *parser.OC_UpdatingCla
 }
 
 func (s *MultiPartQueryVisitor) EnterOC_With(ctx *parser.OC_WithContext) {
+	if s.partClosed() {
+		s.startPart()
+	}
+
 	s.ctx.Enter(NewWithVisitor())
 }
 
@@ -429,7 +451,8 @@ func (s *MultiPartQueryVisitor) ExitOC_SinglePartQuery(ctx *parser.OC_SinglePart
 type QueryVisitor struct {
 	BaseVisitor
 
-	Query *model.RegularQuery
+	currentQuery *model.SingleQuery
+	Query        *model.RegularQuery
 }
 
 func (s *QueryVisitor) EnterOC_Cypher(ctx *parser.OC_CypherContext) {
@@ -463,8 +486,82 @@ func (s *QueryVisitor) EnterOC_RegularQuery(ctx *parser.OC_RegularQueryContext)
 func (s *QueryVisitor) ExitOC_RegularQuery(ctx *parser.OC_RegularQueryContext) {
 }
 
+func (s *QueryVisitor) EnterOC_Union(ctx *parser.OC_UnionContext) {
+	s.Query.Unions = append(s.Query.Unions, model.NewUnion(HasTokens(ctx, parser.CypherLexerALL)))
+}
+
+func (s *QueryVisitor) ExitOC_Union(ctx *parser.OC_UnionContext) {
+	// Unions must either all keep or all remove duplicate rows
+	if numUnions := len(s.Query.Unions); numUnions > 1 && s.Query.Unions[0].All != s.Query.Unions[numUnions-1].All {
+		s.newUnionError(ctx, "invalid combination of UNION and UNION ALL")
+	}
+
+	// Each query of a union must return the same columns as the first query
+	if regularQueryCtx, isRegularQuery := ctx.GetParent().(*parser.OC_RegularQueryContext); isRegularQuery {
+		firstColumns, firstHasColumns := returnColumnNames(regularQueryCtx.OC_SingleQuery())
+		unionColumns, unionHasColumns := returnColumnNames(ctx.OC_SingleQuery())
+
+		if firstHasColumns && unionHasColumns && !slices.Equal(firstColumns, unionColumns) {
+			s.newUnionError(ctx, fmt.Sprintf("all queries in a UNION must return the same column names: expected %s but got %s", strings.Join(firstColumns, ", "), strings.Join(unionColumns, ", ")))
+		}
+	}
+}
+
+func (s *QueryVisitor) newUnionError(ctx *parser.OC_UnionContext, message string) {
+	s.ctx.AddErrors(SyntaxError{
+		Line:            ctx.GetStart().GetLine(),
+		Column:          ctx.GetStart().GetColumn(),
+		OffendingSymbol: ctx.GetText(),
+		Message:         message,
+	})
+}
+
+// returnColumnNames returns the names of the columns returned by the given single query. Columns are named after the
+// binding of the projection or, if the projection has no binding, the text of the projection expression. Queries that
+// return all bound variables with a wildcard have no known column names.
+func returnColumnNames(ctx parser.IOC_SingleQueryContext) ([]string, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+
+	singlePartQueryCtx := ctx.OC_SinglePartQuery()
+
+	if multiPartQueryCtx := ctx.OC_MultiPartQuery(); multiPartQueryCtx != nil {
+		singlePartQueryCtx = multiPartQueryCtx.OC_SinglePartQuery()
+	}
+
+	if singlePartQueryCtx == nil || singlePartQueryCtx.OC_Return() == nil {
+		return nil, false
+	}
+
+	projectionItemsCtx := singlePartQueryCtx.OC_Return().OC_ProjectionBody().OC_ProjectionItems()
+
+	if projectionItemsCtx == nil || projectionItemsCtx.GetStart().GetText() == "*" {
+		return nil, false
+	}
+
+	var columnNames []string
+
+	for _, projectionItemCtx := range projectionItemsCtx.AllOC_ProjectionItem() {
+		if variableCtx := projectionItemCtx.OC_Variable(); variableCtx != nil {
+			columnNames = append(columnNames, variableCtx.GetText())
+		} else {
+			columnNames = append(columnNames, originalText(projectionItemCtx.OC_Expression()))
+		}
+	}
+
+	return columnNames, true
+}
+
 func (s *QueryVisitor) EnterOC_SingleQuery(ctx *parser.OC_SingleQueryContext) {
-	s.Query.SingleQuery = model.NewSingleQuery()
+	s.currentQuery = model.NewSingleQuery()
+
+	// Single queries that follow a union belong to it
+	if numUnions := len(s.Query.Unions); numUnions > 0 {
+		s.Query.Unions[numUnions-1].SingleQuery = s.currentQuery
+	} else {
+		s.Query.SingleQuery = s.currentQuery
+	}
 }
 
 func (s *QueryVisitor) ExitOC_SingleQuery(ctx *parser.OC_SingleQueryContext) {
@@ -475,7 +572,7 @@ func (s *QueryVisitor) EnterOC_MultiPartQuery(ctx *parser.OC_MultiPartQueryConte
 }
 
 func (s *QueryVisitor) ExitOC_MultiPartQuery(ctx *parser.OC_MultiPartQueryContext) {
-	s.Query.SingleQuery.MultiPartQuery = s.ctx.Exit().(*MultiPartQueryVisitor).Query
+	s.currentQuery.MultiPartQuery = s.ctx.Exit().(*MultiPartQueryVisitor).Query
 }
 
 func (s *QueryVisitor) EnterOC_SinglePartQuery(ctx *parser.OC_SinglePartQueryContext) {
@@ -483,7 +580,7 @@ func (s *QueryVisitor) EnterOC_SinglePartQuery(ctx *parser.OC_SinglePartQueryCon
 }
 
 func (s *QueryVisitor) ExitOC_SinglePartQuery(ctx *parser.OC_SinglePartQueryContext) {
-	s.Query.SingleQuery.SinglePartQuery = s.ctx.Exit().(*SinglePartQueryVisitor).Query
+	s.currentQuery.SinglePartQuery = s.ctx.Exit().(*SinglePartQueryVisitor).Query
 }
 
 type RemoveVisitor struct {
@@ -557,6 +654,51 @@ func (s *CreateVisitor) ExitOC_Pattern(ctx *parser.OC_PatternContext) {
 	s.Create.Pattern = s.ctx.Exit().(*PatternVisitor).PatternParts
 }
 
+type MergeActionVisitor struct {
+	BaseVisitor
+
+	MergeAction *model.MergeAction
+}
+
+func (s *MergeActionVisitor) EnterOC_Set(ctx *parser.OC_SetContext) {
+	s.ctx.Enter(&SetVisitor{
+		Set: &model.Set{},
+	})
+}
+
+func (s *MergeActionVisitor) ExitOC_Set(ctx *parser.OC_SetContext) {
+	s.MergeAction.Set = s.ctx.Exit().(*SetVisitor).Set
+}
+
+type MergeVisitor struct {
+	BaseVisitor
+
+	Merge *model.Merge
+}
+
+func (s *MergeVisitor) EnterOC_PatternPart(ctx *parser.OC_PatternPartContext) {
+	s.ctx.Enter(&PatternVisitor{
+		currentPart: &model.PatternPart{},
+	})
+}
+
+func (s *MergeVisitor) ExitOC_PatternPart(ctx *parser.OC_PatternPartContext) {
+	s.Merge.PatternPart = s.ctx.Exit().(*PatternVisitor).currentPart
+}
+
+func (s *MergeVisitor) EnterOC_MergeAction(ctx *parser.OC_MergeActionContext) {
+	s.ctx.Enter(&MergeActionVisitor{
+		MergeAction: &model.MergeAction{
+			OnCreate: HasTokens(ctx, parser.CypherLexerCREATE),
+			OnMatch:  HasTokens(ctx, parser.CypherLexerMATCH),
+		},
+	})
+}
+
+func (s *MergeVisitor) ExitOC_MergeAction(ctx *parser.OC_MergeActionContext) {
+	s.Merge.Actions = append(s.Merge.Actions, s.ctx.Exit().(*MergeActionVisitor).MergeAction)
+}
+
 type UpdatingClauseVisitor struct {
 	BaseVisitor
 
@@ -581,6 +723,16 @@ func (s *UpdatingClauseVisitor) ExitOC_Create(ctx *parser.OC_CreateContext) {
 	s.UpdatingClause.Clause = s.ctx.Exit().(*CreateVisitor).Create
 }
 
+func (s *UpdatingClauseVisitor) EnterOC_Merge(ctx *parser.OC_MergeContext) {
+	s.ctx.Enter(&MergeVisitor{
+		Merge: &model.Merge{},
+	})
+}
+
+func (s *UpdatingClauseVisitor) ExitOC_Merge(ctx *parser.OC_MergeContext) {
+	s.UpdatingClause.Clause = s.ctx.Exit().(*MergeVisitor).Merge
+}
+
 func (s *UpdatingClauseVisitor) EnterOC_Delete(ctx *parser.OC_DeleteContext) {
 	s.ctx.Enter(&DeleteVisitor{
 		Delete: &model.Delete{
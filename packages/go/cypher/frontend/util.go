@@ -31,6 +31,12 @@ type TokenProvider interface {
 	GetText() string
 }
 
+// originalText returns the text of the given rule as it was written in the query, including any whitespace between
+// its tokens
+func originalText(ctx antlr.ParserRuleContext) string {
+	return ctx.GetStart().GetInputStream().GetTextFromInterval(antlr.NewInterval(ctx.GetStart().GetStart(), ctx.GetStop().GetStop()))
+}
+
 func HasTokens(ctx TokenProvider, tokens ...int) bool {
 	for _, nextToken := range tokens {
 		if ctx.GetToken(nextToken, 0) == nil {
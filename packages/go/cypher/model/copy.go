@@ -50,6 +50,9 @@ func Copy[T any](value T, extensions ...CopyExtension[T]) T {
 	case *SingleQuery:
 		return any(typedValue.copy()).(T)
 
+	case *Union:
+		return any(typedValue.copy()).(T)
+
 	case *SinglePartQuery:
 		return any(typedValue.copy()).(T)
 
@@ -137,6 +140,12 @@ func Copy[T any](value T, extensions ...CopyExtension[T]) T {
 	case *Create:
 		return any(typedValue.copy()).(T)
 
+	case *Merge:
+		return any(typedValue.copy()).(T)
+
+	case *MergeAction:
+		return any(typedValue.copy()).(T)
+
 	case *KindMatcher:
 		return any(typedValue.copy()).(T)
 
@@ -215,6 +224,9 @@ func Copy[T any](value T, extensions ...CopyExtension[T]) T {
 	case []*SetItem:
 		return any(copySlice(typedValue)).(T)
 
+	case []*MergeAction:
+		return any(copySlice(typedValue)).(T)
+
 	case []*PatternElement:
 		return any(copySlice(typedValue)).(T)
 
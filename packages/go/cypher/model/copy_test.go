@@ -35,6 +35,13 @@ func int64Pointer(value int64) *int64 {
 func TestCopy(t *testing.T) {
 	validateCopy(t, &model.RegularQuery{})
 	validateCopy(t, &model.SingleQuery{})
+	validateCopy(t, &model.RegularQuery{
+		SingleQuery: &model.SingleQuery{},
+		Unions: []*model.Union{{
+			All:         true,
+			SingleQuery: &model.SingleQuery{},
+		}},
+	})
 	validateCopy(t, &model.SinglePartQuery{
 		ReadingClauses: []*model.ReadingClause{{
 			Match: &model.Match{
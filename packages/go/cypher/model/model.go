@@ -148,6 +148,7 @@ func (s *errorContext) Errors() []error {
 
 type RegularQuery struct {
 	SingleQuery *SingleQuery
+	Unions      []*Union
 }
 
 func NewRegularQuery() *RegularQuery {
@@ -161,6 +162,31 @@ func (s *RegularQuery) copy() *RegularQuery {
 
 	return &RegularQuery{
 		SingleQuery: Copy(s.SingleQuery),
+		Unions:      copySlice(s.Unions),
+	}
+}
+
+// Union combines the results of its single query with the results of the single queries that precede it. Unless All
+// is set, duplicate rows are removed from the combined results.
+type Union struct {
+	All         bool
+	SingleQuery *SingleQuery
+}
+
+func NewUnion(all bool) *Union {
+	return &Union{
+		All: all,
+	}
+}
+
+func (s *Union) copy() *Union {
+	if s == nil {
+		return nil
+	}
+
+	return &Union{
+		All:         s.All,
+		SingleQuery: Copy(s.SingleQuery),
 	}
 }
 
@@ -410,7 +436,7 @@ type UpdatingClause struct {
 	Clause Expression
 }
 
-func NewUpdatingClause[T *Delete | *Remove | *Set | *Create](clause T) *UpdatingClause {
+func NewUpdatingClause[T *Delete | *Remove | *Set | *Create | *Merge](clause T) *UpdatingClause {
 	return &UpdatingClause{
 		Clause: clause,
 	}
@@ -556,6 +582,48 @@ func (s *Create) copy() *Create {
 	}
 }
 
+type Merge struct {
+	PatternPart *PatternPart
+	Actions     []*MergeAction
+}
+
+func NewMerge() *Merge {
+	return &Merge{}
+}
+
+func (s *Merge) copy() *Merge {
+	if s == nil {
+		return nil
+	}
+
+	return &Merge{
+		PatternPart: Copy(s.PatternPart),
+		Actions:     Copy(s.Actions),
+	}
+}
+
+type MergeAction struct {
+	OnCreate bool
+	OnMatch  bool
+	Set      *Set
+}
+
+func NewMergeAction() *MergeAction {
+	return &MergeAction{}
+}
+
+func (s *MergeAction) copy() *MergeAction {
+	if s == nil {
+		return nil
+	}
+
+	return &MergeAction{
+		OnCreate: s.OnCreate,
+		OnMatch:  s.OnMatch,
+		Set:      Copy(s.Set),
+	}
+}
+
 type IDInCollection struct {
 	Variable   *Variable
 	Expression Expression
@@ -55,6 +55,11 @@ func CollectPGSQLTypes(nextCursor *model.WalkCursor, expression model.Expression
 		model.CollectSlice(nextCursor, typedExpression.PatternElements)
 		model.CollectExpression(nextCursor, typedExpression.Filter)
 
+	case *Expansion:
+		model.Collect(nextCursor, typedExpression.Binding)
+		model.Collect(nextCursor, typedExpression.Relationship)
+		model.CollectExpression(nextCursor, typedExpression.Criteria)
+
 	case *PropertyMutation:
 		model.Collect(nextCursor, typedExpression.Reference)
 		model.Collect(nextCursor, typedExpression.Removals)
@@ -68,13 +73,37 @@ func CollectPGSQLTypes(nextCursor *model.WalkCursor, expression model.Expression
 		model.Collect(nextCursor, typedExpression.Removals)
 		model.Collect(nextCursor, typedExpression.Additions)
 
+	case *NodeCreate:
+		model.Collect(nextCursor, typedExpression.Binding)
+		model.Collect(nextCursor, typedExpression.Kinds)
+		model.Collect(nextCursor, typedExpression.Properties)
+
+	case *EdgeCreate:
+		model.Collect(nextCursor, typedExpression.Binding)
+		model.Collect(nextCursor, typedExpression.Start)
+		model.Collect(nextCursor, typedExpression.End)
+		model.Collect(nextCursor, typedExpression.Kind)
+		model.Collect(nextCursor, typedExpression.Properties)
+
 	case *NodeKindsReference:
 		model.CollectExpression(nextCursor, typedExpression.Variable)
 
 	case *EdgeKindReference:
 		model.CollectExpression(nextCursor, typedExpression.Variable)
 
-	case *AnnotatedLiteral, *AnnotatedVariable, *AnnotatedParameter:
+	case *AnnotatedLiteral:
+		// List and map literals may contain expressions that must be translated, such as function invocations
+		switch typedValue := typedExpression.Value.(type) {
+		case *model.ListLiteral:
+			model.CollectExpressions(nextCursor, *typedValue)
+
+		case model.MapLiteral:
+			for _, key := range typedValue.Keys() {
+				model.CollectExpression(nextCursor, typedValue[key.(string)])
+			}
+		}
+
+	case *AnnotatedVariable, *AnnotatedParameter:
 		// Valid types but no descent
 
 	default:
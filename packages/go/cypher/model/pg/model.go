@@ -52,6 +52,7 @@ const (
 	Float4Array              DataType = "float4[]"
 	Float8                   DataType = "float8"
 	Float8Array              DataType = "float8[]"
+	Numeric                  DataType = "numeric"
 	Boolean                  DataType = "bool"
 	Text                     DataType = "text"
 	TextArray                DataType = "text[]"
@@ -204,6 +205,17 @@ type Subquery struct {
 	Filter          model.Expression
 }
 
+// Expansion replaces a variable-length relationship pattern. Expansions are translated to recursive common table
+// expressions where Criteria is evaluated against each traversed relationship, bound as Relationship.
+type Expansion struct {
+	Binding      *AnnotatedVariable
+	Relationship *AnnotatedVariable
+	Direction    graph.Direction
+	MinDepth     int64
+	MaxDepth     int64
+	Criteria     model.Expression
+}
+
 type SubQueryAnnotation struct {
 	FilterExpression model.Expression
 }
@@ -220,6 +232,11 @@ func NewSQLTypeAnnotationFromExpression(expression model.Expression) (*SQLTypeAn
 	case *model.Literal:
 		return NewSQLTypeAnnotationFromLiteral(typedExpression)
 
+	case *AnnotatedVariable:
+		return &SQLTypeAnnotation{
+			Type: typedExpression.Type,
+		}, nil
+
 	case *model.ListLiteral:
 		var expectedTypeAnnotation *SQLTypeAnnotation
 
@@ -235,6 +252,13 @@ func NewSQLTypeAnnotationFromExpression(expression model.Expression) (*SQLTypeAn
 			}
 		}
 
+		if expectedTypeAnnotation == nil {
+			// Lists of expressions other than literals, such as function invocations, have no known type
+			return &SQLTypeAnnotation{
+				Type: UnknownDataType,
+			}, nil
+		}
+
 		return expectedTypeAnnotation, nil
 
 	default:
@@ -338,6 +362,11 @@ func NewSQLTypeAnnotationFromValue(value any) (*SQLTypeAnnotation, error) {
 	case *model.ListLiteral:
 		return NewSQLTypeAnnotationFromExpression(typedValue)
 
+	case model.MapLiteral:
+		return &SQLTypeAnnotation{
+			Type: JSONB,
+		}, nil
+
 	default:
 		return nil, fmt.Errorf("literal type %T is not supported", value)
 	}
@@ -363,10 +392,14 @@ func NewEdgeKindReference(ref *AnnotatedVariable) *EdgeKindReference {
 	}
 }
 
+// Delete removes the entity bound by Binding for each row matched by the reading clauses that precede it. Deleting a
+// node always removes the edges attached to it. Detach is set when the query asked for this, otherwise the deletion
+// must be rejected if the node has edges.
 type Delete struct {
 	Binding    *AnnotatedVariable
 	NodeDelete bool
 	EdgeDelete bool
+	Detach     bool
 }
 
 func NewDelete() *Delete {
@@ -403,3 +436,23 @@ type KindMutation struct {
 	Additions *AnnotatedParameter
 	Removals  *AnnotatedParameter
 }
+
+// NodeCreate inserts a node for each row matched by the reading clauses that precede it. Merges only insert the node
+// if no node with the same kinds and properties exists.
+type NodeCreate struct {
+	Binding    *AnnotatedVariable
+	Kinds      *AnnotatedParameter
+	Properties *AnnotatedParameter
+	Merge      bool
+}
+
+// EdgeCreate inserts an edge between the start and end nodes of each row matched by the reading clauses that precede
+// it. Merges only insert the edge if no edge of the same kind and with the same properties connects the nodes.
+type EdgeCreate struct {
+	Binding    *AnnotatedVariable
+	Start      *AnnotatedVariable
+	End        *AnnotatedVariable
+	Kind       *AnnotatedParameter
+	Properties *AnnotatedParameter
+	Merge      bool
+}
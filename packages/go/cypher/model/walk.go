@@ -18,6 +18,8 @@ package model
 
 import (
 	"fmt"
+	"sort"
+
 	"github.com/specterops/bloodhound/dawgs/graph"
 )
 
@@ -161,6 +163,10 @@ func cypherModelCollect(nextCursor *WalkCursor, expression Expression) bool {
 
 	case *RegularQuery:
 		Collect(nextCursor, typedExpr.SingleQuery)
+		CollectSlice(nextCursor, typedExpr.Unions)
+
+	case *Union:
+		Collect(nextCursor, typedExpr.SingleQuery)
 
 	case *SingleQuery:
 		Collect(nextCursor, typedExpr.SinglePartQuery)
@@ -232,6 +238,13 @@ func cypherModelCollect(nextCursor *WalkCursor, expression Expression) bool {
 	case *Create:
 		CollectSlice(nextCursor, typedExpr.Pattern)
 
+	case *Merge:
+		Collect(nextCursor, typedExpr.PatternPart)
+		CollectSlice(nextCursor, typedExpr.Actions)
+
+	case *MergeAction:
+		Collect(nextCursor, typedExpr.Set)
+
 	case *Return:
 		Collect(nextCursor, typedExpr.Projection)
 
@@ -345,3 +358,43 @@ func Walk(root Expression, visitor Visitor, extensions ...CollectorFunc) error {
 
 	return nil
 }
+
+// BindParameters sets the value of every parameter referenced by the given query from the given map of values. An
+// error is returned if the query references a parameter that has no value.
+func BindParameters(root Expression, values map[string]any) error {
+	return Walk(root, NewVisitor(func(stack *WalkStack, branch Expression) error {
+		if parameter, isParameter := branch.(*Parameter); isParameter {
+			if value, hasValue := values[parameter.Symbol]; !hasValue {
+				return fmt.Errorf("no value supplied for query parameter $%s", parameter.Symbol)
+			} else {
+				parameter.Value = value
+			}
+		}
+
+		return nil
+	}, nil))
+}
+
+// ParameterSymbols returns the sorted and unique symbols of every parameter referenced by the given query
+func ParameterSymbols(root Expression) ([]string, error) {
+	seen := map[string]struct{}{}
+
+	if err := Walk(root, NewVisitor(func(stack *WalkStack, branch Expression) error {
+		if parameter, isParameter := branch.(*Parameter); isParameter {
+			seen[parameter.Symbol] = struct{}{}
+		}
+
+		return nil
+	}, nil)); err != nil {
+		return nil, err
+	}
+
+	symbols := make([]string, 0, len(seen))
+
+	for symbol := range seen {
+		symbols = append(symbols, symbol)
+	}
+
+	sort.Strings(symbols)
+	return symbols, nil
+}
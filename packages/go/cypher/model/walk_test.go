@@ -54,3 +54,45 @@ func TestWalk(t *testing.T) {
 		}
 	}
 }
+
+func TestBindParameters(t *testing.T) {
+	queryModel, err := frontend.ParseCypher(frontend.NewContext(), "match (n) where n.name = $name and n.objectid in $ids return n")
+	require.Nil(t, err)
+
+	require.ErrorContains(t, model.BindParameters(queryModel, map[string]any{"name": "lol"}), "no value supplied for query parameter $ids")
+	require.Nil(t, model.BindParameters(queryModel, map[string]any{
+		"name": "lol",
+		"ids":  []string{"1234"},
+	}))
+
+	var bound = map[string]any{}
+
+	require.Nil(t, model.Walk(queryModel, model.NewVisitor(func(stack *model.WalkStack, branch model.Expression) error {
+		if parameter, isParameter := branch.(*model.Parameter); isParameter {
+			bound[parameter.Symbol] = parameter.Value
+		}
+
+		return nil
+	}, nil)))
+
+	require.Equal(t, map[string]any{
+		"name": "lol",
+		"ids":  []string{"1234"},
+	}, bound)
+}
+
+func TestParameterSymbols(t *testing.T) {
+	queryModel, err := frontend.ParseCypher(frontend.NewContext(), "match (n) where n.name = $name and n.objectid in $ids and n.displayname = $name return n")
+	require.Nil(t, err)
+
+	symbols, err := model.ParameterSymbols(queryModel)
+	require.Nil(t, err)
+	require.Equal(t, []string{"ids", "name"}, symbols)
+
+	queryModel, err = frontend.ParseCypher(frontend.NewContext(), "match (n) return n")
+	require.Nil(t, err)
+
+	symbols, err = model.ParameterSymbols(queryModel)
+	require.Nil(t, err)
+	require.Empty(t, symbols)
+}
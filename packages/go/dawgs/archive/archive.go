@@ -0,0 +1,260 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package archive implements a driver-agnostic, portable snapshot format for DAWGS graphs.
+//
+// An archive is a gzip compressed stream of newline delimited JSON entries. The first entry is a Header that describes
+// the archive format and the kinds present in the graph. The header is followed by every node in the graph, then every
+// edge and finally a Footer that records how many nodes and edges were written. Node and edge IDs in an archive are
+// the IDs of the source graph and are only meaningful within the archive itself; Import remaps them to the IDs the
+// target database allocates.
+//
+// Property values are written as JSON. Values whose type does not survive a JSON round trip, such as timestamps, typed
+// arrays and integral floats, are recorded in the property types of their node or edge so that Import can restore them.
+package archive
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	Format = "dawgs-graph-archive"
+
+	// Version 2 added property types. Version 1 archives are still imported, however their property values are
+	// restored with the types the JSON decoder produces.
+	Version = 2
+
+	// FileExtension is the conventional extension for graph archives.
+	FileExtension = ".ndjson.gz"
+)
+
+// Property types recorded for values whose type would otherwise be lost when the archive is decoded.
+const (
+	PropertyTypeFloat       = "float"
+	PropertyTypeDateTime    = "datetime"
+	PropertyTypeStringArray = "string[]"
+	PropertyTypeIntArray    = "int[]"
+	PropertyTypeFloatArray  = "float[]"
+	PropertyTypeBoolArray   = "bool[]"
+)
+
+var (
+	ErrInvalidArchive = errors.New("invalid graph archive")
+	ErrGraphNotEmpty  = errors.New("graph archives may only be imported into an empty graph")
+)
+
+// Header is the first entry of an archive.
+type Header struct {
+	Format    string    `json:"format"`
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	NodeKinds []string  `json:"node_kinds"`
+	EdgeKinds []string  `json:"edge_kinds"`
+}
+
+// Node is an archived node.
+type Node struct {
+	ID            uint64            `json:"id"`
+	Kinds         []string          `json:"kinds"`
+	Properties    map[string]any    `json:"properties"`
+	PropertyTypes map[string]string `json:"property_types,omitempty"`
+}
+
+// Edge is an archived edge. StartID and EndID refer to the archived IDs of the edge's nodes.
+type Edge struct {
+	ID            uint64            `json:"id"`
+	StartID       uint64            `json:"start_id"`
+	EndID         uint64            `json:"end_id"`
+	Kind          string            `json:"kind"`
+	Properties    map[string]any    `json:"properties"`
+	PropertyTypes map[string]string `json:"property_types,omitempty"`
+}
+
+// Footer is the last entry of an archive.
+type Footer struct {
+	Nodes int64 `json:"nodes"`
+	Edges int64 `json:"edges"`
+}
+
+// Summary reports the number of nodes and edges written to or read from an archive.
+type Summary struct {
+	Nodes int64 `json:"nodes"`
+	Edges int64 `json:"edges"`
+}
+
+// entry is a single line of an archive. Exactly one of its fields is set.
+type entry struct {
+	Header *Header `json:"header,omitempty"`
+	Node   *Node   `json:"node,omitempty"`
+	Edge   *Edge   `json:"edge,omitempty"`
+	Footer *Footer `json:"footer,omitempty"`
+}
+
+// normalizeValue converts json.Number values produced by the archive decoder into int64 values where the number is
+// integral and float64 values otherwise.
+func normalizeValue(value any) any {
+	switch typedValue := value.(type) {
+	case json.Number:
+		if intValue, err := typedValue.Int64(); err == nil {
+			return intValue
+		} else if floatValue, err := typedValue.Float64(); err == nil {
+			return floatValue
+		}
+
+		return typedValue.String()
+
+	case []any:
+		for idx, element := range typedValue {
+			typedValue[idx] = normalizeValue(element)
+		}
+
+	case map[string]any:
+		for key, element := range typedValue {
+			typedValue[key] = normalizeValue(element)
+		}
+	}
+
+	return value
+}
+
+// propertyType returns the property type to record for the given value, if its type would otherwise be lost.
+func propertyType(value any) (string, bool) {
+	switch value.(type) {
+	case float32, float64:
+		return PropertyTypeFloat, true
+	case time.Time:
+		return PropertyTypeDateTime, true
+	case []string:
+		return PropertyTypeStringArray, true
+	case []int, []int8, []int16, []int32, []int64, []uint, []uint8, []uint16, []uint32, []uint64:
+		return PropertyTypeIntArray, true
+	case []float32, []float64:
+		return PropertyTypeFloatArray, true
+	case []bool:
+		return PropertyTypeBoolArray, true
+	default:
+		return "", false
+	}
+}
+
+// propertyTypes returns the property types to record for the given properties or nil if there are none.
+func propertyTypes(properties map[string]any) map[string]string {
+	var types map[string]string
+
+	for key, value := range properties {
+		if valueType, hasType := propertyType(value); hasType {
+			if types == nil {
+				types = map[string]string{}
+			}
+
+			types[key] = valueType
+		}
+	}
+
+	return types
+}
+
+func convertArray[T any](value any, convert func(element any) (T, bool)) ([]T, bool) {
+	if elements, isArray := value.([]any); isArray {
+		converted := make([]T, len(elements))
+
+		for idx, element := range elements {
+			if convertedElement, ok := convert(element); !ok {
+				return nil, false
+			} else {
+				converted[idx] = convertedElement
+			}
+		}
+
+		return converted, true
+	}
+
+	return nil, false
+}
+
+func toFloat(value any) (float64, bool) {
+	switch typedValue := value.(type) {
+	case int64:
+		return float64(typedValue), true
+	case float64:
+		return typedValue, true
+	default:
+		return 0, false
+	}
+}
+
+func isType[T any](value any) (T, bool) {
+	typedValue, ok := value.(T)
+	return typedValue, ok
+}
+
+// restoreValue converts a normalized value into the given property type.
+func restoreValue(value any, valueType string) (any, bool) {
+	switch valueType {
+	case PropertyTypeFloat:
+		return toFloat(value)
+
+	case PropertyTypeDateTime:
+		if formatted, isString := value.(string); !isString {
+			return nil, false
+		} else if parsed, err := time.Parse(time.RFC3339Nano, formatted); err != nil {
+			return nil, false
+		} else {
+			return parsed, true
+		}
+
+	case PropertyTypeStringArray:
+		return convertArray(value, isType[string])
+
+	case PropertyTypeIntArray:
+		return convertArray(value, isType[int64])
+
+	case PropertyTypeFloatArray:
+		return convertArray(value, toFloat)
+
+	case PropertyTypeBoolArray:
+		return convertArray(value, isType[bool])
+
+	default:
+		return nil, false
+	}
+}
+
+// normalizeProperties converts decoded property values into the types they were archived with.
+func normalizeProperties(properties map[string]any, types map[string]string) (map[string]any, error) {
+	if properties == nil {
+		properties = map[string]any{}
+	}
+
+	for key, value := range properties {
+		properties[key] = normalizeValue(value)
+	}
+
+	for key, valueType := range types {
+		if value, hasValue := properties[key]; !hasValue {
+			return nil, fmt.Errorf("%w: property type recorded for missing property %s", ErrInvalidArchive, key)
+		} else if restored, ok := restoreValue(value, valueType); !ok {
+			return nil, fmt.Errorf("%w: property %s is not a valid %s value", ErrInvalidArchive, key, valueType)
+		} else {
+			properties[key] = restored
+		}
+	}
+
+	return properties, nil
+}
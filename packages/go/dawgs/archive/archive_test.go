@@ -0,0 +1,212 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package archive_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/specterops/bloodhound/dawgs"
+	"github.com/specterops/bloodhound/dawgs/archive"
+	"github.com/specterops/bloodhound/dawgs/drivers/memory"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/query"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	lastLogon = time.Date(2024, 3, 1, 12, 30, 0, 500, time.UTC)
+
+	User     = graph.StringKind("User")
+	Base     = graph.StringKind("Base")
+	Group    = graph.StringKind("Group")
+	MemberOf = graph.StringKind("MemberOf")
+)
+
+func openGraph(t *testing.T) graph.Database {
+	db, err := dawgs.Open(context.Background(), memory.DriverName, dawgs.Config{})
+	require.Nil(t, err)
+
+	return db
+}
+
+func populatedGraph(t *testing.T) graph.Database {
+	db := openGraph(t)
+
+	require.Nil(t, db.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		// Delete the first node so that the archived IDs do not line up with the IDs an empty graph allocates
+		if discarded, err := tx.CreateNode(graph.NewProperties(), User); err != nil {
+			return err
+		} else if err := tx.Nodes().Filter(query.Equals(query.NodeID(), discarded.ID)).Delete(); err != nil {
+			return err
+		}
+
+		user, err := tx.CreateNode(graph.AsProperties(map[string]any{
+			"name":      "alice",
+			"logons":    42,
+			"score":     1.5,
+			"weight":    2.0,
+			"enabled":   true,
+			"spns":      []string{"http/a", "http/b"},
+			"ports":     []int{80, 443},
+			"flags":     []bool{true, false},
+			"lastlogon": lastLogon,
+			"objectid":  "S-1-5-21-1",
+		}), User, Base)
+		if err != nil {
+			return err
+		}
+
+		group, err := tx.CreateNode(graph.AsProperties(map[string]any{"name": "admins"}), Group, Base)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.CreateRelationshipByIDs(user.ID, group.ID, MemberOf, graph.AsProperties(map[string]any{"isacl": false}))
+		return err
+	}))
+
+	return db
+}
+
+func TestExportImport(t *testing.T) {
+	var (
+		source = populatedGraph(t)
+		target = openGraph(t)
+		buffer = &bytes.Buffer{}
+		ctx    = context.Background()
+	)
+
+	exported, err := archive.Export(ctx, source, buffer)
+	require.Nil(t, err)
+	require.Equal(t, archive.Summary{Nodes: 2, Edges: 1}, exported)
+
+	imported, err := archive.Import(ctx, target, bytes.NewReader(buffer.Bytes()))
+	require.Nil(t, err)
+	require.Equal(t, exported, imported)
+
+	require.Nil(t, target.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		user, err := tx.Nodes().Filter(query.Equals(query.NodeProperty("name"), "alice")).First()
+		require.Nil(t, err)
+		require.ElementsMatch(t, graph.Kinds{User, Base}, user.Kinds)
+		require.Equal(t, int64(42), user.Properties.Get("logons").Any())
+		require.Equal(t, 1.5, user.Properties.Get("score").Any())
+		require.Equal(t, true, user.Properties.Get("enabled").Any())
+		require.Equal(t, 2.0, user.Properties.Get("weight").Any())
+		require.Equal(t, []string{"http/a", "http/b"}, user.Properties.Get("spns").Any())
+		require.Equal(t, []int64{80, 443}, user.Properties.Get("ports").Any())
+		require.Equal(t, []bool{true, false}, user.Properties.Get("flags").Any())
+
+		importedLastLogon, isTime := user.Properties.Get("lastlogon").Any().(time.Time)
+		require.True(t, isTime)
+		require.True(t, lastLogon.Equal(importedLastLogon))
+
+		edge, err := tx.Relationships().Filter(query.Kind(query.Relationship(), MemberOf)).First()
+		require.Nil(t, err)
+		require.Equal(t, user.ID, edge.StartID)
+		require.Equal(t, false, edge.Properties.Get("isacl").Any())
+
+		group, err := tx.Nodes().Filter(query.Equals(query.NodeID(), edge.EndID)).First()
+		require.Nil(t, err)
+		require.Equal(t, "admins", group.Properties.Get("name").Any())
+
+		return nil
+	}))
+}
+
+func TestImportRequiresEmptyGraph(t *testing.T) {
+	var (
+		source = populatedGraph(t)
+		buffer = &bytes.Buffer{}
+	)
+
+	_, err := archive.Export(context.Background(), source, buffer)
+	require.Nil(t, err)
+
+	_, err = archive.Import(context.Background(), source, buffer)
+	require.ErrorIs(t, err, archive.ErrGraphNotEmpty)
+}
+
+func compress(t *testing.T, content string) *bytes.Buffer {
+	var (
+		buffer = &bytes.Buffer{}
+		writer = gzip.NewWriter(buffer)
+	)
+
+	_, err := writer.Write([]byte(content))
+	require.Nil(t, err)
+	require.Nil(t, writer.Close())
+
+	return buffer
+}
+
+func TestImportInvalidArchives(t *testing.T) {
+	for name, content := range map[string]string{
+		"missing header":  `{"node":{"id":1,"kinds":["User"],"properties":{}}}` + "\n",
+		"unknown format":  `{"header":{"format":"other","version":1}}` + "\n",
+		"future version":  `{"header":{"format":"dawgs-graph-archive","version":99}}` + "\n",
+		"missing footer":  `{"header":{"format":"dawgs-graph-archive","version":1}}` + "\n" + `{"node":{"id":1,"kinds":["User"],"properties":{}}}` + "\n",
+		"count mismatch":  `{"header":{"format":"dawgs-graph-archive","version":1}}` + "\n" + `{"footer":{"nodes":1,"edges":0}}` + "\n",
+		"dangling edge":   `{"header":{"format":"dawgs-graph-archive","version":1}}` + "\n" + `{"edge":{"id":1,"start_id":1,"end_id":2,"kind":"MemberOf","properties":{}}}` + "\n" + `{"footer":{"nodes":0,"edges":1}}` + "\n",
+		"bad property":    `{"header":{"format":"dawgs-graph-archive","version":2}}` + "\n" + `{"node":{"id":1,"kinds":["User"],"properties":{"lastlogon":42},"property_types":{"lastlogon":"datetime"}}}` + "\n" + `{"footer":{"nodes":1,"edges":0}}` + "\n",
+		"unknown type":    `{"header":{"format":"dawgs-graph-archive","version":2}}` + "\n" + `{"node":{"id":1,"kinds":["User"],"properties":{"name":"a"},"property_types":{"name":"uuid"}}}` + "\n" + `{"footer":{"nodes":1,"edges":0}}` + "\n",
+		"node after edge": `{"header":{"format":"dawgs-graph-archive","version":1}}` + "\n" + `{"node":{"id":1,"kinds":["User"],"properties":{}}}` + "\n" + `{"edge":{"id":1,"start_id":1,"end_id":1,"kind":"MemberOf","properties":{}}}` + "\n" + `{"node":{"id":2,"kinds":["User"],"properties":{}}}` + "\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := archive.Import(context.Background(), openGraph(t), compress(t, content))
+			require.ErrorIs(t, err, archive.ErrInvalidArchive)
+		})
+	}
+
+	_, err := archive.Import(context.Background(), openGraph(t), bytes.NewBufferString("not compressed"))
+	require.ErrorIs(t, err, archive.ErrInvalidArchive)
+}
+
+func TestImportRollsBackOnFailure(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		target = openGraph(t)
+		buffer = &bytes.Buffer{}
+	)
+
+	// The nodes are written before the dangling edge fails the import
+	_, err := archive.Import(ctx, target, compress(t, `{"header":{"format":"dawgs-graph-archive","version":2}}`+"\n"+
+		`{"node":{"id":1,"kinds":["User"],"properties":{}}}`+"\n"+
+		`{"node":{"id":2,"kinds":["Group"],"properties":{}}}`+"\n"+
+		`{"edge":{"id":1,"start_id":1,"end_id":2,"kind":"MemberOf","properties":{}}}`+"\n"+
+		`{"edge":{"id":2,"start_id":1,"end_id":3,"kind":"MemberOf","properties":{}}}`+"\n"+
+		`{"footer":{"nodes":2,"edges":2}}`+"\n"))
+	require.ErrorIs(t, err, archive.ErrInvalidArchive)
+
+	require.Nil(t, target.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		_, err := tx.Nodes().First()
+		require.ErrorIs(t, err, graph.ErrNoResultsFound)
+
+		return nil
+	}))
+
+	// A retry against the same graph must not be rejected as a non-empty graph
+	_, err = archive.Export(ctx, populatedGraph(t), buffer)
+	require.Nil(t, err)
+
+	imported, err := archive.Import(ctx, target, buffer)
+	require.Nil(t, err)
+	require.Equal(t, archive.Summary{Nodes: 2, Edges: 1}, imported)
+}
@@ -0,0 +1,154 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package archive
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/specterops/bloodhound/dawgs/graph"
+)
+
+func sortedKindStrings(kinds map[string]struct{}) []string {
+	sorted := make([]string, 0, len(kinds))
+
+	for kind := range kinds {
+		sorted = append(sorted, kind)
+	}
+
+	sort.Strings(sorted)
+	return sorted
+}
+
+func fetchHeader(tx graph.Transaction) (Header, error) {
+	var (
+		nodeKinds = map[string]struct{}{}
+		edgeKinds = map[string]struct{}{}
+	)
+
+	if err := tx.Nodes().FetchKinds(func(cursor graph.Cursor[graph.KindsResult]) error {
+		for next := range cursor.Chan() {
+			for _, kind := range next.Kinds {
+				nodeKinds[kind.String()] = struct{}{}
+			}
+		}
+
+		return cursor.Error()
+	}); err != nil {
+		return Header{}, err
+	}
+
+	if err := tx.Relationships().FetchKinds(func(cursor graph.Cursor[graph.RelationshipKindsResult]) error {
+		for next := range cursor.Chan() {
+			edgeKinds[next.Kind.String()] = struct{}{}
+		}
+
+		return cursor.Error()
+	}); err != nil {
+		return Header{}, err
+	}
+
+	return Header{
+		Format:    Format,
+		Version:   Version,
+		CreatedAt: time.Now().UTC(),
+		NodeKinds: sortedKindStrings(nodeKinds),
+		EdgeKinds: sortedKindStrings(edgeKinds),
+	}, nil
+}
+
+// Export writes every node and edge in the given database to the writer as a compressed archive. The graph is read
+// within a single read transaction.
+func Export(ctx context.Context, db graph.Database, writer io.Writer) (Summary, error) {
+	var (
+		summary          Summary
+		compressedWriter = gzip.NewWriter(writer)
+		entryEncoder     = json.NewEncoder(compressedWriter)
+	)
+
+	if err := db.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		if header, err := fetchHeader(tx); err != nil {
+			return fmt.Errorf("failed fetching graph kinds: %w", err)
+		} else if err := entryEncoder.Encode(entry{Header: &header}); err != nil {
+			return err
+		}
+
+		if err := tx.Nodes().Fetch(func(cursor graph.Cursor[*graph.Node]) error {
+			for next := range cursor.Chan() {
+				properties := next.Properties.MapOrEmpty()
+
+				if err := entryEncoder.Encode(entry{
+					Node: &Node{
+						ID:            next.ID.Uint64(),
+						Kinds:         next.Kinds.Strings(),
+						Properties:    properties,
+						PropertyTypes: propertyTypes(properties),
+					},
+				}); err != nil {
+					return err
+				}
+
+				summary.Nodes++
+			}
+
+			return cursor.Error()
+		}); err != nil {
+			return fmt.Errorf("failed exporting nodes: %w", err)
+		}
+
+		if err := tx.Relationships().Fetch(func(cursor graph.Cursor[*graph.Relationship]) error {
+			for next := range cursor.Chan() {
+				properties := next.Properties.MapOrEmpty()
+
+				if err := entryEncoder.Encode(entry{
+					Edge: &Edge{
+						ID:            next.ID.Uint64(),
+						StartID:       next.StartID.Uint64(),
+						EndID:         next.EndID.Uint64(),
+						Kind:          next.Kind.String(),
+						Properties:    properties,
+						PropertyTypes: propertyTypes(properties),
+					},
+				}); err != nil {
+					return err
+				}
+
+				summary.Edges++
+			}
+
+			return cursor.Error()
+		}); err != nil {
+			return fmt.Errorf("failed exporting edges: %w", err)
+		}
+
+		return entryEncoder.Encode(entry{
+			Footer: &Footer{
+				Nodes: summary.Nodes,
+				Edges: summary.Edges,
+			},
+		})
+	}); err != nil {
+		return summary, err
+	}
+
+	return summary, compressedWriter.Close()
+}
@@ -0,0 +1,294 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package archive
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/query"
+)
+
+const (
+	// importChunkSize is the number of nodes or edges written per write transaction during an import.
+	importChunkSize = 1_000
+)
+
+type importer struct {
+	ctx            context.Context
+	db             graph.Database
+	nodeIDMappings map[uint64]graph.ID
+	edgeIDs        []graph.ID
+	pendingNodes   []*Node
+	pendingEdges   []*Edge
+	summary        Summary
+}
+
+func (s *importer) flushNodes() error {
+	if len(s.pendingNodes) == 0 {
+		return nil
+	}
+
+	nodeIDs := make([]graph.ID, 0, len(s.pendingNodes))
+
+	if err := s.db.WriteTransaction(s.ctx, func(tx graph.Transaction) error {
+		for _, node := range s.pendingNodes {
+			if created, err := tx.CreateNode(graph.AsProperties(node.Properties), graph.StringsToKinds(node.Kinds)...); err != nil {
+				return err
+			} else {
+				nodeIDs = append(nodeIDs, created.ID)
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed importing nodes: %w", err)
+	}
+
+	// Mappings are only recorded once committed so that a rollback never targets IDs of a failed transaction
+	for idx, node := range s.pendingNodes {
+		s.nodeIDMappings[node.ID] = nodeIDs[idx]
+	}
+
+	s.summary.Nodes += int64(len(s.pendingNodes))
+	s.pendingNodes = s.pendingNodes[:0]
+
+	return nil
+}
+
+func (s *importer) flushEdges() error {
+	if len(s.pendingEdges) == 0 {
+		return nil
+	}
+
+	edgeIDs := make([]graph.ID, 0, len(s.pendingEdges))
+
+	if err := s.db.WriteTransaction(s.ctx, func(tx graph.Transaction) error {
+		for _, edge := range s.pendingEdges {
+			if startID, found := s.nodeIDMappings[edge.StartID]; !found {
+				return fmt.Errorf("%w: edge %d references unknown start node %d", ErrInvalidArchive, edge.ID, edge.StartID)
+			} else if endID, found := s.nodeIDMappings[edge.EndID]; !found {
+				return fmt.Errorf("%w: edge %d references unknown end node %d", ErrInvalidArchive, edge.ID, edge.EndID)
+			} else if created, err := tx.CreateRelationshipByIDs(startID, endID, graph.StringKind(edge.Kind), graph.AsProperties(edge.Properties)); err != nil {
+				return err
+			} else {
+				edgeIDs = append(edgeIDs, created.ID)
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed importing edges: %w", err)
+	}
+
+	s.edgeIDs = append(s.edgeIDs, edgeIDs...)
+	s.summary.Edges += int64(len(s.pendingEdges))
+	s.pendingEdges = s.pendingEdges[:0]
+
+	return nil
+}
+
+// deleteInChunks deletes the given IDs in chunks of separate write transactions using the given deletion.
+func (s *importer) deleteInChunks(ctx context.Context, ids []graph.ID, deleteChunk func(tx graph.Transaction, ids []graph.ID) error) error {
+	for chunkStart := 0; chunkStart < len(ids); chunkStart += importChunkSize {
+		chunk := ids[chunkStart:min(chunkStart+importChunkSize, len(ids))]
+
+		if err := s.db.WriteTransaction(ctx, func(tx graph.Transaction) error {
+			return deleteChunk(tx, chunk)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rollback deletes every node and edge written by the import so that a failed import leaves the graph empty and may
+// be retried. The deletion is detached from the import's context as the import may have failed due to its
+// cancellation.
+func (s *importer) rollback() error {
+	var (
+		ctx     = context.WithoutCancel(s.ctx)
+		nodeIDs = make([]graph.ID, 0, len(s.nodeIDMappings))
+	)
+
+	for _, nodeID := range s.nodeIDMappings {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+
+	if err := s.deleteInChunks(ctx, s.edgeIDs, func(tx graph.Transaction, ids []graph.ID) error {
+		return tx.Relationships().Filter(query.InIDs(query.RelationshipID(), ids...)).Delete()
+	}); err != nil {
+		return fmt.Errorf("failed deleting imported edges: %w", err)
+	}
+
+	if err := s.deleteInChunks(ctx, nodeIDs, func(tx graph.Transaction, ids []graph.ID) error {
+		return tx.Nodes().Filter(query.InIDs(query.NodeID(), ids...)).Delete()
+	}); err != nil {
+		return fmt.Errorf("failed deleting imported nodes: %w", err)
+	}
+
+	return nil
+}
+
+func readHeader(decoder *json.Decoder) (Header, error) {
+	var next entry
+
+	if err := decoder.Decode(&next); err != nil {
+		return Header{}, fmt.Errorf("%w: unable to read header: %v", ErrInvalidArchive, err)
+	} else if next.Header == nil {
+		return Header{}, fmt.Errorf("%w: archive does not begin with a header", ErrInvalidArchive)
+	} else if next.Header.Format != Format {
+		return Header{}, fmt.Errorf("%w: unknown archive format %q", ErrInvalidArchive, next.Header.Format)
+	} else if next.Header.Version < 1 || next.Header.Version > Version {
+		return Header{}, fmt.Errorf("%w: unsupported archive version %d", ErrInvalidArchive, next.Header.Version)
+	}
+
+	return *next.Header, nil
+}
+
+func isEmpty(ctx context.Context, db graph.Database) (bool, error) {
+	var empty bool
+
+	return empty, db.ReadTransaction(ctx, func(tx graph.Transaction) error {
+		if _, err := tx.Nodes().First(); errors.Is(err, graph.ErrNoResultsFound) {
+			empty = true
+		} else if err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+// Import reads a compressed archive written by Export and recreates its nodes and edges in the given database. The
+// target graph must be empty. Nodes and edges are written in chunks of separate write transactions; an import that
+// fails part way through deletes the entities written so far before returning its error.
+func Import(ctx context.Context, db graph.Database, reader io.Reader) (Summary, error) {
+	if empty, err := isEmpty(ctx, db); err != nil {
+		return Summary{}, err
+	} else if !empty {
+		return Summary{}, ErrGraphNotEmpty
+	}
+
+	compressedReader, err := gzip.NewReader(reader)
+	if err != nil {
+		return Summary{}, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+
+	defer compressedReader.Close()
+
+	decoder := json.NewDecoder(compressedReader)
+	decoder.UseNumber()
+
+	if _, err := readHeader(decoder); err != nil {
+		return Summary{}, err
+	}
+
+	state := &importer{
+		ctx:            ctx,
+		db:             db,
+		nodeIDMappings: map[uint64]graph.ID{},
+	}
+
+	if err := state.importEntries(decoder); err != nil {
+		if rollbackErr := state.rollback(); rollbackErr != nil {
+			return Summary{}, errors.Join(err, fmt.Errorf("failed removing partially imported graph: %w", rollbackErr))
+		}
+
+		return Summary{}, err
+	}
+
+	return state.summary, nil
+}
+
+func (s *importer) importEntries(decoder *json.Decoder) error {
+	var footer *Footer
+
+	for footer == nil {
+		var next entry
+
+		if err := decoder.Decode(&next); errors.Is(err, io.EOF) {
+			return fmt.Errorf("%w: archive is missing its footer", ErrInvalidArchive)
+		} else if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+		}
+
+		switch {
+		case next.Node != nil:
+			if len(s.pendingEdges) > 0 || s.summary.Edges > 0 {
+				return fmt.Errorf("%w: node %d follows edge entries", ErrInvalidArchive, next.Node.ID)
+			}
+
+			if properties, err := normalizeProperties(next.Node.Properties, next.Node.PropertyTypes); err != nil {
+				return err
+			} else {
+				next.Node.Properties = properties
+			}
+
+			s.pendingNodes = append(s.pendingNodes, next.Node)
+
+			if len(s.pendingNodes) >= importChunkSize {
+				if err := s.flushNodes(); err != nil {
+					return err
+				}
+			}
+
+		case next.Edge != nil:
+			if err := s.flushNodes(); err != nil {
+				return err
+			}
+
+			if properties, err := normalizeProperties(next.Edge.Properties, next.Edge.PropertyTypes); err != nil {
+				return err
+			} else {
+				next.Edge.Properties = properties
+			}
+
+			s.pendingEdges = append(s.pendingEdges, next.Edge)
+
+			if len(s.pendingEdges) >= importChunkSize {
+				if err := s.flushEdges(); err != nil {
+					return err
+				}
+			}
+
+		case next.Footer != nil:
+			footer = next.Footer
+
+		default:
+			return fmt.Errorf("%w: unexpected archive entry", ErrInvalidArchive)
+		}
+	}
+
+	if err := s.flushNodes(); err != nil {
+		return err
+	} else if err := s.flushEdges(); err != nil {
+		return err
+	}
+
+	if footer.Nodes != s.summary.Nodes || footer.Edges != s.summary.Edges {
+		return fmt.Errorf("%w: archive footer expects %d nodes and %d edges but %d nodes and %d edges were read", ErrInvalidArchive, footer.Nodes, footer.Edges, s.summary.Nodes, s.summary.Edges)
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package changefeed
+
+import (
+	"github.com/specterops/bloodhound/dawgs/graph"
+)
+
+// batch records the changes made by a batch operation. Batches buffer their writes and drivers assign IDs when a
+// buffer is flushed, so created and upserted entities are recorded without an ID. Batch deletes are issued by ID only
+// and are recorded without kinds.
+type batch struct {
+	*recorder
+
+	batch graph.Batch
+}
+
+func (s *batch) WithGraph(graphSchema graph.Graph) graph.Batch {
+	s.batch = s.batch.WithGraph(graphSchema)
+	return s
+}
+
+func (s *batch) CreateNode(node *graph.Node) error {
+	if err := s.batch.CreateNode(node); err != nil {
+		return err
+	}
+
+	s.record(Change{
+		Entity:       EntityNode,
+		Operation:    OperationCreate,
+		ID:           node.ID,
+		Kinds:        kindStrings(node.Kinds),
+		PropertyKeys: propertyKeys(node.Properties),
+	})
+
+	return nil
+}
+
+func (s *batch) DeleteNode(id graph.ID) error {
+	if err := s.batch.DeleteNode(id); err != nil {
+		return err
+	}
+
+	s.record(Change{
+		Entity:    EntityNode,
+		Operation: OperationDelete,
+		ID:        id,
+	})
+
+	return nil
+}
+
+func (s *batch) Nodes() graph.NodeQuery {
+	return newNodeQuery(s.recorder, s.batch.Nodes)
+}
+
+func (s *batch) Relationships() graph.RelationshipQuery {
+	return newRelationshipQuery(s.recorder, s.batch.Relationships)
+}
+
+func (s *batch) UpdateNodeBy(update graph.NodeUpdate) error {
+	if err := s.batch.UpdateNodeBy(update); err != nil {
+		return err
+	}
+
+	s.record(Change{
+		Entity:       EntityNode,
+		Operation:    OperationUpsert,
+		Kinds:        kindStrings(update.Node.Kinds),
+		PropertyKeys: propertyKeys(update.Node.Properties),
+		Identity:     identityOf(update.Node.Properties, update.IdentityProperties),
+	})
+
+	return nil
+}
+
+func (s *batch) CreateRelationship(relationship *graph.Relationship) error {
+	if err := s.batch.CreateRelationship(relationship); err != nil {
+		return err
+	}
+
+	s.record(Change{
+		Entity:       EntityRelationship,
+		Operation:    OperationCreate,
+		ID:           relationship.ID,
+		Kinds:        kindStrings(graph.Kinds{relationship.Kind}),
+		StartID:      relationship.StartID,
+		EndID:        relationship.EndID,
+		PropertyKeys: propertyKeys(relationship.Properties),
+	})
+
+	return nil
+}
+
+func (s *batch) CreateRelationshipByIDs(startNodeID, endNodeID graph.ID, kind graph.Kind, properties *graph.Properties) error {
+	if err := s.batch.CreateRelationshipByIDs(startNodeID, endNodeID, kind, properties); err != nil {
+		return err
+	}
+
+	s.record(Change{
+		Entity:       EntityRelationship,
+		Operation:    OperationCreate,
+		Kinds:        kindStrings(graph.Kinds{kind}),
+		StartID:      startNodeID,
+		EndID:        endNodeID,
+		PropertyKeys: propertyKeys(properties),
+	})
+
+	return nil
+}
+
+func (s *batch) DeleteRelationship(id graph.ID) error {
+	if err := s.batch.DeleteRelationship(id); err != nil {
+		return err
+	}
+
+	s.record(Change{
+		Entity:    EntityRelationship,
+		Operation: OperationDelete,
+		ID:        id,
+	})
+
+	return nil
+}
+
+func (s *batch) UpdateRelationshipBy(update graph.RelationshipUpdate) error {
+	if err := s.batch.UpdateRelationshipBy(update); err != nil {
+		return err
+	}
+
+	change := Change{
+		Entity:       EntityRelationship,
+		Operation:    OperationUpsert,
+		Kinds:        kindStrings(graph.Kinds{update.Relationship.Kind}),
+		StartID:      update.Relationship.StartID,
+		EndID:        update.Relationship.EndID,
+		PropertyKeys: propertyKeys(update.Relationship.Properties),
+		Identity:     identityOf(update.Relationship.Properties, update.IdentityProperties),
+	}
+
+	if update.Start != nil {
+		change.StartIdentity = identityOf(update.Start.Properties, update.StartIdentityProperties)
+	}
+
+	if update.End != nil {
+		change.EndIdentity = identityOf(update.End.Properties, update.EndIdentityProperties)
+	}
+
+	s.record(change)
+	return nil
+}
+
+func (s *batch) Commit() error {
+	// Committing a batch only flushes its buffered writes; changes are published once the batch operation completes
+	return s.batch.Commit()
+}
@@ -0,0 +1,157 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package changefeed provides a graph.Database wrapper that publishes the node and relationship mutations made by
+// committed write transactions and batch operations to a set of sinks.
+//
+// Mutations made through the typed Transaction, Batch, NodeQuery and RelationshipQuery APIs are published entity by
+// entity. Cypher mutations and raw queries run within a write transaction can not be observed at that granularity and
+// are published as a single opaque query change instead. graph.Database.Run is not published.
+package changefeed
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/specterops/bloodhound/dawgs/graph"
+)
+
+type EntityType string
+
+const (
+	EntityNode         EntityType = "node"
+	EntityRelationship EntityType = "relationship"
+
+	// EntityQuery identifies changes made by a query whose individual node and relationship mutations can not be
+	// observed by the feed
+	EntityQuery EntityType = "query"
+)
+
+type Operation string
+
+const (
+	OperationCreate Operation = "create"
+	OperationUpdate Operation = "update"
+	OperationDelete Operation = "delete"
+
+	// OperationUpsert is recorded for batch writes that either create or update an entity depending on whether an
+	// entity matching the update's identity already exists. The ID of an upserted entity is not known until the batch
+	// is flushed, so these changes are identified by their Identity instead.
+	OperationUpsert Operation = "upsert"
+
+	// OperationMutate is recorded for a cypher query with updating clauses run through graph.Mutate. The change carries
+	// the query and the summary of the mutations it made.
+	OperationMutate Operation = "mutate"
+
+	// OperationRaw is recorded for every raw query run within a write transaction since the driver can not report
+	// whether the query modified the graph.
+	OperationRaw Operation = "raw"
+)
+
+// Change describes a single mutation of a node or relationship.
+type Change struct {
+	Entity        EntityType     `json:"entity"`
+	Operation     Operation      `json:"operation"`
+	ID            graph.ID       `json:"id,omitempty"`
+	Kinds         []string       `json:"kinds,omitempty"`
+	AddedKinds    []string       `json:"added_kinds,omitempty"`
+	DeletedKinds  []string       `json:"deleted_kinds,omitempty"`
+	StartID       graph.ID       `json:"start_id,omitempty"`
+	EndID         graph.ID       `json:"end_id,omitempty"`
+	PropertyKeys  []string       `json:"property_keys,omitempty"`
+	Identity      map[string]any `json:"identity,omitempty"`
+	StartIdentity map[string]any `json:"start_identity,omitempty"`
+	EndIdentity   map[string]any `json:"end_identity,omitempty"`
+
+	Query   string                 `json:"query,omitempty"`
+	Summary *graph.MutationSummary `json:"summary,omitempty"`
+}
+
+// Event is the set of changes made durable by a single commit.
+type Event struct {
+	Changes     []Change  `json:"changes"`
+	CommittedAt time.Time `json:"committed_at"`
+}
+
+// Sink receives events after the changes they describe have been committed. Sinks are invoked synchronously and in
+// registration order from the goroutine that committed the changes. The context given to a sink is not cancelled
+// with the context of the commit since the changes are durable regardless. Errors returned by a sink are logged and do
+// not fail the commit.
+type Sink interface {
+	Write(ctx context.Context, event Event) error
+}
+
+// SinkFunc adapts a function to the Sink interface.
+type SinkFunc func(ctx context.Context, event Event) error
+
+func (s SinkFunc) Write(ctx context.Context, event Event) error {
+	return s(ctx, event)
+}
+
+// changedPropertyKeys returns the sorted set of property keys that were either modified or deleted.
+func changedPropertyKeys(properties *graph.Properties) []string {
+	if properties == nil {
+		return nil
+	}
+
+	keys := properties.DeletedProperties()
+
+	for key := range properties.ModifiedProperties() {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+	return slices.Compact(keys)
+}
+
+// propertyKeys returns the sorted set of all property keys.
+func propertyKeys(properties *graph.Properties) []string {
+	if properties == nil || properties.Len() == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, properties.Len())
+
+	for key := range properties.Map {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+	return keys
+}
+
+func identityOf(properties *graph.Properties, identityProperties []string) map[string]any {
+	if properties == nil || len(identityProperties) == 0 {
+		return nil
+	}
+
+	identity := make(map[string]any, len(identityProperties))
+
+	for _, identityProperty := range identityProperties {
+		identity[identityProperty] = properties.Get(identityProperty).Any()
+	}
+
+	return identity
+}
+
+func kindStrings(kinds graph.Kinds) []string {
+	if len(kinds) == 0 {
+		return nil
+	}
+
+	return kinds.Strings()
+}
@@ -0,0 +1,401 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package changefeed_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/specterops/bloodhound/dawgs"
+	"github.com/specterops/bloodhound/dawgs/changefeed"
+	"github.com/specterops/bloodhound/dawgs/drivers/memory"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/query"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	User     = graph.StringKind("User")
+	Group    = graph.StringKind("Group")
+	MemberOf = graph.StringKind("MemberOf")
+)
+
+type capturingSink struct {
+	events []changefeed.Event
+}
+
+func (s *capturingSink) Write(_ context.Context, event changefeed.Event) error {
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *capturingSink) changes() []changefeed.Change {
+	var changes []changefeed.Change
+
+	for _, event := range s.events {
+		changes = append(changes, event.Changes...)
+	}
+
+	return changes
+}
+
+func newDatabase(t *testing.T) (*changefeed.Database, *capturingSink) {
+	db, err := dawgs.Open(context.Background(), memory.DriverName, dawgs.Config{})
+	require.Nil(t, err)
+
+	sink := &capturingSink{}
+	return changefeed.Wrap(db, sink), sink
+}
+
+func TestDatabase_WriteTransaction(t *testing.T) {
+	var (
+		db, sink   = newDatabase(t)
+		alice, grp *graph.Node
+		memberOf   *graph.Relationship
+	)
+
+	require.Nil(t, db.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		var err error
+
+		if alice, err = tx.CreateNode(graph.AsProperties(map[string]any{"name": "alice", "enabled": true}), User); err != nil {
+			return err
+		} else if grp, err = tx.CreateNode(graph.AsProperties(map[string]any{"name": "admins"}), Group); err != nil {
+			return err
+		} else if memberOf, err = tx.CreateRelationshipByIDs(alice.ID, grp.ID, MemberOf, graph.NewProperties()); err != nil {
+			return err
+		}
+
+		return nil
+	}))
+
+	require.Len(t, sink.events, 1)
+	require.Equal(t, []changefeed.Change{{
+		Entity:       changefeed.EntityNode,
+		Operation:    changefeed.OperationCreate,
+		ID:           alice.ID,
+		Kinds:        []string{"User"},
+		PropertyKeys: []string{"enabled", "name"},
+	}, {
+		Entity:       changefeed.EntityNode,
+		Operation:    changefeed.OperationCreate,
+		ID:           grp.ID,
+		Kinds:        []string{"Group"},
+		PropertyKeys: []string{"name"},
+	}, {
+		Entity:    changefeed.EntityRelationship,
+		Operation: changefeed.OperationCreate,
+		ID:        memberOf.ID,
+		Kinds:     []string{"MemberOf"},
+		StartID:   alice.ID,
+		EndID:     grp.ID,
+	}}, sink.events[0].Changes)
+
+	sink.events = nil
+
+	require.Nil(t, db.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		alice.Properties.Set("title", "engineer")
+		alice.Properties.Delete("enabled")
+
+		if err := tx.UpdateNode(alice); err != nil {
+			return err
+		}
+
+		return tx.Relationships().Filter(query.Equals(query.StartID(), alice.ID)).Delete()
+	}))
+
+	require.Equal(t, []changefeed.Change{{
+		Entity:       changefeed.EntityNode,
+		Operation:    changefeed.OperationUpdate,
+		ID:           alice.ID,
+		Kinds:        []string{"User"},
+		PropertyKeys: []string{"enabled", "title"},
+	}, {
+		Entity:    changefeed.EntityRelationship,
+		Operation: changefeed.OperationDelete,
+		ID:        memberOf.ID,
+		Kinds:     []string{"MemberOf"},
+		StartID:   alice.ID,
+		EndID:     grp.ID,
+	}}, sink.changes())
+}
+
+func TestDatabase_QueryMutations(t *testing.T) {
+	db, sink := newDatabase(t)
+
+	require.Nil(t, db.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		for _, name := range []string{"alice", "bob", "carol"} {
+			if _, err := tx.CreateNode(graph.AsProperties(map[string]any{"name": name}), User); err != nil {
+				return err
+			}
+		}
+
+		_, err := tx.CreateNode(graph.AsProperties(map[string]any{"name": "admins"}), Group)
+		return err
+	}))
+
+	sink.events = nil
+
+	require.Nil(t, db.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		updated := graph.NewProperties()
+		updated.Set("enabled", false)
+
+		if err := tx.Nodes().Filterf(func() graph.Criteria {
+			return query.Kind(query.Node(), User)
+		}).OrderBy(query.Order(query.NodeProperty("name"), query.Ascending())).Limit(2).Update(updated); err != nil {
+			return err
+		}
+
+		return tx.Nodes().Filter(query.Kind(query.Node(), Group)).Delete()
+	}))
+
+	changes := sink.changes()
+	require.Len(t, changes, 3)
+
+	for _, change := range changes[:2] {
+		require.Equal(t, changefeed.OperationUpdate, change.Operation)
+		require.Equal(t, []string{"User"}, change.Kinds)
+		require.Equal(t, []string{"enabled"}, change.PropertyKeys)
+	}
+
+	require.Equal(t, changefeed.OperationDelete, changes[2].Operation)
+	require.Equal(t, []string{"Group"}, changes[2].Kinds)
+
+	require.Nil(t, db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		count, err := tx.Nodes().Filter(query.Equals(query.NodeProperty("enabled"), false)).Count()
+		require.Nil(t, err)
+		require.Equal(t, int64(2), count)
+
+		return nil
+	}))
+}
+
+func TestDatabase_RolledBackChangesAreNotPublished(t *testing.T) {
+	var (
+		db, sink  = newDatabase(t)
+		errFailed = errors.New("failed")
+	)
+
+	require.ErrorIs(t, db.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		if _, err := tx.CreateNode(graph.NewProperties(), User); err != nil {
+			return err
+		}
+
+		return errFailed
+	}), errFailed)
+
+	require.ErrorIs(t, db.BatchOperation(context.Background(), func(batch graph.Batch) error {
+		if err := batch.CreateNode(graph.PrepareNode(graph.NewProperties(), User)); err != nil {
+			return err
+		}
+
+		return errFailed
+	}), errFailed)
+
+	require.Empty(t, sink.events)
+}
+
+func TestDatabase_BatchOperation(t *testing.T) {
+	db, sink := newDatabase(t)
+
+	require.Nil(t, db.BatchOperation(context.Background(), func(batch graph.Batch) error {
+		if err := batch.UpdateNodeBy(graph.NodeUpdate{
+			Node:               graph.PrepareNode(graph.AsProperties(map[string]any{"objectid": "1", "name": "alice"}), User),
+			IdentityKind:       User,
+			IdentityProperties: []string{"objectid"},
+		}); err != nil {
+			return err
+		}
+
+		return batch.UpdateRelationshipBy(graph.RelationshipUpdate{
+			Relationship:            graph.PrepareRelationship(graph.NewProperties(), MemberOf),
+			Start:                   graph.PrepareNode(graph.AsProperties(map[string]any{"objectid": "1"}), User),
+			StartIdentityKind:       User,
+			StartIdentityProperties: []string{"objectid"},
+			End:                     graph.PrepareNode(graph.AsProperties(map[string]any{"objectid": "2"}), Group),
+			EndIdentityKind:         Group,
+			EndIdentityProperties:   []string{"objectid"},
+		})
+	}))
+
+	require.Len(t, sink.events, 1)
+	require.Equal(t, []changefeed.Change{{
+		Entity:       changefeed.EntityNode,
+		Operation:    changefeed.OperationUpsert,
+		Kinds:        []string{"User"},
+		PropertyKeys: []string{"name", "objectid"},
+		Identity:     map[string]any{"objectid": "1"},
+	}, {
+		Entity:        changefeed.EntityRelationship,
+		Operation:     changefeed.OperationUpsert,
+		Kinds:         []string{"MemberOf"},
+		StartIdentity: map[string]any{"objectid": "1"},
+		EndIdentity:   map[string]any{"objectid": "2"},
+	}}, sink.events[0].Changes)
+}
+
+func TestFeed(t *testing.T) {
+	var (
+		feed       = changefeed.NewFeed()
+		db, _      = newDatabase(t)
+		wrapped    = changefeed.Wrap(db.Unwrap(), feed)
+		subscriber = feed.Subscribe(1)
+	)
+
+	for idx := 0; idx < 2; idx++ {
+		require.Nil(t, wrapped.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+			_, err := tx.CreateNode(graph.NewProperties(), User)
+			return err
+		}))
+	}
+
+	// No changes were made so no event should be published
+	require.Nil(t, wrapped.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		return nil
+	}))
+
+	event := <-subscriber.Events()
+	require.Len(t, event.Changes, 1)
+	require.Equal(t, int64(1), subscriber.Dropped())
+
+	subscriber.Cancel()
+	subscriber.Cancel()
+
+	_, open := <-subscriber.Events()
+	require.False(t, open)
+}
+
+// queryTransaction stands in for a driver transaction that supports raw and cypher mutation queries
+type queryTransaction struct {
+	graph.Transaction
+}
+
+func (s queryTransaction) Raw(query string, parameters map[string]any) graph.Result {
+	return graph.NewErrorResult(nil)
+}
+
+func (s queryTransaction) Mutate(query string, parameters map[string]any) (graph.MutationSummary, error) {
+	if query == "match (n) return n" {
+		return graph.MutationSummary{}, nil
+	}
+
+	return graph.MutationSummary{NodesDeleted: 1, RelationshipsDeleted: 2}, nil
+}
+
+// queryDatabase hands out queryTransaction instances to write transactions
+type queryDatabase struct {
+	graph.Database
+}
+
+func (s queryDatabase) WriteTransaction(ctx context.Context, txDelegate graph.TransactionDelegate, options ...graph.TransactionOption) error {
+	return s.Database.WriteTransaction(ctx, func(tx graph.Transaction) error {
+		return txDelegate(queryTransaction{
+			Transaction: tx,
+		})
+	}, options...)
+}
+
+func TestDatabase_QueryChanges(t *testing.T) {
+	var (
+		db, _   = newDatabase(t)
+		sink    = &capturingSink{}
+		wrapped = changefeed.Wrap(queryDatabase{Database: db.Unwrap()}, sink)
+	)
+
+	require.Nil(t, wrapped.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		if result := tx.Raw("delete from node", nil); result.Error() != nil {
+			return result.Error()
+		} else if _, err := graph.Mutate(tx, "match (n) detach delete n", nil); err != nil {
+			return err
+		}
+
+		// Queries that change nothing are not published
+		_, err := graph.Mutate(tx, "match (n) return n", nil)
+		return err
+	}))
+
+	require.Equal(t, []changefeed.Change{{
+		Entity:    changefeed.EntityQuery,
+		Operation: changefeed.OperationRaw,
+		Query:     "delete from node",
+	}, {
+		Entity:    changefeed.EntityQuery,
+		Operation: changefeed.OperationMutate,
+		Query:     "match (n) detach delete n",
+		Summary:   &graph.MutationSummary{NodesDeleted: 1, RelationshipsDeleted: 2},
+	}}, sink.changes())
+}
+
+func TestDatabase_PublishOutlivesCommitContext(t *testing.T) {
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		db, _       = newDatabase(t)
+		sinkErrs    []error
+		wrapped     = changefeed.Wrap(db.Unwrap(), changefeed.SinkFunc(func(ctx context.Context, event changefeed.Event) error {
+			sinkErrs = append(sinkErrs, ctx.Err())
+			return nil
+		}))
+	)
+
+	require.Nil(t, wrapped.WriteTransaction(ctx, func(tx graph.Transaction) error {
+		_, err := tx.CreateNode(graph.NewProperties(), User)
+
+		// The request that made the change goes away before the change is published
+		cancel()
+		return err
+	}))
+
+	require.Equal(t, []error{nil}, sinkErrs)
+}
+
+func TestFind(t *testing.T) {
+	var (
+		feed     = changefeed.NewFeed()
+		db, _    = newDatabase(t)
+		wrapped  = changefeed.Wrap(db.Unwrap(), feed)
+		dbSwitch = graph.NewDatabaseSwitch(context.Background(), wrapped)
+	)
+
+	_, found := changefeed.Find(db.Unwrap())
+	require.False(t, found)
+
+	changeFeed, found := changefeed.Find(dbSwitch)
+	require.True(t, found)
+	require.Same(t, wrapped, changeFeed)
+
+	foundFeed, found := changeFeed.Feed()
+	require.True(t, found)
+	require.Same(t, feed, foundFeed)
+
+	// A replacement database keeps publishing to the same sinks
+	replacement, err := dawgs.Open(context.Background(), memory.DriverName, dawgs.Config{})
+	require.Nil(t, err)
+
+	dbSwitch.Switch(changeFeed.Rewrap(replacement))
+	subscriber := feed.Subscribe(1)
+
+	require.Nil(t, dbSwitch.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		_, err := tx.CreateNode(graph.NewProperties(), User)
+		return err
+	}))
+
+	event := <-subscriber.Events()
+	require.Len(t, event.Changes, 1)
+
+	_, found = db.Feed()
+	require.False(t, found)
+}
@@ -0,0 +1,193 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package changefeed
+
+import (
+	"context"
+	"time"
+
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/log"
+)
+
+// Database wraps a graph.Database and publishes an Event to its sinks each time a write transaction or batch
+// operation commits a set of node or relationship mutations. Read transactions and all other graph.Database functions
+// are passed through to the wrapped database unmodified.
+type Database struct {
+	graph.Database
+
+	sinks []Sink
+}
+
+func Wrap(db graph.Database, sinks ...Sink) *Database {
+	return &Database{
+		Database: db,
+		sinks:    sinks,
+	}
+}
+
+// Find returns the change feed in the wrapper chain of the given database, if there is one.
+func Find(db graph.Database) (*Database, bool) {
+	for db != nil {
+		if changeFeed, isChangeFeed := db.(*Database); isChangeFeed {
+			return changeFeed, true
+		} else if wrapper, isWrapper := db.(graph.DatabaseWrapper); isWrapper {
+			db = wrapper.Unwrap()
+		} else {
+			break
+		}
+	}
+
+	return nil, false
+}
+
+// Unwrap returns the graph.Database that this change feed wraps.
+func (s *Database) Unwrap() graph.Database {
+	return s.Database
+}
+
+// Rewrap returns a new change feed that wraps the given database and publishes to the same sinks as this one. It is
+// used when the database behind a change feed is replaced, for example by switching graph drivers.
+func (s *Database) Rewrap(db graph.Database) *Database {
+	return Wrap(db, s.sinks...)
+}
+
+// Sinks returns the sinks this change feed publishes to.
+func (s *Database) Sinks() []Sink {
+	return s.sinks
+}
+
+// Feed returns the first in-process Feed this change feed publishes to, if there is one.
+func (s *Database) Feed() (*Feed, bool) {
+	for _, sink := range s.sinks {
+		if feed, isFeed := sink.(*Feed); isFeed {
+			return feed, true
+		}
+	}
+
+	return nil, false
+}
+
+// AssertGraph forwards graph management to the wrapped database if it supports more than one named graph.
+func (s *Database) AssertGraph(ctx context.Context, graphSchema graph.Graph) error {
+	if graphManager, isManager := s.Database.(graph.GraphManager); !isManager {
+		return graph.ErrGraphManagementUnsupported
+	} else {
+		return graphManager.AssertGraph(ctx, graphSchema)
+	}
+}
+
+// DropGraph forwards graph management to the wrapped database if it supports more than one named graph. Dropping a
+// graph is not recorded as a change since consumers are expected to track graph lifecycles separately.
+func (s *Database) DropGraph(ctx context.Context, name string) error {
+	if graphManager, isManager := s.Database.(graph.GraphManager); !isManager {
+		return graph.ErrGraphManagementUnsupported
+	} else {
+		return graphManager.DropGraph(ctx, name)
+	}
+}
+
+func (s *Database) publish(ctx context.Context, changes []Change) {
+	if len(changes) == 0 {
+		return
+	}
+
+	var (
+		// The changes have been committed so publishing them must not be abandoned if the context of the commit is
+		// cancelled
+		publishCtx = context.WithoutCancel(ctx)
+		event      = Event{
+			Changes:     changes,
+			CommittedAt: time.Now().UTC(),
+		}
+	)
+
+	for _, sink := range s.sinks {
+		if err := sink.Write(publishCtx, event); err != nil {
+			log.Errorf("Failed writing graph change event with %d changes to sink: %v", len(changes), err)
+		}
+	}
+}
+
+func (s *Database) WriteTransaction(ctx context.Context, txDelegate graph.TransactionDelegate, options ...graph.TransactionOption) error {
+	var tx *transaction
+
+	if err := s.Database.WriteTransaction(ctx, func(innerTx graph.Transaction) error {
+		// The delegate may be invoked more than once if the driver retries the transaction so only changes recorded
+		// by the final invocation are considered
+		tx = &transaction{
+			recorder: newRecorder(ctx, s),
+			tx:       innerTx,
+		}
+
+		return txDelegate(tx)
+	}, options...); err != nil {
+		return err
+	}
+
+	if tx != nil {
+		tx.flush()
+	}
+
+	return nil
+}
+
+func (s *Database) BatchOperation(ctx context.Context, batchDelegate graph.BatchDelegate) error {
+	var operation *batch
+
+	if err := s.Database.BatchOperation(ctx, func(innerBatch graph.Batch) error {
+		operation = &batch{
+			recorder: newRecorder(ctx, s),
+			batch:    innerBatch,
+		}
+
+		return batchDelegate(operation)
+	}); err != nil {
+		return err
+	}
+
+	if operation != nil {
+		operation.flush()
+	}
+
+	return nil
+}
+
+// recorder accumulates the changes made by a single transaction or batch until they are committed.
+type recorder struct {
+	ctx     context.Context
+	db      *Database
+	pending []Change
+}
+
+func newRecorder(ctx context.Context, db *Database) *recorder {
+	return &recorder{
+		ctx: ctx,
+		db:  db,
+	}
+}
+
+func (s *recorder) record(changes ...Change) {
+	s.pending = append(s.pending, changes...)
+}
+
+func (s *recorder) flush() {
+	changes := s.pending
+	s.pending = nil
+
+	s.db.publish(s.ctx, changes)
+}
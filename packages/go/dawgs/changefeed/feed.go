@@ -0,0 +1,101 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package changefeed
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// Feed is an in-process Sink that fans events out to subscribers. Delivery never blocks the committing goroutine: if
+// a subscriber's buffer is full the event is dropped for that subscriber and counted against its Dropped total.
+type Feed struct {
+	subscribers map[*Subscription]struct{}
+	lock        *sync.RWMutex
+}
+
+func NewFeed() *Feed {
+	return &Feed{
+		subscribers: map[*Subscription]struct{}{},
+		lock:        &sync.RWMutex{},
+	}
+}
+
+type Subscription struct {
+	feed    *Feed
+	events  chan Event
+	dropped *atomic.Int64
+	closed  bool
+}
+
+// Events returns the channel events are delivered on. The channel is closed when the subscription is cancelled.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Dropped returns the number of events that were not delivered because the subscription's buffer was full.
+func (s *Subscription) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+// Cancel removes the subscription from its feed and closes the events channel.
+func (s *Subscription) Cancel() {
+	s.feed.unsubscribe(s)
+}
+
+// Subscribe registers a new subscription with the given event buffer size.
+func (s *Feed) Subscribe(buffer int) *Subscription {
+	subscription := &Subscription{
+		feed:    s,
+		events:  make(chan Event, buffer),
+		dropped: &atomic.Int64{},
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.subscribers[subscription] = struct{}{}
+	return subscription
+}
+
+func (s *Feed) unsubscribe(subscription *Subscription) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !subscription.closed {
+		subscription.closed = true
+
+		delete(s.subscribers, subscription)
+		close(subscription.events)
+	}
+}
+
+func (s *Feed) Write(_ context.Context, event Event) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	for subscription := range s.subscribers {
+		select {
+		case subscription.events <- event:
+		default:
+			subscription.dropped.Add(1)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,309 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package changefeed
+
+import (
+	"github.com/specterops/bloodhound/dawgs/graph"
+)
+
+// nodeQuery records the nodes affected by query deletes and updates. Query builders accumulate their criteria in
+// place, so the builder calls are also replayed against a fresh query to fetch the affected nodes before the
+// mutation is executed.
+type nodeQuery struct {
+	recorder *recorder
+	newQuery func() graph.NodeQuery
+	query    graph.NodeQuery
+	builders []func(query graph.NodeQuery) graph.NodeQuery
+}
+
+func newNodeQuery(recorder *recorder, newQuery func() graph.NodeQuery) *nodeQuery {
+	return &nodeQuery{
+		recorder: recorder,
+		newQuery: newQuery,
+		query:    newQuery(),
+	}
+}
+
+func (s *nodeQuery) apply(builder func(query graph.NodeQuery) graph.NodeQuery) graph.NodeQuery {
+	s.builders = append(s.builders, builder)
+	s.query = builder(s.query)
+
+	return s
+}
+
+func (s *nodeQuery) affected() ([]graph.KindsResult, error) {
+	var (
+		query    = s.newQuery()
+		affected []graph.KindsResult
+	)
+
+	for _, builder := range s.builders {
+		query = builder(query)
+	}
+
+	return affected, query.FetchKinds(func(cursor graph.Cursor[graph.KindsResult]) error {
+		for next := range cursor.Chan() {
+			affected = append(affected, next)
+		}
+
+		return cursor.Error()
+	})
+}
+
+func (s *nodeQuery) Filter(criteria graph.Criteria) graph.NodeQuery {
+	return s.apply(func(query graph.NodeQuery) graph.NodeQuery {
+		return query.Filter(criteria)
+	})
+}
+
+func (s *nodeQuery) Filterf(criteriaDelegate graph.CriteriaProvider) graph.NodeQuery {
+	return s.Filter(criteriaDelegate())
+}
+
+func (s *nodeQuery) OrderBy(criteria ...graph.Criteria) graph.NodeQuery {
+	return s.apply(func(query graph.NodeQuery) graph.NodeQuery {
+		return query.OrderBy(criteria...)
+	})
+}
+
+func (s *nodeQuery) Offset(skip int) graph.NodeQuery {
+	return s.apply(func(query graph.NodeQuery) graph.NodeQuery {
+		return query.Offset(skip)
+	})
+}
+
+func (s *nodeQuery) Limit(limit int) graph.NodeQuery {
+	return s.apply(func(query graph.NodeQuery) graph.NodeQuery {
+		return query.Limit(limit)
+	})
+}
+
+func (s *nodeQuery) Delete() error {
+	if affected, err := s.affected(); err != nil {
+		return err
+	} else if err := s.query.Delete(); err != nil {
+		return err
+	} else {
+		for _, node := range affected {
+			s.recorder.record(Change{
+				Entity:    EntityNode,
+				Operation: OperationDelete,
+				ID:        node.ID,
+				Kinds:     kindStrings(node.Kinds),
+			})
+		}
+	}
+
+	return nil
+}
+
+func (s *nodeQuery) Update(properties *graph.Properties) error {
+	if affected, err := s.affected(); err != nil {
+		return err
+	} else if err := s.query.Update(properties); err != nil {
+		return err
+	} else {
+		propertyKeys := changedPropertyKeys(properties)
+
+		for _, node := range affected {
+			s.recorder.record(Change{
+				Entity:       EntityNode,
+				Operation:    OperationUpdate,
+				ID:           node.ID,
+				Kinds:        kindStrings(node.Kinds),
+				PropertyKeys: propertyKeys,
+			})
+		}
+	}
+
+	return nil
+}
+
+func (s *nodeQuery) Query(delegate func(results graph.Result) error, finalCriteria ...graph.Criteria) error {
+	return s.query.Query(delegate, finalCriteria...)
+}
+
+func (s *nodeQuery) Count() (int64, error) {
+	return s.query.Count()
+}
+
+func (s *nodeQuery) First() (*graph.Node, error) {
+	return s.query.First()
+}
+
+func (s *nodeQuery) Fetch(delegate func(cursor graph.Cursor[*graph.Node]) error) error {
+	return s.query.Fetch(delegate)
+}
+
+func (s *nodeQuery) FetchIDs(delegate func(cursor graph.Cursor[graph.ID]) error) error {
+	return s.query.FetchIDs(delegate)
+}
+
+func (s *nodeQuery) FetchKinds(delegate func(cursor graph.Cursor[graph.KindsResult]) error) error {
+	return s.query.FetchKinds(delegate)
+}
+
+// relationshipQuery records the relationships affected by query deletes and updates in the same manner as nodeQuery.
+type relationshipQuery struct {
+	recorder *recorder
+	newQuery func() graph.RelationshipQuery
+	query    graph.RelationshipQuery
+	builders []func(query graph.RelationshipQuery) graph.RelationshipQuery
+}
+
+func newRelationshipQuery(recorder *recorder, newQuery func() graph.RelationshipQuery) *relationshipQuery {
+	return &relationshipQuery{
+		recorder: recorder,
+		newQuery: newQuery,
+		query:    newQuery(),
+	}
+}
+
+func (s *relationshipQuery) apply(builder func(query graph.RelationshipQuery) graph.RelationshipQuery) graph.RelationshipQuery {
+	s.builders = append(s.builders, builder)
+	s.query = builder(s.query)
+
+	return s
+}
+
+func (s *relationshipQuery) affected() ([]graph.RelationshipKindsResult, error) {
+	var (
+		query    = s.newQuery()
+		affected []graph.RelationshipKindsResult
+	)
+
+	for _, builder := range s.builders {
+		query = builder(query)
+	}
+
+	return affected, query.FetchKinds(func(cursor graph.Cursor[graph.RelationshipKindsResult]) error {
+		for next := range cursor.Chan() {
+			affected = append(affected, next)
+		}
+
+		return cursor.Error()
+	})
+}
+
+func (s *relationshipQuery) Filter(criteria graph.Criteria) graph.RelationshipQuery {
+	return s.apply(func(query graph.RelationshipQuery) graph.RelationshipQuery {
+		return query.Filter(criteria)
+	})
+}
+
+func (s *relationshipQuery) Filterf(criteriaDelegate graph.CriteriaProvider) graph.RelationshipQuery {
+	return s.Filter(criteriaDelegate())
+}
+
+func (s *relationshipQuery) OrderBy(criteria ...graph.Criteria) graph.RelationshipQuery {
+	return s.apply(func(query graph.RelationshipQuery) graph.RelationshipQuery {
+		return query.OrderBy(criteria...)
+	})
+}
+
+func (s *relationshipQuery) Offset(skip int) graph.RelationshipQuery {
+	return s.apply(func(query graph.RelationshipQuery) graph.RelationshipQuery {
+		return query.Offset(skip)
+	})
+}
+
+func (s *relationshipQuery) Limit(limit int) graph.RelationshipQuery {
+	return s.apply(func(query graph.RelationshipQuery) graph.RelationshipQuery {
+		return query.Limit(limit)
+	})
+}
+
+func (s *relationshipQuery) Delete() error {
+	if affected, err := s.affected(); err != nil {
+		return err
+	} else if err := s.query.Delete(); err != nil {
+		return err
+	} else {
+		for _, relationship := range affected {
+			s.recorder.record(Change{
+				Entity:    EntityRelationship,
+				Operation: OperationDelete,
+				ID:        relationship.ID,
+				Kinds:     kindStrings(graph.Kinds{relationship.Kind}),
+				StartID:   relationship.StartID,
+				EndID:     relationship.EndID,
+			})
+		}
+	}
+
+	return nil
+}
+
+func (s *relationshipQuery) Update(properties *graph.Properties) error {
+	if affected, err := s.affected(); err != nil {
+		return err
+	} else if err := s.query.Update(properties); err != nil {
+		return err
+	} else {
+		propertyKeys := changedPropertyKeys(properties)
+
+		for _, relationship := range affected {
+			s.recorder.record(Change{
+				Entity:       EntityRelationship,
+				Operation:    OperationUpdate,
+				ID:           relationship.ID,
+				Kinds:        kindStrings(graph.Kinds{relationship.Kind}),
+				StartID:      relationship.StartID,
+				EndID:        relationship.EndID,
+				PropertyKeys: propertyKeys,
+			})
+		}
+	}
+
+	return nil
+}
+
+func (s *relationshipQuery) Query(delegate func(results graph.Result) error, finalCriteria ...graph.Criteria) error {
+	return s.query.Query(delegate, finalCriteria...)
+}
+
+func (s *relationshipQuery) Count() (int64, error) {
+	return s.query.Count()
+}
+
+func (s *relationshipQuery) First() (*graph.Relationship, error) {
+	return s.query.First()
+}
+
+func (s *relationshipQuery) Fetch(delegate func(cursor graph.Cursor[*graph.Relationship]) error) error {
+	return s.query.Fetch(delegate)
+}
+
+func (s *relationshipQuery) FetchDirection(direction graph.Direction, delegate func(cursor graph.Cursor[graph.DirectionalResult]) error) error {
+	return s.query.FetchDirection(direction, delegate)
+}
+
+func (s *relationshipQuery) FetchIDs(delegate func(cursor graph.Cursor[graph.ID]) error) error {
+	return s.query.FetchIDs(delegate)
+}
+
+func (s *relationshipQuery) FetchTriples(delegate func(cursor graph.Cursor[graph.RelationshipTripleResult]) error) error {
+	return s.query.FetchTriples(delegate)
+}
+
+func (s *relationshipQuery) FetchAllShortestPaths(delegate func(cursor graph.Cursor[graph.Path]) error) error {
+	return s.query.FetchAllShortestPaths(delegate)
+}
+
+func (s *relationshipQuery) FetchKinds(delegate func(cursor graph.Cursor[graph.RelationshipKindsResult]) error) error {
+	return s.query.FetchKinds(delegate)
+}
@@ -0,0 +1,168 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package changefeed
+
+import (
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/util/size"
+)
+
+type transaction struct {
+	*recorder
+
+	tx graph.Transaction
+}
+
+func (s *transaction) WithGraph(graphSchema graph.Graph) graph.Transaction {
+	s.tx = s.tx.WithGraph(graphSchema)
+	return s
+}
+
+func (s *transaction) CreateNode(properties *graph.Properties, kinds ...graph.Kind) (*graph.Node, error) {
+	node, err := s.tx.CreateNode(properties, kinds...)
+
+	if err == nil {
+		s.record(Change{
+			Entity:       EntityNode,
+			Operation:    OperationCreate,
+			ID:           node.ID,
+			Kinds:        kindStrings(kinds),
+			PropertyKeys: propertyKeys(properties),
+		})
+	}
+
+	return node, err
+}
+
+func (s *transaction) UpdateNode(node *graph.Node) error {
+	if err := s.tx.UpdateNode(node); err != nil {
+		return err
+	}
+
+	s.record(Change{
+		Entity:       EntityNode,
+		Operation:    OperationUpdate,
+		ID:           node.ID,
+		Kinds:        kindStrings(node.Kinds),
+		AddedKinds:   kindStrings(node.AddedKinds),
+		DeletedKinds: kindStrings(node.DeletedKinds),
+		PropertyKeys: changedPropertyKeys(node.Properties),
+	})
+
+	return nil
+}
+
+func (s *transaction) Nodes() graph.NodeQuery {
+	return newNodeQuery(s.recorder, s.tx.Nodes)
+}
+
+func (s *transaction) CreateRelationshipByIDs(startNodeID, endNodeID graph.ID, kind graph.Kind, properties *graph.Properties) (*graph.Relationship, error) {
+	relationship, err := s.tx.CreateRelationshipByIDs(startNodeID, endNodeID, kind, properties)
+
+	if err == nil {
+		s.record(Change{
+			Entity:       EntityRelationship,
+			Operation:    OperationCreate,
+			ID:           relationship.ID,
+			Kinds:        kindStrings(graph.Kinds{kind}),
+			StartID:      startNodeID,
+			EndID:        endNodeID,
+			PropertyKeys: propertyKeys(properties),
+		})
+	}
+
+	return relationship, err
+}
+
+func (s *transaction) UpdateRelationship(relationship *graph.Relationship) error {
+	if err := s.tx.UpdateRelationship(relationship); err != nil {
+		return err
+	}
+
+	s.record(Change{
+		Entity:       EntityRelationship,
+		Operation:    OperationUpdate,
+		ID:           relationship.ID,
+		Kinds:        kindStrings(graph.Kinds{relationship.Kind}),
+		StartID:      relationship.StartID,
+		EndID:        relationship.EndID,
+		PropertyKeys: changedPropertyKeys(relationship.Properties),
+	})
+
+	return nil
+}
+
+func (s *transaction) Relationships() graph.RelationshipQuery {
+	return newRelationshipQuery(s.recorder, s.tx.Relationships)
+}
+
+func (s *transaction) Raw(query string, parameters map[string]any) graph.Result {
+	result := s.tx.Raw(query, parameters)
+
+	if result.Error() == nil {
+		s.record(Change{
+			Entity:    EntityQuery,
+			Operation: OperationRaw,
+			Query:     query,
+		})
+	}
+
+	return result
+}
+
+func (s *transaction) Query(query string, parameters map[string]any) graph.Result {
+	return s.tx.Query(query, parameters)
+}
+
+func (s *transaction) Explain(query string, parameters map[string]any) (graph.QueryPlan, error) {
+	return graph.Explain(s.tx, query, parameters)
+}
+
+func (s *transaction) Mutate(query string, parameters map[string]any) (graph.MutationSummary, error) {
+	summary, err := graph.Mutate(s.tx, query, parameters)
+
+	if err == nil && summary.ContainsUpdates() {
+		recordedSummary := summary
+
+		s.record(Change{
+			Entity:    EntityQuery,
+			Operation: OperationMutate,
+			Query:     query,
+			Summary:   &recordedSummary,
+		})
+	}
+
+	return summary, err
+}
+
+func (s *transaction) PropertyKeys() ([]string, error) {
+	return graph.PropertyKeys(s.tx)
+}
+
+func (s *transaction) Commit() error {
+	if err := s.tx.Commit(); err != nil {
+		return err
+	}
+
+	// Changes made before an explicit commit are durable even if the remainder of the transaction fails
+	s.flush()
+	return nil
+}
+
+func (s *transaction) TraversalMemoryLimit() size.Size {
+	return s.tx.TraversalMemoryLimit()
+}
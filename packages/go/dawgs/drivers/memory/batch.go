@@ -0,0 +1,109 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"github.com/specterops/bloodhound/dawgs/graph"
+)
+
+type batch struct {
+	*session
+}
+
+func (s *batch) WithGraph(graphSchema graph.Graph) graph.Batch {
+	// The memory driver only supports a single graph
+	return s
+}
+
+func (s *batch) CreateNode(node *graph.Node) error {
+	return s.write(func(graphStore *store) (undoFunc, error) {
+		_, undo := graphStore.createNode(node)
+		return undo, nil
+	})
+}
+
+func (s *batch) DeleteNode(id graph.ID) error {
+	return s.write(func(graphStore *store) (undoFunc, error) {
+		return graphStore.deleteNode(id), nil
+	})
+}
+
+func (s *batch) Nodes() graph.NodeQuery {
+	return s.nodes()
+}
+
+func (s *batch) Relationships() graph.RelationshipQuery {
+	return s.relationships()
+}
+
+func (s *batch) UpdateNodeBy(update graph.NodeUpdate) error {
+	return s.write(func(graphStore *store) (undoFunc, error) {
+		_, undo := graphStore.upsertNode(update)
+		return undo, nil
+	})
+}
+
+func (s *batch) CreateRelationship(relationship *graph.Relationship) error {
+	return s.write(func(graphStore *store) (undoFunc, error) {
+		return graphStore.upsertRelationship(relationship.StartID, relationship.EndID, relationship.Kind, relationship.Properties)
+	})
+}
+
+func (s *batch) CreateRelationshipByIDs(startNodeID, endNodeID graph.ID, kind graph.Kind, properties *graph.Properties) error {
+	return s.write(func(graphStore *store) (undoFunc, error) {
+		return graphStore.upsertRelationship(startNodeID, endNodeID, kind, properties)
+	})
+}
+
+func (s *batch) DeleteRelationship(id graph.ID) error {
+	return s.write(func(graphStore *store) (undoFunc, error) {
+		return graphStore.deleteRelationship(id), nil
+	})
+}
+
+func (s *batch) UpdateRelationshipBy(update graph.RelationshipUpdate) error {
+	return s.write(func(graphStore *store) (undoFunc, error) {
+		startID, undoStart := graphStore.upsertNode(graph.NodeUpdate{
+			Node:               update.Start,
+			IdentityKind:       update.StartIdentityKind,
+			IdentityProperties: update.StartIdentityProperties,
+		})
+
+		endID, undoEnd := graphStore.upsertNode(graph.NodeUpdate{
+			Node:               update.End,
+			IdentityKind:       update.EndIdentityKind,
+			IdentityProperties: update.EndIdentityProperties,
+		})
+
+		if undoRelationship, err := graphStore.upsertRelationship(startID, endID, update.Relationship.Kind, update.Relationship.Properties); err != nil {
+			undoEnd(graphStore)
+			undoStart(graphStore)
+
+			return nil, err
+		} else {
+			return func(s *store) {
+				undoRelationship(s)
+				undoEnd(s)
+				undoStart(s)
+			}, nil
+		}
+	})
+}
+
+func (s *batch) Commit() error {
+	return s.commit()
+}
@@ -0,0 +1,614 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/specterops/bloodhound/cypher/model"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/query"
+)
+
+// binding carries the entities that criteria may reference by their query symbol. Criteria are evaluated against a
+// binding with the store's read lock held.
+type binding struct {
+	store        *store
+	node         *graph.Node
+	start        *graph.Node
+	relationship *graph.Relationship
+	end          *graph.Node
+}
+
+func (s binding) lookup(symbol string) (any, error) {
+	var entity any
+
+	switch symbol {
+	case query.NodeSymbol:
+		entity = s.node
+
+	case query.EdgeSymbol:
+		entity = s.relationship
+
+	case query.EdgeStartSymbol:
+		entity = s.start
+
+	case query.EdgeEndSymbol:
+		entity = s.end
+
+	default:
+		return nil, fmt.Errorf("unknown query symbol: %s", symbol)
+	}
+
+	if reflect.ValueOf(entity).IsNil() {
+		return nil, fmt.Errorf("query symbol %s is not bound for this query", symbol)
+	}
+
+	return entity, nil
+}
+
+// matches evaluates the given criteria against the binding. Criteria that evaluate to null are treated as not matching.
+// Nil criteria match everything.
+func matches(criteria graph.Criteria, bound binding) (bool, error) {
+	if criteria == nil {
+		return true, nil
+	}
+
+	if value, err := evaluate(criteria, bound); err != nil {
+		return false, err
+	} else {
+		return value == true, nil
+	}
+}
+
+// Boolean operators follow cypher's three-valued logic where a nil value represents null.
+
+func and(left, right any) any {
+	if left == false || right == false {
+		return false
+	}
+
+	if left == nil || right == nil {
+		return nil
+	}
+
+	return true
+}
+
+func or(left, right any) any {
+	if left == true || right == true {
+		return true
+	}
+
+	if left == nil || right == nil {
+		return nil
+	}
+
+	return false
+}
+
+func not(value any) any {
+	if value == nil {
+		return nil
+	}
+
+	return value != true
+}
+
+func asBoolean(value any) (any, error) {
+	switch value.(type) {
+	case nil, bool:
+		return value, nil
+
+	default:
+		return nil, fmt.Errorf("expected a boolean expression but got %T", value)
+	}
+}
+
+func evaluateBoolean(expression model.Expression, bound binding) (any, error) {
+	if value, err := evaluate(expression, bound); err != nil {
+		return nil, err
+	} else {
+		return asBoolean(value)
+	}
+}
+
+func evaluateConjunction(expressions []model.Expression, bound binding) (any, error) {
+	var result any = true
+
+	for _, expression := range expressions {
+		if value, err := evaluateBoolean(expression, bound); err != nil {
+			return nil, err
+		} else if result = and(result, value); result == false {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+func evaluate(expression model.Expression, bound binding) (any, error) {
+	switch typedExpression := expression.(type) {
+	case *model.Where:
+		return evaluateConjunction(typedExpression.Expressions, bound)
+
+	case *model.Conjunction:
+		return evaluateConjunction(typedExpression.Expressions, bound)
+
+	case *model.Disjunction:
+		var result any = false
+
+		for _, expression := range typedExpression.Expressions {
+			if value, err := evaluateBoolean(expression, bound); err != nil {
+				return nil, err
+			} else if result = or(result, value); result == true {
+				break
+			}
+		}
+
+		return result, nil
+
+	case *model.ExclusiveDisjunction:
+		var result any = false
+
+		for _, expression := range typedExpression.Expressions {
+			if value, err := evaluateBoolean(expression, bound); err != nil {
+				return nil, err
+			} else if result == nil || value == nil {
+				result = nil
+			} else {
+				result = result != value
+			}
+		}
+
+		return result, nil
+
+	case *model.Parenthetical:
+		return evaluate(typedExpression.Expression, bound)
+
+	case *model.Negation:
+		if value, err := evaluateBoolean(typedExpression.Expression, bound); err != nil {
+			return nil, err
+		} else {
+			return not(value), nil
+		}
+
+	case *model.Not:
+		if value, err := evaluateBoolean(typedExpression.Expression, bound); err != nil {
+			return nil, err
+		} else {
+			return not(value), nil
+		}
+
+	case *model.Comparison:
+		return evaluateComparison(typedExpression, bound)
+
+	case *model.KindMatcher:
+		return evaluateKindMatcher(typedExpression, bound)
+
+	case *model.PatternPredicate:
+		return evaluatePatternPredicate(typedExpression, bound)
+
+	case *model.FunctionInvocation:
+		return evaluateFunction(typedExpression, bound)
+
+	case *model.PropertyLookup:
+		if len(typedExpression.Symbols) != 1 {
+			return nil, fmt.Errorf("unsupported property lookup: %v", typedExpression.Symbols)
+		} else if entity, err := evaluate(typedExpression.Atom, bound); err != nil {
+			return nil, err
+		} else {
+			switch typedEntity := entity.(type) {
+			case *graph.Node:
+				return typedEntity.Properties.Map[typedExpression.Symbols[0]], nil
+
+			case *graph.Relationship:
+				return typedEntity.Properties.Map[typedExpression.Symbols[0]], nil
+
+			case nil:
+				return nil, nil
+
+			default:
+				return nil, fmt.Errorf("unable to look up property %s on type %T", typedExpression.Symbols[0], entity)
+			}
+		}
+
+	case *model.Variable:
+		return bound.lookup(typedExpression.Symbol)
+
+	case *model.Parameter:
+		return typedExpression.Value, nil
+
+	case *model.Literal:
+		if typedExpression.Null {
+			return nil, nil
+		}
+
+		if stringValue, isString := typedExpression.Value.(string); isString && len(stringValue) >= 2 && strings.HasPrefix(stringValue, "'") && strings.HasSuffix(stringValue, "'") {
+			return stringValue[1 : len(stringValue)-1], nil
+		}
+
+		return typedExpression.Value, nil
+
+	case model.ListLiteral:
+		values := make([]any, len(typedExpression))
+
+		for idx, element := range typedExpression {
+			if value, err := evaluate(element, bound); err != nil {
+				return nil, err
+			} else {
+				values[idx] = value
+			}
+		}
+
+		return values, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported criteria type for the memory driver: %T", expression)
+	}
+}
+
+func evaluateKindMatcher(kindMatcher *model.KindMatcher, bound binding) (any, error) {
+	if entity, err := evaluate(kindMatcher.Reference, bound); err != nil {
+		return nil, err
+	} else {
+		switch typedEntity := entity.(type) {
+		case *graph.Node:
+			for _, kind := range kindMatcher.Kinds {
+				if !typedEntity.Kinds.ContainsOneOf(kind) {
+					return false, nil
+				}
+			}
+
+			return true, nil
+
+		case *graph.Relationship:
+			return kindMatcher.Kinds.ContainsOneOf(typedEntity.Kind), nil
+
+		default:
+			return nil, fmt.Errorf("unable to match kinds on type %T", entity)
+		}
+	}
+}
+
+// evaluatePatternPredicate supports single hop pattern predicates anchored on a bound node such as the ones built by
+// query.HasRelationships.
+func evaluatePatternPredicate(patternPredicate *model.PatternPredicate, bound binding) (any, error) {
+	if len(patternPredicate.PatternElements) != 3 {
+		return nil, fmt.Errorf("unsupported pattern predicate with %d elements", len(patternPredicate.PatternElements))
+	}
+
+	if nodePattern, typeOK := patternPredicate.PatternElements[0].Element.(*model.NodePattern); !typeOK || nodePattern.Binding == nil {
+		return nil, fmt.Errorf("pattern predicates must begin with a bound node pattern")
+	} else if relationshipPattern, typeOK := patternPredicate.PatternElements[1].Element.(*model.RelationshipPattern); !typeOK {
+		return nil, fmt.Errorf("expected a relationship pattern but got %T", patternPredicate.PatternElements[1].Element)
+	} else if entity, err := evaluate(nodePattern.Binding, bound); err != nil {
+		return nil, err
+	} else if node, typeOK := entity.(*graph.Node); !typeOK {
+		return nil, fmt.Errorf("expected a node binding but got %T", entity)
+	} else {
+		for _, relationshipID := range bound.store.adjacent(node.ID, relationshipPattern.Direction) {
+			if len(relationshipPattern.Kinds) == 0 || relationshipPattern.Kinds.ContainsOneOf(bound.store.relationships[relationshipID].Kind) {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+}
+
+func evaluateFunction(function *model.FunctionInvocation, bound binding) (any, error) {
+	if len(function.Arguments) != 1 {
+		return nil, fmt.Errorf("unsupported function invocation %s with %d arguments", function.Name, len(function.Arguments))
+	}
+
+	argument, err := evaluate(function.Arguments[0], bound)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(function.Name) {
+	case "id":
+		switch typedArgument := argument.(type) {
+		case *graph.Node:
+			return typedArgument.ID, nil
+
+		case *graph.Relationship:
+			return typedArgument.ID, nil
+		}
+
+	case "labels":
+		if node, typeOK := argument.(*graph.Node); typeOK {
+			return node.Kinds, nil
+		}
+
+	case "type":
+		if relationship, typeOK := argument.(*graph.Relationship); typeOK {
+			return relationship.Kind, nil
+		}
+
+	case "tolower":
+		switch typedArgument := argument.(type) {
+		case nil:
+			return nil, nil
+
+		case string:
+			return strings.ToLower(typedArgument), nil
+		}
+
+	case "toupper":
+		switch typedArgument := argument.(type) {
+		case nil:
+			return nil, nil
+
+		case string:
+			return strings.ToUpper(typedArgument), nil
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported function for the memory driver: %s", function.Name)
+	}
+
+	return nil, fmt.Errorf("unsupported argument type %T for function %s", argument, function.Name)
+}
+
+func evaluateComparison(comparison *model.Comparison, bound binding) (any, error) {
+	var result any = true
+
+	left, err := evaluate(comparison.Left, bound)
+	if err != nil {
+		return nil, err
+	}
+
+	// Chained comparisons such as a < b < c are evaluated as a < b and b < c
+	for _, partial := range comparison.Partials {
+		if right, err := evaluate(partial.Right, bound); err != nil {
+			return nil, err
+		} else if value, err := compare(left, partial.Operator, right); err != nil {
+			return nil, err
+		} else {
+			result = and(result, value)
+			left = right
+		}
+	}
+
+	return result, nil
+}
+
+func compare(left any, operator model.Operator, right any) (any, error) {
+	switch operator {
+	case model.OperatorIs:
+		return left == nil, nil
+
+	case model.OperatorIsNot:
+		return left != nil, nil
+	}
+
+	if left == nil || right == nil {
+		return nil, nil
+	}
+
+	switch operator {
+	case model.OperatorEquals:
+		return valuesEqual(left, right), nil
+
+	case model.OperatorNotEquals:
+		return !valuesEqual(left, right), nil
+
+	case model.OperatorGreaterThan, model.OperatorGreaterThanOrEqualTo, model.OperatorLessThan, model.OperatorLessThanOrEqualTo:
+		if ordering, comparable := compareValues(left, right); !comparable {
+			return nil, nil
+		} else {
+			switch operator {
+			case model.OperatorGreaterThan:
+				return ordering > 0, nil
+
+			case model.OperatorGreaterThanOrEqualTo:
+				return ordering >= 0, nil
+
+			case model.OperatorLessThan:
+				return ordering < 0, nil
+
+			default:
+				return ordering <= 0, nil
+			}
+		}
+
+	case model.OperatorStartsWith, model.OperatorEndsWith, model.OperatorContains:
+		leftString, leftIsString := left.(string)
+		rightString, rightIsString := right.(string)
+
+		if !leftIsString || !rightIsString {
+			return nil, nil
+		}
+
+		switch operator {
+		case model.OperatorStartsWith:
+			return strings.HasPrefix(leftString, rightString), nil
+
+		case model.OperatorEndsWith:
+			return strings.HasSuffix(leftString, rightString), nil
+
+		default:
+			return strings.Contains(leftString, rightString), nil
+		}
+
+	case model.OperatorIn:
+		if candidates := reflect.ValueOf(right); candidates.Kind() != reflect.Slice && candidates.Kind() != reflect.Array {
+			return nil, fmt.Errorf("expected a list on the right side of an in comparison but got %T", right)
+		} else {
+			for idx := 0; idx < candidates.Len(); idx++ {
+				if valuesEqual(left, candidates.Index(idx).Interface()) {
+					return true, nil
+				}
+			}
+
+			return false, nil
+		}
+
+	case model.OperatorRegexMatch:
+		leftString, leftIsString := left.(string)
+		rightString, rightIsString := right.(string)
+
+		if !leftIsString || !rightIsString {
+			return nil, nil
+		}
+
+		if matched, err := regexp.MatchString("^(?:"+rightString+")$", leftString); err != nil {
+			return nil, fmt.Errorf("invalid regular expression %s: %w", rightString, err)
+		} else {
+			return matched, nil
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported comparison operator for the memory driver: %s", operator)
+	}
+}
+
+func asInteger(value any) (int64, bool) {
+	switch typedValue := value.(type) {
+	case int:
+		return int64(typedValue), true
+	case int8:
+		return int64(typedValue), true
+	case int16:
+		return int64(typedValue), true
+	case int32:
+		return int64(typedValue), true
+	case int64:
+		return typedValue, true
+	case uint:
+		return int64(typedValue), true
+	case uint8:
+		return int64(typedValue), true
+	case uint16:
+		return int64(typedValue), true
+	case uint32:
+		return int64(typedValue), true
+	case uint64:
+		return int64(typedValue), true
+	case graph.ID:
+		return typedValue.Int64(), true
+	default:
+		return 0, false
+	}
+}
+
+func asFloat(value any) (float64, bool) {
+	switch typedValue := value.(type) {
+	case float32:
+		return float64(typedValue), true
+	case float64:
+		return typedValue, true
+	default:
+		if integerValue, isInteger := asInteger(value); isInteger {
+			return float64(integerValue), true
+		}
+
+		return 0, false
+	}
+}
+
+func asString(value any) (string, bool) {
+	switch typedValue := value.(type) {
+	case string:
+		return typedValue, true
+	case graph.Kind:
+		return typedValue.String(), true
+	default:
+		return "", false
+	}
+}
+
+// compareValues orders two values of compatible types. The second return value is false if the values may not be
+// ordered against each other.
+func compareValues(left, right any) (int, bool) {
+	if leftInteger, leftIsInteger := asInteger(left); leftIsInteger {
+		if rightInteger, rightIsInteger := asInteger(right); rightIsInteger {
+			switch {
+			case leftInteger < rightInteger:
+				return -1, true
+			case leftInteger > rightInteger:
+				return 1, true
+			default:
+				return 0, true
+			}
+		}
+	}
+
+	if leftFloat, leftIsFloat := asFloat(left); leftIsFloat {
+		if rightFloat, rightIsFloat := asFloat(right); rightIsFloat {
+			switch {
+			case leftFloat < rightFloat:
+				return -1, true
+			case leftFloat > rightFloat:
+				return 1, true
+			default:
+				return 0, true
+			}
+		}
+
+		return 0, false
+	}
+
+	if leftString, leftIsString := asString(left); leftIsString {
+		if rightString, rightIsString := asString(right); rightIsString {
+			return strings.Compare(leftString, rightString), true
+		}
+
+		return 0, false
+	}
+
+	if leftTime, leftIsTime := left.(time.Time); leftIsTime {
+		if rightTime, rightIsTime := right.(time.Time); rightIsTime {
+			return leftTime.Compare(rightTime), true
+		}
+
+		return 0, false
+	}
+
+	if leftBool, leftIsBool := left.(bool); leftIsBool {
+		if rightBool, rightIsBool := right.(bool); rightIsBool {
+			switch {
+			case leftBool == rightBool:
+				return 0, true
+			case rightBool:
+				return -1, true
+			default:
+				return 1, true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+func valuesEqual(left, right any) bool {
+	if left == nil || right == nil {
+		return left == nil && right == nil
+	}
+
+	if ordering, comparable := compareValues(left, right); comparable {
+		return ordering == 0
+	}
+
+	return reflect.DeepEqual(left, right)
+}
@@ -0,0 +1,114 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/specterops/bloodhound/dawgs"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/util/size"
+)
+
+// Driver is a graph.Database that holds its graph in process memory. Each store operation is atomic but transactions
+// are not isolated from one another: writes become visible to concurrent transactions as soon as they are made and
+// are undone if the transaction delegate returns an error.
+type Driver struct {
+	store                *store
+	traversalMemoryLimit size.Size
+}
+
+func NewDriver(cfg dawgs.Config) *Driver {
+	driver := &Driver{
+		store:                newStore(),
+		traversalMemoryLimit: defaultTraversalMemoryLimit,
+	}
+
+	if cfg.TraversalMemoryLimit > 0 {
+		driver.traversalMemoryLimit = cfg.TraversalMemoryLimit
+	}
+
+	return driver
+}
+
+func (s *Driver) SetWriteFlushSize(interval int) {
+	// The memory driver applies writes immediately and does not buffer them
+}
+
+func (s *Driver) SetBatchWriteSize(interval int) {
+	// The memory driver applies writes immediately and does not buffer them
+}
+
+func (s *Driver) newSession(ctx context.Context, writable bool) *session {
+	return &session{
+		ctx:                  ctx,
+		store:                s.store,
+		writable:             writable,
+		traversalMemoryLimit: s.traversalMemoryLimit,
+	}
+}
+
+func (s *Driver) ReadTransaction(ctx context.Context, txDelegate graph.TransactionDelegate, options ...graph.TransactionOption) error {
+	return txDelegate(&transaction{
+		session: s.newSession(ctx, false),
+	})
+}
+
+func (s *Driver) WriteTransaction(ctx context.Context, txDelegate graph.TransactionDelegate, options ...graph.TransactionOption) error {
+	tx := &transaction{
+		session: s.newSession(ctx, true),
+	}
+
+	if err := txDelegate(tx); err != nil {
+		tx.rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *Driver) BatchOperation(ctx context.Context, batchDelegate graph.BatchDelegate) error {
+	operation := &batch{
+		session: s.newSession(ctx, true),
+	}
+
+	if err := batchDelegate(operation); err != nil {
+		operation.rollback()
+		return err
+	}
+
+	return operation.Commit()
+}
+
+func (s *Driver) AssertSchema(ctx context.Context, dbSchema graph.Schema) error {
+	// The memory driver is schemaless
+	return nil
+}
+
+func (s *Driver) SetDefaultGraph(ctx context.Context, graphSchema graph.Graph) error {
+	// The memory driver only supports a single graph
+	return nil
+}
+
+func (s *Driver) Run(ctx context.Context, query string, parameters map[string]any) error {
+	return fmt.Errorf("memory driver does not support raw statements: %w", graph.ErrUnsupportedDatabaseOperation)
+}
+
+func (s *Driver) Close(ctx context.Context) error {
+	return nil
+}
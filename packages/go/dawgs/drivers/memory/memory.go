@@ -0,0 +1,40 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package memory implements a pure Go, in-memory DAWGS driver. The driver holds the entire graph in process memory and
+// evaluates query criteria built with the dawgs query package directly against it. It is intended for unit tests and
+// small demo deployments; data does not survive a restart and raw or cypher queries are not supported.
+package memory
+
+import (
+	"context"
+
+	"github.com/specterops/bloodhound/dawgs"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/util/size"
+)
+
+const (
+	DriverName = "memory"
+
+	defaultTraversalMemoryLimit = size.Gibibyte
+)
+
+func init() {
+	dawgs.Register(DriverName, func(ctx context.Context, cfg dawgs.Config) (graph.Database, error) {
+		return NewDriver(cfg), nil
+	})
+}
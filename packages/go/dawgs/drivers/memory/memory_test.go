@@ -0,0 +1,396 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/specterops/bloodhound/dawgs"
+	"github.com/specterops/bloodhound/dawgs/drivers/memory"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/ops"
+	"github.com/specterops/bloodhound/dawgs/query"
+	"github.com/specterops/bloodhound/dawgs/util/size"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	User     = graph.StringKind("User")
+	Group    = graph.StringKind("Group")
+	Computer = graph.StringKind("Computer")
+	MemberOf = graph.StringKind("MemberOf")
+	AdminTo  = graph.StringKind("AdminTo")
+)
+
+type harness struct {
+	db    graph.Database
+	nodes map[string]*graph.Node
+}
+
+// newHarness builds the following graph:
+//
+//	alice -MemberOf-> admins -AdminTo-> dc
+//	alice -MemberOf-> helpdesk -AdminTo-> dc
+//	bob -MemberOf-> helpdesk
+//	carol
+func newHarness(t *testing.T) harness {
+	db, err := dawgs.Open(context.Background(), memory.DriverName, dawgs.Config{})
+	require.Nil(t, err)
+
+	nodes := map[string]*graph.Node{}
+
+	require.Nil(t, db.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		for _, user := range []struct {
+			name    string
+			kind    graph.Kind
+			enabled any
+		}{
+			{"alice", User, true},
+			{"bob", User, false},
+			{"carol", User, nil},
+			{"admins", Group, nil},
+			{"helpdesk", Group, nil},
+			{"dc", Computer, nil},
+		} {
+			properties := graph.AsProperties(map[string]any{"name": user.name})
+
+			if user.enabled != nil {
+				properties.Set("enabled", user.enabled)
+			}
+
+			if node, err := tx.CreateNode(properties, user.kind); err != nil {
+				return err
+			} else {
+				nodes[user.name] = node
+			}
+		}
+
+		for _, edge := range []struct {
+			start, end string
+			kind       graph.Kind
+		}{
+			{"alice", "admins", MemberOf},
+			{"alice", "helpdesk", MemberOf},
+			{"bob", "helpdesk", MemberOf},
+			{"admins", "dc", AdminTo},
+			{"helpdesk", "dc", AdminTo},
+		} {
+			if _, err := tx.CreateRelationshipByIDs(nodes[edge.start].ID, nodes[edge.end].ID, edge.kind, graph.NewProperties()); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}))
+
+	return harness{
+		db:    db,
+		nodes: nodes,
+	}
+}
+
+func (s harness) fetchNodeNames(t *testing.T, criteria graph.Criteria) []string {
+	var names []string
+
+	require.Nil(t, s.db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		return tx.Nodes().Filter(criteria).OrderBy(query.Order(query.NodeProperty("name"), query.Ascending())).Fetch(func(cursor graph.Cursor[*graph.Node]) error {
+			for node := range cursor.Chan() {
+				name, _ := node.Properties.Get("name").String()
+				names = append(names, name)
+			}
+
+			return cursor.Error()
+		})
+	}))
+
+	return names
+}
+
+func TestNodeCriteria(t *testing.T) {
+	h := newHarness(t)
+
+	require.Equal(t, []string{"alice", "bob", "carol"}, h.fetchNodeNames(t, query.Kind(query.Node(), User)))
+	require.Equal(t, []string{"alice"}, h.fetchNodeNames(t, query.Equals(query.NodeProperty("enabled"), true)))
+	require.Equal(t, []string{"carol"}, h.fetchNodeNames(t, query.And(
+		query.Kind(query.Node(), User),
+		query.IsNull(query.NodeProperty("enabled")),
+	)))
+	require.Equal(t, []string{"admins", "bob", "carol", "dc", "helpdesk"}, h.fetchNodeNames(t, query.Not(query.Equals(query.NodeProperty("name"), "alice"))))
+	require.Equal(t, []string{"dc", "helpdesk"}, h.fetchNodeNames(t, query.Or(
+		query.CaseInsensitiveStringContains(query.NodeProperty("name"), "DESK"),
+		query.Kind(query.Node(), Computer),
+	)))
+	require.Equal(t, []string{"admins", "helpdesk"}, h.fetchNodeNames(t, query.In(query.NodeProperty("name"), []string{"admins", "helpdesk", "missing"})))
+	require.Equal(t, []string{"bob"}, h.fetchNodeNames(t, query.InIDs(query.NodeID(), h.nodes["bob"].ID)))
+	require.Equal(t, []string{"carol"}, h.fetchNodeNames(t, query.Not(query.HasRelationships(query.Node()))))
+}
+
+func TestTraversalMemoryLimit(t *testing.T) {
+	for _, testCase := range []struct {
+		Config   dawgs.Config
+		Expected size.Size
+	}{
+		{Config: dawgs.Config{}, Expected: size.Gibibyte},
+		{Config: dawgs.Config{TraversalMemoryLimit: size.Mebibyte}, Expected: size.Mebibyte},
+	} {
+		db, err := dawgs.Open(context.Background(), memory.DriverName, testCase.Config)
+		require.Nil(t, err)
+
+		require.Nil(t, db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+			require.Equal(t, testCase.Expected, tx.TraversalMemoryLimit())
+			return nil
+		}))
+	}
+}
+
+func TestNodeQueryPagingAndCount(t *testing.T) {
+	h := newHarness(t)
+
+	require.Nil(t, h.db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		count, err := tx.Nodes().Filter(query.Kind(query.Node(), User)).Count()
+		require.Nil(t, err)
+		require.Equal(t, int64(3), count)
+
+		node, err := tx.Nodes().Filter(query.Kind(query.Node(), User)).OrderBy(query.Order(query.NodeProperty("name"), query.Descending())).Offset(1).First()
+		require.Nil(t, err)
+		require.Equal(t, h.nodes["bob"].ID, node.ID)
+
+		_, err = tx.Nodes().Filter(query.Equals(query.NodeProperty("name"), "nobody")).First()
+		require.ErrorIs(t, err, graph.ErrNoResultsFound)
+
+		groups := graph.NewNodeSet(
+			graph.NewNode(h.nodes["admins"].ID, graph.NewProperties()),
+			graph.NewNode(h.nodes["helpdesk"].ID, graph.NewProperties()),
+		)
+
+		require.Nil(t, ops.FetchNodeProperties(tx, groups, []string{"name"}))
+		require.Equal(t, "helpdesk", groups.Get(h.nodes["helpdesk"].ID).Properties.Get("name").Any())
+
+		return nil
+	}))
+}
+
+func TestWriteTransactionRollback(t *testing.T) {
+	var (
+		h         = newHarness(t)
+		errFailed = errors.New("failed")
+	)
+
+	require.ErrorIs(t, h.db.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		if _, err := tx.CreateNode(graph.AsProperties(map[string]any{"name": "dave"}), User); err != nil {
+			return err
+		}
+
+		if err := tx.Nodes().Filter(query.Equals(query.NodeProperty("name"), "admins")).Delete(); err != nil {
+			return err
+		}
+
+		alice := h.nodes["alice"]
+		alice.Properties.Set("name", "alicia")
+
+		if err := tx.UpdateNode(alice); err != nil {
+			return err
+		}
+
+		return errFailed
+	}), errFailed)
+
+	require.Equal(t, []string{"admins", "alice", "bob", "carol", "dc", "helpdesk"}, h.fetchNodeNames(t, nil))
+
+	require.Nil(t, h.db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		count, err := tx.Relationships().Count()
+		require.Nil(t, err)
+		require.Equal(t, int64(5), count)
+
+		_, err = tx.CreateNode(graph.NewProperties(), User)
+		require.ErrorIs(t, err, memory.ErrReadOnlyTransaction)
+
+		return nil
+	}))
+}
+
+func TestRelationshipQueries(t *testing.T) {
+	h := newHarness(t)
+
+	require.Nil(t, h.db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		var triples []graph.RelationshipTripleResult
+
+		require.Nil(t, tx.Relationships().Filter(query.And(
+			query.Equals(query.StartID(), h.nodes["alice"].ID),
+			query.Kind(query.Relationship(), MemberOf),
+		)).FetchTriples(func(cursor graph.Cursor[graph.RelationshipTripleResult]) error {
+			for triple := range cursor.Chan() {
+				triples = append(triples, triple)
+			}
+
+			return cursor.Error()
+		}))
+
+		require.Len(t, triples, 2)
+		require.Equal(t, h.nodes["admins"].ID, triples[0].EndID)
+		require.Equal(t, h.nodes["helpdesk"].ID, triples[1].EndID)
+
+		var directional []graph.DirectionalResult
+
+		require.Nil(t, tx.Relationships().Filter(
+			query.Equals(query.StartID(), h.nodes["helpdesk"].ID),
+		).FetchDirection(graph.DirectionInbound, func(cursor graph.Cursor[graph.DirectionalResult]) error {
+			for result := range cursor.Chan() {
+				directional = append(directional, result)
+			}
+
+			return cursor.Error()
+		}))
+
+		require.Len(t, directional, 1)
+		require.Equal(t, h.nodes["dc"].ID, directional[0].Node.ID)
+		require.Equal(t, AdminTo, directional[0].Relationship.Kind)
+
+		var kinds []graph.RelationshipKindsResult
+
+		require.Nil(t, tx.Relationships().Filter(
+			query.Equals(query.EndID(), h.nodes["dc"].ID),
+		).FetchKinds(func(cursor graph.Cursor[graph.RelationshipKindsResult]) error {
+			for result := range cursor.Chan() {
+				kinds = append(kinds, result)
+			}
+
+			return cursor.Error()
+		}))
+
+		require.Len(t, kinds, 2)
+		require.Equal(t, AdminTo, kinds[0].Kind)
+
+		groups, err := ops.FetchEndNodes(tx.Relationships().Filter(query.And(
+			query.Kind(query.Start(), User),
+			query.Equals(query.StartProperty("enabled"), false),
+			query.Kind(query.End(), Group),
+		)))
+
+		require.Nil(t, err)
+		require.Len(t, groups, 1)
+		require.True(t, groups.Contains(h.nodes["helpdesk"]))
+
+		return nil
+	}))
+}
+
+func TestFetchAllShortestPaths(t *testing.T) {
+	h := newHarness(t)
+
+	require.Nil(t, h.db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		var paths []graph.Path
+
+		require.Nil(t, tx.Relationships().Filter(query.And(
+			query.Equals(query.StartID(), h.nodes["alice"].ID),
+			query.Equals(query.EndID(), h.nodes["dc"].ID),
+		)).FetchAllShortestPaths(func(cursor graph.Cursor[graph.Path]) error {
+			for path := range cursor.Chan() {
+				paths = append(paths, path)
+			}
+
+			return cursor.Error()
+		}))
+
+		require.Len(t, paths, 2)
+
+		for _, path := range paths {
+			require.Len(t, path.Edges, 2)
+			require.Equal(t, h.nodes["alice"].ID, path.Root().ID)
+			require.Equal(t, h.nodes["dc"].ID, path.Terminal().ID)
+		}
+
+		paths = nil
+
+		require.Nil(t, tx.Relationships().Filter(query.And(
+			query.Equals(query.StartID(), h.nodes["alice"].ID),
+			query.KindIn(query.Relationship(), AdminTo),
+			query.Equals(query.EndID(), h.nodes["dc"].ID),
+		)).FetchAllShortestPaths(func(cursor graph.Cursor[graph.Path]) error {
+			for path := range cursor.Chan() {
+				paths = append(paths, path)
+			}
+
+			return cursor.Error()
+		}))
+
+		require.Empty(t, paths)
+
+		require.Nil(t, tx.Relationships().Filter(query.And(
+			query.Kind(query.Start(), User),
+			query.KindIn(query.Relationship(), MemberOf),
+			query.Kind(query.End(), Group),
+		)).FetchAllShortestPaths(func(cursor graph.Cursor[graph.Path]) error {
+			for path := range cursor.Chan() {
+				paths = append(paths, path)
+			}
+
+			return cursor.Error()
+		}))
+
+		require.Len(t, paths, 3)
+
+		return nil
+	}))
+}
+
+func TestBatchUpserts(t *testing.T) {
+	h := newHarness(t)
+
+	require.Nil(t, h.db.BatchOperation(context.Background(), func(batch graph.Batch) error {
+		if err := batch.UpdateNodeBy(graph.NodeUpdate{
+			Node:               graph.NewNode(0, graph.AsProperties(map[string]any{"name": "alice", "title": "engineer"}), Group),
+			IdentityKind:       User,
+			IdentityProperties: []string{"name"},
+		}); err != nil {
+			return err
+		}
+
+		return batch.UpdateRelationshipBy(graph.RelationshipUpdate{
+			Relationship:            graph.NewRelationship(0, 0, 0, graph.AsProperties(map[string]any{"isacl": false}), MemberOf),
+			Start:                   graph.NewNode(0, graph.AsProperties(map[string]any{"name": "bob"})),
+			StartIdentityKind:       User,
+			StartIdentityProperties: []string{"name"},
+			End:                     graph.NewNode(0, graph.AsProperties(map[string]any{"name": "admins"})),
+			EndIdentityKind:         Group,
+			EndIdentityProperties:   []string{"name"},
+		})
+	}))
+
+	require.Nil(t, h.db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		alice, err := tx.Nodes().Filter(query.Equals(query.NodeID(), h.nodes["alice"].ID)).First()
+		require.Nil(t, err)
+		require.True(t, alice.Kinds.ContainsOneOf(User))
+		require.True(t, alice.Kinds.ContainsOneOf(Group))
+		require.Equal(t, "engineer", alice.Properties.Get("title").Any())
+
+		count, err := tx.Nodes().Count()
+		require.Nil(t, err)
+		require.Equal(t, int64(6), count)
+
+		count, err = tx.Relationships().Filter(query.And(
+			query.Equals(query.StartID(), h.nodes["bob"].ID),
+			query.Equals(query.EndID(), h.nodes["admins"].ID),
+		)).Count()
+		require.Nil(t, err)
+		require.Equal(t, int64(1), count)
+
+		return nil
+	}))
+}
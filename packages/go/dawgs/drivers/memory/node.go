@@ -0,0 +1,198 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/query"
+)
+
+type nodeQuery struct {
+	session   *session
+	selection selection
+}
+
+func (s *nodeQuery) Filter(criteria graph.Criteria) graph.NodeQuery {
+	s.selection.filter(criteria)
+	return s
+}
+
+func (s *nodeQuery) Filterf(criteriaDelegate graph.CriteriaProvider) graph.NodeQuery {
+	return s.Filter(criteriaDelegate())
+}
+
+func (s *nodeQuery) Query(delegate func(results graph.Result) error, finalCriteria ...graph.Criteria) error {
+	var rows [][]any
+
+	if querySelection, err := s.selection.apply(finalCriteria...); err != nil {
+		return err
+	} else if err := s.session.read(func(graphStore *store) error {
+		var err error
+
+		rows, err = querySelection.execute(nodeBindings(graphStore, querySelection.where()))
+		return err
+	}); err != nil {
+		return err
+	}
+
+	result := newResult(s.session.ctx, rows)
+	defer result.Close()
+
+	return delegate(result)
+}
+
+func (s *nodeQuery) matchedIDs() ([]graph.ID, error) {
+	var ids []graph.ID
+
+	return ids, s.FetchIDs(func(cursor graph.Cursor[graph.ID]) error {
+		for id := range cursor.Chan() {
+			ids = append(ids, id)
+		}
+
+		return cursor.Error()
+	})
+}
+
+func (s *nodeQuery) Delete() error {
+	if ids, err := s.matchedIDs(); err != nil {
+		return err
+	} else {
+		for _, id := range ids {
+			if err := s.session.write(func(graphStore *store) (undoFunc, error) {
+				return graphStore.deleteNode(id), nil
+			}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func (s *nodeQuery) Update(properties *graph.Properties) error {
+	if ids, err := s.matchedIDs(); err != nil {
+		return err
+	} else {
+		for _, id := range ids {
+			if err := s.session.write(func(graphStore *store) (undoFunc, error) {
+				return graphStore.updateNode(graph.NewNode(id, properties))
+			}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func (s *nodeQuery) OrderBy(criteria ...graph.Criteria) graph.NodeQuery {
+	s.selection.order = query.OrderBy(criteria...)
+	return s
+}
+
+func (s *nodeQuery) Offset(offset int) graph.NodeQuery {
+	s.selection.skip = query.Offset(offset)
+	return s
+}
+
+func (s *nodeQuery) Limit(limit int) graph.NodeQuery {
+	s.selection.limit = query.Limit(limit)
+	return s
+}
+
+func (s *nodeQuery) Count() (int64, error) {
+	var count int64
+
+	return count, s.Query(func(results graph.Result) error {
+		if !results.Next() {
+			return graph.ErrNoResultsFound
+		}
+
+		return results.Scan(&count)
+	}, query.Returning(
+		query.Count(query.Node()),
+	))
+}
+
+func (s *nodeQuery) First() (*graph.Node, error) {
+	var node graph.Node
+
+	return &node, s.Query(
+		func(results graph.Result) error {
+			if !results.Next() {
+				return graph.ErrNoResultsFound
+			}
+
+			return results.Scan(&node)
+		},
+		query.Returning(
+			query.Node(),
+		),
+		query.Limit(1),
+	)
+}
+
+func (s *nodeQuery) Fetch(delegate func(cursor graph.Cursor[*graph.Node]) error) error {
+	return s.Query(func(result graph.Result) error {
+		cursor := graph.NewResultIterator(s.session.ctx, result, func(scanner graph.Scanner) (*graph.Node, error) {
+			var node graph.Node
+			return &node, scanner.Scan(&node)
+		})
+
+		defer cursor.Close()
+		return delegate(cursor)
+	}, query.Returning(
+		query.Node(),
+	))
+}
+
+func (s *nodeQuery) FetchIDs(delegate func(cursor graph.Cursor[graph.ID]) error) error {
+	return s.Query(func(result graph.Result) error {
+		cursor := graph.NewResultIterator(s.session.ctx, result, func(scanner graph.Scanner) (graph.ID, error) {
+			var nodeID graph.ID
+			return nodeID, scanner.Scan(&nodeID)
+		})
+
+		defer cursor.Close()
+		return delegate(cursor)
+	}, query.Returning(
+		query.NodeID(),
+	))
+}
+
+func (s *nodeQuery) FetchKinds(delegate func(cursor graph.Cursor[graph.KindsResult]) error) error {
+	return s.Query(func(result graph.Result) error {
+		cursor := graph.NewResultIterator(s.session.ctx, result, func(scanner graph.Scanner) (graph.KindsResult, error) {
+			var (
+				nodeID    graph.ID
+				nodeKinds graph.Kinds
+				err       = scanner.Scan(&nodeID, &nodeKinds)
+			)
+
+			return graph.KindsResult{
+				ID:    nodeID,
+				Kinds: nodeKinds,
+			}, err
+		})
+
+		defer cursor.Close()
+		return delegate(cursor)
+	}, query.Returning(
+		query.NodeID(),
+		query.KindsOf(query.Node()),
+	))
+}
@@ -0,0 +1,243 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"context"
+
+	"github.com/specterops/bloodhound/cypher/model"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/query"
+)
+
+// collectSymbols records the query symbols referenced by the given expression.
+func collectSymbols(expression model.Expression, symbols map[string]struct{}) {
+	switch typedExpression := expression.(type) {
+	case model.ExpressionList:
+		for _, element := range typedExpression.GetAll() {
+			collectSymbols(element, symbols)
+		}
+
+	case *model.Parenthetical:
+		collectSymbols(typedExpression.Expression, symbols)
+
+	case *model.Negation:
+		collectSymbols(typedExpression.Expression, symbols)
+
+	case *model.Not:
+		collectSymbols(typedExpression.Expression, symbols)
+
+	case *model.Comparison:
+		collectSymbols(typedExpression.Left, symbols)
+
+		for _, partial := range typedExpression.Partials {
+			collectSymbols(partial.Right, symbols)
+		}
+
+	case *model.KindMatcher:
+		collectSymbols(typedExpression.Reference, symbols)
+
+	case *model.PropertyLookup:
+		collectSymbols(typedExpression.Atom, symbols)
+
+	case *model.FunctionInvocation:
+		for _, argument := range typedExpression.Arguments {
+			collectSymbols(argument, symbols)
+		}
+
+	case *model.PatternPredicate:
+		for _, element := range typedExpression.PatternElements {
+			if nodePattern, typeOK := element.Element.(*model.NodePattern); typeOK && nodePattern.Binding != nil {
+				collectSymbols(nodePattern.Binding, symbols)
+			}
+		}
+
+	case *model.Variable:
+		symbols[typedExpression.Symbol] = struct{}{}
+	}
+}
+
+// shortestPathCriteria splits the top-level conjunction of the given criteria into root criteria that only reference
+// the start node, terminal criteria that only reference the end node and traversal criteria that are evaluated for
+// every expanded relationship.
+type shortestPathCriteria struct {
+	root      []graph.Criteria
+	terminal  []graph.Criteria
+	traversal []graph.Criteria
+}
+
+func (s *shortestPathCriteria) add(criteria graph.Criteria) {
+	switch typedCriteria := criteria.(type) {
+	case nil:
+		return
+
+	case *model.Where:
+		for _, expression := range typedCriteria.Expressions {
+			s.add(expression)
+		}
+
+	case *model.Conjunction:
+		for _, expression := range typedCriteria.Expressions {
+			s.add(expression)
+		}
+
+	default:
+		symbols := map[string]struct{}{}
+		collectSymbols(criteria, symbols)
+
+		_, referencesStart := symbols[query.EdgeStartSymbol]
+		_, referencesEnd := symbols[query.EdgeEndSymbol]
+
+		if len(symbols) == 1 && referencesStart {
+			s.root = append(s.root, criteria)
+		} else if len(symbols) == 1 && referencesEnd {
+			s.terminal = append(s.terminal, criteria)
+		} else {
+			s.traversal = append(s.traversal, criteria)
+		}
+	}
+}
+
+func conjunction(criteria []graph.Criteria) graph.Criteria {
+	if len(criteria) == 0 {
+		return nil
+	}
+
+	return query.And(criteria...)
+}
+
+// findAllShortestPaths expands outbound relationships from every node that satisfies the root criteria and returns, for
+// each root, all shortest paths to the nearest nodes that satisfy the terminal criteria.
+func findAllShortestPaths(ctx context.Context, s *store, criteria graph.Criteria) ([]graph.Path, error) {
+	var (
+		split = shortestPathCriteria{}
+		paths []graph.Path
+	)
+
+	split.add(criteria)
+
+	var (
+		rootCriteria      = conjunction(split.root)
+		terminalCriteria  = conjunction(split.terminal)
+		traversalCriteria = conjunction(split.traversal)
+	)
+
+	rootIDs, constrained := idConstraint(rootCriteria, query.EdgeStartSymbol)
+	if !constrained {
+		rootIDs = sortedIDs(s.nodes)
+	}
+
+	for _, rootID := range rootIDs {
+		root, found := s.nodes[rootID]
+		if !found {
+			continue
+		}
+
+		if matched, err := matches(rootCriteria, binding{store: s, start: root}); err != nil {
+			return nil, err
+		} else if !matched {
+			continue
+		}
+
+		if rootPaths, err := shortestPathsFrom(ctx, s, root, traversalCriteria, terminalCriteria); err != nil {
+			return nil, err
+		} else {
+			paths = append(paths, rootPaths...)
+		}
+	}
+
+	return paths, nil
+}
+
+func shortestPathsFrom(ctx context.Context, s *store, root *graph.Node, traversalCriteria, terminalCriteria graph.Criteria) ([]graph.Path, error) {
+	var (
+		// parents tracks every relationship that reaches a node at its shortest depth from the root
+		parents   = map[graph.ID][]*graph.Relationship{}
+		depths    = map[graph.ID]int{root.ID: 0}
+		frontier  = []graph.ID{root.ID}
+		terminals []graph.ID
+	)
+
+	for depth := 1; len(frontier) > 0 && len(terminals) == 0; depth++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		var nextFrontier []graph.ID
+
+		for _, nodeID := range frontier {
+			for _, relationshipID := range s.adjacent(nodeID, graph.DirectionOutbound) {
+				relationship := s.relationships[relationshipID]
+
+				if endDepth, seen := depths[relationship.EndID]; seen && endDepth != depth {
+					continue
+				}
+
+				if matched, err := matches(traversalCriteria, relationshipBinding(s, relationship)); err != nil {
+					return nil, err
+				} else if !matched {
+					continue
+				}
+
+				if _, seen := depths[relationship.EndID]; !seen {
+					depths[relationship.EndID] = depth
+					nextFrontier = append(nextFrontier, relationship.EndID)
+
+					if matched, err := matches(terminalCriteria, binding{store: s, end: s.nodes[relationship.EndID]}); err != nil {
+						return nil, err
+					} else if matched {
+						terminals = append(terminals, relationship.EndID)
+					}
+				}
+
+				parents[relationship.EndID] = append(parents[relationship.EndID], relationship)
+			}
+		}
+
+		frontier = nextFrontier
+	}
+
+	var paths []graph.Path
+
+	for _, terminalID := range terminals {
+		paths = append(paths, expandPaths(s, root.ID, terminalID, parents)...)
+	}
+
+	return paths, nil
+}
+
+// expandPaths walks parent links backwards from the terminal to the root and returns every distinct path found.
+func expandPaths(s *store, rootID, terminalID graph.ID, parents map[graph.ID][]*graph.Relationship) []graph.Path {
+	if terminalID == rootID {
+		return []graph.Path{{
+			Nodes: []*graph.Node{copyNode(s.nodes[rootID])},
+		}}
+	}
+
+	var paths []graph.Path
+
+	for _, relationship := range parents[terminalID] {
+		for _, prefix := range expandPaths(s, rootID, relationship.StartID, parents) {
+			paths = append(paths, graph.Path{
+				Nodes: append(prefix.Nodes[:len(prefix.Nodes):len(prefix.Nodes)], copyNode(s.nodes[terminalID])),
+				Edges: append(prefix.Edges[:len(prefix.Edges):len(prefix.Edges)], copyRelationship(relationship)),
+			})
+		}
+	}
+
+	return paths
+}
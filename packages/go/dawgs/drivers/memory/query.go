@@ -0,0 +1,546 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/specterops/bloodhound/cypher/model"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/query"
+)
+
+// selection accumulates the criteria, ordering and paging of a node or relationship query.
+type selection struct {
+	criteria   []graph.Criteria
+	order      *model.Order
+	skip       *model.Skip
+	limit      *model.Limit
+	projection *model.Projection
+}
+
+func (s *selection) filter(criteria graph.Criteria) {
+	s.criteria = append(s.criteria, criteria)
+}
+
+func (s *selection) where() graph.Criteria {
+	switch len(s.criteria) {
+	case 0:
+		return nil
+
+	case 1:
+		return s.criteria[0]
+
+	default:
+		return query.And(s.criteria...)
+	}
+}
+
+// apply merges final query criteria into a copy of this selection.
+func (s selection) apply(finalCriteria ...graph.Criteria) (selection, error) {
+	for _, criteria := range finalCriteria {
+		switch typedCriteria := criteria.(type) {
+		case *model.Return:
+			s.projection = typedCriteria.Projection
+
+			if typedCriteria.Projection.Order != nil {
+				s.order = typedCriteria.Projection.Order
+			}
+
+			if typedCriteria.Projection.Skip != nil {
+				s.skip = typedCriteria.Projection.Skip
+			}
+
+			if typedCriteria.Projection.Limit != nil {
+				s.limit = typedCriteria.Projection.Limit
+			}
+
+		case *model.Order:
+			s.order = typedCriteria
+
+		case *model.Skip:
+			s.skip = typedCriteria
+
+		case *model.Limit:
+			s.limit = typedCriteria
+
+		case *model.Where:
+			s.criteria = append(s.criteria, typedCriteria)
+
+		default:
+			return s, fmt.Errorf("unsupported final query criteria for the memory driver: %T: %w", criteria, graph.ErrUnsupportedDatabaseOperation)
+		}
+	}
+
+	if s.projection == nil {
+		return s, fmt.Errorf("query has no return projection")
+	}
+
+	return s, nil
+}
+
+// idConstraint looks for an id(symbol) = x or id(symbol) in [x...] comparison in the top-level conjunction of the
+// given criteria and returns the IDs it constrains the symbol to.
+func idConstraint(criteria graph.Criteria, symbol string) ([]graph.ID, bool) {
+	switch typedCriteria := criteria.(type) {
+	case *model.Where:
+		return idConstraintOf(typedCriteria.Expressions, symbol)
+
+	case *model.Conjunction:
+		return idConstraintOf(typedCriteria.Expressions, symbol)
+
+	case *model.Parenthetical:
+		return idConstraint(typedCriteria.Expression, symbol)
+
+	case *model.Comparison:
+		if len(typedCriteria.Partials) != 1 {
+			return nil, false
+		}
+
+		if function, typeOK := typedCriteria.Left.(*model.FunctionInvocation); !typeOK || function.Name != "id" || len(function.Arguments) != 1 {
+			return nil, false
+		} else if variable, typeOK := function.Arguments[0].(*model.Variable); !typeOK || variable.Symbol != symbol {
+			return nil, false
+		}
+
+		parameter, typeOK := typedCriteria.Partials[0].Right.(*model.Parameter)
+		if !typeOK {
+			return nil, false
+		}
+
+		switch typedCriteria.Partials[0].Operator {
+		case model.OperatorEquals:
+			if id, isInteger := asInteger(parameter.Value); isInteger {
+				return []graph.ID{graph.ID(id)}, true
+			}
+
+		case model.OperatorIn:
+			if ids, typeOK := parameter.Value.([]graph.ID); typeOK {
+				return ids, true
+			} else if ids, err := graph.AsNumericSlice[graph.ID](parameter.Value); err == nil {
+				return ids, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func idConstraintOf(expressions []model.Expression, symbol string) ([]graph.ID, bool) {
+	for _, expression := range expressions {
+		if ids, found := idConstraint(expression, symbol); found {
+			return ids, true
+		}
+	}
+
+	return nil, false
+}
+
+func nodeBindings(s *store, criteria graph.Criteria) []binding {
+	var bindings []binding
+
+	if ids, found := idConstraint(criteria, query.NodeSymbol); found {
+		for _, id := range ids {
+			if node, found := s.nodes[id]; found {
+				bindings = append(bindings, binding{store: s, node: node})
+			}
+		}
+	} else {
+		for _, id := range sortedIDs(s.nodes) {
+			bindings = append(bindings, binding{store: s, node: s.nodes[id]})
+		}
+	}
+
+	return bindings
+}
+
+func relationshipBinding(s *store, relationship *graph.Relationship) binding {
+	return binding{
+		store:        s,
+		start:        s.nodes[relationship.StartID],
+		relationship: relationship,
+		end:          s.nodes[relationship.EndID],
+	}
+}
+
+func relationshipBindings(s *store, criteria graph.Criteria) []binding {
+	var (
+		bindings        []binding
+		relationshipIDs []graph.ID
+	)
+
+	if ids, found := idConstraint(criteria, query.EdgeSymbol); found {
+		relationshipIDs = ids
+	} else if ids, found := idConstraint(criteria, query.EdgeStartSymbol); found {
+		for _, id := range ids {
+			relationshipIDs = append(relationshipIDs, s.adjacent(id, graph.DirectionOutbound)...)
+		}
+	} else if ids, found := idConstraint(criteria, query.EdgeEndSymbol); found {
+		for _, id := range ids {
+			relationshipIDs = append(relationshipIDs, s.adjacent(id, graph.DirectionInbound)...)
+		}
+	} else {
+		relationshipIDs = sortedIDs(s.relationships)
+	}
+
+	for _, id := range relationshipIDs {
+		if relationship, found := s.relationships[id]; found {
+			bindings = append(bindings, relationshipBinding(s, relationship))
+		}
+	}
+
+	return bindings
+}
+
+func literalInt(expression model.Expression) (int, error) {
+	switch typedExpression := expression.(type) {
+	case *model.Literal:
+		if value, isInteger := asInteger(typedExpression.Value); isInteger {
+			return int(value), nil
+		}
+
+	case *model.Parameter:
+		if value, isInteger := asInteger(typedExpression.Value); isInteger {
+			return int(value), nil
+		}
+	}
+
+	return 0, fmt.Errorf("expected an integer value but got %T", expression)
+}
+
+func isAggregate(expression model.Expression) bool {
+	function, isFunction := expression.(*model.FunctionInvocation)
+	return isFunction && strings.EqualFold(function.Name, "count")
+}
+
+// exportValue converts an evaluated value into the form returned to result scanners. Stored entities are copied and
+// IDs and kinds are converted to the types the graph value mapper negotiates.
+func exportValue(value any) any {
+	switch typedValue := value.(type) {
+	case *graph.Node:
+		return copyNode(typedValue)
+
+	case *graph.Relationship:
+		return copyRelationship(typedValue)
+
+	case graph.ID:
+		return typedValue.Int64()
+
+	case graph.Kind:
+		return typedValue.String()
+
+	case graph.Kinds:
+		kindStrings := make([]any, len(typedValue))
+
+		for idx, kind := range typedValue {
+			kindStrings[idx] = kind.String()
+		}
+
+		return kindStrings
+
+	default:
+		return value
+	}
+}
+
+func distinctKey(values []any) string {
+	key := strings.Builder{}
+
+	for _, value := range values {
+		switch typedValue := value.(type) {
+		case *graph.Node:
+			key.WriteString(fmt.Sprintf("n%d;", typedValue.ID))
+
+		case *graph.Relationship:
+			key.WriteString(fmt.Sprintf("r%d;", typedValue.ID))
+
+		default:
+			key.WriteString(fmt.Sprintf("%T:%v;", value, value))
+		}
+	}
+
+	return key.String()
+}
+
+func (s selection) sort(bindings []binding) error {
+	if s.order == nil || len(s.order.Items) == 0 {
+		return nil
+	}
+
+	keys := make([][]any, len(bindings))
+
+	for idx, bound := range bindings {
+		keys[idx] = make([]any, len(s.order.Items))
+
+		for itemIdx, item := range s.order.Items {
+			if value, err := evaluate(item.Expression, bound); err != nil {
+				return err
+			} else {
+				keys[idx][itemIdx] = value
+			}
+		}
+	}
+
+	indices := make([]int, len(bindings))
+	for idx := range indices {
+		indices[idx] = idx
+	}
+
+	sort.SliceStable(indices, func(i, j int) bool {
+		for itemIdx, item := range s.order.Items {
+			left, right := keys[indices[i]][itemIdx], keys[indices[j]][itemIdx]
+
+			// Nulls sort last in ascending order and first in descending order
+			if left == nil || right == nil {
+				if left == nil && right == nil {
+					continue
+				}
+
+				return (right == nil) == item.Ascending
+			}
+
+			if ordering, _ := compareValues(left, right); ordering != 0 {
+				return (ordering < 0) == item.Ascending
+			}
+		}
+
+		return false
+	})
+
+	sorted := make([]binding, len(bindings))
+	for idx, bindingIdx := range indices {
+		sorted[idx] = bindings[bindingIdx]
+	}
+
+	copy(bindings, sorted)
+	return nil
+}
+
+func (s selection) page(rows [][]any) ([][]any, error) {
+	if s.skip != nil {
+		if skip, err := literalInt(s.skip.Value); err != nil {
+			return nil, err
+		} else if skip >= len(rows) {
+			rows = nil
+		} else if skip > 0 {
+			rows = rows[skip:]
+		}
+	}
+
+	if s.limit != nil {
+		if limit, err := literalInt(s.limit.Value); err != nil {
+			return nil, err
+		} else if limit >= 0 && limit < len(rows) {
+			rows = rows[:limit]
+		}
+	}
+
+	return rows, nil
+}
+
+func (s selection) aggregate(bindings []binding) ([]any, error) {
+	row := make([]any, len(s.projection.Items))
+
+	for idx, item := range s.projection.Items {
+		projectionItem, typeOK := item.(*model.ProjectionItem)
+		if !typeOK || !isAggregate(projectionItem.Expression) {
+			return nil, fmt.Errorf("mixing aggregate and non-aggregate projections is not supported by the memory driver")
+		}
+
+		var (
+			function = projectionItem.Expression.(*model.FunctionInvocation)
+			count    int64
+			seen     = map[string]struct{}{}
+		)
+
+		if len(function.Arguments) != 1 {
+			return nil, fmt.Errorf("count expects exactly one argument")
+		}
+
+		for _, bound := range bindings {
+			if value, err := evaluate(function.Arguments[0], bound); err != nil {
+				return nil, err
+			} else if value != nil {
+				if function.Distinct {
+					key := distinctKey([]any{value})
+
+					if _, isDuplicate := seen[key]; isDuplicate {
+						continue
+					}
+
+					seen[key] = struct{}{}
+				}
+
+				count++
+			}
+		}
+
+		row[idx] = count
+	}
+
+	return row, nil
+}
+
+// execute filters, orders, projects and pages the given candidate bindings.
+func (s selection) execute(candidates []binding) ([][]any, error) {
+	var (
+		where    = s.where()
+		bindings = make([]binding, 0, len(candidates))
+		rows     [][]any
+	)
+
+	for _, candidate := range candidates {
+		if matched, err := matches(where, candidate); err != nil {
+			return nil, err
+		} else if matched {
+			bindings = append(bindings, candidate)
+		}
+	}
+
+	if err := s.sort(bindings); err != nil {
+		return nil, err
+	}
+
+	if len(s.projection.Items) > 0 {
+		if projectionItem, typeOK := s.projection.Items[0].(*model.ProjectionItem); typeOK && isAggregate(projectionItem.Expression) {
+			if row, err := s.aggregate(bindings); err != nil {
+				return nil, err
+			} else {
+				return s.page([][]any{row})
+			}
+		}
+	}
+
+	seen := map[string]struct{}{}
+
+	for _, bound := range bindings {
+		row := make([]any, len(s.projection.Items))
+
+		for idx, item := range s.projection.Items {
+			expression := item
+
+			if projectionItem, typeOK := item.(*model.ProjectionItem); typeOK {
+				expression = projectionItem.Expression
+			}
+
+			if isAggregate(expression) {
+				return nil, fmt.Errorf("mixing aggregate and non-aggregate projections is not supported by the memory driver")
+			} else if value, err := evaluate(expression, bound); err != nil {
+				return nil, err
+			} else {
+				row[idx] = value
+			}
+		}
+
+		if s.projection.Distinct {
+			key := distinctKey(row)
+
+			if _, isDuplicate := seen[key]; isDuplicate {
+				continue
+			}
+
+			seen[key] = struct{}{}
+		}
+
+		for idx, value := range row {
+			row[idx] = exportValue(value)
+		}
+
+		rows = append(rows, row)
+	}
+
+	return s.page(rows)
+}
+
+// mapValue extends the default graph value mapper with the entity types the memory driver returns directly.
+func mapValue(rawValue, target any) (bool, error) {
+	switch typedTarget := target.(type) {
+	case *graph.Node:
+		if node, typeOK := rawValue.(*graph.Node); typeOK {
+			*typedTarget = *node
+			return true, nil
+		}
+
+	case *graph.Relationship:
+		if relationship, typeOK := rawValue.(*graph.Relationship); typeOK {
+			*typedTarget = *relationship
+			return true, nil
+		}
+
+	case *graph.Path:
+		if path, typeOK := rawValue.(graph.Path); typeOK {
+			*typedTarget = path
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// result is a graph.Result over fully materialized rows.
+type result struct {
+	ctx     context.Context
+	rows    [][]any
+	current []any
+	err     error
+}
+
+func newResult(ctx context.Context, rows [][]any) *result {
+	return &result{
+		ctx:  ctx,
+		rows: rows,
+	}
+}
+
+func (s *result) Next() bool {
+	if s.err != nil || len(s.rows) == 0 {
+		return false
+	}
+
+	if err := s.ctx.Err(); err != nil {
+		s.err = err
+		return false
+	}
+
+	s.current = s.rows[0]
+	s.rows = s.rows[1:]
+
+	return true
+}
+
+func (s *result) Values() (graph.ValueMapper, error) {
+	return graph.NewValueMapper(s.current, mapValue), nil
+}
+
+func (s *result) Scan(targets ...any) error {
+	if values, err := s.Values(); err != nil {
+		return err
+	} else {
+		return values.Scan(targets...)
+	}
+}
+
+func (s *result) Error() error {
+	return s.err
+}
+
+func (s *result) Close() {
+	s.rows = nil
+}
@@ -0,0 +1,369 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/specterops/bloodhound/dawgs/graph"
+)
+
+// undoFunc reverts a single store mutation. Undo functions are called with the store's write lock held.
+type undoFunc func(s *store)
+
+// store holds the graph. Entities held by the store are never mutated in place: updates replace the stored instance
+// with a modified copy so that undo functions may restore the previous instance and so that readers never observe a
+// partially applied change. Entities handed out of the store are always copies.
+type store struct {
+	lock               *sync.RWMutex
+	nodes              map[graph.ID]*graph.Node
+	relationships      map[graph.ID]*graph.Relationship
+	outbound           map[graph.ID]map[graph.ID]struct{}
+	inbound            map[graph.ID]map[graph.ID]struct{}
+	nextNodeID         graph.ID
+	nextRelationshipID graph.ID
+}
+
+func newStore() *store {
+	return &store{
+		lock:               &sync.RWMutex{},
+		nodes:              map[graph.ID]*graph.Node{},
+		relationships:      map[graph.ID]*graph.Relationship{},
+		outbound:           map[graph.ID]map[graph.ID]struct{}{},
+		inbound:            map[graph.ID]map[graph.ID]struct{}{},
+		nextNodeID:         1,
+		nextRelationshipID: 1,
+	}
+}
+
+func copyProperties(properties *graph.Properties) *graph.Properties {
+	var (
+		source = properties.MapOrEmpty()
+		copied = make(map[string]any, len(source))
+	)
+
+	for key, value := range source {
+		copied[key] = value
+	}
+
+	return &graph.Properties{
+		Map: copied,
+	}
+}
+
+func copyNode(node *graph.Node) *graph.Node {
+	return graph.NewNode(node.ID, copyProperties(node.Properties), node.Kinds.Copy()...)
+}
+
+func copyRelationship(relationship *graph.Relationship) *graph.Relationship {
+	return graph.NewRelationship(relationship.ID, relationship.StartID, relationship.EndID, copyProperties(relationship.Properties), relationship.Kind)
+}
+
+func applyPropertyChanges(target, changes *graph.Properties) {
+	if changes == nil {
+		return
+	}
+
+	for key, value := range changes.ModifiedProperties() {
+		target.Map[key] = value
+	}
+
+	for _, key := range changes.DeletedProperties() {
+		delete(target.Map, key)
+	}
+}
+
+func sortedIDs[T any](entities map[graph.ID]T) []graph.ID {
+	ids := make([]graph.ID, 0, len(entities))
+
+	for id := range entities {
+		ids = append(ids, id)
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+
+	return ids
+}
+
+func (s *store) read(delegate func(s *store) error) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return delegate(s)
+}
+
+func (s *store) write(delegate func(s *store) (undoFunc, error)) (undoFunc, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return delegate(s)
+}
+
+func (s *store) undo(undoFuncs []undoFunc) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for idx := len(undoFuncs) - 1; idx >= 0; idx-- {
+		undoFuncs[idx](s)
+	}
+}
+
+func (s *store) link(relationship *graph.Relationship) {
+	if outbound, found := s.outbound[relationship.StartID]; found {
+		outbound[relationship.ID] = struct{}{}
+	} else {
+		s.outbound[relationship.StartID] = map[graph.ID]struct{}{relationship.ID: {}}
+	}
+
+	if inbound, found := s.inbound[relationship.EndID]; found {
+		inbound[relationship.ID] = struct{}{}
+	} else {
+		s.inbound[relationship.EndID] = map[graph.ID]struct{}{relationship.ID: {}}
+	}
+}
+
+func (s *store) unlink(relationship *graph.Relationship) {
+	delete(s.outbound[relationship.StartID], relationship.ID)
+	delete(s.inbound[relationship.EndID], relationship.ID)
+}
+
+// adjacent returns the IDs of the relationships attached to the given node in the given direction, in ID order.
+func (s *store) adjacent(nodeID graph.ID, direction graph.Direction) []graph.ID {
+	switch direction {
+	case graph.DirectionOutbound:
+		return sortedIDs(s.outbound[nodeID])
+
+	case graph.DirectionInbound:
+		return sortedIDs(s.inbound[nodeID])
+
+	default:
+		return append(sortedIDs(s.outbound[nodeID]), sortedIDs(s.inbound[nodeID])...)
+	}
+}
+
+func (s *store) insertNode(node *graph.Node) undoFunc {
+	s.nodes[node.ID] = node
+
+	if node.ID >= s.nextNodeID {
+		s.nextNodeID = node.ID + 1
+	}
+
+	return func(s *store) {
+		delete(s.nodes, node.ID)
+	}
+}
+
+// createNode stores a copy of the given node. The node's ID is kept if it is registered and not already in use,
+// otherwise a new ID is allocated.
+func (s *store) createNode(node *graph.Node) (*graph.Node, undoFunc) {
+	created := graph.NewNode(node.ID, copyProperties(node.Properties), node.Kinds.Copy()...)
+
+	if _, inUse := s.nodes[created.ID]; created.ID == graph.UnregisteredNodeID || inUse {
+		created.ID = s.nextNodeID
+	}
+
+	return copyNode(created), s.insertNode(created)
+}
+
+func (s *store) updateNode(node *graph.Node) (undoFunc, error) {
+	if existing, found := s.nodes[node.ID]; !found {
+		return nil, fmt.Errorf("node %d: %w", node.ID, graph.ErrNoResultsFound)
+	} else {
+		updated := copyNode(existing)
+		updated.Kinds = updated.Kinds.Add(node.AddedKinds...).Exclude(node.DeletedKinds)
+		applyPropertyChanges(updated.Properties, node.Properties)
+
+		s.nodes[node.ID] = updated
+
+		return func(s *store) {
+			s.nodes[node.ID] = existing
+		}, nil
+	}
+}
+
+// mergeNode replaces the stored node with one carrying the union of its kinds and the given properties merged over
+// its own.
+func (s *store) mergeNode(id graph.ID, properties *graph.Properties, kinds graph.Kinds) undoFunc {
+	existing := s.nodes[id]
+	merged := copyNode(existing)
+	merged.Kinds = merged.Kinds.Add(kinds...)
+
+	for key, value := range properties.MapOrEmpty() {
+		merged.Properties.Map[key] = value
+	}
+
+	s.nodes[id] = merged
+
+	return func(s *store) {
+		s.nodes[id] = existing
+	}
+}
+
+func (s *store) deleteNode(id graph.ID) undoFunc {
+	var undoFuncs []undoFunc
+
+	if existing, found := s.nodes[id]; found {
+		for _, relationshipID := range s.adjacent(id, graph.DirectionBoth) {
+			undoFuncs = append(undoFuncs, s.deleteRelationship(relationshipID))
+		}
+
+		delete(s.nodes, id)
+		delete(s.outbound, id)
+		delete(s.inbound, id)
+
+		undoFuncs = append(undoFuncs, func(s *store) {
+			s.nodes[id] = existing
+		})
+	}
+
+	return func(s *store) {
+		for idx := len(undoFuncs) - 1; idx >= 0; idx-- {
+			undoFuncs[idx](s)
+		}
+	}
+}
+
+func (s *store) createRelationship(startID, endID graph.ID, kind graph.Kind, properties *graph.Properties) (*graph.Relationship, undoFunc, error) {
+	if _, found := s.nodes[startID]; !found {
+		return nil, nil, fmt.Errorf("start node %d: %w", startID, graph.ErrNoResultsFound)
+	} else if _, found := s.nodes[endID]; !found {
+		return nil, nil, fmt.Errorf("end node %d: %w", endID, graph.ErrNoResultsFound)
+	}
+
+	created := graph.NewRelationship(s.nextRelationshipID, startID, endID, copyProperties(properties), kind)
+	s.nextRelationshipID++
+
+	s.relationships[created.ID] = created
+	s.link(created)
+
+	return copyRelationship(created), func(s *store) {
+		s.unlink(created)
+		delete(s.relationships, created.ID)
+	}, nil
+}
+
+// findRelationship returns the relationship of the given kind between the given nodes, if any.
+func (s *store) findRelationship(startID, endID graph.ID, kind graph.Kind) (*graph.Relationship, bool) {
+	for relationshipID := range s.outbound[startID] {
+		if relationship := s.relationships[relationshipID]; relationship.EndID == endID && relationship.Kind.Is(kind) {
+			return relationship, true
+		}
+	}
+
+	return nil, false
+}
+
+// upsertRelationship creates a relationship of the given kind between the given nodes or, if one already exists, merges
+// the given properties into it.
+func (s *store) upsertRelationship(startID, endID graph.ID, kind graph.Kind, properties *graph.Properties) (undoFunc, error) {
+	if existing, found := s.findRelationship(startID, endID, kind); found {
+		merged := copyRelationship(existing)
+
+		for key, value := range properties.MapOrEmpty() {
+			merged.Properties.Map[key] = value
+		}
+
+		s.relationships[existing.ID] = merged
+
+		return func(s *store) {
+			s.relationships[existing.ID] = existing
+		}, nil
+	}
+
+	_, undo, err := s.createRelationship(startID, endID, kind, properties)
+	return undo, err
+}
+
+func (s *store) updateRelationship(relationship *graph.Relationship) (undoFunc, error) {
+	if existing, found := s.relationships[relationship.ID]; !found {
+		return nil, fmt.Errorf("relationship %d: %w", relationship.ID, graph.ErrNoResultsFound)
+	} else {
+		updated := copyRelationship(existing)
+		applyPropertyChanges(updated.Properties, relationship.Properties)
+
+		s.relationships[relationship.ID] = updated
+
+		return func(s *store) {
+			s.relationships[relationship.ID] = existing
+		}, nil
+	}
+}
+
+func (s *store) deleteRelationship(id graph.ID) undoFunc {
+	if existing, found := s.relationships[id]; !found {
+		return func(s *store) {}
+	} else {
+		s.unlink(existing)
+		delete(s.relationships, id)
+
+		return func(s *store) {
+			s.relationships[id] = existing
+			s.link(existing)
+		}
+	}
+}
+
+// findNode returns the first node, in ID order, that has the given kind and whose identity properties all match the
+// given node's. An empty kind matches any node.
+func (s *store) findNode(kind graph.Kind, identityProperties []string, node *graph.Node) (*graph.Node, bool) {
+	if len(identityProperties) == 0 {
+		return nil, false
+	}
+
+	for _, id := range sortedIDs(s.nodes) {
+		var (
+			candidate = s.nodes[id]
+			matches   = kind == nil || candidate.Kinds.ContainsOneOf(kind)
+		)
+
+		for _, identityProperty := range identityProperties {
+			if !matches {
+				break
+			}
+
+			candidateValue, candidateHasValue := candidate.Properties.Map[identityProperty]
+			matches = candidateHasValue && valuesEqual(candidateValue, node.Properties.MapOrEmpty()[identityProperty])
+		}
+
+		if matches {
+			return candidate, true
+		}
+	}
+
+	return nil, false
+}
+
+// upsertNode creates the given node or merges it into the node already stored under the same identity and returns the
+// ID of the stored node.
+func (s *store) upsertNode(update graph.NodeUpdate) (graph.ID, undoFunc) {
+	kinds := update.Node.Kinds.Copy()
+
+	if update.IdentityKind != nil {
+		kinds = kinds.Add(update.IdentityKind)
+	}
+
+	if existing, found := s.findNode(update.IdentityKind, update.IdentityProperties, update.Node); found {
+		return existing.ID, s.mergeNode(existing.ID, update.Node.Properties, kinds)
+	}
+
+	created, undo := s.createNode(graph.NewNode(graph.UnregisteredNodeID, update.Node.Properties, kinds...))
+	return created.ID, undo
+}
@@ -0,0 +1,153 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/util/size"
+)
+
+var (
+	ErrReadOnlyTransaction = errors.New("write operation attempted in a read-only transaction")
+)
+
+// session is the transactional state shared by transactions and batches. Writes are applied to the store immediately
+// and an undo function is recorded for each of them so that the session may be rolled back.
+type session struct {
+	ctx                  context.Context
+	store                *store
+	writable             bool
+	undoFuncs            []undoFunc
+	traversalMemoryLimit size.Size
+}
+
+func (s *session) read(delegate func(s *store) error) error {
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+
+	return s.store.read(delegate)
+}
+
+func (s *session) write(delegate func(s *store) (undoFunc, error)) error {
+	if !s.writable {
+		return ErrReadOnlyTransaction
+	} else if err := s.ctx.Err(); err != nil {
+		return err
+	} else if undo, err := s.store.write(delegate); err != nil {
+		return err
+	} else {
+		s.undoFuncs = append(s.undoFuncs, undo)
+		return nil
+	}
+}
+
+func (s *session) rollback() {
+	s.store.undo(s.undoFuncs)
+	s.undoFuncs = nil
+}
+
+func (s *session) commit() error {
+	s.undoFuncs = nil
+	return nil
+}
+
+func (s *session) nodes() *nodeQuery {
+	return &nodeQuery{
+		session: s,
+	}
+}
+
+func (s *session) relationships() *relationshipQuery {
+	return &relationshipQuery{
+		session: s,
+	}
+}
+
+type transaction struct {
+	*session
+}
+
+func (s *transaction) WithGraph(graphSchema graph.Graph) graph.Transaction {
+	// The memory driver only supports a single graph
+	return s
+}
+
+func (s *transaction) CreateNode(properties *graph.Properties, kinds ...graph.Kind) (*graph.Node, error) {
+	var created *graph.Node
+
+	return created, s.write(func(graphStore *store) (undoFunc, error) {
+		var undo undoFunc
+
+		created, undo = graphStore.createNode(graph.NewNode(graph.UnregisteredNodeID, properties, kinds...))
+		return undo, nil
+	})
+}
+
+func (s *transaction) UpdateNode(node *graph.Node) error {
+	return s.write(func(graphStore *store) (undoFunc, error) {
+		return graphStore.updateNode(node)
+	})
+}
+
+func (s *transaction) Nodes() graph.NodeQuery {
+	return s.nodes()
+}
+
+func (s *transaction) CreateRelationshipByIDs(startNodeID, endNodeID graph.ID, kind graph.Kind, properties *graph.Properties) (*graph.Relationship, error) {
+	var created *graph.Relationship
+
+	return created, s.write(func(graphStore *store) (undoFunc, error) {
+		var (
+			undo undoFunc
+			err  error
+		)
+
+		created, undo, err = graphStore.createRelationship(startNodeID, endNodeID, kind, properties)
+		return undo, err
+	})
+}
+
+func (s *transaction) UpdateRelationship(relationship *graph.Relationship) error {
+	return s.write(func(graphStore *store) (undoFunc, error) {
+		return graphStore.updateRelationship(relationship)
+	})
+}
+
+func (s *transaction) Relationships() graph.RelationshipQuery {
+	return s.relationships()
+}
+
+func (s *transaction) Raw(query string, parameters map[string]any) graph.Result {
+	return graph.NewErrorResult(fmt.Errorf("memory driver does not support raw queries: %w", graph.ErrUnsupportedDatabaseOperation))
+}
+
+func (s *transaction) Query(query string, parameters map[string]any) graph.Result {
+	return graph.NewErrorResult(fmt.Errorf("memory driver does not support cypher queries: %w", graph.ErrUnsupportedDatabaseOperation))
+}
+
+func (s *transaction) Commit() error {
+	return s.commit()
+}
+
+func (s *transaction) TraversalMemoryLimit() size.Size {
+	return s.traversalMemoryLimit
+}
@@ -23,6 +23,7 @@ const (
 	cypherDeleteNodesByID         = `match (n) where id(n) in $id_list detach delete n`
 	cypherDeleteRelationshipByID  = `match ()-[r]->() where id(r) = $id delete r`
 	cypherDeleteRelationshipsByID = `unwind $p as rid match ()-[r]->() where id(r) = rid delete r`
+	cypherPropertyKeys            = `call db.propertyKeys()`
 	idParameterName               = "id"
 	idListParameterName           = "id_list"
 )
@@ -59,12 +59,22 @@ func newNeo4jSchema() neo4jSchema {
 	}
 }
 
+// indexedKinds returns the kinds an index or constraint should be created for. Indexes scoped to a kind apply only to
+// that kind while all others apply to every node kind of the graph.
+func indexedKinds(graphKinds graph.Kinds, scopedKind graph.Kind) graph.Kinds {
+	if scopedKind != nil {
+		return graph.Kinds{scopedKind}
+	}
+
+	return graphKinds
+}
+
 func toNeo4jSchema(dbSchema graph.Schema) neo4jSchema {
 	neo4jSchemaInst := newNeo4jSchema()
 
 	for _, graphSchema := range dbSchema.Graphs {
 		for _, index := range graphSchema.NodeIndexes {
-			for _, kind := range graphSchema.Nodes {
+			for _, kind := range indexedKinds(graphSchema.Nodes, index.Kind) {
 				indexName := strings.ToLower(kind.String()) + "_" + strings.ToLower(index.Field) + "_index"
 
 				neo4jSchemaInst.Indexes[indexName] = neo4jIndex{
@@ -79,7 +89,7 @@ func toNeo4jSchema(dbSchema graph.Schema) neo4jSchema {
 		}
 
 		for _, constraint := range graphSchema.NodeConstraints {
-			for _, kind := range graphSchema.Nodes {
+			for _, kind := range indexedKinds(graphSchema.Nodes, constraint.Kind) {
 				constraintName := strings.ToLower(kind.String()) + "_" + strings.ToLower(constraint.Field) + "_constraint"
 
 				neo4jSchemaInst.Constraints[constraintName] = neo4jConstraint{
@@ -0,0 +1,59 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package neo4j
+
+import (
+	"testing"
+
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_toNeo4jSchema(t *testing.T) {
+	var (
+		userKind     = graph.StringKind("User")
+		computerKind = graph.StringKind("Computer")
+		schema       = toNeo4jSchema(graph.Schema{
+			Graphs: []graph.Graph{{
+				Name:  "default",
+				Nodes: graph.Kinds{userKind, computerKind},
+				NodeIndexes: []graph.Index{{
+					Field: "name",
+					Type:  graph.BTreeIndex,
+				}, {
+					Field: "department",
+					Type:  graph.TextSearchIndex,
+					Kind:  userKind,
+				}},
+				NodeConstraints: []graph.Constraint{{
+					Field: "objectid",
+					Type:  graph.BTreeIndex,
+				}},
+			}},
+		})
+	)
+
+	require.Len(t, schema.Indexes, 3)
+	require.Len(t, schema.Constraints, 2)
+
+	require.Equal(t, userKind, schema.Indexes["user_name_index"].kind)
+	require.Equal(t, computerKind, schema.Indexes["computer_name_index"].kind)
+	require.Equal(t, graph.TextSearchIndex, schema.Indexes["user_department_index"].Type)
+
+	_, hasComputerDepartmentIndex := schema.Indexes["computer_department_index"]
+	require.False(t, hasComputerDepartmentIndex)
+}
@@ -43,4 +43,9 @@ func TestValueMapper_MapOptions(t *testing.T) {
 
 	require.Nil(t, mappedPointer)
 	require.ErrorContains(t, err, "no matching target given for type: int")
+
+	mappedPointer, err = neo4j.NewValueMapper([]any{nil}).MapOptions(&intOption)
+
+	require.Nil(t, mappedPointer)
+	require.Nil(t, err)
 }
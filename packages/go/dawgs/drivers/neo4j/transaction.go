@@ -347,6 +347,84 @@ func (s *neo4jTransaction) Raw(stmt string, params map[string]any) graph.Result
 	return NewResult(stmt, err, driverResult)
 }
 
+// Explain returns the given cypher query along with the Neo4j plan for it. The query is planned but not run.
+func (s *neo4jTransaction) Explain(query string, parameters map[string]any) (graph.QueryPlan, error) {
+	if driverResult, err := s.currentTx().Run("explain "+query, parameters); err != nil {
+		return graph.QueryPlan{}, graph.NewError(stripCypherQuery(query), err)
+	} else if summary, err := driverResult.Consume(); err != nil {
+		return graph.QueryPlan{}, graph.NewError(stripCypherQuery(query), err)
+	} else {
+		return graph.QueryPlan{
+			Statement: query,
+			Plan:      planToMap(summary.Plan()),
+		}, nil
+	}
+}
+
+// Mutate runs the given cypher query and summarizes its changes to the graph from the counters Neo4j reports for it
+func (s *neo4jTransaction) Mutate(query string, parameters map[string]any) (graph.MutationSummary, error) {
+	if driverResult, err := s.currentTx().Run(query, parameters); err != nil {
+		return graph.MutationSummary{}, graph.NewError(stripCypherQuery(query), err)
+	} else if summary, err := driverResult.Consume(); err != nil {
+		return graph.MutationSummary{}, graph.NewError(stripCypherQuery(query), err)
+	} else {
+		counters := summary.Counters()
+
+		return graph.MutationSummary{
+			NodesCreated:         counters.NodesCreated(),
+			NodesDeleted:         counters.NodesDeleted(),
+			RelationshipsCreated: counters.RelationshipsCreated(),
+			RelationshipsDeleted: counters.RelationshipsDeleted(),
+			PropertiesSet:        counters.PropertiesSet(),
+			LabelsAdded:          counters.LabelsAdded(),
+			LabelsRemoved:        counters.LabelsRemoved(),
+		}, nil
+	}
+}
+
+// PropertyKeys returns the property keys that Neo4j has recorded for the graph
+func (s *neo4jTransaction) PropertyKeys() ([]string, error) {
+	if driverResult, err := s.currentTx().Run(cypherPropertyKeys, nil); err != nil {
+		return nil, graph.NewError(cypherPropertyKeys, err)
+	} else {
+		var propertyKeys []string
+
+		for driverResult.Next() {
+			if values := driverResult.Record().Values; len(values) > 0 {
+				if propertyKey, isString := values[0].(string); isString {
+					propertyKeys = append(propertyKeys, propertyKey)
+				}
+			}
+		}
+
+		if err := driverResult.Err(); err != nil {
+			return nil, graph.NewError(cypherPropertyKeys, err)
+		}
+
+		return propertyKeys, nil
+	}
+}
+
+// planToMap converts the given Neo4j plan and its children into nested maps
+func planToMap(plan neo4j_core.Plan) map[string]any {
+	if plan == nil {
+		return nil
+	}
+
+	children := make([]map[string]any, 0, len(plan.Children()))
+
+	for _, child := range plan.Children() {
+		children = append(children, planToMap(child))
+	}
+
+	return map[string]any{
+		"operator":    plan.Operator(),
+		"arguments":   plan.Arguments(),
+		"identifiers": plan.Identifiers(),
+		"children":    children,
+	}
+}
+
 func (s *neo4jTransaction) Nodes() graph.NodeQuery {
 	return NewNodeQuery(s.ctx, s)
 }
@@ -324,3 +324,162 @@ func TestNeo4jTransaction_CreateNode(t *testing.T) {
 	_, err := tx.CreateNode(graph.AsProperties(properties), User)
 	require.Nil(t, err)
 }
+
+type testPlan struct {
+	operator string
+	children []neo4j_core.Plan
+}
+
+func (s testPlan) Operator() string {
+	return s.operator
+}
+
+func (s testPlan) Arguments() map[string]any {
+	return map[string]any{}
+}
+
+func (s testPlan) Identifiers() []string {
+	return []string{"n"}
+}
+
+func (s testPlan) Children() []neo4j_core.Plan {
+	return s.children
+}
+
+type testCounters struct {
+	neo4j_core.Counters
+}
+
+func (s testCounters) NodesCreated() int {
+	return 0
+}
+
+func (s testCounters) NodesDeleted() int {
+	return 0
+}
+
+func (s testCounters) RelationshipsCreated() int {
+	return 1
+}
+
+func (s testCounters) RelationshipsDeleted() int {
+	return 2
+}
+
+func (s testCounters) PropertiesSet() int {
+	return 3
+}
+
+func (s testCounters) LabelsAdded() int {
+	return 4
+}
+
+func (s testCounters) LabelsRemoved() int {
+	return 5
+}
+
+type testSummary struct {
+	neo4j_core.ResultSummary
+
+	plan neo4j_core.Plan
+}
+
+func (s testSummary) Plan() neo4j_core.Plan {
+	return s.plan
+}
+
+func (s testSummary) Counters() neo4j_core.Counters {
+	return testCounters{}
+}
+
+func TestNeo4jTransaction_Explain(t *testing.T) {
+	var (
+		mockCtl         = gomock.NewController(t)
+		resultMock      = neo4j.NewMockResult(mockCtl)
+		transactionMock = neo4j.NewMockTransaction(mockCtl)
+		tx              = &neo4jTransaction{
+			innerTx: transactionMock,
+		}
+		parameters = map[string]any{
+			"name": "lol",
+		}
+	)
+
+	transactionMock.EXPECT().Run("explain match (n) where n.name = $name return n", parameters).Return(resultMock, nil)
+	resultMock.EXPECT().Consume().Return(testSummary{
+		plan: testPlan{
+			operator: "ProduceResults",
+			children: []neo4j_core.Plan{testPlan{
+				operator: "AllNodesScan",
+			}},
+		},
+	}, nil)
+
+	plan, err := graph.Explain(tx, "match (n) where n.name = $name return n", parameters)
+	require.Nil(t, err)
+	require.Equal(t, "match (n) where n.name = $name return n", plan.Statement)
+	require.Equal(t, map[string]any{
+		"operator":    "ProduceResults",
+		"arguments":   map[string]any{},
+		"identifiers": []string{"n"},
+		"children": []map[string]any{{
+			"operator":    "AllNodesScan",
+			"arguments":   map[string]any{},
+			"identifiers": []string{"n"},
+			"children":    []map[string]any{},
+		}},
+	}, plan.Plan)
+}
+
+func TestNeo4jTransaction_Mutate(t *testing.T) {
+	var (
+		mockCtl         = gomock.NewController(t)
+		resultMock      = neo4j.NewMockResult(mockCtl)
+		transactionMock = neo4j.NewMockTransaction(mockCtl)
+		tx              = &neo4jTransaction{
+			innerTx: transactionMock,
+		}
+		parameters = map[string]any{
+			"name": "lol",
+		}
+	)
+
+	transactionMock.EXPECT().Run("match (n) where n.name = $name set n.owned = true", parameters).Return(resultMock, nil)
+	resultMock.EXPECT().Consume().Return(testSummary{}, nil)
+
+	summary, err := graph.Mutate(tx, "match (n) where n.name = $name set n.owned = true", parameters)
+	require.Nil(t, err)
+	require.Equal(t, graph.MutationSummary{
+		RelationshipsCreated: 1,
+		RelationshipsDeleted: 2,
+		PropertiesSet:        3,
+		LabelsAdded:          4,
+		LabelsRemoved:        5,
+	}, summary)
+}
+
+func TestNeo4jTransaction_PropertyKeys(t *testing.T) {
+	var (
+		mockCtl         = gomock.NewController(t)
+		resultMock      = neo4j.NewMockResult(mockCtl)
+		transactionMock = neo4j.NewMockTransaction(mockCtl)
+		tx              = &neo4jTransaction{
+			innerTx: transactionMock,
+		}
+	)
+
+	transactionMock.EXPECT().Run("call db.propertyKeys()", gomock.Nil()).Return(resultMock, nil)
+
+	gomock.InOrder(
+		resultMock.EXPECT().Next().Return(true),
+		resultMock.EXPECT().Record().Return(&neo4j_core.Record{Values: []any{"name"}, Keys: []string{"propertyKey"}}),
+		resultMock.EXPECT().Next().Return(true),
+		resultMock.EXPECT().Record().Return(&neo4j_core.Record{Values: []any{"objectid"}, Keys: []string{"propertyKey"}}),
+		resultMock.EXPECT().Next().Return(false),
+		resultMock.EXPECT().Err().Return(nil),
+	)
+
+	propertyKeys, err := graph.PropertyKeys(tx)
+	require.Nil(t, err)
+	require.Equal(t, []string{"name", "objectid"}, propertyKeys)
+}
@@ -64,6 +64,8 @@ type batch struct {
 	relationshipUpdateByBuffer []graph.RelationshipUpdate
 	batchWriteSize             int
 	kindIDEncoder              Int2ArrayEncoder
+	bulkLoad                   bool
+	stagingTablesCreated       bool
 }
 
 func newBatch(ctx context.Context, conn *pgxpool.Conn, schemaManager *SchemaManager, cfg *Config) (*batch, error) {
@@ -75,6 +77,7 @@ func newBatch(ctx context.Context, conn *pgxpool.Conn, schemaManager *SchemaMana
 			schemaManager:    schemaManager,
 			innerTransaction: tx,
 			batchWriteSize:   cfg.BatchWriteSize,
+			bulkLoad:         cfg.BulkLoad,
 			kindIDEncoder: Int2ArrayEncoder{
 				buffer: &bytes.Buffer{},
 			},
@@ -141,6 +144,10 @@ func (s *batch) flushNodeCreateBuffer() error {
 		}
 	}
 
+	if s.bulkLoad {
+		return s.bulkCopyNodeCreateBuffer(!withoutIDs)
+	}
+
 	if withoutIDs {
 		return s.flushNodeCreateBufferWithoutIDs()
 	}
@@ -254,6 +261,10 @@ func (s *batch) flushNodeUpsertBatch(updates *sql.NodeUpdateBatch) error {
 func (s *batch) tryFlushNodeUpdateByBuffer() error {
 	if updates, err := sql.ValidateNodeUpdateByBatch(s.nodeUpdateByBuffer); err != nil {
 		return err
+	} else if s.bulkLoad {
+		if err := s.bulkNodeUpsertBatch(updates); err != nil {
+			return err
+		}
 	} else if err := s.flushNodeUpsertBatch(updates); err != nil {
 		return err
 	}
@@ -397,6 +408,10 @@ func (s *batch) flushRelationshipUpdateByBuffer(updates *sql.RelationshipUpdateB
 func (s *batch) tryFlushRelationshipUpdateByBuffer() error {
 	if updateBatch, err := sql.ValidateRelationshipUpdateByBatch(s.relationshipUpdateByBuffer); err != nil {
 		return err
+	} else if s.bulkLoad {
+		if err := s.bulkRelationshipUpdateByBuffer(updateBatch); err != nil {
+			return err
+		}
 	} else if err := s.flushRelationshipUpdateByBuffer(updateBatch); err != nil {
 		return err
 	}
@@ -503,6 +518,10 @@ func (s *batch) flushRelationshipCreateBuffer() error {
 
 	if createBatch, err := batchBuilder.Build(); err != nil {
 		return err
+	} else if s.bulkLoad {
+		if err := s.bulkRelationshipCreateBatch(createBatch); err != nil {
+			return err
+		}
 	} else if graphTarget, err := s.innerTransaction.getTargetGraph(); err != nil {
 		return err
 	} else if _, err := s.innerTransaction.tx.Exec(s.ctx, createEdgeBatchStatement, graphTarget.ID, createBatch.startIDs, createBatch.endIDs, createBatch.edgeKindIDs, createBatch.edgePropertyBags); err != nil {
@@ -0,0 +1,215 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pg
+
+import (
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/specterops/bloodhound/cypher/backend/pgsql"
+	sql "github.com/specterops/bloodhound/dawgs/drivers/pg/query"
+	"github.com/specterops/bloodhound/dawgs/graph"
+)
+
+// The bulk load path replaces the array-unnest statements used by the batch flush functions with the PostgreSQL COPY
+// protocol. Plain creates are copied directly into their target table. Upserts are copied into a transaction-scoped
+// staging table first and then merged into the graph partitions with the same conflict semantics as the unnest path.
+
+var (
+	nodeStageColumns        = []string{"idx", "kind_ids", "properties"}
+	edgeStageColumns        = []string{"start_id", "end_id", "kind_id", "properties"}
+	nodeCreateColumns       = []string{"graph_id", "kind_ids", "properties"}
+	nodeCreateWithIDColumns = []string{"graph_id", "id", "kind_ids", "properties"}
+)
+
+func propertiesToJSON(properties *graph.Properties) ([]byte, error) {
+	if propertiesJSONB, err := pgsql.PropertiesToJSONB(properties); err != nil {
+		return nil, err
+	} else {
+		return propertiesJSONB.Bytes, nil
+	}
+}
+
+func (s *batch) assertStagingTables() error {
+	if s.stagingTablesCreated {
+		return nil
+	}
+
+	if _, err := s.innerTransaction.tx.Exec(s.ctx, createNodeStageTableStatement); err != nil {
+		return fmt.Errorf("failed creating node staging table: %w", err)
+	} else if _, err := s.innerTransaction.tx.Exec(s.ctx, createEdgeStageTableStatement); err != nil {
+		return fmt.Errorf("failed creating edge staging table: %w", err)
+	}
+
+	s.stagingTablesCreated = true
+	return nil
+}
+
+func (s *batch) copyRows(tableName string, columns []string, rows [][]any) error {
+	if copied, err := s.innerTransaction.tx.CopyFrom(s.ctx, pgx.Identifier{tableName}, columns, pgx.CopyFromRows(rows)); err != nil {
+		return err
+	} else if copied != int64(len(rows)) {
+		return fmt.Errorf("expected to copy %d rows into %s but copied %d", len(rows), tableName, copied)
+	}
+
+	return nil
+}
+
+func (s *batch) bulkCopyNodeCreateBuffer(withIDs bool) error {
+	var (
+		columns = nodeCreateColumns
+		rows    = make([][]any, 0, len(s.nodeCreateBuffer))
+	)
+
+	if withIDs {
+		columns = nodeCreateWithIDColumns
+	}
+
+	graphTarget, err := s.innerTransaction.getTargetGraph()
+	if err != nil {
+		return err
+	}
+
+	for _, nextNode := range s.nodeCreateBuffer {
+		if mappedKindIDs, missingKinds := s.schemaManager.MapKinds(nextNode.Kinds); len(missingKinds) > 0 {
+			return fmt.Errorf("unable to map kinds %v", missingKinds)
+		} else if properties, err := propertiesToJSON(nextNode.Properties); err != nil {
+			return err
+		} else if withIDs {
+			rows = append(rows, []any{graphTarget.ID, nextNode.ID.Uint32(), mappedKindIDs, properties})
+		} else {
+			rows = append(rows, []any{graphTarget.ID, mappedKindIDs, properties})
+		}
+	}
+
+	if err := s.copyRows(graphTarget.Partitions.Node.Name, columns, rows); err != nil {
+		return err
+	}
+
+	s.nodeCreateBuffer = s.nodeCreateBuffer[:0]
+	return nil
+}
+
+func (s *batch) bulkNodeUpsertBatch(updates *sql.NodeUpdateBatch) error {
+	var (
+		idFutures = make([]*sql.Future[graph.ID], 0, len(updates.Updates))
+		rows      = make([][]any, 0, len(updates.Updates))
+	)
+
+	for _, nextUpdate := range updates.Updates {
+		if mappedKindIDs, missingKinds := s.schemaManager.MapKinds(nextUpdate.Node.Kinds); len(missingKinds) > 0 {
+			return fmt.Errorf("unable to map kinds %v", missingKinds)
+		} else if properties, err := propertiesToJSON(nextUpdate.Node.Properties); err != nil {
+			return err
+		} else {
+			rows = append(rows, []any{int32(len(rows)), mappedKindIDs, properties})
+			idFutures = append(idFutures, nextUpdate.IDFuture)
+		}
+	}
+
+	graphTarget, err := s.innerTransaction.getTargetGraph()
+	if err != nil {
+		return err
+	}
+
+	if err := s.assertStagingTables(); err != nil {
+		return err
+	} else if err := s.copyRows(nodeStageTableName, nodeStageColumns, rows); err != nil {
+		return err
+	}
+
+	if err := func() error {
+		rows, err := s.innerTransaction.tx.Query(s.ctx, sql.FormatNodeUpsertFromStage(graphTarget, nodeStageTableName, updates.IdentityProperties), graphTarget.ID)
+		if err != nil {
+			return err
+		}
+
+		defer rows.Close()
+
+		for rows.Next() {
+			var (
+				stageIdx int32
+				nodeID   graph.ID
+			)
+
+			if err := rows.Scan(&stageIdx, &nodeID); err != nil {
+				return err
+			} else if stageIdx < 0 || int(stageIdx) >= len(idFutures) {
+				return fmt.Errorf("node upsert returned unknown staging index %d", stageIdx)
+			}
+
+			idFutures[stageIdx].Value = nodeID
+		}
+
+		return rows.Err()
+	}(); err != nil {
+		return err
+	}
+
+	_, err = s.innerTransaction.tx.Exec(s.ctx, truncateNodeStageStatement)
+	return err
+}
+
+func (s *batch) bulkRelationshipUpdateByBuffer(updates *sql.RelationshipUpdateBatch) error {
+	if err := s.bulkNodeUpsertBatch(updates.NodeUpdates); err != nil {
+		return err
+	}
+
+	parameters := NewRelationshipUpdateByParameters(len(updates.Updates))
+
+	if err := parameters.AppendAll(updates, s.schemaManager); err != nil {
+		return err
+	}
+
+	rows := make([][]any, len(parameters.Properties))
+
+	for idx := range rows {
+		rows[idx] = []any{parameters.StartIDs[idx].Uint32(), parameters.EndIDs[idx].Uint32(), parameters.KindIDs[idx], parameters.Properties[idx].Bytes}
+	}
+
+	if graphTarget, err := s.innerTransaction.getTargetGraph(); err != nil {
+		return err
+	} else if err := s.copyRows(edgeStageTableName, edgeStageColumns, rows); err != nil {
+		return err
+	} else if _, err := s.innerTransaction.tx.Exec(s.ctx, sql.FormatRelationshipPartitionUpsertFromStage(graphTarget, edgeStageTableName), graphTarget.ID); err != nil {
+		return err
+	}
+
+	_, err := s.innerTransaction.tx.Exec(s.ctx, truncateEdgeStageStatement)
+	return err
+}
+
+func (s *batch) bulkRelationshipCreateBatch(createBatch *relationshipCreateBatch) error {
+	rows := make([][]any, len(createBatch.startIDs))
+
+	for idx := range rows {
+		rows[idx] = []any{createBatch.startIDs[idx], createBatch.endIDs[idx], createBatch.edgeKindIDs[idx], createBatch.edgePropertyBags[idx].Bytes}
+	}
+
+	if graphTarget, err := s.innerTransaction.getTargetGraph(); err != nil {
+		return err
+	} else if err := s.assertStagingTables(); err != nil {
+		return err
+	} else if err := s.copyRows(edgeStageTableName, edgeStageColumns, rows); err != nil {
+		return err
+	} else if _, err := s.innerTransaction.tx.Exec(s.ctx, createEdgeFromStageStatement, graphTarget.ID); err != nil {
+		return err
+	}
+
+	_, err := s.innerTransaction.tx.Exec(s.ctx, truncateEdgeStageStatement)
+	return err
+}
@@ -23,7 +23,9 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"time"
 
+	"github.com/specterops/bloodhound/dawgs/drivers/pg/model"
 	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/util/size"
 )
 
 var (
@@ -37,10 +39,12 @@ var (
 )
 
 type Config struct {
-	Options            pgx.TxOptions
-	QueryExecMode      pgx.QueryExecMode
-	QueryResultFormats pgx.QueryResultFormats
-	BatchWriteSize     int
+	Options              pgx.TxOptions
+	QueryExecMode        pgx.QueryExecMode
+	QueryResultFormats   pgx.QueryResultFormats
+	BatchWriteSize       int
+	BulkLoad             bool
+	TraversalMemoryLimit size.Size
 }
 
 func OptionSetQueryExecMode(queryExecMode pgx.QueryExecMode) graph.TransactionOption {
@@ -51,11 +55,22 @@ func OptionSetQueryExecMode(queryExecMode pgx.QueryExecMode) graph.TransactionOp
 	}
 }
 
+// OptionSetBulkLoad toggles whether batch operations flush their buffers using the PostgreSQL COPY protocol
+func OptionSetBulkLoad(enabled bool) graph.TransactionOption {
+	return func(config *graph.TransactionConfig) {
+		if pgCfg, typeOK := config.DriverConfig.(*Config); typeOK {
+			pgCfg.BulkLoad = enabled
+		}
+	}
+}
+
 type Driver struct {
 	pool                      *pgxpool.Pool
 	schemaManager             *SchemaManager
 	defaultTransactionTimeout time.Duration
 	batchWriteSize            int
+	bulkLoad                  bool
+	traversalMemoryLimit      size.Size
 }
 
 func (s *Driver) SetDefaultGraph(ctx context.Context, graphSchema graph.Graph) error {
@@ -64,20 +79,46 @@ func (s *Driver) SetDefaultGraph(ctx context.Context, graphSchema graph.Graph) e
 	})
 }
 
+// AssertGraph creates the given graph and its partitions if it does not yet exist
+func (s *Driver) AssertGraph(ctx context.Context, graphSchema graph.Graph) error {
+	return s.WriteTransaction(ctx, func(tx graph.Transaction) error {
+		_, err := s.schemaManager.AssertGraph(tx, graphSchema)
+		return err
+	}, OptionSetQueryExecMode(pgx.QueryExecModeSimpleProtocol))
+}
+
+// DropGraph removes the named graph along with all of its nodes and edges
+func (s *Driver) DropGraph(ctx context.Context, name string) error {
+	return s.WriteTransaction(ctx, func(tx graph.Transaction) error {
+		return s.schemaManager.DropGraph(tx, name)
+	}, OptionSetQueryExecMode(pgx.QueryExecModeSimpleProtocol))
+}
+
 func (s *Driver) KindMapper() KindMapper {
 	return s.schemaManager
 }
 
+// DefaultGraph returns the graph that operations are scoped to when a transaction has no graph target set
+func (s *Driver) DefaultGraph() (model.Graph, bool) {
+	return s.schemaManager.DefaultGraph()
+}
+
 func (s *Driver) SetBatchWriteSize(size int) {
 	s.batchWriteSize = size
 }
 
+// SetBulkLoad opts batch operations into loading data with the PostgreSQL COPY protocol. This is considerably faster
+// for large, first-time loads of data at the cost of staging each flushed batch in a temporary table.
+func (s *Driver) SetBulkLoad(enabled bool) {
+	s.bulkLoad = enabled
+}
+
 func (s *Driver) SetWriteFlushSize(size int) {
 	// THis is a no-op function since PostgreSQL does not require transaction rotation like Neo4j does
 }
 
 func (s *Driver) BatchOperation(ctx context.Context, batchDelegate graph.BatchDelegate) error {
-	if cfg, err := renderConfig(s.batchWriteSize, readWriteTxOptions, nil); err != nil {
+	if cfg, err := s.renderConfig(readWriteTxOptions, []graph.TransactionOption{OptionSetBulkLoad(s.bulkLoad)}); err != nil {
 		return err
 	} else if conn, err := s.pool.Acquire(ctx); err != nil {
 		return err
@@ -103,13 +144,14 @@ func (s *Driver) Close(ctx context.Context) error {
 	return nil
 }
 
-func renderConfig(batchWriteSize int, pgxOptions pgx.TxOptions, userOptions []graph.TransactionOption) (*Config, error) {
+func (s *Driver) renderConfig(pgxOptions pgx.TxOptions, userOptions []graph.TransactionOption) (*Config, error) {
 	graphCfg := graph.TransactionConfig{
 		DriverConfig: &Config{
-			Options:            pgxOptions,
-			QueryExecMode:      pgx.QueryExecModeCacheStatement,
-			QueryResultFormats: pgx.QueryResultFormats{pgx.BinaryFormatCode},
-			BatchWriteSize:     batchWriteSize,
+			Options:              pgxOptions,
+			QueryExecMode:        pgx.QueryExecModeCacheStatement,
+			QueryResultFormats:   pgx.QueryResultFormats{pgx.BinaryFormatCode},
+			BatchWriteSize:       s.batchWriteSize,
+			TraversalMemoryLimit: s.traversalMemoryLimit,
 		},
 	}
 
@@ -129,7 +171,7 @@ func renderConfig(batchWriteSize int, pgxOptions pgx.TxOptions, userOptions []gr
 }
 
 func (s *Driver) ReadTransaction(ctx context.Context, txDelegate graph.TransactionDelegate, options ...graph.TransactionOption) error {
-	if cfg, err := renderConfig(s.batchWriteSize, readOnlyTxOptions, options); err != nil {
+	if cfg, err := s.renderConfig(readOnlyTxOptions, options); err != nil {
 		return err
 	} else if conn, err := s.pool.Acquire(ctx); err != nil {
 		return err
@@ -137,17 +179,18 @@ func (s *Driver) ReadTransaction(ctx context.Context, txDelegate graph.Transacti
 		defer conn.Release()
 
 		return txDelegate(&transaction{
-			schemaManager:   s.schemaManager,
-			queryExecMode:   cfg.QueryExecMode,
-			ctx:             ctx,
-			conn:            conn,
-			targetSchemaSet: false,
+			schemaManager:        s.schemaManager,
+			queryExecMode:        cfg.QueryExecMode,
+			traversalMemoryLimit: cfg.TraversalMemoryLimit,
+			ctx:                  ctx,
+			conn:                 conn,
+			targetSchemaSet:      false,
 		})
 	}
 }
 
 func (s *Driver) WriteTransaction(ctx context.Context, txDelegate graph.TransactionDelegate, options ...graph.TransactionOption) error {
-	if cfg, err := renderConfig(s.batchWriteSize, readWriteTxOptions, options); err != nil {
+	if cfg, err := s.renderConfig(readWriteTxOptions, options); err != nil {
 		return err
 	} else if conn, err := s.pool.Acquire(ctx); err != nil {
 		return err
@@ -176,11 +219,17 @@ func (s *Driver) FetchSchema(ctx context.Context) (graph.Schema, error) {
 }
 
 func (s *Driver) AssertSchema(ctx context.Context, schema graph.Schema) error {
+	var indexStatements []string
+
 	if err := s.WriteTransaction(ctx, func(tx graph.Transaction) error {
 		if err := s.schemaManager.AssertSchema(tx, schema); err != nil {
 			return err
 		} else if schema.DefaultGraph.Name != "" {
-			return s.schemaManager.AssertDefaultGraph(tx, schema.DefaultGraph)
+			if statements, err := s.schemaManager.AssertDefaultGraph(tx, schema.DefaultGraph); err != nil {
+				return err
+			} else {
+				indexStatements = statements
+			}
 		}
 
 		return nil
@@ -191,6 +240,14 @@ func (s *Driver) AssertSchema(ctx context.Context, schema graph.Schema) error {
 		s.pool.Reset()
 	}
 
+	// Indexes added to an existing graph are built concurrently so that writes to the graph are not blocked for the
+	// duration of the build. Concurrent builds may not run within a transaction and are therefore executed directly.
+	for _, indexStatement := range indexStatements {
+		if _, err := s.pool.Exec(ctx, indexStatement, pgx.QueryExecModeSimpleProtocol); err != nil {
+			return fmt.Errorf("failed building index: %w", err)
+		}
+	}
+
 	return nil
 }
 
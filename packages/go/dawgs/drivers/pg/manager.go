@@ -18,6 +18,7 @@ package pg
 
 import (
 	"errors"
+	"fmt"
 	"sync"
 
 	"github.com/jackc/pgx/v5"
@@ -179,7 +180,7 @@ func (s *SchemaManager) SetDefaultGraph(tx graph.Transaction, schema graph.Graph
 		return err
 	} else {
 		s.graphs[schema.Name] = definition
-		
+
 		s.defaultGraph = definition
 		s.hasDefaultGraph = true
 	}
@@ -187,18 +188,36 @@ func (s *SchemaManager) SetDefaultGraph(tx graph.Transaction, schema graph.Graph
 	return nil
 }
 
-func (s *SchemaManager) AssertDefaultGraph(tx graph.Transaction, schema graph.Graph) error {
-	if graphInstance, err := s.AssertGraph(tx, schema); err != nil {
-		return err
-	} else {
-		s.lock.Lock()
-		defer s.lock.Unlock()
+// AssertDefaultGraph creates or validates the default graph. Unlike AssertGraph this always validates the graph so that
+// index changes made to the schema at runtime are applied even if the graph definition has already been cached.
+//
+// Property indexes missing from an existing graph are not built in the given transaction. Their DDL is instead returned
+// and must be run by the caller outside of a transaction once the given transaction has committed.
+func (s *SchemaManager) AssertDefaultGraph(tx graph.Transaction, schema graph.Graph) ([]string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
 
-		s.defaultGraph = graphInstance
-		s.hasDefaultGraph = true
+	var (
+		graphInstance   model.Graph
+		indexStatements []string
+	)
+
+	if definition, err := query.On(tx).SelectGraphByName(schema.Name); err != nil {
+		// ErrNoRows signifies that this graph must be created
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		} else if graphInstance, err = query.On(tx).CreateGraph(schema); err != nil {
+			return nil, err
+		}
+	} else if graphInstance, indexStatements, err = query.On(tx).AssertGraphConcurrently(schema, definition); err != nil {
+		return nil, err
 	}
 
-	return nil
+	s.graphs[schema.Name] = graphInstance
+	s.defaultGraph = graphInstance
+	s.hasDefaultGraph = true
+
+	return indexStatements, nil
 }
 
 func (s *SchemaManager) DefaultGraph() (model.Graph, bool) {
@@ -230,6 +249,12 @@ func (s *SchemaManager) AssertGraph(tx graph.Transaction, schema graph.Graph) (m
 		return graphInstance, nil
 	}
 
+	return s.assertGraph(tx, schema)
+}
+
+// assertGraph validates the graph's indexes and constraints against the given schema, creating the graph if it does
+// not yet exist. Callers must hold the write-lock.
+func (s *SchemaManager) assertGraph(tx graph.Transaction, schema graph.Graph) (model.Graph, error) {
 	// Validate the schema if the graph already exists in the database
 	if definition, err := query.On(tx).SelectGraphByName(schema.Name); err != nil {
 		// ErrNoRows signifies that this graph must be created
@@ -252,6 +277,25 @@ func (s *SchemaManager) AssertGraph(tx graph.Transaction, schema graph.Graph) (m
 	}
 }
 
+// DropGraph removes the named graph and its partitions. The default graph may not be dropped.
+func (s *SchemaManager) DropGraph(tx graph.Transaction, name string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.hasDefaultGraph && s.defaultGraph.Name == name {
+		return fmt.Errorf("refusing to drop the default graph %s", name)
+	}
+
+	if definition, err := query.On(tx).SelectGraphByName(name); err != nil {
+		return err
+	} else if err := query.On(tx).DropGraph(definition); err != nil {
+		return err
+	}
+
+	delete(s.graphs, name)
+	return nil
+}
+
 func (s *SchemaManager) AssertSchema(tx graph.Transaction, schema graph.Schema) error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
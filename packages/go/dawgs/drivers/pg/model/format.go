@@ -39,11 +39,19 @@ func EdgePartitionTableName(graphID int32) string {
 	return partitionTableName(EdgeTable, graphID)
 }
 
+// IndexName returns the name of the given index on the given table. Indexes scoped to a kind are qualified by the kind
+// so that indexes on the same field for different kinds do not collide.
 func IndexName(table string, index graph.Index) string {
 	stringBuilder := strings.Builder{}
 
 	stringBuilder.WriteString(table)
 	stringBuilder.WriteString("_")
+
+	if index.Kind != nil {
+		stringBuilder.WriteString(strings.ToLower(index.Kind.String()))
+		stringBuilder.WriteString("_")
+	}
+
 	stringBuilder.WriteString(index.Field)
 	stringBuilder.WriteString("_index")
 
@@ -20,7 +20,7 @@ import (
 	"bytes"
 	"context"
 	"github.com/specterops/bloodhound/cypher/backend/pgsql"
-	"github.com/specterops/bloodhound/cypher/backend/pgsql/pgtransition"
+	"github.com/specterops/bloodhound/dawgs/drivers/pg/model"
 	"github.com/specterops/bloodhound/dawgs/graph"
 	"github.com/specterops/bloodhound/dawgs/query"
 )
@@ -45,22 +45,41 @@ func newLiveQuery(ctx context.Context, tx graph.Transaction, kindMapper KindMapp
 	}
 }
 
-func (s *liveQuery) runAllShortestPathsQuery() graph.Result {
-	if aspArguments, err := pgtransition.TranslateAllShortestPaths(s.queryBuilder.RegularQuery(), s.kindMapper); err != nil {
-		return graph.NewErrorResult(err)
-	} else {
-		return s.tx.Raw(`select (t.nodes, t.edges)::pathComposite from all_shortest_paths(@p1, @p2, @p3, @p4) as t`, map[string]any{
-			"p1": aspArguments.RootCriteria,
-			"p2": aspArguments.TraversalCriteria,
-			"p3": aspArguments.TerminalCriteria,
-			"p4": aspArguments.MaxDepth,
-		})
+// graphScopedTransaction is implemented by transactions that scope their queries to a single graph
+type graphScopedTransaction interface {
+	queryTarget() (model.Graph, bool, error)
+}
+
+// graphTarget returns the graph that this query is scoped to. Queries run against transactions that are not graph
+// aware span every graph.
+func (s *liveQuery) graphTarget() (model.Graph, bool, error) {
+	if scopedTx, isScoped := s.tx.(graphScopedTransaction); isScoped {
+		return scopedTx.queryTarget()
 	}
+
+	return model.Graph{}, false, nil
+}
+
+// nodeTable returns the name of the table that node criteria should be evaluated against
+func (s *liveQuery) nodeTable() (string, error) {
+	if graphTarget, hasTarget, err := s.graphTarget(); err != nil {
+		return "", err
+	} else if hasTarget {
+		return graphTarget.Partitions.Node.Name, nil
+	}
+
+	return model.NodeTable, nil
 }
 
 func (s *liveQuery) runRegularQuery() graph.Result {
 	buffer := &bytes.Buffer{}
 
+	if graphTarget, hasTarget, err := s.graphTarget(); err != nil {
+		return graph.NewErrorResult(err)
+	} else if hasTarget {
+		s.emitter.WithGraph(graphTarget)
+	}
+
 	if regularQuery, err := s.queryBuilder.Build(); err != nil {
 		return graph.NewErrorResult(err)
 	} else if arguments, err := pgsql.Translate(regularQuery, s.kindMapper); err != nil {
@@ -18,6 +18,7 @@ package pg
 
 import (
 	"context"
+	"github.com/specterops/bloodhound/dawgs/drivers/pg/model"
 	"github.com/specterops/bloodhound/dawgs/graph"
 	graph_mocks "github.com/specterops/bloodhound/dawgs/graph/mocks"
 	"github.com/specterops/bloodhound/dawgs/query"
@@ -97,3 +98,48 @@ func TestNodeQuery(t *testing.T) {
 	_, err := nodeQueryInst.First()
 	require.Nil(t, err)
 }
+
+type scopedTestTransaction struct {
+	*graph_mocks.MockTransaction
+
+	target model.Graph
+}
+
+func (s scopedTestTransaction) queryTarget() (model.Graph, bool, error) {
+	return s.target, true, nil
+}
+
+func TestNodeQuery_GraphScoped(t *testing.T) {
+	var (
+		mockCtrl   = gomock.NewController(t)
+		mockResult = graph_mocks.NewMockResult(mockCtrl)
+		mockTx     = scopedTestTransaction{
+			MockTransaction: graph_mocks.NewMockTransaction(mockCtrl),
+			target: model.Graph{
+				ID:   2,
+				Name: "workspace",
+				Partitions: model.GraphPartitions{
+					Node: model.NewGraphPartition(model.NodePartitionTableName(2)),
+					Edge: model.NewGraphPartition(model.EdgePartitionTableName(2)),
+				},
+			},
+		}
+
+		nodeQueryInst = &nodeQuery{
+			liveQuery: newLiveQuery(context.Background(), mockTx, testKindMapper{}),
+		}
+	)
+
+	mockTx.EXPECT().Raw(`select count(n) as "count(n)" from node_2 as n where (n.properties->>'prop')::text = @p0`, gomock.Any()).Return(mockResult)
+
+	mockResult.EXPECT().Error().Return(nil)
+	mockResult.EXPECT().Next().Return(true)
+	mockResult.EXPECT().Close().Return()
+	mockResult.EXPECT().Scan(gomock.Any()).Return(nil)
+
+	_, err := nodeQueryInst.Filter(
+		query.Equals(query.NodeProperty("prop"), "1234"),
+	).Count()
+
+	require.Nil(t, err)
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/specterops/bloodhound/cypher/model/pg"
 	"github.com/specterops/bloodhound/dawgs"
 	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/util/size"
 	"github.com/specterops/bloodhound/log"
 	"time"
 )
@@ -31,9 +32,11 @@ import (
 const (
 	DriverName = "pg"
 
-	poolInitConnectionTimeout = time.Second * 10
-	defaultTransactionTimeout = time.Minute * 15
-	defaultBatchWriteSize     = 20_000
+	poolInitConnectionTimeout   = time.Second * 10
+	defaultTransactionTimeout   = time.Minute * 15
+	defaultBatchWriteSize       = 20_000
+	defaultTraversalMemoryLimit = size.Gibibyte
+	propertyKeySampleSize       = 100_000
 )
 
 func afterPooledConnectionEstablished(ctx context.Context, conn *pgx.Conn) error {
@@ -90,6 +93,7 @@ func newDatabase(connectionString string) (*Driver, error) {
 				schemaManager:             NewSchemaManager(),
 				defaultTransactionTimeout: defaultTransactionTimeout,
 				batchWriteSize:            defaultBatchWriteSize,
+				traversalMemoryLimit:      defaultTraversalMemoryLimit,
 			}, nil
 		}
 	}
@@ -104,6 +108,10 @@ func init() {
 		} else if err := graphDB.AssertSchema(ctx, graph.Schema{}); err != nil {
 			return nil, err
 		} else {
+			if cfg.TraversalMemoryLimit > 0 {
+				graphDB.traversalMemoryLimit = cfg.TraversalMemoryLimit
+			}
+
 			return graphDB, nil
 		}
 	})
@@ -68,9 +68,19 @@ func formatCreatePropertyConstraint(constraintName, tableName, fieldName string,
 }
 
 func formatCreatePropertyIndex(indexName, tableName, fieldName string, indexType graph.IndexType) string {
+	return formatCreatePropertyIndexStatement("create index ", indexName, tableName, fieldName, indexType)
+}
+
+// formatCreatePropertyIndexConcurrently formats a property index build that does not block writes to the table. The
+// resulting statement may not be run within a transaction.
+func formatCreatePropertyIndexConcurrently(indexName, tableName, fieldName string, indexType graph.IndexType) string {
+	return formatCreatePropertyIndexStatement("create index concurrently ", indexName, tableName, fieldName, indexType)
+}
+
+func formatCreatePropertyIndexStatement(prefix, indexName, tableName, fieldName string, indexType graph.IndexType) string {
 	var (
 		pgIndexType  = postgresIndexType(indexType)
-		queryPartial = join("create index ", indexName, " on ", tableName, " using ",
+		queryPartial = join(prefix, indexName, " on ", tableName, " using ",
 			pgIndexType, " ((", tableName, ".", pgPropertiesColumn, " ->> '", fieldName)
 	)
 
@@ -96,6 +106,10 @@ func formatCreatePartitionTable(name, parent string, graphID int32) string {
 	return builder.String()
 }
 
+func formatDropTable(name string) string {
+	return join("drop table if exists ", name, ";")
+}
+
 func formatConflictMatcher(propertyNames []string, defaultOnConflict string) string {
 	builder := strings.Builder{}
 	builder.WriteString("on conflict (")
@@ -139,6 +153,79 @@ func FormatRelationshipPartitionUpsert(graphTarget model.Graph) string {
 	)
 }
 
+// FormatNodeUpsertFromStage renders the same upsert as FormatNodeUpsert but sources its rows from a staging table
+// populated by a bulk copy. Postgres does not guarantee the order of the rows returned by an insert so each returned
+// node ID is paired with the staging idx of the row that produced it.
+//
+// Node IDs are only allocated for staged rows that are inserted. When the upsert has identity properties, staged rows
+// that match an existing node update it in place and only the remainder is inserted. Inserted rows are matched back to
+// their staged row by their identity properties. Without identity properties every staged row is a new node and its ID
+// is allocated ahead of the insert so that the two may be matched by ID.
+func FormatNodeUpsertFromStage(graphTarget model.Graph, stageTableName string, identityProperties []string) string {
+	if len(identityProperties) == 0 {
+		return join(
+			"with staged as (select s.idx, nextval(pg_get_serial_sequence('node', 'id'))::int4 as id, s.kind_ids, s.properties from ", stageTableName, " as s), ",
+			"inserted as (insert into ", graphTarget.Partitions.Node.Name, " as n ",
+			"(graph_id, id, kind_ids, properties) ",
+			"select $1::int4, s.id, s.kind_ids, s.properties from staged as s ",
+			"returning n.id) ",
+			"select s.idx, i.id from staged as s join inserted as i on i.id = s.id;",
+		)
+	}
+
+	return join(
+		"with staged as (select s.idx, s.kind_ids, s.properties from ", stageTableName, " as s), ",
+		"updated as (update ", graphTarget.Partitions.Node.Name, " as n ",
+		"set properties = n.properties || s.properties, kind_ids = uniq(sort(n.kind_ids || s.kind_ids)) ",
+		"from staged as s where ", formatStageMatcher("n", identityProperties), " ",
+		"returning s.idx, n.id), ",
+		"inserted as (insert into ", graphTarget.Partitions.Node.Name, " as n ",
+		"(graph_id, kind_ids, properties) ",
+		"select $1::int4, s.kind_ids, s.properties from staged as s ",
+		"where not exists (select 1 from ", graphTarget.Partitions.Node.Name, " as e where ", formatStageMatcher("e", identityProperties), ") ",
+		// Guards against nodes inserted by a concurrent transaction after this statement's snapshot was taken
+		formatConflictMatcher(identityProperties, ""),
+		"do update set properties = n.properties || excluded.properties, kind_ids = uniq(sort(n.kind_ids || excluded.kind_ids)) ",
+		"returning n.id, n.properties) ",
+		"select u.idx, u.id from updated as u ",
+		"union all ",
+		"select s.idx, i.id from staged as s join inserted as i on ", formatStageMatcher("i", identityProperties), ";",
+	)
+}
+
+// formatStageMatcher renders the condition that matches a staged node row, aliased as s, to the node row aliased by
+// the given alias using the given identity properties.
+func formatStageMatcher(alias string, identityProperties []string) string {
+	builder := strings.Builder{}
+
+	for idx, propertyName := range identityProperties {
+		if idx > 0 {
+			builder.WriteString(" and ")
+		}
+
+		builder.WriteString(alias)
+		builder.WriteString(".properties->>'")
+		builder.WriteString(propertyName)
+		builder.WriteString("' = s.properties->>'")
+		builder.WriteString(propertyName)
+		builder.WriteString("'")
+	}
+
+	return builder.String()
+}
+
+// FormatRelationshipPartitionUpsertFromStage renders the same merge as FormatRelationshipPartitionUpsert but sources
+// its rows from a staging table populated by a bulk copy.
+func FormatRelationshipPartitionUpsertFromStage(graphTarget model.Graph, stageTableName string) string {
+	return join(
+		"merge into ", graphTarget.Partitions.Edge.Name, " as e ",
+		"using (select $1::int4 as gid, s.start_id as sid, s.end_id as eid, s.kind_id as kid, s.properties as p from ", stageTableName, " as s) as ei ",
+		"on e.start_id = ei.sid and e.end_id = ei.eid and e.kind_id = ei.kid ",
+		"when matched then update set properties = e.properties || ei.p ",
+		"when not matched then insert (graph_id, start_id, end_id, kind_id, properties) values (ei.gid, ei.sid, ei.eid, ei.kid, ei.p);",
+	)
+}
+
 type NodeUpdate struct {
 	IDFuture *Future[graph.ID]
 	Node     *graph.Node
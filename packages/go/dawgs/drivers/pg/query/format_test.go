@@ -0,0 +1,74 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package query_test
+
+import (
+	"testing"
+
+	"github.com/specterops/bloodhound/dawgs/drivers/pg/model"
+	"github.com/specterops/bloodhound/dawgs/drivers/pg/query"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/stretchr/testify/require"
+)
+
+var testGraph = model.Graph{
+	ID:   1,
+	Name: "test",
+	Partitions: model.GraphPartitions{
+		Node: model.NewGraphPartition("node_1"),
+		Edge: model.NewGraphPartition("edge_1"),
+	},
+}
+
+func TestFormatNodeUpsertFromStage(t *testing.T) {
+	require.Equal(t,
+		"with staged as (select s.idx, s.kind_ids, s.properties from node_bulk_stage as s), updated as (update node_1 as n set properties = n.properties || s.properties, kind_ids = uniq(sort(n.kind_ids || s.kind_ids)) from staged as s where n.properties->>'objectid' = s.properties->>'objectid' returning s.idx, n.id), inserted as (insert into node_1 as n (graph_id, kind_ids, properties) select $1::int4, s.kind_ids, s.properties from staged as s where not exists (select 1 from node_1 as e where e.properties->>'objectid' = s.properties->>'objectid') on conflict ((properties->>'objectid')) do update set properties = n.properties || excluded.properties, kind_ids = uniq(sort(n.kind_ids || excluded.kind_ids)) returning n.id, n.properties) select u.idx, u.id from updated as u union all select s.idx, i.id from staged as s join inserted as i on i.properties->>'objectid' = s.properties->>'objectid';",
+		query.FormatNodeUpsertFromStage(testGraph, "node_bulk_stage", []string{"objectid"}),
+	)
+
+	require.Equal(t,
+		"with staged as (select s.idx, nextval(pg_get_serial_sequence('node', 'id'))::int4 as id, s.kind_ids, s.properties from node_bulk_stage as s), inserted as (insert into node_1 as n (graph_id, id, kind_ids, properties) select $1::int4, s.id, s.kind_ids, s.properties from staged as s returning n.id) select s.idx, i.id from staged as s join inserted as i on i.id = s.id;",
+		query.FormatNodeUpsertFromStage(testGraph, "node_bulk_stage", nil),
+	)
+}
+
+func TestFormatRelationshipPartitionUpsertFromStage(t *testing.T) {
+	require.Equal(t,
+		"merge into edge_1 as e using (select $1::int4 as gid, s.start_id as sid, s.end_id as eid, s.kind_id as kid, s.properties as p from edge_bulk_stage as s) as ei on e.start_id = ei.sid and e.end_id = ei.eid and e.kind_id = ei.kid when matched then update set properties = e.properties || ei.p when not matched then insert (graph_id, start_id, end_id, kind_id, properties) values (ei.gid, ei.sid, ei.eid, ei.kid, ei.p);",
+		query.FormatRelationshipPartitionUpsertFromStage(testGraph, "edge_bulk_stage"),
+	)
+}
+
+func TestNewGraphPartitionFromSchema_KindScopedIndexes(t *testing.T) {
+	partition := model.NewGraphPartitionFromSchema("node_1", []graph.Index{{
+		Field: "name",
+		Type:  graph.BTreeIndex,
+	}, {
+		Field: "department",
+		Type:  graph.BTreeIndex,
+		Kind:  graph.StringKind("User"),
+	}, {
+		Field: "department",
+		Type:  graph.TextSearchIndex,
+		Kind:  graph.StringKind("Group"),
+	}}, nil)
+
+	require.Len(t, partition.Indexes, 3)
+	require.Contains(t, partition.Indexes, "node_1_name_index")
+	require.Equal(t, graph.BTreeIndex, partition.Indexes["node_1_user_department_index"].Type)
+	require.Equal(t, graph.TextSearchIndex, partition.Indexes["node_1_group_department_index"].Type)
+}
@@ -19,6 +19,8 @@ package query
 import (
 	_ "embed"
 	"fmt"
+	"slices"
+
 	"github.com/jackc/pgx/v5"
 	"github.com/specterops/bloodhound/dawgs/drivers/pg/model"
 	"github.com/specterops/bloodhound/dawgs/graph"
@@ -73,6 +75,19 @@ func (s Query) describeGraphPartition(name string) (model.GraphPartition, error)
 		}
 	}
 
+	// Invalid indexes are left behind by failed concurrent builds. Describing them as unsupported ensures that they are
+	// either dropped or rebuilt when the partition is next asserted.
+	if invalidIndexNames, err := s.SelectInvalidTableIndexNames(name); err != nil {
+		return graphPartition, err
+	} else {
+		for _, indexName := range invalidIndexNames {
+			if index, isIndex := graphPartition.Indexes[indexName]; isIndex {
+				index.Type = graph.UnsupportedIndex
+				graphPartition.Indexes[indexName] = index
+			}
+		}
+	}
+
 	return graphPartition, nil
 }
 
@@ -288,6 +303,29 @@ func (s Query) SelectTableIndexDefinitions(tableName string) ([]string, error) {
 	return definitions, result.Error()
 }
 
+func (s Query) SelectInvalidTableIndexNames(tableName string) ([]string, error) {
+	var (
+		name  string
+		names []string
+
+		result = s.tx.Raw(sqlSelectInvalidTableIndexes, map[string]any{
+			"tablename": tableName,
+		})
+	)
+
+	defer result.Close()
+
+	for result.Next() {
+		if err := result.Scan(&name); err != nil {
+			return nil, err
+		}
+
+		names = append(names, name)
+	}
+
+	return names, result.Error()
+}
+
 func (s Query) SelectKindID(kind graph.Kind) (int16, error) {
 	var (
 		kindID int16
@@ -350,6 +388,53 @@ func (s Query) assertGraphPartitionIndexes(partitions model.GraphPartitions, ind
 }
 
 func (s Query) AssertGraph(schema graph.Graph, definition model.Graph) (model.Graph, error) {
+	if assertedDefinition, indexChangeSet, err := s.graphIndexChanges(schema, definition); err != nil {
+		return model.Graph{}, err
+	} else {
+		return assertedDefinition, s.assertGraphPartitionIndexes(definition.Partitions, indexChangeSet)
+	}
+}
+
+// AssertGraphConcurrently validates the indexes and constraints of an existing graph against the given schema. Unlike
+// AssertGraph, missing property indexes are not created. Their DDL is instead returned so that they may be built
+// concurrently, without blocking writes to the graph, once the current transaction has committed.
+func (s Query) AssertGraphConcurrently(schema graph.Graph, definition model.Graph) (model.Graph, []string, error) {
+	if assertedDefinition, indexChangeSet, err := s.graphIndexChanges(schema, definition); err != nil {
+		return model.Graph{}, nil, err
+	} else {
+		indexStatements := make([]string, 0, len(indexChangeSet.NodeIndexesToAdd)+len(indexChangeSet.EdgeIndexesToAdd))
+
+		for _, indexName := range sortedKeys(indexChangeSet.NodeIndexesToAdd) {
+			index := indexChangeSet.NodeIndexesToAdd[indexName]
+			indexStatements = append(indexStatements, formatCreatePropertyIndexConcurrently(indexName, definition.Partitions.Node.Name, index.Field, index.Type))
+		}
+
+		for _, indexName := range sortedKeys(indexChangeSet.EdgeIndexesToAdd) {
+			index := indexChangeSet.EdgeIndexesToAdd[indexName]
+			indexStatements = append(indexStatements, formatCreatePropertyIndexConcurrently(indexName, definition.Partitions.Edge.Name, index.Field, index.Type))
+		}
+
+		indexChangeSet.NodeIndexesToAdd = map[string]graph.Index{}
+		indexChangeSet.EdgeIndexesToAdd = map[string]graph.Index{}
+
+		return assertedDefinition, indexStatements, s.assertGraphPartitionIndexes(definition.Partitions, indexChangeSet)
+	}
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+	return keys
+}
+
+// graphIndexChanges computes the index and constraint changes required to bring the given graph definition in line with
+// the given schema.
+func (s Query) graphIndexChanges(schema graph.Graph, definition model.Graph) (model.Graph, model.IndexChangeSet, error) {
 	var (
 		requiredNodePartition = model.NewGraphPartitionFromSchema(definition.Partitions.Node.Name, schema.NodeIndexes, schema.NodeConstraints)
 		requiredEdgePartition = model.NewGraphPartitionFromSchema(definition.Partitions.Edge.Name, schema.EdgeIndexes, schema.EdgeConstraints)
@@ -357,7 +442,7 @@ func (s Query) AssertGraph(schema graph.Graph, definition model.Graph) (model.Gr
 	)
 
 	if presentNodePartition, err := s.describeGraphPartition(definition.Partitions.Node.Name); err != nil {
-		return model.Graph{}, err
+		return model.Graph{}, indexChangeSet, err
 	} else {
 		for presentNodeIndexName := range presentNodePartition.Indexes {
 			if _, hasMatchingDefinition := requiredNodePartition.Indexes[presentNodeIndexName]; !hasMatchingDefinition {
@@ -391,7 +476,7 @@ func (s Query) AssertGraph(schema graph.Graph, definition model.Graph) (model.Gr
 	}
 
 	if presentEdgePartition, err := s.describeGraphPartition(definition.Partitions.Edge.Name); err != nil {
-		return model.Graph{}, err
+		return model.Graph{}, indexChangeSet, err
 	} else {
 		for presentEdgeIndexName := range presentEdgePartition.Indexes {
 			if _, hasMatchingDefinition := requiredEdgePartition.Indexes[presentEdgeIndexName]; !hasMatchingDefinition {
@@ -431,7 +516,7 @@ func (s Query) AssertGraph(schema graph.Graph, definition model.Graph) (model.Gr
 			Node: requiredNodePartition,
 			Edge: requiredEdgePartition,
 		},
-	}, s.assertGraphPartitionIndexes(definition.Partitions, indexChangeSet)
+	}, indexChangeSet, nil
 }
 
 func (s Query) createGraphPartitions(definition model.Graph) (model.Graph, error) {
@@ -465,6 +550,24 @@ func (s Query) CreateGraph(schema graph.Graph) (model.Graph, error) {
 	}
 }
 
+// DropGraph drops the partition tables of the given graph and then removes its entry from the graph table
+func (s Query) DropGraph(definition model.Graph) error {
+	var (
+		nodePartitionName = model.NodePartitionTableName(definition.ID)
+		edgePartitionName = model.EdgePartitionTableName(definition.ID)
+	)
+
+	if err := s.exec(formatDropTable(edgePartitionName), nil); err != nil {
+		return err
+	} else if err := s.exec(formatDropTable(nodePartitionName), nil); err != nil {
+		return err
+	}
+
+	return s.exec(sqlDeleteGraph, map[string]any{
+		"id": definition.ID,
+	})
+}
+
 func (s Query) InsertOrGetKind(kind graph.Kind) (int16, error) {
 	var (
 		kindID int16
@@ -59,13 +59,15 @@ func loadSQL(name string) string {
 }
 
 var (
-	sqlSchemaUp           = loadSQL("schema_up.sql")
-	sqlSchemaDown         = loadSQL("schema_down.sql")
-	sqlSelectTableIndexes = loadSQL("select_table_indexes.sql")
-	sqlSelectKindID       = loadSQL("select_table_indexes.sql")
-	sqlSelectGraphs       = loadSQL("select_graphs.sql")
-	sqlInsertGraph        = loadSQL("insert_graph.sql")
-	sqlInsertKind         = loadSQL("insert_or_get_kind.sql")
-	sqlSelectKinds        = loadSQL("select_kinds.sql")
-	sqlSelectGraphByName  = loadSQL("select_graph_by_name.sql")
+	sqlSchemaUp                  = loadSQL("schema_up.sql")
+	sqlSchemaDown                = loadSQL("schema_down.sql")
+	sqlSelectTableIndexes        = loadSQL("select_table_indexes.sql")
+	sqlSelectInvalidTableIndexes = loadSQL("select_invalid_table_indexes.sql")
+	sqlSelectKindID              = loadSQL("select_table_indexes.sql")
+	sqlSelectGraphs              = loadSQL("select_graphs.sql")
+	sqlInsertGraph               = loadSQL("insert_graph.sql")
+	sqlInsertKind                = loadSQL("insert_or_get_kind.sql")
+	sqlSelectKinds               = loadSQL("select_kinds.sql")
+	sqlSelectGraphByName         = loadSQL("select_graph_by_name.sql")
+	sqlDeleteGraph               = loadSQL("delete_graph.sql")
 )
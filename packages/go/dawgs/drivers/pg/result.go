@@ -115,3 +115,51 @@ func (s *queryResult) Error() error {
 func (s *queryResult) Close() {
 	s.rows.Close()
 }
+
+// pathResult is a result over paths that were assembled by the driver rather than read directly from a query.
+type pathResult struct {
+	paths      []graph.Path
+	current    graph.Path
+	kindMapper KindMapper
+}
+
+func newPathResult(paths []graph.Path, kindMapper KindMapper) *pathResult {
+	return &pathResult{
+		paths:      paths,
+		kindMapper: kindMapper,
+	}
+}
+
+func (s *pathResult) Next() bool {
+	if len(s.paths) == 0 {
+		return false
+	}
+
+	s.current = s.paths[0]
+	s.paths = s.paths[1:]
+
+	return true
+}
+
+func (s *pathResult) Values() (graph.ValueMapper, error) {
+	return NewValueMapper([]any{s.current}, s.kindMapper), nil
+}
+
+func (s *pathResult) Scan(targets ...any) error {
+	if len(targets) != 1 {
+		return fmt.Errorf("expected a single scan target for path results but saw %d", len(targets))
+	} else if pathTarget, typeOK := targets[0].(*graph.Path); !typeOK {
+		return fmt.Errorf("unsupported scan target type for path results: %T", targets[0])
+	} else {
+		*pathTarget = s.current
+		return nil
+	}
+}
+
+func (s *pathResult) Error() error {
+	return nil
+}
+
+func (s *pathResult) Close() {
+	s.paths = nil
+}
@@ -0,0 +1,493 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pg
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/specterops/bloodhound/cypher/backend/pgsql/pgtransition"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/ops"
+	"github.com/specterops/bloodhound/dawgs/util/size"
+)
+
+const (
+	// shortestPathFetchBatchSize caps the number of frontier node IDs sent with each adjacency fetch
+	shortestPathFetchBatchSize = 10_000
+)
+
+var (
+	pathLinkSize     = size.Of(pathLink{})
+	visitedEntrySize = size.Of(graph.ID(0))*2 + size.Of(0) + size.Of([]pathLink(nil))
+)
+
+// adjacencyFetcher returns every relationship that leaves (outbound) or enters (inbound) the given nodes.
+type adjacencyFetcher func(direction graph.Direction, nodeIDs []graph.ID) ([]graph.RelationshipTripleResult, error)
+
+// pathLink records a relationship that reaches a node at its shortest depth from one side of the search along with
+// the node on the far side of that relationship.
+type pathLink struct {
+	relationshipID graph.ID
+	nodeID         graph.ID
+}
+
+// idPath is a path that has not yet been hydrated with node and relationship data.
+type idPath struct {
+	nodeIDs         []graph.ID
+	relationshipIDs []graph.ID
+}
+
+func (s idPath) isSimple() bool {
+	visited := make(map[graph.ID]struct{}, len(s.nodeIDs))
+
+	for _, nodeID := range s.nodeIDs {
+		if _, seen := visited[nodeID]; seen {
+			return false
+		}
+
+		visited[nodeID] = struct{}{}
+	}
+
+	return true
+}
+
+// searchFront is one side of a bidirectional breadth-first search. The forward front expands outbound from the root
+// nodes while the backward front expands inbound from the terminal nodes.
+type searchFront struct {
+	direction graph.Direction
+	depth     int
+	frontier  []graph.ID
+	depths    map[graph.ID]int
+	links     map[graph.ID][]pathLink
+}
+
+func newSearchFront(direction graph.Direction, origins []graph.ID) *searchFront {
+	front := &searchFront{
+		direction: direction,
+		depths:    make(map[graph.ID]int, len(origins)),
+		links:     map[graph.ID][]pathLink{},
+	}
+
+	for _, origin := range origins {
+		if _, seen := front.depths[origin]; !seen {
+			front.depths[origin] = 0
+			front.frontier = append(front.frontier, origin)
+		}
+	}
+
+	return front
+}
+
+// walk returns every shortest path from this front's origins to the given node. Paths are ordered from the origin to
+// the node.
+func (s *searchFront) walk(nodeID graph.ID) []idPath {
+	if s.depths[nodeID] == 0 {
+		return []idPath{{
+			nodeIDs: []graph.ID{nodeID},
+		}}
+	}
+
+	var paths []idPath
+
+	for _, link := range s.links[nodeID] {
+		for _, prefix := range s.walk(link.nodeID) {
+			paths = append(paths, idPath{
+				nodeIDs:         append(prefix.nodeIDs[:len(prefix.nodeIDs):len(prefix.nodeIDs)], nodeID),
+				relationshipIDs: append(prefix.relationshipIDs[:len(prefix.relationshipIDs):len(prefix.relationshipIDs)], link.relationshipID),
+			})
+		}
+	}
+
+	return paths
+}
+
+// shortestPathSearch finds all shortest paths between a set of root nodes and a set of terminal nodes by expanding
+// one breadth-first front from each set and stopping at the first depth where the two fronts meet. The smaller of the
+// two frontiers is always expanded next which keeps the explored pathspace far smaller than a one-sided expansion on
+// densely connected graphs.
+//
+// Nodes that are both a root and a terminal are zero-length matches and are not reported.
+type shortestPathSearch struct {
+	ctx           context.Context
+	maxDepth      int
+	memoryLimit   size.Size
+	memoryInUse   size.Size
+	fetchAdjacent adjacencyFetcher
+}
+
+func (s *shortestPathSearch) allocate(allocation size.Size) error {
+	s.memoryInUse += allocation
+
+	if s.memoryLimit > 0 && s.memoryInUse > s.memoryLimit {
+		return fmt.Errorf("%w - Limit: %.2f MB - Memory In-Use: %.2f MB", ops.ErrTraversalMemoryLimit, s.memoryLimit.Mebibytes(), s.memoryInUse.Mebibytes())
+	}
+
+	return nil
+}
+
+// expand advances the given front by one depth and returns the newly discovered nodes that the opposite front has
+// already visited at its shallowest depth.
+func (s *shortestPathSearch) expand(front, opposite *searchFront) ([]graph.ID, error) {
+	var (
+		nextDepth    = front.depth + 1
+		nextFrontier []graph.ID
+	)
+
+	for batchStart := 0; batchStart < len(front.frontier); batchStart += shortestPathFetchBatchSize {
+		batchEnd := min(batchStart+shortestPathFetchBatchSize, len(front.frontier))
+
+		if relationships, err := s.fetchAdjacent(front.direction, front.frontier[batchStart:batchEnd]); err != nil {
+			return nil, err
+		} else {
+			for _, relationship := range relationships {
+				fromID, toID := relationship.StartID, relationship.EndID
+
+				if front.direction == graph.DirectionInbound {
+					fromID, toID = toID, fromID
+				}
+
+				if depth, visited := front.depths[toID]; visited && depth != nextDepth {
+					continue
+				} else if !visited {
+					front.depths[toID] = nextDepth
+					nextFrontier = append(nextFrontier, toID)
+
+					if err := s.allocate(visitedEntrySize); err != nil {
+						return nil, err
+					}
+				}
+
+				front.links[toID] = append(front.links[toID], pathLink{
+					relationshipID: relationship.ID,
+					nodeID:         fromID,
+				})
+
+				if err := s.allocate(pathLinkSize); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	front.depth = nextDepth
+	front.frontier = nextFrontier
+
+	var (
+		meetingDepth = -1
+		meetingNodes []graph.ID
+	)
+
+	for _, nodeID := range nextFrontier {
+		if oppositeDepth, visited := opposite.depths[nodeID]; visited {
+			if meetingDepth < 0 || oppositeDepth < meetingDepth {
+				meetingDepth = oppositeDepth
+				meetingNodes = meetingNodes[:0]
+			}
+
+			if oppositeDepth == meetingDepth {
+				meetingNodes = append(meetingNodes, nodeID)
+			}
+		}
+	}
+
+	return meetingNodes, nil
+}
+
+func (s *shortestPathSearch) join(forward, backward *searchFront, meetingNodes []graph.ID) ([]idPath, error) {
+	var paths []idPath
+
+	for _, meetingNodeID := range meetingNodes {
+		var (
+			prefixes = forward.walk(meetingNodeID)
+			suffixes = backward.walk(meetingNodeID)
+		)
+
+		for _, prefix := range prefixes {
+			for _, suffix := range suffixes {
+				path := idPath{
+					nodeIDs:         make([]graph.ID, 0, len(prefix.nodeIDs)+len(suffix.nodeIDs)-1),
+					relationshipIDs: make([]graph.ID, 0, len(prefix.relationshipIDs)+len(suffix.relationshipIDs)),
+				}
+
+				// Suffixes are walked from the terminal node back to the meeting node and must be reversed
+				path.nodeIDs = append(path.nodeIDs, prefix.nodeIDs...)
+				path.relationshipIDs = append(path.relationshipIDs, prefix.relationshipIDs...)
+
+				for idx := len(suffix.nodeIDs) - 2; idx >= 0; idx-- {
+					path.nodeIDs = append(path.nodeIDs, suffix.nodeIDs[idx])
+				}
+
+				for idx := len(suffix.relationshipIDs) - 1; idx >= 0; idx-- {
+					path.relationshipIDs = append(path.relationshipIDs, suffix.relationshipIDs[idx])
+				}
+
+				if !path.isSimple() {
+					continue
+				}
+
+				if err := s.allocate(size.OfSlice(path.nodeIDs) + size.OfSlice(path.relationshipIDs)); err != nil {
+					return nil, err
+				}
+
+				paths = append(paths, path)
+			}
+		}
+	}
+
+	return paths, nil
+}
+
+func (s *shortestPathSearch) run(rootIDs, terminalIDs []graph.ID) ([]idPath, error) {
+	var (
+		forward  = newSearchFront(graph.DirectionOutbound, rootIDs)
+		backward = newSearchFront(graph.DirectionInbound, terminalIDs)
+	)
+
+	if err := s.allocate(visitedEntrySize * size.Size(len(forward.depths)+len(backward.depths))); err != nil {
+		return nil, err
+	}
+
+	for forward.depth+backward.depth < s.maxDepth && len(forward.frontier) > 0 && len(backward.frontier) > 0 {
+		if err := s.ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		var (
+			meetingNodes []graph.ID
+			err          error
+		)
+
+		if len(forward.frontier) <= len(backward.frontier) {
+			meetingNodes, err = s.expand(forward, backward)
+		} else {
+			meetingNodes, err = s.expand(backward, forward)
+		}
+
+		if err != nil {
+			return nil, err
+		} else if len(meetingNodes) > 0 {
+			return s.join(forward, backward, meetingNodes)
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *liveQuery) fetchIDs(statement string, parameters map[string]any) ([]graph.ID, error) {
+	result := s.tx.Raw(statement, parameters)
+	defer result.Close()
+
+	var ids []graph.ID
+
+	for result.Next() {
+		var id int32
+
+		if err := result.Scan(&id); err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, graph.ID(id))
+	}
+
+	return ids, result.Error()
+}
+
+func toInt32IDs(ids []graph.ID) []int32 {
+	int32IDs := make([]int32, len(ids))
+
+	for idx, id := range ids {
+		int32IDs[idx] = int32(id.Uint32())
+	}
+
+	return int32IDs
+}
+
+func (s *liveQuery) adjacencyFetcher(traversalCriteria string) adjacencyFetcher {
+	return func(direction graph.Direction, nodeIDs []graph.ID) ([]graph.RelationshipTripleResult, error) {
+		var statement string
+
+		switch direction {
+		case graph.DirectionOutbound:
+			statement = selectOutboundAdjacencyStatement
+
+		case graph.DirectionInbound:
+			statement = selectInboundAdjacencyStatement
+
+		default:
+			return nil, fmt.Errorf("bad direction: %d", direction)
+		}
+
+		if traversalCriteria != "" {
+			statement += " and (" + traversalCriteria + ")"
+		}
+
+		result := s.tx.Raw(statement, map[string]any{
+			"ids": toInt32IDs(nodeIDs),
+		})
+		defer result.Close()
+
+		var relationships []graph.RelationshipTripleResult
+
+		for result.Next() {
+			var relationshipID, startID, endID int32
+
+			if err := result.Scan(&relationshipID, &startID, &endID); err != nil {
+				return nil, err
+			}
+
+			relationships = append(relationships, graph.RelationshipTripleResult{
+				ID:      graph.ID(relationshipID),
+				StartID: graph.ID(startID),
+				EndID:   graph.ID(endID),
+			})
+		}
+
+		return relationships, result.Error()
+	}
+}
+
+func (s *liveQuery) hydratePaths(idPaths []idPath) ([]graph.Path, error) {
+	var (
+		nodes           = map[graph.ID]*graph.Node{}
+		relationships   = map[graph.ID]*graph.Relationship{}
+		nodeIDs         []graph.ID
+		relationshipIDs []graph.ID
+	)
+
+	for _, path := range idPaths {
+		for _, nodeID := range path.nodeIDs {
+			if _, seen := nodes[nodeID]; !seen {
+				nodes[nodeID] = nil
+				nodeIDs = append(nodeIDs, nodeID)
+			}
+		}
+
+		for _, relationshipID := range path.relationshipIDs {
+			if _, seen := relationships[relationshipID]; !seen {
+				relationships[relationshipID] = nil
+				relationshipIDs = append(relationshipIDs, relationshipID)
+			}
+		}
+	}
+
+	if err := s.scanEach(fetchPathNodesStatement, nodeIDs, func(scanner graph.Scanner) error {
+		var node graph.Node
+
+		if err := scanner.Scan(&node); err != nil {
+			return err
+		}
+
+		nodes[node.ID] = &node
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := s.scanEach(fetchPathEdgesStatement, relationshipIDs, func(scanner graph.Scanner) error {
+		var relationship graph.Relationship
+
+		if err := scanner.Scan(&relationship); err != nil {
+			return err
+		}
+
+		relationships[relationship.ID] = &relationship
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	paths := make([]graph.Path, 0, len(idPaths))
+
+	for _, path := range idPaths {
+		hydratedPath := graph.Path{
+			Nodes: make([]*graph.Node, len(path.nodeIDs)),
+			Edges: make([]*graph.Relationship, len(path.relationshipIDs)),
+		}
+
+		for idx, nodeID := range path.nodeIDs {
+			if hydratedPath.Nodes[idx] = nodes[nodeID]; hydratedPath.Nodes[idx] == nil {
+				return nil, fmt.Errorf("path node %d no longer exists", nodeID)
+			}
+		}
+
+		for idx, relationshipID := range path.relationshipIDs {
+			if hydratedPath.Edges[idx] = relationships[relationshipID]; hydratedPath.Edges[idx] == nil {
+				return nil, fmt.Errorf("path relationship %d no longer exists", relationshipID)
+			}
+		}
+
+		paths = append(paths, hydratedPath)
+	}
+
+	return paths, nil
+}
+
+func (s *liveQuery) scanEach(statement string, ids []graph.ID, delegate func(scanner graph.Scanner) error) error {
+	for batchStart := 0; batchStart < len(ids); batchStart += shortestPathFetchBatchSize {
+		batchEnd := min(batchStart+shortestPathFetchBatchSize, len(ids))
+
+		if err := func() error {
+			result := s.tx.Raw(statement, map[string]any{
+				"ids": toInt32IDs(ids[batchStart:batchEnd]),
+			})
+			defer result.Close()
+
+			for result.Next() {
+				if err := delegate(result); err != nil {
+					return err
+				}
+			}
+
+			return result.Error()
+		}(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// runAllShortestPathsQuery splits the query criteria into root, traversal and terminal criteria and then runs a
+// bidirectional breadth-first search between the root and terminal nodes. Each hop of the search is a single batched
+// adjacency fetch, which avoids materializing the entire pathspace in temporary tables.
+func (s *liveQuery) runAllShortestPathsQuery() graph.Result {
+	if aspArguments, err := pgtransition.TranslateAllShortestPaths(s.queryBuilder.RegularQuery(), s.kindMapper); err != nil {
+		return graph.NewErrorResult(err)
+	} else if nodeTable, err := s.nodeTable(); err != nil {
+		return graph.NewErrorResult(err)
+	} else if rootIDs, err := s.fetchIDs(fmt.Sprintf(selectShortestPathRootIDsFormat, nodeTable, aspArguments.RootCriteria), nil); err != nil {
+		return graph.NewErrorResult(err)
+	} else if terminalIDs, err := s.fetchIDs(fmt.Sprintf(selectShortestPathTerminalIDsFormat, nodeTable, aspArguments.TerminalCriteria), nil); err != nil {
+		return graph.NewErrorResult(err)
+	} else {
+		search := shortestPathSearch{
+			ctx:           s.ctx,
+			maxDepth:      aspArguments.MaxDepth,
+			memoryLimit:   s.tx.TraversalMemoryLimit(),
+			fetchAdjacent: s.adjacencyFetcher(aspArguments.TraversalCriteria),
+		}
+
+		if idPaths, err := search.run(rootIDs, terminalIDs); err != nil {
+			return graph.NewErrorResult(err)
+		} else if paths, err := s.hydratePaths(idPaths); err != nil {
+			return graph.NewErrorResult(err)
+		} else {
+			return newPathResult(paths, s.kindMapper)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pg
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/specterops/bloodhound/dawgs/graph"
+	graph_mocks "github.com/specterops/bloodhound/dawgs/graph/mocks"
+	"github.com/specterops/bloodhound/dawgs/ops"
+	"github.com/specterops/bloodhound/dawgs/util/size"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type testAdjacency struct {
+	relationships []graph.RelationshipTripleResult
+	fetches       int
+}
+
+func (s *testAdjacency) link(startID, endID graph.ID) {
+	s.relationships = append(s.relationships, graph.RelationshipTripleResult{
+		ID:      graph.ID(len(s.relationships) + 100),
+		StartID: startID,
+		EndID:   endID,
+	})
+}
+
+func (s *testAdjacency) fetch(direction graph.Direction, nodeIDs []graph.ID) ([]graph.RelationshipTripleResult, error) {
+	var matched []graph.RelationshipTripleResult
+
+	s.fetches++
+
+	for _, relationship := range s.relationships {
+		for _, nodeID := range nodeIDs {
+			if (direction == graph.DirectionOutbound && relationship.StartID == nodeID) || (direction == graph.DirectionInbound && relationship.EndID == nodeID) {
+				matched = append(matched, relationship)
+			}
+		}
+	}
+
+	return matched, nil
+}
+
+func newTestSearch(adjacency *testAdjacency) *shortestPathSearch {
+	return &shortestPathSearch{
+		ctx:           context.Background(),
+		maxDepth:      12,
+		fetchAdjacent: adjacency.fetch,
+	}
+}
+
+func TestShortestPathSearch_AllShortestPaths(t *testing.T) {
+	adjacency := &testAdjacency{}
+
+	// Two shortest paths of length 3 from 1 to 6 and one longer path through 7 and 8
+	adjacency.link(1, 2)
+	adjacency.link(1, 3)
+	adjacency.link(2, 4)
+	adjacency.link(3, 4)
+	adjacency.link(4, 6)
+	adjacency.link(1, 7)
+	adjacency.link(7, 8)
+	adjacency.link(8, 9)
+	adjacency.link(9, 6)
+
+	paths, err := newTestSearch(adjacency).run([]graph.ID{1}, []graph.ID{6})
+	require.Nil(t, err)
+	require.Len(t, paths, 2)
+
+	for _, path := range paths {
+		require.Len(t, path.relationshipIDs, 3)
+		require.Equal(t, graph.ID(1), path.nodeIDs[0])
+		require.Equal(t, graph.ID(4), path.nodeIDs[2])
+		require.Equal(t, graph.ID(6), path.nodeIDs[3])
+	}
+
+	require.ElementsMatch(t, []graph.ID{2, 3}, []graph.ID{paths[0].nodeIDs[1], paths[1].nodeIDs[1]})
+}
+
+func TestShortestPathSearch_RespectsDirection(t *testing.T) {
+	adjacency := &testAdjacency{}
+
+	adjacency.link(2, 1)
+	adjacency.link(2, 3)
+
+	paths, err := newTestSearch(adjacency).run([]graph.ID{1}, []graph.ID{3})
+	require.Nil(t, err)
+	require.Empty(t, paths)
+}
+
+func TestShortestPathSearch_MaxDepth(t *testing.T) {
+	adjacency := &testAdjacency{}
+
+	for nodeID := graph.ID(1); nodeID < 6; nodeID++ {
+		adjacency.link(nodeID, nodeID+1)
+	}
+
+	search := newTestSearch(adjacency)
+	search.maxDepth = 4
+
+	paths, err := search.run([]graph.ID{1}, []graph.ID{6})
+	require.Nil(t, err)
+	require.Empty(t, paths)
+
+	search = newTestSearch(adjacency)
+	search.maxDepth = 5
+
+	paths, err = search.run([]graph.ID{1}, []graph.ID{6})
+	require.Nil(t, err)
+	require.Len(t, paths, 1)
+	require.Equal(t, []graph.ID{1, 2, 3, 4, 5, 6}, paths[0].nodeIDs)
+	require.Equal(t, []graph.ID{100, 101, 102, 103, 104}, paths[0].relationshipIDs)
+}
+
+func TestShortestPathSearch_ExpandsSmallerFrontier(t *testing.T) {
+	adjacency := &testAdjacency{}
+
+	// The root fans out to many nodes that all lead to the terminal through a single node
+	for nodeID := graph.ID(10); nodeID < 60; nodeID++ {
+		adjacency.link(1, nodeID)
+		adjacency.link(nodeID, 2)
+	}
+
+	adjacency.link(2, 3)
+
+	paths, err := newTestSearch(adjacency).run([]graph.ID{1}, []graph.ID{3})
+	require.Nil(t, err)
+	require.Len(t, paths, 50)
+	require.Equal(t, 3, adjacency.fetches)
+}
+
+func TestShortestPathSearch_MemoryLimit(t *testing.T) {
+	adjacency := &testAdjacency{}
+
+	for nodeID := graph.ID(10); nodeID < 1000; nodeID++ {
+		adjacency.link(1, nodeID)
+		adjacency.link(nodeID, 2)
+	}
+
+	search := newTestSearch(adjacency)
+	search.memoryLimit = size.Kibibyte
+
+	_, err := search.run([]graph.ID{1}, []graph.ID{2})
+	require.True(t, errors.Is(err, ops.ErrTraversalMemoryLimit))
+}
+
+func TestShortestPathSearch_Cancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	adjacency := &testAdjacency{}
+	adjacency.link(1, 2)
+
+	search := newTestSearch(adjacency)
+	search.ctx = ctx
+
+	_, err := search.run([]graph.ID{1}, []graph.ID{2})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestAdjacencyFetcher_TraversalCriteria(t *testing.T) {
+	var (
+		mockCtrl   = gomock.NewController(t)
+		mockTx     = graph_mocks.NewMockTransaction(mockCtrl)
+		mockResult = graph_mocks.NewMockResult(mockCtrl)
+		liveQuery  = newLiveQuery(context.Background(), mockTx, testKindMapper{})
+	)
+
+	mockTx.EXPECT().Raw(selectInboundAdjacencyStatement+" and (r.kind_id = any(array[3]::int2[]))", map[string]any{
+		"ids": []int32{1, 2},
+	}).Return(mockResult)
+
+	mockResult.EXPECT().Next().Return(false)
+	mockResult.EXPECT().Error().Return(nil)
+	mockResult.EXPECT().Close()
+
+	relationships, err := liveQuery.adjacencyFetcher("r.kind_id = any(array[3]::int2[])")(graph.DirectionInbound, []graph.ID{1, 2})
+	require.Nil(t, err)
+	require.Empty(t, relationships)
+}
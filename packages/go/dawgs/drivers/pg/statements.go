@@ -33,7 +33,24 @@ const (
 	deleteEdgeStatement       = `delete from edge as e where e.id = $1`
 	deleteEdgeWithIDStatement = `delete from edge as e where e.id = any($1)`
 
+	nodeStageTableName            = "node_bulk_stage"
+	edgeStageTableName            = "edge_bulk_stage"
+	createNodeStageTableStatement = `create temporary table if not exists node_bulk_stage (idx int4 not null, kind_ids int2[] not null, properties jsonb not null) on commit drop;`
+	createEdgeStageTableStatement = `create temporary table if not exists edge_bulk_stage (start_id int4 not null, end_id int4 not null, kind_id int2 not null, properties jsonb not null) on commit drop;`
+	truncateNodeStageStatement    = `truncate node_bulk_stage;`
+	truncateEdgeStageStatement    = `truncate edge_bulk_stage;`
+	createEdgeFromStageStatement  = `merge into edge as e using (select $1::int4 as gid, s.start_id as sid, s.end_id as eid, s.kind_id as kid, s.properties as p from edge_bulk_stage as s) as ei on e.start_id = ei.sid and e.end_id = ei.eid and e.kind_id = ei.kid when matched then update set properties = e.properties || ei.p when not matched then insert (graph_id, start_id, end_id, kind_id, properties) values (ei.gid, ei.sid, ei.eid, ei.kid, ei.p);`
+
+	selectShortestPathRootIDsFormat     = `select s.id from %s s where %s;`
+	selectShortestPathTerminalIDsFormat = `select e.id from %s e where %s;`
+	selectOutboundAdjacencyStatement    = `select r.id, r.start_id, r.end_id from edge r where r.start_id = any(@ids) and r.start_id != r.end_id`
+	selectInboundAdjacencyStatement     = `select r.id, r.start_id, r.end_id from edge r where r.end_id = any(@ids) and r.start_id != r.end_id`
+	fetchPathNodesStatement             = `select (n.id, n.kind_ids, n.properties)::nodeComposite from node n where n.id = any(@ids);`
+	fetchPathEdgesStatement             = `select (r.id, r.start_id, r.end_id, r.kind_id, r.properties)::edgeComposite from edge r where r.id = any(@ids);`
+
 	edgePropertySetOnlyStatement      = `update edge set properties = properties || $1::jsonb where edge.id = $2`
 	edgePropertyDeleteOnlyStatement   = `update edge set properties = properties - $1::text[] where edge.id = $2`
 	edgePropertySetAndDeleteStatement = `update edge set properties = properties || $1::jsonb - $2::text[] where edge.id = $3`
+
+	selectPropertyKeysStatement = `select k.key from (select jsonb_object_keys(n.properties) as key from (select properties from node limit @sample_size) n union select jsonb_object_keys(e.properties) from (select properties from edge limit @sample_size) e) k order by k.key;`
 )
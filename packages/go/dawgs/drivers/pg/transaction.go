@@ -19,6 +19,7 @@ package pg
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/jackc/pgx/v5"
@@ -26,12 +27,17 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/specterops/bloodhound/cypher/backend/pgsql"
 	"github.com/specterops/bloodhound/cypher/frontend"
+	cypherModel "github.com/specterops/bloodhound/cypher/model"
 	"github.com/specterops/bloodhound/dawgs/drivers/pg/model"
 	"github.com/specterops/bloodhound/dawgs/graph"
 	"github.com/specterops/bloodhound/dawgs/query"
 	"github.com/specterops/bloodhound/dawgs/util/size"
 )
 
+// ErrDeleteNodeWithRelationships is returned by mutations that delete a node that still has relationships without
+// detaching it first. Neo4j rejects these deletions as well.
+var ErrDeleteNodeWithRelationships = errors.New("can not delete a node that still has relationships, use detach delete to delete its relationships with it")
+
 type driver interface {
 	Exec(ctx context.Context, sql string, arguments ...any) (commandTag pgconn.CommandTag, err error)
 	Query(ctx context.Context, sql string, arguments ...any) (pgx.Rows, error)
@@ -58,14 +64,15 @@ func (s inspectingDriver) QueryRow(ctx context.Context, sql string, arguments ..
 }
 
 type transaction struct {
-	schemaManager      *SchemaManager
-	queryExecMode      pgx.QueryExecMode
-	queryResultsFormat pgx.QueryResultFormats
-	ctx                context.Context
-	conn               *pgxpool.Conn
-	tx                 pgx.Tx
-	targetSchema       graph.Graph
-	targetSchemaSet    bool
+	schemaManager        *SchemaManager
+	queryExecMode        pgx.QueryExecMode
+	queryResultsFormat   pgx.QueryResultFormats
+	traversalMemoryLimit size.Size
+	ctx                  context.Context
+	conn                 *pgxpool.Conn
+	tx                   pgx.Tx
+	targetSchema         graph.Graph
+	targetSchemaSet      bool
 }
 
 func newTransaction(ctx context.Context, conn *pgxpool.Conn, schemaManager *SchemaManager, cfg *Config) (*transaction, error) {
@@ -73,13 +80,14 @@ func newTransaction(ctx context.Context, conn *pgxpool.Conn, schemaManager *Sche
 		return nil, err
 	} else {
 		return &transaction{
-			schemaManager:      schemaManager,
-			queryExecMode:      cfg.QueryExecMode,
-			queryResultsFormat: cfg.QueryResultFormats,
-			ctx:                ctx,
-			conn:               conn,
-			tx:                 pgxTx,
-			targetSchemaSet:    false,
+			schemaManager:        schemaManager,
+			queryExecMode:        cfg.QueryExecMode,
+			queryResultsFormat:   cfg.QueryResultFormats,
+			traversalMemoryLimit: cfg.TraversalMemoryLimit,
+			ctx:                  ctx,
+			conn:                 conn,
+			tx:                   pgxTx,
+			targetSchemaSet:      false,
 		}, nil
 	}
 }
@@ -97,7 +105,7 @@ func (s *transaction) driver() driver {
 }
 
 func (s *transaction) TraversalMemoryLimit() size.Size {
-	return size.Gibibyte
+	return s.traversalMemoryLimit
 }
 
 func (s *transaction) WithGraph(schema graph.Graph) graph.Transaction {
@@ -127,6 +135,21 @@ func (s *transaction) getTargetGraph() (model.Graph, error) {
 	return s.schemaManager.AssertGraph(s, s.targetSchema)
 }
 
+// queryTarget returns the graph that queries run by this transaction are scoped to. Queries are left unscoped if no
+// graph target has been set and no default graph has been defined.
+func (s *transaction) queryTarget() (model.Graph, bool, error) {
+	if !s.targetSchemaSet {
+		defaultGraph, hasDefaultGraph := s.schemaManager.DefaultGraph()
+		return defaultGraph, hasDefaultGraph, nil
+	}
+
+	if graphTarget, err := s.schemaManager.AssertGraph(s, s.targetSchema); err != nil {
+		return model.Graph{}, false, err
+	} else {
+		return graphTarget, true, nil
+	}
+}
+
 func (s *transaction) CreateNode(properties *graph.Properties, kinds ...graph.Kind) (*graph.Node, error) {
 	if graphTarget, err := s.getTargetGraph(); err != nil {
 		return nil, err
@@ -269,30 +292,170 @@ func (s *transaction) query(query string, parameters map[string]any) (pgx.Rows,
 	return s.driver().Query(s.ctx, query, queryArgs...)
 }
 
-func (s *transaction) Query(query string, parameters map[string]any) graph.Result {
+// translate parses the given cypher query, binds the given parameter values to it and translates it to SQL. The
+// returned parameters are the values that the translated statement references. The translated query model is returned
+// alongside the statement for callers that must inspect it.
+func (s *transaction) translate(query string, parameters map[string]any) (*cypherModel.RegularQuery, string, map[string]any, error) {
+	return s.translateWith(query, parameters, func(emitter *pgsql.Emitter) {})
+}
+
+func (s *transaction) translateWith(query string, parameters map[string]any, configure func(emitter *pgsql.Emitter)) (*cypherModel.RegularQuery, string, map[string]any, error) {
 	if parsedQuery, err := frontend.ParseCypher(frontend.NewContext(), query); err != nil {
-		return graph.NewErrorResult(err)
+		return nil, "", nil, err
+	} else if err := cypherModel.BindParameters(parsedQuery, parameters); err != nil {
+		// Parameter values must be bound before translation as translation renames parameters and types them by value
+		return nil, "", nil, err
 	} else if translatedParams, err := pgsql.Translate(parsedQuery, s.schemaManager); err != nil {
-		return graph.NewErrorResult(err)
+		return nil, "", nil, err
 	} else {
 		var (
 			buffer  = &bytes.Buffer{}
 			emitter = pgsql.NewEmitter(false, s.schemaManager)
 		)
 
-		for key, value := range parameters {
-			if _, hasKey := translatedParams[key]; hasKey {
-				return graph.NewErrorResult(fmt.Errorf("Query specifies a parameter value that is overwritten by translation: %s", key))
-			}
+		configure(emitter)
 
-			translatedParams[key] = value
+		if graphTarget, hasTarget, err := s.queryTarget(); err != nil {
+			return nil, "", nil, err
+		} else if hasTarget {
+			emitter.WithGraph(graphTarget)
 		}
 
 		if err := emitter.Write(parsedQuery, buffer); err != nil {
-			return graph.NewErrorResult(err)
+			return nil, "", nil, err
+		}
+
+		return parsedQuery, buffer.String(), translatedParams, nil
+	}
+}
+
+func (s *transaction) Query(query string, parameters map[string]any) graph.Result {
+	if _, statement, translatedParams, err := s.translate(query, parameters); err != nil {
+		return graph.NewErrorResult(err)
+	} else {
+		return s.Raw(statement, translatedParams)
+	}
+}
+
+// Explain translates the given cypher query and returns the translated statement along with the PostgreSQL plan for
+// it. The statement is planned but not run.
+func (s *transaction) Explain(query string, parameters map[string]any) (graph.QueryPlan, error) {
+	var plan any
+
+	if _, statement, translatedParams, err := s.translate(query, parameters); err != nil {
+		return graph.QueryPlan{}, err
+	} else if err := s.queryRow("explain (format json) "+statement, pgx.NamedArgs(translatedParams)).Scan(&plan); err != nil {
+		return graph.QueryPlan{}, err
+	} else {
+		return graph.QueryPlan{
+			Statement: statement,
+			Plan:      plan,
+		}, nil
+	}
+}
+
+// Mutate translates and runs the given cypher query. PostgreSQL only reports the number of rows a statement affected,
+// so the changes are summarized by multiplying the per-row effects of the translated query by that count.
+func (s *transaction) Mutate(query string, parameters map[string]any) (graph.MutationSummary, error) {
+	// Node deletions report the number of nodes and edges they removed as the edges attached to each deleted node are
+	// removed by a trigger and are not counted in the rows affected by the statement
+	if translatedQuery, statement, translatedParams, err := s.translateWith(query, parameters, func(emitter *pgsql.Emitter) {
+		emitter.WithNodeDeleteSummary()
+	}); err != nil {
+		return graph.MutationSummary{}, err
+	} else if nodeDeletion, isNodeDeletion := pgsql.NodeDeletion(translatedQuery); isNodeDeletion {
+		return s.mutateNodeDeletion(statement, translatedParams, nodeDeletion.Detach)
+	} else if effects, err := pgsql.MutationEffects(translatedQuery); err != nil {
+		return graph.MutationSummary{}, err
+	} else if rows, err := s.query(statement, translatedParams); err != nil {
+		return graph.MutationSummary{}, err
+	} else {
+		for rows.Next() {
+			// Rows returned by the statement are discarded
+		}
+
+		// Closing the rows is required before the command tag of the statement is available
+		rows.Close()
+
+		if err := rows.Err(); err != nil {
+			return graph.MutationSummary{}, err
+		}
+
+		// Translated mutations are a single statement that makes the same changes to each row it affects
+		numAffected := int(rows.CommandTag().RowsAffected())
+
+		return graph.MutationSummary{
+			NodesCreated:         effects.NodesCreated * numAffected,
+			NodesDeleted:         effects.NodesDeleted * numAffected,
+			RelationshipsCreated: effects.RelationshipsCreated * numAffected,
+			RelationshipsDeleted: effects.RelationshipsDeleted * numAffected,
+			PropertiesSet:        effects.PropertiesSet * numAffected,
+			LabelsAdded:          effects.LabelsAdded * numAffected,
+			LabelsRemoved:        effects.LabelsRemoved * numAffected,
+		}, nil
+	}
+}
+
+// mutateNodeDeletion runs a node deletion written with pgsql.Emitter.WithNodeDeleteSummary. Deletions that were not
+// detached are rejected if they removed any edges. Rejecting a deletion fails the transaction it was run in which rolls
+// the deletion back.
+func (s *transaction) mutateNodeDeletion(statement string, parameters map[string]any, detach bool) (graph.MutationSummary, error) {
+	var (
+		summary      graph.MutationSummary
+		nodesDeleted int64
+		edgesDeleted int64
+	)
+
+	if rows, err := s.query(statement, parameters); err != nil {
+		return summary, err
+	} else {
+		defer rows.Close()
+
+		if rows.Next() {
+			if err := rows.Scan(&nodesDeleted, &edgesDeleted); err != nil {
+				return summary, err
+			}
+		}
+
+		if err := rows.Err(); err != nil {
+			return summary, err
+		}
+	}
+
+	if edgesDeleted > 0 && !detach {
+		return summary, ErrDeleteNodeWithRelationships
+	}
+
+	summary.NodesDeleted = int(nodesDeleted)
+	summary.RelationshipsDeleted = int(edgesDeleted)
+
+	return summary, nil
+}
+
+// PropertyKeys returns the distinct property keys of a sample of the nodes and edges in the database. PostgreSQL keeps
+// no record of the keys used in the properties column so they are found by scanning at most propertyKeySampleSize
+// nodes and edges.
+func (s *transaction) PropertyKeys() ([]string, error) {
+	if rows, err := s.query(selectPropertyKeysStatement, map[string]any{
+		"sample_size": propertyKeySampleSize,
+	}); err != nil {
+		return nil, err
+	} else {
+		defer rows.Close()
+
+		var propertyKeys []string
+
+		for rows.Next() {
+			var propertyKey string
+
+			if err := rows.Scan(&propertyKey); err != nil {
+				return nil, err
+			}
+
+			propertyKeys = append(propertyKeys, propertyKey)
 		}
 
-		return s.Raw(buffer.String(), parameters)
+		return propertyKeys, rows.Err()
 	}
 }
 
@@ -0,0 +1,51 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"errors"
+)
+
+var (
+	ErrQueryExplainUnsupported = errors.New("explaining queries is not supported by this driver")
+)
+
+// QueryPlan describes how the database would run a query.
+type QueryPlan struct {
+	// Statement is the statement the driver would run for the query. Drivers that translate queries return the
+	// translated statement.
+	Statement string
+
+	// Plan is the database's plan for the statement in the database's own representation.
+	Plan any
+}
+
+// QueryExplainer is implemented by transactions that can describe how the database would run a query without running
+// it.
+type QueryExplainer interface {
+	// Explain returns the statement and database plan for the given query and its parameters.
+	Explain(query string, parameters map[string]any) (QueryPlan, error)
+}
+
+// Explain returns the plan for the given query if the given transaction supports explaining queries.
+func Explain(tx Transaction, query string, parameters map[string]any) (QueryPlan, error) {
+	if explainer, isExplainer := tx.(QueryExplainer); !isExplainer {
+		return QueryPlan{}, ErrQueryExplainUnsupported
+	} else {
+		return explainer.Explain(query, parameters)
+	}
+}
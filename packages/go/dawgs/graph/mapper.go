@@ -450,20 +450,33 @@ func SliceOf[T any](raw any) ([]T, error) {
 	}
 }
 
+// MapOptions maps the next value to the first of the given targets that accepts it and returns that target. Null
+// values, such as those produced by an optional match that did not match, accept no target and return nil.
 func (s *valueMapper) MapOptions(targets ...any) (any, error) {
 	if rawValue, err := s.Next(); err != nil {
 		return nil, err
 	} else {
-		for _, target := range targets {
-			for _, mapperFunc := range s.mapperFuncs {
-				if mapped, _ := mapperFunc(rawValue, target); mapped {
-					return target, nil
-				}
+		return s.MapValueOptions(rawValue, targets...)
+	}
+}
+
+// MapValueOptions maps the given raw value to the first of the given targets that accepts it and returns that target.
+// This allows values nested in a raw value, such as the elements of a list, to be mapped with the same rules as the
+// values of a result.
+func (s *valueMapper) MapValueOptions(rawValue any, targets ...any) (any, error) {
+	if rawValue == nil {
+		return nil, nil
+	}
+
+	for _, target := range targets {
+		for _, mapperFunc := range s.mapperFuncs {
+			if mapped, _ := mapperFunc(rawValue, target); mapped {
+				return target, nil
 			}
 		}
-
-		return nil, fmt.Errorf("no matching target given for type: %T", rawValue)
 	}
+
+	return nil, fmt.Errorf("no matching target given for type: %T", rawValue)
 }
 
 func (s *valueMapper) Scan(targets ...any) error {
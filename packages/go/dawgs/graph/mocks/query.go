@@ -83,6 +83,26 @@ func (mr *MockValueMapperMockRecorder) MapOptions(target ...interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MapOptions", reflect.TypeOf((*MockValueMapper)(nil).MapOptions), target...)
 }
 
+// MapValueOptions mocks base method.
+func (m *MockValueMapper) MapValueOptions(rawValue any, target ...any) (any, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{rawValue}
+	for _, a := range target {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "MapValueOptions", varargs...)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MapValueOptions indicates an expected call of MapValueOptions.
+func (mr *MockValueMapperMockRecorder) MapValueOptions(rawValue interface{}, target ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{rawValue}, target...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MapValueOptions", reflect.TypeOf((*MockValueMapper)(nil).MapValueOptions), varargs...)
+}
+
 // Next mocks base method.
 func (m *MockValueMapper) Next() (any, error) {
 	m.ctrl.T.Helper()
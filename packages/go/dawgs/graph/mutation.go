@@ -0,0 +1,57 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"errors"
+)
+
+var (
+	ErrQueryMutationUnsupported = errors.New("reporting the effects of mutation queries is not supported by this driver")
+)
+
+// MutationSummary counts the changes a mutation query made to the graph.
+type MutationSummary struct {
+	NodesCreated         int `json:"nodes_created"`
+	NodesDeleted         int `json:"nodes_deleted"`
+	RelationshipsCreated int `json:"relationships_created"`
+	RelationshipsDeleted int `json:"relationships_deleted"`
+	PropertiesSet        int `json:"properties_set"`
+	LabelsAdded          int `json:"labels_added"`
+	LabelsRemoved        int `json:"labels_removed"`
+}
+
+// ContainsUpdates returns true if the summary counts any change to the graph.
+func (s MutationSummary) ContainsUpdates() bool {
+	return s.NodesCreated+s.NodesDeleted+s.RelationshipsCreated+s.RelationshipsDeleted+s.PropertiesSet+s.LabelsAdded+s.LabelsRemoved > 0
+}
+
+// QueryMutator is implemented by transactions that can report the changes a query with updating clauses made to the
+// graph.
+type QueryMutator interface {
+	// Mutate runs the given query and its parameters, discards any rows it returns and summarizes the changes it made.
+	Mutate(query string, parameters map[string]any) (MutationSummary, error)
+}
+
+// Mutate runs the given mutation query if the given transaction supports summarizing mutations.
+func Mutate(tx Transaction, query string, parameters map[string]any) (MutationSummary, error) {
+	if mutator, isMutator := tx.(QueryMutator); !isMutator {
+		return MutationSummary{}, ErrQueryMutationUnsupported
+	} else {
+		return mutator.Mutate(query, parameters)
+	}
+}
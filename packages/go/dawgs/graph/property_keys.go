@@ -0,0 +1,41 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"errors"
+)
+
+var (
+	ErrPropertyKeysUnsupported = errors.New("listing property keys is not supported by this driver")
+)
+
+// PropertyKeyLister is implemented by transactions that can list the property keys observed in the graph.
+type PropertyKeyLister interface {
+	// PropertyKeys returns the distinct property keys of the nodes and relationships in the graph. Drivers that have to
+	// scan the graph to find them may only inspect a sample of it.
+	PropertyKeys() ([]string, error)
+}
+
+// PropertyKeys returns the property keys observed in the graph if the given transaction supports listing them.
+func PropertyKeys(tx Transaction) ([]string, error) {
+	if lister, isLister := tx.(PropertyKeyLister); !isLister {
+		return nil, ErrPropertyKeysUnsupported
+	} else {
+		return lister.PropertyKeys()
+	}
+}
@@ -22,6 +22,7 @@ type ValueMapper interface {
 	Next() (any, error)
 	Map(target any) error
 	MapOptions(target ...any) (any, error)
+	MapValueOptions(rawValue any, target ...any) (any, error)
 	Scan(targets ...any) error
 }
 
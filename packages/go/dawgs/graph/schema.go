@@ -41,6 +41,10 @@ type Index struct {
 	Name  string
 	Field string
 	Type  IndexType
+
+	// Kind optionally scopes the index to entities of a single kind. Drivers that do not index by kind, such as the
+	// PostgreSQL driver where indexes are defined against a graph partition, index the field for all entities instead.
+	Kind Kind
 }
 
 type Constraint Index
@@ -0,0 +1,49 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrGraphManagementUnsupported = errors.New("graph management is not supported by this driver")
+)
+
+type graphScopeKey struct{}
+
+// GraphManager is implemented by databases that support hosting more than one named graph.
+type GraphManager interface {
+	// AssertGraph creates the given graph if it does not yet exist and validates its indexes and constraints otherwise.
+	AssertGraph(ctx context.Context, graphSchema Graph) error
+
+	// DropGraph removes the named graph along with all of its nodes and relationships.
+	DropGraph(ctx context.Context, name string) error
+}
+
+// ContextWithGraph returns a copy of the given context that scopes any transaction or batch operation opened through
+// a DatabaseSwitch to the given graph.
+func ContextWithGraph(ctx context.Context, graphSchema Graph) context.Context {
+	return context.WithValue(ctx, graphScopeKey{}, graphSchema)
+}
+
+// GraphFromContext returns the graph that the given context is scoped to, if any.
+func GraphFromContext(ctx context.Context) (Graph, bool) {
+	graphSchema, hasGraph := ctx.Value(graphScopeKey{}).(Graph)
+	return graphSchema, hasGraph
+}
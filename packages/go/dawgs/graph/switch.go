@@ -46,6 +46,31 @@ func NewDatabaseSwitch(ctx context.Context, initialDB Database) *DatabaseSwitch
 	}
 }
 
+// DatabaseWrapper is implemented by databases that forward their operations to another database.
+type DatabaseWrapper interface {
+	Unwrap() Database
+}
+
+// UnwrapDatabase returns the innermost database that the given database forwards its operations to. Databases that do
+// not wrap another database are returned as-is.
+func UnwrapDatabase(db Database) Database {
+	for {
+		if wrapper, isWrapper := db.(DatabaseWrapper); !isWrapper {
+			return db
+		} else {
+			db = wrapper.Unwrap()
+		}
+	}
+}
+
+// Unwrap returns the database that the switch currently forwards its operations to.
+func (s *DatabaseSwitch) Unwrap() Database {
+	s.currentDBLock.RLock()
+	defer s.currentDBLock.RUnlock()
+
+	return s.currentDB
+}
+
 func (s *DatabaseSwitch) SetDefaultGraph(ctx context.Context, graphSchema Graph) error {
 	s.currentDBLock.RLock()
 	defer s.currentDBLock.RUnlock()
@@ -53,6 +78,54 @@ func (s *DatabaseSwitch) SetDefaultGraph(ctx context.Context, graphSchema Graph)
 	return s.currentDB.SetDefaultGraph(ctx, graphSchema)
 }
 
+// AssertGraph creates or validates the given graph if the current database supports more than one named graph.
+func (s *DatabaseSwitch) AssertGraph(ctx context.Context, graphSchema Graph) error {
+	s.currentDBLock.RLock()
+	defer s.currentDBLock.RUnlock()
+
+	if graphManager, isManager := s.currentDB.(GraphManager); !isManager {
+		return ErrGraphManagementUnsupported
+	} else {
+		return graphManager.AssertGraph(ctx, graphSchema)
+	}
+}
+
+// DropGraph removes the named graph if the current database supports more than one named graph.
+func (s *DatabaseSwitch) DropGraph(ctx context.Context, name string) error {
+	s.currentDBLock.RLock()
+	defer s.currentDBLock.RUnlock()
+
+	if graphManager, isManager := s.currentDB.(GraphManager); !isManager {
+		return ErrGraphManagementUnsupported
+	} else {
+		return graphManager.DropGraph(ctx, name)
+	}
+}
+
+// scopeTransaction wraps the given delegate so that the transaction it receives targets the graph carried by the
+// given context, if any.
+func scopeTransaction(ctx context.Context, txDelegate TransactionDelegate) TransactionDelegate {
+	if graphSchema, hasGraph := GraphFromContext(ctx); hasGraph {
+		return func(tx Transaction) error {
+			return txDelegate(tx.WithGraph(graphSchema))
+		}
+	}
+
+	return txDelegate
+}
+
+// scopeBatch wraps the given delegate so that the batch it receives targets the graph carried by the given context,
+// if any.
+func scopeBatch(ctx context.Context, batchDelegate BatchDelegate) BatchDelegate {
+	if graphSchema, hasGraph := GraphFromContext(ctx); hasGraph {
+		return func(batch Batch) error {
+			return batchDelegate(batch.WithGraph(graphSchema))
+		}
+	}
+
+	return batchDelegate
+}
+
 func (s *DatabaseSwitch) Switch(db Database) {
 	s.inSwitch = true
 
@@ -119,7 +192,7 @@ func (s *DatabaseSwitch) ReadTransaction(ctx context.Context, txDelegate Transac
 		s.currentDBLock.RLock()
 		defer s.currentDBLock.RUnlock()
 
-		return s.currentDB.ReadTransaction(internalCtx, txDelegate, options...)
+		return s.currentDB.ReadTransaction(internalCtx, scopeTransaction(ctx, txDelegate), options...)
 	}
 }
 
@@ -132,7 +205,7 @@ func (s *DatabaseSwitch) WriteTransaction(ctx context.Context, txDelegate Transa
 		s.currentDBLock.RLock()
 		defer s.currentDBLock.RUnlock()
 
-		return s.currentDB.WriteTransaction(internalCtx, txDelegate, options...)
+		return s.currentDB.WriteTransaction(internalCtx, scopeTransaction(ctx, txDelegate), options...)
 	}
 }
 
@@ -145,7 +218,7 @@ func (s *DatabaseSwitch) BatchOperation(ctx context.Context, batchDelegate Batch
 		s.currentDBLock.RLock()
 		defer s.currentDBLock.RUnlock()
 
-		return s.currentDB.BatchOperation(internalCtx, batchDelegate)
+		return s.currentDB.BatchOperation(internalCtx, scopeBatch(ctx, batchDelegate))
 	}
 }
 
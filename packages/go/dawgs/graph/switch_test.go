@@ -0,0 +1,73 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package graph_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/specterops/bloodhound/dawgs/graph"
+	graph_mocks "github.com/specterops/bloodhound/dawgs/graph/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDatabaseSwitch_ContextGraphScope(t *testing.T) {
+	var (
+		mockCtrl    = gomock.NewController(t)
+		mockDB      = graph_mocks.NewMockDatabase(mockCtrl)
+		mockTx      = graph_mocks.NewMockTransaction(mockCtrl)
+		dbSwitch    = graph.NewDatabaseSwitch(context.Background(), mockDB)
+		scopedGraph = graph.Graph{Name: "workspace_1"}
+		runDelegate = func(ctx context.Context, delegate graph.TransactionDelegate, _ ...graph.TransactionOption) error {
+			return delegate(mockTx)
+		}
+	)
+
+	mockDB.EXPECT().ReadTransaction(gomock.Any(), gomock.Any()).DoAndReturn(runDelegate).Times(2)
+	mockTx.EXPECT().WithGraph(scopedGraph).Return(mockTx).Times(1)
+
+	// Unscoped contexts must not retarget the transaction
+	require.Nil(t, dbSwitch.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		return nil
+	}))
+
+	require.Nil(t, dbSwitch.ReadTransaction(graph.ContextWithGraph(context.Background(), scopedGraph), func(tx graph.Transaction) error {
+		return nil
+	}))
+}
+
+func TestDatabaseSwitch_GraphManagementUnsupported(t *testing.T) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		dbSwitch = graph.NewDatabaseSwitch(context.Background(), graph_mocks.NewMockDatabase(mockCtrl))
+	)
+
+	require.ErrorIs(t, dbSwitch.AssertGraph(context.Background(), graph.Graph{Name: "workspace_1"}), graph.ErrGraphManagementUnsupported)
+	require.ErrorIs(t, dbSwitch.DropGraph(context.Background(), "workspace_1"), graph.ErrGraphManagementUnsupported)
+}
+
+func TestUnwrapDatabase(t *testing.T) {
+	var (
+		mockCtrl = gomock.NewController(t)
+		mockDB   = graph_mocks.NewMockDatabase(mockCtrl)
+		dbSwitch = graph.NewDatabaseSwitch(context.Background(), graph.NewDatabaseSwitch(context.Background(), mockDB))
+	)
+
+	require.Equal(t, mockDB, graph.UnwrapDatabase(dbSwitch))
+	require.Equal(t, mockDB, graph.UnwrapDatabase(mockDB))
+}
@@ -17,122 +17,163 @@
 package graphcache
 
 import (
-	"sync"
+	"sync/atomic"
 
 	"github.com/specterops/bloodhound/dawgs/graph"
 	"github.com/specterops/bloodhound/dawgs/query"
 	"github.com/specterops/bloodhound/dawgs/util/size"
 )
 
+// TraversalMemoryLimitShare is the divisor applied to a transaction's traversal memory limit when a Cache adopts it as
+// its budget. Only half of the limit is claimed so that the path trees built by a traversal have room to grow.
+const TraversalMemoryLimitShare = 2
+
+// Stats is a point-in-time snapshot of the effectiveness and footprint of a Cache.
+type Stats struct {
+	Hits          uint64
+	Misses        uint64
+	Evictions     uint64
+	Nodes         int
+	Relationships int
+	Size          size.Size
+	Limit         size.Size
+}
+
+// HitRatio returns the ratio of cache lookups that were served from the cache.
+func (s Stats) HitRatio() float64 {
+	if lookups := s.Hits + s.Misses; lookups > 0 {
+		return float64(s.Hits) / float64(lookups)
+	}
+
+	return 0
+}
+
+// Cache is a sharded, concurrency-safe LRU cache of nodes and relationships. A bounded Cache evicts its least recently
+// used entries once its size budget is exhausted. Half of the budget is reserved for nodes and the other half for
+// relationships.
 type Cache struct {
-	nodes         *graph.IndexedSlice[graph.ID, *graph.Node]
-	relationships *graph.IndexedSlice[graph.ID, *graph.Relationship]
-	lock          *sync.RWMutex
+	nodes         *shardedLRU[*graph.Node]
+	relationships *shardedLRU[*graph.Relationship]
+	limit         *atomic.Uintptr
+	limitBound    *atomic.Bool
+	counters      *counters
+}
+
+func newCache(limit size.Size, limitBound bool) Cache {
+	cache := Cache{
+		limit:      &atomic.Uintptr{},
+		limitBound: &atomic.Bool{},
+		counters:   &counters{},
+	}
+
+	cache.nodes = newShardedLRU[*graph.Node](cache.limit, 2, cache.counters)
+	cache.relationships = newShardedLRU[*graph.Relationship](cache.limit, 2, cache.counters)
+
+	if limitBound {
+		cache.setLimit(limit)
+	}
+
+	return cache
 }
 
+// New creates a Cache that adopts its size budget from the traversal memory limit of the first transaction passed to
+// one of the fetch functions of this package. See TraversalMemoryLimitShare.
 func New() Cache {
-	return Cache{
-		nodes:         graph.NewIndexedSlice[graph.ID, *graph.Node](),
-		relationships: graph.NewIndexedSlice[graph.ID, *graph.Relationship](),
-		lock:          &sync.RWMutex{},
+	return newCache(0, false)
+}
+
+// NewWithLimit creates a Cache bounded to the given size. A limit of 0 creates an unbounded Cache.
+func NewWithLimit(limit size.Size) Cache {
+	return newCache(limit, true)
+}
+
+func (s Cache) setLimit(limit size.Size) {
+	s.limitBound.Store(true)
+	s.limit.Store(uintptr(limit))
+}
+
+// bindTransaction adopts the traversal memory limit of the given transaction as this cache's budget if the cache was
+// not created with an explicit limit.
+func (s Cache) bindTransaction(tx graph.Transaction) {
+	if s.limitBound.CompareAndSwap(false, true) {
+		s.setLimit(tx.TraversalMemoryLimit() / TraversalMemoryLimitShare)
+	}
+}
+
+// Limit returns the size budget of this cache. A limit of 0 signifies that the cache is unbounded.
+func (s Cache) Limit() size.Size {
+	return size.Size(s.limit.Load())
+}
+
+func (s Cache) Stats() Stats {
+	return Stats{
+		Hits:          s.counters.hits.Load(),
+		Misses:        s.counters.misses.Load(),
+		Evictions:     s.counters.evictions.Load(),
+		Nodes:         s.nodes.Len(),
+		Relationships: s.relationships.Len(),
+		Size:          s.SizeOf(),
+		Limit:         s.Limit(),
 	}
 }
 
 func (s Cache) SizeOf() size.Size {
-	// We're not including the total size of the lock here on purpose. Copying a locker can be dangerous and to get
-	// the size of the backing struct for the pointer we would have to dereference and copy it.
 	return size.Of(s) + s.nodes.SizeOf() + s.relationships.SizeOf()
 }
 
 func (s Cache) NodesCached() int {
-	s.lock.RLock()
-	defer s.lock.RUnlock()
-
 	return s.nodes.Len()
 }
 
 func (s Cache) RelationshipsCached() int {
-	s.lock.RLock()
-	defer s.lock.RUnlock()
-
-	return s.nodes.Len()
+	return s.relationships.Len()
 }
 
 func (s Cache) GetNode(id graph.ID) *graph.Node {
-	s.lock.RLock()
-	defer s.lock.RUnlock()
-
 	node, _ := s.nodes.CheckedGet(id)
 	return node
 }
 
 func (s Cache) CheckedGetNode(id graph.ID) (*graph.Node, bool) {
-	s.lock.RLock()
-	defer s.lock.RUnlock()
-
 	return s.nodes.CheckedGet(id)
 }
 
 func (s Cache) GetNodes(ids []graph.ID) ([]*graph.Node, []graph.ID) {
-	s.lock.RLock()
-	defer s.lock.RUnlock()
-
 	return s.nodes.GetAll(ids)
 }
 
 func (s Cache) PutNodes(nodes []*graph.Node) {
-	s.lock.Lock()
-	defer s.lock.Unlock()
-
 	for _, node := range nodes {
 		s.nodes.Put(node.ID, node)
 	}
 }
 
 func (s Cache) PutNodeSet(nodes graph.NodeSet) {
-	s.lock.Lock()
-	defer s.lock.Unlock()
-
 	for _, node := range nodes {
 		s.nodes.Put(node.ID, node)
 	}
 }
 
 func (s Cache) GetRelationship(id graph.ID) *graph.Relationship {
-	s.lock.RLock()
-	defer s.lock.RUnlock()
-
 	relationship, _ := s.relationships.CheckedGet(id)
 	return relationship
 }
 
 func (s Cache) CheckedGetRelationship(id graph.ID) (*graph.Relationship, bool) {
-	s.lock.RLock()
-	defer s.lock.RUnlock()
-
 	return s.relationships.CheckedGet(id)
 }
 
 func (s Cache) GetRelationships(ids []graph.ID) ([]*graph.Relationship, []graph.ID) {
-	s.lock.RLock()
-	defer s.lock.RUnlock()
-
 	return s.relationships.GetAll(ids)
 }
 
 func (s Cache) PutRelationships(relationships []*graph.Relationship) {
-	s.lock.Lock()
-	defer s.lock.Unlock()
-
 	for _, relationship := range relationships {
 		s.relationships.Put(relationship.ID, relationship)
 	}
 }
 
 func (s Cache) PutRelationshipSet(relationships graph.RelationshipSet) {
-	s.lock.Lock()
-	defer s.lock.Unlock()
-
 	for _, relationship := range relationships {
 		s.relationships.Put(relationship.ID, relationship)
 	}
@@ -150,7 +191,11 @@ func fetchRelationshipsByIDQuery(tx graph.Transaction, ids []graph.ID) graph.Rel
 	})
 }
 
+// FetchNodesByID returns the nodes for the given IDs, fetching any that are not cached from the database. Nodes
+// fetched from the database are returned even when they can not be retained by the cache.
 func FetchNodesByID(tx graph.Transaction, cache Cache, ids []graph.ID) ([]*graph.Node, error) {
+	cache.bindTransaction(tx)
+
 	var (
 		cachedNodes, missingNodeIDs = cache.GetNodes(ids)
 		toBeCachedCount             = 0
@@ -180,13 +225,21 @@ func FetchNodesByID(tx graph.Transaction, cache Cache, ids []graph.ID) ([]*graph
 	return cachedNodes, nil
 }
 
+// FetchRelationshipsByID returns the relationships for the given IDs, fetching any that are not cached from the
+// database. Relationships fetched from the database are returned even when they can not be retained by the cache.
 func FetchRelationshipsByID(tx graph.Transaction, cache Cache, ids []graph.ID) (graph.RelationshipSet, error) {
-	cachedRelationships, missingRelationshipIDs := cache.GetRelationships(ids)
+	cache.bindTransaction(tx)
+
+	var (
+		cachedRelationships, missingRelationshipIDs = cache.GetRelationships(ids)
+		toBeCachedCount                             = 0
+	)
 
 	if len(missingRelationshipIDs) > 0 {
-		if err := fetchRelationshipsByIDQuery(tx, ids).Fetch(func(cursor graph.Cursor[*graph.Relationship]) error {
+		if err := fetchRelationshipsByIDQuery(tx, missingRelationshipIDs).Fetch(func(cursor graph.Cursor[*graph.Relationship]) error {
 			for next := range cursor.Chan() {
 				cachedRelationships = append(cachedRelationships, next)
+				toBeCachedCount++
 			}
 
 			return cursor.Error()
@@ -194,7 +247,9 @@ func FetchRelationshipsByID(tx graph.Transaction, cache Cache, ids []graph.ID) (
 			return nil, err
 		}
 
-		cache.PutRelationships(cachedRelationships[len(cachedRelationships)-len(missingRelationshipIDs):])
+		if toBeCachedCount > 0 {
+			cache.PutRelationships(cachedRelationships[len(cachedRelationships)-toBeCachedCount:])
+		}
 	}
 
 	return graph.NewRelationshipSet(cachedRelationships...), nil
@@ -218,12 +273,18 @@ func ShallowFetchRelationships(cache Cache, graphQuery graph.RelationshipQuery)
 }
 
 func ShallowFetchNodesByID(tx graph.Transaction, cache Cache, ids []graph.ID) ([]*graph.Node, error) {
-	cachedNodes, missingNodeIDs := cache.GetNodes(ids)
+	cache.bindTransaction(tx)
+
+	var (
+		cachedNodes, missingNodeIDs = cache.GetNodes(ids)
+		toBeCachedCount             = 0
+	)
 
 	if len(missingNodeIDs) > 0 {
 		if err := fetchNodesByIDQuery(tx, missingNodeIDs).FetchKinds(func(cursor graph.Cursor[graph.KindsResult]) error {
 			for next := range cursor.Chan() {
 				cachedNodes = append(cachedNodes, graph.NewNode(next.ID, nil, next.Kinds...))
+				toBeCachedCount++
 			}
 
 			return cursor.Error()
@@ -231,7 +292,9 @@ func ShallowFetchNodesByID(tx graph.Transaction, cache Cache, ids []graph.ID) ([
 			return nil, err
 		}
 
-		cache.PutNodes(cachedNodes[len(cachedNodes)-len(missingNodeIDs):])
+		if toBeCachedCount > 0 {
+			cache.PutNodes(cachedNodes[len(cachedNodes)-toBeCachedCount:])
+		}
 	}
 
 	return cachedNodes, nil
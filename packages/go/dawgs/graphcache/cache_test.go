@@ -0,0 +1,231 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package graphcache_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/specterops/bloodhound/dawgs"
+	"github.com/specterops/bloodhound/dawgs/drivers/memory"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/graphcache"
+	"github.com/specterops/bloodhound/dawgs/util/size"
+	"github.com/stretchr/testify/require"
+)
+
+var testKind = graph.StringKind("Test")
+
+func newTestNodes(count int) []*graph.Node {
+	nodes := make([]*graph.Node, count)
+
+	for idx := range nodes {
+		nodes[idx] = graph.NewNode(graph.ID(idx), graph.NewProperties(), testKind)
+	}
+
+	return nodes
+}
+
+func TestCache_Unbounded(t *testing.T) {
+	var (
+		cache = graphcache.NewWithLimit(0)
+		nodes = newTestNodes(1024)
+	)
+
+	cache.PutNodes(nodes)
+	require.Equal(t, len(nodes), cache.NodesCached())
+	require.Equal(t, 0, cache.RelationshipsCached())
+
+	cached, missing := cache.GetNodes([]graph.ID{1, 2, 4096})
+	require.Len(t, cached, 2)
+	require.Equal(t, []graph.ID{4096}, missing)
+
+	stats := cache.Stats()
+	require.Equal(t, uint64(2), stats.Hits)
+	require.Equal(t, uint64(1), stats.Misses)
+	require.Equal(t, uint64(0), stats.Evictions)
+	require.Equal(t, size.Size(0), stats.Limit)
+}
+
+func TestCache_Eviction(t *testing.T) {
+	var (
+		nodes    = newTestNodes(4096)
+		nodeSize = nodes[0].SizeOf()
+
+		// Budget roughly 8 nodes per shard
+		cache = graphcache.NewWithLimit(nodeSize * 8 * 16 * 2)
+	)
+
+	cache.PutNodes(nodes)
+
+	stats := cache.Stats()
+	require.Greater(t, stats.Evictions, uint64(0))
+	require.Less(t, stats.Nodes, len(nodes))
+	require.Equal(t, uint64(len(nodes)-stats.Nodes), stats.Evictions)
+
+	// The most recently stored nodes must have survived eviction while the oldest must not have
+	_, found := cache.CheckedGetNode(nodes[len(nodes)-1].ID)
+	require.True(t, found)
+
+	_, found = cache.CheckedGetNode(nodes[0].ID)
+	require.False(t, found)
+}
+
+func TestCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	var (
+		nodeSize = newTestNodes(1)[0].SizeOf()
+
+		// Every node maps to shard 0 when its ID is a multiple of the shard count
+		first  = graph.NewNode(0, graph.NewProperties(), testKind)
+		second = graph.NewNode(16, graph.NewProperties(), testKind)
+		third  = graph.NewNode(32, graph.NewProperties(), testKind)
+
+		// Budget two nodes per shard for the node half of the cache
+		cache = graphcache.NewWithLimit(nodeSize * 2 * 16 * 2)
+	)
+
+	cache.PutNodes([]*graph.Node{first, second})
+
+	// Touch the first node so that the second is the least recently used
+	require.NotNil(t, cache.GetNode(first.ID))
+
+	cache.PutNodes([]*graph.Node{third})
+
+	require.NotNil(t, cache.GetNode(first.ID))
+	require.Nil(t, cache.GetNode(second.ID))
+	require.NotNil(t, cache.GetNode(third.ID))
+	require.Equal(t, uint64(1), cache.Stats().Evictions)
+}
+
+func TestCache_Concurrent(t *testing.T) {
+	var (
+		cache = graphcache.NewWithLimit(size.Kibibyte * 64)
+		nodes = newTestNodes(2048)
+		wg    = &sync.WaitGroup{}
+	)
+
+	for workerID := 0; workerID < 8; workerID++ {
+		wg.Add(1)
+
+		go func(workerID int) {
+			defer wg.Done()
+
+			for idx := workerID; idx < len(nodes); idx += 8 {
+				cache.PutNodes(nodes[idx : idx+1])
+				cache.GetNode(nodes[idx].ID)
+			}
+		}(workerID)
+	}
+
+	wg.Wait()
+
+	stats := cache.Stats()
+	require.Equal(t, uint64(len(nodes)), stats.Hits+stats.Misses)
+	require.Greater(t, stats.Nodes, 0)
+	require.Equal(t, uint64(len(nodes)-stats.Nodes), stats.Evictions)
+}
+
+func TestFetchByID_DegradesToDatabase(t *testing.T) {
+	db, err := dawgs.Open(context.Background(), memory.DriverName, dawgs.Config{
+		// A 2 byte traversal memory limit is too small to retain any entity in the cache
+		TraversalMemoryLimit: size.Bytes * 2,
+	})
+	require.Nil(t, err)
+
+	var (
+		nodeIDs         []graph.ID
+		relationshipIDs []graph.ID
+		cache           = graphcache.New()
+	)
+
+	require.Nil(t, db.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		for idx := 0; idx < 3; idx++ {
+			if node, err := tx.CreateNode(graph.NewProperties(), testKind); err != nil {
+				return err
+			} else {
+				nodeIDs = append(nodeIDs, node.ID)
+			}
+		}
+
+		for idx := 1; idx < len(nodeIDs); idx++ {
+			if relationship, err := tx.CreateRelationshipByIDs(nodeIDs[idx-1], nodeIDs[idx], testKind, graph.NewProperties()); err != nil {
+				return err
+			} else {
+				relationshipIDs = append(relationshipIDs, relationship.ID)
+			}
+		}
+
+		return nil
+	}))
+
+	require.Nil(t, db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		nodes, err := graphcache.FetchNodesByID(tx, cache, nodeIDs)
+		require.Nil(t, err)
+		require.Len(t, nodes, len(nodeIDs))
+
+		relationships, err := graphcache.FetchRelationshipsByID(tx, cache, relationshipIDs)
+		require.Nil(t, err)
+		require.Len(t, relationships, len(relationshipIDs))
+
+		return nil
+	}))
+
+	require.Equal(t, size.Bytes, cache.Limit())
+	require.Equal(t, 0, cache.NodesCached())
+	require.Equal(t, 0, cache.RelationshipsCached())
+}
+
+func TestFetchByID_AdoptsTraversalMemoryLimit(t *testing.T) {
+	db, err := dawgs.Open(context.Background(), memory.DriverName, dawgs.Config{
+		TraversalMemoryLimit: size.Mebibyte,
+	})
+	require.Nil(t, err)
+
+	var (
+		nodeIDs []graph.ID
+		cache   = graphcache.New()
+	)
+
+	require.Nil(t, db.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		for idx := 0; idx < 3; idx++ {
+			if node, err := tx.CreateNode(graph.NewProperties(), testKind); err != nil {
+				return err
+			} else {
+				nodeIDs = append(nodeIDs, node.ID)
+			}
+		}
+
+		return nil
+	}))
+
+	require.Nil(t, db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		for iteration := 0; iteration < 2; iteration++ {
+			nodes, err := graphcache.FetchNodesByID(tx, cache, nodeIDs)
+			require.Nil(t, err)
+			require.Len(t, nodes, len(nodeIDs))
+		}
+
+		return nil
+	}))
+
+	stats := cache.Stats()
+	require.Equal(t, size.Mebibyte/graphcache.TraversalMemoryLimitShare, stats.Limit)
+	require.Equal(t, len(nodeIDs), stats.Nodes)
+	require.Equal(t, uint64(len(nodeIDs)), stats.Hits)
+	require.Equal(t, uint64(len(nodeIDs)), stats.Misses)
+}
@@ -0,0 +1,203 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package graphcache
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/util/size"
+)
+
+// numShards is the number of independently locked partitions each LRU is split into. This must remain a power of two
+// so that entity IDs may be mapped to shards with a mask.
+const numShards = 16
+
+type lruEntry[T size.Sizable] struct {
+	id    graph.ID
+	value T
+	size  size.Size
+}
+
+type lruShard[T size.Sizable] struct {
+	entries map[graph.ID]*list.Element
+	order   *list.List
+	inUse   size.Size
+	lock    sync.Mutex
+}
+
+func newLRUShard[T size.Sizable]() *lruShard[T] {
+	return &lruShard[T]{
+		entries: map[graph.ID]*list.Element{},
+		order:   list.New(),
+	}
+}
+
+// counters are shared by all shards of both the node and relationship LRUs of a Cache instance.
+type counters struct {
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	evictions atomic.Uint64
+}
+
+// shardedLRU is a size-bounded, least-recently-used store of graph entities keyed by their ID. The LRU is given
+// 1/share of the limit it is created with and each shard is given an equal part of that budget. Shards evict
+// independently of one another.
+type shardedLRU[T size.Sizable] struct {
+	shards   [numShards]*lruShard[T]
+	limit    *atomic.Uintptr
+	share    size.Size
+	counters *counters
+}
+
+func newShardedLRU[T size.Sizable](limit *atomic.Uintptr, share size.Size, counters *counters) *shardedLRU[T] {
+	instance := &shardedLRU[T]{
+		limit:    limit,
+		share:    share,
+		counters: counters,
+	}
+
+	for idx := range instance.shards {
+		instance.shards[idx] = newLRUShard[T]()
+	}
+
+	return instance
+}
+
+func (s *shardedLRU[T]) shard(id graph.ID) *lruShard[T] {
+	return s.shards[id.Uint64()&(numShards-1)]
+}
+
+// shardBudget returns the size budget of a single shard. A budget of 0 signifies that the LRU is unbounded.
+func (s *shardedLRU[T]) shardBudget() size.Size {
+	if limit := size.Size(s.limit.Load()); limit > 0 {
+		// Guard against limits small enough that they round down to an unbounded budget
+		if budget := limit / s.share / numShards; budget > 0 {
+			return budget
+		}
+
+		return 1
+	}
+
+	return 0
+}
+
+func (s *shardedLRU[T]) Len() int {
+	length := 0
+
+	for _, shard := range s.shards {
+		shard.lock.Lock()
+		length += len(shard.entries)
+		shard.lock.Unlock()
+	}
+
+	return length
+}
+
+func (s *shardedLRU[T]) SizeOf() size.Size {
+	var total size.Size
+
+	for _, shard := range s.shards {
+		shard.lock.Lock()
+		total += shard.inUse + size.OfMap(shard.entries) + size.Of(*shard.order)*size.Size(shard.order.Len()+1)
+		shard.lock.Unlock()
+	}
+
+	return total
+}
+
+func (s *shardedLRU[T]) CheckedGet(id graph.ID) (T, bool) {
+	shard := s.shard(id)
+
+	shard.lock.Lock()
+	defer shard.lock.Unlock()
+
+	if element, hasElement := shard.entries[id]; hasElement {
+		shard.order.MoveToFront(element)
+		s.counters.hits.Add(1)
+
+		return element.Value.(*lruEntry[T]).value, true
+	}
+
+	var empty T
+
+	s.counters.misses.Add(1)
+	return empty, false
+}
+
+// GetAll returns all cached values for the given IDs along with the IDs that were not found in the cache.
+func (s *shardedLRU[T]) GetAll(ids []graph.ID) ([]T, []graph.ID) {
+	var (
+		values     = make([]T, 0, len(ids))
+		missingIDs = make([]graph.ID, 0, len(ids))
+	)
+
+	for _, id := range ids {
+		if value, found := s.CheckedGet(id); found {
+			values = append(values, value)
+		} else {
+			missingIDs = append(missingIDs, id)
+		}
+	}
+
+	return values, missingIDs
+}
+
+// Put stores the given value, evicting the least recently used entries of the target shard until the value fits the
+// shard's budget. Values that are larger than the budget of a shard are not cached.
+func (s *shardedLRU[T]) Put(id graph.ID, value T) {
+	var (
+		shard     = s.shard(id)
+		budget    = s.shardBudget()
+		valueSize = value.SizeOf()
+	)
+
+	shard.lock.Lock()
+	defer shard.lock.Unlock()
+
+	if element, hasElement := shard.entries[id]; hasElement {
+		shard.remove(element)
+	}
+
+	if budget > 0 {
+		if valueSize > budget {
+			return
+		}
+
+		for shard.inUse+valueSize > budget {
+			shard.remove(shard.order.Back())
+			s.counters.evictions.Add(1)
+		}
+	}
+
+	shard.entries[id] = shard.order.PushFront(&lruEntry[T]{
+		id:    id,
+		value: value,
+		size:  valueSize,
+	})
+
+	shard.inUse += valueSize
+}
+
+func (s *lruShard[T]) remove(element *list.Element) {
+	entry := s.order.Remove(element).(*lruEntry[T])
+
+	delete(s.entries, entry.id)
+	s.inUse -= entry.size
+}
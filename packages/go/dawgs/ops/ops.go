@@ -161,13 +161,13 @@ func FetchNodeIDs(query graph.NodeQuery) ([]graph.ID, error) {
 	})
 }
 
-func FetchPathSetByQuery(tx graph.Transaction, query string) (graph.PathSet, error) {
+func FetchPathSetByQuery(tx graph.Transaction, query string, parameters map[string]any) (graph.PathSet, error) {
 	var (
 		currentPath graph.Path
 		pathSet     graph.PathSet
 	)
 
-	if result := tx.Query(query, map[string]any{}); result.Error() != nil {
+	if result := tx.Query(query, parameters); result.Error() != nil {
 		return pathSet, result.Error()
 	} else {
 		defer result.Close()
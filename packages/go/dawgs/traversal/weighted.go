@@ -0,0 +1,519 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package traversal
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/ops"
+	"github.com/specterops/bloodhound/dawgs/query"
+	"github.com/specterops/bloodhound/dawgs/util/size"
+)
+
+const (
+	// weightedFetchBatchSize caps the number of nodes whose adjacency is fetched with each relationship query
+	weightedFetchBatchSize = 1_000
+)
+
+var (
+	weightedQueueEntrySize   = size.Of(weightedQueueEntry{})
+	weightedVisitedEntrySize = size.Of(graph.ID(0))*3 + size.Of(float64(0)) + size.Of((*graph.Relationship)(nil))
+)
+
+// CostFunction returns the cost of traversing the given relationship. Costs must not be negative.
+type CostFunction = func(relationship *graph.Relationship) float64
+
+// Heuristic estimates the remaining cost of reaching the plan's end node from the given node. A heuristic turns the
+// weighted search into A* and must never overestimate the remaining cost, otherwise the paths returned are not
+// guaranteed to be the cheapest.
+type Heuristic = func(nodeID graph.ID) float64
+
+// UnitCost is a CostFunction that assigns every relationship the same cost. Weighted searches using this function
+// find paths with the fewest hops.
+func UnitCost(_ *graph.Relationship) float64 {
+	return 1
+}
+
+// WeightedPlan describes a weighted path search between two nodes.
+type WeightedPlan struct {
+	StartID graph.ID
+	EndID   graph.ID
+
+	// Direction controls which relationships are expanded from each node. Note that the zero value of graph.Direction
+	// is graph.DirectionInbound.
+	Direction graph.Direction
+
+	// Criteria optionally filters the relationships that may be traversed.
+	Criteria graph.Criteria
+
+	// Cost prices each relationship. Defaults to UnitCost.
+	Cost CostFunction
+
+	// Heuristic is optional. Without a heuristic the search runs as Dijkstra's algorithm.
+	Heuristic Heuristic
+}
+
+// WeightedPath is a path along with its total cost.
+type WeightedPath struct {
+	Path graph.Path
+	Cost float64
+}
+
+// weightedIDPath is a path that has not yet been hydrated with node data.
+type weightedIDPath struct {
+	nodeIDs       []graph.ID
+	relationships []*graph.Relationship
+	cost          float64
+}
+
+func (s weightedIDPath) hasPrefix(nodeIDs []graph.ID, relationships []*graph.Relationship) bool {
+	if len(s.relationships) < len(relationships) {
+		return false
+	}
+
+	for idx, relationship := range relationships {
+		if s.relationships[idx].ID != relationship.ID {
+			return false
+		}
+	}
+
+	return slices.Equal(s.nodeIDs[:len(nodeIDs)], nodeIDs)
+}
+
+func (s weightedIDPath) equals(other weightedIDPath) bool {
+	return len(s.relationships) == len(other.relationships) && s.hasPrefix(other.nodeIDs, other.relationships)
+}
+
+type weightedQueueEntry struct {
+	nodeID   graph.ID
+	cost     float64
+	priority float64
+}
+
+type weightedQueue []weightedQueueEntry
+
+func (s weightedQueue) Len() int {
+	return len(s)
+}
+
+func (s weightedQueue) Less(i, j int) bool {
+	return s[i].priority < s[j].priority
+}
+
+func (s weightedQueue) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+func (s *weightedQueue) Push(value any) {
+	*s = append(*s, value.(weightedQueueEntry))
+}
+
+func (s *weightedQueue) Pop() any {
+	var (
+		old  = *s
+		last = old[len(old)-1]
+	)
+
+	*s = old[:len(old)-1]
+	return last
+}
+
+// weightedSearch runs repeated cheapest path searches against a single transaction. Relationships fetched from the
+// database are cached by node so that the spur searches of Yen's algorithm do not refetch adjacency.
+type weightedSearch struct {
+	ctx         context.Context
+	tx          graph.Transaction
+	plan        WeightedPlan
+	adjacency   map[graph.ID][]*graph.Relationship
+	memoryInUse size.Size
+}
+
+func newWeightedSearch(ctx context.Context, tx graph.Transaction, plan WeightedPlan) (*weightedSearch, error) {
+	if plan.Direction != graph.DirectionOutbound && plan.Direction != graph.DirectionInbound {
+		return nil, fmt.Errorf("bi-directional or non-directed edges are not supported")
+	}
+
+	if plan.Cost == nil {
+		plan.Cost = UnitCost
+	}
+
+	return &weightedSearch{
+		ctx:       ctx,
+		tx:        tx,
+		plan:      plan,
+		adjacency: map[graph.ID][]*graph.Relationship{},
+	}, nil
+}
+
+func (s *weightedSearch) allocate(allocation size.Size) error {
+	s.memoryInUse += allocation
+
+	if s.memoryInUse > s.tx.TraversalMemoryLimit() {
+		return fmt.Errorf("%w - Limit: %.2f MB - Memory In-Use: %.2f MB", ops.ErrTraversalMemoryLimit, s.tx.TraversalMemoryLimit().Mebibytes(), s.memoryInUse.Mebibytes())
+	}
+
+	return nil
+}
+
+// relationships returns the relationships adjacent to the given node. If the node's adjacency is not yet cached it is
+// fetched in a single query along with the adjacency of every other uncached node waiting in the given queue, up to
+// weightedFetchBatchSize nodes, as these are the nodes the search is most likely to expand next.
+func (s *weightedSearch) relationships(nodeID graph.ID, queue weightedQueue) ([]*graph.Relationship, error) {
+	if relationships, cached := s.adjacency[nodeID]; cached {
+		return relationships, nil
+	}
+
+	var (
+		batchIDs = []graph.ID{nodeID}
+		batched  = map[graph.ID]struct{}{nodeID: {}}
+	)
+
+	for _, entry := range queue {
+		if len(batchIDs) >= weightedFetchBatchSize {
+			break
+		}
+
+		if _, cached := s.adjacency[entry.nodeID]; cached {
+			continue
+		} else if _, isBatched := batched[entry.nodeID]; isBatched {
+			continue
+		}
+
+		batchIDs = append(batchIDs, entry.nodeID)
+		batched[entry.nodeID] = struct{}{}
+	}
+
+	if err := s.fetchAdjacency(batchIDs); err != nil {
+		return nil, err
+	}
+
+	return s.adjacency[nodeID], nil
+}
+
+func (s *weightedSearch) fetchAdjacency(nodeIDs []graph.ID) error {
+	var criteria []graph.Criteria
+
+	switch s.plan.Direction {
+	case graph.DirectionOutbound:
+		criteria = append(criteria, query.InIDs(query.StartID(), nodeIDs...))
+
+	case graph.DirectionInbound:
+		criteria = append(criteria, query.InIDs(query.EndID(), nodeIDs...))
+	}
+
+	if s.plan.Criteria != nil {
+		criteria = append(criteria, s.plan.Criteria)
+	}
+
+	// Nodes without any matching relationships are cached as well so that they are not fetched again
+	for _, nodeID := range nodeIDs {
+		s.adjacency[nodeID] = nil
+	}
+
+	return s.tx.Relationships().Filter(query.And(criteria...)).Fetch(func(cursor graph.Cursor[*graph.Relationship]) error {
+		for relationship := range cursor.Chan() {
+			if nodeID, err := s.plan.Direction.Pick(relationship); err != nil {
+				return err
+			} else if err := s.allocate(relationship.SizeOf()); err != nil {
+				return err
+			} else {
+				s.adjacency[nodeID] = append(s.adjacency[nodeID], relationship)
+			}
+		}
+
+		return cursor.Error()
+	})
+}
+
+func (s *weightedSearch) heuristic(nodeID graph.ID) float64 {
+	if s.plan.Heuristic == nil {
+		return 0
+	}
+
+	return s.plan.Heuristic(nodeID)
+}
+
+// cheapestPath returns the cheapest path from the given node to the plan's end node that avoids the excluded nodes
+// and relationships. The state of the search is charged against the transaction's traversal memory limit along with
+// the cached adjacency which bounds the number of nodes a single search may visit.
+func (s *weightedSearch) cheapestPath(fromID graph.ID, excludedNodes, excludedRelationships map[graph.ID]struct{}) (weightedIDPath, bool, error) {
+	var (
+		costs   = map[graph.ID]float64{fromID: 0}
+		parents = map[graph.ID]*graph.Relationship{}
+		settled = map[graph.ID]struct{}{}
+		queue   = &weightedQueue{{
+			nodeID:   fromID,
+			priority: s.heuristic(fromID),
+		}}
+		searchMemoryInUse size.Size
+	)
+
+	// The search state is released once the search completes while the cached adjacency is kept for subsequent searches
+	defer func() {
+		s.memoryInUse -= searchMemoryInUse
+	}()
+
+	for queue.Len() > 0 {
+		if err := s.ctx.Err(); err != nil {
+			return weightedIDPath{}, false, err
+		}
+
+		next := heap.Pop(queue).(weightedQueueEntry)
+
+		if _, isSettled := settled[next.nodeID]; isSettled || next.cost > costs[next.nodeID] {
+			continue
+		}
+
+		settled[next.nodeID] = struct{}{}
+
+		if next.nodeID == s.plan.EndID {
+			return s.renderPath(fromID, next.nodeID, costs[next.nodeID], parents)
+		}
+
+		relationships, err := s.relationships(next.nodeID, *queue)
+		if err != nil {
+			return weightedIDPath{}, false, err
+		}
+
+		for _, relationship := range relationships {
+			if _, excluded := excludedRelationships[relationship.ID]; excluded {
+				continue
+			}
+
+			targetID, err := s.plan.Direction.PickReverse(relationship)
+			if err != nil {
+				return weightedIDPath{}, false, err
+			}
+
+			if _, excluded := excludedNodes[targetID]; excluded {
+				continue
+			} else if _, isSettled := settled[targetID]; isSettled {
+				continue
+			}
+
+			relationshipCost := s.plan.Cost(relationship)
+
+			if relationshipCost < 0 {
+				return weightedIDPath{}, false, fmt.Errorf("relationship %d has a negative cost of %f", relationship.ID, relationshipCost)
+			}
+
+			targetCost := next.cost + relationshipCost
+
+			if existingCost, seen := costs[targetID]; !seen || targetCost < existingCost {
+				allocation := weightedQueueEntrySize
+
+				if !seen {
+					allocation += weightedVisitedEntrySize
+				}
+
+				searchMemoryInUse += allocation
+
+				if err := s.allocate(allocation); err != nil {
+					return weightedIDPath{}, false, err
+				}
+
+				costs[targetID] = targetCost
+				parents[targetID] = relationship
+
+				heap.Push(queue, weightedQueueEntry{
+					nodeID:   targetID,
+					cost:     targetCost,
+					priority: targetCost + s.heuristic(targetID),
+				})
+			}
+		}
+	}
+
+	return weightedIDPath{}, false, nil
+}
+
+func (s *weightedSearch) renderPath(fromID, toID graph.ID, cost float64, parents map[graph.ID]*graph.Relationship) (weightedIDPath, bool, error) {
+	path := weightedIDPath{
+		nodeIDs: []graph.ID{toID},
+		cost:    cost,
+	}
+
+	for cursor := toID; cursor != fromID; {
+		relationship := parents[cursor]
+
+		if previousID, err := s.plan.Direction.Pick(relationship); err != nil {
+			return weightedIDPath{}, false, err
+		} else {
+			path.nodeIDs = append(path.nodeIDs, previousID)
+			path.relationships = append(path.relationships, relationship)
+			cursor = previousID
+		}
+	}
+
+	slices.Reverse(path.nodeIDs)
+	slices.Reverse(path.relationships)
+
+	return path, true, nil
+}
+
+func (s *weightedSearch) pathCost(relationships []*graph.Relationship) float64 {
+	var cost float64
+
+	for _, relationship := range relationships {
+		cost += s.plan.Cost(relationship)
+	}
+
+	return cost
+}
+
+// kCheapestPaths implements Yen's algorithm for finding the k cheapest loopless paths.
+func (s *weightedSearch) kCheapestPaths(k int) ([]weightedIDPath, error) {
+	var (
+		accepted   []weightedIDPath
+		candidates []weightedIDPath
+	)
+
+	if firstPath, found, err := s.cheapestPath(s.plan.StartID, nil, nil); err != nil || !found {
+		return nil, err
+	} else {
+		accepted = append(accepted, firstPath)
+	}
+
+	for len(accepted) < k {
+		previousPath := accepted[len(accepted)-1]
+
+		for spurIdx := 0; spurIdx < len(previousPath.relationships); spurIdx++ {
+			var (
+				spurNodeID            = previousPath.nodeIDs[spurIdx]
+				rootNodeIDs           = previousPath.nodeIDs[:spurIdx+1]
+				rootRelationships     = previousPath.relationships[:spurIdx]
+				excludedNodes         = map[graph.ID]struct{}{}
+				excludedRelationships = map[graph.ID]struct{}{}
+			)
+
+			// Remove the next relationship of every accepted path that shares this root path so that the spur path
+			// must deviate from them
+			for _, acceptedPath := range accepted {
+				if acceptedPath.hasPrefix(rootNodeIDs, rootRelationships) && len(acceptedPath.relationships) > spurIdx {
+					excludedRelationships[acceptedPath.relationships[spurIdx].ID] = struct{}{}
+				}
+			}
+
+			// Remove the root path's nodes, save for the spur node, to keep the resulting path loopless
+			for _, rootNodeID := range rootNodeIDs[:spurIdx] {
+				excludedNodes[rootNodeID] = struct{}{}
+			}
+
+			if spurPath, found, err := s.cheapestPath(spurNodeID, excludedNodes, excludedRelationships); err != nil {
+				return nil, err
+			} else if found {
+				candidate := weightedIDPath{
+					nodeIDs:       append(slices.Clone(rootNodeIDs[:spurIdx]), spurPath.nodeIDs...),
+					relationships: append(slices.Clone(rootRelationships), spurPath.relationships...),
+				}
+
+				candidate.cost = s.pathCost(candidate.relationships)
+
+				if !slices.ContainsFunc(candidates, candidate.equals) && !slices.ContainsFunc(accepted, candidate.equals) {
+					candidates = append(candidates, candidate)
+				}
+			}
+		}
+
+		if len(candidates) == 0 {
+			break
+		}
+
+		// Stable sorting keeps the order of equally priced candidates deterministic
+		slices.SortStableFunc(candidates, func(a, b weightedIDPath) int {
+			if a.cost < b.cost {
+				return -1
+			} else if a.cost > b.cost {
+				return 1
+			}
+
+			return len(a.relationships) - len(b.relationships)
+		})
+
+		accepted = append(accepted, candidates[0])
+		candidates = candidates[1:]
+	}
+
+	return accepted, nil
+}
+
+func (s *weightedSearch) hydrate(idPaths []weightedIDPath) ([]WeightedPath, error) {
+	var nodeIDs []graph.ID
+
+	for _, idPath := range idPaths {
+		nodeIDs = append(nodeIDs, idPath.nodeIDs...)
+	}
+
+	slices.Sort(nodeIDs)
+	nodeIDs = slices.Compact(nodeIDs)
+
+	if nodes, err := ops.FetchNodeSet(s.tx.Nodes().Filter(query.InIDs(query.NodeID(), nodeIDs...))); err != nil {
+		return nil, err
+	} else {
+		paths := make([]WeightedPath, 0, len(idPaths))
+
+		for _, idPath := range idPaths {
+			path := WeightedPath{
+				Path: graph.Path{
+					Nodes: make([]*graph.Node, len(idPath.nodeIDs)),
+					Edges: idPath.relationships,
+				},
+				Cost: idPath.cost,
+			}
+
+			for idx, nodeID := range idPath.nodeIDs {
+				if path.Path.Nodes[idx] = nodes.Get(nodeID); path.Path.Nodes[idx] == nil {
+					return nil, fmt.Errorf("path node %d no longer exists", nodeID)
+				}
+			}
+
+			paths = append(paths, path)
+		}
+
+		return paths, nil
+	}
+}
+
+// CheapestPath returns the cheapest path between the plan's start and end nodes. If no path exists then
+// graph.ErrNoResultsFound is returned.
+func CheapestPath(ctx context.Context, tx graph.Transaction, plan WeightedPlan) (WeightedPath, error) {
+	if paths, err := KCheapestPaths(ctx, tx, plan, 1); err != nil {
+		return WeightedPath{}, err
+	} else if len(paths) == 0 {
+		return WeightedPath{}, graph.ErrNoResultsFound
+	} else {
+		return paths[0], nil
+	}
+}
+
+// KCheapestPaths returns up to k loopless paths between the plan's start and end nodes ranked by ascending total
+// cost. Paths of equal cost are ranked by ascending length.
+func KCheapestPaths(ctx context.Context, tx graph.Transaction, plan WeightedPlan, k int) ([]WeightedPath, error) {
+	if k < 1 {
+		return nil, fmt.Errorf("expected k to be at least 1 but saw %d", k)
+	} else if search, err := newWeightedSearch(ctx, tx, plan); err != nil {
+		return nil, err
+	} else if idPaths, err := search.kCheapestPaths(k); err != nil {
+		return nil, err
+	} else {
+		return search.hydrate(idPaths)
+	}
+}
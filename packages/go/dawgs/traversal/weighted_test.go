@@ -0,0 +1,308 @@
+// Copyright 2024 Specter Ops, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package traversal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/specterops/bloodhound/dawgs"
+	"github.com/specterops/bloodhound/dawgs/drivers/memory"
+	"github.com/specterops/bloodhound/dawgs/graph"
+	"github.com/specterops/bloodhound/dawgs/ops"
+	"github.com/specterops/bloodhound/dawgs/query"
+	"github.com/specterops/bloodhound/dawgs/traversal"
+	"github.com/specterops/bloodhound/dawgs/util/size"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	nodeKind       = graph.StringKind("Base")
+	cheapKind      = graph.StringKind("Cheap")
+	expensiveKind  = graph.StringKind("Expensive")
+	membershipKind = graph.StringKind("Membership")
+)
+
+func weightedTestCost(relationship *graph.Relationship) float64 {
+	switch relationship.Kind {
+	case membershipKind:
+		return 0
+	case cheapKind:
+		return 1
+	default:
+		return 5
+	}
+}
+
+type weightedTestGraph struct {
+	db    graph.Database
+	nodes map[string]graph.ID
+}
+
+// newWeightedTestGraph creates the following graph:
+//
+//	(a)-[Cheap]->(b)-[Expensive]->(d)
+//	(a)-[Membership]->(c)-[Cheap]->(e)-[Cheap]->(d)
+//	(a)-[Expensive]->(d)
+func newWeightedTestGraph(t *testing.T, cfg dawgs.Config) weightedTestGraph {
+	db, err := dawgs.Open(context.Background(), memory.DriverName, cfg)
+	require.Nil(t, err)
+
+	testGraph := weightedTestGraph{
+		db:    db,
+		nodes: map[string]graph.ID{},
+	}
+
+	require.Nil(t, db.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		for _, name := range []string{"a", "b", "c", "d", "e"} {
+			if node, err := tx.CreateNode(graph.NewProperties().Set("name", name), nodeKind); err != nil {
+				return err
+			} else {
+				testGraph.nodes[name] = node.ID
+			}
+		}
+
+		for _, relationship := range []struct {
+			start, end string
+			kind       graph.Kind
+		}{
+			{"a", "b", cheapKind},
+			{"b", "d", expensiveKind},
+			{"a", "c", membershipKind},
+			{"c", "e", cheapKind},
+			{"e", "d", cheapKind},
+			{"a", "d", expensiveKind},
+		} {
+			if _, err := tx.CreateRelationshipByIDs(testGraph.nodes[relationship.start], testGraph.nodes[relationship.end], relationship.kind, graph.NewProperties()); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}))
+
+	return testGraph
+}
+
+func (s weightedTestGraph) names(path graph.Path) []string {
+	names := make([]string, len(path.Nodes))
+
+	for idx, node := range path.Nodes {
+		names[idx], _ = node.Properties.Get("name").String()
+	}
+
+	return names
+}
+
+func TestCheapestPath(t *testing.T) {
+	testGraph := newWeightedTestGraph(t, dawgs.Config{})
+
+	require.Nil(t, testGraph.db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		unweightedPath, err := traversal.CheapestPath(context.Background(), tx, traversal.WeightedPlan{
+			StartID:   testGraph.nodes["a"],
+			EndID:     testGraph.nodes["d"],
+			Direction: graph.DirectionOutbound,
+		})
+		require.Nil(t, err)
+		require.Equal(t, []string{"a", "d"}, testGraph.names(unweightedPath.Path))
+		require.Equal(t, float64(1), unweightedPath.Cost)
+
+		weightedPath, err := traversal.CheapestPath(context.Background(), tx, traversal.WeightedPlan{
+			StartID:   testGraph.nodes["a"],
+			EndID:     testGraph.nodes["d"],
+			Direction: graph.DirectionOutbound,
+			Cost:      weightedTestCost,
+			Heuristic: func(nodeID graph.ID) float64 {
+				return 0
+			},
+		})
+		require.Nil(t, err)
+		require.Equal(t, []string{"a", "c", "e", "d"}, testGraph.names(weightedPath.Path))
+		require.Equal(t, float64(2), weightedPath.Cost)
+		require.Len(t, weightedPath.Path.Edges, 3)
+
+		inboundPath, err := traversal.CheapestPath(context.Background(), tx, traversal.WeightedPlan{
+			StartID:   testGraph.nodes["d"],
+			EndID:     testGraph.nodes["a"],
+			Direction: graph.DirectionInbound,
+			Cost:      weightedTestCost,
+		})
+		require.Nil(t, err)
+		require.Equal(t, []string{"d", "e", "c", "a"}, testGraph.names(inboundPath.Path))
+
+		_, err = traversal.CheapestPath(context.Background(), tx, traversal.WeightedPlan{
+			StartID:   testGraph.nodes["d"],
+			EndID:     testGraph.nodes["a"],
+			Direction: graph.DirectionOutbound,
+		})
+		require.ErrorIs(t, err, graph.ErrNoResultsFound)
+
+		return nil
+	}))
+}
+
+func TestKCheapestPaths(t *testing.T) {
+	testGraph := newWeightedTestGraph(t, dawgs.Config{})
+
+	require.Nil(t, testGraph.db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		paths, err := traversal.KCheapestPaths(context.Background(), tx, traversal.WeightedPlan{
+			StartID:   testGraph.nodes["a"],
+			EndID:     testGraph.nodes["d"],
+			Direction: graph.DirectionOutbound,
+			Cost:      weightedTestCost,
+		}, 5)
+		require.Nil(t, err)
+		require.Len(t, paths, 3)
+
+		require.Equal(t, []string{"a", "c", "e", "d"}, testGraph.names(paths[0].Path))
+		require.Equal(t, float64(2), paths[0].Cost)
+		require.Equal(t, []string{"a", "d"}, testGraph.names(paths[1].Path))
+		require.Equal(t, float64(5), paths[1].Cost)
+		require.Equal(t, []string{"a", "b", "d"}, testGraph.names(paths[2].Path))
+		require.Equal(t, float64(6), paths[2].Cost)
+
+		_, err = traversal.KCheapestPaths(context.Background(), tx, traversal.WeightedPlan{}, 0)
+		require.NotNil(t, err)
+
+		return nil
+	}))
+}
+
+func TestKCheapestPaths_NegativeCost(t *testing.T) {
+	testGraph := newWeightedTestGraph(t, dawgs.Config{})
+
+	require.Nil(t, testGraph.db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		_, err := traversal.KCheapestPaths(context.Background(), tx, traversal.WeightedPlan{
+			StartID:   testGraph.nodes["a"],
+			EndID:     testGraph.nodes["d"],
+			Direction: graph.DirectionOutbound,
+			Cost: func(relationship *graph.Relationship) float64 {
+				return -1
+			},
+		}, 1)
+		require.ErrorContains(t, err, "negative cost")
+
+		return nil
+	}))
+}
+
+func TestKCheapestPaths_MemoryLimit(t *testing.T) {
+	testGraph := newWeightedTestGraph(t, dawgs.Config{TraversalMemoryLimit: size.Bytes})
+
+	require.Nil(t, testGraph.db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		_, err := traversal.KCheapestPaths(context.Background(), tx, traversal.WeightedPlan{
+			StartID:   testGraph.nodes["a"],
+			EndID:     testGraph.nodes["d"],
+			Direction: graph.DirectionOutbound,
+		}, 1)
+		require.True(t, errors.Is(err, ops.ErrTraversalMemoryLimit))
+
+		return nil
+	}))
+}
+
+// newFanOutTestGraph creates a root node with the given number of leaves. Every leaf has a relationship to the target
+// node if connected is set, otherwise the target node is unreachable.
+func newFanOutTestGraph(t *testing.T, cfg dawgs.Config, numLeaves int, connected bool) (graph.Database, graph.ID, graph.ID) {
+	db, err := dawgs.Open(context.Background(), memory.DriverName, cfg)
+	require.Nil(t, err)
+
+	var rootID, targetID graph.ID
+
+	require.Nil(t, db.WriteTransaction(context.Background(), func(tx graph.Transaction) error {
+		if root, err := tx.CreateNode(graph.NewProperties(), nodeKind); err != nil {
+			return err
+		} else if target, err := tx.CreateNode(graph.NewProperties(), nodeKind); err != nil {
+			return err
+		} else {
+			rootID, targetID = root.ID, target.ID
+		}
+
+		for leafIdx := 0; leafIdx < numLeaves; leafIdx++ {
+			if leaf, err := tx.CreateNode(graph.NewProperties(), nodeKind); err != nil {
+				return err
+			} else if _, err := tx.CreateRelationshipByIDs(rootID, leaf.ID, cheapKind, graph.NewProperties()); err != nil {
+				return err
+			} else if connected {
+				if _, err := tx.CreateRelationshipByIDs(leaf.ID, targetID, cheapKind, graph.NewProperties()); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	}))
+
+	return db, rootID, targetID
+}
+
+func TestCheapestPath_FanOut(t *testing.T) {
+	db, rootID, targetID := newFanOutTestGraph(t, dawgs.Config{}, 2_500, true)
+
+	require.Nil(t, db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		paths, err := traversal.KCheapestPaths(context.Background(), tx, traversal.WeightedPlan{
+			StartID:   rootID,
+			EndID:     targetID,
+			Direction: graph.DirectionOutbound,
+		}, 3)
+		require.Nil(t, err)
+		require.Len(t, paths, 3)
+
+		for _, path := range paths {
+			require.Equal(t, float64(2), path.Cost)
+			require.Len(t, path.Path.Edges, 2)
+		}
+
+		return nil
+	}))
+}
+
+func TestCheapestPath_VisitedMemoryLimit(t *testing.T) {
+	const numLeaves = 1_000
+
+	var (
+		db, rootID, _   = newFanOutTestGraph(t, dawgs.Config{}, numLeaves, false)
+		adjacencyMemory size.Size
+	)
+
+	// Measure the memory of the root's adjacency so that the limit admits it but not the state of visiting every leaf
+	require.Nil(t, db.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		return tx.Relationships().Filterf(func() graph.Criteria {
+			return query.Equals(query.StartID(), rootID)
+		}).Fetch(func(cursor graph.Cursor[*graph.Relationship]) error {
+			for relationship := range cursor.Chan() {
+				adjacencyMemory += relationship.SizeOf()
+			}
+
+			return cursor.Error()
+		})
+	}))
+
+	limitedDB, rootID, targetID := newFanOutTestGraph(t, dawgs.Config{TraversalMemoryLimit: adjacencyMemory + numLeaves*size.Of(graph.ID(0))}, numLeaves, false)
+
+	require.Nil(t, limitedDB.ReadTransaction(context.Background(), func(tx graph.Transaction) error {
+		_, err := traversal.CheapestPath(context.Background(), tx, traversal.WeightedPlan{
+			StartID:   rootID,
+			EndID:     targetID,
+			Direction: graph.DirectionOutbound,
+		})
+		require.ErrorIs(t, err, ops.ErrTraversalMemoryLimit)
+
+		return nil
+	}))
+}
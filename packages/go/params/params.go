@@ -29,6 +29,8 @@ var (
 	StartNode         = newParam("start_node", nil)
 	EndNode           = newParam("end_node", nil)
 	RelationshipKinds = newParam("relationship_kinds", containsPredicate)
+	Weighted          = newParam("weighted", nil)
+	K                 = newParam("k", nil)
 )
 
 // param is an immutable path or query parameter